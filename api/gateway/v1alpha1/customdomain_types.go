@@ -0,0 +1,100 @@
+/*
+Copyright 2026 The Cozystack Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// CustomDomainSpec names the hostname a tenant wants to serve.
+type CustomDomainSpec struct {
+	// Hostname is the fully qualified domain name to serve, e.g.
+	// shop.customer.com. It must not be under a platform apex; those
+	// are served by the TenantGateway without a CustomDomain.
+	// +kubebuilder:validation:MaxLength=253
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)+$`
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="hostname is immutable"
+	// +required
+	Hostname string `json:"hostname"`
+}
+
+// CustomDomainVerification is the DNS record that proves ownership of
+// the hostname.
+type CustomDomainVerification struct {
+	// RecordName is the TXT record to create, e.g.
+	// _cozystack-challenge.shop.customer.com.
+	RecordName string `json:"recordName"`
+
+	// RecordValue is the token the TXT record must contain.
+	RecordValue string `json:"recordValue"`
+}
+
+// CustomDomainStatus reports ownership verification and serving state.
+type CustomDomainStatus struct {
+	// ObservedGeneration mirrors the .metadata.generation reflected in
+	// the latest reconciled state.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Conditions describes the current state of the domain. Verified
+	// is True while the TXT record holds the token and no older claim
+	// for the hostname exists; Ready is True once the namespace's
+	// TenantGateway serves the hostname over HTTPS.
+	// +optional
+	// +patchMergeKey=type
+	// +patchStrategy=merge
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
+
+	// Verification is the TXT record the domain owner must publish.
+	// +optional
+	Verification *CustomDomainVerification `json:"verification,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Namespaced,shortName=cdom
+// +kubebuilder:printcolumn:name="Hostname",type="string",JSONPath=".spec.hostname"
+// +kubebuilder:printcolumn:name="Verified",type="string",JSONPath=`.status.conditions[?(@.type=="Verified")].status`
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// CustomDomain attaches a hostname outside the platform apexes to the
+// TenantGateway serving its namespace. The cozystack-controller
+// verifies ownership through a DNS TXT record before the hostname gets
+// a listener and a certificate.
+type CustomDomain struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   CustomDomainSpec   `json:"spec,omitempty"`
+	Status CustomDomainStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// CustomDomainList contains a list of CustomDomain.
+type CustomDomainList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CustomDomain `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CustomDomain{}, &CustomDomainList{})
+}
@@ -18,6 +18,7 @@ package v1alpha1
 
 import (
 	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -59,7 +60,7 @@ const (
 )
 
 // DNS01Provider names a supported cert-manager DNS-01 solver.
-// +kubebuilder:validation:Enum=cloudflare;route53;digitalocean;rfc2136
+// +kubebuilder:validation:Enum=cloudflare;route53;digitalocean;rfc2136;clouddns;azuredns;hetzner;powerdns;webhook
 type DNS01Provider string
 
 // DNS01Config configures the DNS-01 solver when CertMode=dns01. Only
@@ -84,6 +85,26 @@ type DNS01Config struct {
 	// RFC2136 config. Required when Provider=rfc2136.
 	// +optional
 	RFC2136 *RFC2136DNS01 `json:"rfc2136,omitempty"`
+
+	// CloudDNS config. Required when Provider=clouddns.
+	// +optional
+	CloudDNS *CloudDNSDNS01 `json:"clouddns,omitempty"`
+
+	// AzureDNS config. Required when Provider=azuredns.
+	// +optional
+	AzureDNS *AzureDNSDNS01 `json:"azuredns,omitempty"`
+
+	// Hetzner config. Required when Provider=hetzner.
+	// +optional
+	Hetzner *HetznerDNS01 `json:"hetzner,omitempty"`
+
+	// PowerDNS config. Required when Provider=powerdns.
+	// +optional
+	PowerDNS *PowerDNSDNS01 `json:"powerdns,omitempty"`
+
+	// Webhook config. Required when Provider=webhook.
+	// +optional
+	Webhook *WebhookDNS01 `json:"webhook,omitempty"`
 }
 
 // CloudflareDNS01 configures the cloudflare solver.
@@ -142,6 +163,289 @@ type RFC2136DNS01 struct {
 	TSIGSecretSecretRef corev1.SecretKeySelector `json:"tsigSecretSecretRef"`
 }
 
+// CloudDNSDNS01 configures the Google Cloud DNS solver.
+type CloudDNSDNS01 struct {
+	// Project is the GCP project hosting the managed zone.
+	// +required
+	Project string `json:"project"`
+
+	// HostedZoneName pins the managed zone. Optional; cert-manager
+	// looks the zone up by name when empty.
+	// +optional
+	HostedZoneName string `json:"hostedZoneName,omitempty"`
+
+	// ServiceAccountSecretRef references a Secret holding a service
+	// account JSON key with the DNS Administrator role. Optional when
+	// cert-manager runs with Workload Identity.
+	// +optional
+	ServiceAccountSecretRef *corev1.SecretKeySelector `json:"serviceAccountSecretRef,omitempty"`
+}
+
+// AzureDNSEnvironment names an Azure cloud.
+// +kubebuilder:validation:Enum=AzurePublicCloud;AzureChinaCloud;AzureGermanCloud;AzureUSGovernmentCloud
+type AzureDNSEnvironment string
+
+// AzureDNSDNS01 configures the Azure DNS solver.
+type AzureDNSDNS01 struct {
+	// SubscriptionID is the Azure subscription of the DNS zone.
+	// +required
+	SubscriptionID string `json:"subscriptionID"`
+
+	// ResourceGroupName is the resource group of the DNS zone.
+	// +required
+	ResourceGroupName string `json:"resourceGroupName"`
+
+	// HostedZoneName is the DNS zone name. Optional; cert-manager
+	// derives it from the challenge domain when empty.
+	// +optional
+	HostedZoneName string `json:"hostedZoneName,omitempty"`
+
+	// Environment is the Azure cloud. Default AzurePublicCloud.
+	// +optional
+	Environment AzureDNSEnvironment `json:"environment,omitempty"`
+
+	// TenantID is the Azure AD tenant of the service principal.
+	// TenantID, ClientID and ClientSecretSecretRef are set together,
+	// or all left empty to use the managed identity cert-manager runs
+	// with.
+	// +optional
+	TenantID string `json:"tenantID,omitempty"`
+
+	// ClientID is the application ID of the service principal.
+	// +optional
+	ClientID string `json:"clientID,omitempty"`
+
+	// ClientSecretSecretRef references the Secret holding the service
+	// principal's client secret.
+	// +optional
+	ClientSecretSecretRef *corev1.SecretKeySelector `json:"clientSecretSecretRef,omitempty"`
+}
+
+// HetznerDNS01 configures Hetzner DNS through the
+// cert-manager-webhook-hetzner webhook solver, which must be
+// installed separately.
+type HetznerDNS01 struct {
+	// GroupName is the API group the webhook was installed with.
+	// +required
+	GroupName string `json:"groupName"`
+
+	// APIKeySecretRef names the Secret holding the Hetzner DNS API
+	// token. The webhook reads it from the key "api-key".
+	// +required
+	APIKeySecretRef corev1.LocalObjectReference `json:"apiKeySecretRef"`
+
+	// ZoneName is the Hetzner zone. Optional; the webhook looks up the
+	// zone enclosing the challenge record when empty.
+	// +optional
+	ZoneName string `json:"zoneName,omitempty"`
+
+	// APIURL overrides the Hetzner DNS API endpoint.
+	// +optional
+	APIURL string `json:"apiURL,omitempty"`
+}
+
+// PowerDNSDNS01 configures PowerDNS through the
+// cert-manager-webhook-pdns webhook solver, which must be installed
+// separately.
+type PowerDNSDNS01 struct {
+	// GroupName is the API group the webhook was installed with.
+	// +required
+	GroupName string `json:"groupName"`
+
+	// Host is the base URL of the PowerDNS HTTP API.
+	// +required
+	Host string `json:"host"`
+
+	// ServerID is the PowerDNS server ID. Default localhost.
+	// +optional
+	ServerID string `json:"serverID,omitempty"`
+
+	// APIKeySecretRef references the Secret holding the PowerDNS API
+	// key.
+	// +required
+	APIKeySecretRef corev1.SecretKeySelector `json:"apiKeySecretRef"`
+}
+
+// WebhookDNS01 configures an arbitrary cert-manager webhook solver
+// for DNS providers without a dedicated block.
+type WebhookDNS01 struct {
+	// GroupName is the API group the webhook was installed with.
+	// +required
+	GroupName string `json:"groupName"`
+
+	// SolverName is the solver name the webhook registers.
+	// +required
+	SolverName string `json:"solverName"`
+
+	// Config is passed to the webhook verbatim.
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +optional
+	Config *apiextensionsv1.JSON `json:"config,omitempty"`
+
+	// SecretRefs lists the Secret keys Config refers to. The
+	// controller cannot interpret Config, so these are what it checks
+	// exist before rendering the Issuer.
+	// +optional
+	SecretRefs []corev1.SecretKeySelector `json:"secretRefs,omitempty"`
+}
+
+// L4Protocol selects the transport of an L4 listener.
+// +kubebuilder:validation:Enum=TCP;UDP
+type L4Protocol string
+
+const (
+	L4ProtocolTCP L4Protocol = "TCP"
+	L4ProtocolUDP L4Protocol = "UDP"
+)
+
+// IssuerConfig configures a custom per-tenant Issuer in place of the
+// Let's Encrypt environment named by IssuerName. Exactly one of ACME,
+// CA or Vault is set.
+// +kubebuilder:validation:XValidation:rule="(has(self.acme) ? 1 : 0) + (has(self.ca) ? 1 : 0) + (has(self.vault) ? 1 : 0) == 1",message="exactly one of acme, ca or vault must be set"
+type IssuerConfig struct {
+	// ACME points the Issuer at an ACME server other than Let's
+	// Encrypt: ZeroSSL, Google Trust Services, or a private step-ca.
+	// The solver is still selected by CertMode.
+	// +optional
+	ACME *ACMEIssuerConfig `json:"acme,omitempty"`
+
+	// CA signs certificates with a CA key pair held in a Secret, for
+	// air-gapped clusters. No ACME challenge is run.
+	// +optional
+	CA *CAIssuerConfig `json:"ca,omitempty"`
+
+	// Vault signs certificates through a HashiCorp Vault PKI mount.
+	// No ACME challenge is run.
+	// +optional
+	Vault *VaultIssuerConfig `json:"vault,omitempty"`
+}
+
+// ACMEIssuerConfig configures a custom ACME server.
+type ACMEIssuerConfig struct {
+	// Server is the ACME directory URL.
+	// +kubebuilder:validation:Pattern=`^https://`
+	// +required
+	Server string `json:"server"`
+
+	// Email is the contact address registered with the ACME account.
+	// +optional
+	Email string `json:"email,omitempty"`
+
+	// ExternalAccountBinding binds the ACME account to an existing
+	// account at the CA. ZeroSSL and Google Trust Services require it.
+	// +optional
+	ExternalAccountBinding *ACMEExternalAccountBinding `json:"externalAccountBinding,omitempty"`
+
+	// CABundle is a PEM bundle used to verify the ACME server's
+	// certificate, for servers behind a private root such as step-ca.
+	// +optional
+	CABundle []byte `json:"caBundle,omitempty"`
+}
+
+// ACMEExternalAccountBinding holds the EAB credentials issued by the
+// ACME CA.
+type ACMEExternalAccountBinding struct {
+	// KeyID is the EAB key identifier.
+	// +required
+	KeyID string `json:"keyID"`
+
+	// KeySecretRef references the Secret holding the base64url-encoded
+	// EAB HMAC key.
+	// +required
+	KeySecretRef corev1.SecretKeySelector `json:"keySecretRef"`
+}
+
+// CAIssuerConfig configures a CA Issuer.
+type CAIssuerConfig struct {
+	// SecretName names a Secret in the TenantGateway's namespace
+	// holding the CA certificate and private key under tls.crt and
+	// tls.key.
+	// +required
+	SecretName string `json:"secretName"`
+}
+
+// VaultIssuerConfig configures a Vault Issuer. Exactly one of
+// TokenSecretRef or Kubernetes authenticates it.
+// +kubebuilder:validation:XValidation:rule="has(self.tokenSecretRef) != has(self.kubernetes)",message="exactly one of tokenSecretRef or kubernetes must be set"
+type VaultIssuerConfig struct {
+	// Server is the Vault server URL.
+	// +kubebuilder:validation:Pattern=`^https?://`
+	// +required
+	Server string `json:"server"`
+
+	// Path is the Vault path of the PKI signing endpoint, e.g.
+	// pki_int/sign/example-dot-com.
+	// +required
+	Path string `json:"path"`
+
+	// Namespace is the Vault Enterprise namespace.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// CABundle is a PEM bundle used to verify the Vault server's
+	// certificate.
+	// +optional
+	CABundle []byte `json:"caBundle,omitempty"`
+
+	// TokenSecretRef references a Secret holding a Vault token.
+	// +optional
+	TokenSecretRef *corev1.SecretKeySelector `json:"tokenSecretRef,omitempty"`
+
+	// Kubernetes authenticates with a token of a ServiceAccount in the
+	// TenantGateway's namespace through Vault's Kubernetes auth method.
+	// +optional
+	Kubernetes *VaultKubernetesAuth `json:"kubernetes,omitempty"`
+}
+
+// VaultKubernetesAuth configures Vault's Kubernetes auth method.
+type VaultKubernetesAuth struct {
+	// Role is the Vault role to log in as.
+	// +required
+	Role string `json:"role"`
+
+	// MountPath is the Vault mount path of the Kubernetes auth method.
+	// Default /v1/auth/kubernetes.
+	// +optional
+	MountPath string `json:"mountPath,omitempty"`
+
+	// ServiceAccountName names the ServiceAccount cert-manager requests
+	// a token for. cert-manager must be allowed to create tokens for
+	// it.
+	// +required
+	ServiceAccountName string `json:"serviceAccountName"`
+}
+
+// L4Listener declares a raw TCP or UDP port on the tenant Gateway.
+// TCPRoutes or UDPRoutes attach to it by sectionName or port; a port
+// carries no hostname, so one route owns it.
+type L4Listener struct {
+	// Name identifies the listener. The Gateway listener is named
+	// "tcp-<name>" or "udp-<name>" after the protocol.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=48
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	// +required
+	Name string `json:"name"`
+
+	// Port is the port the Gateway listens on. Ports 80 and 443 are
+	// taken by the HTTP and HTTPS listeners.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// +required
+	Port int32 `json:"port"`
+
+	// Protocol is TCP or UDP.
+	// +kubebuilder:default=TCP
+	// +optional
+	Protocol L4Protocol `json:"protocol,omitempty"`
+
+	// AllowedNamespaces lists the namespaces whose routes may attach
+	// to this listener. Empty means every namespace attached to the
+	// Gateway, as for the HTTPS listeners.
+	// +optional
+	AllowedNamespaces []string `json:"allowedNamespaces,omitempty"`
+}
+
 // TenantGatewaySpec describes the desired state of a per-tenant Gateway.
 type TenantGatewaySpec struct {
 	// Apex is the tenant's apex hostname. The Gateway listeners are
@@ -161,6 +465,12 @@ type TenantGatewaySpec struct {
 	// +kubebuilder:default=letsencrypt-prod
 	IssuerName IssuerName `json:"issuerName,omitempty"`
 
+	// Issuer configures a custom ACME server, CA or Vault issuer in
+	// place of IssuerName, which is then ignored. Ignored when
+	// CertMode=existingSecret.
+	// +optional
+	Issuer *IssuerConfig `json:"issuer,omitempty"`
+
 	// DNS01 configures the DNS-01 solver when CertMode=dns01. Ignored
 	// otherwise. Required (provider + matching config block) when
 	// CertMode=dns01.
@@ -189,6 +499,15 @@ type TenantGatewaySpec struct {
 	// +optional
 	TLSPassthroughServices []string `json:"tlsPassthroughServices,omitempty"`
 
+	// L4Listeners declares raw TCP/UDP listeners for services that do
+	// not speak HTTP or TLS with SNI (databases, brokers, game
+	// servers). Each gets a dedicated Gateway listener admitting
+	// TCPRoute or UDPRoute only.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	L4Listeners []L4Listener `json:"l4Listeners,omitempty"`
+
 	// GatewayClassName names the GatewayClass to attach the rendered
 	// Gateway to. Default cilium.
 	// +kubebuilder:default=cilium
@@ -206,6 +525,20 @@ type TenantGatewayListenerStatus struct {
 	// +optional
 	Hostname string `json:"hostname,omitempty"`
 
+	// Port is the port the listener binds.
+	// +optional
+	Port int32 `json:"port,omitempty"`
+
+	// Protocol is the listener's Gateway API protocol (HTTP, HTTPS,
+	// TLS, TCP or UDP).
+	// +optional
+	Protocol string `json:"protocol,omitempty"`
+
+	// AttachedRoutes is the number of routes the Gateway controller
+	// reports as attached to the listener.
+	// +optional
+	AttachedRoutes int32 `json:"attachedRoutes,omitempty"`
+
 	// Ready indicates the cert is issued and the Gateway has accepted
 	// the listener.
 	Ready bool `json:"ready"`
@@ -0,0 +1,201 @@
+/*
+Copyright 2026 The Cozystack Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RateLimitUnit is the window a RateLimitPolicy counts requests over.
+// +kubebuilder:validation:Enum=Second;Minute;Hour
+type RateLimitUnit string
+
+const (
+	RateLimitUnitSecond RateLimitUnit = "Second"
+	RateLimitUnitMinute RateLimitUnit = "Minute"
+	RateLimitUnitHour   RateLimitUnit = "Hour"
+)
+
+// RateLimitPolicy caps the request rate of a hostname. The limit is
+// enforced by each gateway replica on its own, so the effective
+// cluster-wide rate scales with the number of replicas.
+type RateLimitPolicy struct {
+	// Requests is the number of requests allowed per Unit.
+	// +kubebuilder:validation:Minimum=1
+	// +required
+	Requests int32 `json:"requests"`
+
+	// Unit is the window the requests are counted over.
+	// +kubebuilder:default=Second
+	// +optional
+	Unit RateLimitUnit `json:"unit,omitempty"`
+}
+
+// BasicAuthPolicy protects a hostname with HTTP basic auth.
+type BasicAuthPolicy struct {
+	// SecretRef names a Secret in the policy's namespace whose
+	// ".htpasswd" key holds the users in htpasswd format (SHA hashes).
+	// +required
+	SecretRef corev1.LocalObjectReference `json:"secretRef"`
+}
+
+// OIDCPolicy protects a hostname with an OpenID Connect login.
+type OIDCPolicy struct {
+	// Issuer is the OIDC provider's issuer URL, e.g. the Keycloak
+	// realm URL.
+	// +kubebuilder:validation:Pattern=`^https://`
+	// +required
+	Issuer string `json:"issuer"`
+
+	// ClientID is the OAuth client the gateway authenticates as.
+	// +kubebuilder:validation:MinLength=1
+	// +required
+	ClientID string `json:"clientID"`
+
+	// ClientSecretRef names a Secret in the policy's namespace whose
+	// "client-secret" key holds the OAuth client secret.
+	// +required
+	ClientSecretRef corev1.LocalObjectReference `json:"clientSecretRef"`
+
+	// RedirectURL is the callback URL registered with the provider.
+	// Defaults to /oauth2/callback on the requested hostname.
+	// +optional
+	RedirectURL string `json:"redirectURL,omitempty"`
+
+	// Scopes requested in addition to "openid".
+	// +optional
+	Scopes []string `json:"scopes,omitempty"`
+}
+
+// TenantGatewayPolicySpec describes the edge controls applied to some
+// or all hostnames of a TenantGateway.
+// +kubebuilder:validation:XValidation:rule="has(self.ipAllowList) || has(self.ipDenyList) || has(self.rateLimit) || has(self.maxRequestBodySize) || has(self.basicAuth) || has(self.oidc)",message="at least one of ipAllowList, ipDenyList, rateLimit, maxRequestBodySize, basicAuth or oidc must be set"
+// +kubebuilder:validation:XValidation:rule="!(has(self.basicAuth) && has(self.oidc))",message="basicAuth and oidc are mutually exclusive"
+type TenantGatewayPolicySpec struct {
+	// TargetRef names the TenantGateway in the policy's namespace the
+	// policy applies to.
+	// +required
+	TargetRef corev1.LocalObjectReference `json:"targetRef"`
+
+	// Hostnames the policy applies to. Each must be served by the
+	// TenantGateway: in http01 mode a hostname claimed by an attached
+	// route, in dns01 and existingSecret mode the apex or a hostname
+	// covered by the wildcard certificate, and in http01 and dns01 mode
+	// a verified CustomDomain. Empty applies the policy to
+	// every HTTPS hostname the TenantGateway serves; a policy naming a
+	// hostname replaces it there.
+	// +kubebuilder:validation:MaxItems=32
+	// +kubebuilder:validation:items:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$`
+	// +listType=set
+	// +optional
+	Hostnames []string `json:"hostnames,omitempty"`
+
+	// IPAllowList restricts clients to these CIDRs. Empty allows every
+	// client not in IPDenyList.
+	// +kubebuilder:validation:MaxItems=64
+	// +kubebuilder:validation:items:MaxLength=43
+	// +kubebuilder:validation:XValidation:rule="self.all(c, isCIDR(c))",message="ipAllowList entries must be CIDRs"
+	// +listType=set
+	// +optional
+	IPAllowList []string `json:"ipAllowList,omitempty"`
+
+	// IPDenyList rejects clients in these CIDRs. It takes precedence
+	// over IPAllowList.
+	// +kubebuilder:validation:MaxItems=64
+	// +kubebuilder:validation:items:MaxLength=43
+	// +kubebuilder:validation:XValidation:rule="self.all(c, isCIDR(c))",message="ipDenyList entries must be CIDRs"
+	// +listType=set
+	// +optional
+	IPDenyList []string `json:"ipDenyList,omitempty"`
+
+	// RateLimit caps the request rate.
+	// +optional
+	RateLimit *RateLimitPolicy `json:"rateLimit,omitempty"`
+
+	// MaxRequestBodySize rejects requests whose body is larger with
+	// 413 Payload Too Large.
+	// +optional
+	MaxRequestBodySize *resource.Quantity `json:"maxRequestBodySize,omitempty"`
+
+	// BasicAuth requires HTTP basic auth. Mutually exclusive with OIDC.
+	// +optional
+	BasicAuth *BasicAuthPolicy `json:"basicAuth,omitempty"`
+
+	// OIDC requires an OpenID Connect login. Mutually exclusive with
+	// BasicAuth.
+	// +optional
+	OIDC *OIDCPolicy `json:"oidc,omitempty"`
+}
+
+// TenantGatewayPolicyStatus reports whether the policy was rendered.
+type TenantGatewayPolicyStatus struct {
+	// ObservedGeneration mirrors the .metadata.generation reflected in
+	// the latest reconciled state.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Conditions describes the current state of the policy. Accepted
+	// is True when every hostname resolved and the controls were
+	// rendered for the gateway implementation. On a Cilium class,
+	// FiltersWholeGateway is True when the IP lists also filter the
+	// plain-HTTP listener answering ACME HTTP-01 challenges, or L4 or
+	// TLS-passthrough listeners.
+	// +optional
+	// +patchMergeKey=type
+	// +patchStrategy=merge
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
+
+	// Hostnames lists the hostnames the policy is in effect for.
+	// +optional
+	Hostnames []string `json:"hostnames,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Namespaced,shortName=tgwp
+// +kubebuilder:printcolumn:name="Gateway",type="string",JSONPath=".spec.targetRef.name"
+// +kubebuilder:printcolumn:name="Accepted",type="string",JSONPath=`.status.conditions[?(@.type=="Accepted")].status`
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// TenantGatewayPolicy attaches edge controls (IP allow/deny lists,
+// rate limits, request body limits, basic auth or OIDC) to the
+// hostnames of a TenantGateway. The cozystack-controller renders it
+// into the policy resources of the Gateway's implementation.
+type TenantGatewayPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   TenantGatewayPolicySpec   `json:"spec,omitempty"`
+	Status TenantGatewayPolicyStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// TenantGatewayPolicyList contains a list of TenantGatewayPolicy.
+type TenantGatewayPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []TenantGatewayPolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&TenantGatewayPolicy{}, &TenantGatewayPolicyList{})
+}
@@ -19,6 +19,7 @@ package v1alpha1
 import (
 	helmv2 "github.com/fluxcd/helm-controller/api/v2"
 	"github.com/fluxcd/pkg/apis/kustomize"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -72,6 +73,104 @@ type ApplicationDefinitionApplication struct {
 	Plural string `json:"plural"`
 	// Singular name of the application, used for UI and API
 	Singular string `json:"singular"`
+	// Versions lists the apps.cozystack.io versions the application is served
+	// under. The chart values stored in the HelmRelease are always shaped by
+	// openAPISchema; a version whose schema differs carries the conversion
+	// rules that translate its spec into that shape. When empty the
+	// application is served as v1alpha1 with openAPISchema and no conversion.
+	// +optional
+	// +kubebuilder:validation:MaxItems=8
+	// +listType=map
+	// +listMapKey=name
+	Versions []ApplicationDefinitionVersion `json:"versions,omitempty"`
+	// Move enables the move subresource, which relocates an application to
+	// another tenant namespace. Applications without it cannot be moved.
+	// +optional
+	Move *ApplicationDefinitionMove `json:"move,omitempty"`
+}
+
+// ApplicationDefinitionMove describes how an application is moved between
+// tenants.
+// +kubebuilder:validation:XValidation:rule="self.data != 'Backup' || has(self.backupClassName)",message="backupClassName is required when data is Backup"
+type ApplicationDefinitionMove struct {
+	// Data selects how the application's persistent data follows it
+	Data ApplicationMoveDataMode `json:"data"`
+	// BackupClassName is the BackupClass a move with Backup data runs with
+	// +optional
+	BackupClassName string `json:"backupClassName,omitempty"`
+}
+
+// ApplicationDefinitionVersion describes one served version of an application.
+type ApplicationDefinitionVersion struct {
+	// Name of the apps.cozystack.io version
+	// +kubebuilder:validation:Enum=v1alpha1;v1beta1
+	Name string `json:"name"`
+	// OpenAPI schema of the spec in this version, used for API defaulting and
+	// the published OpenAPI document. Defaults to the application openAPISchema.
+	// +optional
+	OpenAPISchema string `json:"openAPISchema,omitempty"`
+	// Conversion rules translating a spec written against this version into
+	// chart values. They are applied in order on create and update, and
+	// inverted in reverse order when the HelmRelease values are read back.
+	// +kubebuilder:validation:MaxItems=64
+	// +optional
+	Conversion []ApplicationDefinitionConversionRule `json:"conversion,omitempty"`
+}
+
+// ApplicationDefinitionConversionType enumerates the declarative conversion rules.
+// +kubebuilder:validation:Enum=Move;Rename;Default;MapValues
+type ApplicationDefinitionConversionType string
+
+const (
+	// ConversionMove moves the value at path to the absolute path in to.
+	ConversionMove ApplicationDefinitionConversionType = "Move"
+	// ConversionRename renames the last key of path to to, keeping the parent.
+	ConversionRename ApplicationDefinitionConversionType = "Rename"
+	// ConversionDefault sets the value at path when it is absent. Reads leave
+	// the value in place.
+	ConversionDefault ApplicationDefinitionConversionType = "Default"
+	// ConversionMapValues replaces scalar values at path according to values.
+	// Reads apply the reverse mapping for values with a single source.
+	ConversionMapValues ApplicationDefinitionConversionType = "MapValues"
+)
+
+// ApplicationDefinitionConversionRule is a single spec conversion step.
+//
+// Paths are dot-separated keys relative to spec. A "*" segment matches every
+// key of an object or every element of an array; "**" matches any number of
+// levels (including none). Move takes literal paths only; Rename may use
+// wildcards in path but to is a plain key name. Rules mapping legacy
+// resource presets and a renamed field:
+//
+//	conversion:
+//	- type: MapValues
+//	  path: "**.resourcesPreset"
+//	  values:
+//	    nano: t1.nano
+//	    small: t1.small
+//	- type: Rename
+//	  path: size
+//	  to: storageSize
+// +kubebuilder:validation:XValidation:rule="!(self.type in ['Move', 'Rename']) || (has(self.to) && size(self.to) > 0)",message="to is required for Move and Rename"
+// +kubebuilder:validation:XValidation:rule="self.type != 'Rename' || !has(self.to) || !self.to.contains('.')",message="to must be a single key name for Rename"
+// +kubebuilder:validation:XValidation:rule="self.type != 'MapValues' || (has(self.values) && size(self.values) > 0)",message="values must not be empty for MapValues"
+type ApplicationDefinitionConversionRule struct {
+	// Type of the rule
+	Type ApplicationDefinitionConversionType `json:"type"`
+	// Path the rule applies to, relative to spec
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	Path string `json:"path"`
+	// To is the destination path (Move) or the new key name (Rename)
+	// +kubebuilder:validation:MaxLength=253
+	// +optional
+	To string `json:"to,omitempty"`
+	// Value is the value set by a Default rule
+	// +optional
+	Value *apiextensionsv1.JSON `json:"value,omitempty"`
+	// Values is the old-to-new value table of a MapValues rule
+	// +optional
+	Values map[string]string `json:"values,omitempty"`
 }
 
 type ApplicationDefinitionRelease struct {
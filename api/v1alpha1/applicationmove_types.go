@@ -0,0 +1,139 @@
+/*
+Copyright 2026 The Cozystack Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ApplicationMoveDataMode selects how the persistent data of an application
+// follows it to another tenant.
+// +kubebuilder:validation:Enum=None;Backup
+type ApplicationMoveDataMode string
+
+const (
+	// ApplicationMoveDataNone re-creates the release in the target namespace
+	// without copying any data. It is for stateless applications only: moves
+	// of applications that have persistent volumes are refused.
+	ApplicationMoveDataNone ApplicationMoveDataMode = "None"
+	// ApplicationMoveDataBackup backs the application up with a BackupJob
+	// and restores the backup into the target namespace with a RestoreJob
+	// before the source is removed. Writes made after the backup are lost.
+	ApplicationMoveDataBackup ApplicationMoveDataMode = "Backup"
+)
+
+// ApplicationMoveAnnotation is set, to the name of the ApplicationMove, on
+// the HelmRelease a move creates or restores in the target namespace. A move
+// never takes over a target HelmRelease without it.
+const ApplicationMoveAnnotation = "cozystack.io/application-move"
+
+// ApplicationMovePhase is the stage an ApplicationMove is in.
+type ApplicationMovePhase string
+
+const (
+	ApplicationMovePending    ApplicationMovePhase = "Pending"
+	ApplicationMoveBackingUp  ApplicationMovePhase = "BackingUp"
+	ApplicationMoveRestoring  ApplicationMovePhase = "Restoring"
+	ApplicationMoveDeploying  ApplicationMovePhase = "Deploying"
+	ApplicationMoveCleaningUp ApplicationMovePhase = "CleaningUp"
+	ApplicationMoveSucceeded  ApplicationMovePhase = "Succeeded"
+	ApplicationMoveFailed     ApplicationMovePhase = "Failed"
+)
+
+// ApplicationMoveSpec describes a requested move.
+// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="spec is immutable"
+type ApplicationMoveSpec struct {
+	// Application is the moved application
+	Application corev1.TypedLocalObjectReference `json:"application"`
+	// SourceNamespace is the namespace the application is moved from
+	SourceNamespace string `json:"sourceNamespace"`
+	// TargetNamespace is the tenant namespace the application is moved to
+	TargetNamespace string `json:"targetNamespace"`
+	// Data selects how the application's persistent data follows it
+	Data ApplicationMoveDataMode `json:"data"`
+	// BackupClassName is the BackupClass a move with Backup data runs with
+	// +optional
+	BackupClassName string `json:"backupClassName,omitempty"`
+	// RequestedBy is the user who requested the move
+	// +optional
+	RequestedBy string `json:"requestedBy,omitempty"`
+}
+
+// ApplicationMoveStatus is the progress of a move.
+type ApplicationMoveStatus struct {
+	// Phase is the stage the move is in
+	// +optional
+	Phase ApplicationMovePhase `json:"phase,omitempty"`
+	// Message explains the phase, in particular why a move failed
+	// +optional
+	Message string `json:"message,omitempty"`
+	// BackupJobName is the BackupJob, in the source namespace, that backed
+	// the application up
+	// +optional
+	BackupJobName string `json:"backupJobName,omitempty"`
+	// RestoreJobName is the RestoreJob, in the source namespace, that
+	// restored the backup into the target namespace
+	// +optional
+	RestoreJobName string `json:"restoreJobName,omitempty"`
+	// StartedAt is when the controller started the move
+	// +optional
+	StartedAt *metav1.Time `json:"startedAt,omitempty"`
+	// CompletedAt is when the move succeeded or failed
+	// +optional
+	CompletedAt *metav1.Time `json:"completedAt,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:printcolumn:name="Kind",type="string",JSONPath=".spec.application.kind"
+// +kubebuilder:printcolumn:name="Application",type="string",JSONPath=".spec.application.name"
+// +kubebuilder:printcolumn:name="From",type="string",JSONPath=".spec.sourceNamespace"
+// +kubebuilder:printcolumn:name="To",type="string",JSONPath=".spec.targetNamespace"
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// ApplicationMove records the move of an application to another tenant
+// namespace. It is created by the move subresource of apps.cozystack.io
+// applications, carried out by the application move controller, and kept
+// afterwards as the audit record of the move.
+type ApplicationMove struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ApplicationMoveSpec   `json:"spec,omitempty"`
+	Status ApplicationMoveStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ApplicationMoveList contains a list of ApplicationMoves
+type ApplicationMoveList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ApplicationMove `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ApplicationMove{}, &ApplicationMoveList{})
+}
+
+// IsFinished reports whether the move succeeded or failed.
+func (m *ApplicationMove) IsFinished() bool {
+	return m.Status.Phase == ApplicationMoveSucceeded || m.Status.Phase == ApplicationMoveFailed
+}
@@ -0,0 +1,123 @@
+/*
+Copyright 2026 The Cozystack Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// OptionSourceScope is where the objects of an OptionSource are listed.
+// +kubebuilder:validation:Enum=Cluster;Namespace
+type OptionSourceScope string
+
+const (
+	// OptionSourceScopeCluster lists the objects of every namespace, or of
+	// the namespace of the OptionSource, regardless of the request.
+	OptionSourceScopeCluster OptionSourceScope = "Cluster"
+	// OptionSourceScopeNamespace lists the objects of the namespace the
+	// options are requested in.
+	OptionSourceScopeNamespace OptionSourceScope = "Namespace"
+)
+
+// OptionSourceResource identifies the resource an OptionSource lists.
+type OptionSourceResource struct {
+	// Group is the API group, empty for the core group
+	// +optional
+	Group string `json:"group,omitempty"`
+	// Version is the API version
+	Version string `json:"version"`
+	// Resource is the plural resource name, e.g. storageclasses
+	Resource string `json:"resource"`
+}
+
+// OptionSourceDefaultRule marks the options of the objects it matches as
+// preselected.
+type OptionSourceDefaultRule struct {
+	// JSONPath is a JSONPath expression evaluated against the object, e.g.
+	// {.metadata.annotations.storageclass\.kubernetes\.io/is-default-class}
+	JSONPath string `json:"jsonPath"`
+	// Value is what the expression must evaluate to; when empty, any
+	// non-empty result matches
+	// +optional
+	Value string `json:"value,omitempty"`
+}
+
+// OptionSourceSpec describes how the options are computed from objects.
+type OptionSourceSpec struct {
+	// Resource is the resource whose objects become options, one each
+	Resource OptionSourceResource `json:"resource"`
+	// Scope is Namespace to list the objects of the namespace the options
+	// are requested in, or Cluster to list them regardless of it
+	// +kubebuilder:default=Cluster
+	// +optional
+	Scope OptionSourceScope `json:"scope,omitempty"`
+	// Namespace restricts a Cluster source to the objects of one
+	// namespace, e.g. a public catalog
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+	// LabelSelector selects the objects listed
+	// +optional
+	LabelSelector *metav1.LabelSelector `json:"labelSelector,omitempty"`
+	// FieldSelector selects the objects listed by metadata.name and
+	// metadata.namespace
+	// +optional
+	FieldSelector string `json:"fieldSelector,omitempty"`
+	// Value is the JSONPath expression of the value of an option, the name
+	// of its object by default. Objects it yields nothing for are skipped.
+	// +optional
+	Value string `json:"value,omitempty"`
+	// Label is the JSONPath expression of the title of an option
+	// +optional
+	Label string `json:"label,omitempty"`
+	// Description is the JSONPath expression of the helper text of an
+	// option
+	// +optional
+	Description string `json:"description,omitempty"`
+	// Default marks the options of the objects matching any of the rules
+	// as preselected
+	// +optional
+	Default []OptionSourceDefaultRule `json:"default,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:printcolumn:name="Resource",type="string",JSONPath=".spec.resource.resource"
+// +kubebuilder:printcolumn:name="Scope",type="string",JSONPath=".spec.scope"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// OptionSource declares a dropdown source for the dashboard: cozystack-api
+// serves it as the Option of the same name, next to the built-in ones, which
+// take precedence.
+type OptionSource struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec OptionSourceSpec `json:"spec"`
+}
+
+// +kubebuilder:object:root=true
+
+// OptionSourceList contains a list of OptionSources.
+type OptionSourceList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []OptionSource `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&OptionSource{}, &OptionSourceList{})
+}
@@ -0,0 +1,182 @@
+/*
+Copyright 2026 The Cozystack Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// TenantHibernationPhase is the state a hibernated tenant is in.
+type TenantHibernationPhase string
+
+const (
+	TenantAwake       TenantHibernationPhase = "Awake"
+	TenantHibernating TenantHibernationPhase = "Hibernating"
+	TenantHibernated  TenantHibernationPhase = "Hibernated"
+	TenantResuming    TenantHibernationPhase = "Resuming"
+)
+
+// TenantHibernationSchedule hibernates and resumes a tenant at fixed times.
+type TenantHibernationSchedule struct {
+	// Hibernate is the cron expression of when the tenant hibernates
+	Hibernate string `json:"hibernate"`
+	// Resume is the cron expression of when the tenant resumes
+	Resume string `json:"resume"`
+	// TimeZone is the IANA time zone the expressions are in, UTC when empty
+	// +optional
+	TimeZone string `json:"timeZone,omitempty"`
+}
+
+// TenantHibernationSpec is the requested state of a tenant.
+type TenantHibernationSpec struct {
+	// Hibernated requests the tenant to hibernate, or to resume when false.
+	// With a schedule, a change of Hibernated takes effect at once and lasts
+	// until the schedule next fires.
+	// +optional
+	Hibernated bool `json:"hibernated,omitempty"`
+	// Schedule hibernates and resumes the tenant on its own
+	// +optional
+	Schedule *TenantHibernationSchedule `json:"schedule,omitempty"`
+}
+
+// HibernatedRelease is a HelmRelease a hibernation suspended.
+type HibernatedRelease struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	// WasSuspended is set on releases that were suspended already; resuming
+	// leaves them suspended
+	// +optional
+	WasSuspended bool `json:"wasSuspended,omitempty"`
+	// Resumed is set once the release has been resumed
+	// +optional
+	Resumed bool `json:"resumed,omitempty"`
+}
+
+// HibernatedWorkload is a workload a hibernation stopped, with what it ran
+// before.
+type HibernatedWorkload struct {
+	// Kind is Deployment, StatefulSet, VirtualMachine, or the operator kind
+	// Cluster (CloudNativePG or Cluster API, by APIGroup) or
+	// KamajiControlPlane
+	Kind string `json:"kind"`
+	// APIGroup is the group of an operator kind
+	// +optional
+	APIGroup  string `json:"apiGroup,omitempty"`
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	// Release is the HelmRelease that deployed the workload, as
+	// namespace/name; the workload resumes with it
+	// +optional
+	Release string `json:"release,omitempty"`
+	// Replicas is the replica count of a Deployment, StatefulSet or
+	// KamajiControlPlane
+	// +optional
+	Replicas *int32 `json:"replicas,omitempty"`
+	// RunStrategy is the run strategy of a VirtualMachine
+	// +optional
+	RunStrategy string `json:"runStrategy,omitempty"`
+	// Running is spec.running of a VirtualMachine without a run strategy
+	// +optional
+	Running *bool `json:"running,omitempty"`
+	// Hibernation is the cnpg.io/hibernation annotation of a CloudNativePG
+	// Cluster, empty if it had none
+	// +optional
+	Hibernation string `json:"hibernation,omitempty"`
+}
+
+// SkippedWorkload is a running workload of a hibernated tenant that another
+// controller scales, and which the hibernation therefore cannot stop.
+type SkippedWorkload struct {
+	// Kind is Deployment or StatefulSet
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	// Controller is the object controlling the workload, as kind/name
+	Controller string `json:"controller"`
+}
+
+// TenantHibernationStatus is the state of a tenant and what it needs to
+// resume.
+type TenantHibernationStatus struct {
+	// Phase is the state the tenant is in
+	// +optional
+	Phase TenantHibernationPhase `json:"phase,omitempty"`
+	// Message explains the phase
+	// +optional
+	Message string `json:"message,omitempty"`
+	// Hibernated is the state the tenant is driven to, from the spec or the
+	// schedule, whichever changed last
+	// +optional
+	Hibernated bool `json:"hibernated,omitempty"`
+	// ObservedGeneration is the generation Hibernated last followed
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// LastScheduleTime is when the schedule last fired
+	// +optional
+	LastScheduleTime *metav1.Time `json:"lastScheduleTime,omitempty"`
+	// NextScheduleTime is when the schedule fires next
+	// +optional
+	NextScheduleTime *metav1.Time `json:"nextScheduleTime,omitempty"`
+	// HibernatedAt is when the tenant last finished hibernating
+	// +optional
+	HibernatedAt *metav1.Time `json:"hibernatedAt,omitempty"`
+	// ResumedAt is when the tenant last finished resuming
+	// +optional
+	ResumedAt *metav1.Time `json:"resumedAt,omitempty"`
+	// Releases are the HelmReleases the hibernation suspended
+	// +optional
+	Releases []HibernatedRelease `json:"releases,omitempty"`
+	// Workloads are the workloads the hibernation stopped
+	// +optional
+	Workloads []HibernatedWorkload `json:"workloads,omitempty"`
+	// Skipped are the workloads that keep running because the hibernation
+	// cannot stop them. A tenant with skipped workloads stays Hibernating.
+	// +optional
+	Skipped []SkippedWorkload `json:"skipped,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Hibernated",type="boolean",JSONPath=".status.hibernated"
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase"
+// +kubebuilder:printcolumn:name="Next",type="date",JSONPath=".status.nextScheduleTime"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// TenantHibernation hibernates the tenant whose namespace it is in, together
+// with its sub-tenants: their HelmReleases are suspended and their workloads
+// and virtual machines stopped, keeping their volumes, until the tenant
+// resumes. The Tenant chart renders it from the tenant's hibernation values.
+type TenantHibernation struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   TenantHibernationSpec   `json:"spec,omitempty"`
+	Status TenantHibernationStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// TenantHibernationList contains a list of TenantHibernations
+type TenantHibernationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []TenantHibernation `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&TenantHibernation{}, &TenantHibernationList{})
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2026 The Cozystack Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// UsageRecordSpec is the hour a UsageRecord accounts for.
+type UsageRecordSpec struct {
+	// Start is the beginning of the hour, in UTC
+	Start metav1.Time `json:"start"`
+	// End is the end of the hour
+	End metav1.Time `json:"end"`
+}
+
+// ApplicationUsage is what one application was allocated over an hour.
+type ApplicationUsage struct {
+	// Kind is the kind of the application, empty for workloads that belong
+	// to no application
+	// +optional
+	Kind string `json:"kind,omitempty"`
+	// Name is the name of the application
+	// +optional
+	Name string `json:"name,omitempty"`
+	// Usage is the resources of the application's Workloads, in
+	// resource-hours: 2 cores allocated for half an hour account for cpu: 1
+	Usage map[string]resource.Quantity `json:"usage"`
+}
+
+// UsageRecordStatus accumulates the samples taken during the hour.
+type UsageRecordStatus struct {
+	// SampledSeconds is how much of the hour the samples cover
+	// +optional
+	SampledSeconds int64 `json:"sampledSeconds,omitempty"`
+	// LastSampleTime is when the last sample was added
+	// +optional
+	LastSampleTime *metav1.Time `json:"lastSampleTime,omitempty"`
+	// Applications is the usage of every application of the namespace,
+	// sorted by kind and name
+	// +optional
+	Applications []ApplicationUsage `json:"applications,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:printcolumn:name="Start",type="date",JSONPath=".spec.start"
+// +kubebuilder:printcolumn:name="Sampled",type="integer",JSONPath=".status.sampledSeconds"
+
+// UsageRecord is the resource usage of a tenant namespace over one hour, per
+// application. The usage accounting controller samples the Workloads of every
+// tenant namespace and adds each sample to the record of the current hour;
+// core.cozystack.io TenantUsages and their export are computed from them.
+type UsageRecord struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   UsageRecordSpec   `json:"spec,omitempty"`
+	Status UsageRecordStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// UsageRecordList contains a list of UsageRecords
+type UsageRecordList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []UsageRecord `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&UsageRecord{}, &UsageRecordList{})
+}
@@ -7,11 +7,14 @@ import (
 	"crypto/tls"
 	"flag"
 	"os"
+	"time"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
@@ -42,6 +45,8 @@ func main() {
 	var secureMetrics bool
 	var enableHTTP2 bool
 	var tlsOpts []func(*tls.Config)
+	var hubbleRelayAddress string
+	var flowPollInterval time.Duration
 	flag.StringVar(&metricsAddr, "metrics-bind-address", "0", "The address the metrics endpoint binds to. "+
 		"Use :8443 for HTTPS or :8080 for HTTP, or leave as 0 to disable the metrics service.")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
@@ -52,6 +57,11 @@ func main() {
 		"If set, the metrics endpoint is served securely via HTTPS. Use --metrics-secure=false to use HTTP instead.")
 	flag.BoolVar(&enableHTTP2, "enable-http2", false,
 		"If set, HTTP/2 will be enabled for the metrics server")
+	flag.StringVar(&hubbleRelayAddress, "hubble-relay-address", "",
+		"The host:port of the plaintext gRPC endpoint of Hubble Relay. "+
+			"If set, SecurityGroup status reports per-rule flow counters.")
+	flag.DurationVar(&flowPollInterval, "flow-poll-interval", 30*time.Second,
+		"The interval between two reads of the flows from Hubble Relay.")
 	opts := zap.Options{
 		Development: false,
 	}
@@ -98,7 +108,25 @@ func main() {
 		os.Exit(1)
 	}
 
-	if err := (&sgc.Reconciler{Client: mgr.GetClient()}).SetupWithManager(mgr); err != nil {
+	var flows *sgc.FlowCounter
+	if hubbleRelayAddress != "" {
+		conn, err := grpc.NewClient(hubbleRelayAddress, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		if err != nil {
+			setupLog.Error(err, "unable to set up Hubble Relay client")
+			os.Exit(1)
+		}
+		flows = &sgc.FlowCounter{
+			Reader:   mgr.GetClient(),
+			Source:   &sgc.HubbleFlowSource{Conn: conn},
+			Interval: flowPollInterval,
+		}
+		if err := mgr.Add(flows); err != nil {
+			setupLog.Error(err, "unable to set up flow counter")
+			os.Exit(1)
+		}
+	}
+
+	if err := (&sgc.Reconciler{Client: mgr.GetClient(), Flows: flows}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to setup controller", "controller", "SecurityGroupMembership")
 		os.Exit(1)
 	}
@@ -0,0 +1,422 @@
+/*
+Copyright 2026 The Cozystack Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package applicationmove carries out ApplicationMoves: it relocates an
+// apps.cozystack.io application, that is its HelmRelease, from one tenant
+// namespace to another.
+//
+// A move runs through the phases of ApplicationMovePhase:
+//
+//   - BackingUp and Restoring, for moves with Backup data only: a BackupJob
+//     backs the application up in the source namespace, and a RestoreJob
+//     restores the backup into the target namespace, through the
+//     cross-namespace restore of the backup strategies. Volumes are not
+//     cloned or snapshotted directly: the BackupClass decides how the data
+//     is copied.
+//   - Deploying: the HelmRelease is re-created in the target namespace, unless
+//     the restore already did, and the move waits for it to become Ready.
+//     The resources it renders there are labelled with the application's
+//     lineage by the lineage webhook, like those of any new release.
+//   - CleaningUp: the source HelmRelease is deleted, and, for moves with
+//     Backup data, the volumes the application left in the source namespace.
+//
+// The target HelmRelease carries the ApplicationMoveAnnotation of the move
+// that created or restored it; a move fails rather than adopt a HelmRelease
+// it did not make. Moves with None data fail from the start if the
+// application has volumes, which they would otherwise leave behind.
+//
+// A move that fails before CleaningUp leaves the source application as it
+// was. The ApplicationMove is kept once finished, as the record of the move.
+package applicationmove
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	helmv2 "github.com/fluxcd/helm-controller/api/v2"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	backupsv1alpha1 "github.com/cozystack/cozystack/api/backups/v1alpha1"
+	cozyv1alpha1 "github.com/cozystack/cozystack/api/v1alpha1"
+	appsv1alpha1 "github.com/cozystack/cozystack/pkg/apis/apps/v1alpha1"
+)
+
+// pollInterval is how often a move waiting for the target HelmRelease to
+// become Ready looks again.
+const pollInterval = 15 * time.Second
+
+// +kubebuilder:rbac:groups=cozystack.io,resources=applicationmoves,verbs=get;list;watch
+// +kubebuilder:rbac:groups=cozystack.io,resources=applicationmoves/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=helm.toolkit.fluxcd.io,resources=helmreleases,verbs=get;list;watch;create;patch;delete
+// +kubebuilder:rbac:groups=backups.cozystack.io,resources=backupjobs;restorejobs,verbs=get;list;watch;create
+// +kubebuilder:rbac:groups="",resources=persistentvolumeclaims,verbs=list;delete
+
+// Reconciler drives ApplicationMoves to completion.
+type Reconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+}
+
+// Reconcile advances an ApplicationMove by one phase at a time, persisting
+// the phase before acting on it so that every step is retried from the
+// status alone.
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	move := &cozyv1alpha1.ApplicationMove{}
+	if err := r.Get(ctx, req.NamespacedName, move); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if move.IsFinished() {
+		return ctrl.Result{}, nil
+	}
+
+	switch move.Status.Phase {
+	case "", cozyv1alpha1.ApplicationMovePending:
+		return r.start(ctx, move)
+	case cozyv1alpha1.ApplicationMoveBackingUp:
+		return r.backUp(ctx, move)
+	case cozyv1alpha1.ApplicationMoveRestoring:
+		return r.restore(ctx, move)
+	case cozyv1alpha1.ApplicationMoveDeploying:
+		return r.deploy(ctx, move)
+	case cozyv1alpha1.ApplicationMoveCleaningUp:
+		return r.cleanUp(ctx, move)
+	}
+	logger.Info("unknown ApplicationMove phase", "phase", move.Status.Phase)
+	return ctrl.Result{}, nil
+}
+
+// start checks the source application is still there, and can be moved as
+// requested, and enters the first phase of the move.
+func (r *Reconciler) start(ctx context.Context, move *cozyv1alpha1.ApplicationMove) (ctrl.Result, error) {
+	hr, err := r.sourceRelease(ctx, move)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if hr == nil {
+		return r.fail(ctx, move, "the application no longer exists in %s", move.Spec.SourceNamespace)
+	}
+	if move.Spec.Data != cozyv1alpha1.ApplicationMoveDataBackup {
+		pvcs := &corev1.PersistentVolumeClaimList{}
+		if err := r.List(ctx, pvcs, client.InNamespace(move.Spec.SourceNamespace), lineageLabels(move)); err != nil {
+			return ctrl.Result{}, err
+		}
+		if n := len(pvcs.Items); n > 0 {
+			return r.fail(ctx, move, "the application has %d persistent volumes in %s, which a move without data would leave behind",
+				n, move.Spec.SourceNamespace)
+		}
+	}
+	if dst, err := r.targetRelease(ctx, move, hr.Name); err != nil {
+		return ctrl.Result{}, err
+	} else if dst != nil {
+		return r.fail(ctx, move, "HelmRelease %s/%s already exists", dst.Namespace, dst.Name)
+	}
+	now := metav1.Now()
+	move.Status.StartedAt = &now
+	next := cozyv1alpha1.ApplicationMoveDeploying
+	if move.Spec.Data == cozyv1alpha1.ApplicationMoveDataBackup {
+		next = cozyv1alpha1.ApplicationMoveBackingUp
+	}
+	return r.enter(ctx, move, next, "moving %s %s from %s to %s", move.Spec.Application.Kind,
+		move.Spec.Application.Name, move.Spec.SourceNamespace, move.Spec.TargetNamespace)
+}
+
+// backUp runs the BackupJob of a move with Backup data.
+func (r *Reconciler) backUp(ctx context.Context, move *cozyv1alpha1.ApplicationMove) (ctrl.Result, error) {
+	job := &backupsv1alpha1.BackupJob{}
+	key := client.ObjectKey{Namespace: move.Spec.SourceNamespace, Name: move.Name}
+	if err := r.Get(ctx, key, job); apierrors.IsNotFound(err) {
+		job = &backupsv1alpha1.BackupJob{
+			ObjectMeta: metav1.ObjectMeta{Namespace: key.Namespace, Name: key.Name},
+			Spec: backupsv1alpha1.BackupJobSpec{
+				ApplicationRef:  move.Spec.Application,
+				BackupClassName: move.Spec.BackupClassName,
+			},
+		}
+		if err := controllerutil.SetOwnerReference(move, job, r.Scheme); err != nil {
+			return ctrl.Result{}, err
+		}
+		if err := r.Create(ctx, job); err != nil {
+			return ctrl.Result{}, err
+		}
+	} else if err != nil {
+		return ctrl.Result{}, err
+	}
+	if move.Status.BackupJobName != job.Name {
+		move.Status.BackupJobName = job.Name
+		return ctrl.Result{}, r.Status().Update(ctx, move)
+	}
+
+	switch job.Status.Phase {
+	case backupsv1alpha1.BackupJobPhaseSucceeded:
+		if job.Status.BackupRef == nil {
+			return r.fail(ctx, move, "BackupJob %s succeeded without a Backup", job.Name)
+		}
+		return r.enter(ctx, move, cozyv1alpha1.ApplicationMoveRestoring, "backed up as %s", job.Status.BackupRef.Name)
+	case backupsv1alpha1.BackupJobPhaseFailed:
+		return r.fail(ctx, move, "BackupJob %s failed: %s", job.Name, job.Status.Message)
+	}
+	// Owned BackupJobs requeue the move when their status changes.
+	return ctrl.Result{}, nil
+}
+
+// restore runs the RestoreJob copying the backup into the target namespace.
+func (r *Reconciler) restore(ctx context.Context, move *cozyv1alpha1.ApplicationMove) (ctrl.Result, error) {
+	backupJob := &backupsv1alpha1.BackupJob{}
+	if err := r.Get(ctx, client.ObjectKey{Namespace: move.Spec.SourceNamespace, Name: move.Status.BackupJobName}, backupJob); err != nil {
+		if apierrors.IsNotFound(err) {
+			return r.fail(ctx, move, "BackupJob %s is gone", move.Status.BackupJobName)
+		}
+		return ctrl.Result{}, err
+	}
+	if backupJob.Status.BackupRef == nil {
+		return r.fail(ctx, move, "BackupJob %s has no Backup", backupJob.Name)
+	}
+
+	job := &backupsv1alpha1.RestoreJob{}
+	key := client.ObjectKey{Namespace: move.Spec.SourceNamespace, Name: move.Name}
+	if err := r.Get(ctx, key, job); apierrors.IsNotFound(err) {
+		options, err := json.Marshal(map[string]any{
+			"targetNamespace":    move.Spec.TargetNamespace,
+			"failIfTargetExists": true,
+		})
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		job = &backupsv1alpha1.RestoreJob{
+			ObjectMeta: metav1.ObjectMeta{Namespace: key.Namespace, Name: key.Name},
+			Spec: backupsv1alpha1.RestoreJobSpec{
+				BackupRef: *backupJob.Status.BackupRef,
+				Options:   &runtime.RawExtension{Raw: options},
+			},
+		}
+		if err := controllerutil.SetOwnerReference(move, job, r.Scheme); err != nil {
+			return ctrl.Result{}, err
+		}
+		if err := r.Create(ctx, job); err != nil {
+			return ctrl.Result{}, err
+		}
+	} else if err != nil {
+		return ctrl.Result{}, err
+	}
+	if move.Status.RestoreJobName != job.Name {
+		move.Status.RestoreJobName = job.Name
+		return ctrl.Result{}, r.Status().Update(ctx, move)
+	}
+
+	switch job.Status.Phase {
+	case backupsv1alpha1.RestoreJobPhaseSucceeded:
+		// The restore fails if the target exists, so a HelmRelease there now
+		// is the restored one: claim it for the move.
+		src, err := r.sourceRelease(ctx, move)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if src == nil {
+			return r.fail(ctx, move, "the application disappeared from %s during the move", move.Spec.SourceNamespace)
+		}
+		dst, err := r.targetRelease(ctx, move, src.Name)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if dst != nil {
+			if owner, ok := dst.Annotations[cozyv1alpha1.ApplicationMoveAnnotation]; ok && owner != move.Name {
+				return r.fail(ctx, move, "HelmRelease %s/%s belongs to ApplicationMove %s", dst.Namespace, dst.Name, owner)
+			} else if !ok {
+				patch := client.MergeFrom(dst.DeepCopy())
+				metav1.SetMetaDataAnnotation(&dst.ObjectMeta, cozyv1alpha1.ApplicationMoveAnnotation, move.Name)
+				if err := r.Patch(ctx, dst, patch); err != nil {
+					return ctrl.Result{}, err
+				}
+			}
+		}
+		return r.enter(ctx, move, cozyv1alpha1.ApplicationMoveDeploying, "restored into %s", move.Spec.TargetNamespace)
+	case backupsv1alpha1.RestoreJobPhaseFailed:
+		return r.fail(ctx, move, "RestoreJob %s failed: %s", job.Name, job.Status.Message)
+	}
+	return ctrl.Result{}, nil
+}
+
+// deploy re-creates the HelmRelease in the target namespace and waits for it
+// to become Ready. A target HelmRelease the move did not create or restore
+// fails the move.
+func (r *Reconciler) deploy(ctx context.Context, move *cozyv1alpha1.ApplicationMove) (ctrl.Result, error) {
+	src, err := r.sourceRelease(ctx, move)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if src == nil {
+		return r.fail(ctx, move, "the application disappeared from %s during the move", move.Spec.SourceNamespace)
+	}
+
+	dst, err := r.targetRelease(ctx, move, src.Name)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if dst == nil {
+		dst = &helmv2.HelmRelease{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   move.Spec.TargetNamespace,
+				Name:        src.Name,
+				Labels:      src.Labels,
+				Annotations: map[string]string{},
+			},
+			Spec: *src.Spec.DeepCopy(),
+		}
+		for k, v := range src.Annotations {
+			dst.Annotations[k] = v
+		}
+		dst.Annotations[cozyv1alpha1.ApplicationMoveAnnotation] = move.Name
+		if err := r.Create(ctx, dst); err != nil {
+			return ctrl.Result{}, err
+		}
+		r.event(move, corev1.EventTypeNormal, "Deploying", "created HelmRelease %s/%s", dst.Namespace, dst.Name)
+		return ctrl.Result{RequeueAfter: pollInterval}, nil
+	}
+	if owner := dst.Annotations[cozyv1alpha1.ApplicationMoveAnnotation]; owner != move.Name {
+		return r.fail(ctx, move, "HelmRelease %s/%s was not created by this move", dst.Namespace, dst.Name)
+	}
+
+	if !apimeta.IsStatusConditionTrue(dst.Status.Conditions, "Ready") {
+		return ctrl.Result{RequeueAfter: pollInterval}, nil
+	}
+	return r.enter(ctx, move, cozyv1alpha1.ApplicationMoveCleaningUp, "HelmRelease %s/%s is ready", dst.Namespace, dst.Name)
+}
+
+// cleanUp removes the application from the source namespace.
+func (r *Reconciler) cleanUp(ctx context.Context, move *cozyv1alpha1.ApplicationMove) (ctrl.Result, error) {
+	src, err := r.sourceRelease(ctx, move)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if src != nil {
+		if err := r.Delete(ctx, src); client.IgnoreNotFound(err) != nil {
+			return ctrl.Result{}, err
+		}
+	}
+	if move.Spec.Data == cozyv1alpha1.ApplicationMoveDataBackup {
+		// Volumes outlive the release that created them; their data now
+		// lives in the target namespace.
+		pvcs := &corev1.PersistentVolumeClaimList{}
+		if err := r.List(ctx, pvcs, client.InNamespace(move.Spec.SourceNamespace), lineageLabels(move)); err != nil {
+			return ctrl.Result{}, err
+		}
+		for i := range pvcs.Items {
+			if err := r.Delete(ctx, &pvcs.Items[i]); client.IgnoreNotFound(err) != nil {
+				return ctrl.Result{}, err
+			}
+		}
+	}
+	now := metav1.Now()
+	move.Status.CompletedAt = &now
+	return r.enter(ctx, move, cozyv1alpha1.ApplicationMoveSucceeded, "moved to %s", move.Spec.TargetNamespace)
+}
+
+// sourceRelease returns the moved application's HelmRelease in the source
+// namespace, or nil if there is none.
+func (r *Reconciler) sourceRelease(ctx context.Context, move *cozyv1alpha1.ApplicationMove) (*helmv2.HelmRelease, error) {
+	releases := &helmv2.HelmReleaseList{}
+	if err := r.List(ctx, releases, client.InNamespace(move.Spec.SourceNamespace), lineageLabels(move)); err != nil {
+		return nil, err
+	}
+	switch len(releases.Items) {
+	case 0:
+		return nil, nil
+	case 1:
+		return &releases.Items[0], nil
+	}
+	return nil, fmt.Errorf("%d HelmReleases in %s belong to %s %s", len(releases.Items),
+		move.Spec.SourceNamespace, move.Spec.Application.Kind, move.Spec.Application.Name)
+}
+
+// targetRelease returns the HelmRelease named name in the target namespace,
+// or nil if there is none.
+func (r *Reconciler) targetRelease(ctx context.Context, move *cozyv1alpha1.ApplicationMove, name string) (*helmv2.HelmRelease, error) {
+	hr := &helmv2.HelmRelease{}
+	if err := r.Get(ctx, client.ObjectKey{Namespace: move.Spec.TargetNamespace, Name: name}, hr); err != nil {
+		return nil, client.IgnoreNotFound(err)
+	}
+	return hr, nil
+}
+
+// lineageLabels selects the objects of the moved application.
+func lineageLabels(move *cozyv1alpha1.ApplicationMove) client.MatchingLabels {
+	group := appsv1alpha1.GroupName
+	if move.Spec.Application.APIGroup != nil {
+		group = *move.Spec.Application.APIGroup
+	}
+	return client.MatchingLabels{
+		appsv1alpha1.ApplicationKindLabel:  move.Spec.Application.Kind,
+		appsv1alpha1.ApplicationGroupLabel: group,
+		appsv1alpha1.ApplicationNameLabel:  move.Spec.Application.Name,
+	}
+}
+
+// enter moves to phase, recording why.
+func (r *Reconciler) enter(ctx context.Context, move *cozyv1alpha1.ApplicationMove, phase cozyv1alpha1.ApplicationMovePhase, format string, args ...any) (ctrl.Result, error) {
+	move.Status.Phase = phase
+	move.Status.Message = fmt.Sprintf(format, args...)
+	if err := r.Status().Update(ctx, move); err != nil {
+		return ctrl.Result{}, err
+	}
+	r.event(move, corev1.EventTypeNormal, string(phase), "%s", move.Status.Message)
+	// The status update requeues the move, which then runs the new phase.
+	return ctrl.Result{}, nil
+}
+
+// fail ends the move unsuccessfully.
+func (r *Reconciler) fail(ctx context.Context, move *cozyv1alpha1.ApplicationMove, format string, args ...any) (ctrl.Result, error) {
+	now := metav1.Now()
+	move.Status.Phase = cozyv1alpha1.ApplicationMoveFailed
+	move.Status.Message = fmt.Sprintf(format, args...)
+	move.Status.CompletedAt = &now
+	if err := r.Status().Update(ctx, move); err != nil {
+		return ctrl.Result{}, err
+	}
+	r.event(move, corev1.EventTypeWarning, "MoveFailed", "%s", move.Status.Message)
+	return ctrl.Result{}, nil
+}
+
+func (r *Reconciler) event(move *cozyv1alpha1.ApplicationMove, eventType, reason, format string, args ...any) {
+	if r.Recorder == nil {
+		return
+	}
+	r.Recorder.Eventf(move, eventType, reason, format, args...)
+}
+
+// SetupWithManager registers the controller. BackupJobs and RestoreJobs are
+// owned by the move that created them, so their progress requeues it.
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("applicationmove").
+		For(&cozyv1alpha1.ApplicationMove{}).
+		Owns(&backupsv1alpha1.BackupJob{}).
+		Owns(&backupsv1alpha1.RestoreJob{}).
+		Complete(r)
+}
@@ -0,0 +1,278 @@
+/*
+Copyright 2026 The Cozystack Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package applicationmove
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	helmv2 "github.com/fluxcd/helm-controller/api/v2"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	backupsv1alpha1 "github.com/cozystack/cozystack/api/backups/v1alpha1"
+	cozyv1alpha1 "github.com/cozystack/cozystack/api/v1alpha1"
+	appsv1alpha1 "github.com/cozystack/cozystack/pkg/apis/apps/v1alpha1"
+)
+
+func lineage(name string) map[string]string {
+	return map[string]string{
+		appsv1alpha1.ApplicationKindLabel:  "PostgreSQL",
+		appsv1alpha1.ApplicationGroupLabel: appsv1alpha1.GroupName,
+		appsv1alpha1.ApplicationNameLabel:  name,
+	}
+}
+
+func sourceHR() *helmv2.HelmRelease {
+	return &helmv2.HelmRelease{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "tenant-a", Name: "postgresql-db", Labels: lineage("db")},
+		Spec:       helmv2.HelmReleaseSpec{ReleaseName: "postgresql-db"},
+	}
+}
+
+func newMove(data cozyv1alpha1.ApplicationMoveDataMode) *cozyv1alpha1.ApplicationMove {
+	group := appsv1alpha1.GroupName
+	return &cozyv1alpha1.ApplicationMove{
+		ObjectMeta: metav1.ObjectMeta{Name: "postgresql-db-x1"},
+		Spec: cozyv1alpha1.ApplicationMoveSpec{
+			Application:     corev1.TypedLocalObjectReference{APIGroup: &group, Kind: "PostgreSQL", Name: "db"},
+			SourceNamespace: "tenant-a",
+			TargetNamespace: "tenant-b",
+			Data:            data,
+			BackupClassName: "velero",
+		},
+	}
+}
+
+func newReconciler(t *testing.T, objs ...client.Object) *Reconciler {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = helmv2.AddToScheme(scheme)
+	_ = cozyv1alpha1.AddToScheme(scheme)
+	_ = backupsv1alpha1.AddToScheme(scheme)
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(objs...).
+		WithStatusSubresource(&cozyv1alpha1.ApplicationMove{}, &helmv2.HelmRelease{},
+			&backupsv1alpha1.BackupJob{}, &backupsv1alpha1.RestoreJob{}).
+		Build()
+	return &Reconciler{Client: c, Scheme: scheme}
+}
+
+// step reconciles the move once and returns it as stored afterwards.
+func step(t *testing.T, r *Reconciler) *cozyv1alpha1.ApplicationMove {
+	t.Helper()
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Name: "postgresql-db-x1"}}
+	if _, err := r.Reconcile(context.Background(), req); err != nil {
+		t.Fatalf("Reconcile: %v", err)
+	}
+	move := &cozyv1alpha1.ApplicationMove{}
+	if err := r.Get(context.Background(), req.NamespacedName, move); err != nil {
+		t.Fatal(err)
+	}
+	return move
+}
+
+func TestReconcile_MoveWithoutData(t *testing.T) {
+	ctx := context.Background()
+	r := newReconciler(t, newMove(cozyv1alpha1.ApplicationMoveDataNone), sourceHR())
+
+	if move := step(t, r); move.Status.Phase != cozyv1alpha1.ApplicationMoveDeploying || move.Status.StartedAt == nil {
+		t.Fatalf("after start: %+v", move.Status)
+	}
+	step(t, r)
+	dst := &helmv2.HelmRelease{}
+	if err := r.Get(ctx, client.ObjectKey{Namespace: "tenant-b", Name: "postgresql-db"}, dst); err != nil {
+		t.Fatalf("target HelmRelease: %v", err)
+	}
+	if dst.Labels[appsv1alpha1.ApplicationNameLabel] != "db" || dst.Spec.ReleaseName != "postgresql-db" ||
+		dst.Annotations[cozyv1alpha1.ApplicationMoveAnnotation] != "postgresql-db-x1" {
+		t.Errorf("target HelmRelease = %+v, want a copy of the source claimed by the move", dst.ObjectMeta)
+	}
+
+	// Not Ready yet: the source stays.
+	if move := step(t, r); move.Status.Phase != cozyv1alpha1.ApplicationMoveDeploying {
+		t.Fatalf("before Ready: phase %s", move.Status.Phase)
+	}
+	dst.Status.Conditions = []metav1.Condition{{Type: "Ready", Status: metav1.ConditionTrue, Reason: "Test", LastTransitionTime: metav1.Now()}}
+	if err := r.Status().Update(ctx, dst); err != nil {
+		t.Fatal(err)
+	}
+	if move := step(t, r); move.Status.Phase != cozyv1alpha1.ApplicationMoveCleaningUp {
+		t.Fatalf("after Ready: phase %s", move.Status.Phase)
+	}
+	if move := step(t, r); move.Status.Phase != cozyv1alpha1.ApplicationMoveSucceeded || move.Status.CompletedAt == nil {
+		t.Fatalf("after clean-up: %+v", move.Status)
+	}
+	if err := r.Get(ctx, client.ObjectKey{Namespace: "tenant-a", Name: "postgresql-db"}, &helmv2.HelmRelease{}); !apierrors.IsNotFound(err) {
+		t.Errorf("source HelmRelease still there (get error %v)", err)
+	}
+}
+
+func TestReconcile_MoveWithBackup(t *testing.T) {
+	ctx := context.Background()
+	pvc := &corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Namespace: "tenant-a", Name: "data-db-0", Labels: lineage("db")}}
+	other := &corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Namespace: "tenant-a", Name: "data-other-0", Labels: lineage("other")}}
+	r := newReconciler(t, newMove(cozyv1alpha1.ApplicationMoveDataBackup), sourceHR(), pvc, other)
+
+	if move := step(t, r); move.Status.Phase != cozyv1alpha1.ApplicationMoveBackingUp {
+		t.Fatalf("after start: phase %s", move.Status.Phase)
+	}
+	if move := step(t, r); move.Status.BackupJobName != "postgresql-db-x1" {
+		t.Fatalf("backup job = %q", move.Status.BackupJobName)
+	}
+	backupJob := &backupsv1alpha1.BackupJob{}
+	if err := r.Get(ctx, client.ObjectKey{Namespace: "tenant-a", Name: "postgresql-db-x1"}, backupJob); err != nil {
+		t.Fatal(err)
+	}
+	if backupJob.Spec.ApplicationRef.Name != "db" || backupJob.Spec.BackupClassName != "velero" || len(backupJob.OwnerReferences) != 1 {
+		t.Errorf("BackupJob = %+v", backupJob)
+	}
+	backupJob.Status.Phase = backupsv1alpha1.BackupJobPhaseSucceeded
+	backupJob.Status.BackupRef = &corev1.LocalObjectReference{Name: "db-backup"}
+	if err := r.Status().Update(ctx, backupJob); err != nil {
+		t.Fatal(err)
+	}
+	if move := step(t, r); move.Status.Phase != cozyv1alpha1.ApplicationMoveRestoring {
+		t.Fatalf("after backup: phase %s", move.Status.Phase)
+	}
+
+	step(t, r)
+	restoreJob := &backupsv1alpha1.RestoreJob{}
+	if err := r.Get(ctx, client.ObjectKey{Namespace: "tenant-a", Name: "postgresql-db-x1"}, restoreJob); err != nil {
+		t.Fatal(err)
+	}
+	var options map[string]any
+	if err := json.Unmarshal(restoreJob.Spec.Options.Raw, &options); err != nil {
+		t.Fatal(err)
+	}
+	if restoreJob.Spec.BackupRef.Name != "db-backup" || options["targetNamespace"] != "tenant-b" {
+		t.Errorf("RestoreJob = %+v, options %v", restoreJob.Spec, options)
+	}
+	// The restore re-creates the release in the target namespace.
+	dst := sourceHR()
+	dst.Namespace = "tenant-b"
+	if err := r.Create(ctx, dst); err != nil {
+		t.Fatal(err)
+	}
+	restoreJob.Status.Phase = backupsv1alpha1.RestoreJobPhaseSucceeded
+	if err := r.Status().Update(ctx, restoreJob); err != nil {
+		t.Fatal(err)
+	}
+	if move := step(t, r); move.Status.Phase != cozyv1alpha1.ApplicationMoveDeploying {
+		t.Fatalf("after restore: phase %s", move.Status.Phase)
+	}
+	if err := r.Get(ctx, client.ObjectKeyFromObject(dst), dst); err != nil {
+		t.Fatal(err)
+	}
+	if dst.Annotations[cozyv1alpha1.ApplicationMoveAnnotation] != "postgresql-db-x1" {
+		t.Errorf("restored HelmRelease annotations = %v, want it claimed by the move", dst.Annotations)
+	}
+	dst.Status.Conditions = []metav1.Condition{{Type: "Ready", Status: metav1.ConditionTrue, Reason: "Test", LastTransitionTime: metav1.Now()}}
+	if err := r.Status().Update(ctx, dst); err != nil {
+		t.Fatal(err)
+	}
+	step(t, r)
+	if move := step(t, r); move.Status.Phase != cozyv1alpha1.ApplicationMoveSucceeded {
+		t.Fatalf("after clean-up: phase %s", move.Status.Phase)
+	}
+	if err := r.Get(ctx, client.ObjectKeyFromObject(pvc), &corev1.PersistentVolumeClaim{}); !apierrors.IsNotFound(err) {
+		t.Errorf("source volume still there (get error %v)", err)
+	}
+	if err := r.Get(ctx, client.ObjectKeyFromObject(other), &corev1.PersistentVolumeClaim{}); err != nil {
+		t.Errorf("another application's volume was deleted: %v", err)
+	}
+}
+
+func TestReconcile_FailedBackupKeepsSource(t *testing.T) {
+	ctx := context.Background()
+	r := newReconciler(t, newMove(cozyv1alpha1.ApplicationMoveDataBackup), sourceHR())
+	step(t, r)
+	step(t, r)
+	backupJob := &backupsv1alpha1.BackupJob{}
+	if err := r.Get(ctx, client.ObjectKey{Namespace: "tenant-a", Name: "postgresql-db-x1"}, backupJob); err != nil {
+		t.Fatal(err)
+	}
+	backupJob.Status.Phase = backupsv1alpha1.BackupJobPhaseFailed
+	backupJob.Status.Message = "no space left"
+	if err := r.Status().Update(ctx, backupJob); err != nil {
+		t.Fatal(err)
+	}
+	move := step(t, r)
+	if move.Status.Phase != cozyv1alpha1.ApplicationMoveFailed || move.Status.CompletedAt == nil {
+		t.Fatalf("after failed backup: %+v", move.Status)
+	}
+	if err := r.Get(ctx, client.ObjectKey{Namespace: "tenant-a", Name: "postgresql-db"}, &helmv2.HelmRelease{}); err != nil {
+		t.Errorf("source HelmRelease: %v", err)
+	}
+	// A finished move is left alone.
+	if again := step(t, r); again.Status.Phase != cozyv1alpha1.ApplicationMoveFailed {
+		t.Errorf("finished move changed to %s", again.Status.Phase)
+	}
+}
+
+func TestReconcile_MissingSourceFails(t *testing.T) {
+	r := newReconciler(t, newMove(cozyv1alpha1.ApplicationMoveDataNone))
+	if move := step(t, r); move.Status.Phase != cozyv1alpha1.ApplicationMoveFailed {
+		t.Errorf("phase = %s, want Failed", move.Status.Phase)
+	}
+}
+
+func TestReconcile_ForeignTargetFails(t *testing.T) {
+	ctx := context.Background()
+	foreign := sourceHR()
+	foreign.Namespace = "tenant-b"
+	foreign.Spec.ReleaseName = "someone-elses"
+	r := newReconciler(t, newMove(cozyv1alpha1.ApplicationMoveDataNone), sourceHR(), foreign)
+	if move := step(t, r); move.Status.Phase != cozyv1alpha1.ApplicationMoveFailed {
+		t.Fatalf("phase = %s, want Failed", move.Status.Phase)
+	}
+
+	// A HelmRelease appearing in the target during the move is not adopted.
+	r = newReconciler(t, newMove(cozyv1alpha1.ApplicationMoveDataNone), sourceHR())
+	step(t, r)
+	foreign.ResourceVersion = ""
+	if err := r.Create(ctx, foreign); err != nil {
+		t.Fatal(err)
+	}
+	if move := step(t, r); move.Status.Phase != cozyv1alpha1.ApplicationMoveFailed {
+		t.Fatalf("phase = %s, want Failed", move.Status.Phase)
+	}
+	if err := r.Get(ctx, client.ObjectKey{Namespace: "tenant-a", Name: "postgresql-db"}, &helmv2.HelmRelease{}); err != nil {
+		t.Errorf("source HelmRelease: %v", err)
+	}
+}
+
+func TestReconcile_VolumesWithoutDataFail(t *testing.T) {
+	ctx := context.Background()
+	pvc := &corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Namespace: "tenant-a", Name: "data-db-0", Labels: lineage("db")}}
+	r := newReconciler(t, newMove(cozyv1alpha1.ApplicationMoveDataNone), sourceHR(), pvc)
+	if move := step(t, r); move.Status.Phase != cozyv1alpha1.ApplicationMoveFailed {
+		t.Fatalf("phase = %s, want Failed", move.Status.Phase)
+	}
+	if err := r.Get(ctx, client.ObjectKey{Namespace: "tenant-b", Name: "postgresql-db"}, &helmv2.HelmRelease{}); !apierrors.IsNotFound(err) {
+		t.Errorf("target HelmRelease created (get error %v)", err)
+	}
+}
@@ -0,0 +1,430 @@
+/*
+Copyright 2026 The Cozystack Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package customdomain verifies CustomDomains: it proves that the tenant
+// owns the hostname through a DNS TXT record and settles which tenant
+// gets a hostname several of them claim.
+//
+// The controller only writes CustomDomain status. The TenantGateway
+// controller picks up every Verified CustomDomain in the namespaces its
+// Gateway serves and renders the listener and certificate for it; the
+// Ready condition mirrors the listener it reports.
+package customdomain
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	gatewayv1alpha1 "github.com/cozystack/cozystack/api/gateway/v1alpha1"
+)
+
+const (
+	// ChallengePrefix is prepended to the hostname to form the name of
+	// the TXT record that proves ownership.
+	ChallengePrefix = "_cozystack-challenge."
+
+	// ConditionVerified is True while the TXT record holds the token
+	// and the CustomDomain is the oldest verified claim for its
+	// hostname. The TenantGateway controller serves only Verified
+	// CustomDomains.
+	ConditionVerified = "Verified"
+	// ConditionReady is True once the TenantGateway serving the
+	// namespace reports a ready HTTPS listener for the hostname.
+	ConditionReady = "Ready"
+
+	// ReasonConflicted marks a CustomDomain whose TXT record is in
+	// place but an older CustomDomain in another namespace holds the
+	// hostname. It still counts as proven when the older claim goes
+	// away.
+	ReasonConflicted = "Conflicted"
+
+	reasonRecordFound         = "RecordFound"
+	reasonRecordNotFound      = "RecordNotFound"
+	reasonRecordMismatch      = "RecordMismatch"
+	reasonLookupFailed        = "LookupFailed"
+	reasonVerificationLost    = "VerificationLost"
+	reasonPlatformDomain      = "PlatformDomain"
+	reasonNotVerified         = "NotVerified"
+	reasonNoGateway           = "NoGateway"
+	reasonCertModeUnsupported = "CertModeUnsupported"
+	reasonListenerPending     = "ListenerPending"
+	reasonListenerReady       = "ListenerReady"
+
+	// retryInterval is how often an unverified CustomDomain looks up
+	// its TXT record again.
+	retryInterval = time.Minute
+	// reverifyInterval is how often a verified CustomDomain checks
+	// that its TXT record is still in place.
+	reverifyInterval = time.Hour
+
+	namespaceHostLabel    = "namespace.cozystack.io/host"
+	namespaceGatewayLabel = "namespace.cozystack.io/gateway"
+)
+
+// Resolver looks up TXT records. *net.Resolver satisfies it.
+type Resolver interface {
+	LookupTXT(ctx context.Context, name string) ([]string, error)
+}
+
+// +kubebuilder:rbac:groups=gateway.cozystack.io,resources=customdomains,verbs=get;list;watch
+// +kubebuilder:rbac:groups=gateway.cozystack.io,resources=customdomains/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=gateway.cozystack.io,resources=tenantgateways,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
+
+// Reconciler verifies CustomDomains and reports whether they are served.
+type Reconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+	// Resolver looks up the challenge records; net.DefaultResolver
+	// when nil.
+	Resolver Resolver
+}
+
+// Reconcile looks up the challenge record of a CustomDomain, settles
+// conflicting claims for its hostname and reports whether the
+// namespace's TenantGateway serves it.
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	cd := &gatewayv1alpha1.CustomDomain{}
+	if err := r.Get(ctx, req.NamespacedName, cd); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	status := cd.Status.DeepCopy()
+	status.ObservedGeneration = cd.Generation
+
+	if status.Verification == nil {
+		token, err := newToken()
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		status.Verification = &gatewayv1alpha1.CustomDomainVerification{
+			RecordName:  ChallengePrefix + cd.Spec.Hostname,
+			RecordValue: token,
+		}
+	}
+
+	verified, retry, err := r.verify(ctx, cd, status)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if verified {
+		if err := r.settleConflicts(ctx, cd, status); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+	if err := r.setReady(ctx, cd, status); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if !equality.Semantic.DeepEqual(&cd.Status, status) {
+		cd.Status = *status
+		if err := r.Status().Update(ctx, cd); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+	if apimeta.IsStatusConditionTrue(status.Conditions, ConditionVerified) && !retry {
+		return ctrl.Result{RequeueAfter: reverifyInterval}, nil
+	}
+	return ctrl.Result{RequeueAfter: retryInterval}, nil
+}
+
+// verify sets the Verified condition from the challenge record and
+// reports whether ownership is proven. A failed lookup other than a
+// missing record leaves the condition as it was and asks for a retry,
+// so a DNS outage does not take served hostnames down.
+func (r *Reconciler) verify(ctx context.Context, cd *gatewayv1alpha1.CustomDomain, status *gatewayv1alpha1.CustomDomainStatus) (verified, retry bool, err error) {
+	if apex, ok, err := r.platformApex(ctx, cd.Spec.Hostname); err != nil {
+		return false, false, err
+	} else if ok {
+		setCondition(cd, status, ConditionVerified, metav1.ConditionFalse, reasonPlatformDomain,
+			fmt.Sprintf("%s is under the platform domain %s, which the TenantGateway serves without a CustomDomain", cd.Spec.Hostname, apex))
+		return false, false, nil
+	}
+
+	records, err := r.resolver().LookupTXT(ctx, status.Verification.RecordName)
+	var dnsErr *net.DNSError
+	switch {
+	case err == nil:
+	case errors.As(err, &dnsErr) && dnsErr.IsNotFound:
+		records = nil
+	default:
+		log.FromContext(ctx).Info("TXT lookup failed, retrying", "record", status.Verification.RecordName, "error", err.Error())
+		prev := apimeta.FindStatusCondition(status.Conditions, ConditionVerified)
+		if prev == nil {
+			setCondition(cd, status, ConditionVerified, metav1.ConditionUnknown, reasonLookupFailed, err.Error())
+			return false, true, nil
+		}
+		return prev.Status == metav1.ConditionTrue, true, nil
+	}
+
+	for _, rec := range records {
+		if strings.TrimSpace(rec) == status.Verification.RecordValue {
+			setCondition(cd, status, ConditionVerified, metav1.ConditionTrue, reasonRecordFound,
+				fmt.Sprintf("TXT record %s holds the verification token", status.Verification.RecordName))
+			return true, false, nil
+		}
+	}
+
+	reason, msg := reasonRecordNotFound, fmt.Sprintf("create a TXT record %s with the value %s", status.Verification.RecordName, status.Verification.RecordValue)
+	if len(records) > 0 {
+		reason = reasonRecordMismatch
+		msg = fmt.Sprintf("TXT record %s does not hold %s", status.Verification.RecordName, status.Verification.RecordValue)
+	}
+	if prev := apimeta.FindStatusCondition(status.Conditions, ConditionVerified); prev != nil && (prev.Status == metav1.ConditionTrue || prev.Reason == ReasonConflicted) {
+		reason = reasonVerificationLost
+		msg = fmt.Sprintf("TXT record %s no longer holds the verification token; restore it to serve %s again", status.Verification.RecordName, cd.Spec.Hostname)
+	}
+	setCondition(cd, status, ConditionVerified, metav1.ConditionFalse, reason, msg)
+	return false, false, nil
+}
+
+// platformApex returns the tenant apex hostname is equal to or under,
+// if any. Those hostnames belong to whichever tenant owns the apex, and
+// a TXT record in a zone the platform operator controls proves nothing
+// about the tenant.
+func (r *Reconciler) platformApex(ctx context.Context, hostname string) (string, bool, error) {
+	list := &corev1.NamespaceList{}
+	if err := r.List(ctx, list, client.HasLabels{namespaceHostLabel}); err != nil {
+		return "", false, fmt.Errorf("list namespaces by host label: %w", err)
+	}
+	for i := range list.Items {
+		apex := strings.ToLower(list.Items[i].Labels[namespaceHostLabel])
+		if apex == "" {
+			continue
+		}
+		if hostname == apex || strings.HasSuffix(hostname, "."+apex) {
+			return apex, true, nil
+		}
+	}
+	return "", false, nil
+}
+
+// settleConflicts hands the hostname to the oldest proven claim.
+// cd has just been proven; the other claims count as proven while
+// their Verified condition is True or Conflicted. The loser keeps
+// Verified=False with ReasonConflicted and takes over when the winner
+// is deleted or loses its record.
+func (r *Reconciler) settleConflicts(ctx context.Context, cd *gatewayv1alpha1.CustomDomain, status *gatewayv1alpha1.CustomDomainStatus) error {
+	list := &gatewayv1alpha1.CustomDomainList{}
+	if err := r.List(ctx, list); err != nil {
+		return fmt.Errorf("list CustomDomains: %w", err)
+	}
+	claims := []*gatewayv1alpha1.CustomDomain{cd}
+	for i := range list.Items {
+		other := &list.Items[i]
+		if other.Spec.Hostname != cd.Spec.Hostname || (other.Namespace == cd.Namespace && other.Name == cd.Name) {
+			continue
+		}
+		if !other.DeletionTimestamp.IsZero() || !proven(other) {
+			continue
+		}
+		claims = append(claims, other)
+	}
+	sortClaims(claims)
+	winner := claims[0]
+	if winner == cd || winner.Namespace == cd.Namespace {
+		return nil
+	}
+	setCondition(cd, status, ConditionVerified, metav1.ConditionFalse, ReasonConflicted,
+		fmt.Sprintf("%s is already claimed by CustomDomain %s/%s", cd.Spec.Hostname, winner.Namespace, winner.Name))
+	return nil
+}
+
+// proven reports whether cd's owner has published its challenge
+// record, whether or not it won the hostname.
+func proven(cd *gatewayv1alpha1.CustomDomain) bool {
+	c := apimeta.FindStatusCondition(cd.Status.Conditions, ConditionVerified)
+	return c != nil && (c.Status == metav1.ConditionTrue || c.Reason == ReasonConflicted)
+}
+
+// sortClaims orders the claims for a hostname oldest first, then by
+// namespace/name so the order is deterministic.
+func sortClaims(claims []*gatewayv1alpha1.CustomDomain) {
+	sort.SliceStable(claims, func(i, j int) bool {
+		a, b := claims[i], claims[j]
+		if !a.CreationTimestamp.Equal(&b.CreationTimestamp) {
+			return a.CreationTimestamp.Before(&b.CreationTimestamp)
+		}
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.Name < b.Name
+	})
+}
+
+// setReady mirrors the listener the namespace's TenantGateway reports
+// for the hostname into the Ready condition.
+func (r *Reconciler) setReady(ctx context.Context, cd *gatewayv1alpha1.CustomDomain, status *gatewayv1alpha1.CustomDomainStatus) error {
+	if !apimeta.IsStatusConditionTrue(status.Conditions, ConditionVerified) {
+		setCondition(cd, status, ConditionReady, metav1.ConditionFalse, reasonNotVerified,
+			"the hostname is served once ownership is verified")
+		return nil
+	}
+
+	ns := &corev1.Namespace{}
+	if err := r.Get(ctx, types.NamespacedName{Name: cd.Namespace}, ns); err != nil {
+		return fmt.Errorf("get namespace %s: %w", cd.Namespace, err)
+	}
+	gatewayNs := ns.Labels[namespaceGatewayLabel]
+	if gatewayNs == "" {
+		setCondition(cd, status, ConditionReady, metav1.ConditionFalse, reasonNoGateway,
+			fmt.Sprintf("namespace %s is not served by a TenantGateway", cd.Namespace))
+		return nil
+	}
+	tgws := &gatewayv1alpha1.TenantGatewayList{}
+	if err := r.List(ctx, tgws, client.InNamespace(gatewayNs)); err != nil {
+		return fmt.Errorf("list TenantGateways in %s: %w", gatewayNs, err)
+	}
+	if len(tgws.Items) == 0 {
+		setCondition(cd, status, ConditionReady, metav1.ConditionFalse, reasonNoGateway,
+			fmt.Sprintf("no TenantGateway in namespace %s", gatewayNs))
+		return nil
+	}
+
+	unsupported := ""
+	for i := range tgws.Items {
+		tgw := &tgws.Items[i]
+		if tgw.Spec.CertMode == gatewayv1alpha1.CertModeExistingSecret {
+			unsupported = tgw.Name
+			continue
+		}
+		for _, l := range tgw.Status.Listeners {
+			if l.Hostname != cd.Spec.Hostname || l.Protocol != "HTTPS" {
+				continue
+			}
+			if l.Ready {
+				setCondition(cd, status, ConditionReady, metav1.ConditionTrue, reasonListenerReady,
+					fmt.Sprintf("served by listener %s of TenantGateway %s/%s", l.Name, tgw.Namespace, tgw.Name))
+				return nil
+			}
+			setCondition(cd, status, ConditionReady, metav1.ConditionFalse, reasonListenerPending,
+				fmt.Sprintf("listener %s of TenantGateway %s/%s is not ready: %s", l.Name, tgw.Namespace, tgw.Name, l.Reason))
+			return nil
+		}
+	}
+	if unsupported != "" {
+		setCondition(cd, status, ConditionReady, metav1.ConditionFalse, reasonCertModeUnsupported,
+			fmt.Sprintf("TenantGateway %s/%s uses certMode existingSecret, which cannot issue certificates for custom domains", gatewayNs, unsupported))
+		return nil
+	}
+	setCondition(cd, status, ConditionReady, metav1.ConditionFalse, reasonListenerPending,
+		fmt.Sprintf("waiting for the TenantGateway in namespace %s to add a listener", gatewayNs))
+	return nil
+}
+
+func setCondition(cd *gatewayv1alpha1.CustomDomain, status *gatewayv1alpha1.CustomDomainStatus, condType string, s metav1.ConditionStatus, reason, msg string) {
+	apimeta.SetStatusCondition(&status.Conditions, metav1.Condition{
+		Type:               condType,
+		Status:             s,
+		ObservedGeneration: cd.Generation,
+		Reason:             reason,
+		Message:            msg,
+	})
+}
+
+func (r *Reconciler) resolver() Resolver {
+	if r.Resolver != nil {
+		return r.Resolver
+	}
+	return net.DefaultResolver
+}
+
+// newToken returns the random value the challenge record must hold.
+func newToken() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("generate verification token: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// SetupWithManager registers the controller. Claims for the same
+// hostname requeue each other so a deleted winner hands over at once,
+// and a TenantGateway change requeues the CustomDomains of the
+// namespaces it serves to refresh Ready.
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("customdomain").
+		For(&gatewayv1alpha1.CustomDomain{}).
+		Watches(&gatewayv1alpha1.CustomDomain{}, handler.EnqueueRequestsFromMapFunc(r.mapSameHostname)).
+		Watches(&gatewayv1alpha1.TenantGateway{}, handler.EnqueueRequestsFromMapFunc(r.mapTenantGateway)).
+		Complete(r)
+}
+
+// mapSameHostname requeues the other claims for a CustomDomain's
+// hostname.
+func (r *Reconciler) mapSameHostname(ctx context.Context, obj client.Object) []reconcile.Request {
+	cd, ok := obj.(*gatewayv1alpha1.CustomDomain)
+	if !ok {
+		return nil
+	}
+	list := &gatewayv1alpha1.CustomDomainList{}
+	if err := r.List(ctx, list); err != nil {
+		log.FromContext(ctx).Error(err, "list CustomDomains for hostname mapper")
+		return nil
+	}
+	var out []reconcile.Request
+	for i := range list.Items {
+		other := &list.Items[i]
+		if other.Spec.Hostname != cd.Spec.Hostname || (other.Namespace == cd.Namespace && other.Name == cd.Name) {
+			continue
+		}
+		out = append(out, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: other.Namespace, Name: other.Name}})
+	}
+	return out
+}
+
+// mapTenantGateway requeues the CustomDomains of every namespace the
+// TenantGateway serves.
+func (r *Reconciler) mapTenantGateway(ctx context.Context, obj client.Object) []reconcile.Request {
+	nsList := &corev1.NamespaceList{}
+	selector := labels.SelectorFromSet(labels.Set{namespaceGatewayLabel: obj.GetNamespace()})
+	if err := r.List(ctx, nsList, client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		log.FromContext(ctx).Error(err, "list namespaces for TenantGateway mapper")
+		return nil
+	}
+	var out []reconcile.Request
+	for i := range nsList.Items {
+		list := &gatewayv1alpha1.CustomDomainList{}
+		if err := r.List(ctx, list, client.InNamespace(nsList.Items[i].Name)); err != nil {
+			log.FromContext(ctx).Error(err, "list CustomDomains for TenantGateway mapper")
+			return nil
+		}
+		for j := range list.Items {
+			out = append(out, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: list.Items[j].Namespace, Name: list.Items[j].Name}})
+		}
+	}
+	return out
+}
@@ -0,0 +1,330 @@
+/*
+Copyright 2026 The Cozystack Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package customdomain
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	gatewayv1alpha1 "github.com/cozystack/cozystack/api/gateway/v1alpha1"
+)
+
+// fakeResolver answers TXT lookups from a map; names it does not know
+// are NXDOMAIN, and err, when set, fails every lookup.
+type fakeResolver struct {
+	records map[string][]string
+	err     error
+}
+
+func (f *fakeResolver) LookupTXT(_ context.Context, name string) ([]string, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	if recs, ok := f.records[name]; ok {
+		return recs, nil
+	}
+	return nil, &net.DNSError{Err: "no such host", Name: name, IsNotFound: true}
+}
+
+func namespace(name string, labels map[string]string) *corev1.Namespace {
+	return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels}}
+}
+
+func customDomain(ns, name, hostname string, created time.Time) *gatewayv1alpha1.CustomDomain {
+	return &gatewayv1alpha1.CustomDomain{
+		ObjectMeta: metav1.ObjectMeta{Namespace: ns, Name: name, Generation: 1, CreationTimestamp: metav1.NewTime(created)},
+		Spec:       gatewayv1alpha1.CustomDomainSpec{Hostname: hostname},
+	}
+}
+
+// withToken pre-seeds the verification token so tests can publish it.
+func withToken(cd *gatewayv1alpha1.CustomDomain, token string) *gatewayv1alpha1.CustomDomain {
+	cd.Status.Verification = &gatewayv1alpha1.CustomDomainVerification{
+		RecordName:  ChallengePrefix + cd.Spec.Hostname,
+		RecordValue: token,
+	}
+	return cd
+}
+
+func withVerified(cd *gatewayv1alpha1.CustomDomain, s metav1.ConditionStatus, reason string) *gatewayv1alpha1.CustomDomain {
+	apimeta.SetStatusCondition(&cd.Status.Conditions, metav1.Condition{Type: ConditionVerified, Status: s, Reason: reason})
+	return cd
+}
+
+func newReconciler(t *testing.T, res *fakeResolver, objs ...client.Object) *Reconciler {
+	t.Helper()
+	s := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+	if err := gatewayv1alpha1.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+	c := fake.NewClientBuilder().
+		WithScheme(s).
+		WithObjects(objs...).
+		WithStatusSubresource(&gatewayv1alpha1.CustomDomain{}, &gatewayv1alpha1.TenantGateway{}).
+		Build()
+	return &Reconciler{Client: c, Scheme: s, Resolver: res}
+}
+
+func reconcileDomain(t *testing.T, r *Reconciler, ns, name string) (*gatewayv1alpha1.CustomDomain, reconcile.Result) {
+	t.Helper()
+	key := types.NamespacedName{Namespace: ns, Name: name}
+	res, err := r.Reconcile(context.Background(), reconcile.Request{NamespacedName: key})
+	if err != nil {
+		t.Fatalf("Reconcile: %v", err)
+	}
+	cd := &gatewayv1alpha1.CustomDomain{}
+	if err := r.Get(context.Background(), key, cd); err != nil {
+		t.Fatal(err)
+	}
+	return cd, res
+}
+
+func requireCondition(t *testing.T, cd *gatewayv1alpha1.CustomDomain, condType string, s metav1.ConditionStatus, reason string) {
+	t.Helper()
+	c := apimeta.FindStatusCondition(cd.Status.Conditions, condType)
+	if c == nil {
+		t.Fatalf("%s condition missing: %+v", condType, cd.Status.Conditions)
+	}
+	if c.Status != s || c.Reason != reason {
+		t.Fatalf("%s = %s/%s (%s), want %s/%s", condType, c.Status, c.Reason, c.Message, s, reason)
+	}
+}
+
+func TestReconcile_IssuesTokenAndWaitsForRecord(t *testing.T) {
+	r := newReconciler(t, &fakeResolver{},
+		namespace("tenant-shop", nil),
+		customDomain("tenant-shop", "shop", "shop.customer.com", time.Now()))
+
+	cd, res := reconcileDomain(t, r, "tenant-shop", "shop")
+	if cd.Status.Verification == nil || cd.Status.Verification.RecordValue == "" {
+		t.Fatalf("verification token not issued: %+v", cd.Status)
+	}
+	if cd.Status.Verification.RecordName != "_cozystack-challenge.shop.customer.com" {
+		t.Errorf("RecordName = %q", cd.Status.Verification.RecordName)
+	}
+	requireCondition(t, cd, ConditionVerified, metav1.ConditionFalse, reasonRecordNotFound)
+	requireCondition(t, cd, ConditionReady, metav1.ConditionFalse, reasonNotVerified)
+	if res.RequeueAfter != retryInterval {
+		t.Errorf("RequeueAfter = %v, want %v", res.RequeueAfter, retryInterval)
+	}
+
+	// The token is stable across reconciles: the owner publishes it once.
+	token := cd.Status.Verification.RecordValue
+	cd, _ = reconcileDomain(t, r, "tenant-shop", "shop")
+	if cd.Status.Verification.RecordValue != token {
+		t.Errorf("token changed from %q to %q", token, cd.Status.Verification.RecordValue)
+	}
+}
+
+func TestReconcile_VerifiesMatchingRecord(t *testing.T) {
+	res := &fakeResolver{records: map[string][]string{
+		"_cozystack-challenge.shop.customer.com": {"unrelated", " token-a "},
+	}}
+	r := newReconciler(t, res,
+		namespace("tenant-shop", nil),
+		withToken(customDomain("tenant-shop", "shop", "shop.customer.com", time.Now()), "token-a"))
+
+	cd, result := reconcileDomain(t, r, "tenant-shop", "shop")
+	requireCondition(t, cd, ConditionVerified, metav1.ConditionTrue, reasonRecordFound)
+	requireCondition(t, cd, ConditionReady, metav1.ConditionFalse, reasonNoGateway)
+	if result.RequeueAfter != reverifyInterval {
+		t.Errorf("RequeueAfter = %v, want %v", result.RequeueAfter, reverifyInterval)
+	}
+}
+
+func TestReconcile_RecordMismatch(t *testing.T) {
+	res := &fakeResolver{records: map[string][]string{
+		"_cozystack-challenge.shop.customer.com": {"someone-else"},
+	}}
+	r := newReconciler(t, res,
+		namespace("tenant-shop", nil),
+		withToken(customDomain("tenant-shop", "shop", "shop.customer.com", time.Now()), "token-a"))
+
+	cd, _ := reconcileDomain(t, r, "tenant-shop", "shop")
+	requireCondition(t, cd, ConditionVerified, metav1.ConditionFalse, reasonRecordMismatch)
+}
+
+func TestReconcile_RejectsPlatformDomain(t *testing.T) {
+	// A TXT record under a platform apex proves nothing about the
+	// tenant: whoever runs the platform zone can publish it.
+	res := &fakeResolver{records: map[string][]string{
+		"_cozystack-challenge.shop.bob.example.org": {"token-a"},
+	}}
+	r := newReconciler(t, res,
+		namespace("tenant-bob", map[string]string{namespaceHostLabel: "bob.example.org"}),
+		namespace("tenant-alice", nil),
+		withToken(customDomain("tenant-alice", "shop", "shop.bob.example.org", time.Now()), "token-a"))
+
+	cd, _ := reconcileDomain(t, r, "tenant-alice", "shop")
+	requireCondition(t, cd, ConditionVerified, metav1.ConditionFalse, reasonPlatformDomain)
+}
+
+func TestReconcile_OldestProvenClaimWins(t *testing.T) {
+	now := time.Now()
+	res := &fakeResolver{records: map[string][]string{
+		"_cozystack-challenge.shop.customer.com": {"token-a", "token-b"},
+	}}
+	older := withVerified(withToken(customDomain("tenant-a", "shop", "shop.customer.com", now.Add(-time.Hour)), "token-a"), metav1.ConditionTrue, reasonRecordFound)
+	r := newReconciler(t, res,
+		namespace("tenant-a", nil), namespace("tenant-b", nil),
+		older,
+		withToken(customDomain("tenant-b", "shop", "shop.customer.com", now), "token-b"))
+
+	cd, _ := reconcileDomain(t, r, "tenant-b", "shop")
+	requireCondition(t, cd, ConditionVerified, metav1.ConditionFalse, ReasonConflicted)
+
+	// The winner is unaffected by the younger claim.
+	cd, _ = reconcileDomain(t, r, "tenant-a", "shop")
+	requireCondition(t, cd, ConditionVerified, metav1.ConditionTrue, reasonRecordFound)
+
+	// Once the winner goes, the proven runner-up takes over.
+	if err := r.Delete(context.Background(), cd); err != nil {
+		t.Fatal(err)
+	}
+	cd, _ = reconcileDomain(t, r, "tenant-b", "shop")
+	requireCondition(t, cd, ConditionVerified, metav1.ConditionTrue, reasonRecordFound)
+}
+
+func TestReconcile_UnprovenOlderClaimDoesNotBlock(t *testing.T) {
+	// Creating a CustomDomain first is not enough to squat a hostname:
+	// only claims whose record was found compete.
+	now := time.Now()
+	res := &fakeResolver{records: map[string][]string{
+		"_cozystack-challenge.shop.customer.com": {"token-b"},
+	}}
+	squatter := withVerified(withToken(customDomain("tenant-a", "shop", "shop.customer.com", now.Add(-time.Hour)), "token-a"), metav1.ConditionFalse, reasonRecordNotFound)
+	r := newReconciler(t, res,
+		namespace("tenant-a", nil), namespace("tenant-b", nil),
+		squatter,
+		withToken(customDomain("tenant-b", "shop", "shop.customer.com", now), "token-b"))
+
+	cd, _ := reconcileDomain(t, r, "tenant-b", "shop")
+	requireCondition(t, cd, ConditionVerified, metav1.ConditionTrue, reasonRecordFound)
+}
+
+func TestReconcile_VerificationLost(t *testing.T) {
+	r := newReconciler(t, &fakeResolver{},
+		namespace("tenant-shop", nil),
+		withVerified(withToken(customDomain("tenant-shop", "shop", "shop.customer.com", time.Now()), "token-a"), metav1.ConditionTrue, reasonRecordFound))
+
+	cd, _ := reconcileDomain(t, r, "tenant-shop", "shop")
+	requireCondition(t, cd, ConditionVerified, metav1.ConditionFalse, reasonVerificationLost)
+}
+
+func TestReconcile_LookupFailureKeepsVerification(t *testing.T) {
+	res := &fakeResolver{err: errors.New("i/o timeout")}
+	r := newReconciler(t, res,
+		namespace("tenant-shop", nil),
+		withVerified(withToken(customDomain("tenant-shop", "shop", "shop.customer.com", time.Now()), "token-a"), metav1.ConditionTrue, reasonRecordFound))
+
+	cd, result := reconcileDomain(t, r, "tenant-shop", "shop")
+	requireCondition(t, cd, ConditionVerified, metav1.ConditionTrue, reasonRecordFound)
+	if result.RequeueAfter != retryInterval {
+		t.Errorf("RequeueAfter = %v, want %v", result.RequeueAfter, retryInterval)
+	}
+}
+
+func TestReconcile_ReadyMirrorsListener(t *testing.T) {
+	res := &fakeResolver{records: map[string][]string{
+		"_cozystack-challenge.shop.customer.com": {"token-a"},
+	}}
+	tgw := &gatewayv1alpha1.TenantGateway{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "tenant-root", Name: "gateway"},
+		Spec:       gatewayv1alpha1.TenantGatewaySpec{Apex: "example.org"},
+		Status: gatewayv1alpha1.TenantGatewayStatus{Listeners: []gatewayv1alpha1.TenantGatewayListenerStatus{{
+			Name: "https-shop", Hostname: "shop.customer.com", Protocol: "HTTPS", Reason: "CertificateNotReady",
+		}}},
+	}
+	r := newReconciler(t, res,
+		namespace("tenant-root", map[string]string{namespaceGatewayLabel: "tenant-root"}),
+		namespace("tenant-shop", map[string]string{namespaceGatewayLabel: "tenant-root"}),
+		tgw,
+		withToken(customDomain("tenant-shop", "shop", "shop.customer.com", time.Now()), "token-a"))
+
+	cd, _ := reconcileDomain(t, r, "tenant-shop", "shop")
+	requireCondition(t, cd, ConditionReady, metav1.ConditionFalse, reasonListenerPending)
+
+	tgw.Status.Listeners[0].Ready = true
+	tgw.Status.Listeners[0].Reason = ""
+	if err := r.Status().Update(context.Background(), tgw); err != nil {
+		t.Fatal(err)
+	}
+	cd, _ = reconcileDomain(t, r, "tenant-shop", "shop")
+	requireCondition(t, cd, ConditionReady, metav1.ConditionTrue, reasonListenerReady)
+}
+
+func TestReconcile_ExistingSecretModeUnsupported(t *testing.T) {
+	res := &fakeResolver{records: map[string][]string{
+		"_cozystack-challenge.shop.customer.com": {"token-a"},
+	}}
+	r := newReconciler(t, res,
+		namespace("tenant-root", map[string]string{namespaceGatewayLabel: "tenant-root"}),
+		&gatewayv1alpha1.TenantGateway{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "tenant-root", Name: "gateway"},
+			Spec:       gatewayv1alpha1.TenantGatewaySpec{Apex: "example.org", CertMode: gatewayv1alpha1.CertModeExistingSecret},
+		},
+		withToken(customDomain("tenant-root", "shop", "shop.customer.com", time.Now()), "token-a"))
+
+	cd, _ := reconcileDomain(t, r, "tenant-root", "shop")
+	requireCondition(t, cd, ConditionReady, metav1.ConditionFalse, reasonCertModeUnsupported)
+}
+
+func TestMapSameHostname(t *testing.T) {
+	now := time.Now()
+	a := customDomain("tenant-a", "shop", "shop.customer.com", now)
+	r := newReconciler(t, &fakeResolver{},
+		a,
+		customDomain("tenant-b", "shop", "shop.customer.com", now),
+		customDomain("tenant-b", "blog", "blog.customer.com", now))
+
+	reqs := r.mapSameHostname(context.Background(), a)
+	if len(reqs) != 1 || reqs[0].Namespace != "tenant-b" || reqs[0].Name != "shop" {
+		t.Fatalf("mapSameHostname = %v, want only tenant-b/shop", reqs)
+	}
+}
+
+func TestMapTenantGateway(t *testing.T) {
+	now := time.Now()
+	r := newReconciler(t, &fakeResolver{},
+		namespace("tenant-root", map[string]string{namespaceGatewayLabel: "tenant-root"}),
+		namespace("tenant-shop", map[string]string{namespaceGatewayLabel: "tenant-root"}),
+		namespace("tenant-other", map[string]string{namespaceGatewayLabel: "tenant-other"}),
+		customDomain("tenant-shop", "shop", "shop.customer.com", now),
+		customDomain("tenant-other", "blog", "blog.customer.com", now))
+
+	tgw := &gatewayv1alpha1.TenantGateway{ObjectMeta: metav1.ObjectMeta{Namespace: "tenant-root", Name: "gateway"}}
+	reqs := r.mapTenantGateway(context.Background(), tgw)
+	if len(reqs) != 1 || reqs[0].Namespace != "tenant-shop" || reqs[0].Name != "shop" {
+		t.Fatalf("mapTenantGateway = %v, want only tenant-shop/shop", reqs)
+	}
+}
@@ -0,0 +1,438 @@
+/*
+Copyright 2026 The Cozystack Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package secretsync backs TenantSecrets with the external secret store of
+// their tenant, through external-secrets-operator.
+//
+// A tenant opts in by setting secretStore in its values: the tenant chart then
+// renders a SecretStore named "tenant-secrets" in the tenant namespace,
+// pointing at the KV mount of an OpenBao (or Vault) server, and annotated with
+// the KV path to mirror into and the Application kinds to mirror. This
+// controller reconciles the Secrets tenants reach as TenantSecrets against it:
+//
+//   - a TenantSecret created with an externalRef carries its KV path in the
+//     secrets.cozystack.io/external-path annotation, and gets an ExternalSecret
+//     that pulls that path into it;
+//   - the credentials of an Application of a mirrored kind get a PushSecret
+//     that writes them to "<path>/<secret name>".
+//
+// Both are owned by the Secret, so they go away with it. Their Ready condition
+// is copied into the sync annotations of the Secret, which cozystack-api
+// reports as the sync status of the TenantSecret.
+package secretsync
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	crsource "sigs.k8s.io/controller-runtime/pkg/source"
+
+	appsv1alpha1 "github.com/cozystack/cozystack/pkg/apis/apps/v1alpha1"
+	corev1alpha1 "github.com/cozystack/cozystack/pkg/apis/core/v1alpha1"
+)
+
+const (
+	// tenantResourceLabel is stamped "true" by the lineage webhook on the
+	// Secrets tenants may reach as TenantSecrets.
+	tenantResourceLabel = "internal.cozystack.io/tenantresource"
+
+	// refreshInterval is how often external-secrets re-reads or re-writes
+	// the store, on top of the changes it watches.
+	refreshInterval = "1h"
+
+	// syncMessageLimit bounds the error message copied into an annotation.
+	syncMessageLimit = 256
+)
+
+var (
+	secretStoreGVK    = schema.GroupVersionKind{Group: "external-secrets.io", Version: "v1beta1", Kind: "SecretStore"}
+	externalSecretGVK = schema.GroupVersionKind{Group: "external-secrets.io", Version: "v1beta1", Kind: "ExternalSecret"}
+	pushSecretGVK     = schema.GroupVersionKind{Group: "external-secrets.io", Version: "v1alpha1", Kind: "PushSecret"}
+)
+
+// Reconciler syncs tenant Secrets with the SecretStore of their namespace.
+type Reconciler struct {
+	// Client writes the Secrets and the external-secrets objects, and reads
+	// the latter from the manager's cache.
+	Client client.Client
+	// Reader reads Secrets. It must not be the manager's cache, whose Secret
+	// informer is scoped to WildcardSecret's replicas.
+	Reader client.Reader
+}
+
+// sync is the synchronization a Secret should have.
+type sync struct {
+	direction string
+	path      string
+}
+
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;patch
+// +kubebuilder:rbac:groups=external-secrets.io,resources=secretstores,verbs=get;list;watch
+// +kubebuilder:rbac:groups=external-secrets.io,resources=externalsecrets;pushsecrets,verbs=get;list;watch;create;update;patch;delete
+
+// Reconcile syncs one Secret.
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	sec := &corev1.Secret{}
+	if err := r.Reader.Get(ctx, req.NamespacedName, sec); err != nil {
+		// The external-secrets objects of a deleted Secret are garbage
+		// collected with it.
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if sec.DeletionTimestamp != nil {
+		return ctrl.Result{}, nil
+	}
+
+	store := newObject(secretStoreGVK)
+	if err := r.Client.Get(ctx, types.NamespacedName{Namespace: sec.Namespace, Name: corev1alpha1.TenantSecretStoreName}, store); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return ctrl.Result{}, fmt.Errorf("get SecretStore: %w", err)
+		}
+		store = nil
+	}
+	want := desiredSync(sec, store)
+
+	var status *corev1alpha1.TenantSecretSyncStatus
+	for _, gvk := range []schema.GroupVersionKind{externalSecretGVK, pushSecretGVK} {
+		var desired *unstructured.Unstructured
+		switch {
+		case want == nil:
+		case gvk == externalSecretGVK && want.direction == corev1alpha1.TenantSecretSyncPull:
+			desired = externalSecret(sec, want.path)
+		case gvk == pushSecretGVK && want.direction == corev1alpha1.TenantSecretSyncPush:
+			desired = pushSecret(sec, want.path)
+		}
+		current, err := r.apply(ctx, sec, gvk, desired)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if desired != nil {
+			status = syncStatusOf(current)
+			status.Direction = want.direction
+			status.Path = want.path
+		}
+	}
+	return ctrl.Result{}, r.writeStatus(ctx, sec, status)
+}
+
+// desiredSync decides how sec syncs with store, or returns nil when it does
+// not.
+func desiredSync(sec *corev1.Secret, store *unstructured.Unstructured) *sync {
+	if store == nil || sec.Labels[tenantResourceLabel] != "true" {
+		return nil
+	}
+	// The previous versions of a rotated TenantSecret stay in the cluster.
+	if _, ok := sec.Labels[corev1alpha1.TenantSecretVersionOfLabel]; ok {
+		return nil
+	}
+	kind := sec.Labels[appsv1alpha1.ApplicationKindLabel]
+	if path := sec.Annotations[corev1alpha1.TenantSecretExternalPathAnnotation]; path != "" && kind == "" {
+		return &sync{direction: corev1alpha1.TenantSecretSyncPull, path: path}
+	}
+	if kind == "" || !mirrors(store.GetAnnotations()[corev1alpha1.TenantSecretStoreMirrorAnnotation], kind) {
+		return nil
+	}
+	path := sec.Name
+	if prefix := strings.Trim(store.GetAnnotations()[corev1alpha1.TenantSecretStorePathAnnotation], "/"); prefix != "" {
+		path = prefix + "/" + path
+	}
+	return &sync{direction: corev1alpha1.TenantSecretSyncPush, path: path}
+}
+
+// mirrors reports whether the comma-separated kinds of a SecretStore's mirror
+// annotation include kind; "*" mirrors every kind.
+func mirrors(kinds, kind string) bool {
+	for _, k := range strings.Split(kinds, ",") {
+		if k = strings.TrimSpace(k); k == "*" || k == kind {
+			return true
+		}
+	}
+	return false
+}
+
+func newObject(gvk schema.GroupVersionKind) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(gvk)
+	return obj
+}
+
+// ownedBy returns an object of kind gvk named after sec and controlled by it.
+func ownedBy(sec *corev1.Secret, gvk schema.GroupVersionKind) *unstructured.Unstructured {
+	obj := newObject(gvk)
+	obj.SetNamespace(sec.Namespace)
+	obj.SetName(sec.Name)
+	obj.SetOwnerReferences([]metav1.OwnerReference{{
+		APIVersion:         "v1",
+		Kind:               "Secret",
+		Name:               sec.Name,
+		UID:                sec.UID,
+		Controller:         ptr.To(true),
+		BlockOwnerDeletion: ptr.To(true),
+	}})
+	return obj
+}
+
+// externalSecret pulls the KV path into sec, leaving the keys it does not
+// hold alone.
+func externalSecret(sec *corev1.Secret, path string) *unstructured.Unstructured {
+	obj := ownedBy(sec, externalSecretGVK)
+	obj.Object["spec"] = map[string]interface{}{
+		"refreshInterval": refreshInterval,
+		"secretStoreRef": map[string]interface{}{
+			"kind": secretStoreGVK.Kind,
+			"name": corev1alpha1.TenantSecretStoreName,
+		},
+		"target": map[string]interface{}{
+			"name":           sec.Name,
+			"creationPolicy": "Merge",
+		},
+		"dataFrom": []interface{}{
+			map[string]interface{}{"extract": map[string]interface{}{"key": path}},
+		},
+	}
+	return obj
+}
+
+// pushSecret writes every key of sec to the KV path, and deletes it from the
+// store when it stops being mirrored.
+func pushSecret(sec *corev1.Secret, path string) *unstructured.Unstructured {
+	obj := ownedBy(sec, pushSecretGVK)
+	obj.Object["spec"] = map[string]interface{}{
+		"refreshInterval": refreshInterval,
+		"deletionPolicy":  "Delete",
+		"secretStoreRefs": []interface{}{
+			map[string]interface{}{
+				"kind": secretStoreGVK.Kind,
+				"name": corev1alpha1.TenantSecretStoreName,
+			},
+		},
+		"selector": map[string]interface{}{
+			"secret": map[string]interface{}{"name": sec.Name},
+		},
+		"data": []interface{}{
+			map[string]interface{}{
+				"match": map[string]interface{}{
+					"remoteRef": map[string]interface{}{"remoteKey": path},
+				},
+			},
+		},
+	}
+	return obj
+}
+
+// apply makes the object of kind gvk named after sec match desired, or
+// deletes it when desired is nil. It never touches an object sec does not
+// control. It returns the object as it is in the cluster.
+func (r *Reconciler) apply(ctx context.Context, sec *corev1.Secret, gvk schema.GroupVersionKind, desired *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	current := newObject(gvk)
+	err := r.Client.Get(ctx, types.NamespacedName{Namespace: sec.Namespace, Name: sec.Name}, current)
+	switch {
+	case apierrors.IsNotFound(err):
+		if desired == nil {
+			return nil, nil
+		}
+		if err := r.Client.Create(ctx, desired); err != nil {
+			return nil, fmt.Errorf("create %s %s/%s: %w", gvk.Kind, sec.Namespace, sec.Name, err)
+		}
+		return desired, nil
+	case err != nil:
+		return nil, fmt.Errorf("get %s %s/%s: %w", gvk.Kind, sec.Namespace, sec.Name, err)
+	}
+
+	ref := metav1.GetControllerOf(current)
+	if ref == nil || ref.UID != sec.UID {
+		if desired == nil {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("%s %s/%s exists and is not managed for the Secret", gvk.Kind, sec.Namespace, sec.Name)
+	}
+	if desired == nil {
+		if err := r.Client.Delete(ctx, current); client.IgnoreNotFound(err) != nil {
+			return nil, fmt.Errorf("delete %s %s/%s: %w", gvk.Kind, sec.Namespace, sec.Name, err)
+		}
+		return nil, nil
+	}
+	if reflect.DeepEqual(current.Object["spec"], desired.Object["spec"]) {
+		return current, nil
+	}
+	current.Object["spec"] = desired.Object["spec"]
+	if err := r.Client.Update(ctx, current); err != nil {
+		return nil, fmt.Errorf("update %s %s/%s: %w", gvk.Kind, sec.Namespace, sec.Name, err)
+	}
+	return current, nil
+}
+
+// syncStatusOf reads the phase of a sync from the Ready condition of its
+// external-secrets object.
+func syncStatusOf(obj *unstructured.Unstructured) *corev1alpha1.TenantSecretSyncStatus {
+	out := &corev1alpha1.TenantSecretSyncStatus{Phase: corev1alpha1.TenantSecretSyncPending}
+	conditions, _, _ := unstructured.NestedSlice(obj.Object, "status", "conditions")
+	for _, c := range conditions {
+		cond, ok := c.(map[string]interface{})
+		if !ok || cond["type"] != "Ready" {
+			continue
+		}
+		switch cond["status"] {
+		case string(metav1.ConditionTrue):
+			out.Phase = corev1alpha1.TenantSecretSyncSynced
+		case string(metav1.ConditionFalse):
+			out.Phase = corev1alpha1.TenantSecretSyncFailed
+			out.Message, _ = cond["message"].(string)
+		}
+		if at, ok := cond["lastTransitionTime"].(string); ok {
+			if t, err := time.Parse(time.RFC3339, at); err == nil {
+				out.LastSyncTime = &metav1.Time{Time: t}
+			}
+		}
+	}
+	if len(out.Message) > syncMessageLimit {
+		out.Message = out.Message[:syncMessageLimit]
+	}
+	return out
+}
+
+// writeStatus records status in the sync annotations of sec, or removes them
+// when status is nil.
+func (r *Reconciler) writeStatus(ctx context.Context, sec *corev1.Secret, status *corev1alpha1.TenantSecretSyncStatus) error {
+	want := map[string]string{}
+	if status != nil {
+		want[corev1alpha1.TenantSecretSyncDirectionAnnotation] = status.Direction
+		want[corev1alpha1.TenantSecretSyncPathAnnotation] = status.Path
+		want[corev1alpha1.TenantSecretSyncPhaseAnnotation] = status.Phase
+		want[corev1alpha1.TenantSecretSyncMessageAnnotation] = status.Message
+		if status.LastSyncTime != nil {
+			want[corev1alpha1.TenantSecretSyncedAtAnnotation] = status.LastSyncTime.UTC().Format(time.RFC3339)
+		}
+	}
+	updated := sec.DeepCopy()
+	for _, key := range syncAnnotations {
+		if v := want[key]; v != "" {
+			if updated.Annotations == nil {
+				updated.Annotations = map[string]string{}
+			}
+			updated.Annotations[key] = v
+		} else {
+			delete(updated.Annotations, key)
+		}
+	}
+	if reflect.DeepEqual(updated.Annotations, sec.Annotations) {
+		return nil
+	}
+	if err := r.Client.Patch(ctx, updated, client.MergeFrom(sec)); err != nil {
+		return fmt.Errorf("record sync status of Secret %s/%s: %w", sec.Namespace, sec.Name, err)
+	}
+	return nil
+}
+
+// syncAnnotations are the annotations writeStatus owns.
+var syncAnnotations = []string{
+	corev1alpha1.TenantSecretSyncDirectionAnnotation,
+	corev1alpha1.TenantSecretSyncPathAnnotation,
+	corev1alpha1.TenantSecretSyncPhaseAnnotation,
+	corev1alpha1.TenantSecretSyncMessageAnnotation,
+	corev1alpha1.TenantSecretSyncedAtAnnotation,
+}
+
+// Served reports whether the external-secrets objects this controller writes
+// are served by the cluster.
+func Served(mapper apimeta.RESTMapper) (bool, error) {
+	for _, gvk := range []schema.GroupVersionKind{secretStoreGVK, externalSecretGVK, pushSecretGVK} {
+		if _, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version); err != nil {
+			if apimeta.IsNoMatchError(err) {
+				return false, nil
+			}
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// SetupWithManager wires the reconciler to Secrets, watched as metadata on
+// secretMetaCache, for the reason main.go gives for the CA source cache, and
+// to the external-secrets objects on the manager's cache.
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager, secretMetaCache cache.Cache) error {
+	secretMeta := &metav1.PartialObjectMetadata{}
+	secretMeta.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("Secret"))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("secretsync").
+		WatchesRawSource(crsource.Kind(secretMetaCache, secretMeta,
+			&handler.TypedEnqueueRequestForObject[*metav1.PartialObjectMetadata]{},
+			predicate.NewTypedPredicateFuncs(syncCandidate),
+		)).
+		Watches(newObject(externalSecretGVK), handler.EnqueueRequestsFromMapFunc(ownerSecret)).
+		Watches(newObject(pushSecretGVK), handler.EnqueueRequestsFromMapFunc(ownerSecret)).
+		Watches(newObject(secretStoreGVK), handler.EnqueueRequestsFromMapFunc(r.secretsForStore(secretMetaCache))).
+		Complete(r)
+}
+
+// syncCandidate keeps the Secret events that can start, change or end a sync.
+func syncCandidate(obj *metav1.PartialObjectMetadata) bool {
+	return obj.GetLabels()[tenantResourceLabel] == "true" ||
+		obj.GetAnnotations()[corev1alpha1.TenantSecretSyncDirectionAnnotation] != ""
+}
+
+// ownerSecret maps an external-secrets object to the Secret controlling it.
+func ownerSecret(_ context.Context, obj client.Object) []reconcile.Request {
+	ref := metav1.GetControllerOf(obj)
+	if ref == nil || ref.APIVersion != "v1" || ref.Kind != "Secret" {
+		return nil
+	}
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: obj.GetNamespace(), Name: ref.Name}}}
+}
+
+// secretsForStore maps the SecretStore of a namespace to its tenant Secrets,
+// and to those still synced, so a change of its path or mirrored kinds, or its
+// removal, applies at once.
+func (r *Reconciler) secretsForStore(secretMetaCache cache.Cache) handler.MapFunc {
+	return func(ctx context.Context, obj client.Object) []reconcile.Request {
+		if obj.GetName() != corev1alpha1.TenantSecretStoreName {
+			return nil
+		}
+		list := &metav1.PartialObjectMetadataList{}
+		list.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("SecretList"))
+		if err := secretMetaCache.List(ctx, list, client.InNamespace(obj.GetNamespace())); err != nil {
+			log.FromContext(ctx).Error(err, "map SecretStore to its Secrets", "namespace", obj.GetNamespace())
+			return nil
+		}
+		var out []reconcile.Request
+		for i := range list.Items {
+			if syncCandidate(&list.Items[i]) {
+				out = append(out, reconcile.Request{NamespacedName: types.NamespacedName{
+					Namespace: list.Items[i].Namespace, Name: list.Items[i].Name,
+				}})
+			}
+		}
+		return out
+	}
+}
@@ -0,0 +1,313 @@
+/*
+Copyright 2026 The Cozystack Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secretsync
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	appsv1alpha1 "github.com/cozystack/cozystack/pkg/apis/apps/v1alpha1"
+	corev1alpha1 "github.com/cozystack/cozystack/pkg/apis/core/v1alpha1"
+)
+
+const testNamespace = "tenant-foo"
+
+func tenantSecret(name string, labels, annotations map[string]string) *corev1.Secret {
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	labels[tenantResourceLabel] = "true"
+	return &corev1.Secret{ObjectMeta: metav1.ObjectMeta{
+		Namespace:   testNamespace,
+		Name:        name,
+		UID:         types.UID(name + "-uid"),
+		Labels:      labels,
+		Annotations: annotations,
+	}}
+}
+
+func store(mirror string) *unstructured.Unstructured {
+	obj := newObject(secretStoreGVK)
+	obj.SetNamespace(testNamespace)
+	obj.SetName(corev1alpha1.TenantSecretStoreName)
+	obj.SetAnnotations(map[string]string{
+		corev1alpha1.TenantSecretStorePathAnnotation:   "cozystack/",
+		corev1alpha1.TenantSecretStoreMirrorAnnotation: mirror,
+	})
+	return obj
+}
+
+func newTestReconciler(t *testing.T, objs ...client.Object) *Reconciler {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	if err := corev1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+	return &Reconciler{Client: c, Reader: c}
+}
+
+func reconcileSecret(t *testing.T, r *Reconciler, name string) {
+	t.Helper()
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: testNamespace, Name: name}}
+	if _, err := r.Reconcile(context.Background(), req); err != nil {
+		t.Fatalf("Reconcile %s: %v", name, err)
+	}
+}
+
+func getObject(t *testing.T, r *Reconciler, obj client.Object, name string) error {
+	t.Helper()
+	return r.Client.Get(context.Background(), types.NamespacedName{Namespace: testNamespace, Name: name}, obj)
+}
+
+func TestReconcile_PushesMirroredCredentials(t *testing.T) {
+	creds := tenantSecret("postgres-db-credentials", map[string]string{
+		appsv1alpha1.ApplicationKindLabel: "Postgres",
+		appsv1alpha1.ApplicationNameLabel: "db",
+	}, nil)
+	redis := tenantSecret("redis-cache-auth", map[string]string{
+		appsv1alpha1.ApplicationKindLabel: "Redis",
+		appsv1alpha1.ApplicationNameLabel: "cache",
+	}, nil)
+	r := newTestReconciler(t, store("Postgres, MySQL"), creds, redis)
+
+	reconcileSecret(t, r, "postgres-db-credentials")
+	ps := newObject(pushSecretGVK)
+	if err := getObject(t, r, ps, "postgres-db-credentials"); err != nil {
+		t.Fatalf("PushSecret: %v", err)
+	}
+	data, _, _ := unstructured.NestedSlice(ps.Object, "spec", "data")
+	if len(data) != 1 {
+		t.Fatalf("data = %v", data)
+	}
+	if key, _, _ := unstructured.NestedString(data[0].(map[string]interface{}), "match", "remoteRef", "remoteKey"); key != "cozystack/postgres-db-credentials" {
+		t.Errorf("remoteKey = %q", key)
+	}
+	if ref := metav1.GetControllerOf(ps); ref == nil || ref.UID != creds.UID {
+		t.Errorf("owner = %v, want the Secret", ref)
+	}
+
+	sec := &corev1.Secret{}
+	if err := getObject(t, r, sec, "postgres-db-credentials"); err != nil {
+		t.Fatal(err)
+	}
+	if sec.Annotations[corev1alpha1.TenantSecretSyncDirectionAnnotation] != corev1alpha1.TenantSecretSyncPush ||
+		sec.Annotations[corev1alpha1.TenantSecretSyncPhaseAnnotation] != corev1alpha1.TenantSecretSyncPending {
+		t.Errorf("annotations = %v, want a pending push", sec.Annotations)
+	}
+
+	// A kind that is not mirrored is left alone.
+	reconcileSecret(t, r, "redis-cache-auth")
+	if err := getObject(t, r, newObject(pushSecretGVK), "redis-cache-auth"); !apierrors.IsNotFound(err) {
+		t.Errorf("PushSecret of Redis: %v, want none", err)
+	}
+}
+
+func TestReconcile_PullsExternalRefAndReportsStatus(t *testing.T) {
+	sec := tenantSecret("api-token", nil, map[string]string{
+		corev1alpha1.TenantSecretExternalPathAnnotation: "apps/api",
+	})
+	r := newTestReconciler(t, store(""), sec)
+	reconcileSecret(t, r, "api-token")
+
+	es := newObject(externalSecretGVK)
+	if err := getObject(t, r, es, "api-token"); err != nil {
+		t.Fatalf("ExternalSecret: %v", err)
+	}
+	if policy, _, _ := unstructured.NestedString(es.Object, "spec", "target", "creationPolicy"); policy != "Merge" {
+		t.Errorf("creationPolicy = %q, want Merge", policy)
+	}
+
+	// external-secrets reports the pull failed.
+	_ = unstructured.SetNestedSlice(es.Object, []interface{}{map[string]interface{}{
+		"type":               "Ready",
+		"status":             "False",
+		"message":            "secret not found at apps/api",
+		"lastTransitionTime": "2026-10-19T12:00:00Z",
+	}}, "status", "conditions")
+	if err := r.Client.Update(context.Background(), es); err != nil {
+		t.Fatal(err)
+	}
+	reconcileSecret(t, r, "api-token")
+	if err := getObject(t, r, sec, "api-token"); err != nil {
+		t.Fatal(err)
+	}
+	if sec.Annotations[corev1alpha1.TenantSecretSyncPhaseAnnotation] != corev1alpha1.TenantSecretSyncFailed ||
+		sec.Annotations[corev1alpha1.TenantSecretSyncMessageAnnotation] != "secret not found at apps/api" ||
+		sec.Annotations[corev1alpha1.TenantSecretSyncedAtAnnotation] != "2026-10-19T12:00:00Z" {
+		t.Errorf("annotations = %v, want the failure", sec.Annotations)
+	}
+
+	// Dropping the reference ends the sync.
+	delete(sec.Annotations, corev1alpha1.TenantSecretExternalPathAnnotation)
+	if err := r.Client.Update(context.Background(), sec); err != nil {
+		t.Fatal(err)
+	}
+	reconcileSecret(t, r, "api-token")
+	if err := getObject(t, r, newObject(externalSecretGVK), "api-token"); !apierrors.IsNotFound(err) {
+		t.Errorf("ExternalSecret: %v, want it deleted", err)
+	}
+	if err := getObject(t, r, sec, "api-token"); err != nil {
+		t.Fatal(err)
+	}
+	for _, key := range syncAnnotations {
+		if _, ok := sec.Annotations[key]; ok {
+			t.Errorf("annotation %s left behind", key)
+		}
+	}
+}
+
+// The ExternalSecret extracts the whole KV path, property-less, through the
+// tenant SecretStore, and merges it into the Secret.
+func TestReconcile_ExternalSecretSpec(t *testing.T) {
+	sec := tenantSecret("api-token", nil, map[string]string{
+		corev1alpha1.TenantSecretExternalPathAnnotation: "apps/api",
+	})
+	r := newTestReconciler(t, store(""), sec)
+	reconcileSecret(t, r, "api-token")
+
+	es := newObject(externalSecretGVK)
+	if err := getObject(t, r, es, "api-token"); err != nil {
+		t.Fatalf("ExternalSecret: %v", err)
+	}
+	want := map[string]interface{}{
+		"refreshInterval": "1h",
+		"secretStoreRef":  map[string]interface{}{"kind": "SecretStore", "name": corev1alpha1.TenantSecretStoreName},
+		"target":          map[string]interface{}{"name": "api-token", "creationPolicy": "Merge"},
+		"dataFrom": []interface{}{
+			map[string]interface{}{"extract": map[string]interface{}{"key": "apps/api"}},
+		},
+	}
+	if !reflect.DeepEqual(es.Object["spec"], want) {
+		t.Errorf("spec = %v\nwant %v", es.Object["spec"], want)
+	}
+}
+
+// The PushSecret writes every key of the Secret, property-less, to
+// "<store path>/<secret name>", and deletes them from the store with it.
+func TestReconcile_PushSecretSpec(t *testing.T) {
+	for _, tc := range []struct {
+		path, remoteKey string
+	}{
+		{"cozystack/", "cozystack/postgres-db-credentials"},
+		{"/tenants/foo/", "tenants/foo/postgres-db-credentials"},
+		{"", "postgres-db-credentials"},
+	} {
+		t.Run(tc.path, func(t *testing.T) {
+			creds := tenantSecret("postgres-db-credentials", map[string]string{
+				appsv1alpha1.ApplicationKindLabel: "Postgres",
+				appsv1alpha1.ApplicationNameLabel: "db",
+			}, nil)
+			st := store("Postgres")
+			st.SetAnnotations(map[string]string{
+				corev1alpha1.TenantSecretStorePathAnnotation:   tc.path,
+				corev1alpha1.TenantSecretStoreMirrorAnnotation: "Postgres",
+			})
+			r := newTestReconciler(t, st, creds)
+			reconcileSecret(t, r, "postgres-db-credentials")
+
+			ps := newObject(pushSecretGVK)
+			if err := getObject(t, r, ps, "postgres-db-credentials"); err != nil {
+				t.Fatalf("PushSecret: %v", err)
+			}
+			want := map[string]interface{}{
+				"refreshInterval": "1h",
+				"deletionPolicy":  "Delete",
+				"secretStoreRefs": []interface{}{
+					map[string]interface{}{"kind": "SecretStore", "name": corev1alpha1.TenantSecretStoreName},
+				},
+				"selector": map[string]interface{}{
+					"secret": map[string]interface{}{"name": "postgres-db-credentials"},
+				},
+				"data": []interface{}{
+					map[string]interface{}{
+						"match": map[string]interface{}{
+							"remoteRef": map[string]interface{}{"remoteKey": tc.remoteKey},
+						},
+					},
+				},
+			}
+			if !reflect.DeepEqual(ps.Object["spec"], want) {
+				t.Errorf("spec = %v\nwant %v", ps.Object["spec"], want)
+			}
+		})
+	}
+}
+
+// A kind the store stops mirroring loses its PushSecret, whose Delete
+// policy removes the credentials from the store.
+func TestReconcile_StopsMirroring(t *testing.T) {
+	creds := tenantSecret("postgres-db-credentials", map[string]string{
+		appsv1alpha1.ApplicationKindLabel: "Postgres",
+		appsv1alpha1.ApplicationNameLabel: "db",
+	}, nil)
+	st := store("Postgres")
+	r := newTestReconciler(t, st, creds)
+	reconcileSecret(t, r, "postgres-db-credentials")
+	if err := getObject(t, r, newObject(pushSecretGVK), "postgres-db-credentials"); err != nil {
+		t.Fatalf("PushSecret: %v", err)
+	}
+
+	if err := getObject(t, r, st, corev1alpha1.TenantSecretStoreName); err != nil {
+		t.Fatal(err)
+	}
+	annotations := st.GetAnnotations()
+	annotations[corev1alpha1.TenantSecretStoreMirrorAnnotation] = "MySQL"
+	st.SetAnnotations(annotations)
+	if err := r.Client.Update(context.Background(), st); err != nil {
+		t.Fatal(err)
+	}
+	reconcileSecret(t, r, "postgres-db-credentials")
+	if err := getObject(t, r, newObject(pushSecretGVK), "postgres-db-credentials"); !apierrors.IsNotFound(err) {
+		t.Errorf("PushSecret: %v, want it deleted", err)
+	}
+	sec := &corev1.Secret{}
+	if err := getObject(t, r, sec, "postgres-db-credentials"); err != nil {
+		t.Fatal(err)
+	}
+	if dir := sec.Annotations[corev1alpha1.TenantSecretSyncDirectionAnnotation]; dir != "" {
+		t.Errorf("sync direction = %q, want the sync ended", dir)
+	}
+}
+
+func TestReconcile_LeavesForeignObjects(t *testing.T) {
+	sec := tenantSecret("api-token", nil, map[string]string{
+		corev1alpha1.TenantSecretExternalPathAnnotation: "apps/api",
+	})
+	foreign := newObject(externalSecretGVK)
+	foreign.SetNamespace(testNamespace)
+	foreign.SetName("api-token")
+	r := newTestReconciler(t, sec, foreign)
+
+	// Without a SecretStore nothing syncs, and the ExternalSecret the tenant
+	// made themselves is kept.
+	reconcileSecret(t, r, "api-token")
+	if err := getObject(t, r, newObject(externalSecretGVK), "api-token"); err != nil {
+		t.Errorf("foreign ExternalSecret: %v, want it kept", err)
+	}
+}
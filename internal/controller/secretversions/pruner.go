@@ -0,0 +1,100 @@
+/*
+Copyright 2026 The Cozystack Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package secretversions deletes the previous versions of rotated
+// TenantSecrets once their grace period is over.
+//
+// Rotating a TenantSecret through cozystack-api keeps its previous values as a
+// Secret labelled with the name of the TenantSecret and annotated with when it
+// expires. cozystack-api only prunes them on the next rotation, which may
+// never come, so the Pruner deletes expired versions periodically.
+package secretversions
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	corev1alpha1 "github.com/cozystack/cozystack/pkg/apis/core/v1alpha1"
+)
+
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=list;delete
+
+// Pruner deletes expired TenantSecret versions every Interval.
+type Pruner struct {
+	// Client deletes the versions.
+	Client client.Client
+	// Reader lists the versions. It must not be the manager's cache, whose
+	// Secret informer is scoped to other Secrets.
+	Reader client.Reader
+	// Interval is the time between two passes.
+	Interval time.Duration
+	// Now returns the current time; time.Now when nil.
+	Now func() time.Time
+}
+
+// Start implements manager.Runnable.
+func (p *Pruner) Start(ctx context.Context) error {
+	ticker := time.NewTicker(p.Interval)
+	defer ticker.Stop()
+	for {
+		if err := p.Prune(ctx); err != nil {
+			log.FromContext(ctx).Error(err, "failed to prune TenantSecret versions")
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable.
+func (p *Pruner) NeedLeaderElection() bool {
+	return true
+}
+
+// Prune deletes the versions whose expiry has passed. Versions without a
+// valid expiry are left alone.
+func (p *Pruner) Prune(ctx context.Context) error {
+	versions := &corev1.SecretList{}
+	if err := p.Reader.List(ctx, versions, client.HasLabels{corev1alpha1.TenantSecretVersionOfLabel}); err != nil {
+		return fmt.Errorf("list TenantSecret versions: %w", err)
+	}
+	now := p.now()
+	for i := range versions.Items {
+		sec := &versions.Items[i]
+		expiresAt, err := time.Parse(time.RFC3339, sec.Annotations[corev1alpha1.TenantSecretExpiresAtAnnotation])
+		if err != nil || now.Before(expiresAt) {
+			continue
+		}
+		if err := p.Client.Delete(ctx, sec); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("delete TenantSecret version %s/%s: %w", sec.Namespace, sec.Name, err)
+		}
+	}
+	return nil
+}
+
+func (p *Pruner) now() time.Time {
+	if p.Now != nil {
+		return p.Now()
+	}
+	return time.Now()
+}
@@ -0,0 +1,79 @@
+/*
+Copyright 2026 The Cozystack Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secretversions
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	corev1alpha1 "github.com/cozystack/cozystack/pkg/apis/core/v1alpha1"
+)
+
+func secret(name string, labels, annotations map[string]string) *corev1.Secret {
+	return &corev1.Secret{ObjectMeta: metav1.ObjectMeta{
+		Namespace:   "tenant-foo",
+		Name:        name,
+		Labels:      labels,
+		Annotations: annotations,
+	}}
+}
+
+func TestPruneDeletesExpiredVersions(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	versionOf := map[string]string{corev1alpha1.TenantSecretVersionOfLabel: "db-credentials"}
+	expiring := func(at time.Time) map[string]string {
+		return map[string]string{corev1alpha1.TenantSecretExpiresAtAnnotation: at.Format(time.RFC3339)}
+	}
+	scheme := runtime.NewScheme()
+	if err := corev1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		secret("db-credentials.v1", versionOf, expiring(now.Add(-time.Minute))),
+		secret("db-credentials.v2", versionOf, expiring(now.Add(time.Hour))),
+		secret("db-credentials.v0", versionOf, nil),
+		// Not a version, whatever its annotations say.
+		secret("other", nil, expiring(now.Add(-time.Hour))),
+	).Build()
+
+	p := &Pruner{Client: c, Reader: c, Now: func() time.Time { return now }}
+	if err := p.Prune(context.Background()); err != nil {
+		t.Fatalf("Prune: %v", err)
+	}
+	for name, kept := range map[string]bool{
+		"db-credentials.v1": false,
+		"db-credentials.v2": true,
+		"db-credentials.v0": true,
+		"other":             true,
+	} {
+		err := c.Get(context.Background(), types.NamespacedName{Namespace: "tenant-foo", Name: name}, &corev1.Secret{})
+		if kept && err != nil {
+			t.Errorf("%s: %v, want it kept", name, err)
+		}
+		if !kept && !apierrors.IsNotFound(err) {
+			t.Errorf("%s: %v, want it deleted", name, err)
+		}
+	}
+}
@@ -0,0 +1,395 @@
+/*
+Copyright 2026 The Cozystack Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tenantgateway
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	gatewayv1alpha1 "github.com/cozystack/cozystack/api/gateway/v1alpha1"
+)
+
+// Cilium serves every Gateway from the Envoy of each node, under the
+// node-wide reserved:ingress identity: a network policy on that
+// identity would filter the Gateways of every tenant at once. The
+// per-Gateway enforcement point Cilium does offer is the source range
+// check of the LoadBalancer Service it generates for each Gateway, so
+// the IP lists of a gateway-wide TenantGatewayPolicy are rendered into
+// a CiliumGatewayClassConfig the Gateway references through
+// spec.infrastructure.parametersRef.
+const (
+	// ciliumGatewayControllerName is the controllerName Cilium
+	// registers its GatewayClasses under.
+	ciliumGatewayControllerName = "io.cilium/gateway-controller"
+
+	// ciliumGatewayServicePrefix prefixes the name of the Service
+	// Cilium generates for a Gateway.
+	ciliumGatewayServicePrefix = "cilium-gateway-"
+
+	// ciliumSourceRangesPolicyAnnotation switches the source ranges of
+	// a Service from an allow list to a deny list.
+	ciliumSourceRangesPolicyAnnotation = "service.cilium.io/src-ranges-policy"
+
+	sourceRangesAllow = "Allow"
+	sourceRangesDeny  = "Deny"
+
+	// policyFiltersWholeGatewayCondition warns that a policy's source
+	// ranges reach listeners the gateway-wide policy leaves alone on
+	// Envoy Gateway.
+	policyFiltersWholeGatewayCondition = "FiltersWholeGateway"
+)
+
+var ciliumGatewayClassConfigGVK = schema.GroupVersionKind{Group: "cilium.io", Version: "v2alpha1", Kind: "CiliumGatewayClassConfig"}
+
+// ciliumGatewayConfigName is the name of the CiliumGatewayClassConfig
+// carrying tgw's source ranges.
+func ciliumGatewayConfigName(tgw *gatewayv1alpha1.TenantGateway) string {
+	return tgw.Name + "-source-ranges"
+}
+
+// ciliumGatewayServiceName is the name of the Service Cilium
+// generates for tgw's Gateway.
+func ciliumGatewayServiceName(tgw *gatewayv1alpha1.TenantGateway) string {
+	return ciliumGatewayServicePrefix + tgw.Name
+}
+
+// sourceRanges is the source range filter of a Gateway's Service: the
+// CIDRs and whether they are allowed or denied.
+type sourceRanges struct {
+	cidrs  []string
+	policy string
+}
+
+// policySourceRanges folds a policy's IP lists into the one list a
+// Service takes. A deny list alone is a Deny filter; an allow list is
+// an Allow filter with the denied CIDRs cut out of it, since the deny
+// list takes precedence. An allow list the deny list covers entirely
+// admits nobody, which an Allow filter cannot say: it becomes a Deny
+// filter on every address.
+func policySourceRanges(p *gatewayv1alpha1.TenantGatewayPolicy) (*sourceRanges, error) {
+	allow, err := parsePrefixes(p.Spec.IPAllowList)
+	if err != nil {
+		return nil, fmt.Errorf("ipAllowList: %w", err)
+	}
+	deny, err := parsePrefixes(p.Spec.IPDenyList)
+	if err != nil {
+		return nil, fmt.Errorf("ipDenyList: %w", err)
+	}
+	switch {
+	case len(allow) == 0 && len(deny) == 0:
+		return nil, nil
+	case len(allow) == 0:
+		return &sourceRanges{cidrs: formatPrefixes(deny), policy: sourceRangesDeny}, nil
+	}
+	for _, d := range deny {
+		var rest []netip.Prefix
+		for _, a := range allow {
+			rest = append(rest, subtractPrefix(a, d)...)
+		}
+		allow = rest
+	}
+	if len(allow) == 0 {
+		return &sourceRanges{cidrs: []string{"0.0.0.0/0", "::/0"}, policy: sourceRangesDeny}, nil
+	}
+	return &sourceRanges{cidrs: formatPrefixes(allow), policy: sourceRangesAllow}, nil
+}
+
+func parsePrefixes(cidrs []string) ([]netip.Prefix, error) {
+	out := make([]netip.Prefix, 0, len(cidrs))
+	for _, c := range cidrs {
+		p, err := netip.ParsePrefix(c)
+		if err != nil {
+			return nil, err
+		}
+		if p.Addr().Is4In6() && p.Bits() >= 96 {
+			p = netip.PrefixFrom(p.Addr().Unmap(), p.Bits()-96)
+		}
+		out = append(out, p.Masked())
+	}
+	return out, nil
+}
+
+// formatPrefixes returns the sorted, deduplicated string forms.
+func formatPrefixes(prefixes []netip.Prefix) []string {
+	seen := map[string]struct{}{}
+	out := make([]string, 0, len(prefixes))
+	for _, p := range prefixes {
+		s := p.String()
+		if _, dup := seen[s]; dup {
+			continue
+		}
+		seen[s] = struct{}{}
+		out = append(out, s)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// subtractPrefix returns the prefixes covering a but not d. a is
+// halved until a half is d; the other halves are kept.
+func subtractPrefix(a, d netip.Prefix) []netip.Prefix {
+	if a.Addr().Is4() != d.Addr().Is4() || !a.Overlaps(d) {
+		return []netip.Prefix{a}
+	}
+	if d.Bits() <= a.Bits() {
+		return nil
+	}
+	var out []netip.Prefix
+	for a.Bits() < d.Bits() {
+		lo := netip.PrefixFrom(a.Addr(), a.Bits()+1)
+		hi := netip.PrefixFrom(setBit(a.Addr(), a.Bits()), a.Bits()+1)
+		if lo.Contains(d.Addr()) {
+			out = append(out, hi)
+			a = lo
+		} else {
+			out = append(out, lo)
+			a = hi
+		}
+	}
+	return out
+}
+
+// setBit returns addr with bit i, counted from the most significant,
+// set.
+func setBit(addr netip.Addr, i int) netip.Addr {
+	b := addr.AsSlice()
+	b[i/8] |= 0x80 >> (i % 8)
+	out, _ := netip.AddrFromSlice(b)
+	return out
+}
+
+// ciliumInfrastructure returns the infrastructure block pointing tgw's
+// Gateway at its CiliumGatewayClassConfig, or nil when the plan has no
+// source ranges.
+func (p *policyPlan) ciliumInfrastructure(tgw *gatewayv1alpha1.TenantGateway) *gatewayv1.GatewayInfrastructure {
+	if p.sourceRanges == nil {
+		return nil
+	}
+	return &gatewayv1.GatewayInfrastructure{
+		ParametersRef: &gatewayv1.LocalParametersReference{
+			Group: gatewayv1.Group(ciliumGatewayClassConfigGVK.Group),
+			Kind:  gatewayv1.Kind(ciliumGatewayClassConfigGVK.Kind),
+			Name:  ciliumGatewayConfigName(tgw),
+		},
+	}
+}
+
+type ciliumGatewayClassConfigSpec struct {
+	Service ciliumServiceConfig `json:"service"`
+}
+
+type ciliumServiceConfig struct {
+	LoadBalancerSourceRanges       []string `json:"loadBalancerSourceRanges"`
+	LoadBalancerSourceRangesPolicy string   `json:"loadBalancerSourceRangesPolicy"`
+}
+
+// applyCiliumGatewayConfig renders the CiliumGatewayClassConfig of the
+// plan's source ranges. It runs before the Gateway is rendered, so the
+// Gateway never references a config that does not exist yet; the
+// config of a plan without source ranges is deleted once the Gateway
+// no longer references it, by deleteCiliumGatewayConfig.
+func (r *Reconciler) applyCiliumGatewayConfig(ctx context.Context, tgw *gatewayv1alpha1.TenantGateway, plan *policyPlan) error {
+	if plan.sourceRanges == nil {
+		return nil
+	}
+	spec := ciliumGatewayClassConfigSpec{Service: ciliumServiceConfig{
+		LoadBalancerSourceRanges:       plan.sourceRanges.cidrs,
+		LoadBalancerSourceRangesPolicy: plan.sourceRanges.policy,
+	}}
+	desired, err := r.renderOwnedObject(tgw, ciliumGatewayClassConfigGVK, ciliumGatewayConfigName(tgw), &spec)
+	if err != nil {
+		return err
+	}
+	return r.applyOwnedObject(ctx, tgw, desired)
+}
+
+// deleteCiliumGatewayConfig deletes tgw's CiliumGatewayClassConfig
+// when the plan has no source ranges.
+func (r *Reconciler) deleteCiliumGatewayConfig(ctx context.Context, tgw *gatewayv1alpha1.TenantGateway, plan *policyPlan) error {
+	if plan.sourceRanges != nil {
+		return nil
+	}
+	err := r.deleteOwnedObject(ctx, tgw, ciliumGatewayClassConfigGVK, ciliumGatewayConfigName(tgw))
+	// Without Cilium's CRDs there is nothing to delete.
+	if meta.IsNoMatchError(err) {
+		return nil
+	}
+	return err
+}
+
+// ciliumSourceRangesApplied reports whether the Service Cilium
+// generated for tgw's Gateway filters on the plan's source ranges.
+// Cilium applies them asynchronously, and a Cilium version that does
+// not read the Gateway's parametersRef never does; until then the
+// policy is not reported as accepted.
+func (r *Reconciler) ciliumSourceRangesApplied(ctx context.Context, tgw *gatewayv1alpha1.TenantGateway, want *sourceRanges) (bool, error) {
+	svc := &corev1.Service{}
+	err := r.Get(ctx, types.NamespacedName{Namespace: tgw.Namespace, Name: ciliumGatewayServiceName(tgw)}, svc)
+	if apierrors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("get Service %s: %w", ciliumGatewayServiceName(tgw), err)
+	}
+	got, err := parsePrefixes(svc.Spec.LoadBalancerSourceRanges)
+	if err != nil {
+		return false, nil
+	}
+	deny := strings.EqualFold(svc.Annotations[ciliumSourceRangesPolicyAnnotation], sourceRangesDeny)
+	if deny != (want.policy == sourceRangesDeny) {
+		return false, nil
+	}
+	return strings.Join(formatPrefixes(got), ",") == strings.Join(want.cidrs, ","), nil
+}
+
+// ciliumUnenforcedFields lists the fields of p the Cilium path does
+// not enforce: everything but the IP lists, and the IP lists too on a
+// policy naming hostnames, since the Service filter cannot tell
+// hostnames apart.
+func ciliumUnenforcedFields(p *gatewayv1alpha1.TenantGatewayPolicy) []string {
+	var fields []string
+	if len(p.Spec.Hostnames) > 0 {
+		if len(p.Spec.IPAllowList) > 0 {
+			fields = append(fields, "ipAllowList")
+		}
+		if len(p.Spec.IPDenyList) > 0 {
+			fields = append(fields, "ipDenyList")
+		}
+	}
+	if p.Spec.RateLimit != nil {
+		fields = append(fields, "rateLimit")
+	}
+	if p.Spec.MaxRequestBodySize != nil {
+		fields = append(fields, "maxRequestBodySize")
+	}
+	if p.Spec.BasicAuth != nil {
+		fields = append(fields, "basicAuth")
+	}
+	if p.Spec.OIDC != nil {
+		fields = append(fields, "oidc")
+	}
+	return fields
+}
+
+// ciliumPolicyCondition fills in the Accepted condition of a policy on
+// a Cilium class and returns the hostnames it is in effect for.
+func ciliumPolicyCondition(tgw *gatewayv1alpha1.TenantGateway, plan *policyPlan, planned *plannedPolicy, applied bool, cond *metav1.Condition) []string {
+	p := planned.policy
+	enforcing := plan.sourceRanges != nil && plan.sourceRangesPolicy == p
+	switch {
+	case len(planned.unresolved) > 0:
+		cond.Status = metav1.ConditionFalse
+		cond.Reason = "HostnameNotFound"
+		cond.Message = fmt.Sprintf("TenantGateway %s does not serve %s", tgw.Name, strings.Join(planned.unresolved, ", "))
+	case planned.conflicted && len(p.Spec.Hostnames) == 0:
+		cond.Status = metav1.ConditionFalse
+		cond.Reason = "Conflicted"
+		cond.Message = fmt.Sprintf("An older TenantGatewayPolicy already applies gateway-wide to TenantGateway %s", tgw.Name)
+	case planned.conflicted:
+		cond.Status = metav1.ConditionFalse
+		cond.Reason = "Conflicted"
+		cond.Message = "Some hostnames are already covered by an older TenantGatewayPolicy"
+	case len(ciliumUnenforcedFields(p)) > 0:
+		cond.Status = metav1.ConditionFalse
+		cond.Reason = "UnsupportedField"
+		cond.Message = fmt.Sprintf("GatewayClass %s is implemented by Cilium, which enforces only ipAllowList and ipDenyList, and only on a policy without hostnames; not enforced: %s", gatewayClassName(tgw), strings.Join(ciliumUnenforcedFields(p), ", "))
+	case enforcing && !applied:
+		cond.Status = metav1.ConditionFalse
+		cond.Reason = "Pending"
+		cond.Message = fmt.Sprintf("Waiting for Cilium to apply the source ranges to Service %s/%s", tgw.Namespace, ciliumGatewayServiceName(tgw))
+	}
+	if enforcing && applied {
+		return planned.hostnames
+	}
+	return nil
+}
+
+// servesHTTP01Challenges reports whether ACME HTTP-01 challenges for
+// tgw's certificates arrive on its plain-HTTP listener: in http01 mode,
+// and in dns01 mode for the verified custom domains, which the DNS-01
+// provider's zone does not hold.
+func servesHTTP01Challenges(tgw *gatewayv1alpha1.TenantGateway, customHostnames []string) bool {
+	if !usesACME(tgw) {
+		return false
+	}
+	switch tgw.Spec.CertMode {
+	case gatewayv1alpha1.CertModeHTTP01, "":
+		return true
+	case gatewayv1alpha1.CertModeDNS01:
+		return len(customHostnames) > 0
+	}
+	return false
+}
+
+// ciliumFilteredListeners returns "<name>:<port>" for each listener:
+// Cilium's Service filter cannot tell them apart.
+func ciliumFilteredListeners(listeners []gatewayv1.Listener) []string {
+	out := make([]string, 0, len(listeners))
+	for _, l := range listeners {
+		out = append(out, fmt.Sprintf("%s:%d", l.Name, l.Port))
+	}
+	return out
+}
+
+// ciliumScopeWarning returns the FiltersWholeGateway condition of the
+// policy whose source ranges Cilium enforces, when they reach what the
+// gateway-wide policy would leave alone on Envoy Gateway: the
+// plain-HTTP listener while it answers ACME HTTP-01 challenges, which
+// ACME servers send from addresses they do not publish, and the L4 and
+// TLS-passthrough listeners. It is nil for every other policy and when
+// the Gateway has none of these.
+func ciliumScopeWarning(tgw *gatewayv1alpha1.TenantGateway, plan *policyPlan, planned *plannedPolicy) *metav1.Condition {
+	if plan.sourceRanges == nil || plan.sourceRangesPolicy != planned.policy {
+		return nil
+	}
+	var extra []string
+	for _, l := range plan.listeners {
+		switch {
+		case l.Protocol == gatewayv1.HTTPProtocolType && plan.http01Challenges,
+			l.Protocol == gatewayv1.TLSProtocolType,
+			l.Protocol == gatewayv1.TCPProtocolType,
+			l.Protocol == gatewayv1.UDPProtocolType:
+			extra = append(extra, fmt.Sprintf("%s:%d", l.Name, l.Port))
+		}
+	}
+	if len(extra) == 0 {
+		return nil
+	}
+	cond := &metav1.Condition{
+		Type:               policyFiltersWholeGatewayCondition,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: planned.policy.Generation,
+		Reason:             "NonHTTPSListenersFiltered",
+		Message:            fmt.Sprintf("Cilium applies the source ranges to the whole Service of Gateway %s/%s, beyond the HTTPS listeners: %s", tgw.Namespace, tgw.Name, strings.Join(extra, ", ")),
+	}
+	if plan.http01Challenges {
+		cond.Reason = "HTTP01ChallengesFiltered"
+		cond.Message += "; ACME servers send HTTP-01 challenges from addresses they do not publish, so an allow list can stop certificate issuance (certMode dns01 avoids it)"
+	}
+	return cond
+}
@@ -0,0 +1,262 @@
+/*
+Copyright 2026 The Cozystack Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tenantgateway
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	gatewayv1alpha1 "github.com/cozystack/cozystack/api/gateway/v1alpha1"
+)
+
+func TestPolicySourceRanges(t *testing.T) {
+	cases := []struct {
+		name        string
+		allow, deny []string
+		want        *sourceRanges
+	}{
+		{
+			name: "none",
+		},
+		{
+			name: "deny only",
+			deny: []string{"192.0.2.0/24", "2001:db8::/32"},
+			want: &sourceRanges{cidrs: []string{"192.0.2.0/24", "2001:db8::/32"}, policy: sourceRangesDeny},
+		},
+		{
+			name:  "allow only, normalised",
+			allow: []string{"10.1.2.3/8", "10.0.0.0/8"},
+			want:  &sourceRanges{cidrs: []string{"10.0.0.0/8"}, policy: sourceRangesAllow},
+		},
+		{
+			name:  "deny cut out of allow",
+			allow: []string{"10.0.0.0/8"},
+			deny:  []string{"10.1.0.0/16", "2001:db8::/32"},
+			want: &sourceRanges{cidrs: []string{
+				"10.0.0.0/16", "10.128.0.0/9", "10.16.0.0/12", "10.2.0.0/15",
+				"10.32.0.0/11", "10.4.0.0/14", "10.64.0.0/10", "10.8.0.0/13",
+			}, policy: sourceRangesAllow},
+		},
+		{
+			name:  "allow covered by deny",
+			allow: []string{"10.1.0.0/16"},
+			deny:  []string{"10.0.0.0/8"},
+			want:  &sourceRanges{cidrs: []string{"0.0.0.0/0", "::/0"}, policy: sourceRangesDeny},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			p := tenantGatewayPolicy("edge", time.Now(), gatewayv1alpha1.TenantGatewayPolicySpec{IPAllowList: tc.allow, IPDenyList: tc.deny})
+			got, err := policySourceRanges(p)
+			if err != nil {
+				t.Fatalf("policySourceRanges: %v", err)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("policySourceRanges = %+v, want %+v", got, tc.want)
+			}
+		})
+	}
+}
+
+func ciliumTenantGateway() *gatewayv1alpha1.TenantGateway {
+	tgw := policyTenantGateway(gatewayv1alpha1.CertModeHTTP01)
+	tgw.Spec.GatewayClassName = "cilium"
+	return tgw
+}
+
+// On a Cilium class the IP lists of the gateway-wide policy become the
+// source ranges of the Gateway's Service. The policy is accepted only
+// once Cilium has put them on the Service, and dropping the policy
+// drops the config and the Gateway's reference to it.
+func TestReconcile_PolicyCiliumSourceRanges(t *testing.T) {
+	p := tenantGatewayPolicy("edge", time.Now(), gatewayv1alpha1.TenantGatewayPolicySpec{
+		IPAllowList: []string{"10.0.0.0/8"},
+		IPDenyList:  []string{"10.128.0.0/9"},
+	})
+	c, r := newPolicyReconciler(t, ciliumTenantGateway(), p, gatewayClass("cilium", ciliumGatewayControllerName))
+	reconcileTenantGateway(t, r)
+
+	cfg := renderedEnvoyPolicy(t, c, ciliumGatewayClassConfigGVK, "cozystack-source-ranges")
+	if cfg == nil {
+		t.Fatal("expected CiliumGatewayClassConfig cozystack-source-ranges")
+	}
+	ranges, _, _ := unstructured.NestedStringSlice(cfg.Object, "spec", "service", "loadBalancerSourceRanges")
+	policy, _, _ := unstructured.NestedString(cfg.Object, "spec", "service", "loadBalancerSourceRangesPolicy")
+	if !reflect.DeepEqual(ranges, []string{"10.0.0.0/9"}) || policy != sourceRangesAllow {
+		t.Errorf("unexpected source ranges %v / %q", ranges, policy)
+	}
+	if len(cfg.GetOwnerReferences()) != 1 || cfg.GetOwnerReferences()[0].Kind != "TenantGateway" {
+		t.Errorf("expected the config to be owned by the TenantGateway, got %v", cfg.GetOwnerReferences())
+	}
+	gw := &gatewayv1.Gateway{}
+	if err := c.Get(context.TODO(), types.NamespacedName{Name: "cozystack", Namespace: "tenant-foo"}, gw); err != nil {
+		t.Fatalf("get Gateway: %v", err)
+	}
+	if gw.Spec.Infrastructure == nil || gw.Spec.Infrastructure.ParametersRef == nil ||
+		gw.Spec.Infrastructure.ParametersRef.Kind != "CiliumGatewayClassConfig" || gw.Spec.Infrastructure.ParametersRef.Name != "cozystack-source-ranges" {
+		t.Errorf("expected the Gateway to reference the config, got %+v", gw.Spec.Infrastructure)
+	}
+	if obj := renderedEnvoyPolicy(t, c, securityPolicyGVK, "edge-security"); obj != nil {
+		t.Errorf("expected no SecurityPolicy, got %v", obj.Object)
+	}
+
+	cond, _ := policyAccepted(t, c, "edge")
+	if cond.Status != metav1.ConditionFalse || cond.Reason != "Pending" {
+		t.Errorf("expected Accepted=False/Pending before Cilium applies the ranges, got %s/%s", cond.Status, cond.Reason)
+	}
+
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "cilium-gateway-cozystack", Namespace: "tenant-foo"},
+		Spec:       corev1.ServiceSpec{Type: corev1.ServiceTypeLoadBalancer, LoadBalancerSourceRanges: []string{"10.0.0.0/9"}},
+	}
+	if err := c.Create(context.TODO(), svc); err != nil {
+		t.Fatalf("create Service: %v", err)
+	}
+	reconcileTenantGateway(t, r)
+	cond, _ = policyAccepted(t, c, "edge")
+	if cond.Status != metav1.ConditionTrue {
+		t.Errorf("expected Accepted=True once the Service carries the ranges, got %s/%s: %s", cond.Status, cond.Reason, cond.Message)
+	}
+	if !strings.HasSuffix(cond.Message, "filtering every listener: http:80") {
+		t.Errorf("expected the message to list the filtered listeners, got %q", cond.Message)
+	}
+	// In http01 mode the filter covers the challenges' listener.
+	warning := policyWarning(t, c, "edge")
+	if warning == nil || warning.Reason != "HTTP01ChallengesFiltered" || !strings.Contains(warning.Message, "http:80") {
+		t.Errorf("expected FiltersWholeGateway/HTTP01ChallengesFiltered naming http:80, got %+v", warning)
+	}
+
+	if err := c.Delete(context.TODO(), p); err != nil {
+		t.Fatalf("delete policy: %v", err)
+	}
+	reconcileTenantGateway(t, r)
+	if obj := renderedEnvoyPolicy(t, c, ciliumGatewayClassConfigGVK, "cozystack-source-ranges"); obj != nil {
+		t.Errorf("expected the config to be deleted, got %v", obj.Object)
+	}
+	if err := c.Get(context.TODO(), types.NamespacedName{Name: "cozystack", Namespace: "tenant-foo"}, gw); err != nil {
+		t.Fatalf("get Gateway: %v", err)
+	}
+	if gw.Spec.Infrastructure != nil {
+		t.Errorf("expected the Gateway to drop the reference, got %+v", gw.Spec.Infrastructure)
+	}
+}
+
+// policyWarning returns the FiltersWholeGateway condition of the named
+// policy, or nil when it has none.
+func policyWarning(t *testing.T, c client.Client, name string) *metav1.Condition {
+	t.Helper()
+	p := &gatewayv1alpha1.TenantGatewayPolicy{}
+	if err := c.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: "tenant-foo"}, p); err != nil {
+		t.Fatalf("get TenantGatewayPolicy %s: %v", name, err)
+	}
+	return apimeta.FindStatusCondition(p.Status.Conditions, policyFiltersWholeGatewayCondition)
+}
+
+// In dns01 mode the plain-HTTP listener only redirects, so the Cilium
+// filter warns about the L4 listeners alone, and not at all on a
+// Gateway without any. Every filtered listener is listed either way.
+func TestReconcile_PolicyCiliumFiltersWholeGateway(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		l4        []gatewayv1alpha1.L4Listener
+		wantWarn  bool
+		listeners string
+	}{
+		{name: "https only", listeners: "http:80, https:443, https-apex:443"},
+		{
+			name:      "L4 listener",
+			l4:        []gatewayv1alpha1.L4Listener{{Name: "postgres", Port: 5432}},
+			wantWarn:  true,
+			listeners: "http:80, https:443, https-apex:443, tcp-postgres:5432",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			tgw := policyTenantGateway(gatewayv1alpha1.CertModeDNS01)
+			tgw.Spec.GatewayClassName = "cilium"
+			tgw.Spec.L4Listeners = tc.l4
+			p := tenantGatewayPolicy("edge", time.Now(), gatewayv1alpha1.TenantGatewayPolicySpec{IPAllowList: []string{"10.0.0.0/8"}})
+			svc := &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{Name: "cilium-gateway-cozystack", Namespace: "tenant-foo"},
+				Spec:       corev1.ServiceSpec{Type: corev1.ServiceTypeLoadBalancer, LoadBalancerSourceRanges: []string{"10.0.0.0/8"}},
+			}
+			c, r := newPolicyReconciler(t, tgw, p, svc, gatewayClass("cilium", ciliumGatewayControllerName))
+			reconcileTenantGateway(t, r)
+
+			cond, _ := policyAccepted(t, c, "edge")
+			if cond.Status != metav1.ConditionTrue {
+				t.Fatalf("expected Accepted=True, got %s/%s: %s", cond.Status, cond.Reason, cond.Message)
+			}
+			if !strings.HasSuffix(cond.Message, "filtering every listener: "+tc.listeners) {
+				t.Errorf("expected the message to list %s, got %q", tc.listeners, cond.Message)
+			}
+			warning := policyWarning(t, c, "edge")
+			if !tc.wantWarn {
+				if warning != nil {
+					t.Errorf("expected no FiltersWholeGateway condition, got %+v", warning)
+				}
+				return
+			}
+			if warning == nil || warning.Status != metav1.ConditionTrue || warning.Reason != "NonHTTPSListenersFiltered" {
+				t.Fatalf("expected FiltersWholeGateway=True/NonHTTPSListenersFiltered, got %+v", warning)
+			}
+			if !strings.HasSuffix(warning.Message, "beyond the HTTPS listeners: tcp-postgres:5432") {
+				t.Errorf("expected the warning to name only the L4 listener, got %q", warning.Message)
+			}
+		})
+	}
+}
+
+// Fields Cilium cannot enforce are reported on the condition, and a
+// policy naming hostnames renders nothing.
+func TestReconcile_PolicyCiliumUnsupportedField(t *testing.T) {
+	p := tenantGatewayPolicy("harbor", time.Now(), gatewayv1alpha1.TenantGatewayPolicySpec{
+		Hostnames:   []string{"harbor.foo.example.com"},
+		IPAllowList: []string{"10.0.0.0/8"},
+		RateLimit:   &gatewayv1alpha1.RateLimitPolicy{Requests: 100},
+	})
+	route := httpRouteAttached("harbor", "cozy-harbor", "harbor.foo.example.com")
+	c, r := newPolicyReconciler(t, ciliumTenantGateway(), p, route, gatewayClass("cilium", ciliumGatewayControllerName))
+	reconcileTenantGateway(t, r)
+
+	cond, hostnames := policyAccepted(t, c, "harbor")
+	if cond.Status != metav1.ConditionFalse || cond.Reason != "UnsupportedField" {
+		t.Fatalf("expected Accepted=False/UnsupportedField, got %s/%s", cond.Status, cond.Reason)
+	}
+	if !strings.Contains(cond.Message, "not enforced: ipAllowList, rateLimit") {
+		t.Errorf("expected the message to name the unenforced fields, got %q", cond.Message)
+	}
+	if len(hostnames) != 0 {
+		t.Errorf("expected no status.hostnames, got %v", hostnames)
+	}
+	if obj := renderedEnvoyPolicy(t, c, ciliumGatewayClassConfigGVK, "cozystack-source-ranges"); obj != nil {
+		t.Errorf("expected no CiliumGatewayClassConfig, got %v", obj.Object)
+	}
+	if obj := renderedEnvoyPolicy(t, c, backendTrafficPolicyGVK, "harbor-traffic"); obj != nil {
+		t.Errorf("expected no BackendTrafficPolicy, got %v", obj.Object)
+	}
+}
@@ -32,16 +32,24 @@ import (
 	gatewayv1alpha1 "github.com/cozystack/cozystack/api/gateway/v1alpha1"
 )
 
-// routeKind discriminates HTTPRoute vs TLSRoute when stamping
-// RouteParentStatus back. Without this, status writes would target
-// the wrong resource type entirely.
+// routeKind discriminates HTTPRoute vs TLSRoute vs TCPRoute vs
+// UDPRoute when stamping RouteParentStatus back. Without this, status
+// writes would target the wrong resource type entirely.
 type routeKind int
 
 const (
 	routeKindHTTP routeKind = iota
 	routeKindTLS
+	routeKindTCP
+	routeKindUDP
 )
 
+// isL4 reports whether routes of kind k attach to L4 listeners, where
+// claims are keyed by listener name rather than hostname.
+func (k routeKind) isL4() bool {
+	return k == routeKindTCP || k == routeKindUDP
+}
+
 // ControllerName is the value used in HTTPRoute.Status.Parents[].ControllerName
 // for entries written by this reconciler. Distinct from any GatewayClass
 // controllerName (Cilium etc.) so multiple controllers can coexist.
@@ -76,17 +84,7 @@ func resolveHostnameOwners(claims map[string][]routeRef) (map[string]routeRef, m
 		if len(refs) == 0 {
 			continue
 		}
-		sort.Slice(refs, func(i, j int) bool {
-			ic := strings.HasPrefix(refs[i].namespace, "cozy-")
-			jc := strings.HasPrefix(refs[j].namespace, "cozy-")
-			if ic != jc {
-				return ic // cozy-* sorts first
-			}
-			if refs[i].namespace != refs[j].namespace {
-				return refs[i].namespace < refs[j].namespace
-			}
-			return refs[i].name < refs[j].name
-		})
+		sortClaimants(refs)
 		winner := refs[0]
 		winners[hostname] = winner
 		for _, lr := range refs[1:] {
@@ -103,12 +101,63 @@ func resolveHostnameOwners(claims map[string][]routeRef) (map[string]routeRef, m
 	return winners, losers
 }
 
+// resolveListenerOwners decides who owns each L4 listener claimed in
+// claims (listener name -> claimants), with the same precedence as
+// resolveHostnameOwners. Unlike hostnames, every non-winner loses,
+// same namespace or not: a raw port has no hostname, path or header
+// to split traffic on, so a second route on it is never a merge.
+func resolveListenerOwners(claims map[string][]routeRef) (map[string]routeRef, map[routeRef][]string) {
+	winners := make(map[string]routeRef, len(claims))
+	losers := make(map[routeRef][]string)
+
+	for listener, refs := range claims {
+		if len(refs) == 0 {
+			continue
+		}
+		sortClaimants(refs)
+		winner := refs[0]
+		winners[listener] = winner
+		for _, lr := range refs[1:] {
+			// The same route may reach a listener through two
+			// parentRefs of the same Gateway; it does not conflict
+			// with itself.
+			if lr.kind == winner.kind && lr.namespace == winner.namespace && lr.name == winner.name {
+				continue
+			}
+			losers[lr] = append(losers[lr], listener)
+		}
+	}
+	for ref := range losers {
+		sort.Strings(losers[ref])
+	}
+	return winners, losers
+}
+
+// sortClaimants orders the routes claiming a hostname or listener so
+// the first one wins: cozy-* namespaces first, then the
+// lexicographically smallest namespace/name pair (deterministic).
+func sortClaimants(refs []routeRef) {
+	sort.Slice(refs, func(i, j int) bool {
+		ic := strings.HasPrefix(refs[i].namespace, "cozy-")
+		jc := strings.HasPrefix(refs[j].namespace, "cozy-")
+		if ic != jc {
+			return ic // cozy-* sorts first
+		}
+		if refs[i].namespace != refs[j].namespace {
+			return refs[i].namespace < refs[j].namespace
+		}
+		return refs[i].name < refs[j].name
+	})
+}
+
 // updateRouteStatuses writes RouteParentStatus entries under our
 // ControllerName, one per (route, parentRef) tuple that attached to
 // this TenantGateway. Accepted=True for tuples not in losers,
 // Accepted=False with Reason=HostnameConflict for tuples that lost
-// at least one hostname race. Other controllers' entries (Cilium
-// etc.) are untouched.
+// at least one hostname race (ListenerConflict for TCPRoutes and
+// UDPRoutes losing an L4 listener), and Accepted=False with the
+// Gateway API reason in rejected for L4 routes no listener admits.
+// Other controllers' entries (Cilium etc.) are untouched.
 //
 // allRefs is the full set of (route, parentRef) tuples observed by
 // collectHostnameClaims — without it, multi-parentRef routes would
@@ -119,6 +168,7 @@ func (r *Reconciler) updateRouteStatuses(
 	tgw *gatewayv1alpha1.TenantGateway,
 	allRefs map[routeRef]struct{},
 	losers map[routeRef][]string,
+	rejected map[routeRef]string,
 ) error {
 	logger := log.FromContext(ctx)
 
@@ -127,6 +177,36 @@ func (r *Reconciler) updateRouteStatuses(
 	// transitions; building Conditions here without it keeps the
 	// no-op reconcile no-op.
 	for ref := range allRefs {
+		if reason, isRejected := rejected[ref]; isRejected {
+			message := fmt.Sprintf("No L4 listener on TenantGateway %s/%s matches the parentRef", tgw.Namespace, tgw.Name)
+			if reason == "NotAllowedByListeners" {
+				message = fmt.Sprintf("Namespace %s may not attach to the matching L4 listeners of TenantGateway %s/%s", ref.namespace, tgw.Namespace, tgw.Name)
+			}
+			if err := r.updateRouteParentStatus(ctx, ref, []metav1.Condition{
+				{
+					Type:    "Accepted",
+					Status:  metav1.ConditionFalse,
+					Reason:  reason,
+					Message: message,
+				},
+			}); err != nil {
+				logger.Error(err, "update rejected route status", "route", ref.namespace+"/"+ref.name)
+			}
+			continue
+		}
+		if names, isLoser := losers[ref]; isLoser && ref.kind.isL4() {
+			if err := r.updateRouteParentStatus(ctx, ref, []metav1.Condition{
+				{
+					Type:    "Accepted",
+					Status:  metav1.ConditionFalse,
+					Reason:  "ListenerConflict",
+					Message: fmt.Sprintf("Listener(s) %s already claimed by another route on TenantGateway %s/%s", strings.Join(names, ", "), tgw.Namespace, tgw.Name),
+				},
+			}); err != nil {
+				logger.Error(err, "update loser route status", "route", ref.namespace+"/"+ref.name)
+			}
+			continue
+		}
 		if hostnames, isLoser := losers[ref]; isLoser {
 			// A route can claim multiple hostnames; conflict status
 			// takes priority over the happy path.
@@ -157,8 +237,9 @@ func (r *Reconciler) updateRouteStatuses(
 }
 
 // updateRouteParentStatus locates or creates the RouteParentStatus
-// entry for our ControllerName on the given route (HTTPRoute or
-// TLSRoute, by ref.kind) and merges Conditions in.
+// entry for our ControllerName on the given route (HTTPRoute,
+// TLSRoute, TCPRoute or UDPRoute, by ref.kind) and merges Conditions
+// in.
 //
 // Idempotency contract: Status().Update() is only issued when the
 // merge actually changes something. apimeta.SetStatusCondition
@@ -190,6 +271,28 @@ func (r *Reconciler) updateRouteParentStatus(ctx context.Context, ref routeRef,
 			return nil
 		}
 		return r.Status().Update(ctx, route)
+	case routeKindTCP:
+		route := &gatewayv1alpha2.TCPRoute{}
+		if err := r.Get(ctx, types.NamespacedName{Namespace: ref.namespace, Name: ref.name}, route); err != nil {
+			return fmt.Errorf("get TCPRoute: %w", err)
+		}
+		before := route.DeepCopy()
+		mergeRouteParentStatus(&route.Status.Parents, ref.parentRef, conds)
+		if routeParentStatusEqual(before.Status.Parents, route.Status.Parents) {
+			return nil
+		}
+		return r.Status().Update(ctx, route)
+	case routeKindUDP:
+		route := &gatewayv1alpha2.UDPRoute{}
+		if err := r.Get(ctx, types.NamespacedName{Namespace: ref.namespace, Name: ref.name}, route); err != nil {
+			return fmt.Errorf("get UDPRoute: %w", err)
+		}
+		before := route.DeepCopy()
+		mergeRouteParentStatus(&route.Status.Parents, ref.parentRef, conds)
+		if routeParentStatusEqual(before.Status.Parents, route.Status.Parents) {
+			return nil
+		}
+		return r.Status().Update(ctx, route)
 	default:
 		return fmt.Errorf("unknown route kind %d for %s/%s", ref.kind, ref.namespace, ref.name)
 	}
@@ -0,0 +1,179 @@
+/*
+Copyright 2026 The Cozystack Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tenantgateway
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	gatewayv1alpha1 "github.com/cozystack/cozystack/api/gateway/v1alpha1"
+	"github.com/cozystack/cozystack/internal/controller/customdomain"
+)
+
+// customDomainsAnnotation lists, comma-separated, the custom domains
+// the routes of a namespace may carry. The route and Gateway hostname
+// VAPs admit these hostnames next to the namespace's apex; only
+// trusted callers may write the annotation (Layer 5), so a tenant
+// cannot grant itself a hostname it has not verified.
+const customDomainsAnnotation = "namespace.cozystack.io/custom-domains"
+
+// collectCustomDomains returns hostname -> namespace for the Verified
+// CustomDomains in the namespaces that attach to tgw's Gateway. The
+// CustomDomain controller already settles conflicting claims; should
+// two Verified claims overlap for a moment, the oldest wins here too.
+// Nil in existingSecret mode, which has no issuer to mint a
+// certificate for a hostname outside the operator-supplied one.
+func (r *Reconciler) collectCustomDomains(ctx context.Context, tgw *gatewayv1alpha1.TenantGateway) (map[string]string, error) {
+	if tgw.Spec.CertMode == gatewayv1alpha1.CertModeExistingSecret {
+		return nil, nil
+	}
+	allowed, err := r.attachableNamespaces(ctx, tgw)
+	if err != nil {
+		return nil, err
+	}
+	list := &gatewayv1alpha1.CustomDomainList{}
+	if err := r.List(ctx, list); err != nil {
+		return nil, fmt.Errorf("list CustomDomains: %w", err)
+	}
+	var verified []*gatewayv1alpha1.CustomDomain
+	for i := range list.Items {
+		cd := &list.Items[i]
+		if _, ok := allowed[cd.Namespace]; !ok {
+			continue
+		}
+		if !cd.DeletionTimestamp.IsZero() || !apimeta.IsStatusConditionTrue(cd.Status.Conditions, customdomain.ConditionVerified) {
+			continue
+		}
+		verified = append(verified, cd)
+	}
+	sort.SliceStable(verified, func(i, j int) bool {
+		a, b := verified[i], verified[j]
+		if !a.CreationTimestamp.Equal(&b.CreationTimestamp) {
+			return a.CreationTimestamp.Before(&b.CreationTimestamp)
+		}
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.Name < b.Name
+	})
+	out := map[string]string{}
+	for _, cd := range verified {
+		h := strings.ToLower(cd.Spec.Hostname)
+		if _, taken := out[h]; !taken {
+			out[h] = cd.Namespace
+		}
+	}
+	return out, nil
+}
+
+// preemptCustomDomainClaims removes from claims the routes that claim
+// a custom domain from a namespace other than the one that verified
+// it, and returns them as losers. Admission already keeps tenant
+// routes to their own domains; this also covers the cozy-* namespaces
+// the route VAP does not gate, which would otherwise win the hostname
+// by precedence.
+func preemptCustomDomainClaims(claims map[string][]routeRef, domains map[string]string) map[routeRef][]string {
+	losers := map[routeRef][]string{}
+	for h, owner := range domains {
+		refs, ok := claims[h]
+		if !ok {
+			continue
+		}
+		kept := refs[:0]
+		for _, ref := range refs {
+			if ref.namespace == owner {
+				kept = append(kept, ref)
+				continue
+			}
+			losers[ref] = append(losers[ref], h)
+		}
+		if len(kept) == 0 {
+			delete(claims, h)
+		} else {
+			claims[h] = kept
+		}
+	}
+	return losers
+}
+
+// ensureCustomDomainAnnotations records on each namespace the custom
+// domains it may publish: its own verified domains, and on the
+// TenantGateway's namespace all of them, since the Gateway listeners
+// and cert-manager's HTTP-01 solver routes live there. The annotation
+// is removed from attachable namespaces left without a domain.
+func (r *Reconciler) ensureCustomDomainAnnotations(ctx context.Context, tgw *gatewayv1alpha1.TenantGateway, domains map[string]string) error {
+	desired := map[string][]string{}
+	for h, ns := range domains {
+		desired[ns] = append(desired[ns], h)
+		if ns != tgw.Namespace {
+			desired[tgw.Namespace] = append(desired[tgw.Namespace], h)
+		}
+	}
+	allowed, err := r.attachableNamespaces(ctx, tgw)
+	if err != nil {
+		return err
+	}
+	for ns := range allowed {
+		hostnames := desired[ns]
+		sort.Strings(hostnames)
+		if err := r.patchNamespaceCustomDomains(ctx, ns, strings.Join(hostnames, ",")); err != nil {
+			return fmt.Errorf("annotate namespace %s with custom domains: %w", ns, err)
+		}
+	}
+	return nil
+}
+
+// patchNamespaceCustomDomains sets the custom-domains annotation, or
+// removes it when value is empty. A missing namespace is not fatal,
+// as in patchNamespaceGatewayLabel.
+func (r *Reconciler) patchNamespaceCustomDomains(ctx context.Context, name, value string) error {
+	ns := &corev1.Namespace{}
+	if err := r.Get(ctx, types.NamespacedName{Name: name}, ns); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	before := ns.DeepCopy()
+	if value == "" {
+		delete(ns.Annotations, customDomainsAnnotation)
+	} else {
+		if ns.Annotations == nil {
+			ns.Annotations = map[string]string{}
+		}
+		ns.Annotations[customDomainsAnnotation] = value
+	}
+	if equality.Semantic.DeepEqual(before.Annotations, ns.Annotations) {
+		return nil
+	}
+	return r.Patch(ctx, ns, client.MergeFrom(before))
+}
+
+// sortedHostnames returns the keys of domains in order.
+func sortedHostnames(domains map[string]string) []string {
+	out := make([]string, 0, len(domains))
+	for h := range domains {
+		out = append(out, h)
+	}
+	sort.Strings(out)
+	return out
+}
@@ -0,0 +1,324 @@
+/*
+Copyright 2026 The Cozystack Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tenantgateway
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	cmv1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	gatewayv1alpha1 "github.com/cozystack/cozystack/api/gateway/v1alpha1"
+	"github.com/cozystack/cozystack/internal/controller/customdomain"
+)
+
+func gatewayNamespace(name string, annotations map[string]string) *corev1.Namespace {
+	return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+		Name:        name,
+		Labels:      map[string]string{namespaceGatewayLabel: "tenant-foo"},
+		Annotations: annotations,
+	}}
+}
+
+func verifiedCustomDomain(ns, name, hostname string, created time.Time, verified bool) *gatewayv1alpha1.CustomDomain {
+	status := metav1.ConditionFalse
+	if verified {
+		status = metav1.ConditionTrue
+	}
+	return &gatewayv1alpha1.CustomDomain{
+		ObjectMeta: metav1.ObjectMeta{Namespace: ns, Name: name, CreationTimestamp: metav1.NewTime(created)},
+		Spec:       gatewayv1alpha1.CustomDomainSpec{Hostname: hostname},
+		Status: gatewayv1alpha1.CustomDomainStatus{Conditions: []metav1.Condition{{
+			Type: customdomain.ConditionVerified, Status: status, Reason: "Test",
+		}}},
+	}
+}
+
+func newCustomDomainReconciler(t *testing.T, objs ...client.Object) (client.Client, *Reconciler) {
+	t.Helper()
+	s := newScheme(t)
+	objs = append(objs, dns01Secret("tenant-foo", "cf-token", "api-token"))
+	c := fake.NewClientBuilder().
+		WithScheme(s).
+		WithObjects(objs...).
+		WithStatusSubresource(&gatewayv1alpha1.TenantGateway{}, &gatewayv1.HTTPRoute{}).
+		Build()
+	return c, &Reconciler{Client: c, Reader: c, Scheme: s}
+}
+
+func gatewayListener(t *testing.T, c client.Client, hostname string) *gatewayv1.Listener {
+	t.Helper()
+	gw := &gatewayv1.Gateway{}
+	if err := c.Get(context.TODO(), types.NamespacedName{Name: "cozystack", Namespace: "tenant-foo"}, gw); err != nil {
+		t.Fatalf("get Gateway: %v", err)
+	}
+	for i := range gw.Spec.Listeners {
+		if l := &gw.Spec.Listeners[i]; l.Hostname != nil && string(*l.Hostname) == hostname {
+			return l
+		}
+	}
+	return nil
+}
+
+func customDomainsAnnotationOf(t *testing.T, c client.Client, ns string) string {
+	t.Helper()
+	obj := &corev1.Namespace{}
+	if err := c.Get(context.TODO(), types.NamespacedName{Name: ns}, obj); err != nil {
+		t.Fatalf("get namespace %s: %v", ns, err)
+	}
+	return obj.Annotations[customDomainsAnnotation]
+}
+
+// TestReconcile_VerifiedCustomDomainGetsListenerAndCertificate pins the
+// serving path for both issuing modes: a Verified CustomDomain in a
+// namespace attached to the Gateway gets an HTTPS listener with its own
+// per-listener Certificate, even before any route claims it.
+func TestReconcile_VerifiedCustomDomainGetsListenerAndCertificate(t *testing.T) {
+	for _, mode := range []gatewayv1alpha1.CertMode{gatewayv1alpha1.CertModeHTTP01, gatewayv1alpha1.CertModeDNS01} {
+		t.Run(string(mode), func(t *testing.T) {
+			tgw := policyTenantGateway(mode)
+			c, r := newCustomDomainReconciler(t, tgw,
+				gatewayNamespace("tenant-foo", nil),
+				gatewayNamespace("tenant-shop", nil),
+				verifiedCustomDomain("tenant-shop", "shop", "shop.customer.com", time.Now(), true))
+			reconcileTenantGateway(t, r)
+
+			l := gatewayListener(t, c, "shop.customer.com")
+			if l == nil {
+				t.Fatal("no listener for the custom domain")
+			}
+			certName := perListenerCertName(tgw, "shop.customer.com")
+			if string(l.Name) != perListenerName("shop.customer.com") || string(l.TLS.CertificateRefs[0].Name) != certName {
+				t.Errorf("listener = %s with cert %s, want %s with cert %s", l.Name, l.TLS.CertificateRefs[0].Name, perListenerName("shop.customer.com"), certName)
+			}
+			cert := &cmv1.Certificate{}
+			if err := c.Get(context.TODO(), types.NamespacedName{Name: certName, Namespace: "tenant-foo"}, cert); err != nil {
+				t.Fatalf("get per-listener Certificate: %v", err)
+			}
+			if !reflect.DeepEqual(cert.Spec.DNSNames, []string{"shop.customer.com"}) || cert.Spec.IssuerRef.Name != gatewayIssuerName(tgw) {
+				t.Errorf("Certificate spec = %+v", cert.Spec)
+			}
+		})
+	}
+}
+
+func TestReconcile_UnverifiedCustomDomainIgnored(t *testing.T) {
+	c, r := newCustomDomainReconciler(t, policyTenantGateway(gatewayv1alpha1.CertModeHTTP01),
+		// A stale annotation left from when the domain was verified.
+		gatewayNamespace("tenant-foo", map[string]string{customDomainsAnnotation: "shop.customer.com"}),
+		gatewayNamespace("tenant-shop", map[string]string{customDomainsAnnotation: "shop.customer.com"}),
+		verifiedCustomDomain("tenant-shop", "shop", "shop.customer.com", time.Now(), false))
+	reconcileTenantGateway(t, r)
+
+	if l := gatewayListener(t, c, "shop.customer.com"); l != nil {
+		t.Errorf("unverified custom domain got listener %s", l.Name)
+	}
+	for _, ns := range []string{"tenant-foo", "tenant-shop"} {
+		if got := customDomainsAnnotationOf(t, c, ns); got != "" {
+			t.Errorf("namespace %s keeps custom-domains annotation %q", ns, got)
+		}
+	}
+}
+
+func TestReconcile_CustomDomainOutsideGatewayIgnored(t *testing.T) {
+	other := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+		Name:   "tenant-bar",
+		Labels: map[string]string{namespaceGatewayLabel: "tenant-bar"},
+	}}
+	c, r := newCustomDomainReconciler(t, policyTenantGateway(gatewayv1alpha1.CertModeHTTP01),
+		gatewayNamespace("tenant-foo", nil),
+		other,
+		verifiedCustomDomain("tenant-bar", "shop", "shop.customer.com", time.Now(), true))
+	reconcileTenantGateway(t, r)
+
+	if l := gatewayListener(t, c, "shop.customer.com"); l != nil {
+		t.Errorf("custom domain of another gateway's namespace got listener %s", l.Name)
+	}
+}
+
+// TestReconcile_CustomDomainAnnotations pins what the hostname VAPs
+// read: each namespace lists its own verified domains, and the
+// TenantGateway's namespace, which holds the listeners and the ACME
+// solver routes, lists all of them.
+func TestReconcile_CustomDomainAnnotations(t *testing.T) {
+	now := time.Now()
+	c, r := newCustomDomainReconciler(t, policyTenantGateway(gatewayv1alpha1.CertModeHTTP01),
+		gatewayNamespace("tenant-foo", nil),
+		gatewayNamespace("tenant-shop", nil),
+		gatewayNamespace("tenant-blog", nil),
+		verifiedCustomDomain("tenant-shop", "shop", "shop.customer.com", now, true),
+		verifiedCustomDomain("tenant-shop", "www", "www.customer.com", now, true),
+		verifiedCustomDomain("tenant-blog", "blog", "blog.example.net", now, true))
+	reconcileTenantGateway(t, r)
+
+	want := map[string]string{
+		"tenant-foo":  "blog.example.net,shop.customer.com,www.customer.com",
+		"tenant-shop": "shop.customer.com,www.customer.com",
+		"tenant-blog": "blog.example.net",
+	}
+	for ns, v := range want {
+		if got := customDomainsAnnotationOf(t, c, ns); got != v {
+			t.Errorf("namespace %s custom-domains = %q, want %q", ns, got, v)
+		}
+	}
+}
+
+// TestReconcile_CustomDomainOldestVerifiedClaimServed covers the short
+// window in which two claims are Verified before the CustomDomain
+// controller settles the conflict: only the oldest namespace is served.
+func TestReconcile_CustomDomainOldestVerifiedClaimServed(t *testing.T) {
+	now := time.Now()
+	c, r := newCustomDomainReconciler(t, policyTenantGateway(gatewayv1alpha1.CertModeHTTP01),
+		gatewayNamespace("tenant-foo", nil),
+		gatewayNamespace("tenant-a", nil),
+		gatewayNamespace("tenant-b", nil),
+		verifiedCustomDomain("tenant-b", "shop", "shop.customer.com", now, true),
+		verifiedCustomDomain("tenant-a", "shop", "shop.customer.com", now.Add(-time.Hour), true))
+	reconcileTenantGateway(t, r)
+
+	if got := customDomainsAnnotationOf(t, c, "tenant-a"); got != "shop.customer.com" {
+		t.Errorf("tenant-a custom-domains = %q, want shop.customer.com", got)
+	}
+	if got := customDomainsAnnotationOf(t, c, "tenant-b"); got != "" {
+		t.Errorf("tenant-b custom-domains = %q, want none", got)
+	}
+}
+
+// TestReconcile_RouteFromOtherNamespaceLosesCustomDomain pins that a
+// custom domain belongs to the namespace that verified it, even against
+// a cozy-* route that would otherwise win the hostname by precedence.
+func TestReconcile_RouteFromOtherNamespaceLosesCustomDomain(t *testing.T) {
+	c, r := newCustomDomainReconciler(t, policyTenantGateway(gatewayv1alpha1.CertModeHTTP01),
+		gatewayNamespace("tenant-foo", nil),
+		gatewayNamespace("tenant-shop", nil),
+		verifiedCustomDomain("tenant-shop", "shop", "shop.customer.com", time.Now(), true),
+		httpRouteAttached("hijack", "cozy-harbor", "shop.customer.com"),
+		httpRouteAttached("shop", "tenant-shop", "shop.customer.com"))
+	reconcileTenantGateway(t, r)
+
+	for _, tc := range []struct {
+		ns, name, reason string
+		status           metav1.ConditionStatus
+	}{
+		{"cozy-harbor", "hijack", "HostnameConflict", metav1.ConditionFalse},
+		{"tenant-shop", "shop", "", metav1.ConditionTrue},
+	} {
+		route := &gatewayv1.HTTPRoute{}
+		if err := c.Get(context.TODO(), types.NamespacedName{Name: tc.name, Namespace: tc.ns}, route); err != nil {
+			t.Fatalf("get route %s/%s: %v", tc.ns, tc.name, err)
+		}
+		var found bool
+		for _, ps := range route.Status.Parents {
+			for _, cond := range ps.Conditions {
+				if cond.Type == "Accepted" && cond.Status == tc.status && (tc.reason == "" || cond.Reason == tc.reason) {
+					found = true
+				}
+			}
+		}
+		if !found {
+			t.Errorf("route %s/%s: want Accepted=%s %s, got %+v", tc.ns, tc.name, tc.status, tc.reason, route.Status.Parents)
+		}
+	}
+}
+
+// TestReconcile_DNS01IssuerSolvesCustomDomainsOverHTTP01 pins the
+// solver split: the DNS-01 provider cannot write to the customer's
+// zone, so the custom domains get an HTTP-01 solver selected by name.
+func TestReconcile_DNS01IssuerSolvesCustomDomainsOverHTTP01(t *testing.T) {
+	tgw := policyTenantGateway(gatewayv1alpha1.CertModeDNS01)
+	c, r := newCustomDomainReconciler(t, tgw,
+		gatewayNamespace("tenant-foo", nil),
+		gatewayNamespace("tenant-shop", nil),
+		verifiedCustomDomain("tenant-shop", "shop", "shop.customer.com", time.Now(), true))
+	reconcileTenantGateway(t, r)
+
+	issuer := &cmv1.Issuer{}
+	if err := c.Get(context.TODO(), types.NamespacedName{Name: gatewayIssuerName(tgw), Namespace: "tenant-foo"}, issuer); err != nil {
+		t.Fatalf("get Issuer: %v", err)
+	}
+	solvers := issuer.Spec.ACME.Solvers
+	if len(solvers) != 2 {
+		t.Fatalf("solvers = %+v, want the DNS-01 solver plus one for custom domains", solvers)
+	}
+	if solvers[0].DNS01 == nil || solvers[0].Selector != nil {
+		t.Errorf("first solver = %+v, want the unselected DNS-01 solver", solvers[0])
+	}
+	custom := solvers[1]
+	if custom.HTTP01 == nil || custom.HTTP01.GatewayHTTPRoute == nil {
+		t.Fatalf("second solver = %+v, want HTTP-01 gatewayHTTPRoute", custom)
+	}
+	if custom.Selector == nil || !reflect.DeepEqual(custom.Selector.DNSNames, []string{"shop.customer.com"}) {
+		t.Errorf("second solver selector = %+v, want dnsNames [shop.customer.com]", custom.Selector)
+	}
+}
+
+func TestReconcile_ExistingSecretModeIgnoresCustomDomains(t *testing.T) {
+	tgw := &gatewayv1alpha1.TenantGateway{
+		ObjectMeta: metav1.ObjectMeta{Name: "cozystack", Namespace: "tenant-foo"},
+		Spec: gatewayv1alpha1.TenantGatewaySpec{
+			Apex:              "foo.example.com",
+			CertMode:          gatewayv1alpha1.CertModeExistingSecret,
+			GatewayClassName:  "cilium",
+			WildcardSecretRef: &corev1.LocalObjectReference{Name: "wildcard-tls"},
+		},
+	}
+	c, r := newCustomDomainReconciler(t, tgw,
+		gatewayNamespace("tenant-foo", nil),
+		gatewayNamespace("tenant-shop", nil),
+		verifiedCustomDomain("tenant-shop", "shop", "shop.customer.com", time.Now(), true))
+	reconcileTenantGateway(t, r)
+
+	if l := gatewayListener(t, c, "shop.customer.com"); l != nil {
+		t.Errorf("existingSecret mode rendered listener %s for a custom domain", l.Name)
+	}
+	err := c.Get(context.TODO(), types.NamespacedName{Name: perListenerCertName(tgw, "shop.customer.com"), Namespace: "tenant-foo"}, &cmv1.Certificate{})
+	if !apierrors.IsNotFound(err) {
+		t.Errorf("existingSecret mode: per-listener Certificate lookup = %v, want NotFound", err)
+	}
+}
+
+func TestServedHostnames_CustomDomainInWildcardMode(t *testing.T) {
+	s := newServedHostnames(policyTenantGateway(gatewayv1alpha1.CertModeDNS01), []string{"shop.customer.com"}, nil)
+	section, dedicated, ok := s.section("shop.customer.com")
+	if !ok || dedicated || string(section) != perListenerName("shop.customer.com") {
+		t.Errorf("section = %q dedicated=%v ok=%v, want the custom domain's own listener", section, dedicated, ok)
+	}
+}
+
+func TestMapCustomDomainToTenantGateways(t *testing.T) {
+	_, r := newCustomDomainReconciler(t, policyTenantGateway(gatewayv1alpha1.CertModeHTTP01),
+		gatewayNamespace("tenant-shop", nil),
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "tenant-loose"}})
+
+	reqs := r.mapCustomDomainToTenantGateways(context.TODO(), verifiedCustomDomain("tenant-shop", "shop", "shop.customer.com", time.Now(), true))
+	want := types.NamespacedName{Name: "cozystack", Namespace: "tenant-foo"}
+	if len(reqs) != 1 || reqs[0].NamespacedName != want {
+		t.Errorf("requests = %v, want [%s]", reqs, want)
+	}
+	if reqs := r.mapCustomDomainToTenantGateways(context.TODO(), verifiedCustomDomain("tenant-loose", "shop", "shop.customer.com", time.Now(), true)); len(reqs) != 0 {
+		t.Errorf("namespace without gateway label mapped to %v", reqs)
+	}
+}
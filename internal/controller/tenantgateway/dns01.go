@@ -0,0 +1,197 @@
+/*
+Copyright 2026 The Cozystack Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tenantgateway
+
+import (
+	"encoding/json"
+	"fmt"
+
+	cmacmev1 "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+
+	gatewayv1alpha1 "github.com/cozystack/cozystack/api/gateway/v1alpha1"
+)
+
+const (
+	// hetznerSolverName and hetznerAPIKeyKey are fixed by
+	// cert-manager-webhook-hetzner: it registers a single solver named
+	// "hetzner" and reads the API token from the "api-key" key of the
+	// Secret named in its config.
+	hetznerSolverName = "hetzner"
+	hetznerAPIKeyKey  = "api-key"
+
+	// powerDNSSolverName is the solver cert-manager-webhook-pdns
+	// registers.
+	powerDNSSolverName = "pdns"
+)
+
+// hetznerWebhookConfig is the config block cert-manager-webhook-hetzner
+// decodes.
+type hetznerWebhookConfig struct {
+	SecretName string `json:"secretName"`
+	ZoneName   string `json:"zoneName,omitempty"`
+	APIURL     string `json:"apiUrl,omitempty"`
+}
+
+// powerDNSWebhookConfig is the config block cert-manager-webhook-pdns
+// decodes.
+type powerDNSWebhookConfig struct {
+	Host            string                      `json:"host"`
+	ServerID        string                      `json:"serverID,omitempty"`
+	APIKeySecretRef powerDNSWebhookSecretKeyRef `json:"apiKeySecretRef"`
+}
+
+type powerDNSWebhookSecretKeyRef struct {
+	Name string `json:"name"`
+	Key  string `json:"key"`
+}
+
+// webhookSolver renders a cert-manager webhook DNS-01 solver whose
+// config is the JSON encoding of config.
+func webhookSolver(groupName, solverName string, config any) (*cmacmev1.ACMEChallengeSolver, error) {
+	raw, err := json.Marshal(config)
+	if err != nil {
+		return nil, fmt.Errorf("encode webhook config: %w", err)
+	}
+	return &cmacmev1.ACMEChallengeSolver{
+		DNS01: &cmacmev1.ACMEChallengeSolverDNS01{
+			Webhook: &cmacmev1.ACMEIssuerDNS01ProviderWebhook{
+				GroupName:  groupName,
+				SolverName: solverName,
+				Config:     &apiextensionsv1.JSON{Raw: raw},
+			},
+		},
+	}, nil
+}
+
+// buildHetznerSolver renders the Hetzner webhook solver. An empty
+// zone is left to the webhook, which looks up the zone enclosing the
+// challenge record; the apex is not a safe default since it may sit
+// below the Hetzner zone.
+func buildHetznerSolver(tgw *gatewayv1alpha1.TenantGateway) (*cmacmev1.ACMEChallengeSolver, error) {
+	cfg := tgw.Spec.DNS01.Hetzner
+	if cfg.GroupName == "" {
+		return nil, fmt.Errorf("dns01.hetzner.groupName is required")
+	}
+	return webhookSolver(cfg.GroupName, hetznerSolverName, hetznerWebhookConfig{
+		SecretName: cfg.APIKeySecretRef.Name,
+		ZoneName:   cfg.ZoneName,
+		APIURL:     cfg.APIURL,
+	})
+}
+
+// buildPowerDNSSolver renders the PowerDNS webhook solver.
+func buildPowerDNSSolver(tgw *gatewayv1alpha1.TenantGateway) (*cmacmev1.ACMEChallengeSolver, error) {
+	cfg := tgw.Spec.DNS01.PowerDNS
+	if cfg.GroupName == "" {
+		return nil, fmt.Errorf("dns01.powerdns.groupName is required")
+	}
+	if cfg.Host == "" {
+		return nil, fmt.Errorf("dns01.powerdns.host is required")
+	}
+	return webhookSolver(cfg.GroupName, powerDNSSolverName, powerDNSWebhookConfig{
+		Host:     cfg.Host,
+		ServerID: cfg.ServerID,
+		APIKeySecretRef: powerDNSWebhookSecretKeyRef{
+			Name: cfg.APIKeySecretRef.Name,
+			Key:  cfg.APIKeySecretRef.Key,
+		},
+	})
+}
+
+// buildGenericWebhookSolver renders dns01.webhook as-is. Config is
+// opaque to the controller; only its JSON well-formedness is checked,
+// so a typo fails here rather than in the webhook.
+func buildGenericWebhookSolver(tgw *gatewayv1alpha1.TenantGateway) (*cmacmev1.ACMEChallengeSolver, error) {
+	cfg := tgw.Spec.DNS01.Webhook
+	if cfg.GroupName == "" || cfg.SolverName == "" {
+		return nil, fmt.Errorf("dns01.webhook requires groupName and solverName")
+	}
+	solver := &cmacmev1.ACMEChallengeSolver{
+		DNS01: &cmacmev1.ACMEChallengeSolverDNS01{
+			Webhook: &cmacmev1.ACMEIssuerDNS01ProviderWebhook{
+				GroupName:  cfg.GroupName,
+				SolverName: cfg.SolverName,
+			},
+		},
+	}
+	if cfg.Config != nil && len(cfg.Config.Raw) > 0 {
+		if !json.Valid(cfg.Config.Raw) {
+			return nil, fmt.Errorf("dns01.webhook.config is not valid JSON")
+		}
+		solver.DNS01.Webhook.Config = cfg.Config.DeepCopy()
+	}
+	return solver, nil
+}
+
+// dns01SecretRefs returns the Secret keys the selected provider of a
+// dns01-mode TenantGateway reads. Optional refs (ambient-credential
+// providers) are included only when set. Providers whose block is
+// missing return nothing; buildSolver reports that case. The refs are
+// checked by validateIssuerSecrets.
+func dns01SecretRefs(tgw *gatewayv1alpha1.TenantGateway) []credentialRef {
+	cfg := tgw.Spec.DNS01
+	if tgw.Spec.CertMode != gatewayv1alpha1.CertModeDNS01 || cfg == nil {
+		return nil
+	}
+	fromSelector := func(field string, sel corev1.SecretKeySelector) credentialRef {
+		return credentialRef{field: field, name: sel.Name, key: sel.Key}
+	}
+	var refs []credentialRef
+	switch cfg.Provider {
+	case "cloudflare":
+		if cfg.Cloudflare != nil {
+			refs = append(refs, fromSelector("dns01.cloudflare.apiTokenSecretRef", cfg.Cloudflare.APITokenSecretRef))
+		}
+	case "route53":
+		if cfg.Route53 != nil && cfg.Route53.SecretAccessKeySecretRef != nil {
+			refs = append(refs, fromSelector("dns01.route53.secretAccessKeySecretRef", *cfg.Route53.SecretAccessKeySecretRef))
+		}
+	case "digitalocean":
+		if cfg.DigitalOcean != nil {
+			refs = append(refs, fromSelector("dns01.digitalocean.tokenSecretRef", cfg.DigitalOcean.TokenSecretRef))
+		}
+	case "rfc2136":
+		if cfg.RFC2136 != nil {
+			refs = append(refs, fromSelector("dns01.rfc2136.tsigSecretSecretRef", cfg.RFC2136.TSIGSecretSecretRef))
+		}
+	case "clouddns":
+		if cfg.CloudDNS != nil && cfg.CloudDNS.ServiceAccountSecretRef != nil {
+			refs = append(refs, fromSelector("dns01.clouddns.serviceAccountSecretRef", *cfg.CloudDNS.ServiceAccountSecretRef))
+		}
+	case "azuredns":
+		if cfg.AzureDNS != nil && cfg.AzureDNS.ClientSecretSecretRef != nil {
+			refs = append(refs, fromSelector("dns01.azuredns.clientSecretSecretRef", *cfg.AzureDNS.ClientSecretSecretRef))
+		}
+	case "hetzner":
+		if cfg.Hetzner != nil {
+			refs = append(refs, credentialRef{field: "dns01.hetzner.apiKeySecretRef", name: cfg.Hetzner.APIKeySecretRef.Name, key: hetznerAPIKeyKey})
+		}
+	case "powerdns":
+		if cfg.PowerDNS != nil {
+			refs = append(refs, fromSelector("dns01.powerdns.apiKeySecretRef", cfg.PowerDNS.APIKeySecretRef))
+		}
+	case "webhook":
+		if cfg.Webhook != nil {
+			for i, sel := range cfg.Webhook.SecretRefs {
+				refs = append(refs, fromSelector(fmt.Sprintf("dns01.webhook.secretRefs[%d]", i), sel))
+			}
+		}
+	}
+	return refs
+}
@@ -0,0 +1,567 @@
+/*
+Copyright 2026 The Cozystack Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tenantgateway
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	cmacmev1 "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	cmv1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/rfc2136"
+	"github.com/miekg/dns"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	gatewayv1alpha1 "github.com/cozystack/cozystack/api/gateway/v1alpha1"
+)
+
+func dns01TenantGateway(cfg *gatewayv1alpha1.DNS01Config) *gatewayv1alpha1.TenantGateway {
+	return &gatewayv1alpha1.TenantGateway{
+		ObjectMeta: metav1.ObjectMeta{Name: "cozystack", Namespace: "tenant-foo"},
+		Spec: gatewayv1alpha1.TenantGatewaySpec{
+			Apex:             "foo.example.com",
+			CertMode:         gatewayv1alpha1.CertModeDNS01,
+			GatewayClassName: "cilium",
+			DNS01:            cfg,
+		},
+	}
+}
+
+func secretKeyRef(name, key string) corev1.SecretKeySelector {
+	return corev1.SecretKeySelector{
+		LocalObjectReference: corev1.LocalObjectReference{Name: name},
+		Key:                  key,
+	}
+}
+
+// reconcileDNS01 runs one reconcile of tgw against a fake client
+// seeded with objs and returns the client and the reconcile error.
+func reconcileDNS01(t *testing.T, tgw *gatewayv1alpha1.TenantGateway, objs ...client.Object) (client.Client, error) {
+	t.Helper()
+	s := newScheme(t)
+	c := fake.NewClientBuilder().WithScheme(s).WithObjects(append([]client.Object{tgw}, objs...)...).WithStatusSubresource(tgw).Build()
+	r := &Reconciler{Client: c, Reader: c, Scheme: s}
+	_, err := r.Reconcile(context.TODO(), ctrl.Request{
+		NamespacedName: types.NamespacedName{Name: tgw.Name, Namespace: tgw.Namespace},
+	})
+	return c, err
+}
+
+// issuedSolver returns the single ACME solver of the Issuer the
+// reconcile rendered.
+func issuedSolver(t *testing.T, c client.Client) cmacmev1.ACMEChallengeSolver {
+	t.Helper()
+	iss := &cmv1.Issuer{}
+	if err := c.Get(context.TODO(), types.NamespacedName{Name: "cozystack-gateway", Namespace: "tenant-foo"}, iss); err != nil {
+		t.Fatalf("get Issuer: %v", err)
+	}
+	if iss.Spec.ACME == nil || len(iss.Spec.ACME.Solvers) != 1 {
+		t.Fatalf("expected exactly one ACME solver, got %+v", iss.Spec.ACME)
+	}
+	return iss.Spec.ACME.Solvers[0]
+}
+
+func TestReconcile_DNS01IssuerCloudDNSSolver(t *testing.T) {
+	sa := secretKeyRef("gcp-dns", "key.json")
+	tgw := dns01TenantGateway(&gatewayv1alpha1.DNS01Config{
+		Provider: "clouddns",
+		CloudDNS: &gatewayv1alpha1.CloudDNSDNS01{
+			Project:                 "acme-dns",
+			HostedZoneName:          "foo-example-com",
+			ServiceAccountSecretRef: &sa,
+		},
+	})
+	c, err := reconcileDNS01(t, tgw, dns01Secret("tenant-foo", "gcp-dns", "key.json"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	solver := issuedSolver(t, c)
+	if solver.DNS01 == nil || solver.DNS01.CloudDNS == nil {
+		t.Fatalf("expected dns01.cloudDNS solver, got %+v", solver)
+	}
+	got := solver.DNS01.CloudDNS
+	if got.Project != "acme-dns" || got.HostedZoneName != "foo-example-com" {
+		t.Errorf("CloudDNS project/zone=%q/%q, want acme-dns/foo-example-com", got.Project, got.HostedZoneName)
+	}
+	if got.ServiceAccount == nil || got.ServiceAccount.Name != "gcp-dns" || got.ServiceAccount.Key != "key.json" {
+		t.Errorf("CloudDNS ServiceAccount=%+v, want gcp-dns/key.json", got.ServiceAccount)
+	}
+}
+
+// TestReconcile_DNS01CloudDNSWorkloadIdentity pins that an unset
+// service account renders no Secret reference and needs no Secret:
+// cert-manager then authenticates with its own Workload Identity.
+func TestReconcile_DNS01CloudDNSWorkloadIdentity(t *testing.T) {
+	tgw := dns01TenantGateway(&gatewayv1alpha1.DNS01Config{
+		Provider: "clouddns",
+		CloudDNS: &gatewayv1alpha1.CloudDNSDNS01{Project: "acme-dns"},
+	})
+	c, err := reconcileDNS01(t, tgw)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if sa := issuedSolver(t, c).DNS01.CloudDNS.ServiceAccount; sa != nil {
+		t.Errorf("CloudDNS ServiceAccount=%+v, want nil", sa)
+	}
+}
+
+func TestReconcile_DNS01IssuerAzureDNSSolver(t *testing.T) {
+	clientSecret := secretKeyRef("azure-sp", "client-secret")
+	tgw := dns01TenantGateway(&gatewayv1alpha1.DNS01Config{
+		Provider: "azuredns",
+		AzureDNS: &gatewayv1alpha1.AzureDNSDNS01{
+			SubscriptionID:        "sub",
+			ResourceGroupName:     "dns-rg",
+			HostedZoneName:        "foo.example.com",
+			TenantID:              "tenant",
+			ClientID:              "app",
+			ClientSecretSecretRef: &clientSecret,
+		},
+	})
+	c, err := reconcileDNS01(t, tgw, dns01Secret("tenant-foo", "azure-sp", "client-secret"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	solver := issuedSolver(t, c)
+	if solver.DNS01 == nil || solver.DNS01.AzureDNS == nil {
+		t.Fatalf("expected dns01.azureDNS solver, got %+v", solver)
+	}
+	got := solver.DNS01.AzureDNS
+	if got.SubscriptionID != "sub" || got.ResourceGroupName != "dns-rg" || got.HostedZoneName != "foo.example.com" {
+		t.Errorf("AzureDNS zone coordinates=%+v", got)
+	}
+	if got.Environment != cmacmev1.AzurePublicCloud {
+		t.Errorf("AzureDNS Environment=%q, want %q (default)", got.Environment, cmacmev1.AzurePublicCloud)
+	}
+	if got.TenantID != "tenant" || got.ClientID != "app" || got.ClientSecret == nil || got.ClientSecret.Name != "azure-sp" {
+		t.Errorf("AzureDNS service principal=%+v", got)
+	}
+}
+
+// TestBuildSolver_AzureDNSPartialServicePrincipal pins that a service
+// principal missing one of its three fields is rejected instead of
+// rendering a solver cert-manager would run on the managed identity.
+func TestBuildSolver_AzureDNSPartialServicePrincipal(t *testing.T) {
+	tgw := dns01TenantGateway(&gatewayv1alpha1.DNS01Config{
+		Provider: "azuredns",
+		AzureDNS: &gatewayv1alpha1.AzureDNSDNS01{
+			SubscriptionID:    "sub",
+			ResourceGroupName: "dns-rg",
+			ClientID:          "app",
+		},
+	})
+	_, err := buildSolver(tgw)
+	if err == nil || !strings.Contains(err.Error(), "must be set together") {
+		t.Fatalf("expected partial service principal error, got %v", err)
+	}
+}
+
+func TestReconcile_DNS01IssuerHetznerWebhookSolver(t *testing.T) {
+	tgw := dns01TenantGateway(&gatewayv1alpha1.DNS01Config{
+		Provider: "hetzner",
+		Hetzner: &gatewayv1alpha1.HetznerDNS01{
+			GroupName:       "acme.hetzner.example",
+			APIKeySecretRef: corev1.LocalObjectReference{Name: "hetzner"},
+		},
+	})
+	c, err := reconcileDNS01(t, tgw, dns01Secret("tenant-foo", "hetzner", "api-key"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	solver := issuedSolver(t, c)
+	if solver.DNS01 == nil || solver.DNS01.Webhook == nil {
+		t.Fatalf("expected dns01.webhook solver, got %+v", solver)
+	}
+	wh := solver.DNS01.Webhook
+	if wh.GroupName != "acme.hetzner.example" || wh.SolverName != "hetzner" {
+		t.Errorf("webhook group/solver=%q/%q, want acme.hetzner.example/hetzner", wh.GroupName, wh.SolverName)
+	}
+	var cfg map[string]string
+	if err := json.Unmarshal(wh.Config.Raw, &cfg); err != nil {
+		t.Fatalf("decode webhook config: %v", err)
+	}
+	if cfg["secretName"] != "hetzner" {
+		t.Errorf("webhook config=%v, want secretName=hetzner", cfg)
+	}
+	for _, key := range []string{"zoneName", "apiUrl"} {
+		if _, set := cfg[key]; set {
+			t.Errorf("webhook config=%v, want %s omitted when unset", cfg, key)
+		}
+	}
+}
+
+func TestReconcile_DNS01IssuerPowerDNSWebhookSolver(t *testing.T) {
+	tgw := dns01TenantGateway(&gatewayv1alpha1.DNS01Config{
+		Provider: "powerdns",
+		PowerDNS: &gatewayv1alpha1.PowerDNSDNS01{
+			GroupName:       "acme.pdns.example",
+			Host:            "https://pdns.example.test",
+			APIKeySecretRef: secretKeyRef("pdns", "api-key"),
+		},
+	})
+	c, err := reconcileDNS01(t, tgw, dns01Secret("tenant-foo", "pdns", "api-key"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	wh := issuedSolver(t, c).DNS01.Webhook
+	if wh == nil || wh.GroupName != "acme.pdns.example" || wh.SolverName != "pdns" {
+		t.Fatalf("webhook solver=%+v, want acme.pdns.example/pdns", wh)
+	}
+	var cfg powerDNSWebhookConfig
+	if err := json.Unmarshal(wh.Config.Raw, &cfg); err != nil {
+		t.Fatalf("decode webhook config: %v", err)
+	}
+	if cfg.Host != "https://pdns.example.test" || cfg.APIKeySecretRef.Name != "pdns" || cfg.APIKeySecretRef.Key != "api-key" {
+		t.Errorf("webhook config=%+v", cfg)
+	}
+}
+
+func TestReconcile_DNS01IssuerGenericWebhookSolver(t *testing.T) {
+	tgw := dns01TenantGateway(&gatewayv1alpha1.DNS01Config{
+		Provider: "webhook",
+		Webhook: &gatewayv1alpha1.WebhookDNS01{
+			GroupName:  "acme.example.test",
+			SolverName: "gandi",
+			Config:     &apiextensionsv1.JSON{Raw: []byte(`{"apiKeySecretRef":{"name":"gandi","key":"token"}}`)},
+			SecretRefs: []corev1.SecretKeySelector{secretKeyRef("gandi", "token")},
+		},
+	})
+	c, err := reconcileDNS01(t, tgw, dns01Secret("tenant-foo", "gandi", "token"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	wh := issuedSolver(t, c).DNS01.Webhook
+	if wh == nil || wh.GroupName != "acme.example.test" || wh.SolverName != "gandi" {
+		t.Fatalf("webhook solver=%+v, want acme.example.test/gandi", wh)
+	}
+	if wh.Config == nil || string(wh.Config.Raw) != `{"apiKeySecretRef":{"name":"gandi","key":"token"}}` {
+		t.Errorf("webhook config=%v, want passed through verbatim", wh.Config)
+	}
+}
+
+func TestBuildSolver_WebhookErrors(t *testing.T) {
+	cases := []struct {
+		name     string
+		dns01    *gatewayv1alpha1.DNS01Config
+		wantSubs string
+	}{
+		{
+			name: "generic webhook without solverName",
+			dns01: &gatewayv1alpha1.DNS01Config{
+				Provider: "webhook",
+				Webhook:  &gatewayv1alpha1.WebhookDNS01{GroupName: "acme.example.test"},
+			},
+			wantSubs: "groupName and solverName",
+		},
+		{
+			name: "generic webhook with malformed config",
+			dns01: &gatewayv1alpha1.DNS01Config{
+				Provider: "webhook",
+				Webhook: &gatewayv1alpha1.WebhookDNS01{
+					GroupName:  "acme.example.test",
+					SolverName: "gandi",
+					Config:     &apiextensionsv1.JSON{Raw: []byte(`{"apiKey":`)},
+				},
+			},
+			wantSubs: "not valid JSON",
+		},
+		{
+			name: "hetzner without groupName",
+			dns01: &gatewayv1alpha1.DNS01Config{
+				Provider: "hetzner",
+				Hetzner:  &gatewayv1alpha1.HetznerDNS01{APIKeySecretRef: corev1.LocalObjectReference{Name: "hetzner"}},
+			},
+			wantSubs: "dns01.hetzner.groupName",
+		},
+		{
+			name: "powerdns without host",
+			dns01: &gatewayv1alpha1.DNS01Config{
+				Provider: "powerdns",
+				PowerDNS: &gatewayv1alpha1.PowerDNSDNS01{GroupName: "acme.pdns.example", APIKeySecretRef: secretKeyRef("pdns", "api-key")},
+			},
+			wantSubs: "dns01.powerdns.host",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := buildSolver(dns01TenantGateway(tc.dns01))
+			if err == nil {
+				t.Fatalf("expected error for %s, got nil", tc.name)
+			}
+			if !strings.Contains(err.Error(), tc.wantSubs) {
+				t.Errorf("error=%q, want to contain %q", err.Error(), tc.wantSubs)
+			}
+		})
+	}
+}
+
+// TestReconcile_DNS01MissingSecretFailsReady pins the per-provider
+// credential check: a Secret or key the provider reads that does not
+// exist reports IssuerReady=False/SecretNotFound naming the field
+// and keeps Ready=False, and no Issuer is rendered for cert-manager to
+// fail on later. Creating the Secret unblocks the Issuer.
+func TestReconcile_DNS01MissingSecretFailsReady(t *testing.T) {
+	cases := []struct {
+		name     string
+		dns01    *gatewayv1alpha1.DNS01Config
+		objs     []client.Object
+		wantSubs string
+	}{
+		{
+			name: "cloudflare Secret missing",
+			dns01: &gatewayv1alpha1.DNS01Config{
+				Provider:   "cloudflare",
+				Cloudflare: &gatewayv1alpha1.CloudflareDNS01{APITokenSecretRef: secretKeyRef("cf-token", "api-token")},
+			},
+			wantSubs: "dns01.cloudflare.apiTokenSecretRef: Secret tenant-foo/cf-token not found",
+		},
+		{
+			name: "hetzner Secret without api-key",
+			dns01: &gatewayv1alpha1.DNS01Config{
+				Provider: "hetzner",
+				Hetzner: &gatewayv1alpha1.HetznerDNS01{
+					GroupName:       "acme.hetzner.example",
+					APIKeySecretRef: corev1.LocalObjectReference{Name: "hetzner"},
+				},
+			},
+			objs:     []client.Object{dns01Secret("tenant-foo", "hetzner", "token")},
+			wantSubs: `dns01.hetzner.apiKeySecretRef: Secret tenant-foo/hetzner has no key "api-key"`,
+		},
+		{
+			name: "powerdns Secret in another namespace",
+			dns01: &gatewayv1alpha1.DNS01Config{
+				Provider: "powerdns",
+				PowerDNS: &gatewayv1alpha1.PowerDNSDNS01{
+					GroupName:       "acme.pdns.example",
+					Host:            "https://pdns.example.test",
+					APIKeySecretRef: secretKeyRef("pdns", "api-key"),
+				},
+			},
+			objs:     []client.Object{dns01Secret("tenant-bar", "pdns", "api-key")},
+			wantSubs: "dns01.powerdns.apiKeySecretRef: Secret tenant-foo/pdns not found",
+		},
+		{
+			name: "generic webhook secretRefs checked",
+			dns01: &gatewayv1alpha1.DNS01Config{
+				Provider: "webhook",
+				Webhook: &gatewayv1alpha1.WebhookDNS01{
+					GroupName:  "acme.example.test",
+					SolverName: "gandi",
+					SecretRefs: []corev1.SecretKeySelector{secretKeyRef("gandi", "token")},
+				},
+			},
+			wantSubs: "dns01.webhook.secretRefs[0]: Secret tenant-foo/gandi not found",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			c, err := reconcileDNS01(t, dns01TenantGateway(tc.dns01), tc.objs...)
+			if err != nil {
+				t.Fatalf("expected the reconcile to carry on past the missing Secret, got %v", err)
+			}
+			got := &gatewayv1alpha1.TenantGateway{}
+			if err := c.Get(context.TODO(), types.NamespacedName{Name: "cozystack", Namespace: "tenant-foo"}, got); err != nil {
+				t.Fatalf("get TenantGateway: %v", err)
+			}
+			ready := apimeta.FindStatusCondition(got.Status.Conditions, "Ready")
+			if ready == nil || ready.Status != metav1.ConditionFalse {
+				t.Errorf("Ready=%+v, want False", ready)
+			}
+			issuerReady := apimeta.FindStatusCondition(got.Status.Conditions, issuerReadyCondition)
+			if issuerReady == nil || issuerReady.Reason != "SecretNotFound" || issuerReady.Message != tc.wantSubs {
+				t.Errorf("IssuerReady=%+v, want SecretNotFound with %q", issuerReady, tc.wantSubs)
+			}
+			if err := c.Get(context.TODO(), types.NamespacedName{Name: "cozystack-gateway", Namespace: "tenant-foo"}, &cmv1.Issuer{}); err == nil {
+				t.Errorf("Issuer rendered despite missing credentials")
+			}
+		})
+	}
+
+	tgw := dns01TenantGateway(cases[0].dns01)
+	c, err := reconcileDNS01(t, tgw)
+	if err != nil {
+		t.Fatalf("reconcile: %v", err)
+	}
+	if err := c.Create(context.TODO(), dns01Secret("tenant-foo", "cf-token", "api-token")); err != nil {
+		t.Fatalf("create Secret: %v", err)
+	}
+	r := &Reconciler{Client: c, Reader: c, Scheme: c.Scheme()}
+	if _, err := r.Reconcile(context.TODO(), ctrl.Request{NamespacedName: types.NamespacedName{Name: tgw.Name, Namespace: tgw.Namespace}}); err != nil {
+		t.Fatalf("reconcile after creating the Secret: %v", err)
+	}
+	if err := c.Get(context.TODO(), types.NamespacedName{Name: "cozystack-gateway", Namespace: "tenant-foo"}, &cmv1.Issuer{}); err != nil {
+		t.Errorf("expected the Issuer once the Secret exists, got %v", err)
+	}
+	got := &gatewayv1alpha1.TenantGateway{}
+	if err := c.Get(context.TODO(), types.NamespacedName{Name: "cozystack", Namespace: "tenant-foo"}, got); err != nil {
+		t.Fatalf("get TenantGateway: %v", err)
+	}
+	if cond := apimeta.FindStatusCondition(got.Status.Conditions, issuerReadyCondition); cond == nil || cond.Reason == "SecretNotFound" {
+		t.Errorf("expected IssuerReady to follow the Issuer once the Secret exists, got %+v", cond)
+	}
+}
+
+// rfc2136Update is a dynamic update received by the stand-in server.
+type rfc2136Update struct {
+	zone    string
+	txt     []string
+	keyName string
+	tsigErr error
+}
+
+// startRFC2136Server runs a UDP nameserver on loopback that accepts
+// RFC 2136 updates signed with keyName / secret, records every update
+// it receives and answers NOTAUTH to unsigned or badly signed ones,
+// the way BIND does with an update-policy keyed on a TSIG key.
+func startRFC2136Server(t *testing.T, keyName, secret string) (string, func() []rfc2136Update) {
+	t.Helper()
+	pc, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	var (
+		mu      sync.Mutex
+		updates []rfc2136Update
+	)
+	handler := dns.HandlerFunc(func(w dns.ResponseWriter, req *dns.Msg) {
+		update := rfc2136Update{tsigErr: w.TsigStatus()}
+		if len(req.Question) == 1 {
+			update.zone = req.Question[0].Name
+		}
+		for _, rr := range req.Ns {
+			if txt, ok := rr.(*dns.TXT); ok {
+				update.txt = append(update.txt, txt.Txt...)
+			}
+		}
+		resp := new(dns.Msg)
+		resp.SetReply(req)
+		if tsig := req.IsTsig(); tsig != nil {
+			update.keyName = tsig.Hdr.Name
+			if update.tsigErr == nil {
+				resp.SetTsig(tsig.Hdr.Name, tsig.Algorithm, 300, time.Now().Unix())
+			}
+		}
+		if update.keyName == "" || update.tsigErr != nil {
+			resp.Rcode = dns.RcodeNotAuth
+		}
+		mu.Lock()
+		updates = append(updates, update)
+		mu.Unlock()
+		_ = w.WriteMsg(resp)
+	})
+	started := make(chan struct{})
+	srv := &dns.Server{
+		PacketConn: pc,
+		Handler:    handler,
+		TsigSecret: map[string]string{dns.Fqdn(keyName): secret},
+		// The default accept func answers NOTIMP to anything but
+		// QUERY and NOTIFY; a nameserver taking updates accepts them.
+		MsgAcceptFunc:     func(dns.Header) dns.MsgAcceptAction { return dns.MsgAccept },
+		NotifyStartedFunc: func() { close(started) },
+	}
+	go func() { _ = srv.ActivateAndServe() }()
+	<-started
+	t.Cleanup(func() { _ = srv.Shutdown() })
+	return pc.LocalAddr().String(), func() []rfc2136Update {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]rfc2136Update(nil), updates...)
+	}
+}
+
+// TestReconcile_DNS01RFC2136AgainstStandInServer drives cert-manager's
+// own RFC 2136 client with the solver the controller rendered and the
+// TSIG secret it validated, against a loopback nameserver, so a
+// rendering mistake in the nameserver, key name, algorithm or Secret
+// reference shows up as a rejected update rather than only as a field
+// mismatch.
+func TestReconcile_DNS01RFC2136AgainstStandInServer(t *testing.T) {
+	const keyName = "letsencrypt.foo.example.com."
+	secret := base64.StdEncoding.EncodeToString([]byte("0123456789abcdef0123456789abcdef"))
+	addr, received := startRFC2136Server(t, keyName, secret)
+
+	tgw := dns01TenantGateway(&gatewayv1alpha1.DNS01Config{
+		Provider: "rfc2136",
+		RFC2136: &gatewayv1alpha1.RFC2136DNS01{
+			Nameserver:          addr,
+			TSIGKeyName:         keyName,
+			TSIGSecretSecretRef: secretKeyRef("tsig", "secret"),
+		},
+	})
+	tsig := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "tsig", Namespace: "tenant-foo"},
+		Data:       map[string][]byte{"secret": []byte(secret)},
+	}
+	c, err := reconcileDNS01(t, tgw, tsig)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	cfg := issuedSolver(t, c).DNS01.RFC2136
+	if cfg == nil {
+		t.Fatalf("expected dns01.rfc2136 solver")
+	}
+
+	// Resolve the Secret reference the way cert-manager does: from the
+	// Issuer's namespace, by the rendered name and key.
+	ref := &corev1.Secret{}
+	if err := c.Get(context.TODO(), types.NamespacedName{Name: cfg.TSIGSecret.Name, Namespace: "tenant-foo"}, ref); err != nil {
+		t.Fatalf("get rendered TSIG Secret: %v", err)
+	}
+	provider, err := rfc2136.NewDNSProviderCredentials(cfg.Nameserver, cfg.TSIGAlgorithm, cfg.TSIGKeyName, string(ref.Data[cfg.TSIGSecret.Key]))
+	if err != nil {
+		t.Fatalf("build RFC2136 provider: %v", err)
+	}
+	if err := provider.Present("foo.example.com", "_acme-challenge.foo.example.com.", "foo.example.com.", "challenge-token"); err != nil {
+		t.Fatalf("Present: %v", err)
+	}
+
+	updates := received()
+	if len(updates) != 1 {
+		t.Fatalf("stand-in server saw %d updates, want 1", len(updates))
+	}
+	got := updates[0]
+	if got.tsigErr != nil || got.keyName != keyName {
+		t.Errorf("update key=%q tsig=%v, want signed by %q", got.keyName, got.tsigErr, keyName)
+	}
+	if got.zone != "foo.example.com." || len(got.txt) != 1 || got.txt[0] != "challenge-token" {
+		t.Errorf("update zone=%q txt=%v, want foo.example.com. [challenge-token]", got.zone, got.txt)
+	}
+
+	// A wrong secret must be refused by the server, proving the check
+	// above exercised TSIG rather than an open resolver.
+	wrong := base64.StdEncoding.EncodeToString([]byte("not-the-tsig-secret-at-all------"))
+	bad, err := rfc2136.NewDNSProviderCredentials(cfg.Nameserver, cfg.TSIGAlgorithm, cfg.TSIGKeyName, wrong)
+	if err != nil {
+		t.Fatalf("build RFC2136 provider: %v", err)
+	}
+	if err := bad.Present("foo.example.com", "_acme-challenge.foo.example.com.", "foo.example.com.", "challenge-token"); err == nil {
+		t.Errorf("Present with a wrong TSIG secret succeeded, want NOTAUTH")
+	}
+}
@@ -0,0 +1,271 @@
+/*
+Copyright 2026 The Cozystack Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tenantgateway
+
+import (
+	"context"
+	"fmt"
+
+	cmacmev1 "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	cmv1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmetav1 "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	gatewayv1alpha1 "github.com/cozystack/cozystack/api/gateway/v1alpha1"
+)
+
+// issuerReadyCondition is the TenantGateway condition mirroring the
+// Ready condition cert-manager writes on the per-tenant Issuer.
+const issuerReadyCondition = "IssuerReady"
+
+// cmSecretKeySelector converts a core SecretKeySelector to the
+// cert-manager one.
+func cmSecretKeySelector(sel corev1.SecretKeySelector) cmmetav1.SecretKeySelector {
+	return cmmetav1.SecretKeySelector{
+		LocalObjectReference: cmmetav1.LocalObjectReference{Name: sel.Name},
+		Key:                  sel.Key,
+	}
+}
+
+// usesACME reports whether the per-tenant Issuer is an ACME issuer,
+// i.e. whether certMode's challenge solver is rendered at all. CA and
+// Vault issuers sign directly and run no challenge.
+func usesACME(tgw *gatewayv1alpha1.TenantGateway) bool {
+	return tgw.Spec.Issuer == nil || tgw.Spec.Issuer.ACME != nil
+}
+
+// buildIssuerConfig renders the issuer block of the per-tenant
+// Issuer. The CRD enforces that spec.issuer sets exactly one of acme,
+// ca or vault; the checks here cover objects admitted before the rule
+// existed, so a misconfiguration fails the reconcile with a readable
+// error instead of rendering an Issuer cert-manager rejects.
+func buildIssuerConfig(tgw *gatewayv1alpha1.TenantGateway, customHostnames []string) (cmv1.IssuerConfig, error) {
+	custom := tgw.Spec.Issuer
+	if custom == nil {
+		server, err := acmeServerForIssuer(tgw.Spec.IssuerName)
+		if err != nil {
+			return cmv1.IssuerConfig{}, err
+		}
+		acme, err := acmeIssuer(tgw, &cmacmev1.ACMEIssuer{Server: server}, customHostnames)
+		if err != nil {
+			return cmv1.IssuerConfig{}, err
+		}
+		return cmv1.IssuerConfig{ACME: acme}, nil
+	}
+
+	set := 0
+	for _, present := range []bool{custom.ACME != nil, custom.CA != nil, custom.Vault != nil} {
+		if present {
+			set++
+		}
+	}
+	if set != 1 {
+		return cmv1.IssuerConfig{}, fmt.Errorf("issuer requires exactly one of acme, ca or vault")
+	}
+
+	switch {
+	case custom.ACME != nil:
+		cfg := custom.ACME
+		if cfg.Server == "" {
+			return cmv1.IssuerConfig{}, fmt.Errorf("issuer.acme.server is required")
+		}
+		out := &cmacmev1.ACMEIssuer{
+			Server:   cfg.Server,
+			Email:    cfg.Email,
+			CABundle: cfg.CABundle,
+		}
+		if eab := cfg.ExternalAccountBinding; eab != nil {
+			if eab.KeyID == "" {
+				return cmv1.IssuerConfig{}, fmt.Errorf("issuer.acme.externalAccountBinding.keyID is required")
+			}
+			out.ExternalAccountBinding = &cmacmev1.ACMEExternalAccountBinding{
+				KeyID: eab.KeyID,
+				Key:   cmSecretKeySelector(eab.KeySecretRef),
+			}
+		}
+		acme, err := acmeIssuer(tgw, out, customHostnames)
+		if err != nil {
+			return cmv1.IssuerConfig{}, err
+		}
+		return cmv1.IssuerConfig{ACME: acme}, nil
+
+	case custom.CA != nil:
+		if custom.CA.SecretName == "" {
+			return cmv1.IssuerConfig{}, fmt.Errorf("issuer.ca.secretName is required")
+		}
+		return cmv1.IssuerConfig{CA: &cmv1.CAIssuer{SecretName: custom.CA.SecretName}}, nil
+
+	default:
+		cfg := custom.Vault
+		if cfg.Server == "" || cfg.Path == "" {
+			return cmv1.IssuerConfig{}, fmt.Errorf("issuer.vault requires server and path")
+		}
+		if (cfg.TokenSecretRef == nil) == (cfg.Kubernetes == nil) {
+			return cmv1.IssuerConfig{}, fmt.Errorf("issuer.vault requires exactly one of tokenSecretRef or kubernetes")
+		}
+		vault := &cmv1.VaultIssuer{
+			Server:    cfg.Server,
+			Path:      cfg.Path,
+			Namespace: cfg.Namespace,
+			CABundle:  cfg.CABundle,
+		}
+		if cfg.TokenSecretRef != nil {
+			ref := cmSecretKeySelector(*cfg.TokenSecretRef)
+			vault.Auth.TokenSecretRef = &ref
+		} else {
+			k8s := cfg.Kubernetes
+			if k8s.Role == "" || k8s.ServiceAccountName == "" {
+				return cmv1.IssuerConfig{}, fmt.Errorf("issuer.vault.kubernetes requires role and serviceAccountName")
+			}
+			vault.Auth.Kubernetes = &cmv1.VaultKubernetesAuth{
+				Path:              k8s.MountPath,
+				Role:              k8s.Role,
+				ServiceAccountRef: &cmv1.ServiceAccountRef{Name: k8s.ServiceAccountName},
+			}
+		}
+		return cmv1.IssuerConfig{Vault: vault}, nil
+	}
+}
+
+// credentialRef is a Secret key the per-tenant Issuer reads, with the
+// spec field it came from for error messages.
+type credentialRef struct {
+	field string
+	name  string
+	key   string
+}
+
+// issuerSecretRefs returns the Secret keys the per-tenant Issuer
+// reads: those of spec.issuer, plus the DNS-01 provider's when the
+// Issuer runs ACME challenges.
+func issuerSecretRefs(tgw *gatewayv1alpha1.TenantGateway) []credentialRef {
+	var refs []credentialRef
+	if custom := tgw.Spec.Issuer; custom != nil {
+		switch {
+		case custom.ACME != nil && custom.ACME.ExternalAccountBinding != nil:
+			sel := custom.ACME.ExternalAccountBinding.KeySecretRef
+			refs = append(refs, credentialRef{field: "issuer.acme.externalAccountBinding.keySecretRef", name: sel.Name, key: sel.Key})
+		case custom.CA != nil:
+			for _, key := range []string{corev1.TLSCertKey, corev1.TLSPrivateKeyKey} {
+				refs = append(refs, credentialRef{field: "issuer.ca.secretName", name: custom.CA.SecretName, key: key})
+			}
+		case custom.Vault != nil && custom.Vault.TokenSecretRef != nil:
+			sel := *custom.Vault.TokenSecretRef
+			refs = append(refs, credentialRef{field: "issuer.vault.tokenSecretRef", name: sel.Name, key: sel.Key})
+		}
+	}
+	if usesACME(tgw) {
+		refs = append(refs, dns01SecretRefs(tgw)...)
+	}
+	return refs
+}
+
+// validateIssuerSecrets checks that every Secret key the per-tenant
+// Issuer reads exists in the TenantGateway's namespace. cert-manager
+// only discovers a missing credential when the Issuer or the first
+// challenge fails, long after the TenantGateway was applied; checking
+// here surfaces the typo on the TenantGateway's IssuerReady condition
+// instead, which it returns with reason SecretNotFound. The condition
+// is nil when every key is in place; the error is reserved for
+// failed reads. Reads go through the uncached Reader: the manager's
+// Secret cache is scoped to the wildcard replicas and holds none of
+// these.
+func (r *Reconciler) validateIssuerSecrets(ctx context.Context, tgw *gatewayv1alpha1.TenantGateway) (*metav1.Condition, error) {
+	problem, err := r.validateCredentialRefs(ctx, tgw.Namespace, issuerSecretRefs(tgw))
+	if err != nil || problem == "" {
+		return nil, err
+	}
+	return &metav1.Condition{
+		Type:               issuerReadyCondition,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: tgw.Generation,
+		Reason:             "SecretNotFound",
+		Message:            problem,
+	}, nil
+}
+
+// validateCredentialRefs checks that each ref names an existing Secret
+// in namespace carrying a non-empty value under its key. It returns a
+// message naming the first ref that does not, or "" when all do.
+func (r *Reconciler) validateCredentialRefs(ctx context.Context, namespace string, refs []credentialRef) (string, error) {
+	for _, ref := range refs {
+		if ref.name == "" || ref.key == "" {
+			return fmt.Sprintf("%s requires name and key", ref.field), nil
+		}
+		secret := &corev1.Secret{}
+		err := r.Reader.Get(ctx, types.NamespacedName{Namespace: namespace, Name: ref.name}, secret)
+		if apierrors.IsNotFound(err) {
+			return fmt.Sprintf("%s: Secret %s/%s not found", ref.field, namespace, ref.name), nil
+		}
+		if err != nil {
+			return "", fmt.Errorf("%s: get Secret %s/%s: %w", ref.field, namespace, ref.name, err)
+		}
+		if len(secret.Data[ref.key]) == 0 {
+			return fmt.Sprintf("%s: Secret %s/%s has no key %q", ref.field, namespace, ref.name, ref.key), nil
+		}
+	}
+	return "", nil
+}
+
+// issuerReadiness reads the per-tenant Issuer and returns the
+// IssuerReady condition mirroring its Ready condition. An Issuer
+// cert-manager has not processed yet, or whose Ready condition
+// predates the latest spec, reports Unknown; both block issuance as
+// surely as Ready=False, so only True counts as ready.
+func (r *Reconciler) issuerReadiness(ctx context.Context, tgw *gatewayv1alpha1.TenantGateway) (metav1.Condition, error) {
+	name := gatewayIssuerName(tgw)
+	cond := metav1.Condition{
+		Type:               issuerReadyCondition,
+		Status:             metav1.ConditionUnknown,
+		ObservedGeneration: tgw.Generation,
+		Reason:             "Pending",
+		Message:            fmt.Sprintf("Issuer %s/%s has not been checked by cert-manager yet", tgw.Namespace, name),
+	}
+	issuer := &cmv1.Issuer{}
+	err := r.Get(ctx, types.NamespacedName{Namespace: tgw.Namespace, Name: name}, issuer)
+	if apierrors.IsNotFound(err) {
+		cond.Status = metav1.ConditionFalse
+		cond.Reason = "IssuerNotFound"
+		cond.Message = fmt.Sprintf("Issuer %s/%s does not exist", tgw.Namespace, name)
+		return cond, nil
+	}
+	if err != nil {
+		return cond, fmt.Errorf("get Issuer for status: %w", err)
+	}
+	for _, c := range issuer.Status.Conditions {
+		if c.Type != cmv1.IssuerConditionReady {
+			continue
+		}
+		if c.ObservedGeneration != 0 && c.ObservedGeneration < issuer.Generation {
+			break
+		}
+		switch c.Status {
+		case cmmetav1.ConditionTrue:
+			cond.Status = metav1.ConditionTrue
+		case cmmetav1.ConditionFalse:
+			cond.Status = metav1.ConditionFalse
+		}
+		if c.Reason != "" {
+			cond.Reason = c.Reason
+		}
+		cond.Message = fmt.Sprintf("Issuer %s/%s: %s", tgw.Namespace, name, c.Message)
+	}
+	return cond, nil
+}
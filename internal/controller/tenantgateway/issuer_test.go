@@ -0,0 +1,385 @@
+/*
+Copyright 2026 The Cozystack Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tenantgateway
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	cmv1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmetav1 "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	gatewayv1alpha1 "github.com/cozystack/cozystack/api/gateway/v1alpha1"
+)
+
+// setIssuerReady writes the Ready condition cert-manager would set on
+// the per-tenant Issuer.
+func setIssuerReady(t *testing.T, c client.Client, namespace, name string, status cmmetav1.ConditionStatus, message string) {
+	t.Helper()
+	iss := &cmv1.Issuer{}
+	if err := c.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: namespace}, iss); err != nil {
+		t.Fatalf("get Issuer: %v", err)
+	}
+	reason := "ACMEAccountRegistered"
+	if status != cmmetav1.ConditionTrue {
+		reason = "ErrInitIssuer"
+	}
+	iss.Status.Conditions = []cmv1.IssuerCondition{{
+		Type:    cmv1.IssuerConditionReady,
+		Status:  status,
+		Reason:  reason,
+		Message: message,
+	}}
+	if err := c.Update(context.TODO(), iss); err != nil {
+		t.Fatalf("update Issuer status: %v", err)
+	}
+}
+
+func issuerTenantGateway(mode gatewayv1alpha1.CertMode, issuer *gatewayv1alpha1.IssuerConfig) *gatewayv1alpha1.TenantGateway {
+	return &gatewayv1alpha1.TenantGateway{
+		ObjectMeta: metav1.ObjectMeta{Name: "cozystack", Namespace: "tenant-foo"},
+		Spec: gatewayv1alpha1.TenantGatewaySpec{
+			Apex:             "foo.example.com",
+			CertMode:         mode,
+			GatewayClassName: "cilium",
+			Issuer:           issuer,
+		},
+	}
+}
+
+func renderedIssuer(t *testing.T, c client.Client) *cmv1.Issuer {
+	t.Helper()
+	iss := &cmv1.Issuer{}
+	if err := c.Get(context.TODO(), types.NamespacedName{Name: "cozystack-gateway", Namespace: "tenant-foo"}, iss); err != nil {
+		t.Fatalf("get Issuer: %v", err)
+	}
+	return iss
+}
+
+func caSecret(namespace, name string, keys ...string) *corev1.Secret {
+	data := map[string][]byte{}
+	for _, k := range keys {
+		data[k] = []byte("pem")
+	}
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Type:       corev1.SecretTypeTLS,
+		Data:       data,
+	}
+}
+
+// A custom ACME server replaces the Let's Encrypt URL and carries the
+// EAB credentials and CA bundle through; the certMode solver and the
+// per-tenant account key are unchanged.
+func TestReconcile_CustomACMEIssuerWithEAB(t *testing.T) {
+	tgw := issuerTenantGateway(gatewayv1alpha1.CertModeHTTP01, &gatewayv1alpha1.IssuerConfig{
+		ACME: &gatewayv1alpha1.ACMEIssuerConfig{
+			Server: "https://acme.zerossl.com/v2/DV90",
+			Email:  "ops@example.com",
+			ExternalAccountBinding: &gatewayv1alpha1.ACMEExternalAccountBinding{
+				KeyID:        "kid-1",
+				KeySecretRef: secretKeyRef("zerossl-eab", "hmac"),
+			},
+			CABundle: []byte("bundle"),
+		},
+	})
+	c, err := reconcileDNS01(t, tgw, dns01Secret("tenant-foo", "zerossl-eab", "hmac"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	acme := renderedIssuer(t, c).Spec.ACME
+	if acme == nil {
+		t.Fatal("expected an ACME issuer")
+	}
+	if acme.Server != "https://acme.zerossl.com/v2/DV90" || acme.Email != "ops@example.com" || string(acme.CABundle) != "bundle" {
+		t.Errorf("unexpected ACME issuer: server=%q email=%q caBundle=%q", acme.Server, acme.Email, acme.CABundle)
+	}
+	eab := acme.ExternalAccountBinding
+	if eab == nil || eab.KeyID != "kid-1" || eab.Key.Name != "zerossl-eab" || eab.Key.Key != "hmac" {
+		t.Errorf("unexpected externalAccountBinding: %+v", eab)
+	}
+	if acme.PrivateKey.Name != "cozystack-acme-account" {
+		t.Errorf("expected per-tenant account key, got %q", acme.PrivateKey.Name)
+	}
+	if len(acme.Solvers) != 1 || acme.Solvers[0].HTTP01 == nil {
+		t.Errorf("expected the HTTP-01 solver, got %+v", acme.Solvers)
+	}
+}
+
+// CA issuers sign directly: no ACME block, no solver, and a dns01-mode
+// TenantGateway needs no provider config.
+func TestReconcile_CAIssuerRendersNoACME(t *testing.T) {
+	for _, mode := range []gatewayv1alpha1.CertMode{gatewayv1alpha1.CertModeHTTP01, gatewayv1alpha1.CertModeDNS01} {
+		t.Run(string(mode), func(t *testing.T) {
+			tgw := issuerTenantGateway(mode, &gatewayv1alpha1.IssuerConfig{
+				CA: &gatewayv1alpha1.CAIssuerConfig{SecretName: "internal-ca"},
+			})
+			c, err := reconcileDNS01(t, tgw, caSecret("tenant-foo", "internal-ca", corev1.TLSCertKey, corev1.TLSPrivateKeyKey))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			spec := renderedIssuer(t, c).Spec
+			if spec.ACME != nil {
+				t.Errorf("expected no ACME block, got %+v", spec.ACME)
+			}
+			if spec.CA == nil || spec.CA.SecretName != "internal-ca" {
+				t.Errorf("expected CA issuer on internal-ca, got %+v", spec.CA)
+			}
+		})
+	}
+}
+
+func TestReconcile_VaultIssuer(t *testing.T) {
+	token := secretKeyRef("vault-token", "token")
+	cases := []struct {
+		name  string
+		vault *gatewayv1alpha1.VaultIssuerConfig
+		objs  []client.Object
+		check func(t *testing.T, auth cmv1.VaultAuth)
+	}{
+		{
+			name: "token",
+			vault: &gatewayv1alpha1.VaultIssuerConfig{
+				Server:         "https://vault.internal:8200",
+				Path:           "pki_int/sign/tenant-foo",
+				TokenSecretRef: &token,
+			},
+			objs: []client.Object{dns01Secret("tenant-foo", "vault-token", "token")},
+			check: func(t *testing.T, auth cmv1.VaultAuth) {
+				if auth.TokenSecretRef == nil || auth.TokenSecretRef.Name != "vault-token" || auth.TokenSecretRef.Key != "token" {
+					t.Errorf("unexpected tokenSecretRef: %+v", auth.TokenSecretRef)
+				}
+				if auth.Kubernetes != nil {
+					t.Errorf("expected no kubernetes auth, got %+v", auth.Kubernetes)
+				}
+			},
+		},
+		{
+			name: "kubernetes",
+			vault: &gatewayv1alpha1.VaultIssuerConfig{
+				Server: "https://vault.internal:8200",
+				Path:   "pki_int/sign/tenant-foo",
+				Kubernetes: &gatewayv1alpha1.VaultKubernetesAuth{
+					Role:               "tenant-foo-issuer",
+					MountPath:          "/v1/auth/cozystack",
+					ServiceAccountName: "vault-issuer",
+				},
+			},
+			check: func(t *testing.T, auth cmv1.VaultAuth) {
+				k := auth.Kubernetes
+				if k == nil || k.Role != "tenant-foo-issuer" || k.Path != "/v1/auth/cozystack" ||
+					k.ServiceAccountRef == nil || k.ServiceAccountRef.Name != "vault-issuer" {
+					t.Errorf("unexpected kubernetes auth: %+v", k)
+				}
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tgw := issuerTenantGateway(gatewayv1alpha1.CertModeHTTP01, &gatewayv1alpha1.IssuerConfig{Vault: tc.vault})
+			c, err := reconcileDNS01(t, tgw, tc.objs...)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			vault := renderedIssuer(t, c).Spec.Vault
+			if vault == nil || vault.Server != tc.vault.Server || vault.Path != tc.vault.Path {
+				t.Fatalf("unexpected Vault issuer: %+v", vault)
+			}
+			tc.check(t, vault.Auth)
+		})
+	}
+}
+
+// Missing issuer credentials hold the Issuer back and report
+// IssuerReady=False/SecretNotFound naming the offending field, while
+// the Gateway still reconciles. A spec the Issuer cannot be built
+// from at all still fails the reconcile.
+func TestReconcile_IssuerMissingCredentials(t *testing.T) {
+	cases := []struct {
+		name   string
+		issuer *gatewayv1alpha1.IssuerConfig
+		objs   []client.Object
+		want   string
+		// wantErr marks a spec error rather than a missing Secret.
+		wantErr bool
+	}{
+		{
+			name: "missing EAB Secret",
+			issuer: &gatewayv1alpha1.IssuerConfig{ACME: &gatewayv1alpha1.ACMEIssuerConfig{
+				Server: "https://acme.zerossl.com/v2/DV90",
+				ExternalAccountBinding: &gatewayv1alpha1.ACMEExternalAccountBinding{
+					KeyID:        "kid-1",
+					KeySecretRef: secretKeyRef("zerossl-eab", "hmac"),
+				},
+			}},
+			want: "issuer.acme.externalAccountBinding.keySecretRef: Secret tenant-foo/zerossl-eab not found",
+		},
+		{
+			name:   "CA Secret without private key",
+			issuer: &gatewayv1alpha1.IssuerConfig{CA: &gatewayv1alpha1.CAIssuerConfig{SecretName: "internal-ca"}},
+			objs:   []client.Object{caSecret("tenant-foo", "internal-ca", corev1.TLSCertKey)},
+			want:   `issuer.ca.secretName: Secret tenant-foo/internal-ca has no key "tls.key"`,
+		},
+		{
+			name: "Vault without auth",
+			issuer: &gatewayv1alpha1.IssuerConfig{Vault: &gatewayv1alpha1.VaultIssuerConfig{
+				Server: "https://vault.internal:8200",
+				Path:   "pki_int/sign/tenant-foo",
+			}},
+			want:    "issuer.vault requires exactly one of tokenSecretRef or kubernetes",
+			wantErr: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tgw := issuerTenantGateway(gatewayv1alpha1.CertModeHTTP01, tc.issuer)
+			c, err := reconcileDNS01(t, tgw, tc.objs...)
+			if tc.wantErr {
+				if err == nil || !strings.Contains(err.Error(), tc.want) {
+					t.Fatalf("expected error containing %q, got %v", tc.want, err)
+				}
+			} else if err != nil {
+				t.Fatalf("expected the reconcile to carry on past the missing Secret, got %v", err)
+			}
+			if err := c.Get(context.TODO(), types.NamespacedName{Name: "cozystack-gateway", Namespace: "tenant-foo"}, &cmv1.Issuer{}); err == nil {
+				t.Error("expected no Issuer to be rendered")
+			}
+			got := &gatewayv1alpha1.TenantGateway{}
+			if err := c.Get(context.TODO(), types.NamespacedName{Name: "cozystack", Namespace: "tenant-foo"}, got); err != nil {
+				t.Fatalf("get tgw: %v", err)
+			}
+			ready := meta.FindStatusCondition(got.Status.Conditions, "Ready")
+			if ready == nil || ready.Status != metav1.ConditionFalse {
+				t.Errorf("expected Ready=False, got %+v", ready)
+			}
+			if tc.wantErr {
+				if !strings.Contains(ready.Message, tc.want) {
+					t.Errorf("expected Ready carrying %q, got %q", tc.want, ready.Message)
+				}
+				return
+			}
+			issuerReady := meta.FindStatusCondition(got.Status.Conditions, issuerReadyCondition)
+			if issuerReady == nil || issuerReady.Status != metav1.ConditionFalse || issuerReady.Reason != "SecretNotFound" || issuerReady.Message != tc.want {
+				t.Errorf("expected IssuerReady=False/SecretNotFound carrying %q, got %+v", tc.want, issuerReady)
+			}
+			if err := c.Get(context.TODO(), types.NamespacedName{Name: "cozystack", Namespace: "tenant-foo"}, &gatewayv1.Gateway{}); err != nil {
+				t.Errorf("expected the Gateway to be rendered regardless, got %v", err)
+			}
+		})
+	}
+}
+
+// An Issuer cert-manager rejects keeps the TenantGateway not Ready,
+// with the Issuer's own message surfaced on IssuerReady.
+func TestReconcile_StatusIssuerNotReady(t *testing.T) {
+	s := newScheme(t)
+	tgw := issuerTenantGateway(gatewayv1alpha1.CertModeHTTP01, &gatewayv1alpha1.IssuerConfig{
+		ACME: &gatewayv1alpha1.ACMEIssuerConfig{
+			Server: "https://acme.zerossl.com/v2/DV90",
+			ExternalAccountBinding: &gatewayv1alpha1.ACMEExternalAccountBinding{
+				KeyID:        "kid-1",
+				KeySecretRef: secretKeyRef("zerossl-eab", "hmac"),
+			},
+		},
+	})
+	c := fake.NewClientBuilder().
+		WithScheme(s).
+		WithObjects(tgw, dns01Secret("tenant-foo", "zerossl-eab", "hmac")).
+		WithStatusSubresource(tgw, &gatewayv1.Gateway{}).
+		Build()
+	r := &Reconciler{Client: c, Reader: c, Scheme: s}
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: "cozystack", Namespace: "tenant-foo"}}
+	if _, err := r.Reconcile(context.TODO(), req); err != nil {
+		t.Fatalf("first reconcile: %v", err)
+	}
+
+	gw := &gatewayv1.Gateway{}
+	if err := c.Get(context.TODO(), req.NamespacedName, gw); err != nil {
+		t.Fatalf("get Gateway: %v", err)
+	}
+	gw.Status.Conditions = []metav1.Condition{
+		{Type: "Accepted", Status: metav1.ConditionTrue, Reason: "Accepted", LastTransitionTime: metav1.Now()},
+		{Type: "Programmed", Status: metav1.ConditionTrue, Reason: "Programmed", LastTransitionTime: metav1.Now()},
+	}
+	if err := c.Status().Update(context.TODO(), gw); err != nil {
+		t.Fatalf("patch Gateway status: %v", err)
+	}
+	setIssuerReady(t, c, "tenant-foo", "cozystack-gateway", cmmetav1.ConditionFalse, "Failed to register ACME account: eab key invalid")
+
+	if _, err := r.Reconcile(context.TODO(), req); err != nil {
+		t.Fatalf("second reconcile: %v", err)
+	}
+	got := &gatewayv1alpha1.TenantGateway{}
+	if err := c.Get(context.TODO(), req.NamespacedName, got); err != nil {
+		t.Fatalf("get tgw: %v", err)
+	}
+	issuerReady := meta.FindStatusCondition(got.Status.Conditions, "IssuerReady")
+	if issuerReady == nil || issuerReady.Status != metav1.ConditionFalse || issuerReady.Reason != "ErrInitIssuer" ||
+		!strings.Contains(issuerReady.Message, "eab key invalid") {
+		t.Errorf("expected IssuerReady=False with the Issuer's message, got %+v", issuerReady)
+	}
+	ready := meta.FindStatusCondition(got.Status.Conditions, "Ready")
+	if ready == nil || ready.Status != metav1.ConditionFalse || ready.Reason != "IssuerNotReady" ||
+		!strings.Contains(ready.Message, "eab key invalid") {
+		t.Errorf("expected Ready=False IssuerNotReady, got %+v", ready)
+	}
+}
+
+// Switching an existing TenantGateway from Let's Encrypt to a CA
+// rewrites the owned Issuer in place.
+func TestReconcile_IssuerSwitchFromLetsEncryptToCA(t *testing.T) {
+	s := newScheme(t)
+	tgw := issuerTenantGateway(gatewayv1alpha1.CertModeHTTP01, nil)
+	c := fake.NewClientBuilder().
+		WithScheme(s).
+		WithObjects(tgw, caSecret("tenant-foo", "internal-ca", corev1.TLSCertKey, corev1.TLSPrivateKeyKey)).
+		WithStatusSubresource(tgw).
+		Build()
+	r := &Reconciler{Client: c, Reader: c, Scheme: s}
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: "cozystack", Namespace: "tenant-foo"}}
+	if _, err := r.Reconcile(context.TODO(), req); err != nil {
+		t.Fatalf("first reconcile: %v", err)
+	}
+	if acme := renderedIssuer(t, c).Spec.ACME; acme == nil || acme.Server != letsencryptProdServer {
+		t.Fatalf("expected Let's Encrypt issuer, got %+v", acme)
+	}
+
+	current := &gatewayv1alpha1.TenantGateway{}
+	if err := c.Get(context.TODO(), req.NamespacedName, current); err != nil {
+		t.Fatalf("get tgw: %v", err)
+	}
+	current.Spec.Issuer = &gatewayv1alpha1.IssuerConfig{CA: &gatewayv1alpha1.CAIssuerConfig{SecretName: "internal-ca"}}
+	if err := c.Update(context.TODO(), current); err != nil {
+		t.Fatalf("update tgw: %v", err)
+	}
+	if _, err := r.Reconcile(context.TODO(), req); err != nil {
+		t.Fatalf("second reconcile: %v", err)
+	}
+	spec := renderedIssuer(t, c).Spec
+	if spec.ACME != nil || spec.CA == nil || spec.CA.SecretName != "internal-ca" {
+		t.Errorf("expected Issuer switched to CA, got %+v", spec.IssuerConfig)
+	}
+}
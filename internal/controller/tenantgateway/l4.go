@@ -0,0 +1,288 @@
+/*
+Copyright 2026 The Cozystack Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tenantgateway
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+
+	gatewayv1alpha1 "github.com/cozystack/cozystack/api/gateway/v1alpha1"
+)
+
+// l4ListenersValidCondition is the TenantGateway condition reporting
+// the spec.l4Listeners entries left off the Gateway.
+const l4ListenersValidCondition = "L4ListenersValid"
+
+// l4Protocol returns the protocol of an L4 listener, defaulting to
+// TCP like the CRD does for objects written before the default
+// existed.
+func l4Protocol(l gatewayv1alpha1.L4Listener) gatewayv1alpha1.L4Protocol {
+	if l.Protocol == "" {
+		return gatewayv1alpha1.L4ProtocolTCP
+	}
+	return l.Protocol
+}
+
+// l4ListenerName produces the Gateway listener name for an L4
+// listener: "tcp-<name>" or "udp-<name>". The protocol prefix keeps
+// the name disjoint from the http / https-* / tls-* listeners, so a
+// tenant naming an L4 listener "apex" cannot shadow https-apex.
+func l4ListenerName(l gatewayv1alpha1.L4Listener) gatewayv1.SectionName {
+	if l4Protocol(l) == gatewayv1alpha1.L4ProtocolUDP {
+		return gatewayv1.SectionName("udp-" + l.Name)
+	}
+	return gatewayv1.SectionName("tcp-" + l.Name)
+}
+
+// validateL4Listeners splits spec.l4Listeners into the listeners
+// the Gateway can serve and a message for each one it would refuse or
+// silently merge: a duplicate name, an unsupported protocol, a port
+// out of range, a second listener on the same port and protocol, and
+// ports 80 / 443, which the http and https listeners own. Cilium
+// merges listeners sharing a port (cilium#45559), so an L4 listener
+// on 443 would take the HTTPS listeners down with it. Of two
+// conflicting entries the first wins. Only the invalid entries are
+// dropped, so one typo does not take the rest of the Gateway down;
+// the messages surface on the L4ListenersValid condition.
+func validateL4Listeners(tgw *gatewayv1alpha1.TenantGateway) ([]gatewayv1alpha1.L4Listener, []string) {
+	var valid []gatewayv1alpha1.L4Listener
+	var invalid []string
+	names := map[string]struct{}{}
+	ports := map[string]string{}
+	for _, l := range tgw.Spec.L4Listeners {
+		if msg := l4ListenerProblem(l, names, ports); msg != "" {
+			invalid = append(invalid, msg)
+			continue
+		}
+		names[l.Name] = struct{}{}
+		ports[fmt.Sprintf("%s/%d", l4Protocol(l), l.Port)] = l.Name
+		valid = append(valid, l)
+	}
+	return valid, invalid
+}
+
+// l4ListenerProblem returns why l cannot be served next to the
+// listeners already accepted into names and ports, or "" if it can.
+func l4ListenerProblem(l gatewayv1alpha1.L4Listener, names map[string]struct{}, ports map[string]string) string {
+	if _, dup := names[l.Name]; dup {
+		return fmt.Sprintf("spec.l4Listeners: duplicate name %q", l.Name)
+	}
+	proto := l4Protocol(l)
+	if proto != gatewayv1alpha1.L4ProtocolTCP && proto != gatewayv1alpha1.L4ProtocolUDP {
+		return fmt.Sprintf("spec.l4Listeners[%s]: unsupported protocol %q (supported: TCP, UDP)", l.Name, proto)
+	}
+	if l.Port < 1 || l.Port > 65535 {
+		return fmt.Sprintf("spec.l4Listeners[%s]: port %d out of range", l.Name, l.Port)
+	}
+	if l.Port == 80 || l.Port == 443 {
+		return fmt.Sprintf("spec.l4Listeners[%s]: port %d is reserved for the HTTP/HTTPS listeners", l.Name, l.Port)
+	}
+	if other, dup := ports[fmt.Sprintf("%s/%d", proto, l.Port)]; dup {
+		return fmt.Sprintf("spec.l4Listeners[%s]: %s port %d already used by %q", l.Name, proto, l.Port, other)
+	}
+	return ""
+}
+
+// l4ListenersCondition reports the outcome of validateL4Listeners on
+// tgw, whose spec already holds the valid listeners only. It is nil
+// for a TenantGateway declaring no L4 listeners.
+func l4ListenersCondition(tgw *gatewayv1alpha1.TenantGateway, invalid []string) *metav1.Condition {
+	if len(tgw.Spec.L4Listeners)+len(invalid) == 0 {
+		return nil
+	}
+	cond := &metav1.Condition{
+		Type:               l4ListenersValidCondition,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: tgw.Generation,
+		Reason:             "Valid",
+		Message:            fmt.Sprintf("All %d L4 listeners are rendered", len(tgw.Spec.L4Listeners)),
+	}
+	if len(invalid) > 0 {
+		cond.Status = metav1.ConditionFalse
+		cond.Reason = "InvalidL4Listener"
+		cond.Message = "Not rendered: " + strings.Join(invalid, "; ")
+	}
+	return cond
+}
+
+// l4AllowedRoutes returns the AllowedRoutes block of an L4 listener.
+// An empty AllowedNamespaces reuses the Gateway-wide label selector
+// of the HTTPS listeners; an explicit list pins the listener to
+// those names through the unspoofable kubernetes.io/metadata.name
+// label, the same shape as the port-80 listener. Kinds admit only
+// the route type matching the protocol, so an HTTPRoute can never
+// attach to a raw port and bypass the hostname policies.
+func l4AllowedRoutes(tgw *gatewayv1alpha1.TenantGateway, l gatewayv1alpha1.L4Listener) *gatewayv1.AllowedRoutes {
+	var allowed *gatewayv1.AllowedRoutes
+	if namespaces := l4AllowedNamespaces(l); len(namespaces) > 0 {
+		allowed = allowedRoutesFromValues(namespaces)
+	} else {
+		allowed = buildAllowedRoutes(tgw)
+	}
+	kind := gatewayv1.Kind("TCPRoute")
+	if l4Protocol(l) == gatewayv1alpha1.L4ProtocolUDP {
+		kind = "UDPRoute"
+	}
+	allowed.Kinds = []gatewayv1.RouteGroupKind{
+		{Group: ptrGroup(gatewayv1.GroupName), Kind: kind},
+	}
+	return allowed
+}
+
+// l4AllowedNamespaces returns the deduplicated, sorted, non-empty
+// entries of l.AllowedNamespaces, so the rendered selector does not
+// churn when the list is reordered.
+func l4AllowedNamespaces(l gatewayv1alpha1.L4Listener) []string {
+	seen := map[string]struct{}{}
+	out := []string{}
+	for _, ns := range l.AllowedNamespaces {
+		if ns == "" {
+			continue
+		}
+		if _, dup := seen[ns]; dup {
+			continue
+		}
+		seen[ns] = struct{}{}
+		out = append(out, ns)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// renderL4Listeners builds one Gateway listener per declared L4
+// listener, in spec order.
+func renderL4Listeners(tgw *gatewayv1alpha1.TenantGateway) []gatewayv1.Listener {
+	out := make([]gatewayv1.Listener, 0, len(tgw.Spec.L4Listeners))
+	for _, l := range tgw.Spec.L4Listeners {
+		protocol := gatewayv1.TCPProtocolType
+		if l4Protocol(l) == gatewayv1alpha1.L4ProtocolUDP {
+			protocol = gatewayv1.UDPProtocolType
+		}
+		out = append(out, gatewayv1.Listener{
+			Name:          l4ListenerName(l),
+			Port:          gatewayv1.PortNumber(l.Port),
+			Protocol:      protocol,
+			AllowedRoutes: l4AllowedRoutes(tgw, l),
+		})
+	}
+	return out
+}
+
+// collectL4Claims lists TCPRoutes and UDPRoutes cluster-wide and
+// returns a map of Gateway listener name -> []routeRef of routes
+// attaching to it, plus the routes attaching to this Gateway that
+// no L4 listener admits, keyed to the Gateway API reason they are
+// rejected with.
+//
+// A parentRef selects the L4 listeners of the route's protocol,
+// narrowed by sectionName and port when set. A listener admits the
+// route when its namespace is in the listener's AllowedNamespaces,
+// or, when that list is empty, among the namespaces attachable to
+// the Gateway (see attachableNamespaces). Routes from namespaces no
+// L4 listener admits are skipped without a status, as
+// collectHostnameClaims skips them.
+func (r *Reconciler) collectL4Claims(ctx context.Context, tgw *gatewayv1alpha1.TenantGateway) (map[string][]routeRef, map[routeRef]string, error) {
+	claims := map[string][]routeRef{}
+	rejected := map[routeRef]string{}
+	if len(tgw.Spec.L4Listeners) == 0 {
+		return claims, rejected, nil
+	}
+
+	attachable, err := r.attachableNamespaces(ctx, tgw)
+	if err != nil {
+		return nil, nil, err
+	}
+	admits := func(l gatewayv1alpha1.L4Listener, ns string) bool {
+		if namespaces := l4AllowedNamespaces(l); len(namespaces) > 0 {
+			for _, allowed := range namespaces {
+				if allowed == ns {
+					return true
+				}
+			}
+			return false
+		}
+		_, ok := attachable[ns]
+		return ok
+	}
+
+	claim := func(kind routeKind, protocol gatewayv1alpha1.L4Protocol, namespace, name string, parentRefs []gatewayv1.ParentReference) {
+		var reachable bool
+		for _, l := range tgw.Spec.L4Listeners {
+			if admits(l, namespace) {
+				reachable = true
+				break
+			}
+		}
+		if !reachable {
+			return
+		}
+		for _, parentRef := range allAttachingParentRefs(parentRefs, namespace, tgw) {
+			ref := routeRef{kind: kind, namespace: namespace, name: name, parentRef: parentRef}
+			var matched, admitted int
+			for _, l := range tgw.Spec.L4Listeners {
+				if l4Protocol(l) != protocol {
+					continue
+				}
+				if parentRef.SectionName != nil && *parentRef.SectionName != l4ListenerName(l) {
+					continue
+				}
+				if parentRef.Port != nil && int32(*parentRef.Port) != l.Port {
+					continue
+				}
+				matched++
+				if !admits(l, namespace) {
+					continue
+				}
+				admitted++
+				listener := string(l4ListenerName(l))
+				claims[listener] = append(claims[listener], ref)
+			}
+			switch {
+			case matched == 0:
+				rejected[ref] = "NoMatchingParent"
+			case admitted == 0:
+				rejected[ref] = "NotAllowedByListeners"
+			}
+		}
+	}
+
+	tcpRoutes := &gatewayv1alpha2.TCPRouteList{}
+	if err := r.List(ctx, tcpRoutes); err != nil {
+		return nil, nil, fmt.Errorf("list TCPRoutes: %w", err)
+	}
+	for i := range tcpRoutes.Items {
+		route := &tcpRoutes.Items[i]
+		claim(routeKindTCP, gatewayv1alpha1.L4ProtocolTCP, route.Namespace, route.Name, route.Spec.ParentRefs)
+	}
+
+	udpRoutes := &gatewayv1alpha2.UDPRouteList{}
+	if err := r.List(ctx, udpRoutes); err != nil {
+		return nil, nil, fmt.Errorf("list UDPRoutes: %w", err)
+	}
+	for i := range udpRoutes.Items {
+		route := &udpRoutes.Items[i]
+		claim(routeKindUDP, gatewayv1alpha1.L4ProtocolUDP, route.Namespace, route.Name, route.Spec.ParentRefs)
+	}
+	return claims, rejected, nil
+}
@@ -0,0 +1,322 @@
+/*
+Copyright 2026 The Cozystack Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tenantgateway
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+
+	gatewayv1alpha1 "github.com/cozystack/cozystack/api/gateway/v1alpha1"
+)
+
+func l4TenantGateway(listeners ...gatewayv1alpha1.L4Listener) *gatewayv1alpha1.TenantGateway {
+	return &gatewayv1alpha1.TenantGateway{
+		ObjectMeta: metav1.ObjectMeta{Name: "cozystack", Namespace: "tenant-foo"},
+		Spec: gatewayv1alpha1.TenantGatewaySpec{
+			Apex:             "foo.example.com",
+			CertMode:         gatewayv1alpha1.CertModeHTTP01,
+			GatewayClassName: "cilium",
+			L4Listeners:      listeners,
+		},
+	}
+}
+
+func tcpRouteAttached(name, ns string, section string) *gatewayv1alpha2.TCPRoute {
+	ref := gatewayv1.ParentReference{
+		Group:     ptrGroup(gatewayv1.GroupName),
+		Kind:      ptrKind("Gateway"),
+		Name:      "cozystack",
+		Namespace: ptrNamespace("tenant-foo"),
+	}
+	if section != "" {
+		ref.SectionName = ptrSectionName(section)
+	}
+	return &gatewayv1alpha2.TCPRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: ns},
+		Spec: gatewayv1alpha2.TCPRouteSpec{
+			CommonRouteSpec: gatewayv1.CommonRouteSpec{ParentRefs: []gatewayv1.ParentReference{ref}},
+		},
+	}
+}
+
+func reconcileL4(t *testing.T, tgw *gatewayv1alpha1.TenantGateway, objs ...client.Object) (client.Client, error) {
+	t.Helper()
+	s := newScheme(t)
+	c := fake.NewClientBuilder().
+		WithScheme(s).
+		WithObjects(append([]client.Object{tgw}, objs...)...).
+		WithStatusSubresource(tgw, &gatewayv1alpha2.TCPRoute{}, &gatewayv1alpha2.UDPRoute{}).
+		Build()
+	r := &Reconciler{Client: c, Reader: c, Scheme: s}
+	_, err := r.Reconcile(context.TODO(), ctrl.Request{
+		NamespacedName: types.NamespacedName{Name: tgw.Name, Namespace: tgw.Namespace},
+	})
+	return c, err
+}
+
+// tcpRouteAccepted returns our controller's Accepted condition on the
+// named TCPRoute, or nil when none was written.
+func tcpRouteAccepted(t *testing.T, c client.Client, ns, name string) *metav1.Condition {
+	t.Helper()
+	route := &gatewayv1alpha2.TCPRoute{}
+	if err := c.Get(context.TODO(), types.NamespacedName{Namespace: ns, Name: name}, route); err != nil {
+		t.Fatalf("get TCPRoute %s/%s: %v", ns, name, err)
+	}
+	for _, ps := range route.Status.Parents {
+		if string(ps.ControllerName) == testControllerName {
+			return apimeta.FindStatusCondition(ps.Conditions, "Accepted")
+		}
+	}
+	return nil
+}
+
+// TestReconcile_L4ListenersRendered pins the rendered shape: one
+// Gateway listener per declared L4 listener, named after its
+// protocol, with no hostname or TLS, and admitting only the route
+// kind of its protocol.
+func TestReconcile_L4ListenersRendered(t *testing.T) {
+	tgw := l4TenantGateway(
+		gatewayv1alpha1.L4Listener{Name: "postgres", Port: 5432},
+		gatewayv1alpha1.L4Listener{Name: "game", Port: 27015, Protocol: gatewayv1alpha1.L4ProtocolUDP, AllowedNamespaces: []string{"tenant-game", "tenant-foo"}},
+	)
+	c, err := reconcileL4(t, tgw)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	gw := &gatewayv1.Gateway{}
+	if err := c.Get(context.TODO(), types.NamespacedName{Name: "cozystack", Namespace: "tenant-foo"}, gw); err != nil {
+		t.Fatalf("get Gateway: %v", err)
+	}
+	byName := map[string]gatewayv1.Listener{}
+	for _, l := range gw.Spec.Listeners {
+		byName[string(l.Name)] = l
+	}
+
+	tcp, ok := byName["tcp-postgres"]
+	if !ok {
+		t.Fatalf("expected tcp-postgres listener, got %+v", gw.Spec.Listeners)
+	}
+	if tcp.Port != 5432 || tcp.Protocol != gatewayv1.TCPProtocolType || tcp.Hostname != nil || tcp.TLS != nil {
+		t.Errorf("tcp-postgres listener = %+v, want port 5432 TCP without hostname or TLS", tcp)
+	}
+	if kinds := tcp.AllowedRoutes.Kinds; len(kinds) != 1 || kinds[0].Kind != "TCPRoute" {
+		t.Errorf("tcp-postgres kinds = %+v, want only TCPRoute", kinds)
+	}
+	if got := tcp.AllowedRoutes.Namespaces.Selector.MatchLabels[namespaceGatewayLabel]; got != "tenant-foo" {
+		t.Errorf("tcp-postgres without allowedNamespaces should use the gateway label selector, got %+v", tcp.AllowedRoutes.Namespaces.Selector)
+	}
+
+	udp, ok := byName["udp-game"]
+	if !ok {
+		t.Fatalf("expected udp-game listener, got %+v", gw.Spec.Listeners)
+	}
+	if udp.Port != 27015 || udp.Protocol != gatewayv1.UDPProtocolType {
+		t.Errorf("udp-game listener = %+v, want port 27015 UDP", udp)
+	}
+	if kinds := udp.AllowedRoutes.Kinds; len(kinds) != 1 || kinds[0].Kind != "UDPRoute" {
+		t.Errorf("udp-game kinds = %+v, want only UDPRoute", kinds)
+	}
+	exprs := udp.AllowedRoutes.Namespaces.Selector.MatchExpressions
+	if len(exprs) != 1 || exprs[0].Key != "kubernetes.io/metadata.name" || strings.Join(exprs[0].Values, ",") != "tenant-foo,tenant-game" {
+		t.Errorf("udp-game selector = %+v, want metadata.name In [tenant-foo tenant-game]", exprs)
+	}
+
+	got := &gatewayv1alpha1.TenantGateway{}
+	if err := c.Get(context.TODO(), types.NamespacedName{Name: "cozystack", Namespace: "tenant-foo"}, got); err != nil {
+		t.Fatalf("get tgw: %v", err)
+	}
+	var sawStatus bool
+	for _, l := range got.Status.Listeners {
+		if l.Name == "udp-game" {
+			sawStatus = true
+			if l.Port != 27015 || l.Protocol != "UDP" {
+				t.Errorf("udp-game status = %+v, want port 27015 UDP", l)
+			}
+		}
+	}
+	if !sawStatus {
+		t.Errorf("expected udp-game in Status.Listeners, got %+v", got.Status.Listeners)
+	}
+}
+
+// TestReconcile_L4ListenerValidation pins the spec checks: a
+// duplicate port/protocol pair or a port owned by the HTTP/HTTPS
+// listeners leaves that listener off the Gateway and reports it on
+// L4ListenersValid, while the rest of the Gateway still reconciles.
+func TestReconcile_L4ListenerValidation(t *testing.T) {
+	cases := map[string]struct {
+		listeners []gatewayv1alpha1.L4Listener
+		dropped   string
+	}{
+		"https port": {
+			listeners: []gatewayv1alpha1.L4Listener{{Name: "db", Port: 443}, {Name: "redis", Port: 6379}},
+			dropped:   "tcp-db",
+		},
+		"http port": {
+			listeners: []gatewayv1alpha1.L4Listener{{Name: "db", Port: 80, Protocol: gatewayv1alpha1.L4ProtocolUDP}, {Name: "redis", Port: 6379}},
+			dropped:   "udp-db",
+		},
+		"same port": {
+			listeners: []gatewayv1alpha1.L4Listener{{Name: "redis", Port: 6379}, {Name: "b", Port: 6379, Protocol: gatewayv1alpha1.L4ProtocolTCP}},
+			dropped:   "tcp-b",
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			tgw := l4TenantGateway(tc.listeners...)
+			c, err := reconcileL4(t, tgw)
+			if err != nil {
+				t.Fatalf("expected the reconcile to carry on past the invalid listener, got %v", err)
+			}
+			gw := &gatewayv1.Gateway{}
+			if err := c.Get(context.TODO(), types.NamespacedName{Name: "cozystack", Namespace: "tenant-foo"}, gw); err != nil {
+				t.Fatalf("get Gateway: %v", err)
+			}
+			rendered := map[string]bool{}
+			for _, l := range gw.Spec.Listeners {
+				rendered[string(l.Name)] = true
+			}
+			if !rendered["http"] || !rendered["tcp-redis"] || rendered[tc.dropped] {
+				t.Errorf("expected http and tcp-redis but not %s, got %v", tc.dropped, rendered)
+			}
+			got := &gatewayv1alpha1.TenantGateway{}
+			if err := c.Get(context.TODO(), types.NamespacedName{Name: "cozystack", Namespace: "tenant-foo"}, got); err != nil {
+				t.Fatalf("get tgw: %v", err)
+			}
+			if len(got.Spec.L4Listeners) != 2 {
+				t.Errorf("expected the spec to keep both entries, got %+v", got.Spec.L4Listeners)
+			}
+			cond := apimeta.FindStatusCondition(got.Status.Conditions, l4ListenersValidCondition)
+			if cond == nil || cond.Status != metav1.ConditionFalse || cond.Reason != "InvalidL4Listener" {
+				t.Fatalf("expected L4ListenersValid=False/InvalidL4Listener, got %+v", cond)
+			}
+			if !strings.Contains(cond.Message, "spec.l4Listeners["+strings.SplitN(tc.dropped, "-", 2)[1]+"]") {
+				t.Errorf("expected the message to name the dropped listener, got %q", cond.Message)
+			}
+			if ready := apimeta.FindStatusCondition(got.Status.Conditions, "Ready"); ready != nil && ready.Reason == "ReconcileError" {
+				t.Errorf("expected no ReconcileError, got %+v", ready)
+			}
+		})
+	}
+
+	// TCP and UDP on the same port are distinct sockets.
+	tgw := l4TenantGateway(
+		gatewayv1alpha1.L4Listener{Name: "dns-tcp", Port: 53},
+		gatewayv1alpha1.L4Listener{Name: "dns-udp", Port: 53, Protocol: gatewayv1alpha1.L4ProtocolUDP},
+	)
+	c, err := reconcileL4(t, tgw)
+	if err != nil {
+		t.Fatalf("TCP and UDP on one port should be accepted, got %v", err)
+	}
+	got := &gatewayv1alpha1.TenantGateway{}
+	if err := c.Get(context.TODO(), types.NamespacedName{Name: "cozystack", Namespace: "tenant-foo"}, got); err != nil {
+		t.Fatalf("get tgw: %v", err)
+	}
+	if cond := apimeta.FindStatusCondition(got.Status.Conditions, l4ListenersValidCondition); cond == nil || cond.Status != metav1.ConditionTrue {
+		t.Errorf("expected L4ListenersValid=True, got %+v", cond)
+	}
+}
+
+// TestReconcile_L4RouteConflictCozyWins pins the L4 conflict rule:
+// the same precedence as hostnames (cozy-* first), but every other
+// claimant loses, even one in the winner's namespace — a raw port
+// cannot be split between routes.
+func TestReconcile_L4RouteConflictCozyWins(t *testing.T) {
+	tgw := l4TenantGateway(gatewayv1alpha1.L4Listener{Name: "postgres", Port: 5432})
+	tgw.Spec.AttachedNamespaces = []string{"cozy-postgres"}
+	cozy := tcpRouteAttached("db", "cozy-postgres", "tcp-postgres")
+	tenant := tcpRouteAttached("db", "tenant-foo", "tcp-postgres")
+	sibling := tcpRouteAttached("db-2", "cozy-postgres", "")
+
+	c, err := reconcileL4(t, tgw, cozy, tenant, sibling)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if cond := tcpRouteAccepted(t, c, "cozy-postgres", "db"); cond == nil || cond.Status != metav1.ConditionTrue {
+		t.Errorf("expected Accepted=True on winner, got %+v", cond)
+	}
+	for _, loser := range []struct{ ns, name string }{{"tenant-foo", "db"}, {"cozy-postgres", "db-2"}} {
+		cond := tcpRouteAccepted(t, c, loser.ns, loser.name)
+		if cond == nil || cond.Status != metav1.ConditionFalse || cond.Reason != "ListenerConflict" {
+			t.Errorf("expected ListenerConflict on %s/%s, got %+v", loser.ns, loser.name, cond)
+		}
+	}
+}
+
+// TestReconcile_L4RouteRejections pins the statuses written for
+// routes no L4 listener takes: a sectionName naming no L4 listener of
+// the route's protocol gets NoMatchingParent, and a namespace outside
+// the listener's allowedNamespaces gets NotAllowedByListeners.
+// Namespaces no L4 listener admits at all are left alone.
+func TestReconcile_L4RouteRejections(t *testing.T) {
+	tgw := l4TenantGateway(
+		gatewayv1alpha1.L4Listener{Name: "postgres", Port: 5432, AllowedNamespaces: []string{"tenant-db"}},
+		gatewayv1alpha1.L4Listener{Name: "mqtt", Port: 1883},
+	)
+	wrongSection := tcpRouteAttached("typo", "tenant-foo", "tcp-postgress")
+	notAllowed := tcpRouteAttached("db", "tenant-foo", "tcp-postgres")
+	stranger := tcpRouteAttached("db", "tenant-other", "tcp-postgres")
+
+	c, err := reconcileL4(t, tgw, wrongSection, notAllowed, stranger)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if cond := tcpRouteAccepted(t, c, "tenant-foo", "typo"); cond == nil || cond.Reason != "NoMatchingParent" {
+		t.Errorf("expected NoMatchingParent, got %+v", cond)
+	}
+	if cond := tcpRouteAccepted(t, c, "tenant-foo", "db"); cond == nil || cond.Reason != "NotAllowedByListeners" {
+		t.Errorf("expected NotAllowedByListeners, got %+v", cond)
+	}
+	if cond := tcpRouteAccepted(t, c, "tenant-other", "db"); cond != nil {
+		t.Errorf("expected no status on a route from an unattached namespace, got %+v", cond)
+	}
+}
+
+// TestMapRouteToTenantGateways_UDPRoute pins that L4 route events
+// requeue the TenantGateway they attach to.
+func TestMapRouteToTenantGateways_UDPRoute(t *testing.T) {
+	s := newScheme(t)
+	tgw := l4TenantGateway(gatewayv1alpha1.L4Listener{Name: "game", Port: 27015, Protocol: gatewayv1alpha1.L4ProtocolUDP})
+	c := fake.NewClientBuilder().WithScheme(s).WithObjects(tgw).Build()
+	r := &Reconciler{Client: c, Reader: c, Scheme: s}
+
+	route := &gatewayv1alpha2.UDPRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: "game", Namespace: "tenant-foo"},
+		Spec: gatewayv1alpha2.UDPRouteSpec{
+			CommonRouteSpec: gatewayv1.CommonRouteSpec{
+				ParentRefs: []gatewayv1.ParentReference{{Name: "cozystack"}},
+			},
+		},
+	}
+	reqs := r.mapRouteToTenantGateways(context.TODO(), route)
+	if len(reqs) != 1 || reqs[0].Name != "cozystack" || reqs[0].Namespace != "tenant-foo" {
+		t.Fatalf("expected a request for tenant-foo/cozystack, got %+v", reqs)
+	}
+}
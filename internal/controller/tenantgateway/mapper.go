@@ -18,7 +18,11 @@ package tenantgateway
 
 import (
 	"context"
+	"strings"
 
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
@@ -30,8 +34,8 @@ import (
 	gatewayv1alpha1 "github.com/cozystack/cozystack/api/gateway/v1alpha1"
 )
 
-// routeToTenantGateway returns an EventHandler that maps an HTTPRoute
-// or TLSRoute change back to the TenantGateway resources whose Gateway
+// routeToTenantGateway returns an EventHandler that maps an HTTPRoute,
+// TLSRoute, TCPRoute or UDPRoute change back to the TenantGateway resources whose Gateway
 // the route attaches to. controller-runtime requeues the parent so
 // listener / cert lifecycle stays in sync with route additions and
 // removals.
@@ -54,6 +58,12 @@ func (r *Reconciler) mapRouteToTenantGateways(ctx context.Context, obj client.Ob
 	case *gatewayv1alpha2.TLSRoute:
 		parentRefs = route.Spec.ParentRefs
 		routeNs = route.Namespace
+	case *gatewayv1alpha2.TCPRoute:
+		parentRefs = route.Spec.ParentRefs
+		routeNs = route.Namespace
+	case *gatewayv1alpha2.UDPRoute:
+		parentRefs = route.Spec.ParentRefs
+		routeNs = route.Namespace
 	default:
 		return nil
 	}
@@ -82,3 +92,92 @@ func (r *Reconciler) mapRouteToTenantGateways(ctx context.Context, obj client.Ob
 	}
 	return out
 }
+
+// policyToTenantGateway returns an EventHandler that maps a
+// TenantGatewayPolicy change to the TenantGateway it targets, which
+// renders and reports on every policy attached to it.
+func (r *Reconciler) policyToTenantGateway() handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(mapPolicyToTenantGateway)
+}
+
+func mapPolicyToTenantGateway(_ context.Context, obj client.Object) []reconcile.Request {
+	p, ok := obj.(*gatewayv1alpha1.TenantGatewayPolicy)
+	if !ok || p.Spec.TargetRef.Name == "" {
+		return nil
+	}
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: p.Namespace, Name: p.Spec.TargetRef.Name}}}
+}
+
+// ciliumServiceToTenantGateway returns an EventHandler that maps a
+// change to the Service Cilium generates for a Gateway to the
+// TenantGateway of that Gateway, whose policies report whether the
+// Service filters on their source ranges yet.
+func (r *Reconciler) ciliumServiceToTenantGateway() handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(mapCiliumServiceToTenantGateway)
+}
+
+func mapCiliumServiceToTenantGateway(_ context.Context, obj client.Object) []reconcile.Request {
+	name, ok := strings.CutPrefix(obj.GetName(), ciliumGatewayServicePrefix)
+	if !ok || name == "" {
+		return nil
+	}
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: obj.GetNamespace(), Name: name}}}
+}
+
+// customDomainToTenantGateway returns an EventHandler that maps a
+// CustomDomain change to the TenantGateways serving its namespace,
+// i.e. those in the namespace its gateway label points at. A domain
+// being verified or losing verification adds or drops its listener.
+func (r *Reconciler) customDomainToTenantGateway() handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(r.mapCustomDomainToTenantGateways)
+}
+
+func (r *Reconciler) mapCustomDomainToTenantGateways(ctx context.Context, obj client.Object) []reconcile.Request {
+	ns := &corev1.Namespace{}
+	if err := r.Get(ctx, types.NamespacedName{Name: obj.GetNamespace()}, ns); err != nil {
+		if !apierrors.IsNotFound(err) {
+			log.FromContext(ctx).Error(err, "get namespace for custom domain mapper")
+		}
+		return nil
+	}
+	// Namespaces listed in spec.attachedNamespaces are labelled too
+	// (ensureNamespaceLabels), so the label alone finds the serving
+	// TenantGateway.
+	gatewayNs := ns.Labels[namespaceGatewayLabel]
+	if gatewayNs == "" {
+		return nil
+	}
+	list := &gatewayv1alpha1.TenantGatewayList{}
+	if err := r.List(ctx, list, client.InNamespace(gatewayNs)); err != nil {
+		log.FromContext(ctx).Error(err, "list TenantGateways for custom domain mapper")
+		return nil
+	}
+	out := make([]reconcile.Request, 0, len(list.Items))
+	for i := range list.Items {
+		out = append(out, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: list.Items[i].Namespace, Name: list.Items[i].Name}})
+	}
+	return out
+}
+
+// mapSecretToTenantGateways maps a Secret, watched as metadata, to the
+// TenantGateways in its namespace whose Issuer reads it, so one
+// reported as SecretNotFound renders its Issuer as soon as the
+// Secret is created or fixed rather than on the next resync.
+func (r *Reconciler) mapSecretToTenantGateways(ctx context.Context, obj *metav1.PartialObjectMetadata) []reconcile.Request {
+	list := &gatewayv1alpha1.TenantGatewayList{}
+	if err := r.List(ctx, list, client.InNamespace(obj.Namespace)); err != nil {
+		log.FromContext(ctx).Error(err, "list TenantGateways for secret mapper")
+		return nil
+	}
+	var out []reconcile.Request
+	for i := range list.Items {
+		tgw := &list.Items[i]
+		for _, ref := range issuerSecretRefs(tgw) {
+			if ref.name == obj.Name {
+				out = append(out, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: tgw.Namespace, Name: tgw.Name}})
+				break
+			}
+		}
+	}
+	return out
+}
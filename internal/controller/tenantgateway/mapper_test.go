@@ -20,6 +20,7 @@ import (
 	"context"
 	"testing"
 
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
@@ -41,7 +42,7 @@ func TestMapRouteToTenantGateways_HTTPRouteEnqueuesMatchingTGW(t *testing.T) {
 		},
 	}
 	c := fake.NewClientBuilder().WithScheme(s).WithObjects(tgw).Build()
-	r := &Reconciler{Client: c, Scheme: s}
+	r := &Reconciler{Client: c, Reader: c, Scheme: s}
 
 	route := httpRouteAttached("harbor", "cozy-harbor", "harbor.foo.example.com")
 	reqs := r.mapRouteToTenantGateways(context.TODO(), route)
@@ -63,7 +64,7 @@ func TestMapRouteToTenantGateways_NoMatchingTGWReturnsNil(t *testing.T) {
 		Spec:       gatewayv1alpha1.TenantGatewaySpec{Apex: "foo.example.com"},
 	}
 	c := fake.NewClientBuilder().WithScheme(s).WithObjects(tgw).Build()
-	r := &Reconciler{Client: c, Scheme: s}
+	r := &Reconciler{Client: c, Reader: c, Scheme: s}
 
 	otherGroup := gatewayv1.Group(gatewayv1.GroupName)
 	otherKind := gatewayv1.Kind("Gateway")
@@ -91,7 +92,7 @@ func TestMapRouteToTenantGateways_NoMatchingTGWReturnsNil(t *testing.T) {
 func TestMapRouteToTenantGateways_EmptyParentRefsReturnsNil(t *testing.T) {
 	s := newScheme(t)
 	c := fake.NewClientBuilder().WithScheme(s).Build()
-	r := &Reconciler{Client: c, Scheme: s}
+	r := &Reconciler{Client: c, Reader: c, Scheme: s}
 
 	route := &gatewayv1.HTTPRoute{
 		ObjectMeta: metav1.ObjectMeta{Name: "noref", Namespace: "tenant-foo"},
@@ -100,3 +101,50 @@ func TestMapRouteToTenantGateways_EmptyParentRefsReturnsNil(t *testing.T) {
 		t.Errorf("expected 0 requests, got %+v", reqs)
 	}
 }
+
+// TestMapCiliumServiceToTenantGateway pins the Service mapper: the
+// Service Cilium generates for a Gateway maps to the TenantGateway of
+// the same name, any other Service to nothing.
+func TestMapCiliumServiceToTenantGateway(t *testing.T) {
+	svc := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "cilium-gateway-cozystack", Namespace: "tenant-foo"}}
+	reqs := mapCiliumServiceToTenantGateway(context.TODO(), svc)
+	if len(reqs) != 1 || reqs[0].Namespace != "tenant-foo" || reqs[0].Name != "cozystack" {
+		t.Errorf("expected request for tenant-foo/cozystack, got %+v", reqs)
+	}
+
+	svc = &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "harbor", Namespace: "tenant-foo"}}
+	if reqs := mapCiliumServiceToTenantGateway(context.TODO(), svc); len(reqs) != 0 {
+		t.Errorf("expected 0 requests, got %+v", reqs)
+	}
+}
+
+// TestMapSecretToTenantGateways pins the Secret watch: a Secret maps
+// to the TenantGateways in its namespace whose Issuer reads it, and
+// to nothing else.
+func TestMapSecretToTenantGateways(t *testing.T) {
+	s := newScheme(t)
+	cf := dns01TenantGateway(&gatewayv1alpha1.DNS01Config{
+		Provider:   "cloudflare",
+		Cloudflare: &gatewayv1alpha1.CloudflareDNS01{APITokenSecretRef: secretKeyRef("cf-token", "api-token")},
+	})
+	other := cf.DeepCopy()
+	other.Name = "other"
+	other.Spec.CertMode = gatewayv1alpha1.CertModeHTTP01
+	other.Spec.DNS01 = nil
+	c := fake.NewClientBuilder().WithScheme(s).WithObjects(cf, other).Build()
+	r := &Reconciler{Client: c, Reader: c, Scheme: s}
+
+	secret := func(ns, name string) *metav1.PartialObjectMetadata {
+		return &metav1.PartialObjectMetadata{ObjectMeta: metav1.ObjectMeta{Namespace: ns, Name: name}}
+	}
+	reqs := r.mapSecretToTenantGateways(context.TODO(), secret("tenant-foo", "cf-token"))
+	if len(reqs) != 1 || reqs[0].Namespace != "tenant-foo" || reqs[0].Name != "cozystack" {
+		t.Errorf("expected a request for tenant-foo/cozystack, got %+v", reqs)
+	}
+	if reqs := r.mapSecretToTenantGateways(context.TODO(), secret("tenant-foo", "unrelated")); len(reqs) != 0 {
+		t.Errorf("expected no requests for an unreferenced Secret, got %+v", reqs)
+	}
+	if reqs := r.mapSecretToTenantGateways(context.TODO(), secret("tenant-bar", "cf-token")); len(reqs) != 0 {
+		t.Errorf("expected no requests for a Secret in another namespace, got %+v", reqs)
+	}
+}
@@ -0,0 +1,696 @@
+/*
+Copyright 2026 The Cozystack Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tenantgateway
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	gatewayv1alpha1 "github.com/cozystack/cozystack/api/gateway/v1alpha1"
+)
+
+const (
+	// envoyGatewayControllerName is the controllerName Envoy Gateway
+	// registers its GatewayClasses under. Envoy Gateway classes get
+	// every TenantGatewayPolicy field; Cilium, the platform default,
+	// has no policy resources for rate limits or auth and gets only
+	// the IP lists (cilium.go).
+	envoyGatewayControllerName = "gateway.envoyproxy.io/gatewayclass-controller"
+
+	// basicAuthSecretKey and oidcClientSecretKey are the Secret keys
+	// Envoy Gateway reads; neither is configurable upstream.
+	basicAuthSecretKey  = ".htpasswd"
+	oidcClientSecretKey = "client-secret"
+
+	policyAcceptedCondition = "Accepted"
+)
+
+var (
+	securityPolicyGVK       = schema.GroupVersionKind{Group: "gateway.envoyproxy.io", Version: "v1alpha1", Kind: "SecurityPolicy"}
+	backendTrafficPolicyGVK = schema.GroupVersionKind{Group: "gateway.envoyproxy.io", Version: "v1alpha1", Kind: "BackendTrafficPolicy"}
+)
+
+// gatewayClassName returns the GatewayClass the tenant Gateway is
+// rendered with.
+func gatewayClassName(tgw *gatewayv1alpha1.TenantGateway) string {
+	if tgw.Spec.GatewayClassName == "" {
+		return "cilium"
+	}
+	return tgw.Spec.GatewayClassName
+}
+
+// policyPlan is the outcome of matching the TenantGatewayPolicies that
+// target a TenantGateway against the hostnames it serves. It is built
+// before the Gateway is rendered because per-hostname policies in the
+// wildcard modes need listeners of their own.
+type policyPlan struct {
+	// unsupported explains why no policy can be rendered; empty when
+	// the Gateway's class has policy resources.
+	unsupported string
+	// cilium is set when the Gateway's class is a Cilium one, which
+	// enforces only the IP lists of the gateway-wide policy, through
+	// sourceRanges.
+	cilium             bool
+	sourceRanges       *sourceRanges
+	sourceRangesPolicy *gatewayv1alpha1.TenantGatewayPolicy
+	policies           []plannedPolicy
+	// listenerHostnames need a dedicated HTTPS listener in dns01 and
+	// existingSecret mode, where the wildcard listener would otherwise
+	// serve them together with every other subdomain.
+	listenerHostnames []string
+	// http01Challenges is set when ACME HTTP-01 challenges for the
+	// Gateway's certificates arrive on its plain-HTTP listener.
+	http01Challenges bool
+	// listeners are the listeners of the rendered Gateway, recorded by
+	// reconcileGateway: on a Cilium class the source ranges filter
+	// every one of them.
+	listeners []gatewayv1.Listener
+}
+
+// plannedPolicy is one policy with the listeners it is in effect on.
+type plannedPolicy struct {
+	policy     *gatewayv1alpha1.TenantGatewayPolicy
+	sections   []gatewayv1.SectionName
+	hostnames  []string
+	unresolved []string
+	conflicted bool
+}
+
+// planPolicies resolves every TenantGatewayPolicy targeting tgw to
+// Gateway listeners. Hostnames are checked against what the
+// TenantGateway serves: the route-claimed hostnames (collectHostnameClaims
+// winners) in http01 mode, the apex and the names the wildcard
+// certificate covers in the wildcard modes. TLS-passthrough hostnames
+// never match, since the gateway does not see their HTTP traffic.
+//
+// Policies are taken oldest first. A hostname named by two policies
+// belongs to the older one, and only the oldest policy without
+// hostnames applies gateway-wide; it covers every HTTPS listener no
+// per-hostname policy claimed. On Envoy Gateway that leaves out the
+// plain-HTTP listener, so ACME HTTP-01 challenges keep working behind
+// an allow-list or login. On Cilium its IP lists filter the Gateway's
+// whole Service instead, the plain-HTTP, TLS-passthrough and L4
+// listeners included; customHostnames, the verified custom domains,
+// decide whether HTTP-01 challenges are among what they filter.
+func (r *Reconciler) planPolicies(ctx context.Context, tgw *gatewayv1alpha1.TenantGateway, dynHostnames, customHostnames []string) (*policyPlan, error) {
+	list := &gatewayv1alpha1.TenantGatewayPolicyList{}
+	if err := r.List(ctx, list, client.InNamespace(tgw.Namespace)); err != nil {
+		return nil, fmt.Errorf("list TenantGatewayPolicies: %w", err)
+	}
+	var policies []*gatewayv1alpha1.TenantGatewayPolicy
+	for i := range list.Items {
+		if list.Items[i].Spec.TargetRef.Name == tgw.Name {
+			policies = append(policies, &list.Items[i])
+		}
+	}
+	plan := &policyPlan{}
+	if len(policies) == 0 {
+		return plan, nil
+	}
+	sort.Slice(policies, func(i, j int) bool {
+		a, b := policies[i], policies[j]
+		if !a.CreationTimestamp.Equal(&b.CreationTimestamp) {
+			return a.CreationTimestamp.Before(&b.CreationTimestamp)
+		}
+		return a.Name < b.Name
+	})
+
+	controller, unsupported, err := r.policyGatewayClass(ctx, tgw)
+	if err != nil {
+		return nil, err
+	}
+	plan.unsupported = unsupported
+	plan.cilium = controller == ciliumGatewayControllerName
+	plan.http01Challenges = servesHTTP01Challenges(tgw, customHostnames)
+	envoy := unsupported == "" && !plan.cilium
+
+	childApexes, err := r.collectInheritingChildApexes(ctx, tgw)
+	if err != nil {
+		return nil, fmt.Errorf("collect inheriting child apexes: %w", err)
+	}
+	served := newServedHostnames(tgw, dynHostnames, childApexes)
+
+	claimed := map[gatewayv1.SectionName]struct{}{}
+	for _, p := range policies {
+		if len(p.Spec.Hostnames) == 0 {
+			continue
+		}
+		planned := plannedPolicy{policy: p}
+		for _, h := range p.Spec.Hostnames {
+			section, dedicated, ok := served.section(h)
+			if !ok {
+				planned.unresolved = append(planned.unresolved, h)
+				continue
+			}
+			if _, taken := claimed[section]; taken {
+				planned.conflicted = true
+				continue
+			}
+			claimed[section] = struct{}{}
+			planned.sections = append(planned.sections, section)
+			planned.hostnames = append(planned.hostnames, h)
+			if dedicated && envoy {
+				plan.listenerHostnames = append(plan.listenerHostnames, h)
+			}
+		}
+		plan.policies = append(plan.policies, planned)
+	}
+
+	gatewayWide := false
+	for _, p := range policies {
+		if len(p.Spec.Hostnames) != 0 {
+			continue
+		}
+		planned := plannedPolicy{policy: p}
+		if gatewayWide {
+			planned.conflicted = true
+			plan.policies = append(plan.policies, planned)
+			continue
+		}
+		gatewayWide = true
+		if plan.cilium {
+			ranges, err := policySourceRanges(p)
+			if err != nil {
+				return nil, fmt.Errorf("TenantGatewayPolicy %s: %w", p.Name, err)
+			}
+			if ranges != nil {
+				plan.sourceRanges = ranges
+				plan.sourceRangesPolicy = p
+			}
+		}
+		for _, l := range served.listeners {
+			if _, taken := claimed[l.section]; taken {
+				continue
+			}
+			planned.sections = append(planned.sections, l.section)
+			planned.hostnames = append(planned.hostnames, l.hostname)
+		}
+		plan.policies = append(plan.policies, planned)
+	}
+	sort.Strings(plan.listenerHostnames)
+	return plan, nil
+}
+
+// policyGatewayClass returns the controller implementing tgw's
+// GatewayClass and, when that is neither Envoy Gateway nor Cilium, why
+// the class cannot carry TenantGatewayPolicies.
+func (r *Reconciler) policyGatewayClass(ctx context.Context, tgw *gatewayv1alpha1.TenantGateway) (string, string, error) {
+	name := gatewayClassName(tgw)
+	gc := &gatewayv1.GatewayClass{}
+	err := r.Get(ctx, types.NamespacedName{Name: name}, gc)
+	if apierrors.IsNotFound(err) {
+		return "", fmt.Sprintf("GatewayClass %s does not exist", name), nil
+	}
+	if err != nil {
+		return "", "", fmt.Errorf("get GatewayClass %s: %w", name, err)
+	}
+	controller := string(gc.Spec.ControllerName)
+	if controller != envoyGatewayControllerName && controller != ciliumGatewayControllerName {
+		return controller, fmt.Sprintf("GatewayClass %s is implemented by %s, which has no policy resources for rate limits, IP filtering or auth; TenantGatewayPolicy requires an Envoy Gateway (%s) or Cilium (%s) class", name, controller, envoyGatewayControllerName, ciliumGatewayControllerName), nil
+	}
+	return controller, "", nil
+}
+
+// servedListener is an HTTPS listener a gateway-wide policy covers.
+type servedListener struct {
+	section  gatewayv1.SectionName
+	hostname string
+}
+
+// servedHostnames answers which listener serves a hostname, mirroring
+// the listener layout renderGateway produces.
+type servedHostnames struct {
+	tgw         *gatewayv1alpha1.TenantGateway
+	wildcard    bool
+	routed      map[string]struct{}
+	childApexes map[string]struct{}
+	passthrough map[string]struct{}
+	listeners   []servedListener
+}
+
+func newServedHostnames(tgw *gatewayv1alpha1.TenantGateway, dynHostnames, childApexes []string) *servedHostnames {
+	s := &servedHostnames{
+		tgw:         tgw,
+		wildcard:    tgw.Spec.CertMode == gatewayv1alpha1.CertModeDNS01 || tgw.Spec.CertMode == gatewayv1alpha1.CertModeExistingSecret,
+		routed:      map[string]struct{}{},
+		childApexes: map[string]struct{}{},
+		passthrough: map[string]struct{}{},
+	}
+	for _, svc := range tgw.Spec.TLSPassthroughServices {
+		s.passthrough[svc+"."+tgw.Spec.Apex] = struct{}{}
+	}
+	if s.wildcard {
+		s.listeners = append(s.listeners,
+			servedListener{section: "https", hostname: "*." + tgw.Spec.Apex},
+			servedListener{section: "https-apex", hostname: tgw.Spec.Apex},
+		)
+		for _, apex := range childApexes {
+			s.childApexes[apex] = struct{}{}
+			s.listeners = append(s.listeners, servedListener{section: childListenerName(apex), hostname: "*." + apex})
+		}
+	}
+	// In the wildcard modes the dynamic hostnames are the custom
+	// domains, which have listeners of their own there too.
+	for _, h := range dynHostnames {
+		if _, ok := s.passthrough[h]; ok {
+			continue
+		}
+		s.routed[h] = struct{}{}
+		s.listeners = append(s.listeners, servedListener{section: gatewayv1.SectionName(perListenerName(h)), hostname: h})
+	}
+	return s
+}
+
+// section returns the listener serving h, and whether that listener
+// exists only because a policy names h (dns01 / existingSecret mode).
+func (s *servedHostnames) section(h string) (gatewayv1.SectionName, bool, bool) {
+	h = strings.ToLower(h)
+	if _, ok := s.passthrough[h]; ok {
+		return "", false, false
+	}
+	if _, ok := s.routed[h]; ok {
+		return gatewayv1.SectionName(perListenerName(h)), false, true
+	}
+	if !s.wildcard {
+		return "", false, false
+	}
+	if h == s.tgw.Spec.Apex {
+		return "https-apex", false, true
+	}
+	// The wildcard certificate covers one label below the apex and
+	// below each inheriting child apex.
+	i := strings.Index(h, ".")
+	if i <= 0 {
+		return "", false, false
+	}
+	parent := h[i+1:]
+	if _, ok := s.childApexes[parent]; ok || parent == s.tgw.Spec.Apex {
+		return gatewayv1.SectionName(perListenerName(h)), true, true
+	}
+	return "", false, false
+}
+
+// policySecretRefs returns the Secret keys a policy's auth block reads.
+func policySecretRefs(p *gatewayv1alpha1.TenantGatewayPolicy) []credentialRef {
+	var refs []credentialRef
+	if p.Spec.BasicAuth != nil {
+		refs = append(refs, credentialRef{field: "basicAuth.secretRef", name: p.Spec.BasicAuth.SecretRef.Name, key: basicAuthSecretKey})
+	}
+	if p.Spec.OIDC != nil {
+		refs = append(refs, credentialRef{field: "oidc.clientSecretRef", name: p.Spec.OIDC.ClientSecretRef.Name, key: oidcClientSecretKey})
+	}
+	return refs
+}
+
+// reconcilePolicies renders each planned policy into Envoy Gateway
+// SecurityPolicy / BackendTrafficPolicy objects and reports the
+// outcome on the policy's Accepted condition. The rendered objects
+// are controlled by the TenantGatewayPolicy, so deleting it removes
+// them. A policy whose Secrets are missing keeps whatever was rendered
+// before: dropping an allow-list or login because of a typo would
+// open the hostnames it protects. On a Cilium class the source ranges
+// were rendered with the Gateway, and each policy reports which of
+// its fields Cilium leaves unenforced.
+func (r *Reconciler) reconcilePolicies(ctx context.Context, tgw *gatewayv1alpha1.TenantGateway, plan *policyPlan) error {
+	if err := r.deleteCiliumGatewayConfig(ctx, tgw, plan); err != nil {
+		return err
+	}
+	applied := false
+	if plan.sourceRanges != nil {
+		var err error
+		if applied, err = r.ciliumSourceRangesApplied(ctx, tgw, plan.sourceRanges); err != nil {
+			return err
+		}
+	}
+	for i := range plan.policies {
+		planned := &plan.policies[i]
+		p := planned.policy
+		cond := metav1.Condition{
+			Type:               policyAcceptedCondition,
+			Status:             metav1.ConditionTrue,
+			ObservedGeneration: p.Generation,
+			Reason:             "Accepted",
+			Message:            fmt.Sprintf("Applied to %d listener(s) of Gateway %s/%s", len(planned.sections), tgw.Namespace, tgw.Name),
+		}
+		hostnames := planned.hostnames
+		var warning *metav1.Condition
+
+		switch {
+		case plan.unsupported != "":
+			cond.Status = metav1.ConditionFalse
+			cond.Reason = "UnsupportedGatewayClass"
+			cond.Message = plan.unsupported
+			hostnames = nil
+		case plan.cilium:
+			cond.Message = fmt.Sprintf("Source ranges applied to Service %s/%s of Gateway %s, filtering every listener: %s", tgw.Namespace, ciliumGatewayServiceName(tgw), tgw.Name, strings.Join(ciliumFilteredListeners(plan.listeners), ", "))
+			hostnames = ciliumPolicyCondition(tgw, plan, planned, applied, &cond)
+			warning = ciliumScopeWarning(tgw, plan, planned)
+		default:
+			problem, err := r.validateCredentialRefs(ctx, p.Namespace, policySecretRefs(p))
+			if err != nil {
+				return err
+			}
+			if problem != "" {
+				cond.Status = metav1.ConditionFalse
+				cond.Reason = "InvalidSecretRef"
+				cond.Message = problem
+				hostnames = nil
+				break
+			}
+			if err := r.applyEnvoyPolicies(ctx, tgw, planned); err != nil {
+				return err
+			}
+			switch {
+			case len(planned.unresolved) > 0:
+				cond.Status = metav1.ConditionFalse
+				cond.Reason = "HostnameNotFound"
+				cond.Message = fmt.Sprintf("TenantGateway %s does not serve %s", tgw.Name, strings.Join(planned.unresolved, ", "))
+			case planned.conflicted && len(p.Spec.Hostnames) == 0:
+				cond.Status = metav1.ConditionFalse
+				cond.Reason = "Conflicted"
+				cond.Message = fmt.Sprintf("An older TenantGatewayPolicy already applies gateway-wide to TenantGateway %s", tgw.Name)
+			case planned.conflicted:
+				cond.Status = metav1.ConditionFalse
+				cond.Reason = "Conflicted"
+				cond.Message = "Some hostnames are already covered by an older TenantGatewayPolicy"
+			}
+		}
+
+		if err := r.updatePolicyStatus(ctx, p, cond, warning, hostnames); err != nil {
+			return fmt.Errorf("update TenantGatewayPolicy %s status: %w", p.Name, err)
+		}
+	}
+	return nil
+}
+
+// applyEnvoyPolicies creates, updates or deletes the two Envoy Gateway
+// objects a policy renders to. Either is deleted when the policy no
+// longer sets any of its controls or no longer covers any listener.
+func (r *Reconciler) applyEnvoyPolicies(ctx context.Context, tgw *gatewayv1alpha1.TenantGateway, planned *plannedPolicy) error {
+	security, err := r.renderSecurityPolicy(tgw, planned)
+	if err != nil {
+		return err
+	}
+	traffic, err := r.renderBackendTrafficPolicy(tgw, planned)
+	if err != nil {
+		return err
+	}
+	for _, item := range []struct {
+		gvk     schema.GroupVersionKind
+		name    string
+		desired *unstructured.Unstructured
+	}{
+		{securityPolicyGVK, planned.policy.Name + "-security", security},
+		{backendTrafficPolicyGVK, planned.policy.Name + "-traffic", traffic},
+	} {
+		if item.desired == nil {
+			if err := r.deleteOwnedObject(ctx, planned.policy, item.gvk, item.name); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := r.applyOwnedObject(ctx, planned.policy, item.desired); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// applyOwnedObject creates desired or updates the spec of the object
+// of its name, which owner must control.
+func (r *Reconciler) applyOwnedObject(ctx context.Context, owner client.Object, desired *unstructured.Unstructured) error {
+	logger := log.FromContext(ctx)
+	kind := desired.GetKind()
+	existing := &unstructured.Unstructured{}
+	existing.SetGroupVersionKind(desired.GroupVersionKind())
+	getErr := r.Get(ctx, types.NamespacedName{Namespace: desired.GetNamespace(), Name: desired.GetName()}, existing)
+	switch {
+	case apierrors.IsNotFound(getErr):
+		if err := r.Create(ctx, desired); err != nil {
+			return fmt.Errorf("create %s %s: %w", kind, desired.GetName(), err)
+		}
+		logger.V(1).Info("created policy object", "kind", kind, "name", desired.GetName())
+		return nil
+	case getErr != nil:
+		return fmt.Errorf("get %s %s: %w", kind, desired.GetName(), getErr)
+	}
+	if !metav1.IsControlledBy(existing, owner) {
+		return fmt.Errorf("%s %s/%s exists but is not owned by %s %s; refusing to take over (delete it manually if you want the controller to manage it)", kind, existing.GetNamespace(), existing.GetName(), r.kindOf(owner), owner.GetName())
+	}
+	if equality.Semantic.DeepEqual(existing.Object["spec"], desired.Object["spec"]) {
+		return nil
+	}
+	existing.Object["spec"] = desired.Object["spec"]
+	if err := r.Update(ctx, existing); err != nil {
+		return fmt.Errorf("update %s %s: %w", kind, desired.GetName(), err)
+	}
+	logger.V(1).Info("updated policy object", "kind", kind, "name", desired.GetName())
+	return nil
+}
+
+// deleteOwnedObject deletes the object of gvk and name in owner's
+// namespace if owner controls it.
+func (r *Reconciler) deleteOwnedObject(ctx context.Context, owner client.Object, gvk schema.GroupVersionKind, name string) error {
+	stale := &unstructured.Unstructured{}
+	stale.SetGroupVersionKind(gvk)
+	err := r.Get(ctx, types.NamespacedName{Namespace: owner.GetNamespace(), Name: name}, stale)
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("get %s %s for cleanup: %w", gvk.Kind, name, err)
+	}
+	if !metav1.IsControlledBy(stale, owner) {
+		return nil
+	}
+	if err := r.Delete(ctx, stale); err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("delete stale %s %s: %w", gvk.Kind, name, err)
+	}
+	return nil
+}
+
+func (r *Reconciler) updatePolicyStatus(ctx context.Context, p *gatewayv1alpha1.TenantGatewayPolicy, cond metav1.Condition, warning *metav1.Condition, hostnames []string) error {
+	stale := p.DeepCopy()
+	stale.Status.ObservedGeneration = p.Generation
+	stale.Status.Hostnames = hostnames
+	meta.SetStatusCondition(&stale.Status.Conditions, cond)
+	if warning != nil {
+		meta.SetStatusCondition(&stale.Status.Conditions, *warning)
+	} else {
+		meta.RemoveStatusCondition(&stale.Status.Conditions, policyFiltersWholeGatewayCondition)
+	}
+	if equality.Semantic.DeepEqual(p.Status, stale.Status) {
+		return nil
+	}
+	p.Status = stale.Status
+	return r.Status().Update(ctx, p)
+}
+
+// The structs below are the subset of the Envoy Gateway
+// gateway.envoyproxy.io/v1alpha1 policy specs the controller renders.
+// Envoy Gateway is not a dependency of this module, so the objects are
+// built as unstructured from these.
+
+type envoyTargetRef struct {
+	Group       string `json:"group"`
+	Kind        string `json:"kind"`
+	Name        string `json:"name"`
+	SectionName string `json:"sectionName,omitempty"`
+}
+
+type envoySecretRef struct {
+	Name string `json:"name"`
+}
+
+type envoySecurityPolicySpec struct {
+	TargetRefs    []envoyTargetRef    `json:"targetRefs"`
+	Authorization *envoyAuthorization `json:"authorization,omitempty"`
+	BasicAuth     *envoyBasicAuth     `json:"basicAuth,omitempty"`
+	OIDC          *envoyOIDC          `json:"oidc,omitempty"`
+}
+
+type envoyAuthorization struct {
+	DefaultAction string                   `json:"defaultAction"`
+	Rules         []envoyAuthorizationRule `json:"rules"`
+}
+
+type envoyAuthorizationRule struct {
+	Name      string         `json:"name"`
+	Action    string         `json:"action"`
+	Principal envoyPrincipal `json:"principal"`
+}
+
+type envoyPrincipal struct {
+	ClientCIDRs []string `json:"clientCIDRs"`
+}
+
+type envoyBasicAuth struct {
+	Users envoySecretRef `json:"users"`
+}
+
+type envoyOIDC struct {
+	Provider     envoyOIDCProvider `json:"provider"`
+	ClientID     string            `json:"clientID"`
+	ClientSecret envoySecretRef    `json:"clientSecret"`
+	RedirectURL  string            `json:"redirectURL,omitempty"`
+	Scopes       []string          `json:"scopes,omitempty"`
+}
+
+type envoyOIDCProvider struct {
+	Issuer string `json:"issuer"`
+}
+
+type envoyBackendTrafficPolicySpec struct {
+	TargetRefs    []envoyTargetRef    `json:"targetRefs"`
+	RateLimit     *envoyRateLimit     `json:"rateLimit,omitempty"`
+	RequestBuffer *envoyRequestBuffer `json:"requestBuffer,omitempty"`
+}
+
+type envoyRateLimit struct {
+	Type  string              `json:"type"`
+	Local envoyLocalRateLimit `json:"local"`
+}
+
+type envoyLocalRateLimit struct {
+	Rules []envoyRateLimitRule `json:"rules"`
+}
+
+type envoyRateLimitRule struct {
+	Limit envoyRateLimitValue `json:"limit"`
+}
+
+type envoyRateLimitValue struct {
+	Requests int64  `json:"requests"`
+	Unit     string `json:"unit"`
+}
+
+type envoyRequestBuffer struct {
+	Limit string `json:"limit"`
+}
+
+func envoyTargetRefs(tgw *gatewayv1alpha1.TenantGateway, sections []gatewayv1.SectionName) []envoyTargetRef {
+	refs := make([]envoyTargetRef, 0, len(sections))
+	for _, s := range sections {
+		refs = append(refs, envoyTargetRef{Group: gatewayv1.GroupName, Kind: "Gateway", Name: tgw.Name, SectionName: string(s)})
+	}
+	return refs
+}
+
+// renderSecurityPolicy renders the IP lists and the auth block, or
+// returns nil when the policy sets none of them. Deny rules come
+// first: Envoy Gateway applies the first matching rule, so a denied
+// CIDR inside an allowed one stays denied.
+func (r *Reconciler) renderSecurityPolicy(tgw *gatewayv1alpha1.TenantGateway, planned *plannedPolicy) (*unstructured.Unstructured, error) {
+	p := planned.policy
+	spec := envoySecurityPolicySpec{TargetRefs: envoyTargetRefs(tgw, planned.sections)}
+	if len(p.Spec.IPAllowList) > 0 || len(p.Spec.IPDenyList) > 0 {
+		authz := &envoyAuthorization{DefaultAction: "Allow"}
+		if len(p.Spec.IPDenyList) > 0 {
+			authz.Rules = append(authz.Rules, envoyAuthorizationRule{Name: "ip-deny-list", Action: "Deny", Principal: envoyPrincipal{ClientCIDRs: p.Spec.IPDenyList}})
+		}
+		if len(p.Spec.IPAllowList) > 0 {
+			authz.DefaultAction = "Deny"
+			authz.Rules = append(authz.Rules, envoyAuthorizationRule{Name: "ip-allow-list", Action: "Allow", Principal: envoyPrincipal{ClientCIDRs: p.Spec.IPAllowList}})
+		}
+		spec.Authorization = authz
+	}
+	if p.Spec.BasicAuth != nil {
+		spec.BasicAuth = &envoyBasicAuth{Users: envoySecretRef{Name: p.Spec.BasicAuth.SecretRef.Name}}
+	}
+	if o := p.Spec.OIDC; o != nil {
+		spec.OIDC = &envoyOIDC{
+			Provider:     envoyOIDCProvider{Issuer: o.Issuer},
+			ClientID:     o.ClientID,
+			ClientSecret: envoySecretRef{Name: o.ClientSecretRef.Name},
+			RedirectURL:  o.RedirectURL,
+			Scopes:       o.Scopes,
+		}
+	}
+	if len(planned.sections) == 0 || (spec.Authorization == nil && spec.BasicAuth == nil && spec.OIDC == nil) {
+		return nil, nil
+	}
+	return r.renderOwnedObject(p, securityPolicyGVK, p.Name+"-security", &spec)
+}
+
+// renderBackendTrafficPolicy renders the rate limit and the request
+// body limit, or returns nil when the policy sets neither.
+func (r *Reconciler) renderBackendTrafficPolicy(tgw *gatewayv1alpha1.TenantGateway, planned *plannedPolicy) (*unstructured.Unstructured, error) {
+	p := planned.policy
+	spec := envoyBackendTrafficPolicySpec{TargetRefs: envoyTargetRefs(tgw, planned.sections)}
+	if rl := p.Spec.RateLimit; rl != nil {
+		unit := rl.Unit
+		if unit == "" {
+			unit = gatewayv1alpha1.RateLimitUnitSecond
+		}
+		spec.RateLimit = &envoyRateLimit{
+			Type: "Local",
+			Local: envoyLocalRateLimit{Rules: []envoyRateLimitRule{{
+				Limit: envoyRateLimitValue{Requests: int64(rl.Requests), Unit: string(unit)},
+			}}},
+		}
+	}
+	if size := p.Spec.MaxRequestBodySize; size != nil {
+		spec.RequestBuffer = &envoyRequestBuffer{Limit: size.String()}
+	}
+	if len(planned.sections) == 0 || (spec.RateLimit == nil && spec.RequestBuffer == nil) {
+		return nil, nil
+	}
+	return r.renderOwnedObject(p, backendTrafficPolicyGVK, p.Name+"-traffic", &spec)
+}
+
+// renderOwnedObject renders an object of gvk with spec, controlled by
+// owner and in its namespace.
+func (r *Reconciler) renderOwnedObject(owner client.Object, gvk schema.GroupVersionKind, name string, spec any) (*unstructured.Unstructured, error) {
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(spec)
+	if err != nil {
+		return nil, fmt.Errorf("encode %s %s: %w", gvk.Kind, name, err)
+	}
+	obj := &unstructured.Unstructured{Object: map[string]any{"spec": content}}
+	obj.SetGroupVersionKind(gvk)
+	obj.SetName(name)
+	obj.SetNamespace(owner.GetNamespace())
+	obj.SetLabels(map[string]string{cozystackManagedByLabel: cozystackManagedByValue})
+	if err := controllerutil.SetControllerReference(owner, obj, r.Scheme); err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
+
+// kindOf returns the kind of obj for messages.
+func (r *Reconciler) kindOf(obj client.Object) string {
+	gvk, err := apiutil.GVKForObject(obj, r.Scheme)
+	if err != nil {
+		return fmt.Sprintf("%T", obj)
+	}
+	return gvk.Kind
+}
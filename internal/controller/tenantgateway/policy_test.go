@@ -0,0 +1,432 @@
+/*
+Copyright 2026 The Cozystack Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tenantgateway
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	gatewayv1alpha1 "github.com/cozystack/cozystack/api/gateway/v1alpha1"
+)
+
+func gatewayClass(name, controller string) *gatewayv1.GatewayClass {
+	return &gatewayv1.GatewayClass{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec:       gatewayv1.GatewayClassSpec{ControllerName: gatewayv1.GatewayController(controller)},
+	}
+}
+
+func policyTenantGateway(mode gatewayv1alpha1.CertMode) *gatewayv1alpha1.TenantGateway {
+	tgw := &gatewayv1alpha1.TenantGateway{
+		ObjectMeta: metav1.ObjectMeta{Name: "cozystack", Namespace: "tenant-foo"},
+		Spec: gatewayv1alpha1.TenantGatewaySpec{
+			Apex:               "foo.example.com",
+			CertMode:           mode,
+			GatewayClassName:   "envoy",
+			AttachedNamespaces: []string{"cozy-harbor"},
+		},
+	}
+	if mode == gatewayv1alpha1.CertModeDNS01 {
+		tgw.Spec.DNS01 = &gatewayv1alpha1.DNS01Config{
+			Provider:   "cloudflare",
+			Cloudflare: &gatewayv1alpha1.CloudflareDNS01{APITokenSecretRef: secretKeyRef("cf-token", "api-token")},
+		}
+	}
+	return tgw
+}
+
+func tenantGatewayPolicy(name string, created time.Time, spec gatewayv1alpha1.TenantGatewayPolicySpec) *gatewayv1alpha1.TenantGatewayPolicy {
+	spec.TargetRef = corev1.LocalObjectReference{Name: "cozystack"}
+	return &gatewayv1alpha1.TenantGatewayPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         "tenant-foo",
+			Generation:        1,
+			CreationTimestamp: metav1.NewTime(created),
+		},
+		Spec: spec,
+	}
+}
+
+// newPolicyReconciler seeds a fake client with objs plus the Envoy
+// Gateway class and the dns01 credential Secret every policy test
+// needs.
+func newPolicyReconciler(t *testing.T, objs ...client.Object) (client.Client, *Reconciler) {
+	t.Helper()
+	s := newScheme(t)
+	objs = append(objs,
+		gatewayClass("envoy", envoyGatewayControllerName),
+		dns01Secret("tenant-foo", "cf-token", "api-token"),
+	)
+	c := fake.NewClientBuilder().
+		WithScheme(s).
+		WithObjects(objs...).
+		WithStatusSubresource(&gatewayv1alpha1.TenantGateway{}, &gatewayv1alpha1.TenantGatewayPolicy{}).
+		Build()
+	return c, &Reconciler{Client: c, Reader: c, Scheme: s}
+}
+
+func reconcileTenantGateway(t *testing.T, r *Reconciler) {
+	t.Helper()
+	if _, err := r.Reconcile(context.TODO(), ctrl.Request{
+		NamespacedName: types.NamespacedName{Name: "cozystack", Namespace: "tenant-foo"},
+	}); err != nil {
+		t.Fatalf("reconcile: %v", err)
+	}
+}
+
+// renderedEnvoyPolicy returns the named Envoy Gateway object, or nil
+// when it does not exist.
+func renderedEnvoyPolicy(t *testing.T, c client.Client, gvk schema.GroupVersionKind, name string) *unstructured.Unstructured {
+	t.Helper()
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(gvk)
+	err := c.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: "tenant-foo"}, obj)
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		t.Fatalf("get %s %s: %v", gvk.Kind, name, err)
+	}
+	return obj
+}
+
+func policyAccepted(t *testing.T, c client.Client, name string) (*metav1.Condition, []string) {
+	t.Helper()
+	p := &gatewayv1alpha1.TenantGatewayPolicy{}
+	if err := c.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: "tenant-foo"}, p); err != nil {
+		t.Fatalf("get TenantGatewayPolicy %s: %v", name, err)
+	}
+	cond := apimeta.FindStatusCondition(p.Status.Conditions, policyAcceptedCondition)
+	if cond == nil {
+		t.Fatalf("TenantGatewayPolicy %s has no Accepted condition", name)
+	}
+	return cond, p.Status.Hostnames
+}
+
+func targetSections(t *testing.T, obj *unstructured.Unstructured) []string {
+	t.Helper()
+	refs, _, err := unstructured.NestedSlice(obj.Object, "spec", "targetRefs")
+	if err != nil {
+		t.Fatalf("read targetRefs: %v", err)
+	}
+	var sections []string
+	for _, ref := range refs {
+		m := ref.(map[string]any)
+		if m["kind"] != "Gateway" || m["name"] != "cozystack" {
+			t.Errorf("unexpected targetRef %v", m)
+		}
+		sections = append(sections, m["sectionName"].(string))
+	}
+	return sections
+}
+
+// A class that is neither Envoy Gateway nor Cilium has no policy
+// resources: the policy is reported as not accepted and nothing is
+// rendered.
+func TestReconcile_PolicyUnsupportedGatewayClass(t *testing.T) {
+	tgw := policyTenantGateway(gatewayv1alpha1.CertModeHTTP01)
+	tgw.Spec.GatewayClassName = "other"
+	p := tenantGatewayPolicy("edge", time.Now(), gatewayv1alpha1.TenantGatewayPolicySpec{IPAllowList: []string{"10.0.0.0/8"}})
+	c, r := newPolicyReconciler(t, tgw, p, gatewayClass("other", "example.com/gateway-controller"))
+	reconcileTenantGateway(t, r)
+
+	cond, _ := policyAccepted(t, c, "edge")
+	if cond.Status != metav1.ConditionFalse || cond.Reason != "UnsupportedGatewayClass" {
+		t.Errorf("expected Accepted=False/UnsupportedGatewayClass, got %s/%s", cond.Status, cond.Reason)
+	}
+	if !strings.Contains(cond.Message, "example.com/gateway-controller") {
+		t.Errorf("expected the message to name the class controller, got %q", cond.Message)
+	}
+	if obj := renderedEnvoyPolicy(t, c, securityPolicyGVK, "edge-security"); obj != nil {
+		t.Errorf("expected no SecurityPolicy, got %v", obj.Object)
+	}
+}
+
+// In http01 mode a hostname claimed by an attached route resolves to
+// its per-hostname listener; the IP lists land in a SecurityPolicy
+// with the deny rule first, the rate and body limits in a
+// BackendTrafficPolicy.
+func TestReconcile_PolicyHTTP01RendersEnvoyPolicies(t *testing.T) {
+	tgw := policyTenantGateway(gatewayv1alpha1.CertModeHTTP01)
+	size := resource.MustParse("10Mi")
+	p := tenantGatewayPolicy("harbor", time.Now(), gatewayv1alpha1.TenantGatewayPolicySpec{
+		Hostnames:          []string{"harbor.foo.example.com"},
+		IPAllowList:        []string{"10.0.0.0/8"},
+		IPDenyList:         []string{"10.1.0.0/16"},
+		RateLimit:          &gatewayv1alpha1.RateLimitPolicy{Requests: 100, Unit: gatewayv1alpha1.RateLimitUnitMinute},
+		MaxRequestBodySize: &size,
+	})
+	route := httpRouteAttached("harbor", "cozy-harbor", "harbor.foo.example.com")
+	c, r := newPolicyReconciler(t, tgw, p, route)
+	reconcileTenantGateway(t, r)
+
+	cond, hostnames := policyAccepted(t, c, "harbor")
+	if cond.Status != metav1.ConditionTrue {
+		t.Fatalf("expected Accepted=True, got %s/%s: %s", cond.Status, cond.Reason, cond.Message)
+	}
+	if !reflect.DeepEqual(hostnames, []string{"harbor.foo.example.com"}) {
+		t.Errorf("unexpected status.hostnames %v", hostnames)
+	}
+
+	sp := renderedEnvoyPolicy(t, c, securityPolicyGVK, "harbor-security")
+	if sp == nil {
+		t.Fatal("expected SecurityPolicy harbor-security")
+	}
+	if got, want := targetSections(t, sp), []string{perListenerName("harbor.foo.example.com")}; !reflect.DeepEqual(got, want) {
+		t.Errorf("targetRefs sections = %v, want %v", got, want)
+	}
+	if action, _, _ := unstructured.NestedString(sp.Object, "spec", "authorization", "defaultAction"); action != "Deny" {
+		t.Errorf("expected defaultAction Deny with an allow-list, got %q", action)
+	}
+	rules, _, _ := unstructured.NestedSlice(sp.Object, "spec", "authorization", "rules")
+	if len(rules) != 2 || rules[0].(map[string]any)["action"] != "Deny" || rules[1].(map[string]any)["action"] != "Allow" {
+		t.Errorf("expected deny rule before allow rule, got %v", rules)
+	}
+	if len(sp.GetOwnerReferences()) != 1 || sp.GetOwnerReferences()[0].Kind != "TenantGatewayPolicy" {
+		t.Errorf("expected SecurityPolicy to be owned by the policy, got %v", sp.GetOwnerReferences())
+	}
+
+	btp := renderedEnvoyPolicy(t, c, backendTrafficPolicyGVK, "harbor-traffic")
+	if btp == nil {
+		t.Fatal("expected BackendTrafficPolicy harbor-traffic")
+	}
+	limit, _, _ := unstructured.NestedSlice(btp.Object, "spec", "rateLimit", "local", "rules")
+	if len(limit) != 1 || !reflect.DeepEqual(limit[0], map[string]any{"limit": map[string]any{"requests": int64(100), "unit": "Minute"}}) {
+		t.Errorf("unexpected rate limit rules %v", limit)
+	}
+	if body, _, _ := unstructured.NestedString(btp.Object, "spec", "requestBuffer", "limit"); body != "10Mi" {
+		t.Errorf("expected requestBuffer.limit 10Mi, got %q", body)
+	}
+
+	// A second pass changes nothing.
+	rv := sp.GetResourceVersion()
+	reconcileTenantGateway(t, r)
+	if got := renderedEnvoyPolicy(t, c, securityPolicyGVK, "harbor-security").GetResourceVersion(); got != rv {
+		t.Errorf("expected SecurityPolicy to be left alone on a no-op reconcile, resourceVersion %s -> %s", rv, got)
+	}
+}
+
+// A hostname no route claims is not served in http01 mode.
+func TestReconcile_PolicyHostnameNotFound(t *testing.T) {
+	tgw := policyTenantGateway(gatewayv1alpha1.CertModeHTTP01)
+	p := tenantGatewayPolicy("ghost", time.Now(), gatewayv1alpha1.TenantGatewayPolicySpec{
+		Hostnames:   []string{"ghost.foo.example.com"},
+		IPAllowList: []string{"10.0.0.0/8"},
+	})
+	c, r := newPolicyReconciler(t, tgw, p)
+	reconcileTenantGateway(t, r)
+
+	cond, _ := policyAccepted(t, c, "ghost")
+	if cond.Status != metav1.ConditionFalse || cond.Reason != "HostnameNotFound" {
+		t.Errorf("expected Accepted=False/HostnameNotFound, got %s/%s", cond.Status, cond.Reason)
+	}
+	if !strings.Contains(cond.Message, "ghost.foo.example.com") {
+		t.Errorf("expected the message to name the hostname, got %q", cond.Message)
+	}
+	if obj := renderedEnvoyPolicy(t, c, securityPolicyGVK, "ghost-security"); obj != nil {
+		t.Errorf("expected no SecurityPolicy, got %v", obj.Object)
+	}
+}
+
+// Two policies naming the same hostname: the older one keeps it.
+func TestReconcile_PolicyHostnameConflictOlderWins(t *testing.T) {
+	tgw := policyTenantGateway(gatewayv1alpha1.CertModeHTTP01)
+	now := time.Now()
+	spec := gatewayv1alpha1.TenantGatewayPolicySpec{
+		Hostnames: []string{"harbor.foo.example.com"},
+		RateLimit: &gatewayv1alpha1.RateLimitPolicy{Requests: 10},
+	}
+	older := tenantGatewayPolicy("older", now.Add(-time.Hour), spec)
+	newer := tenantGatewayPolicy("newer", now, spec)
+	route := httpRouteAttached("harbor", "cozy-harbor", "harbor.foo.example.com")
+	c, r := newPolicyReconciler(t, tgw, newer, older, route)
+	reconcileTenantGateway(t, r)
+
+	if cond, _ := policyAccepted(t, c, "older"); cond.Status != metav1.ConditionTrue {
+		t.Errorf("expected older policy Accepted=True, got %s/%s", cond.Status, cond.Reason)
+	}
+	if cond, _ := policyAccepted(t, c, "newer"); cond.Status != metav1.ConditionFalse || cond.Reason != "Conflicted" {
+		t.Errorf("expected newer policy Accepted=False/Conflicted, got %s/%s", cond.Status, cond.Reason)
+	}
+	if renderedEnvoyPolicy(t, c, backendTrafficPolicyGVK, "older-traffic") == nil {
+		t.Error("expected BackendTrafficPolicy for the older policy")
+	}
+	if obj := renderedEnvoyPolicy(t, c, backendTrafficPolicyGVK, "newer-traffic"); obj != nil {
+		t.Errorf("expected no BackendTrafficPolicy for the newer policy, got %v", obj.Object)
+	}
+}
+
+// In dns01 mode a policy for one subdomain gets a dedicated listener
+// on the wildcard cert, so it does not cover the other subdomains.
+func TestReconcile_PolicyDNS01DedicatedListener(t *testing.T) {
+	tgw := policyTenantGateway(gatewayv1alpha1.CertModeDNS01)
+	p := tenantGatewayPolicy("grafana", time.Now(), gatewayv1alpha1.TenantGatewayPolicySpec{
+		Hostnames: []string{"grafana.foo.example.com"},
+		BasicAuth: &gatewayv1alpha1.BasicAuthPolicy{SecretRef: corev1.LocalObjectReference{Name: "grafana-users"}},
+	})
+	c, r := newPolicyReconciler(t, tgw, p, dns01Secret("tenant-foo", "grafana-users", ".htpasswd"))
+	reconcileTenantGateway(t, r)
+
+	gw := &gatewayv1.Gateway{}
+	if err := c.Get(context.TODO(), types.NamespacedName{Name: "cozystack", Namespace: "tenant-foo"}, gw); err != nil {
+		t.Fatalf("get Gateway: %v", err)
+	}
+	certName, err := wildcardListenerCertName(tgw)
+	if err != nil {
+		t.Fatalf("wildcard cert name: %v", err)
+	}
+	section := perListenerName("grafana.foo.example.com")
+	var found bool
+	for _, l := range gw.Spec.Listeners {
+		if string(l.Name) != section {
+			continue
+		}
+		found = true
+		if l.Hostname == nil || string(*l.Hostname) != "grafana.foo.example.com" {
+			t.Errorf("expected listener hostname grafana.foo.example.com, got %v", l.Hostname)
+		}
+		if l.TLS == nil || len(l.TLS.CertificateRefs) != 1 || string(l.TLS.CertificateRefs[0].Name) != certName {
+			t.Errorf("expected listener to serve the wildcard cert, got %+v", l.TLS)
+		}
+	}
+	if !found {
+		t.Fatalf("expected dedicated listener %s, got %+v", section, gw.Spec.Listeners)
+	}
+
+	sp := renderedEnvoyPolicy(t, c, securityPolicyGVK, "grafana-security")
+	if sp == nil {
+		t.Fatal("expected SecurityPolicy grafana-security")
+	}
+	if got := targetSections(t, sp); !reflect.DeepEqual(got, []string{section}) {
+		t.Errorf("targetRefs sections = %v, want [%s]", got, section)
+	}
+	if users, _, _ := unstructured.NestedString(sp.Object, "spec", "basicAuth", "users", "name"); users != "grafana-users" {
+		t.Errorf("expected basicAuth.users grafana-users, got %q", users)
+	}
+}
+
+// A policy without hostnames covers every HTTPS listener a
+// per-hostname policy did not claim, and never the plain-HTTP one.
+func TestReconcile_PolicyGatewayWideSkipsHTTPListener(t *testing.T) {
+	tgw := policyTenantGateway(gatewayv1alpha1.CertModeDNS01)
+	now := time.Now()
+	wide := tenantGatewayPolicy("wide", now, gatewayv1alpha1.TenantGatewayPolicySpec{IPDenyList: []string{"192.0.2.0/24"}})
+	apex := tenantGatewayPolicy("apex", now, gatewayv1alpha1.TenantGatewayPolicySpec{
+		Hostnames:   []string{"foo.example.com"},
+		IPAllowList: []string{"10.0.0.0/8"},
+	})
+	c, r := newPolicyReconciler(t, tgw, wide, apex)
+	reconcileTenantGateway(t, r)
+
+	sp := renderedEnvoyPolicy(t, c, securityPolicyGVK, "wide-security")
+	if sp == nil {
+		t.Fatal("expected SecurityPolicy wide-security")
+	}
+	if got := targetSections(t, sp); !reflect.DeepEqual(got, []string{"https"}) {
+		t.Errorf("gateway-wide sections = %v, want [https]", got)
+	}
+	if _, hostnames := policyAccepted(t, c, "wide"); !reflect.DeepEqual(hostnames, []string{"*.foo.example.com"}) {
+		t.Errorf("unexpected gateway-wide status.hostnames %v", hostnames)
+	}
+	if got := targetSections(t, renderedEnvoyPolicy(t, c, securityPolicyGVK, "apex-security")); !reflect.DeepEqual(got, []string{"https-apex"}) {
+		t.Errorf("apex sections = %v, want [https-apex]", got)
+	}
+}
+
+// A missing auth Secret leaves the policy not accepted without failing
+// the TenantGateway reconcile.
+func TestReconcile_PolicyMissingSecret(t *testing.T) {
+	tgw := policyTenantGateway(gatewayv1alpha1.CertModeDNS01)
+	p := tenantGatewayPolicy("sso", time.Now(), gatewayv1alpha1.TenantGatewayPolicySpec{
+		OIDC: &gatewayv1alpha1.OIDCPolicy{
+			Issuer:          "https://keycloak.example.com/realms/foo",
+			ClientID:        "gateway",
+			ClientSecretRef: corev1.LocalObjectReference{Name: "sso-client"},
+		},
+	})
+	c, r := newPolicyReconciler(t, tgw, p)
+	reconcileTenantGateway(t, r)
+
+	cond, _ := policyAccepted(t, c, "sso")
+	if cond.Status != metav1.ConditionFalse || cond.Reason != "InvalidSecretRef" {
+		t.Errorf("expected Accepted=False/InvalidSecretRef, got %s/%s", cond.Status, cond.Reason)
+	}
+	if !strings.Contains(cond.Message, "sso-client") {
+		t.Errorf("expected the message to name the Secret, got %q", cond.Message)
+	}
+	if obj := renderedEnvoyPolicy(t, c, securityPolicyGVK, "sso-security"); obj != nil {
+		t.Errorf("expected no SecurityPolicy, got %v", obj.Object)
+	}
+}
+
+// Dropping the last control an Envoy Gateway object carries deletes
+// that object.
+func TestReconcile_PolicyRemovedControlDeletesObject(t *testing.T) {
+	tgw := policyTenantGateway(gatewayv1alpha1.CertModeDNS01)
+	p := tenantGatewayPolicy("edge", time.Now(), gatewayv1alpha1.TenantGatewayPolicySpec{
+		IPAllowList: []string{"10.0.0.0/8"},
+		RateLimit:   &gatewayv1alpha1.RateLimitPolicy{Requests: 50},
+	})
+	c, r := newPolicyReconciler(t, tgw, p)
+	reconcileTenantGateway(t, r)
+	if renderedEnvoyPolicy(t, c, backendTrafficPolicyGVK, "edge-traffic") == nil {
+		t.Fatal("expected BackendTrafficPolicy edge-traffic")
+	}
+
+	live := &gatewayv1alpha1.TenantGatewayPolicy{}
+	if err := c.Get(context.TODO(), types.NamespacedName{Name: "edge", Namespace: "tenant-foo"}, live); err != nil {
+		t.Fatalf("get policy: %v", err)
+	}
+	live.Spec.RateLimit = nil
+	if err := c.Update(context.TODO(), live); err != nil {
+		t.Fatalf("update policy: %v", err)
+	}
+	reconcileTenantGateway(t, r)
+
+	if obj := renderedEnvoyPolicy(t, c, backendTrafficPolicyGVK, "edge-traffic"); obj != nil {
+		t.Errorf("expected BackendTrafficPolicy to be deleted, got %v", obj.Object)
+	}
+	if renderedEnvoyPolicy(t, c, securityPolicyGVK, "edge-security") == nil {
+		t.Error("expected SecurityPolicy edge-security to remain")
+	}
+}
+
+func TestMapPolicyToTenantGateway(t *testing.T) {
+	p := tenantGatewayPolicy("edge", time.Now(), gatewayv1alpha1.TenantGatewayPolicySpec{})
+	got := mapPolicyToTenantGateway(context.TODO(), p)
+	want := types.NamespacedName{Name: "cozystack", Namespace: "tenant-foo"}
+	if len(got) != 1 || got[0].NamespacedName != want {
+		t.Errorf("expected a request for %v, got %v", want, got)
+	}
+}
@@ -39,9 +39,12 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	crsource "sigs.k8s.io/controller-runtime/pkg/source"
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 
@@ -89,13 +92,24 @@ const (
 // +kubebuilder:rbac:groups=gateway.cozystack.io,resources=tenantgateways/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=gateway.cozystack.io,resources=tenantgateways/finalizers,verbs=update
 // +kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=gateways;httproutes;tlsroutes,verbs=get;list;watch;create;update;patch;delete
-// +kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=gateways/status;httproutes/status;tlsroutes/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=tcproutes;udproutes,verbs=get;list;watch
+// +kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=gateways/status;httproutes/status;tlsroutes/status;tcproutes/status;udproutes/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=cert-manager.io,resources=certificates;issuers,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=gateway.cozystack.io,resources=tenantgatewaypolicies,verbs=get;list;watch
+// +kubebuilder:rbac:groups=gateway.cozystack.io,resources=tenantgatewaypolicies/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=gateway.cozystack.io,resources=customdomains,verbs=get;list;watch
+// +kubebuilder:rbac:groups=gateway.envoyproxy.io,resources=securitypolicies;backendtrafficpolicies,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
 
 // Reconciler reconciles TenantGateway resources, owning the downstream
 // Gateway and Certificate state.
 type Reconciler struct {
 	client.Client
+	// Reader is the manager's uncached APIReader. It serves only the
+	// credential checks (validateCredentialRefs): the manager's
+	// Secret cache is scoped to the wildcard replicas and never holds
+	// the provider Secrets in tenant namespaces.
+	Reader client.Reader
 	Scheme *runtime.Scheme
 }
 
@@ -128,16 +142,53 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 // out from Reconcile keeps the error-handling/status-update wrapper
 // in one place.
 func (r *Reconciler) runReconcileSteps(ctx context.Context, tgw *gatewayv1alpha1.TenantGateway) error {
+	// Invalid L4 listeners are dropped from a working copy of the
+	// spec so every later step renders and claims the valid ones only;
+	// status writes ignore the spec, so the copy never reaches it.
+	l4Valid, l4Invalid := validateL4Listeners(tgw)
+	if len(l4Invalid) > 0 {
+		tgw = tgw.DeepCopy()
+		tgw.Spec.L4Listeners = l4Valid
+	}
 	claims, err := r.collectHostnameClaims(ctx, tgw)
 	if err != nil {
 		return fmt.Errorf("collect attached hostnames: %w", err)
 	}
+	// Verified CustomDomains get a listener whether or not a route
+	// claims them yet, and only routes from the namespace that
+	// verified a domain may claim it.
+	domains, err := r.collectCustomDomains(ctx, tgw)
+	if err != nil {
+		return fmt.Errorf("collect custom domains: %w", err)
+	}
+	preempted := preemptCustomDomainClaims(claims, domains)
 	winners, losers := resolveHostnameOwners(claims)
+	for ref, names := range preempted {
+		losers[ref] = append(losers[ref], names...)
+	}
 
-	dynHostnames := make([]string, 0, len(winners))
+	// L4 listeners are declared in the spec rather than derived from
+	// routes; the claims only decide which TCPRoute / UDPRoute owns
+	// each one. Listener names never collide with hostnames, so the
+	// loser sets merge into one map keyed by route.
+	l4Claims, rejected, err := r.collectL4Claims(ctx, tgw)
+	if err != nil {
+		return fmt.Errorf("collect L4 route claims: %w", err)
+	}
+	_, l4Losers := resolveListenerOwners(l4Claims)
+	for ref, names := range l4Losers {
+		losers[ref] = append(losers[ref], names...)
+	}
+
+	dynHostnames := make([]string, 0, len(winners)+len(domains))
 	for h := range winners {
 		dynHostnames = append(dynHostnames, h)
 	}
+	for h := range domains {
+		if _, ok := winners[h]; !ok {
+			dynHostnames = append(dynHostnames, h)
+		}
+	}
 	sort.Strings(dynHostnames)
 
 	// allRefs is the full set of (route, parentRef) tuples that
@@ -151,6 +202,17 @@ func (r *Reconciler) runReconcileSteps(ctx context.Context, tgw *gatewayv1alpha1
 			allRefs[ref] = struct{}{}
 		}
 	}
+	for _, refs := range l4Claims {
+		for _, ref := range refs {
+			allRefs[ref] = struct{}{}
+		}
+	}
+	for ref := range rejected {
+		allRefs[ref] = struct{}{}
+	}
+	for ref := range preempted {
+		allRefs[ref] = struct{}{}
+	}
 
 	// Label every expected namespace BEFORE rendering the Gateway —
 	// the Gateway's allowedRoutes selector is label-based, so any
@@ -161,11 +223,28 @@ func (r *Reconciler) runReconcileSteps(ctx context.Context, tgw *gatewayv1alpha1
 	if err := r.ensureNamespaceLabels(ctx, tgw); err != nil {
 		return err
 	}
+	// The hostname VAPs admit a custom domain on the Gateway and on
+	// routes only once the namespace annotation lists it, so it is
+	// written before the Gateway and the Issuer's solver need it.
+	if err := r.ensureCustomDomainAnnotations(ctx, tgw, domains); err != nil {
+		return err
+	}
 
-	if err := r.reconcileGateway(ctx, tgw, dynHostnames); err != nil {
+	// Policies are matched against the served hostnames before the
+	// Gateway is rendered: in the wildcard modes a per-hostname policy
+	// needs a listener of its own to attach to.
+	plan, err := r.planPolicies(ctx, tgw, dynHostnames, sortedHostnames(domains))
+	if err != nil {
+		return err
+	}
+	if err := r.applyCiliumGatewayConfig(ctx, tgw, plan); err != nil {
 		return err
 	}
-	if err := r.reconcileIssuer(ctx, tgw); err != nil {
+	if err := r.reconcileGateway(ctx, tgw, dynHostnames, plan); err != nil {
+		return err
+	}
+	issuerSecrets, err := r.reconcileIssuer(ctx, tgw, sortedHostnames(domains))
+	if err != nil {
 		return err
 	}
 	if err := r.reconcileWildcardCertificate(ctx, tgw); err != nil {
@@ -174,13 +253,16 @@ func (r *Reconciler) runReconcileSteps(ctx context.Context, tgw *gatewayv1alpha1
 	if err := r.reconcilePerListenerCertificates(ctx, tgw, dynHostnames); err != nil {
 		return err
 	}
-	if err := r.updateRouteStatuses(ctx, tgw, allRefs, losers); err != nil {
+	if err := r.updateRouteStatuses(ctx, tgw, allRefs, losers, rejected); err != nil {
 		return err
 	}
 	if err := r.reconcileHTTPToHTTPSRedirect(ctx, tgw); err != nil {
 		return err
 	}
-	return r.reconcileStatus(ctx, tgw, dynHostnames)
+	if err := r.reconcilePolicies(ctx, tgw, plan); err != nil {
+		return err
+	}
+	return r.reconcileStatus(ctx, tgw, dynHostnames, l4Invalid, issuerSecrets)
 }
 
 // markFailed writes a Ready=False condition with Reason=ReconcileError
@@ -257,28 +339,12 @@ func (r *Reconciler) reconcileHTTPToHTTPSRedirect(ctx context.Context, tgw *gate
 // collectHostnameClaims lists HTTPRoutes and TLSRoutes cluster-wide
 // and returns a map of hostname -> []routeRef of routes claiming
 // it via parentRefs targeting this TenantGateway's Gateway. Routes
-// whose namespace is not allowed to attach to this Gateway are
-// filtered out — Gateway listener allowedRoutes selectors reject
+// whose namespace is not allowed to attach to this Gateway (see
+// attachableNamespaces) are filtered out — Gateway listener allowedRoutes selectors reject
 // those routes at runtime, but the reconciler must not provision
 // certs / listeners for them either (each unused cert eats LE rate
 // limits and leaks the operator's reachable hostname set). Empty
 // map in DNS-01 mode (wildcard handles everything).
-//
-// A namespace is allowed to attach when:
-//   - It is the TenantGateway's own namespace, OR
-//   - It is in Spec.AttachedNamespaces (static admin-configured
-//     attach list of cozy-* system namespaces), OR
-//   - It carries the label namespace.cozystack.io/gateway pointing
-//     at this Gateway's namespace (inheritance — apps/tenant chart
-//     writes the label on every tenant namespace, inherited or
-//     self-owning, so child tenants reach the parent Gateway).
-//
-// The third source is what makes HTTP-01 inheritance work end-to-end:
-// without it, a child tenant's HTTPRoute would be silently dropped
-// here and no per-listener Certificate would be issued — the
-// Gateway's label-based allowedRoutes selector would let the route
-// through at runtime but no listener would accept it (no matching
-// hostname), so Accepted stays False indefinitely.
 func (r *Reconciler) collectHostnameClaims(ctx context.Context, tgw *gatewayv1alpha1.TenantGateway) (map[string][]routeRef, error) {
 	// DNS-01 and existingSecret both serve every hostname off a single
 	// wildcard listener, so neither needs per-host listeners or claims.
@@ -286,24 +352,9 @@ func (r *Reconciler) collectHostnameClaims(ctx context.Context, tgw *gatewayv1al
 		return nil, nil
 	}
 
-	allowed := map[string]struct{}{tgw.Namespace: {}}
-	for _, ns := range tgw.Spec.AttachedNamespaces {
-		if ns == "" {
-			continue
-		}
-		allowed[ns] = struct{}{}
-	}
-	// Inheritance: every namespace pointing at this Gateway via the
-	// label is also allowed. The same label drives the Gateway's
-	// allowedRoutes selector, so the two paths agree on which
-	// namespaces can attach.
-	nsList := &corev1.NamespaceList{}
-	selector := labels.SelectorFromSet(labels.Set{namespaceGatewayLabel: tgw.Namespace})
-	if err := r.List(ctx, nsList, client.MatchingLabelsSelector{Selector: selector}); err != nil {
-		return nil, fmt.Errorf("list namespaces by gateway label: %w", err)
-	}
-	for i := range nsList.Items {
-		allowed[nsList.Items[i].Name] = struct{}{}
+	allowed, err := r.attachableNamespaces(ctx, tgw)
+	if err != nil {
+		return nil, err
 	}
 
 	out := map[string][]routeRef{}
@@ -367,6 +418,45 @@ func (r *Reconciler) collectHostnameClaims(ctx context.Context, tgw *gatewayv1al
 	return out, nil
 }
 
+// attachableNamespaces returns the namespaces whose routes may attach
+// to tgw's Gateway. A namespace is allowed to attach when:
+//   - It is the TenantGateway's own namespace, OR
+//   - It is in Spec.AttachedNamespaces (static admin-configured
+//     attach list of cozy-* system namespaces), OR
+//   - It carries the label namespace.cozystack.io/gateway pointing
+//     at this Gateway's namespace (inheritance — apps/tenant chart
+//     writes the label on every tenant namespace, inherited or
+//     self-owning, so child tenants reach the parent Gateway).
+//
+// The third source is what makes HTTP-01 inheritance work end-to-end:
+// without it, a child tenant's HTTPRoute would be silently dropped
+// by collectHostnameClaims and no per-listener Certificate would be issued — the
+// Gateway's label-based allowedRoutes selector would let the route
+// through at runtime but no listener would accept it (no matching
+// hostname), so Accepted stays False indefinitely.
+func (r *Reconciler) attachableNamespaces(ctx context.Context, tgw *gatewayv1alpha1.TenantGateway) (map[string]struct{}, error) {
+	allowed := map[string]struct{}{tgw.Namespace: {}}
+	for _, ns := range tgw.Spec.AttachedNamespaces {
+		if ns == "" {
+			continue
+		}
+		allowed[ns] = struct{}{}
+	}
+	// Inheritance: every namespace pointing at this Gateway via the
+	// label is also allowed. The same label drives the Gateway's
+	// allowedRoutes selector, so the two paths agree on which
+	// namespaces can attach.
+	nsList := &corev1.NamespaceList{}
+	selector := labels.SelectorFromSet(labels.Set{namespaceGatewayLabel: tgw.Namespace})
+	if err := r.List(ctx, nsList, client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return nil, fmt.Errorf("list namespaces by gateway label: %w", err)
+	}
+	for i := range nsList.Items {
+		allowed[nsList.Items[i].Name] = struct{}{}
+	}
+	return allowed, nil
+}
+
 // pickAttachingParentRef returns the first ParentRef in refs that
 // attaches to tgw's Gateway, plus a boolean ok. Used by the mapper
 // for cheap "does this route attach at all?" queries; for hostname
@@ -418,21 +508,22 @@ func parentRefAttachesTo(ref gatewayv1.ParentReference, routeNs string, tgw *gat
 }
 
 // reconcilePerListenerCertificates creates a Certificate for each
-// dynamic hostname (HTTP-01 mode only) and deletes Certificates owned
-// by this TenantGateway that no longer correspond to a live HTTPRoute
-// hostname OR were left behind by a switch from HTTP-01 to DNS-01
-// mode. The garbage-collect loop runs unconditionally so per-listener
-// certs do not leak across mode transitions.
+// dynamic hostname and deletes Certificates owned by this
+// TenantGateway that no longer correspond to a live HTTPRoute
+// hostname or custom domain OR were left behind by a mode switch. In
+// DNS-01 mode the dynamic hostnames are the custom domains alone,
+// which the wildcard cert does not cover. The garbage-collect loop
+// runs unconditionally so per-listener certs do not leak across mode
+// transitions.
 func (r *Reconciler) reconcilePerListenerCertificates(ctx context.Context, tgw *gatewayv1alpha1.TenantGateway, hostnames []string) error {
 	logger := log.FromContext(ctx)
 
 	desiredNames := map[string]struct{}{}
-	// Provision per-listener certs only in HTTP-01 mode (wildcard
-	// cert in DNS-01 mode covers everything). DNS-01 mode falls
-	// through to the GC loop below with an empty desired set, which
-	// then deletes any stale per-listener certs from a previous
-	// HTTP-01 reconcile.
-	if tgw.Spec.CertMode == gatewayv1alpha1.CertModeHTTP01 || tgw.Spec.CertMode == "" {
+	// existingSecret mode has no Issuer to provision with and serves
+	// no dynamic hostnames; it falls through to the GC loop below
+	// with an empty desired set, which then deletes any stale
+	// per-listener certs from a previous reconcile.
+	if tgw.Spec.CertMode != gatewayv1alpha1.CertModeExistingSecret {
 		for _, h := range hostnames {
 			desired, err := r.renderPerListenerCertificate(tgw, h)
 			if err != nil {
@@ -474,10 +565,9 @@ func (r *Reconciler) reconcilePerListenerCertificates(ctx context.Context, tgw *
 	}
 
 	// Garbage-collect: delete owned Certificates whose name no longer
-	// matches a desired per-listener cert. Runs in both HTTP-01 and
-	// DNS-01 modes — the empty desiredNames set in DNS-01 mode means
-	// every per-listener cert from a previous HTTP-01 phase is
-	// reclaimed.
+	// matches a desired per-listener cert. Runs in every mode — a
+	// switch to DNS-01 reclaims the per-listener certs of routed
+	// hostnames from the previous HTTP-01 phase.
 	owned := &cmv1.CertificateList{}
 	if err := r.List(ctx, owned, client.InNamespace(tgw.Namespace), client.MatchingLabels{cozystackManagedByLabel: cozystackManagedByValue}); err != nil {
 		return fmt.Errorf("list owned Certificates: %w", err)
@@ -513,16 +603,18 @@ func ownedByTenantGateway(refs []metav1.OwnerReference, tgw *gatewayv1alpha1.Ten
 	return false
 }
 
-func (r *Reconciler) reconcileGateway(ctx context.Context, tgw *gatewayv1alpha1.TenantGateway, dynHostnames []string) error {
+func (r *Reconciler) reconcileGateway(ctx context.Context, tgw *gatewayv1alpha1.TenantGateway, dynHostnames []string, plan *policyPlan) error {
 	logger := log.FromContext(ctx)
 	childApexes, err := r.collectInheritingChildApexes(ctx, tgw)
 	if err != nil {
 		return fmt.Errorf("collect inheriting child apexes: %w", err)
 	}
-	desired, err := r.renderGateway(tgw, dynHostnames, childApexes)
+	desired, err := r.renderGateway(tgw, dynHostnames, childApexes, plan.listenerHostnames)
 	if err != nil {
 		return fmt.Errorf("render Gateway: %w", err)
 	}
+	desired.Spec.Infrastructure = plan.ciliumInfrastructure(tgw)
+	plan.listeners = desired.Spec.Listeners
 
 	existing := &gatewayv1.Gateway{}
 	getErr := r.Get(ctx, types.NamespacedName{Namespace: tgw.Namespace, Name: tgw.Name}, existing)
@@ -595,7 +687,7 @@ func labelsEqual(a, b map[string]string) bool {
 	return true
 }
 
-func (r *Reconciler) reconcileIssuer(ctx context.Context, tgw *gatewayv1alpha1.TenantGateway) error {
+func (r *Reconciler) reconcileIssuer(ctx context.Context, tgw *gatewayv1alpha1.TenantGateway, customHostnames []string) (*metav1.Condition, error) {
 	logger := log.FromContext(ctx)
 
 	if tgw.Spec.CertMode == gatewayv1alpha1.CertModeExistingSecret {
@@ -607,24 +699,33 @@ func (r *Reconciler) reconcileIssuer(ctx context.Context, tgw *gatewayv1alpha1.T
 		stale := &cmv1.Issuer{}
 		err := r.Get(ctx, types.NamespacedName{Namespace: tgw.Namespace, Name: gatewayIssuerName(tgw)}, stale)
 		if apierrors.IsNotFound(err) {
-			return nil
+			return nil, nil
 		}
 		if err != nil {
-			return fmt.Errorf("get Issuer for cleanup: %w", err)
+			return nil, fmt.Errorf("get Issuer for cleanup: %w", err)
 		}
 		if !ownedByTenantGateway(stale.OwnerReferences, tgw) {
-			return nil
+			return nil, nil
 		}
 		if err := r.Delete(ctx, stale); err != nil && !apierrors.IsNotFound(err) {
-			return fmt.Errorf("delete stale Issuer %s: %w", stale.Name, err)
+			return nil, fmt.Errorf("delete stale Issuer %s: %w", stale.Name, err)
 		}
 		logger.V(1).Info("deleted stale Issuer after switch to existingSecret", "name", stale.Name)
-		return nil
+		return nil, nil
 	}
 
-	desired, err := r.renderIssuer(tgw)
+	desired, err := r.renderIssuer(tgw, customHostnames)
 	if err != nil {
-		return fmt.Errorf("render Issuer: %w", err)
+		return nil, fmt.Errorf("render Issuer: %w", err)
+	}
+
+	// A missing credential leaves the Issuer as it is: rendering one
+	// cert-manager cannot use only moves the failure out of sight.
+	// The rest of the Gateway still reconciles, and the Secret watch
+	// brings the TenantGateway back once the Secret appears.
+	missing, err := r.validateIssuerSecrets(ctx, tgw)
+	if err != nil || missing != nil {
+		return missing, err
 	}
 
 	existing := &cmv1.Issuer{}
@@ -632,11 +733,11 @@ func (r *Reconciler) reconcileIssuer(ctx context.Context, tgw *gatewayv1alpha1.T
 	switch {
 	case apierrors.IsNotFound(getErr):
 		if err := r.Create(ctx, desired); err != nil {
-			return fmt.Errorf("create Issuer: %w", err)
+			return nil, fmt.Errorf("create Issuer: %w", err)
 		}
 		logger.V(1).Info("created Issuer", "namespace", tgw.Namespace, "name", desired.Name)
 	case getErr != nil:
-		return fmt.Errorf("get Issuer: %w", getErr)
+		return nil, fmt.Errorf("get Issuer: %w", getErr)
 	default:
 		// Same takeover-guard contract as reconcileGateway /
 		// reconcileHTTPToHTTPSRedirect: refuse to mutate a
@@ -646,18 +747,18 @@ func (r *Reconciler) reconcileIssuer(ctx context.Context, tgw *gatewayv1alpha1.T
 		// private CA) gets silently re-issued from our ACME
 		// account on the next reconcile.
 		if !ownedByTenantGateway(existing.OwnerReferences, tgw) {
-			return fmt.Errorf("issuer %s/%s exists but is not owned by TenantGateway %s; refusing to take over (delete it manually if you want the controller to manage this Issuer)", tgw.Namespace, desired.Name, tgw.Name)
+			return nil, fmt.Errorf("issuer %s/%s exists but is not owned by TenantGateway %s; refusing to take over (delete it manually if you want the controller to manage this Issuer)", tgw.Namespace, desired.Name, tgw.Name)
 		}
 		if equality.Semantic.DeepEqual(existing.Spec, desired.Spec) {
-			return nil
+			return nil, nil
 		}
 		existing.Spec = desired.Spec
 		if err := r.Update(ctx, existing); err != nil {
-			return fmt.Errorf("update Issuer: %w", err)
+			return nil, fmt.Errorf("update Issuer: %w", err)
 		}
 		logger.V(1).Info("updated Issuer", "namespace", tgw.Namespace, "name", desired.Name)
 	}
-	return nil
+	return nil, nil
 }
 
 func (r *Reconciler) reconcileWildcardCertificate(ctx context.Context, tgw *gatewayv1alpha1.TenantGateway) error {
@@ -734,13 +835,18 @@ func (r *Reconciler) reconcileWildcardCertificate(ctx context.Context, tgw *gate
 // DNS-01 mode dynHostnames is expected to be empty (collector returns
 // nothing) — the wildcard listener handles all subdomains.
 //
+// policyHostnames are the subdomains a TenantGatewayPolicy names in
+// DNS-01 / existingSecret mode (see planPolicies). Each gets its own
+// listener on the wildcard cert so the policy can target it without
+// also covering every other subdomain.
+//
 // Every listener is gated by an unspoofable namespace selector
 // (kubernetes.io/metadata.name In [...]) so only the publishing
 // tenant namespace plus the TenantGateway.Spec.AttachedNamespaces
 // list (cozy-* platform namespaces) can attach routes. This is
 // Layer 1 of the security model documented in
 // packages/extra/gateway/README.md.
-func (r *Reconciler) renderGateway(tgw *gatewayv1alpha1.TenantGateway, dynHostnames []string, childApexes []string) (*gatewayv1.Gateway, error) {
+func (r *Reconciler) renderGateway(tgw *gatewayv1alpha1.TenantGateway, dynHostnames []string, childApexes []string, policyHostnames []string) (*gatewayv1.Gateway, error) {
 	allowedRoutes := buildAllowedRoutes(tgw)
 	httpAllowedRoutes := buildHTTPListenerAllowedRoutes(tgw)
 	listeners := []gatewayv1.Listener{
@@ -833,16 +939,13 @@ func (r *Reconciler) renderGateway(tgw *gatewayv1alpha1.TenantGateway, dynHostna
 				AllowedRoutes: httpsAllowedRoutes.DeepCopy(),
 			})
 		}
-	} else {
-		// HTTP-01 (default): per-app HTTPS listener per attached
-		// HTTPRoute / TLSRoute hostname. Names + cert refs are
-		// derived from the hostname's first label.
-		for _, h := range dynHostnames {
+		// Gateway API matches the most specific listener hostname, so
+		// routes for these names move from the wildcard listeners to
+		// the dedicated ones without any change on the route side.
+		for _, h := range policyHostnames {
 			hostnameVal := gatewayv1.Hostname(h)
-			listenerName := perListenerName(h)
-			certName := perListenerCertName(tgw, h)
 			listeners = append(listeners, gatewayv1.Listener{
-				Name:     gatewayv1.SectionName(listenerName),
+				Name:     gatewayv1.SectionName(perListenerName(h)),
 				Port:     443,
 				Protocol: gatewayv1.HTTPSProtocolType,
 				Hostname: &hostnameVal,
@@ -855,6 +958,14 @@ func (r *Reconciler) renderGateway(tgw *gatewayv1alpha1.TenantGateway, dynHostna
 				AllowedRoutes: httpsAllowedRoutes.DeepCopy(),
 			})
 		}
+		// Custom domains fall outside the wildcard cert, so each
+		// gets a listener with its own certificate, as in HTTP-01
+		// mode.
+		listeners = append(listeners, perListenerListeners(tgw, dynHostnames, httpsAllowedRoutes)...)
+	} else {
+		// HTTP-01 (default): per-app HTTPS listener per attached
+		// HTTPRoute / TLSRoute hostname and per custom domain.
+		listeners = append(listeners, perListenerListeners(tgw, dynHostnames, httpsAllowedRoutes)...)
 	}
 
 	// TLS-passthrough listeners. One per service in
@@ -888,10 +999,11 @@ func (r *Reconciler) renderGateway(tgw *gatewayv1alpha1.TenantGateway, dynHostna
 		})
 	}
 
-	className := tgw.Spec.GatewayClassName
-	if className == "" {
-		className = "cilium"
-	}
+	// L4 listeners, one per Spec.L4Listeners entry, on their own
+	// ports (validateL4Listeners drops any on 80 or 443). No
+	// hostname and no TLS: TCPRoute / UDPRoute carry none, and the
+	// listener admits only the route kind of its protocol.
+	listeners = append(listeners, renderL4Listeners(tgw)...)
 
 	gw := &gatewayv1.Gateway{
 		ObjectMeta: metav1.ObjectMeta{
@@ -903,7 +1015,7 @@ func (r *Reconciler) renderGateway(tgw *gatewayv1alpha1.TenantGateway, dynHostna
 			},
 		},
 		Spec: gatewayv1.GatewaySpec{
-			GatewayClassName: gatewayv1.ObjectName(className),
+			GatewayClassName: gatewayv1.ObjectName(gatewayClassName(tgw)),
 			Listeners:        listeners,
 		},
 	}
@@ -913,13 +1025,38 @@ func (r *Reconciler) renderGateway(tgw *gatewayv1alpha1.TenantGateway, dynHostna
 	return gw, nil
 }
 
+// perListenerListeners renders an HTTPS listener per hostname, each
+// terminating with the hostname's own per-listener Certificate. Names
+// + cert refs are derived from the hostname's first label.
+func perListenerListeners(tgw *gatewayv1alpha1.TenantGateway, hostnames []string, allowedRoutes *gatewayv1.AllowedRoutes) []gatewayv1.Listener {
+	listeners := make([]gatewayv1.Listener, 0, len(hostnames))
+	for _, h := range hostnames {
+		hostnameVal := gatewayv1.Hostname(h)
+		listeners = append(listeners, gatewayv1.Listener{
+			Name:     gatewayv1.SectionName(perListenerName(h)),
+			Port:     443,
+			Protocol: gatewayv1.HTTPSProtocolType,
+			Hostname: &hostnameVal,
+			TLS: &gatewayv1.ListenerTLSConfig{
+				Mode: ptrTLSMode(gatewayv1.TLSModeTerminate),
+				CertificateRefs: []gatewayv1.SecretObjectReference{
+					{Name: gatewayv1.ObjectName(perListenerCertName(tgw, h))},
+				},
+			},
+			AllowedRoutes: allowedRoutes.DeepCopy(),
+		})
+	}
+	return listeners
+}
+
 func ptrTLSMode(m gatewayv1.TLSModeType) *gatewayv1.TLSModeType {
 	return &m
 }
 
 // SetupWithManager wires the Reconciler into the controller manager
 // with For (TenantGateway as primary), Owns (Gateway and Certificate
-// as owned children), and Watches against HTTPRoute and TLSRoute so
+// as owned children), and Watches against HTTPRoute, TLSRoute,
+// TCPRoute and UDPRoute so
 // collectInheritingChildApexes returns the deduplicated, sorted
 // list of apex hostnames from tenant namespaces that inherit this
 // Gateway's publishing layer. A namespace counts as inheriting when
@@ -1053,8 +1190,14 @@ func (r *Reconciler) stripNamespaceGatewayLabel(ctx context.Context, name string
 }
 
 // route additions in attached namespaces re-trigger reconciliation
-// of the parent TenantGateway.
-func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
+// of the parent TenantGateway. Secrets are watched as metadata on
+// secretMetaCache, for the reason main.go gives for the CA source
+// cache, so an Issuer credential created after the TenantGateway
+// unblocks it at once.
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager, secretMetaCache cache.Cache) error {
+	secretMeta := &metav1.PartialObjectMetadata{}
+	secretMeta.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("Secret"))
+
 	return ctrl.NewControllerManagedBy(mgr).
 		Named("tenantgateway-controller").
 		For(&gatewayv1alpha1.TenantGateway{}).
@@ -1070,5 +1213,28 @@ func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
 			&gatewayv1alpha2.TLSRoute{},
 			r.routeToTenantGateway(),
 		).
+		Watches(
+			&gatewayv1alpha2.TCPRoute{},
+			r.routeToTenantGateway(),
+		).
+		Watches(
+			&gatewayv1alpha2.UDPRoute{},
+			r.routeToTenantGateway(),
+		).
+		Watches(
+			&gatewayv1alpha1.TenantGatewayPolicy{},
+			r.policyToTenantGateway(),
+		).
+		Watches(
+			&gatewayv1alpha1.CustomDomain{},
+			r.customDomainToTenantGateway(),
+		).
+		Watches(
+			&corev1.Service{},
+			r.ciliumServiceToTenantGateway(),
+		).
+		WatchesRawSource(crsource.Kind(secretMetaCache, secretMeta,
+			handler.TypedEnqueueRequestsFromMapFunc(r.mapSecretToTenantGateways),
+		)).
 		Complete(r)
 }
@@ -24,6 +24,7 @@ import (
 	"testing"
 
 	cmv1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmetav1 "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -63,6 +64,16 @@ func newScheme(t *testing.T) *runtime.Scheme {
 	return s
 }
 
+// dns01Secret builds the provider credential Secret a dns01-mode
+// TenantGateway references; reconcileIssuer refuses to render the
+// Issuer until it exists (validateIssuerSecrets).
+func dns01Secret(namespace, name, key string) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Data:       map[string][]byte{key: []byte("credential")},
+	}
+}
+
 // TestReconcile_NotFoundIsNoop pins the early-exit path: a deleted
 // TenantGateway should result in no error and no requeue. This is a
 // canary for the bare reconciler skeleton — the surface that exists
@@ -71,7 +82,7 @@ func TestReconcile_NotFoundIsNoop(t *testing.T) {
 	s := newScheme(t)
 	c := fake.NewClientBuilder().WithScheme(s).Build()
 
-	r := &Reconciler{Client: c, Scheme: s}
+	r := &Reconciler{Client: c, Reader: c, Scheme: s}
 	res, err := r.Reconcile(context.TODO(), ctrl.Request{
 		NamespacedName: types.NamespacedName{Namespace: "tenant-foo", Name: "missing"},
 	})
@@ -105,7 +116,7 @@ func TestReconcile_TenantGatewayProducesGateway(t *testing.T) {
 		WithStatusSubresource(tgw).
 		Build()
 
-	r := &Reconciler{Client: c, Scheme: s}
+	r := &Reconciler{Client: c, Reader: c, Scheme: s}
 	if _, err := r.Reconcile(context.TODO(), ctrl.Request{
 		NamespacedName: types.NamespacedName{Name: "cozystack", Namespace: "tenant-foo"},
 	}); err != nil {
@@ -158,7 +169,7 @@ func TestReconcile_IsIdempotent(t *testing.T) {
 		WithStatusSubresource(tgw, &gatewayv1.Gateway{}, &gatewayv1.HTTPRoute{}).
 		Build()
 
-	r := &Reconciler{Client: c, Scheme: s}
+	r := &Reconciler{Client: c, Reader: c, Scheme: s}
 	for i := 0; i < 2; i++ {
 		if _, err := r.Reconcile(context.TODO(), ctrl.Request{
 			NamespacedName: types.NamespacedName{Name: "cozystack", Namespace: "tenant-foo"},
@@ -226,7 +237,7 @@ func TestReconcile_HTTPListenerExcludesAppNamespaces(t *testing.T) {
 	}
 	c := fake.NewClientBuilder().WithScheme(s).WithObjects(tgw).WithStatusSubresource(tgw, &gatewayv1.Gateway{}).Build()
 
-	r := &Reconciler{Client: c, Scheme: s}
+	r := &Reconciler{Client: c, Reader: c, Scheme: s}
 	if _, err := r.Reconcile(context.TODO(), ctrl.Request{
 		NamespacedName: types.NamespacedName{Name: "cozystack", Namespace: "tenant-foo"},
 	}); err != nil {
@@ -316,7 +327,7 @@ func TestReconcile_LabelsAttachedNamespaces(t *testing.T) {
 		WithStatusSubresource(tgw).
 		Build()
 
-	r := &Reconciler{Client: c, Scheme: s}
+	r := &Reconciler{Client: c, Reader: c, Scheme: s}
 	if _, err := r.Reconcile(context.TODO(), ctrl.Request{
 		NamespacedName: types.NamespacedName{Name: "cozystack", Namespace: "tenant-foo"},
 	}); err != nil {
@@ -364,7 +375,7 @@ func TestReconcile_LabelGCRemovesDroppedAttachedNamespaces(t *testing.T) {
 		WithStatusSubresource(tgw).
 		Build()
 
-	r := &Reconciler{Client: c, Scheme: s}
+	r := &Reconciler{Client: c, Reader: c, Scheme: s}
 	// Phase 1: both namespaces labelled.
 	if _, err := r.Reconcile(context.TODO(), ctrl.Request{
 		NamespacedName: types.NamespacedName{Name: "cozystack", Namespace: "tenant-foo"},
@@ -448,7 +459,7 @@ func TestReconcile_LabelGCDoesNotStripHelmOwnedLabels(t *testing.T) {
 		WithStatusSubresource(tgw).
 		Build()
 
-	r := &Reconciler{Client: c, Scheme: s}
+	r := &Reconciler{Client: c, Reader: c, Scheme: s}
 	if _, err := r.Reconcile(context.TODO(), ctrl.Request{
 		NamespacedName: types.NamespacedName{Name: "cozystack", Namespace: "tenant-foo"},
 	}); err != nil {
@@ -497,7 +508,7 @@ func TestReconcile_HTTPSListenerUsesGatewayLabelSelector(t *testing.T) {
 		WithStatusSubresource(tgw).
 		Build()
 
-	r := &Reconciler{Client: c, Scheme: s}
+	r := &Reconciler{Client: c, Reader: c, Scheme: s}
 	if _, err := r.Reconcile(context.TODO(), ctrl.Request{
 		NamespacedName: types.NamespacedName{Name: "cozystack", Namespace: "tenant-foo"},
 	}); err != nil {
@@ -555,8 +566,8 @@ func TestReconcile_CertModeTransitionHTTP01ToDNS01CleansPerListenerCerts(t *test
 		},
 	}
 	route := httpRouteAttached("harbor", "cozy-harbor", "harbor.foo.example.com")
-	c := fake.NewClientBuilder().WithScheme(s).WithObjects(tgw, route).WithStatusSubresource(tgw, &gatewayv1.Gateway{}).Build()
-	r := &Reconciler{Client: c, Scheme: s}
+	c := fake.NewClientBuilder().WithScheme(s).WithObjects(dns01Secret("tenant-foo", "cf-token", "api-token"), tgw, route).WithStatusSubresource(tgw, &gatewayv1.Gateway{}).Build()
+	r := &Reconciler{Client: c, Reader: c, Scheme: s}
 
 	// Phase 1: HTTP-01 reconcile creates a per-listener cert.
 	if _, err := r.Reconcile(context.TODO(), ctrl.Request{
@@ -637,8 +648,8 @@ func TestReconcile_CertModeTransitionDNS01ToHTTP01CleansWildcardCert(t *testing.
 			},
 		},
 	}
-	c := fake.NewClientBuilder().WithScheme(s).WithObjects(tgw).WithStatusSubresource(tgw, &gatewayv1.Gateway{}).Build()
-	r := &Reconciler{Client: c, Scheme: s}
+	c := fake.NewClientBuilder().WithScheme(s).WithObjects(dns01Secret("tenant-foo", "cf-token", "api-token"), tgw).WithStatusSubresource(tgw, &gatewayv1.Gateway{}).Build()
+	r := &Reconciler{Client: c, Reader: c, Scheme: s}
 
 	if _, err := r.Reconcile(context.TODO(), ctrl.Request{
 		NamespacedName: types.NamespacedName{Name: "cozystack", Namespace: "tenant-foo"},
@@ -698,7 +709,7 @@ func TestReconcile_RouteFromUnwhitelistedNamespaceIgnored(t *testing.T) {
 		WithStatusSubresource(tgw, &gatewayv1.Gateway{}).
 		Build()
 
-	r := &Reconciler{Client: c, Scheme: s}
+	r := &Reconciler{Client: c, Reader: c, Scheme: s}
 	if _, err := r.Reconcile(context.TODO(), ctrl.Request{
 		NamespacedName: types.NamespacedName{Name: "cozystack", Namespace: "tenant-foo"},
 	}); err != nil {
@@ -757,7 +768,7 @@ func TestReconcile_RendersHTTPToHTTPSRedirectRoute(t *testing.T) {
 	}
 	c := fake.NewClientBuilder().WithScheme(s).WithObjects(tgw).WithStatusSubresource(tgw, &gatewayv1.Gateway{}).Build()
 
-	r := &Reconciler{Client: c, Scheme: s}
+	r := &Reconciler{Client: c, Reader: c, Scheme: s}
 	if _, err := r.Reconcile(context.TODO(), ctrl.Request{
 		NamespacedName: types.NamespacedName{Name: "cozystack", Namespace: "tenant-foo"},
 	}); err != nil {
@@ -806,7 +817,7 @@ func TestReconcile_GatewayUpdatePreservesForeignLabels(t *testing.T) {
 		},
 	}
 	c := fake.NewClientBuilder().WithScheme(s).WithObjects(tgw).WithStatusSubresource(tgw, &gatewayv1.Gateway{}).Build()
-	r := &Reconciler{Client: c, Scheme: s}
+	r := &Reconciler{Client: c, Reader: c, Scheme: s}
 
 	// First reconcile creates the Gateway.
 	if _, err := r.Reconcile(context.TODO(), ctrl.Request{
@@ -867,7 +878,7 @@ func TestReconcile_OwnerReferenceOnGateway(t *testing.T) {
 	}
 	c := fake.NewClientBuilder().WithScheme(s).WithObjects(tgw).WithStatusSubresource(tgw).Build()
 
-	r := &Reconciler{Client: c, Scheme: s}
+	r := &Reconciler{Client: c, Reader: c, Scheme: s}
 	if _, err := r.Reconcile(context.TODO(), ctrl.Request{
 		NamespacedName: types.NamespacedName{Name: "cozystack", Namespace: "tenant-foo"},
 	}); err != nil {
@@ -913,9 +924,9 @@ func TestReconcile_DNS01ModeRendersWildcardListener(t *testing.T) {
 			},
 		},
 	}
-	c := fake.NewClientBuilder().WithScheme(s).WithObjects(tgw).WithStatusSubresource(tgw).Build()
+	c := fake.NewClientBuilder().WithScheme(s).WithObjects(dns01Secret("tenant-foo", "cf-token", "api-token"), tgw).WithStatusSubresource(tgw).Build()
 
-	r := &Reconciler{Client: c, Scheme: s}
+	r := &Reconciler{Client: c, Reader: c, Scheme: s}
 	if _, err := r.Reconcile(context.TODO(), ctrl.Request{
 		NamespacedName: types.NamespacedName{Name: "cozystack", Namespace: "tenant-foo"},
 	}); err != nil {
@@ -959,7 +970,7 @@ func TestReconcile_HTTP01ModeNoWildcardListener(t *testing.T) {
 	}
 	c := fake.NewClientBuilder().WithScheme(s).WithObjects(tgw).WithStatusSubresource(tgw).Build()
 
-	r := &Reconciler{Client: c, Scheme: s}
+	r := &Reconciler{Client: c, Reader: c, Scheme: s}
 	if _, err := r.Reconcile(context.TODO(), ctrl.Request{
 		NamespacedName: types.NamespacedName{Name: "cozystack", Namespace: "tenant-foo"},
 	}); err != nil {
@@ -1007,9 +1018,9 @@ func TestReconcile_AlwaysCreatesIssuer(t *testing.T) {
 					},
 				}
 			}
-			c := fake.NewClientBuilder().WithScheme(s).WithObjects(tgw).WithStatusSubresource(tgw).Build()
+			c := fake.NewClientBuilder().WithScheme(s).WithObjects(dns01Secret("tenant-foo", "cf-token", "api-token"), tgw).WithStatusSubresource(tgw).Build()
 
-			r := &Reconciler{Client: c, Scheme: s}
+			r := &Reconciler{Client: c, Reader: c, Scheme: s}
 			if _, err := r.Reconcile(context.TODO(), ctrl.Request{
 				NamespacedName: types.NamespacedName{Name: "cozystack", Namespace: "tenant-foo"},
 			}); err != nil {
@@ -1044,7 +1055,7 @@ func TestReconcile_HTTP01IssuerHasGatewayHTTPRouteSolver(t *testing.T) {
 	}
 	c := fake.NewClientBuilder().WithScheme(s).WithObjects(tgw).WithStatusSubresource(tgw).Build()
 
-	r := &Reconciler{Client: c, Scheme: s}
+	r := &Reconciler{Client: c, Reader: c, Scheme: s}
 	if _, err := r.Reconcile(context.TODO(), ctrl.Request{
 		NamespacedName: types.NamespacedName{Name: "cozystack", Namespace: "tenant-foo"},
 	}); err != nil {
@@ -1096,7 +1107,7 @@ func TestReconcile_IssuerNameStagingHitsStagingACME(t *testing.T) {
 	}
 	c := fake.NewClientBuilder().WithScheme(s).WithObjects(tgw).WithStatusSubresource(tgw).Build()
 
-	r := &Reconciler{Client: c, Scheme: s}
+	r := &Reconciler{Client: c, Reader: c, Scheme: s}
 	if _, err := r.Reconcile(context.TODO(), ctrl.Request{
 		NamespacedName: types.NamespacedName{Name: "cozystack", Namespace: "tenant-foo"},
 	}); err != nil {
@@ -1130,7 +1141,7 @@ func TestReconcile_IssuerNameProdHitsProdACME(t *testing.T) {
 	}
 	c := fake.NewClientBuilder().WithScheme(s).WithObjects(tgw).WithStatusSubresource(tgw).Build()
 
-	r := &Reconciler{Client: c, Scheme: s}
+	r := &Reconciler{Client: c, Reader: c, Scheme: s}
 	if _, err := r.Reconcile(context.TODO(), ctrl.Request{
 		NamespacedName: types.NamespacedName{Name: "cozystack", Namespace: "tenant-foo"},
 	}); err != nil {
@@ -1168,9 +1179,9 @@ func TestReconcile_DNS01IssuerCloudflareSolver(t *testing.T) {
 			},
 		},
 	}
-	c := fake.NewClientBuilder().WithScheme(s).WithObjects(tgw).WithStatusSubresource(tgw).Build()
+	c := fake.NewClientBuilder().WithScheme(s).WithObjects(dns01Secret("tenant-foo", "cloudflare-api-token-secret", "api-token"), tgw).WithStatusSubresource(tgw).Build()
 
-	r := &Reconciler{Client: c, Scheme: s}
+	r := &Reconciler{Client: c, Reader: c, Scheme: s}
 	if _, err := r.Reconcile(context.TODO(), ctrl.Request{
 		NamespacedName: types.NamespacedName{Name: "cozystack", Namespace: "tenant-foo"},
 	}); err != nil {
@@ -1249,7 +1260,7 @@ func TestReconcile_HTTP01CollectsHostnamesFromInheritingChildNamespaces(t *testi
 		WithStatusSubresource(tgw).
 		Build()
 
-	r := &Reconciler{Client: c, Scheme: s}
+	r := &Reconciler{Client: c, Reader: c, Scheme: s}
 	if _, err := r.Reconcile(context.TODO(), ctrl.Request{
 		NamespacedName: types.NamespacedName{Name: "cozystack", Namespace: "tenant-root"},
 	}); err != nil {
@@ -1327,11 +1338,11 @@ func TestReconcile_DNS01WildcardCertCoversInheritingChildApexes(t *testing.T) {
 
 	c := fake.NewClientBuilder().
 		WithScheme(s).
-		WithObjects(tgw, nsRoot, nsAlice).
+		WithObjects(dns01Secret("tenant-root", "cf-token", "api-token"), tgw, nsRoot, nsAlice).
 		WithStatusSubresource(tgw).
 		Build()
 
-	r := &Reconciler{Client: c, Scheme: s}
+	r := &Reconciler{Client: c, Reader: c, Scheme: s}
 	if _, err := r.Reconcile(context.TODO(), ctrl.Request{
 		NamespacedName: types.NamespacedName{Name: "cozystack", Namespace: "tenant-root"},
 	}); err != nil {
@@ -1408,11 +1419,11 @@ func TestReconcile_DNS01WildcardCertDeduplicatesChildApexEqualToParent(t *testin
 
 	c := fake.NewClientBuilder().
 		WithScheme(s).
-		WithObjects(tgw, nsRoot, nsBogus).
+		WithObjects(dns01Secret("tenant-root", "cf-token", "api-token"), tgw, nsRoot, nsBogus).
 		WithStatusSubresource(tgw).
 		Build()
 
-	r := &Reconciler{Client: c, Scheme: s}
+	r := &Reconciler{Client: c, Reader: c, Scheme: s}
 	if _, err := r.Reconcile(context.TODO(), ctrl.Request{
 		NamespacedName: types.NamespacedName{Name: "cozystack", Namespace: "tenant-root"},
 	}); err != nil {
@@ -1469,7 +1480,7 @@ func TestReconcile_HTTP01WildcardCertNeverRendered(t *testing.T) {
 		WithStatusSubresource(tgw).
 		Build()
 
-	r := &Reconciler{Client: c, Scheme: s}
+	r := &Reconciler{Client: c, Reader: c, Scheme: s}
 	if _, err := r.Reconcile(context.TODO(), ctrl.Request{
 		NamespacedName: types.NamespacedName{Name: "cozystack", Namespace: "tenant-root"},
 	}); err != nil {
@@ -1541,11 +1552,11 @@ func TestReconcile_DNS01GatewayHasListenerPerChildApex(t *testing.T) {
 
 	c := fake.NewClientBuilder().
 		WithScheme(s).
-		WithObjects(tgw, nsRoot, nsAlice, nsBob).
+		WithObjects(dns01Secret("tenant-root", "cf-token", "api-token"), tgw, nsRoot, nsAlice, nsBob).
 		WithStatusSubresource(tgw).
 		Build()
 
-	r := &Reconciler{Client: c, Scheme: s}
+	r := &Reconciler{Client: c, Reader: c, Scheme: s}
 	if _, err := r.Reconcile(context.TODO(), ctrl.Request{
 		NamespacedName: types.NamespacedName{Name: "cozystack", Namespace: "tenant-root"},
 	}); err != nil {
@@ -1610,9 +1621,9 @@ func TestReconcile_DNS01CreatesWildcardCertificate(t *testing.T) {
 			},
 		},
 	}
-	c := fake.NewClientBuilder().WithScheme(s).WithObjects(tgw).WithStatusSubresource(tgw).Build()
+	c := fake.NewClientBuilder().WithScheme(s).WithObjects(dns01Secret("tenant-foo", "cf-token", "api-token"), tgw).WithStatusSubresource(tgw).Build()
 
-	r := &Reconciler{Client: c, Scheme: s}
+	r := &Reconciler{Client: c, Reader: c, Scheme: s}
 	if _, err := r.Reconcile(context.TODO(), ctrl.Request{
 		NamespacedName: types.NamespacedName{Name: "cozystack", Namespace: "tenant-foo"},
 	}); err != nil {
@@ -1699,7 +1710,7 @@ func TestReconcile_HTTP01ProducesListenerForHTTPRoute(t *testing.T) {
 		WithStatusSubresource(tgw).
 		Build()
 
-	r := &Reconciler{Client: c, Scheme: s}
+	r := &Reconciler{Client: c, Reader: c, Scheme: s}
 	if _, err := r.Reconcile(context.TODO(), ctrl.Request{
 		NamespacedName: types.NamespacedName{Name: "cozystack", Namespace: "tenant-foo"},
 	}); err != nil {
@@ -1748,7 +1759,7 @@ func TestReconcile_HTTP01ProducesCertificateForHTTPRoute(t *testing.T) {
 		WithStatusSubresource(tgw).
 		Build()
 
-	r := &Reconciler{Client: c, Scheme: s}
+	r := &Reconciler{Client: c, Reader: c, Scheme: s}
 	if _, err := r.Reconcile(context.TODO(), ctrl.Request{
 		NamespacedName: types.NamespacedName{Name: "cozystack", Namespace: "tenant-foo"},
 	}); err != nil {
@@ -1799,7 +1810,7 @@ func TestReconcile_MultipleHTTPRoutesSameHostnameDeduplicates(t *testing.T) {
 		WithStatusSubresource(tgw).
 		Build()
 
-	r := &Reconciler{Client: c, Scheme: s}
+	r := &Reconciler{Client: c, Reader: c, Scheme: s}
 	if _, err := r.Reconcile(context.TODO(), ctrl.Request{
 		NamespacedName: types.NamespacedName{Name: "cozystack", Namespace: "tenant-foo"},
 	}); err != nil {
@@ -1864,11 +1875,11 @@ func TestReconcile_DNS01ModeIgnoresHTTPRoutesForListeners(t *testing.T) {
 
 	c := fake.NewClientBuilder().
 		WithScheme(s).
-		WithObjects(tgw, route).
+		WithObjects(dns01Secret("tenant-foo", "cf-token", "api-token"), tgw, route).
 		WithStatusSubresource(tgw).
 		Build()
 
-	r := &Reconciler{Client: c, Scheme: s}
+	r := &Reconciler{Client: c, Reader: c, Scheme: s}
 	if _, err := r.Reconcile(context.TODO(), ctrl.Request{
 		NamespacedName: types.NamespacedName{Name: "cozystack", Namespace: "tenant-foo"},
 	}); err != nil {
@@ -1913,7 +1924,7 @@ func TestReconcile_ListenersHaveAllowedRoutesSelector(t *testing.T) {
 	}
 	c := fake.NewClientBuilder().WithScheme(s).WithObjects(tgw).WithStatusSubresource(tgw).Build()
 
-	r := &Reconciler{Client: c, Scheme: s}
+	r := &Reconciler{Client: c, Reader: c, Scheme: s}
 	if _, err := r.Reconcile(context.TODO(), ctrl.Request{
 		NamespacedName: types.NamespacedName{Name: "cozystack", Namespace: "tenant-foo"},
 	}); err != nil {
@@ -1979,7 +1990,7 @@ func TestReconcile_TLSPassthroughListenersRendered(t *testing.T) {
 	}
 	c := fake.NewClientBuilder().WithScheme(s).WithObjects(tgw).WithStatusSubresource(tgw).Build()
 
-	r := &Reconciler{Client: c, Scheme: s}
+	r := &Reconciler{Client: c, Reader: c, Scheme: s}
 	if _, err := r.Reconcile(context.TODO(), ctrl.Request{
 		NamespacedName: types.NamespacedName{Name: "cozystack", Namespace: "tenant-foo"},
 	}); err != nil {
@@ -2053,7 +2064,7 @@ func TestReconcile_StatusObservedGeneration(t *testing.T) {
 	}
 	c := fake.NewClientBuilder().WithScheme(s).WithObjects(tgw).WithStatusSubresource(tgw).Build()
 
-	r := &Reconciler{Client: c, Scheme: s}
+	r := &Reconciler{Client: c, Reader: c, Scheme: s}
 	if _, err := r.Reconcile(context.TODO(), ctrl.Request{
 		NamespacedName: types.NamespacedName{Name: "cozystack", Namespace: "tenant-foo"},
 	}); err != nil {
@@ -2093,7 +2104,7 @@ func TestReconcile_StatusListenersMirrorGateway(t *testing.T) {
 		WithStatusSubresource(tgw).
 		Build()
 
-	r := &Reconciler{Client: c, Scheme: s}
+	r := &Reconciler{Client: c, Reader: c, Scheme: s}
 	if _, err := r.Reconcile(context.TODO(), ctrl.Request{
 		NamespacedName: types.NamespacedName{Name: "cozystack", Namespace: "tenant-foo"},
 	}); err != nil {
@@ -2142,7 +2153,7 @@ func TestReconcile_StatusReadyFalseUntilGatewayProgrammed(t *testing.T) {
 	}
 	c := fake.NewClientBuilder().WithScheme(s).WithObjects(tgw).WithStatusSubresource(tgw).Build()
 
-	r := &Reconciler{Client: c, Scheme: s}
+	r := &Reconciler{Client: c, Reader: c, Scheme: s}
 	if _, err := r.Reconcile(context.TODO(), ctrl.Request{
 		NamespacedName: types.NamespacedName{Name: "cozystack", Namespace: "tenant-foo"},
 	}); err != nil {
@@ -2192,7 +2203,7 @@ func TestReconcile_StatusReadyTrueWhenGatewayProgrammed(t *testing.T) {
 		WithStatusSubresource(tgw, &gatewayv1.Gateway{}).
 		Build()
 
-	r := &Reconciler{Client: c, Scheme: s}
+	r := &Reconciler{Client: c, Reader: c, Scheme: s}
 	// First reconcile creates the Gateway; we then patch its status to
 	// simulate Cilium's controller having reconciled it, and run a
 	// second reconcile so the TenantGateway picks up the new status.
@@ -2223,6 +2234,7 @@ func TestReconcile_StatusReadyTrueWhenGatewayProgrammed(t *testing.T) {
 	if err := c.Status().Update(context.TODO(), gw); err != nil {
 		t.Fatalf("patch Gateway status: %v", err)
 	}
+	setIssuerReady(t, c, "tenant-foo", "cozystack-gateway", cmmetav1.ConditionTrue, "")
 
 	if _, err := r.Reconcile(context.TODO(), ctrl.Request{
 		NamespacedName: types.NamespacedName{Name: "cozystack", Namespace: "tenant-foo"},
@@ -2277,7 +2289,7 @@ func TestReconcile_TwoRoutesSameHostnameCozyWins(t *testing.T) {
 		WithStatusSubresource(tgw, &gatewayv1.HTTPRoute{}).
 		Build()
 
-	r := &Reconciler{Client: c, Scheme: s}
+	r := &Reconciler{Client: c, Reader: c, Scheme: s}
 	if _, err := r.Reconcile(context.TODO(), ctrl.Request{
 		NamespacedName: types.NamespacedName{Name: "cozystack", Namespace: "tenant-foo"},
 	}); err != nil {
@@ -2368,7 +2380,7 @@ func TestReconcile_SameNamespaceSameHostnameNoConflict(t *testing.T) {
 		WithStatusSubresource(tgw, &gatewayv1.HTTPRoute{}).
 		Build()
 
-	r := &Reconciler{Client: c, Scheme: s}
+	r := &Reconciler{Client: c, Reader: c, Scheme: s}
 	if _, err := r.Reconcile(context.TODO(), ctrl.Request{
 		NamespacedName: types.NamespacedName{Name: "cozystack", Namespace: "tenant-foo"},
 	}); err != nil {
@@ -2408,7 +2420,7 @@ func TestReconcile_HTTP01DoesNotCreateWildcardCertificate(t *testing.T) {
 	}
 	c := fake.NewClientBuilder().WithScheme(s).WithObjects(tgw).WithStatusSubresource(tgw).Build()
 
-	r := &Reconciler{Client: c, Scheme: s}
+	r := &Reconciler{Client: c, Reader: c, Scheme: s}
 	if _, err := r.Reconcile(context.TODO(), ctrl.Request{
 		NamespacedName: types.NamespacedName{Name: "cozystack", Namespace: "tenant-foo"},
 	}); err != nil {
@@ -2496,12 +2508,12 @@ func TestReconcile_HTTPSListenersRestrictRouteKindsToHTTPRoute(t *testing.T) {
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
 			s := newScheme(t)
-			builder := fake.NewClientBuilder().WithScheme(s).WithObjects(tc.tgw).WithStatusSubresource(tc.tgw)
+			builder := fake.NewClientBuilder().WithScheme(s).WithObjects(dns01Secret("tenant-foo", "cf-token", "api-token"), tc.tgw).WithStatusSubresource(tc.tgw)
 			if len(tc.extra) > 0 {
 				builder = builder.WithObjects(tc.extra...)
 			}
 			c := builder.Build()
-			r := &Reconciler{Client: c, Scheme: s}
+			r := &Reconciler{Client: c, Reader: c, Scheme: s}
 			if _, err := r.Reconcile(context.TODO(), ctrl.Request{
 				NamespacedName: types.NamespacedName{Name: "cozystack", Namespace: "tenant-foo"},
 			}); err != nil {
@@ -2568,9 +2580,9 @@ func TestReconcile_DNS01IssuerRoute53Solver(t *testing.T) {
 			},
 		},
 	}
-	c := fake.NewClientBuilder().WithScheme(s).WithObjects(tgw).WithStatusSubresource(tgw).Build()
+	c := fake.NewClientBuilder().WithScheme(s).WithObjects(dns01Secret("tenant-foo", "aws-iam-secret", "secret-access-key"), tgw).WithStatusSubresource(tgw).Build()
 
-	r := &Reconciler{Client: c, Scheme: s}
+	r := &Reconciler{Client: c, Reader: c, Scheme: s}
 	if _, err := r.Reconcile(context.TODO(), ctrl.Request{
 		NamespacedName: types.NamespacedName{Name: "cozystack", Namespace: "tenant-foo"},
 	}); err != nil {
@@ -2622,9 +2634,9 @@ func TestReconcile_DNS01IssuerDigitalOceanSolver(t *testing.T) {
 			},
 		},
 	}
-	c := fake.NewClientBuilder().WithScheme(s).WithObjects(tgw).WithStatusSubresource(tgw).Build()
+	c := fake.NewClientBuilder().WithScheme(s).WithObjects(dns01Secret("tenant-foo", "do-api-token", "access-token"), tgw).WithStatusSubresource(tgw).Build()
 
-	r := &Reconciler{Client: c, Scheme: s}
+	r := &Reconciler{Client: c, Reader: c, Scheme: s}
 	if _, err := r.Reconcile(context.TODO(), ctrl.Request{
 		NamespacedName: types.NamespacedName{Name: "cozystack", Namespace: "tenant-foo"},
 	}); err != nil {
@@ -2674,9 +2686,9 @@ func TestReconcile_DNS01IssuerRFC2136Solver(t *testing.T) {
 			},
 		},
 	}
-	c := fake.NewClientBuilder().WithScheme(s).WithObjects(tgw).WithStatusSubresource(tgw).Build()
+	c := fake.NewClientBuilder().WithScheme(s).WithObjects(dns01Secret("tenant-foo", "tsig-secret", "tsig-secret-key"), tgw).WithStatusSubresource(tgw).Build()
 
-	r := &Reconciler{Client: c, Scheme: s}
+	r := &Reconciler{Client: c, Reader: c, Scheme: s}
 	if _, err := r.Reconcile(context.TODO(), ctrl.Request{
 		NamespacedName: types.NamespacedName{Name: "cozystack", Namespace: "tenant-foo"},
 	}); err != nil {
@@ -2742,6 +2754,41 @@ func TestReconcile_DNS01ProviderMissingConfigErrors(t *testing.T) {
 			},
 			wantSubs: "dns01.rfc2136",
 		},
+		{
+			name: "clouddns without clouddns block",
+			dns01: &gatewayv1alpha1.DNS01Config{
+				Provider: "clouddns",
+			},
+			wantSubs: "dns01.clouddns",
+		},
+		{
+			name: "azuredns without azuredns block",
+			dns01: &gatewayv1alpha1.DNS01Config{
+				Provider: "azuredns",
+			},
+			wantSubs: "dns01.azuredns",
+		},
+		{
+			name: "hetzner without hetzner block",
+			dns01: &gatewayv1alpha1.DNS01Config{
+				Provider: "hetzner",
+			},
+			wantSubs: "dns01.hetzner",
+		},
+		{
+			name: "powerdns without powerdns block",
+			dns01: &gatewayv1alpha1.DNS01Config{
+				Provider: "powerdns",
+			},
+			wantSubs: "dns01.powerdns",
+		},
+		{
+			name: "webhook without webhook block",
+			dns01: &gatewayv1alpha1.DNS01Config{
+				Provider: "webhook",
+			},
+			wantSubs: "dns01.webhook",
+		},
 		{
 			name: "unknown provider",
 			dns01: &gatewayv1alpha1.DNS01Config{
@@ -2819,7 +2866,7 @@ func TestReconcile_RefusesToTakeOverForeignGateway(t *testing.T) {
 	}
 	c := fake.NewClientBuilder().WithScheme(s).WithObjects(tgw, foreign).WithStatusSubresource(tgw).Build()
 
-	r := &Reconciler{Client: c, Scheme: s}
+	r := &Reconciler{Client: c, Reader: c, Scheme: s}
 	_, err := r.Reconcile(context.TODO(), ctrl.Request{
 		NamespacedName: types.NamespacedName{Name: "cozystack", Namespace: "tenant-foo"},
 	})
@@ -2888,7 +2935,7 @@ func TestReconcile_RefusesToTakeOverForeignRedirectRoute(t *testing.T) {
 	}
 	c := fake.NewClientBuilder().WithScheme(s).WithObjects(tgw, foreign).WithStatusSubresource(tgw, &gatewayv1.HTTPRoute{}).Build()
 
-	r := &Reconciler{Client: c, Scheme: s}
+	r := &Reconciler{Client: c, Reader: c, Scheme: s}
 	_, err := r.Reconcile(context.TODO(), ctrl.Request{
 		NamespacedName: types.NamespacedName{Name: "cozystack", Namespace: "tenant-foo"},
 	})
@@ -2934,7 +2981,7 @@ func TestReconcile_RefusesToTakeOverForeignIssuer(t *testing.T) {
 	}
 	c := fake.NewClientBuilder().WithScheme(s).WithObjects(tgw, foreign).WithStatusSubresource(tgw).Build()
 
-	r := &Reconciler{Client: c, Scheme: s}
+	r := &Reconciler{Client: c, Reader: c, Scheme: s}
 	_, err := r.Reconcile(context.TODO(), ctrl.Request{
 		NamespacedName: types.NamespacedName{Name: "cozystack", Namespace: "tenant-foo"},
 	})
@@ -2985,9 +3032,9 @@ func TestReconcile_RefusesToTakeOverForeignWildcardCertificate(t *testing.T) {
 			DNSNames:   []string{"operator.foo.example.com"},
 		},
 	}
-	c := fake.NewClientBuilder().WithScheme(s).WithObjects(tgw, foreign).WithStatusSubresource(tgw).Build()
+	c := fake.NewClientBuilder().WithScheme(s).WithObjects(dns01Secret("tenant-foo", "cf-token", "api-token"), tgw, foreign).WithStatusSubresource(tgw).Build()
 
-	r := &Reconciler{Client: c, Scheme: s}
+	r := &Reconciler{Client: c, Reader: c, Scheme: s}
 	_, err := r.Reconcile(context.TODO(), ctrl.Request{
 		NamespacedName: types.NamespacedName{Name: "cozystack", Namespace: "tenant-foo"},
 	})
@@ -3052,7 +3099,7 @@ func TestReconcile_RefusesToTakeOverForeignPerListenerCertificate(t *testing.T)
 	}
 	c := fake.NewClientBuilder().WithScheme(s).WithObjects(tgw, route, foreign).WithStatusSubresource(tgw, &gatewayv1.HTTPRoute{}).Build()
 
-	r := &Reconciler{Client: c, Scheme: s}
+	r := &Reconciler{Client: c, Reader: c, Scheme: s}
 	_, err := r.Reconcile(context.TODO(), ctrl.Request{
 		NamespacedName: types.NamespacedName{Name: "cozystack", Namespace: "tenant-foo"},
 	})
@@ -3107,7 +3154,7 @@ func TestReconcile_OwnerReferencesOnDownstream(t *testing.T) {
 	}
 	c := fake.NewClientBuilder().WithScheme(s).WithObjects(tgw, route).WithStatusSubresource(tgw, &gatewayv1.HTTPRoute{}).Build()
 
-	r := &Reconciler{Client: c, Scheme: s}
+	r := &Reconciler{Client: c, Reader: c, Scheme: s}
 	if _, err := r.Reconcile(context.TODO(), ctrl.Request{
 		NamespacedName: types.NamespacedName{Name: "cozystack", Namespace: "tenant-foo"},
 	}); err != nil {
@@ -3178,8 +3225,8 @@ func TestReconcile_DNS01WildcardCertOwnerReference(t *testing.T) {
 			},
 		},
 	}
-	c := fake.NewClientBuilder().WithScheme(s).WithObjects(tgw).WithStatusSubresource(tgw).Build()
-	r := &Reconciler{Client: c, Scheme: s}
+	c := fake.NewClientBuilder().WithScheme(s).WithObjects(dns01Secret("tenant-foo", "cf-token", "api-token"), tgw).WithStatusSubresource(tgw).Build()
+	r := &Reconciler{Client: c, Reader: c, Scheme: s}
 	if _, err := r.Reconcile(context.TODO(), ctrl.Request{
 		NamespacedName: types.NamespacedName{Name: "cozystack", Namespace: "tenant-foo"},
 	}); err != nil {
@@ -3219,7 +3266,7 @@ func TestReconcile_GatewayUpdateRestoresControllerLabel(t *testing.T) {
 		},
 	}
 	c := fake.NewClientBuilder().WithScheme(s).WithObjects(tgw).WithStatusSubresource(tgw, &gatewayv1.Gateway{}).Build()
-	r := &Reconciler{Client: c, Scheme: s}
+	r := &Reconciler{Client: c, Reader: c, Scheme: s}
 	if _, err := r.Reconcile(context.TODO(), ctrl.Request{
 		NamespacedName: types.NamespacedName{Name: "cozystack", Namespace: "tenant-foo"},
 	}); err != nil {
@@ -3314,7 +3361,7 @@ func TestReconcile_MultiParentRefRouteWritesPerRefStatus(t *testing.T) {
 		WithStatusSubresource(tgw, &gatewayv1.HTTPRoute{}).
 		Build()
 
-	r := &Reconciler{Client: c, Scheme: s}
+	r := &Reconciler{Client: c, Reader: c, Scheme: s}
 	if _, err := r.Reconcile(context.TODO(), ctrl.Request{
 		NamespacedName: types.NamespacedName{Name: "cozystack", Namespace: "tenant-foo"},
 	}); err != nil {
@@ -3362,7 +3409,7 @@ func TestReconcile_ExistingSecretModeRendersWildcardListenerWithSecretRef(t *tes
 	}
 	c := fake.NewClientBuilder().WithScheme(s).WithObjects(tgw).WithStatusSubresource(tgw).Build()
 
-	r := &Reconciler{Client: c, Scheme: s}
+	r := &Reconciler{Client: c, Reader: c, Scheme: s}
 	if _, err := r.Reconcile(context.TODO(), ctrl.Request{
 		NamespacedName: types.NamespacedName{Name: "cozystack", Namespace: "tenant-foo"},
 	}); err != nil {
@@ -3416,7 +3463,7 @@ func TestReconcile_ExistingSecretModeCreatesNoIssuerOrCertificate(t *testing.T)
 	}
 	c := fake.NewClientBuilder().WithScheme(s).WithObjects(tgw).WithStatusSubresource(tgw).Build()
 
-	r := &Reconciler{Client: c, Scheme: s}
+	r := &Reconciler{Client: c, Reader: c, Scheme: s}
 	if _, err := r.Reconcile(context.TODO(), ctrl.Request{
 		NamespacedName: types.NamespacedName{Name: "cozystack", Namespace: "tenant-foo"},
 	}); err != nil {
@@ -3451,7 +3498,7 @@ func TestReconcile_ExistingSecretModeMissingSecretRefFails(t *testing.T) {
 	}
 	c := fake.NewClientBuilder().WithScheme(s).WithObjects(tgw).WithStatusSubresource(tgw).Build()
 
-	r := &Reconciler{Client: c, Scheme: s}
+	r := &Reconciler{Client: c, Reader: c, Scheme: s}
 	if _, err := r.Reconcile(context.TODO(), ctrl.Request{
 		NamespacedName: types.NamespacedName{Name: "cozystack", Namespace: "tenant-foo"},
 	}); err == nil {
@@ -3490,7 +3537,7 @@ func TestReconcile_CertModeTransitionHTTP01ToExistingSecretCleansCertsAndIssuer(
 	}
 	route := httpRouteAttached("harbor", "cozy-harbor", "harbor.foo.example.com")
 	c := fake.NewClientBuilder().WithScheme(s).WithObjects(tgw, route).WithStatusSubresource(tgw, &gatewayv1.Gateway{}).Build()
-	r := &Reconciler{Client: c, Scheme: s}
+	r := &Reconciler{Client: c, Reader: c, Scheme: s}
 
 	// Phase 1: HTTP-01 reconcile creates an Issuer + per-listener cert.
 	if _, err := r.Reconcile(context.TODO(), ctrl.Request{
@@ -3560,8 +3607,8 @@ func TestReconcile_CertModeTransitionDNS01ToExistingSecretCleansWildcardCert(t *
 			},
 		},
 	}
-	c := fake.NewClientBuilder().WithScheme(s).WithObjects(tgw).WithStatusSubresource(tgw, &gatewayv1.Gateway{}).Build()
-	r := &Reconciler{Client: c, Scheme: s}
+	c := fake.NewClientBuilder().WithScheme(s).WithObjects(dns01Secret("tenant-foo", "cf-token", "api-token"), tgw).WithStatusSubresource(tgw, &gatewayv1.Gateway{}).Build()
+	r := &Reconciler{Client: c, Reader: c, Scheme: s}
 
 	if _, err := r.Reconcile(context.TODO(), ctrl.Request{
 		NamespacedName: types.NamespacedName{Name: "cozystack", Namespace: "tenant-foo"},
@@ -3611,7 +3658,7 @@ func TestReconcile_ExistingSecretModeKeepsHTTPRedirectAndPassthrough(t *testing.
 	}
 	c := fake.NewClientBuilder().WithScheme(s).WithObjects(tgw).WithStatusSubresource(tgw).Build()
 
-	r := &Reconciler{Client: c, Scheme: s}
+	r := &Reconciler{Client: c, Reader: c, Scheme: s}
 	if _, err := r.Reconcile(context.TODO(), ctrl.Request{
 		NamespacedName: types.NamespacedName{Name: "cozystack", Namespace: "tenant-foo"},
 	}); err != nil {
@@ -3862,14 +3909,14 @@ func TestReconcile_Port443ListenersShareKinds(t *testing.T) {
 			s := newScheme(t)
 			builder := fake.NewClientBuilder().
 				WithScheme(s).
-				WithObjects(tc.tgw).
+				WithObjects(dns01Secret("tenant-foo", "cf-token", "api-token"), tc.tgw).
 				WithStatusSubresource(tc.tgw)
 			if len(tc.objects) > 0 {
 				builder = builder.WithObjects(tc.objects...)
 			}
 			c := builder.Build()
 
-			r := &Reconciler{Client: c, Scheme: s}
+			r := &Reconciler{Client: c, Reader: c, Scheme: s}
 			if _, err := r.Reconcile(context.TODO(), ctrl.Request{
 				NamespacedName: types.NamespacedName{Name: "cozystack", Namespace: "tenant-foo"},
 			}); err != nil {
@@ -3934,7 +3981,7 @@ func TestReconcile_ExistingSecretModeRendersChildApexListenerWithOperatorSecret(
 		WithStatusSubresource(tgw).
 		Build()
 
-	r := &Reconciler{Client: c, Scheme: s}
+	r := &Reconciler{Client: c, Reader: c, Scheme: s}
 	if _, err := r.Reconcile(context.TODO(), ctrl.Request{
 		NamespacedName: types.NamespacedName{Name: "cozystack", Namespace: "tenant-root"},
 	}); err != nil {
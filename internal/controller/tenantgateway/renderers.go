@@ -212,18 +212,17 @@ func perListenerCertName(tgw *gatewayv1alpha1.TenantGateway, hostname string) st
 	return tgw.Name + "-" + hostnameFirstLabel(hostname) + "-" + hostnameSuffix(hostname) + "-tls"
 }
 
-// renderIssuer builds the per-tenant ACME Issuer. The solver block
-// is selected by certMode: HTTP-01 with a gatewayHTTPRoute solver
-// pointing back at the tenant's own Gateway/http listener, or DNS-01
-// with the operator-supplied provider config. The ACME server URL is
-// selected by spec.issuerName.
-func (r *Reconciler) renderIssuer(tgw *gatewayv1alpha1.TenantGateway) (*cmv1.Issuer, error) {
-	server, err := acmeServerForIssuer(tgw.Spec.IssuerName)
-	if err != nil {
-		return nil, err
-	}
-
-	solver, err := buildSolver(tgw)
+// renderIssuer builds the per-tenant Issuer. Without spec.issuer it
+// is an ACME Issuer on the Let's Encrypt environment selected by
+// spec.issuerName; spec.issuer swaps in a custom ACME server, a CA or
+// a Vault issuer (see buildIssuerConfig). For ACME issuers the solver
+// block is selected by certMode: HTTP-01 with a gatewayHTTPRoute
+// solver pointing back at the tenant's own Gateway/http listener, or
+// DNS-01 with the operator-supplied provider config. customHostnames
+// are the verified custom domains, whose zones the DNS-01 provider
+// cannot write to.
+func (r *Reconciler) renderIssuer(tgw *gatewayv1alpha1.TenantGateway, customHostnames []string) (*cmv1.Issuer, error) {
+	config, err := buildIssuerConfig(tgw, customHostnames)
 	if err != nil {
 		return nil, err
 	}
@@ -236,19 +235,7 @@ func (r *Reconciler) renderIssuer(tgw *gatewayv1alpha1.TenantGateway) (*cmv1.Iss
 				cozystackManagedByLabel: cozystackManagedByValue,
 			},
 		},
-		Spec: cmv1.IssuerSpec{
-			IssuerConfig: cmv1.IssuerConfig{
-				ACME: &cmacmev1.ACMEIssuer{
-					Server: server,
-					PrivateKey: cmmetav1.SecretKeySelector{
-						LocalObjectReference: cmmetav1.LocalObjectReference{
-							Name: tgw.Name + "-acme-account",
-						},
-					},
-					Solvers: []cmacmev1.ACMEChallengeSolver{*solver},
-				},
-			},
-		},
+		Spec: cmv1.IssuerSpec{IssuerConfig: config},
 	}
 	if err := controllerutil.SetControllerReference(tgw, issuer, r.Scheme); err != nil {
 		return nil, err
@@ -256,30 +243,57 @@ func (r *Reconciler) renderIssuer(tgw *gatewayv1alpha1.TenantGateway) (*cmv1.Iss
 	return issuer, nil
 }
 
-func buildSolver(tgw *gatewayv1alpha1.TenantGateway) (*cmacmev1.ACMEChallengeSolver, error) {
-	switch tgw.Spec.CertMode {
-	case gatewayv1alpha1.CertModeHTTP01, "":
-		// HTTP-01 with gatewayHTTPRoute solver pointing at the tenant's
-		// own Gateway. cert-manager publishes a transient HTTPRoute
-		// attached to sectionName=http on this Gateway; the local Cilium
-		// data plane forwards the ACME challenge HTTP request.
-		section := gatewayv1.SectionName("http")
-		ns := gatewayv1.Namespace(tgw.Namespace)
-		return &cmacmev1.ACMEChallengeSolver{
-			HTTP01: &cmacmev1.ACMEChallengeSolverHTTP01{
-				GatewayHTTPRoute: &cmacmev1.ACMEChallengeSolverHTTP01GatewayHTTPRoute{
-					ParentRefs: []gatewayv1.ParentReference{
-						{
-							Group:       ptrGroup(gatewayv1.GroupName),
-							Kind:        ptrKind("Gateway"),
-							Name:        gatewayv1.ObjectName(tgw.Name),
-							Namespace:   &ns,
-							SectionName: &section,
-						},
+// acmeIssuer completes an ACME issuer block with the per-tenant
+// account key and the certMode's solver. In DNS-01 mode the custom
+// domains are solved over HTTP-01 instead: cert-manager prefers the
+// solver whose selector names the domain.
+func acmeIssuer(tgw *gatewayv1alpha1.TenantGateway, acme *cmacmev1.ACMEIssuer, customHostnames []string) (*cmacmev1.ACMEIssuer, error) {
+	solver, err := buildSolver(tgw)
+	if err != nil {
+		return nil, err
+	}
+	acme.PrivateKey = cmmetav1.SecretKeySelector{
+		LocalObjectReference: cmmetav1.LocalObjectReference{
+			Name: tgw.Name + "-acme-account",
+		},
+	}
+	acme.Solvers = []cmacmev1.ACMEChallengeSolver{*solver}
+	if tgw.Spec.CertMode == gatewayv1alpha1.CertModeDNS01 && len(customHostnames) > 0 {
+		custom := http01Solver(tgw)
+		custom.Selector = &cmacmev1.CertificateDNSNameSelector{DNSNames: customHostnames}
+		acme.Solvers = append(acme.Solvers, *custom)
+	}
+	return acme, nil
+}
+
+// http01Solver is the HTTP-01 solver with gatewayHTTPRoute pointing
+// at the tenant's own Gateway. cert-manager publishes a transient
+// HTTPRoute attached to sectionName=http on this Gateway; the local
+// Cilium data plane forwards the ACME challenge HTTP request.
+func http01Solver(tgw *gatewayv1alpha1.TenantGateway) *cmacmev1.ACMEChallengeSolver {
+	section := gatewayv1.SectionName("http")
+	ns := gatewayv1.Namespace(tgw.Namespace)
+	return &cmacmev1.ACMEChallengeSolver{
+		HTTP01: &cmacmev1.ACMEChallengeSolverHTTP01{
+			GatewayHTTPRoute: &cmacmev1.ACMEChallengeSolverHTTP01GatewayHTTPRoute{
+				ParentRefs: []gatewayv1.ParentReference{
+					{
+						Group:       ptrGroup(gatewayv1.GroupName),
+						Kind:        ptrKind("Gateway"),
+						Name:        gatewayv1.ObjectName(tgw.Name),
+						Namespace:   &ns,
+						SectionName: &section,
 					},
 				},
 			},
-		}, nil
+		},
+	}
+}
+
+func buildSolver(tgw *gatewayv1alpha1.TenantGateway) (*cmacmev1.ACMEChallengeSolver, error) {
+	switch tgw.Spec.CertMode {
+	case gatewayv1alpha1.CertModeHTTP01, "":
+		return http01Solver(tgw), nil
 
 	case gatewayv1alpha1.CertModeDNS01:
 		if tgw.Spec.DNS01 == nil {
@@ -356,8 +370,73 @@ func buildSolver(tgw *gatewayv1alpha1.TenantGateway) (*cmacmev1.ACMEChallengeSol
 					},
 				},
 			}, nil
+		case "clouddns":
+			if tgw.Spec.DNS01.CloudDNS == nil {
+				return nil, fmt.Errorf("dns01.provider=clouddns requires dns01.clouddns to be set")
+			}
+			cfg := tgw.Spec.DNS01.CloudDNS
+			cloudDNS := &cmacmev1.ACMEIssuerDNS01ProviderCloudDNS{
+				Project:        cfg.Project,
+				HostedZoneName: cfg.HostedZoneName,
+			}
+			if cfg.ServiceAccountSecretRef != nil {
+				cloudDNS.ServiceAccount = &cmmetav1.SecretKeySelector{
+					LocalObjectReference: cmmetav1.LocalObjectReference{Name: cfg.ServiceAccountSecretRef.Name},
+					Key:                  cfg.ServiceAccountSecretRef.Key,
+				}
+			}
+			return &cmacmev1.ACMEChallengeSolver{
+				DNS01: &cmacmev1.ACMEChallengeSolverDNS01{CloudDNS: cloudDNS},
+			}, nil
+		case "azuredns":
+			if tgw.Spec.DNS01.AzureDNS == nil {
+				return nil, fmt.Errorf("dns01.provider=azuredns requires dns01.azuredns to be set")
+			}
+			cfg := tgw.Spec.DNS01.AzureDNS
+			// A partial service principal would make cert-manager fall
+			// back to the managed identity silently; require all or none.
+			principal := cfg.TenantID != "" || cfg.ClientID != "" || cfg.ClientSecretSecretRef != nil
+			if principal && (cfg.TenantID == "" || cfg.ClientID == "" || cfg.ClientSecretSecretRef == nil) {
+				return nil, fmt.Errorf("dns01.azuredns: tenantID, clientID and clientSecretSecretRef must be set together")
+			}
+			env := cmacmev1.AzureDNSEnvironment(cfg.Environment)
+			if env == "" {
+				env = cmacmev1.AzurePublicCloud
+			}
+			azureDNS := &cmacmev1.ACMEIssuerDNS01ProviderAzureDNS{
+				SubscriptionID:    cfg.SubscriptionID,
+				ResourceGroupName: cfg.ResourceGroupName,
+				HostedZoneName:    cfg.HostedZoneName,
+				Environment:       env,
+			}
+			if principal {
+				azureDNS.TenantID = cfg.TenantID
+				azureDNS.ClientID = cfg.ClientID
+				azureDNS.ClientSecret = &cmmetav1.SecretKeySelector{
+					LocalObjectReference: cmmetav1.LocalObjectReference{Name: cfg.ClientSecretSecretRef.Name},
+					Key:                  cfg.ClientSecretSecretRef.Key,
+				}
+			}
+			return &cmacmev1.ACMEChallengeSolver{
+				DNS01: &cmacmev1.ACMEChallengeSolverDNS01{AzureDNS: azureDNS},
+			}, nil
+		case "hetzner":
+			if tgw.Spec.DNS01.Hetzner == nil {
+				return nil, fmt.Errorf("dns01.provider=hetzner requires dns01.hetzner to be set")
+			}
+			return buildHetznerSolver(tgw)
+		case "powerdns":
+			if tgw.Spec.DNS01.PowerDNS == nil {
+				return nil, fmt.Errorf("dns01.provider=powerdns requires dns01.powerdns to be set")
+			}
+			return buildPowerDNSSolver(tgw)
+		case "webhook":
+			if tgw.Spec.DNS01.Webhook == nil {
+				return nil, fmt.Errorf("dns01.provider=webhook requires dns01.webhook to be set")
+			}
+			return buildGenericWebhookSolver(tgw)
 		default:
-			return nil, fmt.Errorf("unsupported dns01.provider=%q (supported: cloudflare, route53, digitalocean, rfc2136)", tgw.Spec.DNS01.Provider)
+			return nil, fmt.Errorf("unsupported dns01.provider=%q (supported: cloudflare, route53, digitalocean, rfc2136, clouddns, azuredns, hetzner, powerdns, webhook)", tgw.Spec.DNS01.Provider)
 		}
 
 	case gatewayv1alpha1.CertModeExistingSecret:
@@ -494,11 +573,11 @@ func (r *Reconciler) renderHTTPRedirect(tgw *gatewayv1alpha1.TenantGateway) (*ga
 }
 
 // renderPerListenerCertificate builds a cert-manager Certificate for a
-// single hostname (HTTP-01 mode). Each per-app listener references
-// this cert via its TLS configuration. Returns an error if the
-// scheme can't establish the controllerRef back to the
-// TenantGateway — without it, deleting the TenantGateway leaves
-// orphan Certificates behind.
+// single hostname (HTTP-01 mode, or a custom domain in DNS-01 mode).
+// Each per-app listener references this cert via its TLS
+// configuration. Returns an error if the scheme can't establish the
+// controllerRef back to the TenantGateway — without it, deleting the
+// TenantGateway leaves orphan Certificates behind.
 func (r *Reconciler) renderPerListenerCertificate(tgw *gatewayv1alpha1.TenantGateway, hostname string) (*cmv1.Certificate, error) {
 	name := perListenerCertName(tgw, hostname)
 	cert := &cmv1.Certificate{
@@ -37,6 +37,8 @@ func (r *Reconciler) reconcileStatus(
 	ctx context.Context,
 	tgw *gatewayv1alpha1.TenantGateway,
 	dynHostnames []string,
+	l4Invalid []string,
+	issuerSecrets *metav1.Condition,
 ) error {
 	gw := &gatewayv1.Gateway{}
 	if err := r.Get(ctx, types.NamespacedName{Namespace: tgw.Namespace, Name: tgw.Name}, gw); err != nil {
@@ -50,9 +52,12 @@ func (r *Reconciler) reconcileStatus(
 	for _, l := range gw.Spec.Listeners {
 		ready, reason := listenerReadinessFromGatewayStatus(string(l.Name), gwListenerStatus)
 		s := gatewayv1alpha1.TenantGatewayListenerStatus{
-			Name:   string(l.Name),
-			Ready:  ready,
-			Reason: reason,
+			Name:           string(l.Name),
+			Port:           int32(l.Port),
+			Protocol:       string(l.Protocol),
+			AttachedRoutes: gwListenerStatus[string(l.Name)].AttachedRoutes,
+			Ready:          ready,
+			Reason:         reason,
 		}
 		if l.Hostname != nil {
 			s.Hostname = string(*l.Hostname)
@@ -68,6 +73,22 @@ func (r *Reconciler) reconcileStatus(
 
 	gwAccepted, gwProgrammed := gatewayConditionStatus(gw.Status.Conditions)
 
+	// existingSecret mode renders no Issuer; every other mode issues
+	// through the per-tenant one, so a CA Secret cert-manager cannot
+	// parse or an ACME account it cannot register blocks the Gateway
+	// as surely as an unprogrammed listener.
+	// issuerSecrets, the SecretNotFound condition reconcileIssuer
+	// returns for a missing credential, takes the place of the
+	// Issuer's own readiness: the Issuer was left as it was.
+	issuerReady := issuerSecrets
+	if issuerReady == nil && tgw.Spec.CertMode != gatewayv1alpha1.CertModeExistingSecret {
+		cond, err := r.issuerReadiness(ctx, tgw)
+		if err != nil {
+			return err
+		}
+		issuerReady = &cond
+	}
+
 	var ready metav1.Condition
 	switch {
 	case !gwAccepted:
@@ -78,6 +99,14 @@ func (r *Reconciler) reconcileStatus(
 			Reason:             "GatewayNotAccepted",
 			Message:            fmt.Sprintf("Underlying Gateway %s/%s has not been accepted by its controller yet", tgw.Namespace, tgw.Name),
 		}
+	case issuerReady != nil && issuerReady.Status != metav1.ConditionTrue:
+		ready = metav1.Condition{
+			Type:               "Ready",
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: tgw.Generation,
+			Reason:             "IssuerNotReady",
+			Message:            issuerReady.Message,
+		}
 	case !gwProgrammed:
 		ready = metav1.Condition{
 			Type:               "Ready",
@@ -108,6 +137,16 @@ func (r *Reconciler) reconcileStatus(
 	stale.Status.ObservedGeneration = tgw.Generation
 	stale.Status.Listeners = listeners
 	meta.SetStatusCondition(&stale.Status.Conditions, ready)
+	if issuerReady != nil {
+		meta.SetStatusCondition(&stale.Status.Conditions, *issuerReady)
+	} else {
+		meta.RemoveStatusCondition(&stale.Status.Conditions, issuerReadyCondition)
+	}
+	if cond := l4ListenersCondition(tgw, l4Invalid); cond != nil {
+		meta.SetStatusCondition(&stale.Status.Conditions, *cond)
+	} else {
+		meta.RemoveStatusCondition(&stale.Status.Conditions, l4ListenersValidCondition)
+	}
 
 	if statusEqual(tgw.Status, stale.Status) {
 		return nil
@@ -0,0 +1,812 @@
+/*
+Copyright 2026 The Cozystack Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package tenanthibernation carries out TenantHibernations: it stops a tenant
+// and its sub-tenants while they are not in use, and starts them again.
+//
+// Hibernating walks the tenant namespace and the namespaces of its
+// sub-tenants and
+//
+//   - suspends every HelmRelease, so that Flux does not undo what follows;
+//   - scales every Deployment and StatefulSet no other object controls to
+//     zero replicas;
+//   - halts every KubeVirt VirtualMachine, including the nodes of tenant
+//     Kubernetes clusters, whose Cluster API Clusters are paused first so
+//     that Cluster API does not remediate the halted nodes;
+//   - hibernates the workloads of operators through their own settings:
+//     CloudNativePG Clusters with the cnpg.io/hibernation annotation, and
+//     Kamaji control planes by scaling their KamajiControlPlane to zero.
+//
+// Deployments and StatefulSets that any other controller scales cannot be
+// stopped: they are listed in the status as skipped, and the tenant stays
+// Hibernating rather than Hibernated while any of them runs.
+//
+// Volumes are kept. What each object ran before is recorded in the status,
+// before the object is touched, so a hibernation interrupted half way resumes
+// everything it stopped.
+//
+// Resuming proceeds in waves, in dependency order: a HelmRelease resumes once
+// the releases of the tenants above it and those it dependsOn are Ready again,
+// and the workloads it deployed are restored with it.
+package tenanthibernation
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	helmv2 "github.com/fluxcd/helm-controller/api/v2"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	cozyv1alpha1 "github.com/cozystack/cozystack/api/v1alpha1"
+)
+
+const (
+	// pollInterval is how often a resuming tenant looks again for releases
+	// that became Ready.
+	pollInterval = 15 * time.Second
+	// sweepInterval is how often a hibernated tenant is swept again for
+	// applications deployed since it hibernated.
+	sweepInterval = 5 * time.Minute
+
+	tenantNamespacePrefix = "tenant-"
+	rootTenantNamespace   = "tenant-root"
+
+	// Helm records the release an object belongs to in these annotations.
+	helmReleaseNameAnnotation      = "meta.helm.sh/release-name"
+	helmReleaseNamespaceAnnotation = "meta.helm.sh/release-namespace"
+
+	kindDeployment         = "Deployment"
+	kindStatefulSet        = "StatefulSet"
+	kindVirtualMachine     = "VirtualMachine"
+	kindCNPGCluster        = "Cluster"
+	kindKamajiControlPlane = "KamajiControlPlane"
+	// kindCAPICluster is the Cluster of Cluster API, told apart from the
+	// CloudNativePG Cluster by its group.
+	kindCAPICluster = "Cluster"
+
+	runStrategyHalted = "Halted"
+
+	// cnpgHibernationAnnotation set to "on" makes CloudNativePG shut a
+	// Cluster's instances down, keeping their volumes.
+	cnpgHibernationAnnotation = "cnpg.io/hibernation"
+	cnpgHibernationOn         = "on"
+)
+
+// The kinds of optional components are handled as unstructured: the
+// KubeVirt VirtualMachine, the CloudNativePG Cluster, the Cluster API
+// Cluster and the KamajiControlPlane, whose TenantControlPlane controls the
+// control plane Deployment.
+var (
+	capiClusterGVK         = schema.GroupVersionKind{Group: "cluster.x-k8s.io", Version: "v1beta1", Kind: kindCAPICluster}
+	virtualMachineGVK      = schema.GroupVersionKind{Group: "kubevirt.io", Version: "v1", Kind: kindVirtualMachine}
+	cnpgClusterGVK         = schema.GroupVersionKind{Group: "postgresql.cnpg.io", Version: "v1", Kind: kindCNPGCluster}
+	kamajiControlPlaneGVK  = schema.GroupVersionKind{Group: "controlplane.cluster.x-k8s.io", Version: "v1alpha1", Kind: kindKamajiControlPlane}
+	tenantControlPlaneKind = schema.GroupKind{Group: "kamaji.clastix.io", Kind: "TenantControlPlane"}
+)
+
+// +kubebuilder:rbac:groups=cozystack.io,resources=tenanthibernations,verbs=get;list;watch
+// +kubebuilder:rbac:groups=cozystack.io,resources=tenanthibernations/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups="",resources=namespaces,verbs=list
+// +kubebuilder:rbac:groups=helm.toolkit.fluxcd.io,resources=helmreleases,verbs=get;list;patch
+// +kubebuilder:rbac:groups=apps,resources=deployments;statefulsets,verbs=get;list;patch
+// +kubebuilder:rbac:groups=kubevirt.io,resources=virtualmachines,verbs=get;list;patch
+// +kubebuilder:rbac:groups=postgresql.cnpg.io,resources=clusters,verbs=get;list;patch
+// +kubebuilder:rbac:groups=controlplane.cluster.x-k8s.io,resources=kamajicontrolplanes,verbs=get;list;patch
+// +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=clusters,verbs=get;list;patch
+
+// Reconciler drives tenants to the hibernation state their TenantHibernation
+// asks for.
+type Reconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+	// Now returns the current time; time.Now when nil.
+	Now func() time.Time
+}
+
+// Reconcile settles whether the tenant should be hibernated, then hibernates
+// or resumes it, and requeues for the next scheduled change.
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	h := &cozyv1alpha1.TenantHibernation{}
+	if err := r.Get(ctx, req.NamespacedName, h); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if !strings.HasPrefix(h.Namespace, tenantNamespacePrefix) {
+		return ctrl.Result{}, r.setMessage(ctx, h, "%s is not a tenant namespace", h.Namespace)
+	}
+
+	before := h.Status.DeepCopy()
+	next, err := follow(h, r.now())
+	if err != nil {
+		r.event(h, corev1.EventTypeWarning, "InvalidSchedule", "%v", err)
+		return ctrl.Result{}, r.setMessage(ctx, h, "%v", err)
+	}
+	if !equality.Semantic.DeepEqual(before, &h.Status) {
+		if err := r.Status().Update(ctx, h); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	var result ctrl.Result
+	if h.Status.Hibernated {
+		result, err = r.hibernate(ctx, h)
+	} else {
+		result, err = r.resume(ctx, h)
+	}
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if !next.IsZero() {
+		if until := next.Sub(r.now()); result.RequeueAfter == 0 || until < result.RequeueAfter {
+			result.RequeueAfter = until
+		}
+	}
+	return result, nil
+}
+
+// hibernate records and stops whatever in the tenant still runs. It runs on
+// every reconcile of a hibernated tenant, so applications deployed meanwhile
+// are stopped too.
+func (r *Reconciler) hibernate(ctx context.Context, h *cozyv1alpha1.TenantHibernation) (ctrl.Result, error) {
+	namespaces, err := r.tenantNamespaces(ctx, h.Namespace)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	before := h.Status.DeepCopy()
+	recorded, skipped, err := r.record(ctx, h, namespaces)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if recorded || (h.Status.Phase != cozyv1alpha1.TenantHibernated && h.Status.Phase != cozyv1alpha1.TenantHibernating) {
+		h.Status.Phase = cozyv1alpha1.TenantHibernating
+		h.Status.Message = fmt.Sprintf("stopping %d releases and %d workloads", len(h.Status.Releases), len(h.Status.Workloads))
+		// What is about to be stopped is persisted before it is stopped.
+		if err := r.Status().Update(ctx, h); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	for _, rec := range h.Status.Releases {
+		if err := r.setSuspended(ctx, rec.Namespace, rec.Name, true); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+	// Cluster API Clusters are paused before anything else stops, so that
+	// the halted VirtualMachines of their nodes are not remediated.
+	for _, pass := range []bool{true, false} {
+		for _, w := range h.Status.Workloads {
+			if isCAPICluster(w) != pass {
+				continue
+			}
+			if err := r.stop(ctx, w); err != nil {
+				return ctrl.Result{}, err
+			}
+		}
+	}
+
+	if len(skipped) > 0 {
+		h.Status.Phase = cozyv1alpha1.TenantHibernating
+		h.Status.Skipped = skipped
+		h.Status.Message = fmt.Sprintf("suspended %d releases and stopped %d workloads; %d workloads other controllers scale keep running",
+			len(h.Status.Releases), len(h.Status.Workloads), len(skipped))
+		if equality.Semantic.DeepEqual(before, &h.Status) {
+			return ctrl.Result{RequeueAfter: sweepInterval}, nil
+		}
+		if err := r.Status().Update(ctx, h); err != nil {
+			return ctrl.Result{}, err
+		}
+		r.event(h, corev1.EventTypeWarning, "WorkloadsSkipped", "%s", h.Status.Message)
+		return ctrl.Result{RequeueAfter: sweepInterval}, nil
+	}
+	if h.Status.Phase == cozyv1alpha1.TenantHibernated {
+		return ctrl.Result{RequeueAfter: sweepInterval}, nil
+	}
+	now := metav1.NewTime(r.now())
+	h.Status.Phase = cozyv1alpha1.TenantHibernated
+	h.Status.HibernatedAt = &now
+	h.Status.Skipped = nil
+	h.Status.Message = fmt.Sprintf("suspended %d releases and stopped %d workloads", len(h.Status.Releases), len(h.Status.Workloads))
+	if err := r.Status().Update(ctx, h); err != nil {
+		return ctrl.Result{}, err
+	}
+	r.event(h, corev1.EventTypeNormal, string(h.Status.Phase), "%s", h.Status.Message)
+	return ctrl.Result{RequeueAfter: sweepInterval}, nil
+}
+
+// record adds to the status the releases and running workloads of namespaces
+// it does not list yet, and reports whether it changed anything, along with
+// the running workloads it cannot stop. Releases a resume already got to are
+// marked as not resumed again.
+func (r *Reconciler) record(ctx context.Context, h *cozyv1alpha1.TenantHibernation, namespaces []string) (bool, []cozyv1alpha1.SkippedWorkload, error) {
+	changed := false
+	releases := map[string]bool{}
+	for i := range h.Status.Releases {
+		rec := &h.Status.Releases[i]
+		releases[rec.Namespace+"/"+rec.Name] = true
+		if rec.Resumed {
+			rec.Resumed = false
+			changed = true
+		}
+	}
+	workloads := map[string]bool{}
+	for _, w := range h.Status.Workloads {
+		workloads[workloadKey(w)] = true
+	}
+	addWorkload := func(w cozyv1alpha1.HibernatedWorkload) {
+		if !workloads[workloadKey(w)] {
+			h.Status.Workloads = append(h.Status.Workloads, w)
+			changed = true
+		}
+	}
+	var skipped []cozyv1alpha1.SkippedWorkload
+
+	// Maps Helm release namespace/name to HelmRelease namespace/name.
+	releaseOf := map[string]string{}
+	for _, ns := range namespaces {
+		hrs := &helmv2.HelmReleaseList{}
+		if err := r.List(ctx, hrs, client.InNamespace(ns)); err != nil {
+			return false, nil, err
+		}
+		for i := range hrs.Items {
+			hr := &hrs.Items[i]
+			key := hr.Namespace + "/" + hr.Name
+			releaseOf[hr.GetReleaseNamespace()+"/"+hr.GetReleaseName()] = key
+			if releases[key] {
+				continue
+			}
+			h.Status.Releases = append(h.Status.Releases, cozyv1alpha1.HibernatedRelease{
+				Namespace:    hr.Namespace,
+				Name:         hr.Name,
+				WasSuspended: hr.Spec.Suspend,
+			})
+			changed = true
+		}
+	}
+	release := func(obj metav1.Object) string {
+		a := obj.GetAnnotations()
+		return releaseOf[a[helmReleaseNamespaceAnnotation]+"/"+a[helmReleaseNameAnnotation]]
+	}
+
+	for _, ns := range namespaces {
+		clusters, err := r.listOptional(ctx, cnpgClusterGVK, ns)
+		if err != nil {
+			return false, nil, err
+		}
+		for i := range clusters {
+			c := &clusters[i]
+			if hibernation := c.GetAnnotations()[cnpgHibernationAnnotation]; hibernation != cnpgHibernationOn {
+				addWorkload(cozyv1alpha1.HibernatedWorkload{Kind: kindCNPGCluster, APIGroup: cnpgClusterGVK.Group, Namespace: ns, Name: c.GetName(),
+					Release: release(c), Hibernation: hibernation})
+			}
+		}
+		planes, err := r.listOptional(ctx, kamajiControlPlaneGVK, ns)
+		if err != nil {
+			return false, nil, err
+		}
+		// The TenantControlPlanes of these KamajiControlPlanes, named after
+		// them, scale with them.
+		controlPlanes := map[string]bool{}
+		for i := range planes {
+			p := &planes[i]
+			controlPlanes[p.GetName()] = true
+			w := cozyv1alpha1.HibernatedWorkload{Kind: kindKamajiControlPlane, APIGroup: kamajiControlPlaneGVK.Group, Namespace: ns, Name: p.GetName(), Release: release(p)}
+			if replicas, found, _ := unstructured.NestedInt64(p.Object, "spec", "replicas"); found {
+				if replicas == 0 {
+					continue
+				}
+				w.Replicas = ptr.To(int32(replicas))
+			}
+			addWorkload(w)
+		}
+		// controlled reports whether a running Deployment or StatefulSet is
+		// another controller's, noting it as skipped unless its controller
+		// is stopped by the hibernation.
+		controlled := func(kind string, obj metav1.Object) bool {
+			owner := metav1.GetControllerOf(obj)
+			if owner == nil {
+				return false
+			}
+			gv, _ := schema.ParseGroupVersion(owner.APIVersion)
+			if (schema.GroupKind{Group: gv.Group, Kind: owner.Kind}) != tenantControlPlaneKind || !controlPlanes[owner.Name] {
+				skipped = append(skipped, cozyv1alpha1.SkippedWorkload{Kind: kind, Namespace: ns, Name: obj.GetName(), Controller: owner.Kind + "/" + owner.Name})
+			}
+			return true
+		}
+
+		deployments := &appsv1.DeploymentList{}
+		if err := r.List(ctx, deployments, client.InNamespace(ns)); err != nil {
+			return false, nil, err
+		}
+		for i := range deployments.Items {
+			d := &deployments.Items[i]
+			if replicas := ptr.Deref(d.Spec.Replicas, 1); replicas > 0 && !controlled(kindDeployment, d) {
+				addWorkload(cozyv1alpha1.HibernatedWorkload{Kind: kindDeployment, Namespace: ns, Name: d.Name, Release: release(d), Replicas: &replicas})
+			}
+		}
+		statefulSets := &appsv1.StatefulSetList{}
+		if err := r.List(ctx, statefulSets, client.InNamespace(ns)); err != nil {
+			return false, nil, err
+		}
+		for i := range statefulSets.Items {
+			s := &statefulSets.Items[i]
+			if replicas := ptr.Deref(s.Spec.Replicas, 1); replicas > 0 && !controlled(kindStatefulSet, s) {
+				addWorkload(cozyv1alpha1.HibernatedWorkload{Kind: kindStatefulSet, Namespace: ns, Name: s.Name, Release: release(s), Replicas: &replicas})
+			}
+		}
+
+		capiClusters, err := r.listOptional(ctx, capiClusterGVK, ns)
+		if err != nil {
+			return false, nil, err
+		}
+		for i := range capiClusters {
+			c := &capiClusters[i]
+			if paused, _, _ := unstructured.NestedBool(c.Object, "spec", "paused"); !paused {
+				addWorkload(cozyv1alpha1.HibernatedWorkload{Kind: kindCAPICluster, APIGroup: capiClusterGVK.Group, Namespace: ns, Name: c.GetName(), Release: release(c)})
+			}
+		}
+
+		vms, err := r.listOptional(ctx, virtualMachineGVK, ns)
+		if err != nil {
+			return false, nil, err
+		}
+		for i := range vms {
+			vm := &vms[i]
+			w := cozyv1alpha1.HibernatedWorkload{Kind: kindVirtualMachine, Namespace: ns, Name: vm.GetName(), Release: release(vm)}
+			strategy, _, _ := unstructured.NestedString(vm.Object, "spec", "runStrategy")
+			running, hasRunning, _ := unstructured.NestedBool(vm.Object, "spec", "running")
+			switch {
+			case strategy != "" && strategy != runStrategyHalted:
+				w.RunStrategy = strategy
+			case strategy == "" && hasRunning && running:
+				w.Running = ptr.To(true)
+			default:
+				continue
+			}
+			addWorkload(w)
+		}
+	}
+	return changed, skipped, nil
+}
+
+// listOptional lists the objects of a kind that may not be installed, in a
+// namespace. Without the kind, there are none.
+func (r *Reconciler) listOptional(ctx context.Context, gvk schema.GroupVersionKind, namespace string) ([]unstructured.Unstructured, error) {
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+	if err := r.List(ctx, list, client.InNamespace(namespace)); apimeta.IsNoMatchError(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	return list.Items, nil
+}
+
+func workloadKey(w cozyv1alpha1.HibernatedWorkload) string {
+	return w.Kind + "." + w.APIGroup + "/" + w.Namespace + "/" + w.Name
+}
+
+// resume restarts the next wave of what the hibernation stopped, and
+// finishes once everything runs again.
+func (r *Reconciler) resume(ctx context.Context, h *cozyv1alpha1.TenantHibernation) (ctrl.Result, error) {
+	status := &h.Status
+	status.Skipped = nil
+	if len(status.Releases) == 0 && len(status.Workloads) == 0 {
+		if status.Phase == cozyv1alpha1.TenantAwake {
+			return ctrl.Result{}, nil
+		}
+		if status.Phase != "" {
+			now := metav1.NewTime(r.now())
+			status.ResumedAt = &now
+		}
+		status.Phase = cozyv1alpha1.TenantAwake
+		status.Message = ""
+		return ctrl.Result{}, r.Status().Update(ctx, h)
+	}
+
+	ready := map[string]bool{}
+	for _, rec := range status.Releases {
+		if !rec.Resumed {
+			continue
+		}
+		ok, err := r.releaseReady(ctx, rec)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		ready[rec.Namespace+"/"+rec.Name] = ok
+	}
+	deps, err := r.dependencies(ctx, status.Releases)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	wave := resumeWave(status.Releases, ready, deps)
+
+	// Workloads of no recorded release resume with the first wave.
+	recorded := map[string]bool{}
+	for _, rec := range status.Releases {
+		recorded[rec.Namespace+"/"+rec.Name] = true
+	}
+	inWave := map[string]bool{}
+	for _, i := range wave {
+		rec := &status.Releases[i]
+		inWave[rec.Namespace+"/"+rec.Name] = true
+	}
+	// Restored workloads are dropped from the status only after the releases
+	// of the wave resumed, so an interrupted wave restores them again.
+	// Cluster API Clusters are unpaused after the VirtualMachines of their
+	// nodes were started again.
+	var restored []cozyv1alpha1.HibernatedWorkload
+	kept := status.Workloads[:0:0]
+	for _, pass := range []bool{false, true} {
+		for _, w := range status.Workloads {
+			if isCAPICluster(w) != pass {
+				continue
+			}
+			if recorded[w.Release] && !inWave[w.Release] {
+				kept = append(kept, w)
+				continue
+			}
+			if err := r.restore(ctx, w); err != nil {
+				return ctrl.Result{}, err
+			}
+			restored = append(restored, w)
+		}
+	}
+	for _, i := range wave {
+		rec := &status.Releases[i]
+		if !rec.WasSuspended {
+			if err := r.setSuspended(ctx, rec.Namespace, rec.Name, false); err != nil {
+				return ctrl.Result{}, err
+			}
+		}
+		rec.Resumed = true
+	}
+	status.Workloads = kept
+
+	var waiting []string
+	for _, rec := range status.Releases {
+		key := rec.Namespace + "/" + rec.Name
+		if !rec.Resumed || (!ready[key] && !rec.WasSuspended) {
+			waiting = append(waiting, key)
+		}
+	}
+	if len(waiting) > 0 {
+		status.Phase = cozyv1alpha1.TenantResuming
+		status.Message = fmt.Sprintf("waiting for %d releases: %s", len(waiting), strings.Join(waiting, ", "))
+		if err := r.Status().Update(ctx, h); err != nil {
+			return ctrl.Result{}, err
+		}
+		if len(wave) > 0 || len(restored) > 0 {
+			r.event(h, corev1.EventTypeNormal, string(status.Phase), "resumed %d releases and %d workloads", len(wave), len(restored))
+		}
+		return ctrl.Result{RequeueAfter: pollInterval}, nil
+	}
+
+	now := metav1.NewTime(r.now())
+	status.Phase = cozyv1alpha1.TenantAwake
+	status.ResumedAt = &now
+	status.Message = fmt.Sprintf("resumed %d releases", len(status.Releases))
+	status.Releases = nil
+	if err := r.Status().Update(ctx, h); err != nil {
+		return ctrl.Result{}, err
+	}
+	r.event(h, corev1.EventTypeNormal, string(status.Phase), "%s", status.Message)
+	return ctrl.Result{}, nil
+}
+
+// resumeWave returns the indexes of the releases to resume now: those not
+// resumed yet whose blockers are all resumed and Ready. A release is blocked
+// by the releases of shallower tenant namespaces, which deploy its namespace,
+// and by those it depends on. Releases that were suspended before the
+// hibernation block nobody. If only releases blocking each other are left,
+// they all resume.
+func resumeWave(releases []cozyv1alpha1.HibernatedRelease, ready map[string]bool, deps map[string][]string) []int {
+	settled := func(rec cozyv1alpha1.HibernatedRelease) bool {
+		return rec.WasSuspended || (rec.Resumed && ready[rec.Namespace+"/"+rec.Name])
+	}
+	byKey := make(map[string]cozyv1alpha1.HibernatedRelease, len(releases))
+	for _, rec := range releases {
+		byKey[rec.Namespace+"/"+rec.Name] = rec
+	}
+
+	var wave, pending []int
+	inFlight := false
+	for i, rec := range releases {
+		if rec.Resumed {
+			inFlight = inFlight || !settled(rec)
+			continue
+		}
+		pending = append(pending, i)
+		blocked := false
+		for _, other := range releases {
+			if depth(other.Namespace) < depth(rec.Namespace) && !settled(other) {
+				blocked = true
+				break
+			}
+		}
+		for _, dep := range deps[rec.Namespace+"/"+rec.Name] {
+			if other, ok := byKey[dep]; ok && !settled(other) {
+				blocked = true
+				break
+			}
+		}
+		if !blocked {
+			wave = append(wave, i)
+		}
+	}
+	if len(wave) == 0 && !inFlight {
+		return pending
+	}
+	return wave
+}
+
+// depth is how deep a tenant namespace is in the tenant tree.
+func depth(ns string) int {
+	if ns == rootTenantNamespace {
+		return 0
+	}
+	return strings.Count(ns, "-")
+}
+
+// dependencies returns the dependsOn of the releases not resumed yet, as
+// namespace/name keys.
+func (r *Reconciler) dependencies(ctx context.Context, releases []cozyv1alpha1.HibernatedRelease) (map[string][]string, error) {
+	deps := map[string][]string{}
+	for _, rec := range releases {
+		if rec.Resumed {
+			continue
+		}
+		hr := &helmv2.HelmRelease{}
+		if err := r.Get(ctx, client.ObjectKey{Namespace: rec.Namespace, Name: rec.Name}, hr); apierrors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		key := rec.Namespace + "/" + rec.Name
+		for _, dep := range hr.Spec.DependsOn {
+			ns := dep.Namespace
+			if ns == "" {
+				ns = rec.Namespace
+			}
+			deps[key] = append(deps[key], ns+"/"+dep.Name)
+		}
+	}
+	return deps, nil
+}
+
+// releaseReady reports whether a resumed release is Ready for its current
+// generation. A release that is gone is as ready as it will get.
+func (r *Reconciler) releaseReady(ctx context.Context, rec cozyv1alpha1.HibernatedRelease) (bool, error) {
+	hr := &helmv2.HelmRelease{}
+	if err := r.Get(ctx, client.ObjectKey{Namespace: rec.Namespace, Name: rec.Name}, hr); apierrors.IsNotFound(err) {
+		return true, nil
+	} else if err != nil {
+		return false, err
+	}
+	cond := apimeta.FindStatusCondition(hr.Status.Conditions, "Ready")
+	return cond != nil && cond.Status == metav1.ConditionTrue && cond.ObservedGeneration >= hr.Generation, nil
+}
+
+// setSuspended sets spec.suspend of a HelmRelease, if it still exists.
+func (r *Reconciler) setSuspended(ctx context.Context, namespace, name string, suspend bool) error {
+	hr := &helmv2.HelmRelease{}
+	if err := r.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, hr); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	if hr.Spec.Suspend == suspend {
+		return nil
+	}
+	patch := client.MergeFrom(hr.DeepCopy())
+	hr.Spec.Suspend = suspend
+	return client.IgnoreNotFound(r.Patch(ctx, hr, patch))
+}
+
+// stop scales a workload to zero, halts a VirtualMachine, hibernates a
+// CloudNativePG Cluster or pauses a Cluster API Cluster.
+func (r *Reconciler) stop(ctx context.Context, w cozyv1alpha1.HibernatedWorkload) error {
+	return r.patchWorkload(ctx, w, func(obj client.Object) error {
+		switch o := obj.(type) {
+		case *appsv1.Deployment:
+			o.Spec.Replicas = ptr.To[int32](0)
+		case *appsv1.StatefulSet:
+			o.Spec.Replicas = ptr.To[int32](0)
+		case *unstructured.Unstructured:
+			switch workloadGVK(w) {
+			case virtualMachineGVK:
+				unstructured.RemoveNestedField(o.Object, "spec", "running")
+				return unstructured.SetNestedField(o.Object, runStrategyHalted, "spec", "runStrategy")
+			case kamajiControlPlaneGVK:
+				return unstructured.SetNestedField(o.Object, int64(0), "spec", "replicas")
+			case cnpgClusterGVK:
+				setAnnotation(o, cnpgHibernationAnnotation, cnpgHibernationOn)
+			case capiClusterGVK:
+				return unstructured.SetNestedField(o.Object, true, "spec", "paused")
+			}
+		}
+		return nil
+	})
+}
+
+// restore gives a workload back what it ran before the hibernation.
+func (r *Reconciler) restore(ctx context.Context, w cozyv1alpha1.HibernatedWorkload) error {
+	return r.patchWorkload(ctx, w, func(obj client.Object) error {
+		switch o := obj.(type) {
+		case *appsv1.Deployment:
+			o.Spec.Replicas = w.Replicas
+		case *appsv1.StatefulSet:
+			o.Spec.Replicas = w.Replicas
+		case *unstructured.Unstructured:
+			switch workloadGVK(w) {
+			case virtualMachineGVK:
+				if w.Running != nil {
+					unstructured.RemoveNestedField(o.Object, "spec", "runStrategy")
+					return unstructured.SetNestedField(o.Object, *w.Running, "spec", "running")
+				}
+				return unstructured.SetNestedField(o.Object, w.RunStrategy, "spec", "runStrategy")
+			case kamajiControlPlaneGVK:
+				if w.Replicas == nil {
+					unstructured.RemoveNestedField(o.Object, "spec", "replicas")
+					return nil
+				}
+				return unstructured.SetNestedField(o.Object, int64(*w.Replicas), "spec", "replicas")
+			case cnpgClusterGVK:
+				setAnnotation(o, cnpgHibernationAnnotation, w.Hibernation)
+			case capiClusterGVK:
+				// Only Clusters that were not paused are recorded.
+				unstructured.RemoveNestedField(o.Object, "spec", "paused")
+			}
+		}
+		return nil
+	})
+}
+
+// setAnnotation sets an annotation, or removes it when value is empty.
+func setAnnotation(obj client.Object, key, value string) {
+	annotations := obj.GetAnnotations()
+	if value == "" {
+		delete(annotations, key)
+	} else {
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+		annotations[key] = value
+	}
+	obj.SetAnnotations(annotations)
+}
+
+// patchWorkload applies mutate to a workload, if it still exists.
+func (r *Reconciler) patchWorkload(ctx context.Context, w cozyv1alpha1.HibernatedWorkload, mutate func(client.Object) error) error {
+	var obj client.Object
+	switch w.Kind {
+	case kindDeployment:
+		obj = &appsv1.Deployment{}
+	case kindStatefulSet:
+		obj = &appsv1.StatefulSet{}
+	default:
+		gvk := workloadGVK(w)
+		if gvk.Empty() {
+			return nil
+		}
+		obj = newUnstructured(gvk)
+	}
+	if err := r.Get(ctx, client.ObjectKey{Namespace: w.Namespace, Name: w.Name}, obj); err != nil {
+		if apierrors.IsNotFound(err) || apimeta.IsNoMatchError(err) {
+			return nil
+		}
+		return err
+	}
+	patch := client.MergeFrom(obj.DeepCopyObject().(client.Object))
+	if err := mutate(obj); err != nil {
+		return err
+	}
+	return client.IgnoreNotFound(r.Patch(ctx, obj, patch))
+}
+
+// workloadGVK returns the kind of an operator workload or VirtualMachine,
+// empty for other workloads. VirtualMachines are recorded without a group.
+func workloadGVK(w cozyv1alpha1.HibernatedWorkload) schema.GroupVersionKind {
+	switch {
+	case w.Kind == kindVirtualMachine:
+		return virtualMachineGVK
+	case w.Kind == kindKamajiControlPlane:
+		return kamajiControlPlaneGVK
+	case isCAPICluster(w):
+		return capiClusterGVK
+	case w.Kind == kindCNPGCluster:
+		return cnpgClusterGVK
+	}
+	return schema.GroupVersionKind{}
+}
+
+func isCAPICluster(w cozyv1alpha1.HibernatedWorkload) bool {
+	return w.Kind == kindCAPICluster && w.APIGroup == capiClusterGVK.Group
+}
+
+func newUnstructured(gvk schema.GroupVersionKind) *unstructured.Unstructured {
+	u := &unstructured.Unstructured{}
+	u.SetGroupVersionKind(gvk)
+	return u
+}
+
+// tenantNamespaces returns the tenant namespace and the namespaces of its
+// sub-tenants, sorted.
+func (r *Reconciler) tenantNamespaces(ctx context.Context, tenant string) ([]string, error) {
+	list := &corev1.NamespaceList{}
+	if err := r.List(ctx, list); err != nil {
+		return nil, err
+	}
+	prefix := tenant + "-"
+	if tenant == rootTenantNamespace {
+		prefix = tenantNamespacePrefix
+	}
+	var out []string
+	for _, ns := range list.Items {
+		if ns.Name == tenant || strings.HasPrefix(ns.Name, prefix) {
+			out = append(out, ns.Name)
+		}
+	}
+	sort.Strings(out)
+	return out, nil
+}
+
+// setMessage records why the tenant cannot be driven to its state.
+func (r *Reconciler) setMessage(ctx context.Context, h *cozyv1alpha1.TenantHibernation, format string, args ...any) error {
+	msg := fmt.Sprintf(format, args...)
+	if h.Status.Message == msg {
+		return nil
+	}
+	log.FromContext(ctx).Info("cannot drive tenant hibernation", "reason", msg)
+	h.Status.Message = msg
+	return r.Status().Update(ctx, h)
+}
+
+func (r *Reconciler) now() time.Time {
+	if r.Now != nil {
+		return r.Now()
+	}
+	return time.Now()
+}
+
+func (r *Reconciler) event(h *cozyv1alpha1.TenantHibernation, eventType, reason, format string, args ...any) {
+	if r.Recorder == nil {
+		return
+	}
+	r.Recorder.Eventf(h, eventType, reason, format, args...)
+}
+
+// SetupWithManager registers the controller. The objects a hibernation
+// touches are not watched: schedules and resume waves requeue on their own.
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("tenanthibernation").
+		For(&cozyv1alpha1.TenantHibernation{}).
+		Complete(r)
+}
@@ -0,0 +1,419 @@
+/*
+Copyright 2026 The Cozystack Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tenanthibernation
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	helmv2 "github.com/fluxcd/helm-controller/api/v2"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	cozyv1alpha1 "github.com/cozystack/cozystack/api/v1alpha1"
+)
+
+func namespace(name string) *corev1.Namespace {
+	return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}}
+}
+
+func release(ns, name string, dependsOn ...string) *helmv2.HelmRelease {
+	hr := &helmv2.HelmRelease{ObjectMeta: metav1.ObjectMeta{Namespace: ns, Name: name, Generation: 1}}
+	for _, dep := range dependsOn {
+		hr.Spec.DependsOn = append(hr.Spec.DependsOn, helmv2.DependencyReference{Name: dep})
+	}
+	return hr
+}
+
+func helmOwned(ns, release string) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Namespace: ns,
+		Name:      release,
+		Annotations: map[string]string{
+			helmReleaseNameAnnotation:      release,
+			helmReleaseNamespaceAnnotation: ns,
+		},
+	}
+}
+
+func deployment(ns, release string, replicas int32) *appsv1.Deployment {
+	return &appsv1.Deployment{ObjectMeta: helmOwned(ns, release), Spec: appsv1.DeploymentSpec{Replicas: &replicas}}
+}
+
+func virtualMachine(ns, release, runStrategy string) *unstructured.Unstructured {
+	vm := &unstructured.Unstructured{Object: map[string]any{"spec": map[string]any{"runStrategy": runStrategy}}}
+	vm.SetGroupVersionKind(virtualMachineGVK)
+	meta := helmOwned(ns, release)
+	vm.SetNamespace(meta.Namespace)
+	vm.SetName(meta.Name)
+	vm.SetAnnotations(meta.Annotations)
+	return vm
+}
+
+func hibernation(ns string, hibernated bool) *cozyv1alpha1.TenantHibernation {
+	return &cozyv1alpha1.TenantHibernation{
+		ObjectMeta: metav1.ObjectMeta{Namespace: ns, Name: "tenant", Generation: 1},
+		Spec:       cozyv1alpha1.TenantHibernationSpec{Hibernated: hibernated},
+	}
+}
+
+func newReconciler(t *testing.T, objs ...client.Object) *Reconciler {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = appsv1.AddToScheme(scheme)
+	_ = helmv2.AddToScheme(scheme)
+	_ = cozyv1alpha1.AddToScheme(scheme)
+	for _, gvk := range []schema.GroupVersionKind{virtualMachineGVK, cnpgClusterGVK, capiClusterGVK, kamajiControlPlaneGVK} {
+		scheme.AddKnownTypeWithName(gvk, &unstructured.Unstructured{})
+		scheme.AddKnownTypeWithName(gvk.GroupVersion().WithKind(gvk.Kind+"List"), &unstructured.UnstructuredList{})
+	}
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(objs...).
+		WithStatusSubresource(&cozyv1alpha1.TenantHibernation{}, &helmv2.HelmRelease{}).
+		Build()
+	return &Reconciler{Client: c, Scheme: scheme}
+}
+
+// step reconciles the hibernation of ns once and returns it as stored
+// afterwards.
+func step(t *testing.T, r *Reconciler, ns string) *cozyv1alpha1.TenantHibernation {
+	t.Helper()
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: ns, Name: "tenant"}}
+	if _, err := r.Reconcile(context.Background(), req); err != nil {
+		t.Fatalf("Reconcile: %v", err)
+	}
+	h := &cozyv1alpha1.TenantHibernation{}
+	if err := r.Get(context.Background(), req.NamespacedName, h); err != nil {
+		t.Fatal(err)
+	}
+	return h
+}
+
+// setHibernated changes the spec as an update through the apiserver would.
+func setHibernated(t *testing.T, r *Reconciler, h *cozyv1alpha1.TenantHibernation, hibernated bool) {
+	t.Helper()
+	h.Spec.Hibernated = hibernated
+	h.Generation++
+	if err := r.Update(context.Background(), h); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func markReady(t *testing.T, r *Reconciler, ns, name string) {
+	t.Helper()
+	hr := &helmv2.HelmRelease{}
+	if err := r.Get(context.Background(), client.ObjectKey{Namespace: ns, Name: name}, hr); err != nil {
+		t.Fatal(err)
+	}
+	apimeta.SetStatusCondition(&hr.Status.Conditions, metav1.Condition{
+		Type: "Ready", Status: metav1.ConditionTrue, Reason: "Succeeded", ObservedGeneration: hr.Generation,
+	})
+	if err := r.Status().Update(context.Background(), hr); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func suspended(t *testing.T, r *Reconciler, ns, name string) bool {
+	t.Helper()
+	hr := &helmv2.HelmRelease{}
+	if err := r.Get(context.Background(), client.ObjectKey{Namespace: ns, Name: name}, hr); err != nil {
+		t.Fatal(err)
+	}
+	return hr.Spec.Suspend
+}
+
+func replicas(t *testing.T, r *Reconciler, ns, name string) int32 {
+	t.Helper()
+	d := &appsv1.Deployment{}
+	if err := r.Get(context.Background(), client.ObjectKey{Namespace: ns, Name: name}, d); err != nil {
+		t.Fatal(err)
+	}
+	return ptr.Deref(d.Spec.Replicas, 1)
+}
+
+func runStrategy(t *testing.T, r *Reconciler, ns, name string) string {
+	t.Helper()
+	vm := &unstructured.Unstructured{}
+	vm.SetGroupVersionKind(virtualMachineGVK)
+	if err := r.Get(context.Background(), client.ObjectKey{Namespace: ns, Name: name}, vm); err != nil {
+		t.Fatal(err)
+	}
+	s, _, _ := unstructured.NestedString(vm.Object, "spec", "runStrategy")
+	return s
+}
+
+func TestReconcile_HibernateAndResume(t *testing.T) {
+	controlled := deployment("tenant-foo", "operated", 2)
+	controlled.OwnerReferences = []metav1.OwnerReference{{APIVersion: "v1", Kind: "Operated", Name: "x", UID: "u", Controller: ptr.To(true)}}
+	paused := release("tenant-foo", "paused")
+	paused.Spec.Suspend = true
+	r := newReconciler(t,
+		namespace("tenant-foo"), namespace("tenant-foo-bar"), namespace("tenant-foobar"),
+		hibernation("tenant-foo", true),
+		release("tenant-foo", "postgres-db"), release("tenant-foo", "api", "postgres-db"), paused,
+		release("tenant-foo-bar", "vm"), release("tenant-foobar", "other"),
+		deployment("tenant-foo", "api", 3), deployment("tenant-foobar", "other", 1), controlled,
+		virtualMachine("tenant-foo-bar", "vm", "Always"),
+	)
+
+	// The Deployment another controller scales keeps running, so the tenant
+	// is not reported as hibernated.
+	h := step(t, r, "tenant-foo")
+	if h.Status.Phase != cozyv1alpha1.TenantHibernating || !h.Status.Hibernated || h.Status.HibernatedAt != nil {
+		t.Fatalf("after hibernating: %+v", h.Status)
+	}
+	want := []cozyv1alpha1.SkippedWorkload{{Kind: kindDeployment, Namespace: "tenant-foo", Name: "operated", Controller: "Operated/x"}}
+	if !equality.Semantic.DeepEqual(h.Status.Skipped, want) {
+		t.Errorf("skipped = %+v, want %+v", h.Status.Skipped, want)
+	}
+	if again := step(t, r, "tenant-foo"); again.ResourceVersion != h.ResourceVersion {
+		t.Errorf("sweeping an unchanged tenant updated its status: %+v", again.Status)
+	}
+	for _, hr := range [][2]string{{"tenant-foo", "postgres-db"}, {"tenant-foo", "api"}, {"tenant-foo-bar", "vm"}} {
+		if !suspended(t, r, hr[0], hr[1]) {
+			t.Errorf("%s/%s not suspended", hr[0], hr[1])
+		}
+	}
+	if replicas(t, r, "tenant-foo", "api") != 0 || runStrategy(t, r, "tenant-foo-bar", "vm") != runStrategyHalted {
+		t.Errorf("workloads not stopped: %+v", h.Status.Workloads)
+	}
+	// Neither another tenant nor a workload another controller scales is
+	// touched.
+	if suspended(t, r, "tenant-foobar", "other") || replicas(t, r, "tenant-foobar", "other") != 1 {
+		t.Error("tenant-foobar was hibernated")
+	}
+	if replicas(t, r, "tenant-foo", "operated") != 2 {
+		t.Error("a controlled Deployment was scaled")
+	}
+
+	setHibernated(t, r, h, false)
+	// The first wave resumes the releases of tenant-foo without unmet
+	// dependencies, and their workloads.
+	h = step(t, r, "tenant-foo")
+	if h.Status.Phase != cozyv1alpha1.TenantResuming {
+		t.Fatalf("after first wave: %+v", h.Status)
+	}
+	if suspended(t, r, "tenant-foo", "postgres-db") || !suspended(t, r, "tenant-foo", "api") || !suspended(t, r, "tenant-foo-bar", "vm") {
+		t.Fatal("first wave resumed the wrong releases")
+	}
+	if !suspended(t, r, "tenant-foo", "paused") {
+		t.Error("a release suspended before the hibernation was resumed")
+	}
+
+	markReady(t, r, "tenant-foo", "postgres-db")
+	step(t, r, "tenant-foo")
+	if suspended(t, r, "tenant-foo", "api") || replicas(t, r, "tenant-foo", "api") != 3 {
+		t.Fatal("api did not resume after its dependency became Ready")
+	}
+	if !suspended(t, r, "tenant-foo-bar", "vm") {
+		t.Fatal("the sub-tenant resumed before its parent's releases were Ready")
+	}
+
+	markReady(t, r, "tenant-foo", "api")
+	step(t, r, "tenant-foo")
+	if suspended(t, r, "tenant-foo-bar", "vm") || runStrategy(t, r, "tenant-foo-bar", "vm") != "Always" {
+		t.Fatal("the sub-tenant did not resume")
+	}
+
+	markReady(t, r, "tenant-foo-bar", "vm")
+	h = step(t, r, "tenant-foo")
+	if h.Status.Phase != cozyv1alpha1.TenantAwake || h.Status.ResumedAt == nil || len(h.Status.Releases) != 0 ||
+		len(h.Status.Workloads) != 0 || len(h.Status.Skipped) != 0 {
+		t.Fatalf("after resuming: %+v", h.Status)
+	}
+}
+
+func operated(gvk schema.GroupVersionKind, ns, release string, spec map[string]any) *unstructured.Unstructured {
+	u := &unstructured.Unstructured{Object: map[string]any{"spec": spec}}
+	u.SetGroupVersionKind(gvk)
+	meta := helmOwned(ns, release)
+	u.SetNamespace(meta.Namespace)
+	u.SetName(meta.Name)
+	u.SetAnnotations(meta.Annotations)
+	return u
+}
+
+func get(t *testing.T, r *Reconciler, gvk schema.GroupVersionKind, ns, name string) *unstructured.Unstructured {
+	t.Helper()
+	u := newUnstructured(gvk)
+	if err := r.Get(context.Background(), client.ObjectKey{Namespace: ns, Name: name}, u); err != nil {
+		t.Fatal(err)
+	}
+	return u
+}
+
+func TestReconcile_HibernatesOperators(t *testing.T) {
+	controlPlane := deployment("tenant-foo", "k8s", 2)
+	controlPlane.Name = "k8s-control-plane"
+	controlPlane.OwnerReferences = []metav1.OwnerReference{{
+		APIVersion: "kamaji.clastix.io/v1alpha1", Kind: "TenantControlPlane", Name: "k8s", UID: "u", Controller: ptr.To(true),
+	}}
+	r := newReconciler(t, namespace("tenant-foo"), hibernation("tenant-foo", true),
+		release("tenant-foo", "postgres-db"), release("tenant-foo", "k8s"),
+		operated(cnpgClusterGVK, "tenant-foo", "postgres-db", map[string]any{"instances": int64(2)}),
+		operated(kamajiControlPlaneGVK, "tenant-foo", "k8s", map[string]any{"replicas": int64(2)}),
+		controlPlane,
+	)
+
+	h := step(t, r, "tenant-foo")
+	if h.Status.Phase != cozyv1alpha1.TenantHibernated || len(h.Status.Skipped) != 0 || len(h.Status.Workloads) != 2 {
+		t.Fatalf("after hibernating: %+v", h.Status)
+	}
+	if got := get(t, r, cnpgClusterGVK, "tenant-foo", "postgres-db").GetAnnotations()[cnpgHibernationAnnotation]; got != cnpgHibernationOn {
+		t.Errorf("cluster hibernation annotation = %q", got)
+	}
+	if got, _, _ := unstructured.NestedInt64(get(t, r, kamajiControlPlaneGVK, "tenant-foo", "k8s").Object, "spec", "replicas"); got != 0 {
+		t.Errorf("control plane replicas = %d", got)
+	}
+	if replicas(t, r, "tenant-foo", "k8s-control-plane") != 2 {
+		t.Error("the Deployment of the TenantControlPlane was scaled directly")
+	}
+
+	setHibernated(t, r, h, false)
+	step(t, r, "tenant-foo")
+	if _, ok := get(t, r, cnpgClusterGVK, "tenant-foo", "postgres-db").GetAnnotations()[cnpgHibernationAnnotation]; ok {
+		t.Error("the cluster was not woken up")
+	}
+	if got, _, _ := unstructured.NestedInt64(get(t, r, kamajiControlPlaneGVK, "tenant-foo", "k8s").Object, "spec", "replicas"); got != 2 {
+		t.Errorf("control plane replicas = %d, want 2 again", got)
+	}
+}
+
+// The Cluster API Cluster of a tenant Kubernetes cluster is paused before
+// its node VMs halt, and unpaused after they run again.
+func TestReconcile_PausesCAPICluster(t *testing.T) {
+	r := newReconciler(t, namespace("tenant-foo"), hibernation("tenant-foo", true),
+		release("tenant-foo", "k8s"),
+		operated(capiClusterGVK, "tenant-foo", "k8s", map[string]any{}),
+		operated(virtualMachineGVK, "tenant-foo", "k8s-md0-abcde", map[string]any{"runStrategy": "Always"}),
+		operated(cnpgClusterGVK, "tenant-foo", "k8s", map[string]any{"instances": int64(1)}),
+	)
+	paused := func() bool {
+		p, _, _ := unstructured.NestedBool(get(t, r, capiClusterGVK, "tenant-foo", "k8s").Object, "spec", "paused")
+		return p
+	}
+	// Every VirtualMachine patch checks the Cluster's state at that point.
+	var pausedAtVMPatch []bool
+	r.Client = interceptor.NewClient(r.Client.(client.WithWatch), interceptor.Funcs{
+		Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+			if u, ok := obj.(*unstructured.Unstructured); ok && u.GroupVersionKind() == virtualMachineGVK {
+				pausedAtVMPatch = append(pausedAtVMPatch, paused())
+			}
+			return c.Patch(ctx, obj, patch, opts...)
+		},
+	})
+
+	h := step(t, r, "tenant-foo")
+	if h.Status.Phase != cozyv1alpha1.TenantHibernated {
+		t.Fatalf("after hibernating: %+v", h.Status)
+	}
+	if !paused() || runStrategy(t, r, "tenant-foo", "k8s-md0-abcde") != runStrategyHalted {
+		t.Fatal("expected the Cluster paused and the node VM halted")
+	}
+	if got := get(t, r, cnpgClusterGVK, "tenant-foo", "k8s").GetAnnotations()[cnpgHibernationAnnotation]; got != cnpgHibernationOn {
+		t.Errorf("the CloudNativePG Cluster of the same name was not hibernated: %q", got)
+	}
+
+	setHibernated(t, r, h, false)
+	step(t, r, "tenant-foo")
+	if paused() || runStrategy(t, r, "tenant-foo", "k8s-md0-abcde") != "Always" {
+		t.Error("expected the Cluster unpaused and the node VM running")
+	}
+	if !reflect.DeepEqual(pausedAtVMPatch, []bool{true, true}) {
+		t.Errorf("Cluster paused at the VM patches = %v, want paused at both", pausedAtVMPatch)
+	}
+}
+
+func TestReconcile_RecordsBeforeStopping(t *testing.T) {
+	r := newReconciler(t, namespace("tenant-foo"), hibernation("tenant-foo", true),
+		release("tenant-foo", "api"), deployment("tenant-foo", "api", 3))
+	step(t, r, "tenant-foo")
+
+	// Hibernating again, e.g. after an interrupted resume, keeps what the
+	// workload ran before the first hibernation.
+	h := step(t, r, "tenant-foo")
+	if len(h.Status.Workloads) != 1 || *h.Status.Workloads[0].Replicas != 3 {
+		t.Fatalf("workloads: %+v", h.Status.Workloads)
+	}
+	if h.Status.Workloads[0].Release != "tenant-foo/api" {
+		t.Errorf("release of the workload: %q", h.Status.Workloads[0].Release)
+	}
+}
+
+func TestReconcile_Schedule(t *testing.T) {
+	now := time.Date(2026, 3, 2, 21, 0, 0, 0, time.UTC) // a Monday
+	h := hibernation("tenant-foo", false)
+	h.Spec.Schedule = &cozyv1alpha1.TenantHibernationSchedule{Hibernate: "0 20 * * 1-5", Resume: "0 7 * * 1-5"}
+	h.Status.ObservedGeneration = 1
+	h.Status.LastScheduleTime = &metav1.Time{Time: now.Add(-2 * time.Hour)}
+	r := newReconciler(t, namespace("tenant-foo"), h, release("tenant-foo", "api"))
+	r.Now = func() time.Time { return now }
+
+	h = step(t, r, "tenant-foo")
+	if !h.Status.Hibernated || h.Status.Phase != cozyv1alpha1.TenantHibernated {
+		t.Fatalf("the schedule did not hibernate the tenant: %+v", h.Status)
+	}
+	if want := time.Date(2026, 3, 3, 7, 0, 0, 0, time.UTC); !h.Status.NextScheduleTime.Time.Equal(want) {
+		t.Errorf("next schedule time = %v, want %v", h.Status.NextScheduleTime, want)
+	}
+
+	// Resuming by hand lasts until the schedule next fires.
+	setHibernated(t, r, h, false)
+	if h = step(t, r, "tenant-foo"); h.Status.Hibernated {
+		t.Fatal("a spec change did not override the schedule")
+	}
+	now = time.Date(2026, 3, 3, 20, 0, 0, 0, time.UTC)
+	if h = step(t, r, "tenant-foo"); !h.Status.Hibernated {
+		t.Fatal("the schedule did not hibernate the tenant again")
+	}
+}
+
+func TestReconcile_InvalidSchedule(t *testing.T) {
+	h := hibernation("tenant-foo", false)
+	h.Spec.Schedule = &cozyv1alpha1.TenantHibernationSchedule{Hibernate: "at night", Resume: "0 7 * * *"}
+	r := newReconciler(t, namespace("tenant-foo"), h)
+	if h = step(t, r, "tenant-foo"); h.Status.Message == "" || h.Status.Phase != "" {
+		t.Fatalf("status: %+v", h.Status)
+	}
+}
+
+func TestResumeWave_BreaksCycles(t *testing.T) {
+	releases := []cozyv1alpha1.HibernatedRelease{
+		{Namespace: "tenant-foo", Name: "a"},
+		{Namespace: "tenant-foo", Name: "b"},
+	}
+	deps := map[string][]string{"tenant-foo/a": {"tenant-foo/b"}, "tenant-foo/b": {"tenant-foo/a"}}
+	if wave := resumeWave(releases, nil, deps); len(wave) != 2 {
+		t.Fatalf("wave = %v, want both releases", wave)
+	}
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2026 The Cozystack Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tenanthibernation
+
+import (
+	"fmt"
+	"time"
+
+	cron "github.com/robfig/cron/v3"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	cozyv1alpha1 "github.com/cozystack/cozystack/api/v1alpha1"
+)
+
+// maxCatchUp bounds how far back a schedule is replayed after the controller
+// was down: only the last firing counts, so older ones need not be visited.
+const maxCatchUp = 31 * 24 * time.Hour
+
+// follow sets status.hibernated to the state the tenant is driven to at now.
+// A spec change takes effect at once; otherwise the schedule, if any, sets it
+// each time it fires. follow returns when the schedule fires next, zero when
+// there is no schedule.
+func follow(h *cozyv1alpha1.TenantHibernation, now time.Time) (time.Time, error) {
+	status := &h.Status
+	specChanged := h.Generation != status.ObservedGeneration
+	if specChanged {
+		status.Hibernated = h.Spec.Hibernated
+		status.ObservedGeneration = h.Generation
+	}
+
+	s := h.Spec.Schedule
+	if s == nil {
+		status.LastScheduleTime = nil
+		status.NextScheduleTime = nil
+		return time.Time{}, nil
+	}
+	hibernate, resume, err := parseSchedule(s)
+	if err != nil {
+		status.NextScheduleTime = nil
+		return time.Time{}, err
+	}
+
+	// Firings before the spec last changed must not override it.
+	if specChanged || status.LastScheduleTime == nil {
+		status.LastScheduleTime = &metav1.Time{Time: now}
+	}
+	from := status.LastScheduleTime.Time
+	if earliest := now.Add(-maxCatchUp); from.Before(earliest) {
+		from = earliest
+	}
+	for {
+		at, hibernated := nextFiring(hibernate, resume, from)
+		if at.After(now) {
+			break
+		}
+		status.Hibernated = hibernated
+		status.LastScheduleTime = &metav1.Time{Time: at}
+		from = at
+	}
+
+	next, _ := nextFiring(hibernate, resume, now)
+	status.NextScheduleTime = &metav1.Time{Time: next}
+	return next, nil
+}
+
+// nextFiring returns when the schedule fires next after t, and whether it
+// then hibernates the tenant. Resuming wins when both fire at once.
+func nextFiring(hibernate, resume cron.Schedule, t time.Time) (time.Time, bool) {
+	h, r := hibernate.Next(t), resume.Next(t)
+	if h.Before(r) {
+		return h, true
+	}
+	return r, false
+}
+
+// parseSchedule parses the cron expressions of s in its time zone.
+func parseSchedule(s *cozyv1alpha1.TenantHibernationSchedule) (cron.Schedule, cron.Schedule, error) {
+	prefix := ""
+	if s.TimeZone != "" {
+		if _, err := time.LoadLocation(s.TimeZone); err != nil {
+			return nil, nil, fmt.Errorf("unknown time zone %q: %w", s.TimeZone, err)
+		}
+		prefix = "CRON_TZ=" + s.TimeZone + " "
+	}
+	hibernate, err := cron.ParseStandard(prefix + s.Hibernate)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not parse hibernate schedule %q: %w", s.Hibernate, err)
+	}
+	resume, err := cron.ParseStandard(prefix + s.Resume)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not parse resume schedule %q: %w", s.Resume, err)
+	}
+	return hibernate, resume, nil
+}
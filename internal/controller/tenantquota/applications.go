@@ -0,0 +1,96 @@
+/*
+Copyright 2026 The Cozystack Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tenantquota
+
+import (
+	"strings"
+
+	helmv2 "github.com/fluxcd/helm-controller/api/v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	appsv1alpha1 "github.com/cozystack/cozystack/pkg/apis/apps/v1alpha1"
+)
+
+// applicationCountPrefix is the prefix of object count quota resource names.
+const applicationCountPrefix = "count/"
+
+// ApplicationCountResource returns the quota resource name that limits the
+// number of Applications of a kind, in the Kubernetes object count syntax
+// count/<resource>.<group>: plural "postgreses" gives
+// count/postgreses.apps.cozystack.io.
+//
+// Applications are served by the aggregated apiserver, so the kube-apiserver
+// quota admission never sees them: the limit is enforced when the Application
+// is created, and usage is counted by this controller from the HelmReleases
+// Applications are stored as.
+func ApplicationCountResource(plural string) corev1.ResourceName {
+	return corev1.ResourceName(applicationCountPrefix + plural + "." + appsv1alpha1.GroupName)
+}
+
+// applicationCountPlural returns the plural an Application count resource
+// limits, and whether name is one.
+func applicationCountPlural(name corev1.ResourceName) (string, bool) {
+	plural, ok := strings.CutPrefix(string(name), applicationCountPrefix)
+	if !ok {
+		return "", false
+	}
+	return strings.CutSuffix(plural, "."+appsv1alpha1.GroupName)
+}
+
+// countApplications counts the Applications stored in releases, per namespace,
+// for the kinds limited by one of the budgets. pluralByKind maps Application
+// kinds to their plurals; releases of unknown kinds are not counted.
+func countApplications(releases []helmv2.HelmRelease, pluralByKind map[string]string, budgets map[string]corev1.ResourceList) map[string]corev1.ResourceList {
+	limited := map[string]bool{}
+	for _, budget := range budgets {
+		for name := range budget {
+			if plural, ok := applicationCountPlural(name); ok {
+				limited[plural] = true
+			}
+		}
+	}
+	if len(limited) == 0 {
+		return nil
+	}
+
+	counts := map[string]map[corev1.ResourceName]int64{}
+	for i := range releases {
+		hr := &releases[i]
+		if hr.Labels[appsv1alpha1.ApplicationGroupLabel] != appsv1alpha1.GroupName {
+			continue
+		}
+		plural, ok := pluralByKind[hr.Labels[appsv1alpha1.ApplicationKindLabel]]
+		if !ok || !limited[plural] {
+			continue
+		}
+		if counts[hr.Namespace] == nil {
+			counts[hr.Namespace] = map[corev1.ResourceName]int64{}
+		}
+		counts[hr.Namespace][ApplicationCountResource(plural)]++
+	}
+
+	out := make(map[string]corev1.ResourceList, len(counts))
+	for ns, byName := range counts {
+		rl := corev1.ResourceList{}
+		for name, n := range byName {
+			rl[name] = *resource.NewQuantity(n, resource.DecimalSI)
+		}
+		out[ns] = rl
+	}
+	return out
+}
@@ -0,0 +1,153 @@
+/*
+Copyright 2026 The Cozystack Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tenantquota
+
+import (
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	quota "k8s.io/apiserver/pkg/quota/v1"
+)
+
+// Borrowing is the lending state of a bounded tenant that opted in to
+// borrowing. Opted-in bounded tenants carving out of the same parent pool
+// lend each other the part of their budget they leave idle:
+//
+//	allowance[i] = min(limit[i], sum(idle of the others) - sum(borrowed by the others))
+//
+// where a tenant's idle budget is what its pool does not use of its budget and
+// its borrowed amount is how far its pool's usage exceeds the budget. The
+// allowance is recomputed on every sweep, so a lender that needs its budget
+// back reclaims it: its idle share shrinks, the borrowers' allowances shrink
+// with it, and a borrower above its new limit cannot start anything new until
+// its usage drops. Nothing is evicted, so until then the pool can be over its
+// parent's carve-outs.
+type Borrowing struct {
+	// Limit caps how far the tenant's pool may grow beyond its budget.
+	Limit corev1.ResourceList
+	// Allowance is how far the tenant's pool may grow beyond its budget now.
+	Allowance corev1.ResourceList
+	// Borrowed is how far the tenant's pool uses more than its budget.
+	Borrowed corev1.ResourceList
+	// Lent is the part of the tenant's idle budget its siblings are using.
+	Lent corev1.ResourceList
+}
+
+// ComputeBorrowing derives the lending state of every opted-in tenant (one
+// with a BorrowLimit) from the pools and the current usage per namespace.
+// Tenants whose parent is governed by no pool have nobody to borrow from and
+// are left out.
+func ComputeBorrowing(tenants []Tenant, pools map[string]*Pool, usedByNS map[string]corev1.ResourceList) map[string]*Borrowing {
+	declaredByNS := make(map[string]corev1.ResourceList, len(pools))
+	for root, p := range pools {
+		declaredByNS[root] = p.Budget
+	}
+
+	// Group the opted-in pool roots by the parent pool they carve out of.
+	groups := map[string][]string{}
+	limits := map[string]corev1.ResourceList{}
+	for _, t := range tenants {
+		if t.BorrowLimit == nil || pools[t.Namespace] == nil {
+			continue
+		}
+		parentPool := poolRootOf(ParentNamespace(t.Namespace), declaredByNS)
+		if parentPool == "" {
+			continue
+		}
+		groups[parentPool] = append(groups[parentPool], t.Namespace)
+		limits[t.Namespace] = t.BorrowLimit
+	}
+
+	result := map[string]*Borrowing{}
+	for _, members := range groups {
+		sort.Strings(members)
+		idle := make(map[string]corev1.ResourceList, len(members))
+		borrowed := make(map[string]corev1.ResourceList, len(members))
+		var totalIdle, totalBorrowed corev1.ResourceList
+		for _, ns := range members {
+			p := pools[ns]
+			used := corev1.ResourceList{}
+			for _, m := range p.Members {
+				used = quota.Add(used, usedByNS[m])
+			}
+			budgeted := quota.ResourceNames(p.Budget)
+			idle[ns] = quota.Mask(quota.SubtractWithNonNegativeResult(p.Budget, used), budgeted)
+			borrowed[ns] = quota.Mask(quota.SubtractWithNonNegativeResult(used, p.Budget), budgeted)
+			totalIdle = quota.Add(totalIdle, idle[ns])
+			totalBorrowed = quota.Add(totalBorrowed, borrowed[ns])
+		}
+
+		for _, ns := range members {
+			// What the others leave idle and do not borrow themselves.
+			available := quota.Subtract(
+				quota.Subtract(totalIdle, idle[ns]),
+				quota.Subtract(totalBorrowed, borrowed[ns]),
+			)
+			allowance := corev1.ResourceList{}
+			for name, limit := range limits[ns] {
+				if _, budgeted := pools[ns].Budget[name]; !budgeted {
+					continue
+				}
+				q := available[name]
+				if q.Sign() < 0 {
+					q = resource.Quantity{}
+				}
+				if limit.Cmp(q) < 0 {
+					q = limit.DeepCopy()
+				}
+				allowance[name] = q
+			}
+			result[ns] = &Borrowing{
+				Limit:     limits[ns],
+				Allowance: allowance,
+				Borrowed:  positive(borrowed[ns]),
+				Lent:      corev1.ResourceList{},
+			}
+		}
+
+		// Attribute what is borrowed to the lenders, in name order.
+		owed := totalBorrowed.DeepCopy()
+		for _, ns := range members {
+			for name, q := range idle[ns] {
+				rest := owed[name]
+				if rest.Sign() <= 0 || q.Sign() <= 0 {
+					continue
+				}
+				lent := q.DeepCopy()
+				if rest.Cmp(lent) < 0 {
+					lent = rest.DeepCopy()
+				}
+				rest.Sub(lent)
+				owed[name] = rest
+				result[ns].Lent[name] = lent
+			}
+		}
+	}
+	return result
+}
+
+// positive returns the positive quantities of rl.
+func positive(rl corev1.ResourceList) corev1.ResourceList {
+	out := corev1.ResourceList{}
+	for name, q := range rl {
+		if q.Sign() > 0 {
+			out[name] = q
+		}
+	}
+	return out
+}
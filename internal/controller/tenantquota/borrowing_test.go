@@ -0,0 +1,84 @@
+/*
+Copyright 2026 The Cozystack Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tenantquota
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// siblings is foo (cpu 10) with bounded children a and b (cpu 4 each) that
+// both opted in to borrowing up to 3 cpu, and c (cpu 2) that did not.
+func siblings() []Tenant {
+	return []Tenant{
+		{Namespace: "tenant-foo", Declared: rl(map[string]string{"cpu": "10"})},
+		{Namespace: "tenant-foo-a", Declared: rl(map[string]string{"cpu": "4"}), BorrowLimit: rl(map[string]string{"cpu": "3"})},
+		{Namespace: "tenant-foo-b", Declared: rl(map[string]string{"cpu": "4"}), BorrowLimit: rl(map[string]string{"cpu": "3"})},
+		{Namespace: "tenant-foo-c", Declared: rl(map[string]string{"cpu": "2"})},
+	}
+}
+
+func TestComputeBorrowing_LendsIdleBudget(t *testing.T) {
+	tenants := siblings()
+	used := map[string]corev1.ResourceList{
+		"tenant-foo-a": rl(map[string]string{"cpu": "5"}),
+		"tenant-foo-b": rl(map[string]string{"cpu": "2"}),
+		// c's idle budget is not lent: it did not opt in.
+		"tenant-foo-c": rl(map[string]string{"cpu": "0"}),
+	}
+	borrowing := ComputeBorrowing(tenants, ComputePools(tenants), used)
+
+	if _, ok := borrowing["tenant-foo-c"]; ok {
+		t.Errorf("tenant-foo-c did not opt in but got %+v", borrowing["tenant-foo-c"])
+	}
+	if _, ok := borrowing["tenant-foo"]; ok {
+		t.Errorf("tenant-foo has no parent pool to borrow within")
+	}
+	a, b := borrowing["tenant-foo-a"], borrowing["tenant-foo-b"]
+	// b leaves 2 idle and a already borrows 1 of it.
+	quantityEqual(t, a.Allowance, "cpu", "2")
+	quantityEqual(t, a.Borrowed, "cpu", "1")
+	// a leaves nothing idle and borrows 1 itself.
+	quantityEqual(t, b.Allowance, "cpu", "0")
+	quantityEqual(t, b.Lent, "cpu", "1")
+	if len(a.Lent) != 0 || len(b.Borrowed) != 0 {
+		t.Errorf("a lends %v, b borrows %v; want nothing", a.Lent, b.Borrowed)
+	}
+}
+
+func TestComputeBorrowing_CappedByLimit(t *testing.T) {
+	tenants := siblings()
+	borrowing := ComputeBorrowing(tenants, ComputePools(tenants), nil)
+	// Both leave 4 idle, but neither may borrow more than 3.
+	quantityEqual(t, borrowing["tenant-foo-a"].Allowance, "cpu", "3")
+	quantityEqual(t, borrowing["tenant-foo-b"].Allowance, "cpu", "3")
+}
+
+func TestComputeBorrowing_ReclaimsForTheLender(t *testing.T) {
+	tenants := siblings()
+	// a borrowed 3 while b was idle; b now uses its whole budget. a may not
+	// grow any further, and keeps running above its budget.
+	used := map[string]corev1.ResourceList{
+		"tenant-foo-a": rl(map[string]string{"cpu": "7"}),
+		"tenant-foo-b": rl(map[string]string{"cpu": "4"}),
+	}
+	borrowing := ComputeBorrowing(tenants, ComputePools(tenants), used)
+	quantityEqual(t, borrowing["tenant-foo-a"].Allowance, "cpu", "0")
+	quantityEqual(t, borrowing["tenant-foo-a"].Borrowed, "cpu", "3")
+	quantityEqual(t, borrowing["tenant-foo-b"].Allowance, "cpu", "0")
+}
@@ -52,20 +52,22 @@ const tenantNamespacePrefix = "tenant-"
 
 // Tenant is a snapshot of one tenant in the hierarchy: the namespace it owns
 // and its declared resourceQuotas. A nil/empty Declared marks an unbounded
-// tenant, which draws from the pool of its nearest bounded ancestor.
+// tenant, which draws from the pool of its nearest bounded ancestor. A
+// non-nil BorrowLimit opts a bounded tenant in to borrowing (see Borrowing).
 type Tenant struct {
-	Namespace string
-	Declared  corev1.ResourceList
+	Namespace   string
+	Declared    corev1.ResourceList
+	BorrowLimit corev1.ResourceList
 }
 
-// parentNamespace returns the namespace owned by the parent of the tenant that
+// ParentNamespace returns the namespace owned by the parent of the tenant that
 // owns ns, or "" for the root tenant and non-tenant namespaces.
 //
 // The hierarchy is encoded in the namespace name by the tenant chart: a tenant
 // "x" created in namespace P owns namespace computeTenantNamespace(P, x) — i.e.
 // P + "-" + x, or "tenant-x" directly under root — so the parent namespace is
 // recovered by stripping the trailing "-x" segment.
-func parentNamespace(ns string) string {
+func ParentNamespace(ns string) string {
 	if ns == rootTenantNamespace || !strings.HasPrefix(ns, tenantNamespacePrefix) {
 		return ""
 	}
@@ -80,7 +82,7 @@ func parentNamespace(ns string) string {
 // ns itself) that declares a quota — the "pool root" whose budget governs ns.
 // It returns "" when no ancestor is bounded, meaning ns is governed by no pool.
 func poolRootOf(ns string, declaredByNS map[string]corev1.ResourceList) string {
-	for cur := ns; cur != ""; cur = parentNamespace(cur) {
+	for cur := ns; cur != ""; cur = ParentNamespace(cur) {
 		if len(declaredByNS[cur]) > 0 {
 			return cur
 		}
@@ -135,7 +137,7 @@ func ComputePools(tenants []Tenant) map[string]*Pool {
 	// Carve-outs: a bounded sub-tenant reserves its declared budget out of the
 	// pool of its parent's nearest bounded ancestor.
 	for ns, declared := range declaredByNS {
-		if parentPool := poolRootOf(parentNamespace(ns), declaredByNS); parentPool != "" {
+		if parentPool := poolRootOf(ParentNamespace(ns), declaredByNS); parentPool != "" {
 			pools[parentPool].CarvedOut = quota.Add(pools[parentPool].CarvedOut, declared)
 		}
 	}
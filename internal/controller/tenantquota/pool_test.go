@@ -52,8 +52,8 @@ func TestParentNamespace(t *testing.T) {
 		"not-a-tenant":       "",
 	}
 	for in, want := range cases {
-		if got := parentNamespace(in); got != want {
-			t.Errorf("parentNamespace(%q) = %q, want %q", in, got, want)
+		if got := ParentNamespace(in); got != want {
+			t.Errorf("ParentNamespace(%q) = %q, want %q", in, got, want)
 		}
 	}
 }
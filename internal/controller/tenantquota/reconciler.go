@@ -27,16 +27,19 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	quota "k8s.io/apiserver/pkg/quota/v1"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
+	cozyv1alpha1 "github.com/cozystack/cozystack/api/v1alpha1"
 	appsv1alpha1 "github.com/cozystack/cozystack/pkg/apis/apps/v1alpha1"
 )
 
@@ -52,6 +55,12 @@ const (
 	// whenever it is below the chart quota, without the controller fighting Flux
 	// over the chart-owned object.
 	allocatedQuotaName = "tenant-quota-allocated"
+	// budgetAnnotation and borrowLimitAnnotation are set by the tenant chart
+	// on its quota when the tenant opts in to borrowing. Its hard limits are
+	// then the ceiling budget+limit, so the budget itself and the borrow
+	// limit, as JSON maps in the quota's key space, are carried here.
+	budgetAnnotation      = "quota.cozystack.io/budget"
+	borrowLimitAnnotation = "quota.cozystack.io/borrow-limit"
 	// managedByLabel marks the controller-owned ResourceQuotas so they can be
 	// listed and garbage-collected.
 	managedByLabel = "quota.cozystack.io/managed-by"
@@ -100,6 +109,7 @@ func (r *Reconciler) Reconcile(ctx context.Context, _ ctrl.Request) (ctrl.Result
 	}
 
 	pools := ComputePools(tenants)
+	borrowing := ComputeBorrowing(tenants, pools, usedByNS)
 
 	desired := map[string]corev1.ResourceList{}
 	for _, p := range pools {
@@ -109,12 +119,18 @@ func (r *Reconciler) Reconcile(ctx context.Context, _ ctrl.Request) (ctrl.Result
 		}
 		// A pool with no carve-outs and a single member is fully covered by the
 		// chart-rendered tenant-quota already; the controller adds nothing.
-		if len(p.CarvedOut) == 0 && len(p.Members) <= 1 {
+		// Unless it borrows: its chart quota is then only the ceiling.
+		b := borrowing[p.Root]
+		if len(p.CarvedOut) == 0 && len(p.Members) <= 1 && b == nil {
 			continue
 		}
+		available := p.Available
+		if b != nil {
+			available = quota.Add(available, b.Allowance)
+		}
 		buffered := &Pool{
 			Root:      p.Root,
-			Available: ScaleResourceList(p.Available, r.bufferPercent()),
+			Available: ScaleResourceList(available, r.bufferPercent()),
 			Members:   p.Members,
 		}
 		for _, ns := range p.Members {
@@ -159,17 +175,19 @@ func (r *Reconciler) snapshot(ctx context.Context) (tenants []Tenant, usedByNS m
 	if err = r.List(ctx, quotas); err != nil {
 		return nil, nil, nil, err
 	}
-	declaredByNS := map[string]corev1.ResourceList{}
-	usedByNS = map[string]corev1.ResourceList{}
-	for i := range quotas.Items {
-		rq := &quotas.Items[i]
-		// Multiple ResourceQuotas in a namespace each report the same usage for
-		// a given resource, so usage is merged with a per-resource max (not a
-		// sum) to avoid double counting.
-		usedByNS[rq.Namespace] = maxResourceList(usedByNS[rq.Namespace], rq.Status.Used)
-		if rq.Name == chartQuotaName {
-			declaredByNS[rq.Namespace] = rq.Spec.Hard
-		}
+	q := readQuotas(quotas.Items)
+	declaredByNS := q.declared
+	usedByNS = q.used
+
+	// Applications are served by the aggregated apiserver, out of reach of the
+	// ResourceQuota controller's admission; count the limited kinds here so
+	// pools never depend on it reporting their usage.
+	counts, err := r.countApplications(ctx, declaredByNS)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	for ns, used := range counts {
+		usedByNS[ns] = maxResourceList(usedByNS[ns], used)
 	}
 
 	nsList := &corev1.NamespaceList{}
@@ -185,11 +203,42 @@ func (r *Reconciler) snapshot(ctx context.Context) (tenants []Tenant, usedByNS m
 	for i := range releases.Items {
 		hr := &releases.Items[i]
 		ns := ownedNamespace(hr.Namespace, strings.TrimPrefix(hr.Name, tenantNamespacePrefix))
-		tenants = append(tenants, Tenant{Namespace: ns, Declared: declaredByNS[ns]})
+		tenants = append(tenants, Tenant{Namespace: ns, Declared: declaredByNS[ns], BorrowLimit: q.borrowLimit[ns]})
 	}
 	return tenants, usedByNS, existing, nil
 }
 
+// countApplications counts, per namespace, the Applications of every kind a
+// declared budget limits with an ApplicationCountResource key.
+func (r *Reconciler) countApplications(ctx context.Context, declaredByNS map[string]corev1.ResourceList) (map[string]corev1.ResourceList, error) {
+	limited := false
+	for _, declared := range declaredByNS {
+		for name := range declared {
+			if _, ok := applicationCountPlural(name); ok {
+				limited = true
+			}
+		}
+	}
+	if !limited {
+		return nil, nil
+	}
+
+	definitions := &cozyv1alpha1.ApplicationDefinitionList{}
+	if err := r.List(ctx, definitions); err != nil {
+		return nil, err
+	}
+	pluralByKind := make(map[string]string, len(definitions.Items))
+	for i := range definitions.Items {
+		app := definitions.Items[i].Spec.Application
+		pluralByKind[app.Kind] = app.Plural
+	}
+	releases := &helmv2.HelmReleaseList{}
+	if err := r.List(ctx, releases, client.HasLabels{appsv1alpha1.ApplicationKindLabel}); err != nil {
+		return nil, err
+	}
+	return countApplications(releases.Items, pluralByKind, declaredByNS), nil
+}
+
 func (r *Reconciler) upsertAllocatedQuota(ctx context.Context, namespace string, hard corev1.ResourceList) error {
 	rq := &corev1.ResourceQuota{ObjectMeta: metav1.ObjectMeta{Name: allocatedQuotaName, Namespace: namespace}}
 	_, err := controllerutil.CreateOrUpdate(ctx, r.Client, rq, func() error {
@@ -237,21 +286,33 @@ func (r *Reconciler) recordOvercommit(ctx context.Context, rootNamespace string,
 }
 
 // SetupWithManager wires the controller. Every relevant change (a tenant
-// HelmRelease, a namespace, or a tenant ResourceQuota whose usage moved)
-// coalesces into one full-tree sweep.
+// HelmRelease, an Application created or deleted, a namespace, or a tenant
+// ResourceQuota whose usage moved) coalesces into one full-tree sweep.
 func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
 	toSweep := handler.EnqueueRequestsFromMapFunc(func(context.Context, client.Object) []reconcile.Request {
 		return []reconcile.Request{sweepKey}
 	})
-	tenantReleases := predicate.NewPredicateFuncs(func(o client.Object) bool {
+	// Tenant releases change the tree; any other Application changes usage of
+	// the count quotas only when it is created or deleted.
+	isTenant := func(o client.Object) bool {
 		return o.GetLabels()[appsv1alpha1.ApplicationKindLabel] == tenantKind
-	})
+	}
+	isApplication := func(o client.Object) bool {
+		_, ok := o.GetLabels()[appsv1alpha1.ApplicationKindLabel]
+		return ok
+	}
+	applicationReleases := predicate.Funcs{
+		CreateFunc:  func(e event.CreateEvent) bool { return isApplication(e.Object) },
+		DeleteFunc:  func(e event.DeleteEvent) bool { return isApplication(e.Object) },
+		UpdateFunc:  func(e event.UpdateEvent) bool { return isTenant(e.ObjectNew) },
+		GenericFunc: func(e event.GenericEvent) bool { return isTenant(e.Object) },
+	}
 	tenantQuotas := predicate.NewPredicateFuncs(func(o client.Object) bool {
 		return o.GetName() == chartQuotaName || o.GetName() == allocatedQuotaName
 	})
 	return ctrl.NewControllerManagedBy(mgr).
 		Named("tenantquota-controller").
-		Watches(&helmv2.HelmRelease{}, toSweep, builder.WithPredicates(tenantReleases)).
+		Watches(&helmv2.HelmRelease{}, toSweep, builder.WithPredicates(applicationReleases)).
 		Watches(&corev1.Namespace{}, toSweep).
 		Watches(&corev1.ResourceQuota{}, toSweep, builder.WithPredicates(tenantQuotas)).
 		Complete(r)
@@ -29,6 +29,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
+	cozyv1alpha1 "github.com/cozystack/cozystack/api/v1alpha1"
 	appsv1alpha1 "github.com/cozystack/cozystack/pkg/apis/apps/v1alpha1"
 )
 
@@ -90,6 +91,9 @@ func newReconciler(t *testing.T, objs ...client.Object) (*Reconciler, client.Cli
 	if err := helmv2.AddToScheme(scheme); err != nil {
 		t.Fatalf("helmv2 scheme: %v", err)
 	}
+	if err := cozyv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatalf("cozystack scheme: %v", err)
+	}
 	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
 	return &Reconciler{Client: c, Scheme: scheme}, c
 }
@@ -107,6 +111,29 @@ func allocatedHard(t *testing.T, c client.Client, namespace, resourceName string
 	return q.String(), true
 }
 
+// applicationHR builds the HelmRelease an Application of kind is stored as.
+func applicationHR(kind, name, namespace string) *helmv2.HelmRelease {
+	return &helmv2.HelmRelease{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels: map[string]string{
+				appsv1alpha1.ApplicationKindLabel:  kind,
+				appsv1alpha1.ApplicationGroupLabel: appsv1alpha1.GroupName,
+			},
+		},
+	}
+}
+
+func applicationDefinition(kind, plural string) *cozyv1alpha1.ApplicationDefinition {
+	return &cozyv1alpha1.ApplicationDefinition{
+		ObjectMeta: metav1.ObjectMeta{Name: plural},
+		Spec: cozyv1alpha1.ApplicationDefinitionSpec{
+			Application: cozyv1alpha1.ApplicationDefinitionApplication{Kind: kind, Plural: plural},
+		},
+	}
+}
+
 // TestReconcile_LoneTenantIsNoOp: a tenant with a quota but no sub-tenants is
 // already fully enforced by its chart quota, so the controller writes nothing.
 func TestReconcile_LoneTenantIsNoOp(t *testing.T) {
@@ -227,3 +254,31 @@ func TestReconcile_GarbageCollect(t *testing.T) {
 		t.Fatalf("stale allocated quota in tenant-gone should have been garbage-collected")
 	}
 }
+
+// TestReconcile_ApplicationCountPool: foo may run 3 Postgres instances, shared
+// with its unbounded child bar. The controller counts the instances itself, so
+// each member is clamped to what the other leaves even though no
+// ResourceQuota reports that usage.
+func TestReconcile_ApplicationCountPool(t *testing.T) {
+	key := string(ApplicationCountResource("postgreses"))
+	r, c := newReconciler(t,
+		tenantHR("foo", "tenant-root"),
+		tenantHR("bar", "tenant-foo"),
+		ns("tenant-foo"), ns("tenant-foo-bar"),
+		chartQuota("tenant-foo", map[string]string{key: "3"}, nil),
+		applicationDefinition("Postgres", "postgreses"),
+		applicationHR("Postgres", "postgres-a", "tenant-foo"),
+		applicationHR("Postgres", "postgres-b", "tenant-foo-bar"),
+		applicationHR("Postgres", "postgres-c", "tenant-foo-bar"),
+		applicationHR("Redis", "redis-a", "tenant-foo-bar"),
+	)
+	if _, err := r.Reconcile(context.Background(), sweepKey); err != nil {
+		t.Fatalf("reconcile: %v", err)
+	}
+	if got, _ := allocatedHard(t, c, "tenant-foo", key); got != "1" { // 3 - bar's 2
+		t.Fatalf("tenant-foo allocated %s = %q, want 1", key, got)
+	}
+	if got, _ := allocatedHard(t, c, "tenant-foo-bar", key); got != "2" { // 3 - foo's 1
+		t.Fatalf("tenant-foo-bar allocated %s = %q, want 2", key, got)
+	}
+}
@@ -0,0 +1,175 @@
+/*
+Copyright 2026 The Cozystack Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tenantquota
+
+import (
+	"encoding/json"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	quota "k8s.io/apiserver/pkg/quota/v1"
+)
+
+// View is the quota picture of one tenant namespace, as the controller sees
+// it during a sweep. The aggregated apiserver serves it as TenantQuota.
+type View struct {
+	Namespace string
+	// Parent is the namespace of the parent tenant, "" for the root tenant.
+	Parent string
+	// Pool is the root of the pool the namespace draws from, "" when no
+	// tenant up to the root declares a quota.
+	Pool string
+	// Declared is the tenant's own budget, from the chart-rendered quota.
+	Declared corev1.ResourceList
+	// CarvedOut and Overcommitted are set on pool roots only: the budgets
+	// reserved by bounded sub-tenants, and how far they exceed Declared.
+	CarvedOut     corev1.ResourceList
+	Overcommitted corev1.ResourceList
+	// Allocated is what the namespace may use: the tightest hard limit of
+	// its ResourceQuotas, i.e. the declared budget clamped to the
+	// namespace's share of its pool.
+	Allocated corev1.ResourceList
+	// Used is the namespace's current usage.
+	Used corev1.ResourceList
+	// Headroom is Allocated minus Used, never negative.
+	Headroom corev1.ResourceList
+	// Descendants lists the namespaces of the tenants below this one, sorted,
+	// and DescendantsUsed sums their usage.
+	Descendants     []string
+	DescendantsUsed corev1.ResourceList
+	// Borrowing is set on tenants that opted in to borrowing from their
+	// siblings and have a parent pool to borrow within.
+	Borrowing *Borrowing
+}
+
+// namespaceQuotas is what the ResourceQuotas of the tenant namespaces say.
+type namespaceQuotas struct {
+	declared    map[string]corev1.ResourceList
+	borrowLimit map[string]corev1.ResourceList
+	hard        map[string]corev1.ResourceList
+	used        map[string]corev1.ResourceList
+}
+
+// readQuotas folds ResourceQuotas into per-namespace declared budgets, hard
+// limits and usage. Multiple ResourceQuotas in a namespace each report the
+// same usage for a given resource, so usage is merged with a per-resource max
+// (not a sum) to avoid double counting; Kubernetes enforces the tightest of
+// them, so hard limits are merged with a per-resource min.
+func readQuotas(quotas []corev1.ResourceQuota) namespaceQuotas {
+	q := namespaceQuotas{
+		declared:    map[string]corev1.ResourceList{},
+		borrowLimit: map[string]corev1.ResourceList{},
+		hard:        map[string]corev1.ResourceList{},
+		used:        map[string]corev1.ResourceList{},
+	}
+	for i := range quotas {
+		rq := &quotas[i]
+		q.used[rq.Namespace] = maxResourceList(q.used[rq.Namespace], rq.Status.Used)
+		q.hard[rq.Namespace] = minResourceList(q.hard[rq.Namespace], rq.Spec.Hard)
+		if rq.Name != chartQuotaName {
+			continue
+		}
+		q.declared[rq.Namespace] = rq.Spec.Hard
+		// A tenant borrowing from its siblings declares its budget beside
+		// the ceiling its chart quota enforces. One that cannot be read is
+		// treated as not borrowing, at its ceiling.
+		budget, errBudget := parseResourceList(rq.Annotations[budgetAnnotation])
+		limit, errLimit := parseResourceList(rq.Annotations[borrowLimitAnnotation])
+		if budget != nil && limit != nil && errBudget == nil && errLimit == nil {
+			q.declared[rq.Namespace] = budget
+			q.borrowLimit[rq.Namespace] = limit
+		}
+	}
+	return q
+}
+
+// parseResourceList decodes a JSON map of quantities; "" decodes to nil.
+func parseResourceList(raw string) (corev1.ResourceList, error) {
+	if raw == "" {
+		return nil, nil
+	}
+	rl := corev1.ResourceList{}
+	if err := json.Unmarshal([]byte(raw), &rl); err != nil {
+		return nil, err
+	}
+	return rl, nil
+}
+
+// ComputeViews computes the quota picture of every tenant namespace from the
+// ResourceQuotas of the cluster, with the same pool model the controller
+// enforces.
+func ComputeViews(namespaces []string, quotas []corev1.ResourceQuota) map[string]*View {
+	q := readQuotas(quotas)
+	tenants := make([]Tenant, 0, len(namespaces))
+	declaredByNS := map[string]corev1.ResourceList{}
+	for _, ns := range namespaces {
+		tenants = append(tenants, Tenant{Namespace: ns, Declared: q.declared[ns], BorrowLimit: q.borrowLimit[ns]})
+		if len(q.declared[ns]) > 0 {
+			declaredByNS[ns] = q.declared[ns]
+		}
+	}
+	pools := ComputePools(tenants)
+	borrowing := ComputeBorrowing(tenants, pools, q.used)
+
+	views := make(map[string]*View, len(namespaces))
+	for _, ns := range namespaces {
+		v := &View{
+			Namespace: ns,
+			Parent:    ParentNamespace(ns),
+			Pool:      poolRootOf(ns, declaredByNS),
+			Declared:  q.declared[ns],
+			Allocated: q.hard[ns],
+			Used:      q.used[ns],
+			Borrowing: borrowing[ns],
+		}
+		if p, ok := pools[ns]; ok {
+			v.CarvedOut = p.CarvedOut
+			v.Overcommitted = p.Overcommitted()
+		}
+		if len(v.Allocated) > 0 {
+			v.Headroom = quota.Mask(quota.SubtractWithNonNegativeResult(v.Allocated, v.Used), quota.ResourceNames(v.Allocated))
+		}
+		views[ns] = v
+	}
+
+	sorted := append([]string(nil), namespaces...)
+	sort.Strings(sorted)
+	for _, ns := range sorted {
+		for anc := ParentNamespace(ns); anc != ""; anc = ParentNamespace(anc) {
+			if v, ok := views[anc]; ok {
+				v.Descendants = append(v.Descendants, ns)
+				v.DescendantsUsed = quota.Add(v.DescendantsUsed, q.used[ns])
+			}
+		}
+	}
+	return views
+}
+
+// minResourceList returns the per-resource minimum of a and b, keeping the
+// resources only one of them bounds.
+func minResourceList(a, b corev1.ResourceList) corev1.ResourceList {
+	out := corev1.ResourceList{}
+	for k, v := range a {
+		out[k] = v.DeepCopy()
+	}
+	for k, v := range b {
+		if cur, ok := out[k]; !ok || v.Cmp(cur) < 0 {
+			out[k] = v.DeepCopy()
+		}
+	}
+	return out
+}
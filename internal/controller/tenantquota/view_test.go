@@ -0,0 +1,91 @@
+/*
+Copyright 2026 The Cozystack Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tenantquota
+
+import (
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func rq(ns, name string, hard, used map[string]string) corev1.ResourceQuota {
+	return corev1.ResourceQuota{
+		ObjectMeta: metav1.ObjectMeta{Namespace: ns, Name: name},
+		Spec:       corev1.ResourceQuotaSpec{Hard: rl(hard)},
+		Status:     corev1.ResourceQuotaStatus{Hard: rl(hard), Used: rl(used)},
+	}
+}
+
+func TestComputeViews(t *testing.T) {
+	// tenant-foo declares cpu 10, its child bar declares cpu 12 (overcommitting
+	// foo's pool) and its child qux declares nothing and draws from foo.
+	views := ComputeViews(
+		[]string{"tenant-root", "tenant-foo", "tenant-foo-bar", "tenant-foo-qux"},
+		[]corev1.ResourceQuota{
+			rq("tenant-foo", chartQuotaName, map[string]string{"cpu": "10"}, map[string]string{"cpu": "1"}),
+			rq("tenant-foo-bar", chartQuotaName, map[string]string{"cpu": "12"}, map[string]string{"cpu": "3"}),
+			rq("tenant-foo-qux", allocatedQuotaName, map[string]string{"cpu": "2"}, map[string]string{"cpu": "500m"}),
+			// A second, tighter quota in qux with the same usage.
+			rq("tenant-foo-qux", "extra", map[string]string{"cpu": "1500m"}, map[string]string{"cpu": "500m"}),
+		},
+	)
+
+	root := views["tenant-root"]
+	if root.Parent != "" || root.Pool != "" || len(root.Allocated) != 0 || len(root.Headroom) != 0 {
+		t.Errorf("root = %+v, want an unbounded tenant", root)
+	}
+	if !reflect.DeepEqual(root.Descendants, []string{"tenant-foo", "tenant-foo-bar", "tenant-foo-qux"}) {
+		t.Errorf("root descendants = %v", root.Descendants)
+	}
+	quantityEqual(t, root.DescendantsUsed, "cpu", "4500m")
+
+	foo := views["tenant-foo"]
+	if foo.Parent != "tenant-root" || foo.Pool != "tenant-foo" {
+		t.Errorf("foo parent %q pool %q", foo.Parent, foo.Pool)
+	}
+	quantityEqual(t, foo.CarvedOut, "cpu", "12")
+	quantityEqual(t, foo.Overcommitted, "cpu", "2")
+	quantityEqual(t, foo.Headroom, "cpu", "9")
+	quantityEqual(t, foo.DescendantsUsed, "cpu", "3500m")
+
+	qux := views["tenant-foo-qux"]
+	if qux.Pool != "tenant-foo" || len(qux.Declared) != 0 || len(qux.CarvedOut) != 0 {
+		t.Errorf("qux = %+v, want a member of foo's pool", qux)
+	}
+	quantityEqual(t, qux.Allocated, "cpu", "1500m")
+	quantityEqual(t, qux.Used, "cpu", "500m")
+	quantityEqual(t, qux.Headroom, "cpu", "1")
+	if len(qux.Descendants) != 0 {
+		t.Errorf("qux descendants = %v", qux.Descendants)
+	}
+}
+
+func TestComputeViews_HeadroomNeverNegative(t *testing.T) {
+	views := ComputeViews(
+		[]string{"tenant-root", "tenant-foo"},
+		[]corev1.ResourceQuota{
+			rq("tenant-foo", chartQuotaName, map[string]string{"cpu": "1"}, map[string]string{"cpu": "2", "memory": "1Gi"}),
+		},
+	)
+	foo := views["tenant-foo"]
+	quantityEqual(t, foo.Headroom, "cpu", "0")
+	if _, ok := foo.Headroom["memory"]; ok {
+		t.Errorf("headroom %v reports a resource that is not limited", foo.Headroom)
+	}
+}
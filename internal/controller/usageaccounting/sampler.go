@@ -0,0 +1,258 @@
+/*
+Copyright 2026 The Cozystack Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package usageaccounting accumulates the resource usage of tenants over
+// time, for showback and chargeback.
+//
+// Workloads already carry what each pod, volume, LoadBalancer Service and
+// bucket of an application is allocated, bucket sizes included. The Sampler
+// periodically adds a snapshot of them, weighted by the sampling interval, to
+// an hourly UsageRecord per tenant namespace, so that each record holds the
+// resource-hours every application used during that hour. Records older than
+// the retention are deleted.
+package usageaccounting
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	cozyv1alpha1 "github.com/cozystack/cozystack/api/v1alpha1"
+	appsv1alpha1 "github.com/cozystack/cozystack/pkg/apis/apps/v1alpha1"
+)
+
+const tenantNamespacePrefix = "tenant-"
+
+// RecordName returns the name of the UsageRecord of the hour starting at
+// start, e.g. usage-2026101914 for 14:00 UTC on 19 October 2026.
+func RecordName(start time.Time) string {
+	return "usage-" + start.UTC().Format("2006010215")
+}
+
+// +kubebuilder:rbac:groups=cozystack.io,resources=workloads,verbs=list
+// +kubebuilder:rbac:groups=cozystack.io,resources=usagerecords,verbs=get;list;create;update;delete
+
+// Sampler adds a sample of the Workloads of every tenant namespace to the
+// UsageRecords of the current hour, every Interval.
+type Sampler struct {
+	Client client.Client
+	// Interval is the time between samples; each sample accounts for that
+	// much of the hour.
+	Interval time.Duration
+	// Retention is how long records are kept; zero keeps them forever.
+	Retention time.Duration
+	// Now returns the current time; time.Now when nil.
+	Now func() time.Time
+
+	prunedHour time.Time
+}
+
+// Start implements manager.Runnable.
+func (s *Sampler) Start(ctx context.Context) error {
+	ticker := time.NewTicker(s.Interval)
+	defer ticker.Stop()
+	for {
+		if err := s.Sample(ctx); err != nil {
+			log.FromContext(ctx).Error(err, "failed to sample tenant usage")
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable: only one
+// replica may add samples.
+func (s *Sampler) NeedLeaderElection() bool {
+	return true
+}
+
+// Sample adds the current allocation of every tenant application to the
+// record of the current hour, then deletes expired records once per hour.
+func (s *Sampler) Sample(ctx context.Context) error {
+	now := s.now().UTC()
+	hour := now.Truncate(time.Hour)
+
+	workloads := &cozyv1alpha1.WorkloadList{}
+	if err := s.Client.List(ctx, workloads); err != nil {
+		return fmt.Errorf("list workloads: %w", err)
+	}
+	byNamespace := Aggregate(workloads.Items)
+
+	namespaces := make([]string, 0, len(byNamespace))
+	for ns := range byNamespace {
+		namespaces = append(namespaces, ns)
+	}
+	sort.Strings(namespaces)
+	var errs []error
+	for _, ns := range namespaces {
+		if err := s.add(ctx, ns, hour, now, byNamespace[ns]); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", ns, err))
+		}
+	}
+
+	if s.Retention > 0 && !hour.Equal(s.prunedHour) {
+		if err := s.prune(ctx, now.Add(-s.Retention)); err != nil {
+			errs = append(errs, err)
+		} else {
+			s.prunedHour = hour
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("sampling usage: %v", errs)
+	}
+	return nil
+}
+
+// add adds one sample of a namespace's applications to its record of hour.
+func (s *Sampler) add(ctx context.Context, namespace string, hour, now time.Time, apps map[ApplicationKey]map[string]resource.Quantity) error {
+	record := &cozyv1alpha1.UsageRecord{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: RecordName(hour)},
+	}
+	_, err := ctrl.CreateOrUpdate(ctx, s.Client, record, func() error {
+		status := &record.Status
+		// A sample taken right after another, e.g. by a new leader, would
+		// count the same time twice.
+		if status.LastSampleTime != nil && now.Sub(status.LastSampleTime.Time) < s.Interval/2 {
+			return nil
+		}
+		record.Spec.Start = metav1.Time{Time: hour}
+		record.Spec.End = metav1.Time{Time: hour.Add(time.Hour)}
+
+		// Samples never account for more than the hour.
+		covered := s.Interval
+		if left := time.Hour - time.Duration(status.SampledSeconds)*time.Second; covered > left {
+			covered = left
+		}
+		if covered <= 0 {
+			return nil
+		}
+		status.SampledSeconds += int64(covered / time.Second)
+		status.LastSampleTime = &metav1.Time{Time: now}
+		status.Applications = addUsage(status.Applications, apps, covered.Hours())
+		return nil
+	})
+	return err
+}
+
+// prune deletes the records that ended before cutoff.
+func (s *Sampler) prune(ctx context.Context, cutoff time.Time) error {
+	records := &cozyv1alpha1.UsageRecordList{}
+	if err := s.Client.List(ctx, records); err != nil {
+		return fmt.Errorf("list usage records: %w", err)
+	}
+	for i := range records.Items {
+		record := &records.Items[i]
+		if record.Spec.End.Time.Before(cutoff) {
+			if err := s.Client.Delete(ctx, record); client.IgnoreNotFound(err) != nil {
+				return fmt.Errorf("delete usage record %s/%s: %w", record.Namespace, record.Name, err)
+			}
+		}
+	}
+	return nil
+}
+
+func (s *Sampler) now() time.Time {
+	if s.Now != nil {
+		return s.Now()
+	}
+	return time.Now()
+}
+
+// ApplicationKey identifies an application within a namespace. Workloads of
+// no application have an empty key.
+type ApplicationKey struct {
+	Kind string
+	Name string
+}
+
+// Aggregate sums the resources of the Workloads of tenant namespaces, per
+// namespace and application. Workloads inherit the application labels of the
+// pods, volumes, Services and buckets they stand for.
+func Aggregate(workloads []cozyv1alpha1.Workload) map[string]map[ApplicationKey]map[string]resource.Quantity {
+	out := map[string]map[ApplicationKey]map[string]resource.Quantity{}
+	for i := range workloads {
+		w := &workloads[i]
+		if !strings.HasPrefix(w.Namespace, tenantNamespacePrefix) || len(w.Status.Resources) == 0 {
+			continue
+		}
+		key := ApplicationKey{
+			Kind: w.Labels[appsv1alpha1.ApplicationKindLabel],
+			Name: w.Labels[appsv1alpha1.ApplicationNameLabel],
+		}
+		if out[w.Namespace] == nil {
+			out[w.Namespace] = map[ApplicationKey]map[string]resource.Quantity{}
+		}
+		sum := out[w.Namespace][key]
+		if sum == nil {
+			sum = map[string]resource.Quantity{}
+			out[w.Namespace][key] = sum
+		}
+		for name, q := range w.Status.Resources {
+			total := sum[name]
+			total.Add(q)
+			sum[name] = total
+		}
+	}
+	return out
+}
+
+// addUsage adds apps, held for hours, to usage, keeping it sorted.
+func addUsage(usage []cozyv1alpha1.ApplicationUsage, apps map[ApplicationKey]map[string]resource.Quantity, hours float64) []cozyv1alpha1.ApplicationUsage {
+	index := make(map[ApplicationKey]int, len(usage))
+	for i, u := range usage {
+		index[ApplicationKey{Kind: u.Kind, Name: u.Name}] = i
+	}
+	for key, resources := range apps {
+		i, ok := index[key]
+		if !ok {
+			usage = append(usage, cozyv1alpha1.ApplicationUsage{Kind: key.Kind, Name: key.Name, Usage: map[string]resource.Quantity{}})
+			i = len(usage) - 1
+			index[key] = i
+		}
+		if usage[i].Usage == nil {
+			usage[i].Usage = map[string]resource.Quantity{}
+		}
+		for name, q := range resources {
+			total := usage[i].Usage[name]
+			total.Add(scale(q, hours))
+			usage[i].Usage[name] = total
+		}
+	}
+	sort.Slice(usage, func(i, j int) bool {
+		if usage[i].Kind != usage[j].Kind {
+			return usage[i].Kind < usage[j].Kind
+		}
+		return usage[i].Name < usage[j].Name
+	})
+	return usage
+}
+
+// scale returns q times f, to the thousandth.
+func scale(q resource.Quantity, f float64) resource.Quantity {
+	return *resource.NewMilliQuantity(int64(math.Round(q.AsApproximateFloat64()*f*1000)), q.Format)
+}
@@ -0,0 +1,187 @@
+/*
+Copyright 2026 The Cozystack Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package usageaccounting
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	cozyv1alpha1 "github.com/cozystack/cozystack/api/v1alpha1"
+	appsv1alpha1 "github.com/cozystack/cozystack/pkg/apis/apps/v1alpha1"
+)
+
+func workload(ns, name, kind, app string, resources corev1.ResourceList) *cozyv1alpha1.Workload {
+	w := &cozyv1alpha1.Workload{
+		ObjectMeta: metav1.ObjectMeta{Namespace: ns, Name: name},
+		Status:     cozyv1alpha1.WorkloadStatus{Resources: map[string]resource.Quantity{}},
+	}
+	if kind != "" {
+		w.Labels = map[string]string{
+			appsv1alpha1.ApplicationKindLabel: kind,
+			appsv1alpha1.ApplicationNameLabel: app,
+		}
+	}
+	for name, q := range resources {
+		w.Status.Resources[string(name)] = q
+	}
+	return w
+}
+
+func newSampler(t *testing.T, now *time.Time, objs ...client.Object) (*Sampler, client.Client) {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	if err := cozyv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+	return &Sampler{
+		Client:    c,
+		Interval:  15 * time.Minute,
+		Retention: 48 * time.Hour,
+		Now:       func() time.Time { return *now },
+	}, c
+}
+
+func getRecord(t *testing.T, c client.Client, ns string, hour time.Time) *cozyv1alpha1.UsageRecord {
+	t.Helper()
+	record := &cozyv1alpha1.UsageRecord{}
+	if err := c.Get(context.Background(), types.NamespacedName{Namespace: ns, Name: RecordName(hour)}, record); err != nil {
+		t.Fatalf("get record: %v", err)
+	}
+	return record
+}
+
+func TestSampleAccumulatesResourceHours(t *testing.T) {
+	hour := time.Date(2026, 10, 19, 14, 0, 0, 0, time.UTC)
+	now := hour.Add(time.Minute)
+	s, c := newSampler(t, &now,
+		workload("tenant-foo", "pod-db-0", "Postgres", "db", corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("2"),
+			corev1.ResourceMemory: resource.MustParse("4Gi"),
+		}),
+		workload("tenant-foo", "pod-db-1", "Postgres", "db", corev1.ResourceList{
+			corev1.ResourceCPU: resource.MustParse("2"),
+		}),
+		workload("tenant-foo", "pod-stray", "", "", corev1.ResourceList{
+			corev1.ResourceCPU: resource.MustParse("500m"),
+		}),
+		workload("cozy-system", "pod-system", "", "", corev1.ResourceList{
+			corev1.ResourceCPU: resource.MustParse("8"),
+		}),
+	)
+
+	for i := 0; i < 4; i++ {
+		if err := s.Sample(context.Background()); err != nil {
+			t.Fatalf("sample %d: %v", i, err)
+		}
+		now = now.Add(s.Interval)
+	}
+
+	record := getRecord(t, c, "tenant-foo", hour)
+	if record.Status.SampledSeconds != 3600 {
+		t.Errorf("sampledSeconds = %d, want 3600", record.Status.SampledSeconds)
+	}
+	if !record.Spec.Start.Time.Equal(hour) || !record.Spec.End.Time.Equal(hour.Add(time.Hour)) {
+		t.Errorf("record covers %v-%v", record.Spec.Start, record.Spec.End)
+	}
+	if len(record.Status.Applications) != 2 {
+		t.Fatalf("applications = %+v, want the stray workloads and db", record.Status.Applications)
+	}
+	stray, db := record.Status.Applications[0], record.Status.Applications[1]
+	if stray.Kind != "" || db.Kind != "Postgres" || db.Name != "db" {
+		t.Fatalf("applications are not sorted: %+v", record.Status.Applications)
+	}
+	for _, tc := range []struct {
+		usage map[string]resource.Quantity
+		name  string
+		want  string
+	}{
+		{db.Usage, "cpu", "4"},
+		{db.Usage, "memory", "4Gi"},
+		{stray.Usage, "cpu", "500m"},
+	} {
+		got := tc.usage[tc.name]
+		if want := resource.MustParse(tc.want); got.Cmp(want) != 0 {
+			t.Errorf("%s = %s, want %s", tc.name, got.String(), tc.want)
+		}
+	}
+
+	if err := c.Get(context.Background(), types.NamespacedName{Namespace: "cozy-system", Name: RecordName(hour)}, &cozyv1alpha1.UsageRecord{}); !apierrors.IsNotFound(err) {
+		t.Errorf("non-tenant namespaces must not be accounted, got %v", err)
+	}
+}
+
+func TestSampleSkipsDuplicateSamples(t *testing.T) {
+	hour := time.Date(2026, 10, 19, 14, 0, 0, 0, time.UTC)
+	now := hour.Add(time.Minute)
+	s, c := newSampler(t, &now, workload("tenant-foo", "pod", "Redis", "cache", corev1.ResourceList{
+		corev1.ResourceCPU: resource.MustParse("1"),
+	}))
+
+	if err := s.Sample(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	// Another leader takes over and samples right away.
+	now = now.Add(time.Minute)
+	if err := s.Sample(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	record := getRecord(t, c, "tenant-foo", hour)
+	if record.Status.SampledSeconds != 900 {
+		t.Errorf("sampledSeconds = %d, want 900", record.Status.SampledSeconds)
+	}
+	got := record.Status.Applications[0].Usage["cpu"]
+	if want := resource.MustParse("250m"); got.Cmp(want) != 0 {
+		t.Errorf("cpu = %s, want 250m", got.String())
+	}
+}
+
+func TestSamplePrunesExpiredRecords(t *testing.T) {
+	hour := time.Date(2026, 10, 19, 14, 0, 0, 0, time.UTC)
+	now := hour.Add(time.Minute)
+	record := func(start time.Time) *cozyv1alpha1.UsageRecord {
+		return &cozyv1alpha1.UsageRecord{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "tenant-foo", Name: RecordName(start)},
+			Spec: cozyv1alpha1.UsageRecordSpec{
+				Start: metav1.Time{Time: start},
+				End:   metav1.Time{Time: start.Add(time.Hour)},
+			},
+		}
+	}
+	expired, kept := hour.Add(-72*time.Hour), hour.Add(-24*time.Hour)
+	s, c := newSampler(t, &now, record(expired), record(kept))
+
+	if err := s.Sample(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := c.Get(context.Background(), types.NamespacedName{Namespace: "tenant-foo", Name: RecordName(expired)}, &cozyv1alpha1.UsageRecord{}); !apierrors.IsNotFound(err) {
+		t.Errorf("expired record was kept: %v", err)
+	}
+	getRecord(t, c, "tenant-foo", kept)
+}
@@ -7,21 +7,67 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	sdnv1alpha1 "github.com/cozystack/cozystack/pkg/apis/sdn/v1alpha1"
 )
 
-// CiliumNetworkPolicy is a metadata-only, in-tree mirror of the cilium.io/v2
-// CiliumNetworkPolicy resource. The securitygroup-controller only reads a
-// policy's marker label, its attachments annotation and its finalizers — never
-// the spec — so this mirror deliberately omits the spec entirely, keeping the
+// CiliumNetworkPolicy is a minimal, in-tree mirror of the cilium.io/v2
+// CiliumNetworkPolicy resource. The securitygroup-controller reads a policy's
+// marker label, its attachments annotation, its finalizers and — to resolve
+// fromSG/toSG references and attribute flows to rules — the peers and ports of
+// its rules, so this mirror carries only that subset of the spec. It keeps the
 // controller binary free of the full Cilium module (whose Kubernetes pin is
 // incompatible with this project's apimachinery fork).
 //
-// NEVER Update an object of this type: a PUT would serialize it without a spec
-// and wipe the real policy's rules. Finalizer changes go through MergeFrom
-// patches, which carry only the changed fields.
+// NEVER Update an object of this type: a PUT would serialize the partial spec
+// and wipe the rest of the real policy. Finalizer and status annotation
+// changes go through MergeFrom patches, which carry only the changed fields.
 type CiliumNetworkPolicy struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec is read-only.
+	Spec *CiliumNetworkPolicySpec `json:"spec,omitempty"`
+}
+
+// CiliumNetworkPolicySpec is the read-only subset of the policy spec the
+// controller needs.
+type CiliumNetworkPolicySpec struct {
+	Ingress     []CiliumIngressRule     `json:"ingress,omitempty"`
+	Egress      []CiliumEgressRule      `json:"egress,omitempty"`
+	IngressDeny []CiliumIngressDenyRule `json:"ingressDeny,omitempty"`
+	EgressDeny  []CiliumEgressDenyRule  `json:"egressDeny,omitempty"`
+}
+
+// CiliumIngressRule is the read-only subset of a cilium.io/v2 ingress rule.
+type CiliumIngressRule struct {
+	FromEndpoints []metav1.LabelSelector `json:"fromEndpoints,omitempty"`
+	FromCIDR      []string               `json:"fromCIDR,omitempty"`
+	ToPorts       []sdnv1alpha1.PortRule `json:"toPorts,omitempty"`
+}
+
+// CiliumEgressRule is the read-only subset of a cilium.io/v2 egress rule.
+type CiliumEgressRule struct {
+	ToEndpoints []metav1.LabelSelector     `json:"toEndpoints,omitempty"`
+	ToCIDR      []string                   `json:"toCIDR,omitempty"`
+	ToFQDNs     []sdnv1alpha1.FQDNSelector `json:"toFQDNs,omitempty"`
+	ToPorts     []sdnv1alpha1.PortRule     `json:"toPorts,omitempty"`
+}
+
+// CiliumIngressDenyRule is the read-only subset of a cilium.io/v2 ingressDeny
+// rule.
+type CiliumIngressDenyRule struct {
+	FromEndpoints []metav1.LabelSelector     `json:"fromEndpoints,omitempty"`
+	FromCIDR      []string                   `json:"fromCIDR,omitempty"`
+	ToPorts       []sdnv1alpha1.PortDenyRule `json:"toPorts,omitempty"`
+}
+
+// CiliumEgressDenyRule is the read-only subset of a cilium.io/v2 egressDeny
+// rule.
+type CiliumEgressDenyRule struct {
+	ToEndpoints []metav1.LabelSelector     `json:"toEndpoints,omitempty"`
+	ToCIDR      []string                   `json:"toCIDR,omitempty"`
+	ToPorts     []sdnv1alpha1.PortDenyRule `json:"toPorts,omitempty"`
 }
 
 // CiliumNetworkPolicyList is a list of CiliumNetworkPolicy objects.
@@ -49,6 +95,93 @@ func (in *CiliumNetworkPolicy) DeepCopyInto(out *CiliumNetworkPolicy) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	if in.Spec != nil {
+		out.Spec = in.Spec.DeepCopy()
+	}
+}
+
+// DeepCopy returns a deep copy of the receiver. Hand-written because cilium.go
+// is not run through deepcopy-gen.
+func (in *CiliumNetworkPolicySpec) DeepCopy() *CiliumNetworkPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := &CiliumNetworkPolicySpec{}
+	if in.Ingress != nil {
+		out.Ingress = make([]CiliumIngressRule, len(in.Ingress))
+		for i, r := range in.Ingress {
+			out.Ingress[i] = CiliumIngressRule{
+				FromEndpoints: copySelectors(r.FromEndpoints),
+				FromCIDR:      append([]string(nil), r.FromCIDR...),
+				ToPorts:       copyPortRules(r.ToPorts),
+			}
+		}
+	}
+	if in.Egress != nil {
+		out.Egress = make([]CiliumEgressRule, len(in.Egress))
+		for i, r := range in.Egress {
+			out.Egress[i] = CiliumEgressRule{
+				ToEndpoints: copySelectors(r.ToEndpoints),
+				ToCIDR:      append([]string(nil), r.ToCIDR...),
+				ToFQDNs:     append([]sdnv1alpha1.FQDNSelector(nil), r.ToFQDNs...),
+				ToPorts:     copyPortRules(r.ToPorts),
+			}
+		}
+	}
+	if in.IngressDeny != nil {
+		out.IngressDeny = make([]CiliumIngressDenyRule, len(in.IngressDeny))
+		for i, r := range in.IngressDeny {
+			out.IngressDeny[i] = CiliumIngressDenyRule{
+				FromEndpoints: copySelectors(r.FromEndpoints),
+				FromCIDR:      append([]string(nil), r.FromCIDR...),
+				ToPorts:       copyPortDenyRules(r.ToPorts),
+			}
+		}
+	}
+	if in.EgressDeny != nil {
+		out.EgressDeny = make([]CiliumEgressDenyRule, len(in.EgressDeny))
+		for i, r := range in.EgressDeny {
+			out.EgressDeny[i] = CiliumEgressDenyRule{
+				ToEndpoints: copySelectors(r.ToEndpoints),
+				ToCIDR:      append([]string(nil), r.ToCIDR...),
+				ToPorts:     copyPortDenyRules(r.ToPorts),
+			}
+		}
+	}
+	return out
+}
+
+func copySelectors(in []metav1.LabelSelector) []metav1.LabelSelector {
+	if in == nil {
+		return nil
+	}
+	out := make([]metav1.LabelSelector, len(in))
+	for i := range in {
+		in[i].DeepCopyInto(&out[i])
+	}
+	return out
+}
+
+func copyPortRules(in []sdnv1alpha1.PortRule) []sdnv1alpha1.PortRule {
+	if in == nil {
+		return nil
+	}
+	out := make([]sdnv1alpha1.PortRule, len(in))
+	for i := range in {
+		in[i].DeepCopyInto(&out[i])
+	}
+	return out
+}
+
+func copyPortDenyRules(in []sdnv1alpha1.PortDenyRule) []sdnv1alpha1.PortDenyRule {
+	if in == nil {
+		return nil
+	}
+	out := make([]sdnv1alpha1.PortDenyRule, len(in))
+	for i := range in {
+		in[i].DeepCopyInto(&out[i])
+	}
+	return out
 }
 
 // DeepCopy returns a deep copy of the receiver.
@@ -0,0 +1,455 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2026 The Cozystack Authors.
+
+package securitygroupcontroller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	sdnv1alpha1 "github.com/cozystack/cozystack/pkg/apis/sdn/v1alpha1"
+	"github.com/cozystack/cozystack/pkg/sdnmatch"
+)
+
+// Flow is one Hubble flow, the flow.Flow message of the Hubble API. Only the
+// fields rule attribution needs are kept.
+type Flow struct {
+	Time             time.Time
+	Verdict          string
+	TrafficDirection string
+	IP               *FlowIP
+	L4               *FlowL4
+	Source           *FlowEndpoint
+	Destination      *FlowEndpoint
+	DestinationNames []string
+}
+
+// FlowIP holds the addresses of a flow.
+type FlowIP struct {
+	Source      string
+	Destination string
+}
+
+// FlowL4 holds the L4 protocol of a flow; exactly one field is set.
+type FlowL4 struct {
+	TCP  *FlowPort
+	UDP  *FlowPort
+	SCTP *FlowPort
+}
+
+// FlowPort holds the destination port of a flow.
+type FlowPort struct {
+	DestinationPort uint32
+}
+
+// FlowEndpoint is one end of a flow. Labels are Cilium identity labels, such
+// as "k8s:app=web".
+type FlowEndpoint struct {
+	Namespace string
+	PodName   string
+	Labels    []string
+}
+
+// FlowSource returns the flows observed since a point in time.
+type FlowSource interface {
+	Flows(ctx context.Context, since time.Time) ([]Flow, error)
+}
+
+// FlowCounter polls a FlowSource and counts, per SecurityGroup rule, the
+// flows of member pods it allowed and denied. Counting starts when the
+// counter starts and is kept in memory: a restart starts over, which the
+// since timestamp of the counters makes visible.
+//
+// The counter never writes: it hands the SecurityGroups whose counters moved
+// to the Reconciler, the only writer of the status annotation, through a
+// channel source.
+type FlowCounter struct {
+	// Reader lists the SecurityGroup-backing policies; the manager's cache.
+	Reader client.Reader
+	// Source provides the flows.
+	Source FlowSource
+	// Interval is the time between two polls.
+	Interval time.Duration
+	// Now returns the current time; time.Now when nil.
+	Now func() time.Time
+
+	mu    sync.Mutex
+	since time.Time
+	// last is the cursor: the time of the newest flow read, from which the
+	// next poll reads on.
+	last   time.Time
+	counts map[types.NamespacedName]*sdnv1alpha1.SecurityGroupFlows
+	events chan event.GenericEvent
+}
+
+// Start implements manager.Runnable.
+func (f *FlowCounter) Start(ctx context.Context) error {
+	f.init()
+	ticker := time.NewTicker(f.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+		// A poll that outlasts the interval is given up.
+		pollCtx, cancel := context.WithTimeout(ctx, f.Interval)
+		err := f.Poll(pollCtx)
+		cancel()
+		if err != nil {
+			log.FromContext(ctx).Error(err, "failed to count SecurityGroup flows")
+		}
+	}
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable: only the
+// leader reconciles, so only its counts are ever written.
+func (f *FlowCounter) NeedLeaderElection() bool {
+	return true
+}
+
+func (f *FlowCounter) init() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.counts != nil {
+		return
+	}
+	f.since = f.now()
+	f.last = f.since
+	f.counts = map[types.NamespacedName]*sdnv1alpha1.SecurityGroupFlows{}
+}
+
+func (f *FlowCounter) channel() chan event.GenericEvent {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.events == nil {
+		f.events = make(chan event.GenericEvent, 1024)
+	}
+	return f.events
+}
+
+// Poll reads the flows newer than the newest one read before and attributes
+// them to the rules of the SecurityGroups selecting their endpoints.
+func (f *FlowCounter) Poll(ctx context.Context) error {
+	f.init()
+	f.mu.Lock()
+	last := f.last
+	f.mu.Unlock()
+
+	flows, err := f.Source.Flows(ctx, last)
+	if err != nil {
+		return err
+	}
+	policies := &CiliumNetworkPolicyList{}
+	if err := f.Reader.List(ctx, policies, client.MatchingLabels{sgLabelKey: sgLabelValue}); err != nil {
+		return fmt.Errorf("list SecurityGroup policies: %w", err)
+	}
+	byNamespace := map[string][]*CiliumNetworkPolicy{}
+	for i := range policies.Items {
+		cnp := &policies.Items[i]
+		byNamespace[cnp.Namespace] = append(byNamespace[cnp.Namespace], cnp)
+	}
+
+	f.mu.Lock()
+	// Forget the counters of deleted groups, and of groups whose rules changed
+	// shape under them.
+	live := map[types.NamespacedName]bool{}
+	for i := range policies.Items {
+		cnp := &policies.Items[i]
+		key := types.NamespacedName{Namespace: cnp.Namespace, Name: cnp.Name}
+		live[key] = true
+		if c, ok := f.counts[key]; ok && !sameShape(c, cnp) {
+			delete(f.counts, key)
+		}
+	}
+	for key := range f.counts {
+		if !live[key] {
+			delete(f.counts, key)
+		}
+	}
+
+	changed := map[types.NamespacedName]bool{}
+	for i := range flows {
+		// Windows of consecutive polls overlap by the flows at their boundary,
+		// and a flow without a timestamp cannot be placed in either.
+		if !flows[i].Time.After(last) {
+			continue
+		}
+		if flows[i].Time.After(f.last) {
+			f.last = flows[i].Time
+		}
+		for _, hit := range attribute(&flows[i], byNamespace) {
+			c := f.counter(hit.policy)
+			var list []sdnv1alpha1.RuleFlowCounter
+			switch {
+			case hit.egress && hit.deny:
+				list = c.EgressDeny
+			case hit.egress:
+				list = c.Egress
+			case hit.deny:
+				list = c.IngressDeny
+			default:
+				list = c.Ingress
+			}
+			if hit.allowed {
+				list[hit.rule].Allowed++
+			} else {
+				list[hit.rule].Denied++
+			}
+			changed[types.NamespacedName{Namespace: hit.policy.Namespace, Name: hit.policy.Name}] = true
+		}
+	}
+	f.mu.Unlock()
+
+	events := f.channel()
+	for key := range changed {
+		obj := &CiliumNetworkPolicy{}
+		obj.Namespace, obj.Name = key.Namespace, key.Name
+		select {
+		case events <- event.GenericEvent{Object: obj}:
+		default:
+			// The periodic resync picks the counts up if the queue is full.
+		}
+	}
+	return nil
+}
+
+// counter returns the counters of cnp, creating zeroed ones for each rule.
+// The caller holds f.mu.
+func (f *FlowCounter) counter(cnp *CiliumNetworkPolicy) *sdnv1alpha1.SecurityGroupFlows {
+	key := types.NamespacedName{Namespace: cnp.Namespace, Name: cnp.Name}
+	if c, ok := f.counts[key]; ok {
+		return c
+	}
+	ingress, egress, ingressDeny, egressDeny := ruleCounts(cnp)
+	c := &sdnv1alpha1.SecurityGroupFlows{
+		Since:       metav1.Time{Time: f.since},
+		Ingress:     zeroCounters(ingress),
+		Egress:      zeroCounters(egress),
+		IngressDeny: zeroCounters(ingressDeny),
+		EgressDeny:  zeroCounters(egressDeny),
+	}
+	f.counts[key] = c
+	return c
+}
+
+func zeroCounters(n int) []sdnv1alpha1.RuleFlowCounter {
+	var out []sdnv1alpha1.RuleFlowCounter
+	for i := 0; i < n; i++ {
+		out = append(out, sdnv1alpha1.RuleFlowCounter{Rule: int32(i)})
+	}
+	return out
+}
+
+// ruleCounts returns the number of ingress, egress, ingressDeny and
+// egressDeny rules of cnp.
+func ruleCounts(cnp *CiliumNetworkPolicy) (int, int, int, int) {
+	if cnp.Spec == nil {
+		return 0, 0, 0, 0
+	}
+	return len(cnp.Spec.Ingress), len(cnp.Spec.Egress), len(cnp.Spec.IngressDeny), len(cnp.Spec.EgressDeny)
+}
+
+// sameShape reports whether counters still have one entry per rule of cnp.
+func sameShape(c *sdnv1alpha1.SecurityGroupFlows, cnp *CiliumNetworkPolicy) bool {
+	ingress, egress, ingressDeny, egressDeny := ruleCounts(cnp)
+	return len(c.Ingress) == ingress && len(c.Egress) == egress &&
+		len(c.IngressDeny) == ingressDeny && len(c.EgressDeny) == egressDeny
+}
+
+// Counters returns a copy of the counters of a SecurityGroup, or nil before
+// any flow of its member pods was seen. A nil FlowCounter has no counters.
+func (f *FlowCounter) Counters(key types.NamespacedName) *sdnv1alpha1.SecurityGroupFlows {
+	if f == nil {
+		return nil
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if c, ok := f.counts[key]; ok {
+		return c.DeepCopy()
+	}
+	return nil
+}
+
+func (f *FlowCounter) now() time.Time {
+	if f.Now != nil {
+		return f.Now()
+	}
+	return time.Now()
+}
+
+// hit is a flow attributed to a rule.
+type hit struct {
+	policy  *CiliumNetworkPolicy
+	egress  bool
+	deny    bool
+	rule    int
+	allowed bool
+}
+
+// attribute returns the rules a flow is attributed to: for each SecurityGroup
+// whose member is the local end of the flow, the first deny rule whose peers
+// and ports match the remote end, as Cilium evaluates deny rules first;
+// failing that the first allow rule whose peers and ports match, or the first
+// whose peers match. Flows with a verdict other than forwarded or dropped are
+// not counted.
+func attribute(fl *Flow, byNamespace map[string][]*CiliumNetworkPolicy) []hit {
+	var allowed bool
+	switch fl.Verdict {
+	case "FORWARDED", "REDIRECTED":
+		allowed = true
+	case "DROPPED":
+	default:
+		return nil
+	}
+	var local, remote *FlowEndpoint
+	var remoteIP string
+	egress := false
+	switch fl.TrafficDirection {
+	case "INGRESS":
+		local, remote = fl.Destination, fl.Source
+		if fl.IP != nil {
+			remoteIP = fl.IP.Source
+		}
+	case "EGRESS":
+		egress = true
+		local, remote = fl.Source, fl.Destination
+		if fl.IP != nil {
+			remoteIP = fl.IP.Destination
+		}
+	default:
+		return nil
+	}
+	if local == nil || local.Namespace == "" {
+		return nil
+	}
+	localLabels := flowLabels(local)
+	remoteLabels := flowLabels(remote)
+	remoteNamespace := ""
+	if remote != nil {
+		remoteNamespace = remote.Namespace
+	}
+	protocol, port := flowPort(fl)
+
+	var out []hit
+	for _, cnp := range byNamespace[local.Namespace] {
+		if cnp.Spec == nil {
+			continue
+		}
+		if _, member := localLabels[membershipLabelKey(cnp.Name)]; !member {
+			continue
+		}
+		ports := func(rules []sdnv1alpha1.PortRule) bool {
+			ok, _ := sdnmatch.Ports(rules, protocol, port)
+			return ok
+		}
+		peer := func(endpoints []metav1.LabelSelector, cidrs []string, fqdns []sdnv1alpha1.FQDNSelector) bool {
+			if len(endpoints) == 0 && len(cidrs) == 0 && len(fqdns) == 0 {
+				return true
+			}
+			if sdnmatch.Selectors(endpoints, cnp.Namespace, remoteNamespace, remoteLabels) || sdnmatch.CIDRs(remoteIP, cidrs) {
+				return true
+			}
+			for _, name := range fl.DestinationNames {
+				if sdnmatch.FQDNs(name, fqdns) {
+					return true
+				}
+			}
+			return false
+		}
+		rule, deny := -1, false
+		if egress {
+			for i, r := range cnp.Spec.EgressDeny {
+				if peer(r.ToEndpoints, r.ToCIDR, nil) && sdnmatch.DenyPorts(r.ToPorts, protocol, port) {
+					rule, deny = i, true
+					break
+				}
+			}
+			for i, r := range cnp.Spec.Egress {
+				if rule >= 0 {
+					break
+				}
+				if peer(r.ToEndpoints, r.ToCIDR, r.ToFQDNs) && ports(r.ToPorts) {
+					rule = i
+					break
+				}
+			}
+			for i := 0; rule < 0 && i < len(cnp.Spec.Egress); i++ {
+				r := cnp.Spec.Egress[i]
+				if peer(r.ToEndpoints, r.ToCIDR, r.ToFQDNs) {
+					rule = i
+				}
+			}
+		} else {
+			for i, r := range cnp.Spec.IngressDeny {
+				if peer(r.FromEndpoints, r.FromCIDR, nil) && sdnmatch.DenyPorts(r.ToPorts, protocol, port) {
+					rule, deny = i, true
+					break
+				}
+			}
+			for i, r := range cnp.Spec.Ingress {
+				if rule >= 0 {
+					break
+				}
+				if peer(r.FromEndpoints, r.FromCIDR, nil) && ports(r.ToPorts) {
+					rule = i
+					break
+				}
+			}
+			for i := 0; rule < 0 && i < len(cnp.Spec.Ingress); i++ {
+				r := cnp.Spec.Ingress[i]
+				if peer(r.FromEndpoints, r.FromCIDR, nil) {
+					rule = i
+				}
+			}
+		}
+		if rule >= 0 {
+			out = append(out, hit{policy: cnp, egress: egress, deny: deny, rule: rule, allowed: allowed})
+		}
+	}
+	return out
+}
+
+// flowLabels parses the Kubernetes labels among the identity labels of an
+// endpoint.
+func flowLabels(ep *FlowEndpoint) map[string]string {
+	out := map[string]string{}
+	if ep == nil {
+		return out
+	}
+	for _, l := range ep.Labels {
+		l, ok := strings.CutPrefix(l, "k8s:")
+		if !ok {
+			continue
+		}
+		k, v, _ := strings.Cut(l, "=")
+		out[k] = v
+	}
+	return out
+}
+
+// flowPort returns the L4 protocol and destination port of a flow.
+func flowPort(fl *Flow) (string, int32) {
+	if fl.L4 == nil {
+		return "", 0
+	}
+	switch {
+	case fl.L4.TCP != nil:
+		return "TCP", int32(fl.L4.TCP.DestinationPort)
+	case fl.L4.UDP != nil:
+		return "UDP", int32(fl.L4.UDP.DestinationPort)
+	case fl.L4.SCTP != nil:
+		return "SCTP", int32(fl.L4.SCTP.DestinationPort)
+	}
+	return "", 0
+}
@@ -0,0 +1,229 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2026 The Cozystack Authors.
+
+package securitygroupcontroller
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	sdnv1alpha1 "github.com/cozystack/cozystack/pkg/apis/sdn/v1alpha1"
+)
+
+type staticFlows []Flow
+
+func (s staticFlows) Flows(context.Context, time.Time) ([]Flow, error) {
+	return s, nil
+}
+
+func endpoint(namespace string, labels ...string) *FlowEndpoint {
+	return &FlowEndpoint{Namespace: namespace, Labels: labels}
+}
+
+func tcp(port uint32) *FlowL4 {
+	return &FlowL4{TCP: &FlowPort{DestinationPort: port}}
+}
+
+func TestFlowCounterAttributesFlows(t *testing.T) {
+	member := "k8s:" + membershipLabelKey("sg-db") + "="
+	web := "k8s:app=web"
+	policy := sg("sg-db", false)
+	policy.Spec = &CiliumNetworkPolicySpec{
+		Ingress: []CiliumIngressRule{
+			{
+				FromEndpoints: []metav1.LabelSelector{{MatchLabels: map[string]string{"app": "web"}}},
+				ToPorts:       []sdnv1alpha1.PortRule{{Ports: []sdnv1alpha1.PortProtocol{{Port: "5432", Protocol: "TCP"}}}},
+			},
+			{FromCIDR: []string{"10.0.0.0/8"}},
+		},
+		Egress: []CiliumEgressRule{
+			{ToFQDNs: []sdnv1alpha1.FQDNSelector{{MatchPattern: "*.example.org"}}},
+		},
+	}
+	start := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	at := start.Add(time.Second)
+	flows := staticFlows{
+		// web -> db:5432, allowed by rule 0.
+		{Time: at, Verdict: "FORWARDED", TrafficDirection: "INGRESS", L4: tcp(5432),
+			Source: endpoint(ns, web), Destination: endpoint(ns, member)},
+		// web -> db:22, dropped; the peers of rule 0 still match.
+		{Time: at, Verdict: "DROPPED", TrafficDirection: "INGRESS", L4: tcp(22),
+			Source: endpoint(ns, web), Destination: endpoint(ns, member)},
+		// A web pod of another namespace is not the peer of rule 0.
+		{Time: at, Verdict: "DROPPED", TrafficDirection: "INGRESS", L4: tcp(5432),
+			IP: &FlowIP{Source: "192.168.0.1"}, Source: endpoint("tenant-other", web), Destination: endpoint(ns, member)},
+		// A CIDR peer, rule 1.
+		{Time: at, Verdict: "FORWARDED", TrafficDirection: "INGRESS", L4: tcp(5432),
+			IP: &FlowIP{Source: "10.1.2.3"}, Source: endpoint(""), Destination: endpoint(ns, member)},
+		// An FQDN peer, egress rule 0.
+		{Time: at, Verdict: "FORWARDED", TrafficDirection: "EGRESS", L4: tcp(443),
+			DestinationNames: []string{"api.example.org."}, Source: endpoint(ns, member), Destination: endpoint("")},
+		// Not a member, not counted.
+		{Time: at, Verdict: "FORWARDED", TrafficDirection: "INGRESS", L4: tcp(5432),
+			Source: endpoint(ns, web), Destination: endpoint(ns, "k8s:app=db")},
+		// Seen by the previous poll.
+		{Time: start, Verdict: "FORWARDED", TrafficDirection: "INGRESS", L4: tcp(5432),
+			Source: endpoint(ns, web), Destination: endpoint(ns, member)},
+	}
+	_, c := newReconciler(t, policy)
+	fc := &FlowCounter{Reader: c, Source: flows, Now: func() time.Time { return start }}
+	if err := fc.Poll(context.Background()); err != nil {
+		t.Fatalf("Poll: %v", err)
+	}
+
+	got := fc.Counters(types.NamespacedName{Namespace: ns, Name: "sg-db"})
+	if got == nil {
+		t.Fatal("no counters")
+	}
+	wantIngress := []sdnv1alpha1.RuleFlowCounter{{Rule: 0, Allowed: 1, Denied: 1}, {Rule: 1, Allowed: 1}}
+	wantEgress := []sdnv1alpha1.RuleFlowCounter{{Rule: 0, Allowed: 1}}
+	if fmt.Sprint(got.Ingress) != fmt.Sprint(wantIngress) || fmt.Sprint(got.Egress) != fmt.Sprint(wantEgress) {
+		t.Errorf("counters = %+v / %+v, want %+v / %+v", got.Ingress, got.Egress, wantIngress, wantEgress)
+	}
+	if !got.Since.Time.Equal(start) {
+		t.Errorf("since = %v, want %v", got.Since, start)
+	}
+	select {
+	case ev := <-fc.channel():
+		if ev.Object.GetName() != "sg-db" {
+			t.Errorf("event for %s, want sg-db", ev.Object.GetName())
+		}
+	default:
+		t.Error("no event for the counted group")
+	}
+
+	// The group is deleted: its counters go.
+	if err := c.Delete(context.Background(), policy); err != nil {
+		t.Fatal(err)
+	}
+	fc.Source = staticFlows(nil)
+	if err := fc.Poll(context.Background()); err != nil {
+		t.Fatalf("Poll: %v", err)
+	}
+	if got := fc.Counters(types.NamespacedName{Namespace: ns, Name: "sg-db"}); got != nil {
+		t.Errorf("counters of a deleted group = %+v", got)
+	}
+}
+
+func TestFlowCounterAttributesDenyRulesFirst(t *testing.T) {
+	member := "k8s:" + membershipLabelKey("sg-db") + "="
+	policy := sg("sg-db", false)
+	policy.Spec = &CiliumNetworkPolicySpec{
+		Ingress: []CiliumIngressRule{{FromCIDR: []string{"10.0.0.0/8"}}},
+		IngressDeny: []CiliumIngressDenyRule{{
+			FromCIDR: []string{"10.66.0.0/16"},
+			ToPorts:  []sdnv1alpha1.PortDenyRule{{Ports: []sdnv1alpha1.PortProtocol{{Port: "5432", Protocol: "TCP"}}}},
+		}},
+	}
+	start := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	from := func(ip string, port uint32, verdict string) Flow {
+		return Flow{Time: start.Add(time.Second), Verdict: verdict, TrafficDirection: "INGRESS", L4: tcp(port),
+			IP: &FlowIP{Source: ip}, Source: endpoint(""), Destination: endpoint(ns, member)}
+	}
+	_, c := newReconciler(t, policy)
+	fc := &FlowCounter{Reader: c, Source: staticFlows{
+		from("10.66.1.1", 5432, "DROPPED"),  // the denied range and port
+		from("10.66.1.1", 443, "FORWARDED"), // the denied range, another port
+		from("10.1.1.1", 5432, "FORWARDED"), // the allowed range
+	}, Now: func() time.Time { return start }}
+	if err := fc.Poll(context.Background()); err != nil {
+		t.Fatalf("Poll: %v", err)
+	}
+	got := fc.Counters(types.NamespacedName{Namespace: ns, Name: "sg-db"})
+	wantDeny := []sdnv1alpha1.RuleFlowCounter{{Rule: 0, Denied: 1}}
+	wantAllow := []sdnv1alpha1.RuleFlowCounter{{Rule: 0, Allowed: 2}}
+	if got == nil || fmt.Sprint(got.IngressDeny) != fmt.Sprint(wantDeny) || fmt.Sprint(got.Ingress) != fmt.Sprint(wantAllow) {
+		t.Errorf("counters = %+v, want ingressDeny %+v and ingress %+v", got, wantDeny, wantAllow)
+	}
+}
+
+func TestFlowCounterAttributesPeeredNamespaceFlows(t *testing.T) {
+	const peer = "tenant-b"
+	member := "k8s:" + membershipLabelKey("sg-db") + "="
+	web := "k8s:app=web"
+	consent := "k8s:" + peerLabelKey(ns) + "="
+	policy := sg("sg-db", false)
+	policy.Spec = &CiliumNetworkPolicySpec{
+		Ingress: []CiliumIngressRule{{FromEndpoints: []metav1.LabelSelector{{MatchLabels: map[string]string{
+			"app": "web", podNamespaceLabel: peer, peerLabelKey(ns): "",
+		}}}}},
+	}
+	start := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	at := start.Add(time.Second)
+	flows := staticFlows{
+		// A consenting web pod of the peered namespace, counted.
+		{Time: at, Verdict: "FORWARDED", TrafficDirection: "INGRESS", L4: tcp(5432),
+			Source: endpoint(peer, web, consent), Destination: endpoint(ns, member)},
+		// The same pod before the peering is established, not the peer.
+		{Time: at, Verdict: "DROPPED", TrafficDirection: "INGRESS", L4: tcp(5432),
+			Source: endpoint(peer, web), Destination: endpoint(ns, member)},
+		// A web pod of the policy's own namespace, not the peer either.
+		{Time: at, Verdict: "FORWARDED", TrafficDirection: "INGRESS", L4: tcp(5432),
+			Source: endpoint(ns, web, consent), Destination: endpoint(ns, member)},
+	}
+	_, c := newReconciler(t, policy)
+	fc := &FlowCounter{Reader: c, Source: flows, Now: func() time.Time { return start }}
+	if err := fc.Poll(context.Background()); err != nil {
+		t.Fatalf("Poll: %v", err)
+	}
+
+	got := fc.Counters(types.NamespacedName{Namespace: ns, Name: "sg-db"})
+	if got == nil {
+		t.Fatal("no counters")
+	}
+	want := []sdnv1alpha1.RuleFlowCounter{{Rule: 0, Allowed: 1}}
+	if fmt.Sprint(got.Ingress) != fmt.Sprint(want) {
+		t.Errorf("ingress counters = %+v, want %+v", got.Ingress, want)
+	}
+}
+
+func TestFlowCounterCountsEachFlowOnce(t *testing.T) {
+	member := "k8s:" + membershipLabelKey("sg-db") + "="
+	policy := sg("sg-db", false)
+	policy.Spec = &CiliumNetworkPolicySpec{Ingress: []CiliumIngressRule{{FromCIDR: []string{"10.0.0.0/8"}}}}
+	start := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	at := func(t time.Time) Flow {
+		return Flow{Time: t, Verdict: "FORWARDED", TrafficDirection: "INGRESS", L4: tcp(5432),
+			IP: &FlowIP{Source: "10.1.1.1"}, Source: endpoint(""), Destination: endpoint(ns, member)}
+	}
+	src := &recordingFlows{flows: []Flow{at(start.Add(time.Second)), at(time.Time{})}}
+	_, c := newReconciler(t, policy)
+	now := start
+	fc := &FlowCounter{Reader: c, Source: src, Now: func() time.Time { return now }}
+
+	// A source returning the same window again, as the relay does for flows
+	// at the boundary, and a flow without a timestamp, counted neither time.
+	for i := 0; i < 2; i++ {
+		if err := fc.Poll(context.Background()); err != nil {
+			t.Fatalf("Poll: %v", err)
+		}
+		now = now.Add(time.Minute)
+	}
+	got := fc.Counters(types.NamespacedName{Namespace: ns, Name: "sg-db"})
+	want := []sdnv1alpha1.RuleFlowCounter{{Rule: 0, Allowed: 1}}
+	if got == nil || fmt.Sprint(got.Ingress) != fmt.Sprint(want) {
+		t.Errorf("counters = %+v, want ingress %+v", got, want)
+	}
+	// The cursor is the newest flow read, not the time of the poll.
+	wantSince := []time.Time{start, start.Add(time.Second)}
+	if fmt.Sprint(src.since) != fmt.Sprint(wantSince) {
+		t.Errorf("polls read since %v, want %v", src.since, wantSince)
+	}
+}
+
+// recordingFlows returns the same flows on every call and records the start
+// of each window asked for.
+type recordingFlows struct {
+	flows []Flow
+	since []time.Time
+}
+
+func (r *recordingFlows) Flows(_ context.Context, since time.Time) ([]Flow, error) {
+	r.since = append(r.since, since)
+	return r.flows, nil
+}
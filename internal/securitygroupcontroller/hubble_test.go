@@ -0,0 +1,210 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2026 The Cozystack Authors.
+
+package securitygroupcontroller
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"reflect"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// relayCodec is the server side of hubbleCodec.
+type relayCodec struct{}
+
+func (relayCodec) Name() string { return "proto" }
+
+func (relayCodec) Marshal(v any) ([]byte, error) {
+	switch m := v.(type) {
+	case []byte:
+		return m, nil
+	case *Flow:
+		b := protowire.AppendTag(nil, responseFlow, protowire.BytesType)
+		return protowire.AppendBytes(b, marshalFlow(m)), nil
+	}
+	return nil, fmt.Errorf("unexpected message %T", v)
+}
+
+func (relayCodec) Unmarshal(data []byte, v any) error {
+	req, ok := v.(*getFlowsRequest)
+	if !ok {
+		return fmt.Errorf("unexpected message %T", v)
+	}
+	return unmarshalFields(data, func(num protowire.Number, _ uint64, b []byte) error {
+		if num != requestSince || b == nil {
+			return nil
+		}
+		var err error
+		req.since, err = unmarshalTimestamp(b)
+		return err
+	})
+}
+
+// serveRelay serves GetFlows with the given handler and returns a client
+// connection to it.
+func serveRelay(t *testing.T, handler func(req *getFlowsRequest, send func(any) error) error) *grpc.ClientConn {
+	t.Helper()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := grpc.NewServer(grpc.ForceServerCodec(relayCodec{}))
+	srv.RegisterService(&grpc.ServiceDesc{
+		ServiceName: "observer.Observer",
+		HandlerType: (*any)(nil),
+		Streams: []grpc.StreamDesc{{
+			StreamName:    "GetFlows",
+			ServerStreams: true,
+			Handler: func(_ any, stream grpc.ServerStream) error {
+				req := &getFlowsRequest{}
+				if err := stream.RecvMsg(req); err != nil {
+					return err
+				}
+				return handler(req, stream.SendMsg)
+			},
+		}},
+	}, struct{}{})
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient(lis.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+	return conn
+}
+
+func TestHubbleFlowSource(t *testing.T) {
+	since := time.Date(2026, 10, 19, 12, 0, 0, 500, time.UTC)
+	want := Flow{
+		Time:             since.Add(time.Second),
+		Verdict:          "DROPPED",
+		TrafficDirection: "EGRESS",
+		IP:               &FlowIP{Source: "10.244.0.5", Destination: "192.0.2.1"},
+		L4:               &FlowL4{UDP: &FlowPort{DestinationPort: 53}},
+		Source:           &FlowEndpoint{Namespace: ns, PodName: "web-0", Labels: []string{"k8s:app=web", "reserved:host"}},
+		Destination:      &FlowEndpoint{},
+		DestinationNames: []string{"api.example.org."},
+	}
+	var gotSince time.Time
+	conn := serveRelay(t, func(req *getFlowsRequest, send func(any) error) error {
+		gotSince = req.since
+		// A node status event: a response without a flow, and a field the
+		// client does not know.
+		status := protowire.AppendTag(nil, 2, protowire.BytesType)
+		status = protowire.AppendBytes(status, nil)
+		status = protowire.AppendTag(status, 1000, protowire.BytesType)
+		status = protowire.AppendBytes(status, []byte("node-1"))
+		if err := send(status); err != nil {
+			return err
+		}
+		return send(&want)
+	})
+
+	flows, err := (&HubbleFlowSource{Conn: conn}).Flows(context.Background(), since)
+	if err != nil {
+		t.Fatalf("Flows: %v", err)
+	}
+	if !gotSince.Equal(since) {
+		t.Errorf("since = %v, want %v", gotSince, since)
+	}
+	if !reflect.DeepEqual(flows, []Flow{want}) {
+		t.Errorf("flows = %+v, want %+v", flows, []Flow{want})
+	}
+}
+
+func TestHubbleFlowSourceError(t *testing.T) {
+	conn := serveRelay(t, func(*getFlowsRequest, func(any) error) error {
+		return fmt.Errorf("no peers")
+	})
+	if _, err := (&HubbleFlowSource{Conn: conn}).Flows(context.Background(), time.Now()); err == nil {
+		t.Error("Flows succeeded on a failed call")
+	}
+}
+
+// marshalFlow is the inverse of unmarshalFlow, for the relay of the tests.
+func marshalFlow(fl *Flow) []byte {
+	var b []byte
+	appendBytes := func(b []byte, num protowire.Number, v []byte) []byte {
+		b = protowire.AppendTag(b, num, protowire.BytesType)
+		return protowire.AppendBytes(b, v)
+	}
+	appendVarint := func(b []byte, num protowire.Number, v uint64) []byte {
+		b = protowire.AppendTag(b, num, protowire.VarintType)
+		return protowire.AppendVarint(b, v)
+	}
+	enum := func(m map[uint64]string, name string) uint64 {
+		for k, v := range m {
+			if v == name {
+				return k
+			}
+		}
+		return 0
+	}
+	endpoint := func(ep *FlowEndpoint) []byte {
+		var b []byte
+		if ep.Namespace != "" {
+			b = appendBytes(b, endpointNamespace, []byte(ep.Namespace))
+		}
+		for _, l := range ep.Labels {
+			b = appendBytes(b, endpointLabels, []byte(l))
+		}
+		if ep.PodName != "" {
+			b = appendBytes(b, endpointPodName, []byte(ep.PodName))
+		}
+		return b
+	}
+	port := func(p *FlowPort) []byte {
+		return appendVarint(nil, portDestination, uint64(p.DestinationPort))
+	}
+
+	if !fl.Time.IsZero() {
+		b = appendBytes(b, flowTime, marshalTimestamp(fl.Time))
+	}
+	if v := enum(verdicts, fl.Verdict); v != 0 {
+		b = appendVarint(b, flowVerdict, v)
+	}
+	if fl.IP != nil {
+		var ip []byte
+		if fl.IP.Source != "" {
+			ip = appendBytes(ip, ipSource, []byte(fl.IP.Source))
+		}
+		if fl.IP.Destination != "" {
+			ip = appendBytes(ip, ipDestination, []byte(fl.IP.Destination))
+		}
+		b = appendBytes(b, flowIP, ip)
+	}
+	if fl.L4 != nil {
+		var l4 []byte
+		switch {
+		case fl.L4.TCP != nil:
+			l4 = appendBytes(l4, l4TCP, port(fl.L4.TCP))
+		case fl.L4.UDP != nil:
+			l4 = appendBytes(l4, l4UDP, port(fl.L4.UDP))
+		case fl.L4.SCTP != nil:
+			l4 = appendBytes(l4, l4SCTP, port(fl.L4.SCTP))
+		}
+		b = appendBytes(b, flowL4, l4)
+	}
+	if fl.Source != nil {
+		b = appendBytes(b, flowSource, endpoint(fl.Source))
+	}
+	if fl.Destination != nil {
+		b = appendBytes(b, flowDestination, endpoint(fl.Destination))
+	}
+	for _, name := range fl.DestinationNames {
+		b = appendBytes(b, flowDestinationNames, []byte(name))
+	}
+	if v := enum(trafficDirections, fl.TrafficDirection); v != 0 {
+		b = appendVarint(b, flowTrafficDirection, v)
+	}
+	return b
+}
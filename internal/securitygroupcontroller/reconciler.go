@@ -19,14 +19,34 @@
 // namespace. That, plus single-key merge patches, keeps a tenant-driven
 // cluster-wide pod-label writer from reaching pods a tenant could not otherwise
 // address.
+//
+// A rule peer in another namespace — allowed through a SecurityGroupPeering —
+// projects to a selector that also requires a consent label, peer.sdn.
+// cozystack.io/<policy namespace>. The controller stamps it onto the peer pods
+// a SecurityGroup selects only while both namespaces have a peering naming
+// the other, and strips it as soon as either withdraws. That label is the one
+// exception to labeling pods of the SecurityGroup's own namespace only: it
+// grants nothing by itself, and only ever lands in a namespace that consented.
+//
+// The controller also resolves the part of a SecurityGroup's status the REST
+// storage cannot see — the pods per attachment and the fromSG/toSG names
+// matching no group — and records it in the policy's status annotation. Given a
+// flow source, it records the per-rule flow counters in a ConfigMap of the
+// namespace instead, since they move with every poll.
 package securitygroupcontroller
 
 import (
 	"context"
 	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -34,10 +54,12 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	sdnv1alpha1 "github.com/cozystack/cozystack/pkg/apis/sdn/v1alpha1"
 )
@@ -52,6 +74,20 @@ const (
 	// of ApplicationReference. It mirrors the constant the REST storage writes.
 	attachmentsAnnotation = "sdn.cozystack.io/attachments"
 
+	// statusAnnotation holds the status the controller resolves, as a JSON
+	// SecurityGroupStatus. It mirrors the constant the REST storage reads; the
+	// storage adds the policy phase from Cilium's Valid condition.
+	statusAnnotation = "sdn.cozystack.io/status"
+
+	// flowsConfigMapName names the ConfigMap of a namespace holding the flow
+	// counters of its SecurityGroups, one JSON SecurityGroupFlows per group
+	// name, and flowsLabelKey marks it. Every change of a CiliumNetworkPolicy
+	// makes the Cilium agents recompute it, so the counters are kept off the
+	// policy. All three mirror the constants the REST storage reads.
+	flowsConfigMapName = "securitygroup-flows"
+	flowsLabelKey      = "sdn.cozystack.io/securitygroup-flows"
+	flowsLabelValue    = "true"
+
 	// membershipFinalizer guards the backing policy so the controller can strip
 	// the membership labels off member pods before the policy disappears. Shared
 	// with the REST storage, which re-asserts it on write.
@@ -66,6 +102,17 @@ const (
 
 	defaultAppGroup = "apps.cozystack.io"
 
+	// peeringLabelKey marks the ConfigMaps backing SecurityGroupPeerings, and
+	// peerNamespaceKey is their data key naming the peer namespace. Both mirror
+	// the constants in pkg/registry/sdn/securitygrouppeering.
+	peeringLabelKey   = "sdn.cozystack.io/securitygrouppeering"
+	peeringLabelValue = "true"
+	peerNamespaceKey  = "peerNamespace"
+
+	// podNamespaceLabel is the label a peer selector names a peered namespace
+	// with. It mirrors the constant in pkg/registry/sdn/securitygroup.
+	podNamespaceLabel = "k8s:io.kubernetes.pod.namespace"
+
 	// managedByLabel marks pods the lineage webhook manages — the only pods that
 	// can ever be SecurityGroup members. The manager caches only these pods.
 	managedByLabel = "internal.cozystack.io/managed-by-cozystack"
@@ -78,14 +125,16 @@ const (
 )
 
 // CacheByObject bounds the manager's informers: pods are cached only when
-// managed by Cozystack (the only pods that can be members) and
-// CiliumNetworkPolicies only when SecurityGroup-owned, keeping the controller's
-// cache small in a busy cluster. A managed pod never loses the managed-by label,
+// managed by Cozystack (the only pods that can be members),
+// CiliumNetworkPolicies only when SecurityGroup-owned and ConfigMaps only when
+// backing a SecurityGroupPeering, keeping the controller's cache small in a
+// busy cluster. A managed pod never loses the managed-by label,
 // so scoping by it cannot hide a pod whose membership must later be removed.
 func CacheByObject() map[client.Object]cache.ByObject {
 	return map[client.Object]cache.ByObject{
 		&corev1.Pod{}:          {Label: labels.SelectorFromSet(labels.Set{managedByLabel: "true"})},
 		&CiliumNetworkPolicy{}: {Label: labels.SelectorFromSet(labels.Set{sgLabelKey: sgLabelValue})},
+		&corev1.ConfigMap{}:    {Label: labels.SelectorFromSet(labels.Set{peeringLabelKey: peeringLabelValue})},
 	}
 }
 
@@ -94,6 +143,12 @@ func membershipLabelKey(name string) string {
 	return sdnv1alpha1.MembershipLabelPrefix + name
 }
 
+// peerLabelKey returns the consent label key the pods of a namespace peered
+// with ns carry while a SecurityGroup of ns selects them.
+func peerLabelKey(ns string) string {
+	return sdnv1alpha1.PeerLabelPrefix + ns
+}
+
 // appLabels projects an ApplicationReference into the lineage labels that select
 // the referenced application's pods. It mirrors the REST storage so the
 // controller resolves attachments to exactly the pods the projection targets.
@@ -125,13 +180,26 @@ func decodeAttachments(ctx context.Context, s string) []sdnv1alpha1.ApplicationR
 	return refs
 }
 
-// Reconciler keeps SecurityGroup membership labels in sync with attachments.
+// Reconciler keeps SecurityGroup membership labels in sync with attachments
+// and records the resolved status.
 type Reconciler struct {
 	client.Client
+
+	// Flows, when set, supplies the per-rule flow counters of the status.
+	Flows *FlowCounter
+
+	// Now returns the current time; time.Now when nil.
+	Now func() time.Time
+
+	mu sync.Mutex
+	// flows holds the encoded counters last recorded per SecurityGroup, so an
+	// unchanged value is not patched again.
+	flows map[types.NamespacedName]string
 }
 
 // +kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch;patch
 // +kubebuilder:rbac:groups=cilium.io,resources=ciliumnetworkpolicies,verbs=get;list;watch;patch
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;patch
 
 // Reconcile brings a single SecurityGroup's membership labels to the desired
 // state: the union of its attachments' pods carries the membership label, and
@@ -152,13 +220,20 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 	ns := cnp.Namespace
 	key := membershipLabelKey(cnp.Name)
 
-	// Deletion: strip the membership label off every member pod, then drop the
-	// finalizer so the policy can be garbage-collected.
+	// Deletion: strip the membership label off every member pod and the flow
+	// counters off the flows ConfigMap, then drop the finalizer so the policy
+	// can be garbage-collected.
 	if !cnp.DeletionTimestamp.IsZero() {
 		if controllerutil.ContainsFinalizer(cnp, membershipFinalizer) {
 			if err := r.stripMembership(ctx, ns, key); err != nil {
 				return ctrl.Result{}, err
 			}
+			if err := r.syncPeerLabels(ctx, ns); err != nil {
+				return ctrl.Result{}, err
+			}
+			if err := r.dropFlows(ctx, req.NamespacedName); err != nil {
+				return ctrl.Result{}, err
+			}
 			if err := r.removeFinalizer(ctx, cnp); err != nil {
 				return ctrl.Result{}, err
 			}
@@ -176,6 +251,7 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 	// directly — is the boundary that stops a SecurityGroup from labeling pods a
 	// tenant could not otherwise select.
 	desired := map[string]struct{}{}
+	var members []sdnv1alpha1.SecurityGroupMember
 	for _, app := range decodeAttachments(ctx, cnp.Annotations[attachmentsAnnotation]) {
 		pods := &corev1.PodList{}
 		if err := r.List(ctx, pods, client.InNamespace(ns), client.MatchingLabels(appLabels(app))); err != nil {
@@ -187,6 +263,7 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 			}
 			desired[pods.Items[i].Name] = struct{}{}
 		}
+		members = append(members, sdnv1alpha1.SecurityGroupMember{Application: app, Pods: int32(len(pods.Items))})
 	}
 
 	// Current members: pods carrying the membership label. Any that are no longer
@@ -204,6 +281,27 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 		}
 	}
 
+	if err := r.syncPeerLabels(ctx, ns); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	unresolved, err := r.unresolvedReferences(ctx, cnp)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	status := sdnv1alpha1.SecurityGroupStatus{
+		Members:              members,
+		UnresolvedReferences: unresolved,
+	}
+	if err := r.writeStatus(ctx, cnp, status); err != nil {
+		return ctrl.Result{}, err
+	}
+	if r.Flows != nil {
+		if err := r.writeFlows(ctx, req.NamespacedName, r.Flows.Counters(req.NamespacedName)); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
 	// Re-queue a periodic resync as a safety net. The pod watch is the prompt
 	// path — a new managed-app pod fires mapPodToSGs and re-reconciles within
 	// milliseconds. But desired/current are computed from the cached lister, so
@@ -217,6 +315,338 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 	return ctrl.Result{RequeueAfter: membershipResyncInterval}, nil
 }
 
+// unresolvedReferences returns the SecurityGroup names the policy's rules
+// select by membership label but that match no SecurityGroup, sorted and
+// without duplicates. A "<namespace>/<name>" reference is unresolved too while
+// the peering with its namespace is not established.
+func (r *Reconciler) unresolvedReferences(ctx context.Context, cnp *CiliumNetworkPolicy) ([]string, error) {
+	refs := referencedGroups(cnp)
+	if len(refs) == 0 {
+		return nil, nil
+	}
+	var peered map[string]bool
+	exists := map[string]map[string]bool{}
+	var out []string
+	for _, ref := range refs {
+		ns, name, qualified := strings.Cut(ref, "/")
+		if !qualified {
+			ns, name = cnp.Namespace, ref
+		} else {
+			if peered == nil {
+				var err error
+				if peered, err = r.peeredNamespaces(ctx, cnp.Namespace); err != nil {
+					return nil, err
+				}
+			}
+			if !peered[ns] {
+				out = append(out, ref)
+				continue
+			}
+		}
+		if exists[ns] == nil {
+			groups := &CiliumNetworkPolicyList{}
+			if err := r.List(ctx, groups, client.InNamespace(ns), client.MatchingLabels{sgLabelKey: sgLabelValue}); err != nil {
+				return nil, err
+			}
+			exists[ns] = map[string]bool{}
+			for i := range groups.Items {
+				exists[ns][groups.Items[i].Name] = true
+			}
+		}
+		if !exists[ns][name] {
+			out = append(out, ref)
+		}
+	}
+	return out, nil
+}
+
+// referencedGroups returns the SecurityGroup names the policy's fromSG/toSG
+// peers resolve to, sorted and without duplicates, a group of a peered
+// namespace as "<namespace>/<name>". A peer selecting exactly one membership
+// label, scoped to a peered namespace by peerSelector or not, is how the REST
+// storage projects them.
+func referencedGroups(cnp *CiliumNetworkPolicy) []string {
+	seen := map[string]bool{}
+	for _, sel := range policySelectors(cnp) {
+		if len(sel.MatchExpressions) != 0 {
+			continue
+		}
+		m, prefix := sel.MatchLabels, ""
+		if peer, rest, ok := peerSelector(cnp.Namespace, sel); ok {
+			m, prefix = rest, peer+"/"
+		}
+		if len(m) != 1 {
+			continue
+		}
+		for k := range m {
+			if name, ok := strings.CutPrefix(k, sdnv1alpha1.MembershipLabelPrefix); ok {
+				seen[prefix+name] = true
+			}
+		}
+	}
+	out := make([]string, 0, len(seen))
+	for name := range seen {
+		out = append(out, name)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// policySelectors returns the peer endpoint selectors of every rule of the
+// policy.
+func policySelectors(cnp *CiliumNetworkPolicy) []metav1.LabelSelector {
+	if cnp.Spec == nil {
+		return nil
+	}
+	var out []metav1.LabelSelector
+	for _, rule := range cnp.Spec.Ingress {
+		out = append(out, rule.FromEndpoints...)
+	}
+	for _, rule := range cnp.Spec.Egress {
+		out = append(out, rule.ToEndpoints...)
+	}
+	for _, rule := range cnp.Spec.IngressDeny {
+		out = append(out, rule.FromEndpoints...)
+	}
+	for _, rule := range cnp.Spec.EgressDeny {
+		out = append(out, rule.ToEndpoints...)
+	}
+	return out
+}
+
+// peerSelector reads a selector of a policy in namespace ns that selects pods
+// of another namespace — as the REST storage projects a peer in a peered
+// namespace — into that namespace and the pod labels it requires besides the
+// consent label. Any other selector yields false.
+func peerSelector(ns string, sel metav1.LabelSelector) (string, map[string]string, bool) {
+	peer, ok := sel.MatchLabels[podNamespaceLabel]
+	if !ok || peer == ns || len(sel.MatchExpressions) != 0 {
+		return "", nil, false
+	}
+	consent := peerLabelKey(ns)
+	if _, ok := sel.MatchLabels[consent]; !ok {
+		return "", nil, false
+	}
+	rest := map[string]string{}
+	for k, v := range sel.MatchLabels {
+		if k != podNamespaceLabel && k != consent {
+			rest[k] = v
+		}
+	}
+	if len(rest) == 0 {
+		return "", nil, false
+	}
+	return peer, rest, true
+}
+
+// peerings returns the namespaces the live SecurityGroupPeerings of ns name.
+func (r *Reconciler) peerings(ctx context.Context, ns string) (map[string]bool, error) {
+	list := &corev1.ConfigMapList{}
+	if err := r.List(ctx, list, client.InNamespace(ns), client.MatchingLabels{peeringLabelKey: peeringLabelValue}); err != nil {
+		return nil, err
+	}
+	out := map[string]bool{}
+	for i := range list.Items {
+		if peer := list.Items[i].Data[peerNamespaceKey]; peer != "" && peer != ns && list.Items[i].DeletionTimestamp.IsZero() {
+			out[peer] = true
+		}
+	}
+	return out, nil
+}
+
+// peeredNamespaces returns the namespaces ns has an established peering with:
+// each has a live SecurityGroupPeering naming the other.
+func (r *Reconciler) peeredNamespaces(ctx context.Context, ns string) (map[string]bool, error) {
+	offered, err := r.peerings(ctx, ns)
+	if err != nil {
+		return nil, err
+	}
+	out := map[string]bool{}
+	for peer := range offered {
+		back, err := r.peerings(ctx, peer)
+		if err != nil {
+			return nil, err
+		}
+		if back[ns] {
+			out[peer] = true
+		}
+	}
+	return out, nil
+}
+
+// syncPeerLabels brings the consent label of ns to the desired state across
+// the cluster: a pod carries it while its namespace and ns have an established
+// peering and a live SecurityGroup of ns selects it as a peer, and no other
+// pod does. It runs on every reconcile of a SecurityGroup of ns, so a peering
+// being withdrawn or a rule being dropped takes the label off.
+func (r *Reconciler) syncPeerLabels(ctx context.Context, ns string) error {
+	key := peerLabelKey(ns)
+	peered, err := r.peeredNamespaces(ctx, ns)
+	if err != nil {
+		return err
+	}
+
+	desired := map[types.NamespacedName]struct{}{}
+	if len(peered) > 0 {
+		cnps := &CiliumNetworkPolicyList{}
+		if err := r.List(ctx, cnps, client.InNamespace(ns), client.MatchingLabels{sgLabelKey: sgLabelValue}); err != nil {
+			return err
+		}
+		for i := range cnps.Items {
+			if !cnps.Items[i].DeletionTimestamp.IsZero() {
+				continue
+			}
+			for _, sel := range policySelectors(&cnps.Items[i]) {
+				peer, match, ok := peerSelector(ns, sel)
+				if !ok || !peered[peer] {
+					continue
+				}
+				pods := &corev1.PodList{}
+				if err := r.List(ctx, pods, client.InNamespace(peer), client.MatchingLabels(match)); err != nil {
+					return err
+				}
+				for j := range pods.Items {
+					if err := r.addMembership(ctx, &pods.Items[j], key); err != nil {
+						return err
+					}
+					desired[types.NamespacedName{Namespace: peer, Name: pods.Items[j].Name}] = struct{}{}
+				}
+			}
+		}
+	}
+
+	current := &corev1.PodList{}
+	if err := r.List(ctx, current, client.HasLabels{key}); err != nil {
+		return err
+	}
+	for i := range current.Items {
+		if _, ok := desired[types.NamespacedName{Namespace: current.Items[i].Namespace, Name: current.Items[i].Name}]; ok {
+			continue
+		}
+		if err := r.removeMembership(ctx, &current.Items[i], key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeStatus records status in the policy's status annotation with a merge
+// patch. The patch is skipped when nothing but the observation time would
+// change, so the update event it causes does not loop back into another write.
+func (r *Reconciler) writeStatus(ctx context.Context, cnp *CiliumNetworkPolicy, status sdnv1alpha1.SecurityGroupStatus) error {
+	var cur sdnv1alpha1.SecurityGroupStatus
+	if s := cnp.Annotations[statusAnnotation]; s != "" {
+		// A malformed value is simply rewritten.
+		_ = json.Unmarshal([]byte(s), &cur)
+	}
+	cur.ObservedAt = nil
+	if cnp.Annotations[statusAnnotation] != "" && reflect.DeepEqual(normalizeStatus(cur), normalizeStatus(status)) {
+		return nil
+	}
+	status.ObservedAt = &metav1.Time{Time: r.now().UTC().Truncate(time.Second)}
+	enc, err := json.Marshal(status)
+	if err != nil {
+		return err
+	}
+	patch := client.MergeFrom(cnp.DeepCopy())
+	if cnp.Annotations == nil {
+		cnp.Annotations = map[string]string{}
+	}
+	cnp.Annotations[statusAnnotation] = string(enc)
+	return r.Patch(ctx, cnp, patch)
+}
+
+// normalizeStatus round-trips a status through JSON, so a freshly built one
+// compares equal to its decoded annotation (empty slices, time precision).
+func normalizeStatus(s sdnv1alpha1.SecurityGroupStatus) sdnv1alpha1.SecurityGroupStatus {
+	var out sdnv1alpha1.SecurityGroupStatus
+	if enc, err := json.Marshal(s); err == nil {
+		_ = json.Unmarshal(enc, &out)
+	}
+	return out
+}
+
+// writeFlows records the flow counters of a SecurityGroup under its name in
+// the flows ConfigMap of its namespace, creating the ConfigMap on the first
+// write; nil counters remove the entry. A value equal to the one last recorded
+// is not written again.
+func (r *Reconciler) writeFlows(ctx context.Context, key types.NamespacedName, flows *sdnv1alpha1.SecurityGroupFlows) error {
+	enc := ""
+	if flows != nil {
+		b, err := json.Marshal(flows)
+		if err != nil {
+			return err
+		}
+		enc = string(b)
+	}
+	r.mu.Lock()
+	prev, ok := r.flows[key]
+	r.mu.Unlock()
+	if ok && prev == enc {
+		return nil
+	}
+	if err := r.patchFlows(ctx, key, enc); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	if r.flows == nil {
+		r.flows = map[types.NamespacedName]string{}
+	}
+	r.flows[key] = enc
+	r.mu.Unlock()
+	return nil
+}
+
+// dropFlows removes the flow counters of a deleted SecurityGroup from the
+// flows ConfigMap of its namespace.
+func (r *Reconciler) dropFlows(ctx context.Context, key types.NamespacedName) error {
+	if err := r.patchFlows(ctx, key, ""); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	delete(r.flows, key)
+	r.mu.Unlock()
+	return nil
+}
+
+// patchFlows sets the entry of a SecurityGroup in the flows ConfigMap of its
+// namespace with a single-key merge patch, or removes it when enc is empty.
+func (r *Reconciler) patchFlows(ctx context.Context, key types.NamespacedName, enc string) error {
+	var value any
+	if enc != "" {
+		value = enc
+	}
+	patch, err := json.Marshal(map[string]any{"data": map[string]any{key.Name: value}})
+	if err != nil {
+		return err
+	}
+	cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: key.Namespace, Name: flowsConfigMapName}}
+	err = r.Patch(ctx, cm, client.RawPatch(types.MergePatchType, patch))
+	if !apierrors.IsNotFound(err) || enc == "" {
+		return client.IgnoreNotFound(err)
+	}
+	cm = &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: key.Namespace,
+			Name:      flowsConfigMapName,
+			Labels:    map[string]string{flowsLabelKey: flowsLabelValue},
+		},
+		Data: map[string]string{key.Name: enc},
+	}
+	if err := r.Create(ctx, cm); !apierrors.IsAlreadyExists(err) {
+		return err
+	}
+	// Another group of the namespace created it first.
+	return r.Patch(ctx, cm, client.RawPatch(types.MergePatchType, patch))
+}
+
+func (r *Reconciler) now() time.Time {
+	if r.Now != nil {
+		return r.Now()
+	}
+	return time.Now()
+}
+
 // stripMembership removes the membership label from every pod that carries it in
 // the namespace. Used on SecurityGroup deletion.
 func (r *Reconciler) stripMembership(ctx context.Context, ns, key string) error {
@@ -232,9 +662,9 @@ func (r *Reconciler) stripMembership(ctx context.Context, ns, key string) error
 	return nil
 }
 
-// addMembership stamps the membership label onto a pod with a single-key merge
-// patch, so it never clobbers another SecurityGroup's label or the pod's lineage
-// labels.
+// addMembership stamps a membership or consent label onto a pod with a
+// single-key merge patch, so it never clobbers another SecurityGroup's label or
+// the pod's lineage labels.
 func (r *Reconciler) addMembership(ctx context.Context, pod *corev1.Pod, key string) error {
 	if _, ok := pod.Labels[key]; ok {
 		return nil
@@ -247,8 +677,8 @@ func (r *Reconciler) addMembership(ctx context.Context, pod *corev1.Pod, key str
 	return r.Patch(ctx, pod, patch)
 }
 
-// removeMembership clears the membership label off a pod with a single-key merge
-// patch.
+// removeMembership clears a membership or consent label off a pod with a
+// single-key merge patch.
 func (r *Reconciler) removeMembership(ctx context.Context, pod *corev1.Pod, key string) error {
 	if _, ok := pod.Labels[key]; !ok {
 		return nil
@@ -278,7 +708,9 @@ func (r *Reconciler) removeFinalizer(ctx context.Context, cnp *CiliumNetworkPoli
 }
 
 // mapPodToSGs maps a managed-app pod to the SecurityGroups whose attachments
-// include that pod's application, so a freshly-created pod is labeled promptly.
+// include that pod's application, so a freshly-created pod is labeled promptly,
+// and to the SecurityGroups of peered namespaces selecting it as a peer, so it
+// gets their consent labels as promptly.
 func (r *Reconciler) mapPodToSGs(ctx context.Context, obj client.Object) []reconcile.Request {
 	pod, ok := obj.(*corev1.Pod)
 	if !ok {
@@ -310,6 +742,108 @@ func (r *Reconciler) mapPodToSGs(ctx context.Context, obj client.Object) []recon
 			}
 		}
 	}
+	return append(reqs, r.mapPodToPeerSGs(ctx, pod)...)
+}
+
+// mapPodToPeerSGs maps a pod to the SecurityGroups of the namespaces its own
+// has an established peering with that select it as a peer.
+func (r *Reconciler) mapPodToPeerSGs(ctx context.Context, pod *corev1.Pod) []reconcile.Request {
+	logger := log.FromContext(ctx)
+	peered, err := r.peeredNamespaces(ctx, pod.Namespace)
+	if err != nil {
+		logger.Error(err, "failed to list SecurityGroup peerings for pod mapping", "pod", pod.Name, "namespace", pod.Namespace)
+		return nil
+	}
+	var reqs []reconcile.Request
+	for peer := range peered {
+		cnps := &CiliumNetworkPolicyList{}
+		if err := r.List(ctx, cnps, client.InNamespace(peer), client.MatchingLabels{sgLabelKey: sgLabelValue}); err != nil {
+			logger.Error(err, "failed to list SecurityGroup policies for pod mapping", "pod", pod.Name, "namespace", peer)
+			continue
+		}
+		for i := range cnps.Items {
+			for _, sel := range policySelectors(&cnps.Items[i]) {
+				if ns, match, ok := peerSelector(peer, sel); ok && ns == pod.Namespace && labels.SelectorFromSet(match).Matches(labels.Set(pod.Labels)) {
+					reqs = append(reqs, reconcile.Request{NamespacedName: types.NamespacedName{
+						Namespace: cnps.Items[i].Namespace,
+						Name:      cnps.Items[i].Name,
+					}})
+					break
+				}
+			}
+		}
+	}
+	return reqs
+}
+
+// mapPolicyToReferrers maps a SecurityGroup that appeared or went away to the
+// SecurityGroups referencing it — in its namespace, or as "<namespace>/<name>"
+// in a namespace its own offers a peering to — so their unresolved references
+// follow.
+func (r *Reconciler) mapPolicyToReferrers(ctx context.Context, obj client.Object) []reconcile.Request {
+	logger := log.FromContext(ctx)
+	ns := obj.GetNamespace()
+	referrers := func(in, ref string) []reconcile.Request {
+		cnps := &CiliumNetworkPolicyList{}
+		if err := r.List(ctx, cnps, client.InNamespace(in), client.MatchingLabels{sgLabelKey: sgLabelValue}); err != nil {
+			logger.Error(err, "failed to list SecurityGroup policies for reference mapping", "namespace", in)
+			return nil
+		}
+		var reqs []reconcile.Request
+		for i := range cnps.Items {
+			if cnps.Items[i].Namespace == ns && cnps.Items[i].Name == obj.GetName() {
+				continue
+			}
+			for _, name := range referencedGroups(&cnps.Items[i]) {
+				if name == ref {
+					reqs = append(reqs, reconcile.Request{NamespacedName: types.NamespacedName{
+						Namespace: cnps.Items[i].Namespace,
+						Name:      cnps.Items[i].Name,
+					}})
+					break
+				}
+			}
+		}
+		return reqs
+	}
+
+	reqs := referrers(ns, obj.GetName())
+	offered, err := r.peerings(ctx, ns)
+	if err != nil {
+		logger.Error(err, "failed to list SecurityGroup peerings for reference mapping", "namespace", ns)
+		return reqs
+	}
+	for peer := range offered {
+		reqs = append(reqs, referrers(peer, ns+"/"+obj.GetName())...)
+	}
+	return reqs
+}
+
+// mapPeeringToSGs maps a SecurityGroupPeering to the SecurityGroups of both
+// namespaces it joins, so their consent labels and unresolved references
+// follow the peering being established or withdrawn.
+func (r *Reconciler) mapPeeringToSGs(ctx context.Context, obj client.Object) []reconcile.Request {
+	cm, ok := obj.(*corev1.ConfigMap)
+	if !ok {
+		return nil
+	}
+	var reqs []reconcile.Request
+	for _, ns := range []string{cm.Namespace, cm.Data[peerNamespaceKey]} {
+		if ns == "" {
+			continue
+		}
+		cnps := &CiliumNetworkPolicyList{}
+		if err := r.List(ctx, cnps, client.InNamespace(ns), client.MatchingLabels{sgLabelKey: sgLabelValue}); err != nil {
+			log.FromContext(ctx).Error(err, "failed to list SecurityGroup policies for peering mapping", "namespace", ns)
+			continue
+		}
+		for i := range cnps.Items {
+			reqs = append(reqs, reconcile.Request{NamespacedName: types.NamespacedName{
+				Namespace: cnps.Items[i].Namespace,
+				Name:      cnps.Items[i].Name,
+			}})
+		}
+	}
 	return reqs
 }
 
@@ -325,15 +859,31 @@ func appMatchesPod(ref sdnv1alpha1.ApplicationReference, podLabels map[string]st
 }
 
 // SetupWithManager wires the controller: it reconciles marked
-// CiliumNetworkPolicies and watches managed-app pods to enqueue the
-// SecurityGroups they belong to.
+// CiliumNetworkPolicies, watches managed-app pods to enqueue the SecurityGroups
+// they belong to or are a peer of, SecurityGroup creations and deletions to
+// enqueue the groups referencing them, and SecurityGroupPeerings to enqueue the
+// groups of both namespaces. With a FlowCounter, the groups whose counters
+// moved are enqueued too.
 func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
 	markerOnly := predicate.NewPredicateFuncs(func(o client.Object) bool {
 		return o.GetLabels()[sgLabelKey] == sgLabelValue
 	})
-	return ctrl.NewControllerManagedBy(mgr).
+	createOrDelete := predicate.Funcs{
+		UpdateFunc:  func(event.UpdateEvent) bool { return false },
+		GenericFunc: func(event.GenericEvent) bool { return false },
+	}
+	b := ctrl.NewControllerManagedBy(mgr).
 		Named("securitygroup-membership").
 		For(&CiliumNetworkPolicy{}, builder.WithPredicates(markerOnly)).
 		Watches(&corev1.Pod{}, handler.EnqueueRequestsFromMapFunc(r.mapPodToSGs)).
-		Complete(r)
+		Watches(&CiliumNetworkPolicy{}, handler.EnqueueRequestsFromMapFunc(r.mapPolicyToReferrers),
+			builder.WithPredicates(markerOnly, createOrDelete)).
+		Watches(&corev1.ConfigMap{}, handler.EnqueueRequestsFromMapFunc(r.mapPeeringToSGs),
+			builder.WithPredicates(predicate.NewPredicateFuncs(func(o client.Object) bool {
+				return o.GetLabels()[peeringLabelKey] == peeringLabelValue
+			})))
+	if r.Flows != nil {
+		b = b.WatchesRawSource(source.Channel(r.Flows.channel(), &handler.EnqueueRequestForObject{}))
+	}
+	return b.Complete(r)
 }
@@ -6,7 +6,9 @@ package securitygroupcontroller
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"testing"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -319,3 +321,276 @@ func TestDecodeAttachments(t *testing.T) {
 		t.Fatalf("decodeAttachments(valid) = %+v, want one Postgres/db ref", got)
 	}
 }
+
+// withPeers sets ingress fromSG-style peers on a policy.
+func withPeers(cnp *CiliumNetworkPolicy, groups ...string) *CiliumNetworkPolicy {
+	rule := CiliumIngressRule{}
+	for _, g := range groups {
+		rule.FromEndpoints = append(rule.FromEndpoints, metav1.LabelSelector{
+			MatchLabels: map[string]string{membershipLabelKey(g): ""},
+		})
+	}
+	cnp.Spec = &CiliumNetworkPolicySpec{Ingress: []CiliumIngressRule{rule}}
+	return cnp
+}
+
+func readStatus(t *testing.T, c client.Client, name string) (sdnv1alpha1.SecurityGroupStatus, string) {
+	t.Helper()
+	cnp := &CiliumNetworkPolicy{}
+	if err := c.Get(context.Background(), types.NamespacedName{Namespace: ns, Name: name}, cnp); err != nil {
+		t.Fatalf("get policy %s: %v", name, err)
+	}
+	var status sdnv1alpha1.SecurityGroupStatus
+	if err := json.Unmarshal([]byte(cnp.Annotations[statusAnnotation]), &status); err != nil {
+		t.Fatalf("decode status annotation %q: %v", cnp.Annotations[statusAnnotation], err)
+	}
+	return status, cnp.ResourceVersion
+}
+
+func TestRecordsStatus(t *testing.T) {
+	db, cache := appRef("Postgres", "db"), appRef("Redis", "cache")
+	r, c := newReconciler(t,
+		withPeers(sg("sg-db", true, db, cache), "sg-web", "sg-gone", "sg-web"),
+		sg("sg-web", true),
+		pod("db-0", ns, db, nil),
+		pod("db-1", ns, db, nil),
+	)
+	doReconcile(t, r, "sg-db")
+
+	status, rv := readStatus(t, c, "sg-db")
+	want := []sdnv1alpha1.SecurityGroupMember{{Application: db, Pods: 2}, {Application: cache, Pods: 0}}
+	if len(status.Members) != 2 || status.Members[0] != want[0] || status.Members[1] != want[1] {
+		t.Errorf("members = %+v, want %+v", status.Members, want)
+	}
+	if len(status.UnresolvedReferences) != 1 || status.UnresolvedReferences[0] != "sg-gone" {
+		t.Errorf("unresolvedReferences = %v, want [sg-gone]", status.UnresolvedReferences)
+	}
+	if status.ObservedAt == nil {
+		t.Error("observedAt not set")
+	}
+	if status.Flows != nil {
+		t.Errorf("flows = %+v without a flow source", status.Flows)
+	}
+
+	// Nothing changed: the status is not rewritten, so the update event of the
+	// patch does not loop.
+	doReconcile(t, r, "sg-db")
+	if _, again := readStatus(t, c, "sg-db"); again != rv {
+		t.Errorf("status rewritten without a change: resourceVersion %s -> %s", rv, again)
+	}
+}
+
+// readFlows returns the flow counters recorded for a group in the flows
+// ConfigMap of the namespace.
+func readFlows(t *testing.T, c client.Client, name string) *sdnv1alpha1.SecurityGroupFlows {
+	t.Helper()
+	cm := &corev1.ConfigMap{}
+	if err := c.Get(context.Background(), types.NamespacedName{Namespace: ns, Name: flowsConfigMapName}, cm); err != nil {
+		t.Fatalf("get flows ConfigMap: %v", err)
+	}
+	if cm.Labels[flowsLabelKey] != flowsLabelValue {
+		t.Errorf("flows ConfigMap labels = %v, want the marker", cm.Labels)
+	}
+	s, ok := cm.Data[name]
+	if !ok {
+		return nil
+	}
+	flows := &sdnv1alpha1.SecurityGroupFlows{}
+	if err := json.Unmarshal([]byte(s), flows); err != nil {
+		t.Fatalf("decode flows of %s %q: %v", name, s, err)
+	}
+	return flows
+}
+
+// Flow counters go to the flows ConfigMap, never the policy: a poll that
+// moves them leaves the policy, which Cilium recomputes on every change,
+// untouched.
+func TestRecordsFlowsOffThePolicy(t *testing.T) {
+	member := "k8s:" + membershipLabelKey("sg-db") + "="
+	policy := sg("sg-db", true, appRef("Postgres", "db"))
+	policy.Spec = &CiliumNetworkPolicySpec{Ingress: []CiliumIngressRule{{FromCIDR: []string{"10.0.0.0/8"}}}}
+	r, c := newReconciler(t, policy)
+	start := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	now := start
+	flow := func() Flow {
+		now = now.Add(time.Second)
+		return Flow{Time: now, Verdict: "FORWARDED", TrafficDirection: "INGRESS", L4: tcp(5432),
+			IP: &FlowIP{Source: "10.1.1.1"}, Source: endpoint(""), Destination: endpoint(ns, member)}
+	}
+	r.Flows = &FlowCounter{Reader: c, Source: staticFlows{flow()}, Now: func() time.Time { return start }}
+	if err := r.Flows.Poll(context.Background()); err != nil {
+		t.Fatalf("Poll: %v", err)
+	}
+	doReconcile(t, r, "sg-db")
+
+	status, rv := readStatus(t, c, "sg-db")
+	if status.Flows != nil {
+		t.Errorf("flows recorded on the policy: %+v", status.Flows)
+	}
+	want := []sdnv1alpha1.RuleFlowCounter{{Rule: 0, Allowed: 1}}
+	if got := readFlows(t, c, "sg-db"); got == nil || fmt.Sprint(got.Ingress) != fmt.Sprint(want) {
+		t.Errorf("recorded flows = %+v, want ingress %+v", got, want)
+	}
+
+	r.Flows.Source = staticFlows{flow()}
+	if err := r.Flows.Poll(context.Background()); err != nil {
+		t.Fatalf("Poll: %v", err)
+	}
+	doReconcile(t, r, "sg-db")
+	if _, again := readStatus(t, c, "sg-db"); again != rv {
+		t.Errorf("policy rewritten for moved counters: resourceVersion %s -> %s", rv, again)
+	}
+	want = []sdnv1alpha1.RuleFlowCounter{{Rule: 0, Allowed: 2}}
+	if got := readFlows(t, c, "sg-db"); got == nil || fmt.Sprint(got.Ingress) != fmt.Sprint(want) {
+		t.Errorf("recorded flows = %+v, want ingress %+v", got, want)
+	}
+
+	// The group is deleted: its entry goes.
+	if err := c.Delete(context.Background(), policy); err != nil {
+		t.Fatalf("delete sg-db: %v", err)
+	}
+	doReconcile(t, r, "sg-db")
+	if got := readFlows(t, c, "sg-db"); got != nil {
+		t.Errorf("flows of a deleted group = %+v", got)
+	}
+}
+
+func TestMapPolicyToReferrers(t *testing.T) {
+	r, _ := newReconciler(t,
+		withPeers(sg("sg-db", true), "sg-web"),
+		withPeers(sg("sg-web", true), "sg-web"),
+		sg("sg-other", true),
+	)
+	reqs := r.mapPolicyToReferrers(context.Background(), sg("sg-web", false))
+	if len(reqs) != 1 || reqs[0].Name != "sg-db" {
+		t.Fatalf("mapPolicyToReferrers = %v, want only sg-db", reqs)
+	}
+}
+
+// peering builds the ConfigMap backing a SecurityGroupPeering in namespace
+// from naming namespace to.
+func peering(from, to string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      to,
+			Namespace: from,
+			Labels:    map[string]string{peeringLabelKey: peeringLabelValue},
+		},
+		Data: map[string]string{peerNamespaceKey: to},
+	}
+}
+
+// withPeerApp adds an egress rule selecting an application of a peered
+// namespace, as the REST storage projects a toApp with a namespace.
+func withPeerApp(cnp *CiliumNetworkPolicy, peer string, ref sdnv1alpha1.ApplicationReference) *CiliumNetworkPolicy {
+	m := appLabels(ref)
+	m[podNamespaceLabel] = peer
+	m[peerLabelKey(cnp.Namespace)] = ""
+	if cnp.Spec == nil {
+		cnp.Spec = &CiliumNetworkPolicySpec{}
+	}
+	cnp.Spec.Egress = append(cnp.Spec.Egress, CiliumEgressRule{ToEndpoints: []metav1.LabelSelector{{MatchLabels: m}}})
+	return cnp
+}
+
+func TestPeerLabelsFollowConsent(t *testing.T) {
+	const peer = "tenant-b"
+	web := appRef("Kubernetes", "web")
+	key := peerLabelKey(ns)
+
+	// Only an offer: the peer's pods are not labeled.
+	r, c := newReconciler(t,
+		withPeerApp(sg("sg-db", true), peer, web),
+		pod("web-0", peer, web, nil),
+		pod("api-0", peer, appRef("Kubernetes", "api"), nil),
+		peering(ns, peer),
+	)
+	doReconcile(t, r, "sg-db")
+	if hasMembership(t, c, "web-0", peer, key) {
+		t.Fatalf("web-0 labeled %q without the peer's consent", key)
+	}
+
+	// The peer accepts: exactly the selected pod is labeled.
+	back := peering(peer, ns)
+	if err := c.Create(context.Background(), back); err != nil {
+		t.Fatalf("create peering: %v", err)
+	}
+	doReconcile(t, r, "sg-db")
+	if !hasMembership(t, c, "web-0", peer, key) {
+		t.Fatalf("web-0 not labeled %q once the peering is established", key)
+	}
+	if hasMembership(t, c, "api-0", peer, key) {
+		t.Fatalf("unselected api-0 labeled %q", key)
+	}
+	status, _ := readStatus(t, c, "sg-db")
+	if len(status.UnresolvedReferences) != 0 {
+		t.Errorf("unresolvedReferences = %v, want none", status.UnresolvedReferences)
+	}
+
+	// The peer withdraws: the label comes off.
+	if err := c.Delete(context.Background(), back); err != nil {
+		t.Fatalf("delete peering: %v", err)
+	}
+	doReconcile(t, r, "sg-db")
+	if hasMembership(t, c, "web-0", peer, key) {
+		t.Fatalf("web-0 kept %q after the peering was withdrawn", key)
+	}
+}
+
+func TestUnresolvedPeerReferences(t *testing.T) {
+	const peered, unpeered = "tenant-b", "tenant-c"
+	cnp := sg("sg-db", true)
+	cnp.Spec = &CiliumNetworkPolicySpec{Ingress: []CiliumIngressRule{{FromEndpoints: []metav1.LabelSelector{
+		{MatchLabels: map[string]string{membershipLabelKey("web"): "", podNamespaceLabel: peered, peerLabelKey(ns): ""}},
+		{MatchLabels: map[string]string{membershipLabelKey("gone"): "", podNamespaceLabel: peered, peerLabelKey(ns): ""}},
+		{MatchLabels: map[string]string{membershipLabelKey("web"): "", podNamespaceLabel: unpeered, peerLabelKey(ns): ""}},
+	}}}}
+	web := sg("web", true)
+	web.Namespace = peered
+	other := sg("web", true)
+	other.Namespace = unpeered
+	r, c := newReconciler(t, cnp, web, other, peering(ns, peered), peering(peered, ns), peering(ns, unpeered))
+	doReconcile(t, r, "sg-db")
+
+	status, _ := readStatus(t, c, "sg-db")
+	want := []string{peered + "/gone", unpeered + "/web"}
+	if len(status.UnresolvedReferences) != 2 || status.UnresolvedReferences[0] != want[0] || status.UnresolvedReferences[1] != want[1] {
+		t.Errorf("unresolvedReferences = %v, want %v", status.UnresolvedReferences, want)
+	}
+}
+
+func TestMapPodToSGsAcrossPeering(t *testing.T) {
+	const peer = "tenant-b"
+	web := appRef("Kubernetes", "web")
+	r, c := newReconciler(t,
+		withPeerApp(sg("sg-db", true), peer, web),
+		peering(ns, peer),
+	)
+
+	// Without the peer's consent its pod enqueues nothing.
+	if reqs := r.mapPodToSGs(context.Background(), pod("web-0", peer, web, nil)); len(reqs) != 0 {
+		t.Fatalf("mapPodToSGs(web-0) = %+v before consent, want none", reqs)
+	}
+
+	if err := c.Create(context.Background(), peering(peer, ns)); err != nil {
+		t.Fatalf("create peering: %v", err)
+	}
+	reqs := r.mapPodToSGs(context.Background(), pod("web-0", peer, web, nil))
+	if len(reqs) != 1 || reqs[0].Namespace != ns || reqs[0].Name != "sg-db" {
+		t.Fatalf("mapPodToSGs(web-0) = %+v, want [%s/sg-db]", reqs, ns)
+	}
+	if reqs := r.mapPodToSGs(context.Background(), pod("api-0", peer, appRef("Kubernetes", "api"), nil)); len(reqs) != 0 {
+		t.Fatalf("mapPodToSGs(api-0) = %+v, want none", reqs)
+	}
+}
+
+func TestMapPeeringToSGs(t *testing.T) {
+	const peer = "tenant-b"
+	theirs := sg("sg-web", true)
+	theirs.Namespace = peer
+	r, _ := newReconciler(t, sg("sg-db", true), theirs)
+	reqs := r.mapPeeringToSGs(context.Background(), peering(ns, peer))
+	if len(reqs) != 2 {
+		t.Fatalf("mapPeeringToSGs = %+v, want the groups of both namespaces", reqs)
+	}
+}
@@ -92,3 +92,31 @@ false
 {{- if .Values.gateway -}}true{{- else -}}false{{- end -}}
 {{- end -}}
 {{- end -}}
+
+{{/*
+  tenant.moduleValues renders the configuration moduleValues gives the module
+  passed as "module", limited to the parameters of the module chart. Anything
+  else the module chart sets itself — images, security contexts — and a
+  tenant must not override it. The TenantModule API rejects other keys; the
+  lists are kept the same as there.
+*/}}
+{{- define "tenant.moduleValues" -}}
+{{- $params := dict
+  "etcd" (list "replicas" "resources" "size" "storageClass" "version")
+  "monitoring" (list "alerta" "grafana" "host" "logsStorages" "metricsStorages" "oidc" "vmagent")
+  "ingress" (list "cloudflareProxy" "proxyProtocol" "replicas" "resources" "resourcesPreset" "whitelist")
+  "gateway" (list "gatewayClassName" "issuer" "l4Listeners" "policies" "tlsPassthroughServices")
+  "seaweedfs" (list "db" "filer" "host" "master" "replicationFactor" "s3" "topology" "volume")
+  "computeplane" (list "nodeGroups")
+-}}
+{{- $values := index .root.Values.moduleValues .module | default dict -}}
+{{- $out := dict -}}
+{{- range $key := index $params .module -}}
+{{- if hasKey $values $key -}}
+{{- $_ := set $out $key (index $values $key) -}}
+{{- end -}}
+{{- end -}}
+{{- if $out -}}
+{{- toYaml $out -}}
+{{- end -}}
+{{- end -}}
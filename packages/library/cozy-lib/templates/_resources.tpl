@@ -195,7 +195,8 @@
   This is a helper function that takes an argument like `list "limits" "services.loadbalancers"`
   or `list "limits" "storage"` or `list "requests" "cpu"` and returns "services.loadbalancers",
   "", and "requests.cpu", respectively, thus transforming them to an acceptable format for k8s
-  ResourceQuotas objects.
+  ResourceQuotas objects. Object count keys (`count/<resource>.<group>`) are returned as-is for
+  "limits" and dropped for "requests".
 */}}
 {{- define "cozy-lib.resources.flattenResource" }}
 {{-   $rawQuotaKeys := list
@@ -215,6 +216,12 @@
 {{-   $out := "" }}
 {{-   if and (eq $section "limits") (eq $type "storage") }}
 {{-     $out = "" }}
+{{-   else if hasPrefix "count/" $type }}
+{{- /* Object count quotas, e.g. count/postgreses.apps.cozystack.io, are
+       emitted once, verbatim. */}}
+{{-     if eq $section "limits" }}
+{{-       $out = $type }}
+{{-     end }}
 {{-   else if and (eq $section "limits") (has $type $rawQuotaKeys) }}
 {{-     $out = $type }}
 {{-   else if not (has $type $rawQuotaKeys) }}
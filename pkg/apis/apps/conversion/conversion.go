@@ -0,0 +1,403 @@
+/*
+Copyright 2026 The Cozystack Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package conversion applies the declarative spec conversion rules of an
+// ApplicationDefinition version. An Application served under a version whose
+// schema differs from the chart values is converted into values on write and
+// back into the version's shape on read:
+//
+//   - Move relocates a value to another literal path;
+//   - Rename changes the last key of a path, keeping its parent;
+//   - Default sets a value when it is absent (one-way: reads keep it);
+//   - MapValues replaces scalar values through a lookup table, e.g. the
+//     presets.LegacyMapping resourcesPreset table.
+//
+// Rules run in order towards the values and are inverted in reverse order
+// towards the version, so a Rename followed by a MapValues on the renamed key
+// reads back cleanly.
+package conversion
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+
+	"github.com/cozystack/cozystack/pkg/config"
+)
+
+// Rule types, matching ApplicationDefinitionConversionType.
+const (
+	TypeMove      = "Move"
+	TypeRename    = "Rename"
+	TypeDefault   = "Default"
+	TypeMapValues = "MapValues"
+)
+
+const (
+	anyKey   = "*"
+	anyDepth = "**"
+)
+
+// Converter converts Application specs of one version to and from chart
+// values. A nil *Converter is the identity conversion.
+type Converter struct {
+	forward []rule
+	reverse []rule
+}
+
+// rule is a compiled conversion step. Exactly one of the operation fields is
+// meaningful, depending on typ.
+type rule struct {
+	typ    string
+	path   []string
+	to     []string
+	value  []byte
+	values map[string]string
+}
+
+// New compiles rules, returning nil for an empty rule set so callers can skip
+// conversion entirely. Errors name the offending rule by index.
+func New(rules []config.ConversionRule) (*Converter, error) {
+	if len(rules) == 0 {
+		return nil, nil
+	}
+	c := &Converter{}
+	for i, in := range rules {
+		r, err := compile(in)
+		if err != nil {
+			return nil, fmt.Errorf("conversion[%d] (%s %q): %w", i, in.Type, in.Path, err)
+		}
+		c.forward = append(c.forward, r)
+	}
+	for i := len(c.forward) - 1; i >= 0; i-- {
+		if inv, ok := c.forward[i].inverse(); ok {
+			c.reverse = append(c.reverse, inv)
+		}
+	}
+	return c, nil
+}
+
+func compile(in config.ConversionRule) (rule, error) {
+	path, err := splitPath(in.Path)
+	if err != nil {
+		return rule{}, err
+	}
+	r := rule{typ: in.Type, path: path}
+	if isWildcard(path[len(path)-1]) {
+		return rule{}, fmt.Errorf("path must end with a key name")
+	}
+	switch in.Type {
+	case TypeMove:
+		if hasWildcard(path) {
+			return rule{}, fmt.Errorf("path must not contain wildcards")
+		}
+		to, err := splitPath(in.To)
+		if err != nil {
+			return rule{}, fmt.Errorf("to: %w", err)
+		}
+		if hasWildcard(to) {
+			return rule{}, fmt.Errorf("to must not contain wildcards")
+		}
+		if isPrefix(path, to) || isPrefix(to, path) {
+			return rule{}, fmt.Errorf("path and to must not contain one another")
+		}
+		r.to = to
+	case TypeRename:
+		if in.To == "" || strings.Contains(in.To, ".") || isWildcard(in.To) {
+			return rule{}, fmt.Errorf("to must be a single key name, got %q", in.To)
+		}
+		if in.To == path[len(path)-1] {
+			return rule{}, fmt.Errorf("to must differ from the renamed key")
+		}
+		r.to = []string{in.To}
+	case TypeDefault:
+		if len(in.Value) == 0 || !json.Valid(in.Value) {
+			return rule{}, fmt.Errorf("value must be valid JSON")
+		}
+		r.value = in.Value
+	case TypeMapValues:
+		if len(in.Values) == 0 {
+			return rule{}, fmt.Errorf("values must not be empty")
+		}
+		r.values = in.Values
+	default:
+		return rule{}, fmt.Errorf("unknown rule type")
+	}
+	return r, nil
+}
+
+// inverse returns the rule undoing r on read, or false for one-way rules.
+func (r rule) inverse() (rule, bool) {
+	switch r.typ {
+	case TypeMove:
+		return rule{typ: TypeMove, path: r.to, to: r.path}, true
+	case TypeRename:
+		parent := r.path[:len(r.path)-1]
+		path := append(append([]string{}, parent...), r.to[0])
+		return rule{typ: TypeRename, path: path, to: []string{r.path[len(r.path)-1]}}, true
+	case TypeMapValues:
+		// Values with several sources cannot be mapped back unambiguously;
+		// they are read as stored.
+		sources := map[string][]string{}
+		for from, to := range r.values {
+			sources[to] = append(sources[to], from)
+		}
+		values := map[string]string{}
+		for to, from := range sources {
+			if len(from) == 1 {
+				values[to] = from[0]
+			}
+		}
+		if len(values) == 0 {
+			return rule{}, false
+		}
+		return rule{typ: TypeMapValues, path: r.path, values: values}, true
+	default:
+		return rule{}, false
+	}
+}
+
+// ToValues converts a spec of the served version into chart values.
+func (c *Converter) ToValues(spec *apiextv1.JSON) (*apiextv1.JSON, error) {
+	if c == nil {
+		return spec, nil
+	}
+	return apply(spec, c.forward)
+}
+
+// FromValues converts chart values into a spec of the served version.
+func (c *Converter) FromValues(values *apiextv1.JSON) (*apiextv1.JSON, error) {
+	if c == nil {
+		return values, nil
+	}
+	return apply(values, c.reverse)
+}
+
+func apply(in *apiextv1.JSON, rules []rule) (*apiextv1.JSON, error) {
+	var obj map[string]any
+	if in != nil && len(in.Raw) > 0 {
+		if err := json.Unmarshal(in.Raw, &obj); err != nil {
+			return nil, fmt.Errorf("spec must be a JSON object: %w", err)
+		}
+	}
+	if obj == nil {
+		obj = map[string]any{}
+	}
+	for _, r := range rules {
+		if err := r.apply(obj); err != nil {
+			return nil, err
+		}
+	}
+	raw, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	return &apiextv1.JSON{Raw: raw}, nil
+}
+
+func (r rule) apply(root map[string]any) error {
+	switch r.typ {
+	case TypeMove:
+		parent, ok := lookupParent(root, r.path)
+		if !ok {
+			return nil
+		}
+		key := r.path[len(r.path)-1]
+		v, ok := parent[key]
+		if !ok {
+			return nil
+		}
+		delete(parent, key)
+		pruneEmpty(root, r.path[:len(r.path)-1])
+		dst, err := ensureParent(root, r.to)
+		if err != nil {
+			return err
+		}
+		dst[r.to[len(r.to)-1]] = v
+	case TypeRename:
+		for _, t := range matches(root, r.path) {
+			if v, ok := t.parent[t.key]; ok {
+				delete(t.parent, t.key)
+				t.parent[r.to[0]] = v
+			}
+		}
+	case TypeDefault:
+		if !hasWildcard(r.path) {
+			if _, err := ensureParent(root, r.path); err != nil {
+				return err
+			}
+		}
+		for _, t := range matches(root, r.path) {
+			if _, ok := t.parent[t.key]; ok {
+				continue
+			}
+			var v any
+			if err := json.Unmarshal(r.value, &v); err != nil {
+				return err
+			}
+			t.parent[t.key] = v
+		}
+	case TypeMapValues:
+		for _, t := range matches(root, r.path) {
+			s, ok := t.parent[t.key].(string)
+			if !ok {
+				continue
+			}
+			if mapped, ok := r.values[s]; ok {
+				t.parent[t.key] = mapped
+			}
+		}
+	}
+	return nil
+}
+
+// target is an object holding a matched key.
+type target struct {
+	parent map[string]any
+	key    string
+}
+
+// matches resolves a path with wildcards to the objects holding its last
+// key. Matches are collected before any rule mutates the tree.
+func matches(root map[string]any, path []string) []target {
+	var out []target
+	var walk func(node any, segs []string)
+	walk = func(node any, segs []string) {
+		if len(segs) == 1 {
+			if m, ok := node.(map[string]any); ok {
+				out = append(out, target{parent: m, key: segs[0]})
+			}
+			return
+		}
+		switch segs[0] {
+		case anyKey:
+			forEachChild(node, func(child any) { walk(child, segs[1:]) })
+		case anyDepth:
+			walk(node, segs[1:])
+			forEachChild(node, func(child any) { walk(child, segs) })
+		default:
+			if m, ok := node.(map[string]any); ok {
+				if child, ok := m[segs[0]]; ok {
+					walk(child, segs[1:])
+				}
+			}
+		}
+	}
+	walk(root, path)
+	return out
+}
+
+func forEachChild(node any, fn func(any)) {
+	switch n := node.(type) {
+	case map[string]any:
+		for _, child := range n {
+			fn(child)
+		}
+	case []any:
+		for _, child := range n {
+			fn(child)
+		}
+	}
+}
+
+// lookupParent returns the object holding the last key of a literal path.
+func lookupParent(root map[string]any, path []string) (map[string]any, bool) {
+	cur := root
+	for _, seg := range path[:len(path)-1] {
+		next, ok := cur[seg].(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		cur = next
+	}
+	return cur, true
+}
+
+// ensureParent creates the objects leading to the last key of a literal
+// path and returns the innermost one.
+func ensureParent(root map[string]any, path []string) (map[string]any, error) {
+	cur := root
+	for i, seg := range path[:len(path)-1] {
+		switch next := cur[seg].(type) {
+		case map[string]any:
+			cur = next
+		case nil:
+			m := map[string]any{}
+			cur[seg] = m
+			cur = m
+		default:
+			return nil, fmt.Errorf("cannot set %s: %s is not an object", strings.Join(path, "."), strings.Join(path[:i+1], "."))
+		}
+	}
+	return cur, nil
+}
+
+// pruneEmpty removes the objects along a literal path, innermost first, that
+// were left empty by a Move, so that moving a field out and back in again
+// does not leave empty parents behind.
+func pruneEmpty(root map[string]any, path []string) {
+	for n := len(path); n > 0; n-- {
+		parent, ok := lookupParent(root, path[:n])
+		if !ok {
+			return
+		}
+		m, ok := parent[path[n-1]].(map[string]any)
+		if !ok || len(m) > 0 {
+			return
+		}
+		delete(parent, path[n-1])
+	}
+}
+
+func splitPath(p string) ([]string, error) {
+	if p == "" {
+		return nil, fmt.Errorf("path must not be empty")
+	}
+	segs := strings.Split(p, ".")
+	for _, s := range segs {
+		if s == "" {
+			return nil, fmt.Errorf("path %q has an empty segment", p)
+		}
+	}
+	return segs, nil
+}
+
+func isWildcard(seg string) bool {
+	return seg == anyKey || seg == anyDepth
+}
+
+func hasWildcard(path []string) bool {
+	for _, s := range path {
+		if isWildcard(s) {
+			return true
+		}
+	}
+	return false
+}
+
+func isPrefix(prefix, path []string) bool {
+	if len(prefix) > len(path) {
+		return false
+	}
+	for i := range prefix {
+		if prefix[i] != path[i] {
+			return false
+		}
+	}
+	return true
+}
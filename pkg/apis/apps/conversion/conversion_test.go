@@ -0,0 +1,188 @@
+/*
+Copyright 2026 The Cozystack Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package conversion
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+
+	"github.com/cozystack/cozystack/pkg/apis/apps/presets"
+	"github.com/cozystack/cozystack/pkg/config"
+)
+
+func mustNew(t *testing.T, rules ...config.ConversionRule) *Converter {
+	t.Helper()
+	c, err := New(rules)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	return c
+}
+
+func convert(t *testing.T, fn func(*apiextv1.JSON) (*apiextv1.JSON, error), in string) map[string]any {
+	t.Helper()
+	out, err := fn(&apiextv1.JSON{Raw: []byte(in)})
+	if err != nil {
+		t.Fatalf("convert %s: %v", in, err)
+	}
+	var m map[string]any
+	if err := json.Unmarshal(out.Raw, &m); err != nil {
+		t.Fatalf("unmarshal %s: %v", out.Raw, err)
+	}
+	return m
+}
+
+func decode(t *testing.T, s string) map[string]any {
+	t.Helper()
+	var m map[string]any
+	if err := json.Unmarshal([]byte(s), &m); err != nil {
+		t.Fatalf("unmarshal %s: %v", s, err)
+	}
+	return m
+}
+
+func TestConverter_RoundTrip(t *testing.T) {
+	cases := []struct {
+		name   string
+		rules  []config.ConversionRule
+		spec   string
+		values string
+	}{
+		{
+			name:   "move into nested object",
+			rules:  []config.ConversionRule{{Type: TypeMove, Path: "replicas", To: "cluster.replicas"}},
+			spec:   `{"replicas":3,"size":"10Gi"}`,
+			values: `{"cluster":{"replicas":3},"size":"10Gi"}`,
+		},
+		{
+			name:   "move prunes emptied parents",
+			rules:  []config.ConversionRule{{Type: TypeMove, Path: "backup.s3.bucket", To: "backupBucket"}},
+			spec:   `{"backup":{"s3":{"bucket":"b"}}}`,
+			values: `{"backupBucket":"b"}`,
+		},
+		{
+			name:   "rename under wildcard",
+			rules:  []config.ConversionRule{{Type: TypeRename, Path: "users.*.pass", To: "password"}},
+			spec:   `{"users":{"a":{"pass":"x"},"b":{"pass":"y"}}}`,
+			values: `{"users":{"a":{"password":"x"},"b":{"password":"y"}}}`,
+		},
+		{
+			name: "rename then remap the renamed key",
+			rules: []config.ConversionRule{
+				{Type: TypeRename, Path: "preset", To: "resourcesPreset"},
+				{Type: TypeMapValues, Path: "resourcesPreset", Values: map[string]string{"small": "t1.small"}},
+			},
+			spec:   `{"preset":"small"}`,
+			values: `{"resourcesPreset":"t1.small"}`,
+		},
+		{
+			name:   "missing fields are left alone",
+			rules:  []config.ConversionRule{{Type: TypeMove, Path: "replicas", To: "cluster.replicas"}},
+			spec:   `{"size":"10Gi"}`,
+			values: `{"size":"10Gi"}`,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := mustNew(t, tc.rules...)
+			if got, want := convert(t, c.ToValues, tc.spec), decode(t, tc.values); !reflect.DeepEqual(got, want) {
+				t.Errorf("ToValues = %v, want %v", got, want)
+			}
+			if got, want := convert(t, c.FromValues, tc.values), decode(t, tc.spec); !reflect.DeepEqual(got, want) {
+				t.Errorf("FromValues = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+// TestConverter_LegacyPresets pins the motivating use case: the
+// presets.LegacyMapping table expressed as a MapValues rule rewrites every
+// resourcesPreset in the tree and reads back as the legacy name.
+func TestConverter_LegacyPresets(t *testing.T) {
+	c := mustNew(t, config.ConversionRule{Type: TypeMapValues, Path: "**.resourcesPreset", Values: presets.LegacyMapping})
+
+	got := convert(t, c.ToValues, `{"resourcesPreset":"nano","keeper":{"resourcesPreset":"2xlarge"},"shards":[{"resourcesPreset":"u1.large"}]}`)
+	want := decode(t, `{"resourcesPreset":"t1.nano","keeper":{"resourcesPreset":"c1.xlarge"},"shards":[{"resourcesPreset":"u1.large"}]}`)
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("ToValues = %v, want %v", got, want)
+	}
+	back := convert(t, c.FromValues, `{"resourcesPreset":"t1.nano","keeper":{"resourcesPreset":"c1.xlarge"}}`)
+	if back["resourcesPreset"] != "nano" || back["keeper"].(map[string]any)["resourcesPreset"] != "2xlarge" {
+		t.Fatalf("FromValues = %v, want legacy names back", back)
+	}
+}
+
+func TestConverter_DefaultIsOneWay(t *testing.T) {
+	c := mustNew(t, config.ConversionRule{Type: TypeDefault, Path: "storage.class", Value: []byte(`"replicated"`)})
+
+	if got := convert(t, c.ToValues, `{}`); !reflect.DeepEqual(got, decode(t, `{"storage":{"class":"replicated"}}`)) {
+		t.Errorf("ToValues on empty spec = %v", got)
+	}
+	if got := convert(t, c.ToValues, `{"storage":{"class":"local"}}`); !reflect.DeepEqual(got, decode(t, `{"storage":{"class":"local"}}`)) {
+		t.Errorf("ToValues overwrote an explicit value: %v", got)
+	}
+	if got := convert(t, c.FromValues, `{"storage":{"class":"replicated"}}`); !reflect.DeepEqual(got, decode(t, `{"storage":{"class":"replicated"}}`)) {
+		t.Errorf("FromValues dropped a defaulted value: %v", got)
+	}
+}
+
+func TestConverter_AmbiguousMapValuesReadAsStored(t *testing.T) {
+	c := mustNew(t, config.ConversionRule{Type: TypeMapValues, Path: "tier", Values: map[string]string{"s": "small", "sm": "small", "l": "large"}})
+	if got := convert(t, c.FromValues, `{"tier":"small"}`); got["tier"] != "small" {
+		t.Errorf("ambiguous value was mapped back to %v", got["tier"])
+	}
+	if got := convert(t, c.FromValues, `{"tier":"large"}`); got["tier"] != "l" {
+		t.Errorf("unambiguous value read back as %v, want l", got["tier"])
+	}
+}
+
+func TestNew_Invalid(t *testing.T) {
+	cases := []struct {
+		rule config.ConversionRule
+		want string
+	}{
+		{config.ConversionRule{Type: TypeMove, Path: "a.*", To: "b"}, "end with a key name"},
+		{config.ConversionRule{Type: TypeMove, Path: "*.a", To: "b"}, "must not contain wildcards"},
+		{config.ConversionRule{Type: TypeMove, Path: "a", To: "a.b"}, "contain one another"},
+		{config.ConversionRule{Type: TypeRename, Path: "a", To: "b.c"}, "single key name"},
+		{config.ConversionRule{Type: TypeDefault, Path: "a", Value: []byte(`{`)}, "valid JSON"},
+		{config.ConversionRule{Type: TypeMapValues, Path: "a"}, "must not be empty"},
+		{config.ConversionRule{Type: "Drop", Path: "a"}, "unknown rule type"},
+		{config.ConversionRule{Type: TypeRename, Path: "a..b", To: "c"}, "empty segment"},
+	}
+	for _, tc := range cases {
+		_, err := New([]config.ConversionRule{tc.rule})
+		if err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("New(%+v) error = %v, want it to contain %q", tc.rule, err, tc.want)
+		}
+	}
+}
+
+func TestNew_EmptyIsIdentity(t *testing.T) {
+	c, err := New(nil)
+	if err != nil || c != nil {
+		t.Fatalf("New(nil) = %v, %v; want nil, nil", c, err)
+	}
+	in := &apiextv1.JSON{Raw: []byte(`{"a":1}`)}
+	if out, _ := c.ToValues(in); out != in {
+		t.Errorf("nil converter did not pass the spec through")
+	}
+}
@@ -25,5 +25,5 @@ import (
 // Install registers the API group and adds types to a scheme
 func Install(scheme *runtime.Scheme) {
 	utilruntime.Must(appsv1alpha1.AddToScheme(scheme))
-	utilruntime.Must(scheme.SetVersionPriority(appsv1alpha1.SchemeGroupVersion))
+	utilruntime.Must(scheme.SetVersionPriority(appsv1alpha1.ServedGroupVersions...))
 }
@@ -17,7 +17,7 @@ limitations under the License.
 // Package presets exposes the legacy-to-instance-type mapping used when
 // migrating resourcesPreset values from the flat naming scheme
 // (nano/micro/small/.../2xlarge) to the new <series>.<size> form
-// (t1/c1/s1/u1/m1 × 8 sizes).
+// (t1/c1/s1/u1/m1 × 8 sizes), and the resources every preset allocates.
 //
 // The same table is mirrored in three other places — keep them in sync:
 //   - packages/library/cozy-lib/templates/_resourcepresets.tpl (legacy block)
@@ -0,0 +1,77 @@
+/*
+Copyright 2026 The Cozystack Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package presets
+
+import (
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// seriesMemoryPerCPU is the memory, in MiB, each instance-type series pairs
+// with one CPU (see cozy-lib.resources.unsanitizedPreset).
+var seriesMemoryPerCPU = map[string]int64{
+	"t1": 512,
+	"c1": 1024,
+	"s1": 2048,
+	"u1": 4096,
+	"m1": 8192,
+}
+
+// sizeMilliCPU is the CPU, in millicores, of every instance-type size.
+var sizeMilliCPU = map[string]int64{
+	"nano":    250,
+	"micro":   500,
+	"small":   1000,
+	"medium":  2000,
+	"large":   4000,
+	"xlarge":  8000,
+	"2xlarge": 16000,
+	"4xlarge": 32000,
+}
+
+// legacyResources keeps the original values of the legacy flat names, which
+// do not follow the series ratios.
+var legacyResources = map[string][2]string{
+	"nano":    {"250m", "128Mi"},
+	"micro":   {"500m", "256Mi"},
+	"small":   {"1", "512Mi"},
+	"medium":  {"1", "1Gi"},
+	"large":   {"2", "2Gi"},
+	"xlarge":  {"4", "4Gi"},
+	"2xlarge": {"8", "8Gi"},
+}
+
+// Resources returns the CPU and memory a resourcesPreset value allocates, as
+// the charts render it. Unknown presets report false.
+func Resources(preset string) (cpu, memory resource.Quantity, ok bool) {
+	if legacy, ok := legacyResources[preset]; ok {
+		return resource.MustParse(legacy[0]), resource.MustParse(legacy[1]), true
+	}
+	series, size, found := strings.Cut(preset, ".")
+	if !found {
+		return cpu, memory, false
+	}
+	perCPU, ok1 := seriesMemoryPerCPU[series]
+	milli, ok2 := sizeMilliCPU[size]
+	if !ok1 || !ok2 {
+		return cpu, memory, false
+	}
+	cpu = *resource.NewMilliQuantity(milli, resource.DecimalSI)
+	memory = *resource.NewQuantity(milli*perCPU/1000*1024*1024, resource.BinarySI)
+	return cpu, memory, true
+}
@@ -0,0 +1,61 @@
+/*
+Copyright 2026 The Cozystack Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package presets
+
+import (
+	"os"
+	"regexp"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// TestResourcesMatchCozyLib pins Resources to the preset table the charts
+// render from, so a preset added or resized in cozy-lib without a matching
+// change here fails instead of skewing estimates.
+func TestResourcesMatchCozyLib(t *testing.T) {
+	raw, err := os.ReadFile("../../../../packages/library/cozy-lib/templates/_resourcepresets.tpl")
+	if err != nil {
+		t.Fatalf("read cozy-lib presets: %v", err)
+	}
+	entry := regexp.MustCompile(`"([a-z0-9.]+)"\s+\(dict "cpu" "([^"]+)"\s+"memory" "([^"]+)"`)
+	matches := entry.FindAllStringSubmatch(string(raw), -1)
+	if len(matches) != 47 {
+		t.Fatalf("found %d presets in cozy-lib, want 47 (40 instance types + 7 legacy aliases)", len(matches))
+	}
+	for _, m := range matches {
+		cpu, memory, ok := Resources(m[1])
+		if !ok {
+			t.Errorf("Resources(%q) is unknown", m[1])
+			continue
+		}
+		if want := resource.MustParse(m[2]); cpu.Cmp(want) != 0 {
+			t.Errorf("Resources(%q) cpu = %s, want %s", m[1], cpu.String(), m[2])
+		}
+		if want := resource.MustParse(m[3]); memory.Cmp(want) != 0 {
+			t.Errorf("Resources(%q) memory = %s, want %s", m[1], memory.String(), m[3])
+		}
+	}
+}
+
+func TestResources_Unknown(t *testing.T) {
+	for _, p := range []string{"", "x1.small", "t1.huge", "t1"} {
+		if _, _, ok := Resources(p); ok {
+			t.Errorf("Resources(%q) reported a known preset", p)
+		}
+	}
+}
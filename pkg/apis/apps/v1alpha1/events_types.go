@@ -0,0 +1,70 @@
+/*
+Copyright 2026 The Cozystack Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ApplicationEventList is served by the <plural>/events subresource of every
+// Application kind. It aggregates the Kubernetes Events recorded against the
+// Application's HelmRelease (including the ones Flux emits while reconciling
+// it) and against every object carrying the Application's lineage labels.
+// Items are sorted by last occurrence, oldest first, like `kubectl events`.
+type ApplicationEventList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []ApplicationEvent `json:"items"`
+}
+
+// ApplicationEvent is a single Event attributed to an Application.
+type ApplicationEvent struct {
+	// InvolvedObject is the child object the Event was recorded against.
+	InvolvedObject ApplicationEventObject `json:"involvedObject"`
+	// Type is the Event type (Normal, Warning).
+	// +optional
+	Type string `json:"type,omitempty"`
+	// Reason is the short, machine-readable reason of the Event.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+	// Message is the human-readable description of the Event.
+	// +optional
+	Message string `json:"message,omitempty"`
+	// Source is the component that reported the Event.
+	// +optional
+	Source string `json:"source,omitempty"`
+	// Count is the number of times the Event has occurred.
+	// +optional
+	Count int32 `json:"count,omitempty"`
+	// FirstTimestamp is the time the Event was first recorded.
+	// +optional
+	FirstTimestamp metav1.Time `json:"firstTimestamp,omitempty"`
+	// LastTimestamp is the time of the most recent occurrence of the Event.
+	// +optional
+	LastTimestamp metav1.Time `json:"lastTimestamp,omitempty"`
+}
+
+// ApplicationEventObject identifies the object an ApplicationEvent refers to.
+type ApplicationEventObject struct {
+	APIVersion string `json:"apiVersion,omitempty"`
+	Kind       string `json:"kind,omitempty"`
+	Namespace  string `json:"namespace,omitempty"`
+	Name       string `json:"name"`
+}
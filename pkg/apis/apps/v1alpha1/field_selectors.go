@@ -0,0 +1,91 @@
+/*
+Copyright 2026 The Cozystack Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Field selector keys Applications support besides metadata.name and
+// metadata.namespace.
+const (
+	// StatusVersionField selects on status.version.
+	StatusVersionField = "status.version"
+	// statusConditionsField prefixes status.conditions[<type>], which
+	// selects on the status of the condition of that type.
+	statusConditionsField = "status.conditions"
+	// specFieldPrefix prefixes spec.<path>, which selects on the scalar
+	// value at that path of the spec.
+	specFieldPrefix = "spec."
+)
+
+// ConditionField returns the field selector key of the condition of the
+// given type, e.g. status.conditions[Ready].
+func ConditionField(conditionType string) string {
+	return statusConditionsField + "[" + conditionType + "]"
+}
+
+// ConditionFieldType returns the condition type a status.conditions[<type>]
+// field selector key names.
+func ConditionFieldType(label string) (string, bool) {
+	rest, ok := strings.CutPrefix(label, statusConditionsField+"[")
+	if !ok {
+		return "", false
+	}
+	conditionType, ok := strings.CutSuffix(rest, "]")
+	if !ok || conditionType == "" || strings.ContainsAny(conditionType, "[]") {
+		return "", false
+	}
+	return conditionType, true
+}
+
+// SpecFieldPath returns the path a spec.<path> field selector key names, one
+// element per dot-separated segment.
+func SpecFieldPath(label string) ([]string, bool) {
+	rest, ok := strings.CutPrefix(label, specFieldPrefix)
+	if !ok {
+		return nil, false
+	}
+	path := strings.Split(rest, ".")
+	for _, p := range path {
+		if p == "" {
+			return nil, false
+		}
+	}
+	return path, true
+}
+
+// ApplicationFieldLabelConversion accepts the field selector keys every
+// Application kind supports: metadata.name, metadata.namespace,
+// status.version, status.conditions[<type>] and spec.<path>. Whether a spec
+// path exists and is a scalar depends on the kind's schema and is checked by
+// its storage.
+func ApplicationFieldLabelConversion(label, value string) (string, string, error) {
+	switch label {
+	case "metadata.name", "metadata.namespace", StatusVersionField:
+		return label, value, nil
+	}
+	if _, ok := ConditionFieldType(label); ok {
+		return label, value, nil
+	}
+	if _, ok := SpecFieldPath(label); ok {
+		return label, value, nil
+	}
+	return "", "", fmt.Errorf("%q is not a known field selector: only %q, %q, %q, %q and %q",
+		label, "metadata.name", "metadata.namespace", StatusVersionField, ConditionField("<type>"), specFieldPrefix+"<path>")
+}
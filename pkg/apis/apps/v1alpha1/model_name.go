@@ -53,3 +53,43 @@ func (in ApplicationList) OpenAPIModelName() string {
 func (in ApplicationStatus) OpenAPIModelName() string {
 	return "com.github.cozystack.cozystack.pkg.apis.apps.v1alpha1.ApplicationStatus"
 }
+
+func (in ApplicationEventList) OpenAPIModelName() string {
+	return "com.github.cozystack.cozystack.pkg.apis.apps.v1alpha1.ApplicationEventList"
+}
+
+func (in ApplicationEvent) OpenAPIModelName() string {
+	return "com.github.cozystack.cozystack.pkg.apis.apps.v1alpha1.ApplicationEvent"
+}
+
+func (in ApplicationEventObject) OpenAPIModelName() string {
+	return "com.github.cozystack.cozystack.pkg.apis.apps.v1alpha1.ApplicationEventObject"
+}
+
+func (in ApplicationRender) OpenAPIModelName() string {
+	return "com.github.cozystack.cozystack.pkg.apis.apps.v1alpha1.ApplicationRender"
+}
+
+func (in ApplicationRenderChart) OpenAPIModelName() string {
+	return "com.github.cozystack.cozystack.pkg.apis.apps.v1alpha1.ApplicationRenderChart"
+}
+
+func (in ApplicationResourceEstimate) OpenAPIModelName() string {
+	return "com.github.cozystack.cozystack.pkg.apis.apps.v1alpha1.ApplicationResourceEstimate"
+}
+
+func (in ApplicationCostEstimate) OpenAPIModelName() string {
+	return "com.github.cozystack.cozystack.pkg.apis.apps.v1alpha1.ApplicationCostEstimate"
+}
+
+func (in ApplicationMove) OpenAPIModelName() string {
+	return "com.github.cozystack.cozystack.pkg.apis.apps.v1alpha1.ApplicationMove"
+}
+
+func (in ApplicationMoveSpec) OpenAPIModelName() string {
+	return "com.github.cozystack.cozystack.pkg.apis.apps.v1alpha1.ApplicationMoveSpec"
+}
+
+func (in ApplicationMoveStatus) OpenAPIModelName() string {
+	return "com.github.cozystack.cozystack.pkg.apis.apps.v1alpha1.ApplicationMoveStatus"
+}
@@ -0,0 +1,54 @@
+/*
+Copyright 2026 The Cozystack Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ApplicationMove is the request and response of the <plural>/move
+// subresource of Application kinds that support moving. POSTing it starts
+// moving the Application to spec.targetNamespace; the response reports the
+// cozystack.io ApplicationMove that records and tracks the move.
+type ApplicationMove struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ApplicationMoveSpec `json:"spec"`
+	// +optional
+	Status ApplicationMoveStatus `json:"status,omitempty"`
+}
+
+// ApplicationMoveSpec is the requested move.
+type ApplicationMoveSpec struct {
+	// TargetNamespace is the tenant namespace to move the Application to.
+	TargetNamespace string `json:"targetNamespace"`
+}
+
+// ApplicationMoveStatus reports the started move.
+type ApplicationMoveStatus struct {
+	// Record is the name of the cluster-scoped cozystack.io ApplicationMove
+	// tracking the move.
+	Record string `json:"record,omitempty"`
+	// Data is how the Application's persistent data follows it: None or
+	// Backup.
+	Data string `json:"data,omitempty"`
+	// Phase is the phase of the move when the response was written.
+	Phase string `json:"phase,omitempty"`
+}
@@ -21,6 +21,15 @@ const GroupName = "apps.cozystack.io"
 // SchemeGroupVersion is the canonical {group,version} for v1alpha1.
 var SchemeGroupVersion = schema.GroupVersion{Group: GroupName, Version: "v1alpha1"}
 
+// V1beta1GroupVersion is the second version Application kinds can be served
+// under. Applications carry their spec as opaque JSON, so both versions share
+// the Go types in this package and differ only in the spec shape, which the
+// ApplicationDefinition describes per version (see pkg/apis/apps/conversion).
+var V1beta1GroupVersion = schema.GroupVersion{Group: GroupName, Version: "v1beta1"}
+
+// ServedGroupVersions lists every apps.cozystack.io version, in priority order.
+var ServedGroupVersions = []schema.GroupVersion{SchemeGroupVersion, V1beta1GroupVersion}
+
 // -----------------------------------------------------------------------------
 // Scheme registration helpers
 // -----------------------------------------------------------------------------
@@ -38,9 +47,19 @@ func init() {
 	localSchemeBuilder.Register(addKnownTypes)
 }
 
-// addKnownTypes is called from init().
+// addKnownTypes is called from init(). It registers, in every served
+// version, the kinds that exist independently of the catalog (the payloads of
+// Application subresources); the per-kind Application types are added by
+// RegisterDynamicTypes.
 func addKnownTypes(scheme *runtime.Scheme) error {
-	metav1.AddToGroupVersion(scheme, SchemeGroupVersion)
+	for _, gv := range ServedGroupVersions {
+		scheme.AddKnownTypes(gv,
+			&ApplicationEventList{},
+			&ApplicationRender{},
+			&ApplicationMove{},
+		)
+		metav1.AddToGroupVersion(scheme, gv)
+	}
 	return nil
 }
 
@@ -59,9 +78,19 @@ func RegisterDynamicTypes(scheme *runtime.Scheme, cfg *config.ResourceConfig) er
 	for _, res := range cfg.Resources {
 		kind := res.Application.Kind
 
-		gvk := SchemeGroupVersion.WithKind(kind)
-		scheme.AddKnownTypeWithName(gvk, &Application{})
-		scheme.AddKnownTypeWithName(gvk.GroupVersion().WithKind(kind+"List"), &ApplicationList{})
+		for _, v := range res.Application.ServedVersions() {
+			gvk := schema.GroupVersion{Group: GroupName, Version: v.Name}.WithKind(kind)
+			scheme.AddKnownTypeWithName(gvk, &Application{})
+			if err := scheme.AddFieldLabelConversionFunc(gvk, ApplicationFieldLabelConversion); err != nil {
+				return err
+			}
+		}
+		// Every kind shares ApplicationList, and the endpoint installer
+		// instantiates a version's list by the first kind the scheme knows for
+		// that type, so each list kind is registered in every version.
+		for _, gv := range ServedGroupVersions {
+			scheme.AddKnownTypeWithName(gv.WithKind(kind+"List"), &ApplicationList{})
+		}
 
 		gvkInternal := schema.GroupVersion{Group: GroupName, Version: runtime.APIVersionInternal}.WithKind(kind)
 		scheme.AddKnownTypeWithName(gvkInternal, &Application{})
@@ -0,0 +1,103 @@
+/*
+Copyright 2026 The Cozystack Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ApplicationRender is served by the <plural>/render subresource of every
+// Application kind. It previews what an Application deploys without touching
+// its HelmRelease: GET renders the stored Application, POST renders the
+// Application in the request body after running it through the regular
+// create or update path with dryRun=All.
+type ApplicationRender struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Chart identifies the chart artifact the preview was rendered from.
+	// +optional
+	Chart ApplicationRenderChart `json:"chart,omitempty"`
+	// Objects are the Kubernetes objects the chart renders with the merged
+	// values, in manifest order. Empty when rendering is unavailable; see
+	// Warnings.
+	// +optional
+	// +listType=atomic
+	Objects []runtime.RawExtension `json:"objects,omitempty"`
+	// Estimate is the resource footprint of the Application and, when the
+	// server has prices configured, its cost.
+	Estimate ApplicationResourceEstimate `json:"estimate"`
+	// Warnings explain what the preview could not cover, e.g. a disabled
+	// renderer or objects the estimate does not understand.
+	// +optional
+	// +listType=atomic
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// ApplicationRenderChart identifies a chart artifact.
+type ApplicationRenderChart struct {
+	// Kind, Namespace and Name are the HelmRelease chartRef.
+	Kind      string `json:"kind,omitempty"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name,omitempty"`
+	// Revision is the artifact revision the preview was rendered from.
+	// +optional
+	Revision string `json:"revision,omitempty"`
+}
+
+// ApplicationResourceEstimate is the resource footprint of an Application.
+type ApplicationResourceEstimate struct {
+	// Source tells how the estimate was obtained: "Manifests" when it sums
+	// the rendered objects, "Values" when it follows the replicas, resources,
+	// resourcesPreset and size conventions of the chart values.
+	Source string `json:"source"`
+	// Requests is the total of the container resource requests, multiplied
+	// by replicas.
+	// +optional
+	Requests corev1.ResourceList `json:"requests,omitempty"`
+	// Limits is the total of the container resource limits, multiplied by
+	// replicas.
+	// +optional
+	Limits corev1.ResourceList `json:"limits,omitempty"`
+	// Storage is the total size of the persistent volume claims.
+	// +optional
+	Storage *resource.Quantity `json:"storage,omitempty"`
+	// LoadBalancers is the number of LoadBalancer Services.
+	// +optional
+	LoadBalancers int32 `json:"loadBalancers,omitempty"`
+	// Cost is the monthly cost of the footprint at the server's prices.
+	// +optional
+	Cost *ApplicationCostEstimate `json:"cost,omitempty"`
+}
+
+// ApplicationCostEstimate is a monthly cost, broken down by resource.
+type ApplicationCostEstimate struct {
+	// Currency of every amount, as configured on the server.
+	// +optional
+	Currency string `json:"currency,omitempty"`
+	// Monthly is the total monthly cost, as a decimal string.
+	Monthly string `json:"monthly"`
+	// Breakdown maps cpu, memory, storage and loadBalancers to their share
+	// of Monthly.
+	// +optional
+	Breakdown map[string]string `json:"breakdown,omitempty"`
+}
@@ -29,6 +29,12 @@ var Funcs = func(codecs runtimeserializer.CodecFactory) []interface{} {
 		func(s *v1alpha1.TenantNamespace, c randfill.Continue) {
 			c.FillNoCustom(s) // fill self without calling this function again
 		},
+		func(s *v1alpha1.TenantQuota, c randfill.Continue) {
+			c.FillNoCustom(s) // fill self without calling this function again
+		},
+		func(s *v1alpha1.TenantUsage, c randfill.Continue) {
+			c.FillNoCustom(s) // fill self without calling this function again
+		},
 		func(s *v1alpha1.Option, c randfill.Continue) {
 			c.FillNoCustom(s) // fill self without calling this function again
 		},
@@ -71,6 +71,10 @@ func (in OptionSpec) OpenAPIModelName() string {
 	return "com.github.cozystack.cozystack.pkg.apis.core.v1alpha1.OptionSpec"
 }
 
+func (in TenantApplicationUsage) OpenAPIModelName() string {
+	return "com.github.cozystack.cozystack.pkg.apis.core.v1alpha1.TenantApplicationUsage"
+}
+
 func (in TenantModule) OpenAPIModelName() string {
 	return "com.github.cozystack.cozystack.pkg.apis.core.v1alpha1.TenantModule"
 }
@@ -79,6 +83,10 @@ func (in TenantModuleList) OpenAPIModelName() string {
 	return "com.github.cozystack.cozystack.pkg.apis.core.v1alpha1.TenantModuleList"
 }
 
+func (in TenantModuleSpec) OpenAPIModelName() string {
+	return "com.github.cozystack.cozystack.pkg.apis.core.v1alpha1.TenantModuleSpec"
+}
+
 func (in TenantModuleStatus) OpenAPIModelName() string {
 	return "com.github.cozystack.cozystack.pkg.apis.core.v1alpha1.TenantModuleStatus"
 }
@@ -87,14 +95,90 @@ func (in TenantNamespace) OpenAPIModelName() string {
 	return "com.github.cozystack.cozystack.pkg.apis.core.v1alpha1.TenantNamespace"
 }
 
+func (in TenantNamespaceFeatures) OpenAPIModelName() string {
+	return "com.github.cozystack.cozystack.pkg.apis.core.v1alpha1.TenantNamespaceFeatures"
+}
+
 func (in TenantNamespaceList) OpenAPIModelName() string {
 	return "com.github.cozystack.cozystack.pkg.apis.core.v1alpha1.TenantNamespaceList"
 }
 
+func (in TenantNamespaceQuota) OpenAPIModelName() string {
+	return "com.github.cozystack.cozystack.pkg.apis.core.v1alpha1.TenantNamespaceQuota"
+}
+
+func (in TenantNamespaceStatus) OpenAPIModelName() string {
+	return "com.github.cozystack.cozystack.pkg.apis.core.v1alpha1.TenantNamespaceStatus"
+}
+
+func (in TenantQuota) OpenAPIModelName() string {
+	return "com.github.cozystack.cozystack.pkg.apis.core.v1alpha1.TenantQuota"
+}
+
+func (in TenantQuotaBorrowing) OpenAPIModelName() string {
+	return "com.github.cozystack.cozystack.pkg.apis.core.v1alpha1.TenantQuotaBorrowing"
+}
+
+func (in TenantQuotaDescendants) OpenAPIModelName() string {
+	return "com.github.cozystack.cozystack.pkg.apis.core.v1alpha1.TenantQuotaDescendants"
+}
+
+func (in TenantQuotaList) OpenAPIModelName() string {
+	return "com.github.cozystack.cozystack.pkg.apis.core.v1alpha1.TenantQuotaList"
+}
+
+func (in TenantQuotaStatus) OpenAPIModelName() string {
+	return "com.github.cozystack.cozystack.pkg.apis.core.v1alpha1.TenantQuotaStatus"
+}
+
 func (in TenantSecret) OpenAPIModelName() string {
 	return "com.github.cozystack.cozystack.pkg.apis.core.v1alpha1.TenantSecret"
 }
 
+func (in TenantSecretExternalRef) OpenAPIModelName() string {
+	return "com.github.cozystack.cozystack.pkg.apis.core.v1alpha1.TenantSecretExternalRef"
+}
+
+func (in TenantSecretHistory) OpenAPIModelName() string {
+	return "com.github.cozystack.cozystack.pkg.apis.core.v1alpha1.TenantSecretHistory"
+}
+
 func (in TenantSecretList) OpenAPIModelName() string {
 	return "com.github.cozystack.cozystack.pkg.apis.core.v1alpha1.TenantSecretList"
 }
+
+func (in TenantSecretRotation) OpenAPIModelName() string {
+	return "com.github.cozystack.cozystack.pkg.apis.core.v1alpha1.TenantSecretRotation"
+}
+
+func (in TenantSecretRotationSpec) OpenAPIModelName() string {
+	return "com.github.cozystack.cozystack.pkg.apis.core.v1alpha1.TenantSecretRotationSpec"
+}
+
+func (in TenantSecretRotationStatus) OpenAPIModelName() string {
+	return "com.github.cozystack.cozystack.pkg.apis.core.v1alpha1.TenantSecretRotationStatus"
+}
+
+func (in TenantSecretStatus) OpenAPIModelName() string {
+	return "com.github.cozystack.cozystack.pkg.apis.core.v1alpha1.TenantSecretStatus"
+}
+
+func (in TenantSecretSyncStatus) OpenAPIModelName() string {
+	return "com.github.cozystack.cozystack.pkg.apis.core.v1alpha1.TenantSecretSyncStatus"
+}
+
+func (in TenantSecretVersion) OpenAPIModelName() string {
+	return "com.github.cozystack.cozystack.pkg.apis.core.v1alpha1.TenantSecretVersion"
+}
+
+func (in TenantUsage) OpenAPIModelName() string {
+	return "com.github.cozystack.cozystack.pkg.apis.core.v1alpha1.TenantUsage"
+}
+
+func (in TenantUsageList) OpenAPIModelName() string {
+	return "com.github.cozystack.cozystack.pkg.apis.core.v1alpha1.TenantUsageList"
+}
+
+func (in TenantUsageStatus) OpenAPIModelName() string {
+	return "com.github.cozystack.cozystack.pkg.apis.core.v1alpha1.TenantUsageStatus"
+}
@@ -57,13 +57,19 @@ func RegisterStaticTypes(scheme *runtime.Scheme) {
 	scheme.AddKnownTypes(SchemeGroupVersion,
 		&TenantNamespace{},
 		&TenantNamespaceList{},
+		&TenantQuota{},
+		&TenantQuotaList{},
+		&TenantUsage{},
+		&TenantUsageList{},
 		&TenantSecret{},
 		&TenantSecretList{},
+		&TenantSecretRotation{},
+		&TenantSecretHistory{},
 		&TenantModule{},
 		&TenantModuleList{},
 		&Option{},
 		&OptionList{},
 	)
 	metav1.AddToGroupVersion(scheme, SchemeGroupVersion)
-	klog.V(1).Info("Registered static kinds: TenantNamespace, TenantSecret, TenantModule, Option")
+	klog.V(1).Info("Registered static kinds: TenantNamespace, TenantQuota, TenantUsage, TenantSecret, TenantModule, Option")
 }
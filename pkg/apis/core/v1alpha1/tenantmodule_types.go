@@ -2,6 +2,7 @@
 package v1alpha1
 
 import (
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -15,10 +16,21 @@ type TenantModule struct {
 	// AppVersion represents the version of the Helm chart
 	AppVersion string `json:"appVersion,omitempty"`
 
+	// Spec contains the module configuration
+	Spec TenantModuleSpec `json:"spec,omitempty"`
+
 	// Status contains the module status
 	Status TenantModuleStatus `json:"status,omitempty"`
 }
 
+// TenantModuleSpec configures a TenantModule. Creating a TenantModule enables
+// the module in the values of its tenant, deleting it disables it.
+type TenantModuleSpec struct {
+	// Values override the defaults of the module chart. They are stored in
+	// the moduleValues of the tenant.
+	Values *apiextensionsv1.JSON `json:"values,omitempty"`
+}
+
 // TenantModuleStatus represents the status of a TenantModule
 type TenantModuleStatus struct {
 	// Version represents the last attempted revision
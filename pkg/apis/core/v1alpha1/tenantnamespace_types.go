@@ -8,16 +8,65 @@
 package v1alpha1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
-// TenantNamespace is a thin wrapper around ObjectMeta.  It has no spec/status
-// because it merely reflects an existing Namespace object.
+// TenantNamespace reflects an existing Namespace object.  It has no spec; its
+// status is derived from the namespace's name, the labels the tenant chart
+// stamps on it and the tenant quotas.
 type TenantNamespace struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Status TenantNamespaceStatus `json:"status,omitempty"`
+}
+
+// TenantNamespaceStatus places a tenant namespace in the tenant hierarchy.
+type TenantNamespaceStatus struct {
+	// Parent is the namespace of the parent tenant; empty for the root tenant
+	Parent string `json:"parent,omitempty"`
+	// Path lists the tenant namespaces from the root tenant down to this one,
+	// inclusive
+	Path []string `json:"path,omitempty"`
+	// Depth is the number of tenants above this one: 0 for the root tenant
+	Depth int32 `json:"depth"`
+	// Children lists the namespaces of the direct sub-tenants, sorted
+	Children []string `json:"children,omitempty"`
+	// Host is the domain the tenant's services are published under
+	Host string `json:"host,omitempty"`
+	// Features tells which tenant provides each shared service the tenant
+	// uses
+	Features TenantNamespaceFeatures `json:"features,omitempty"`
+	// Quota summarizes the tenant's quota; see the TenantQuota of the same
+	// name for the full picture
+	// +optional
+	Quota *TenantNamespaceQuota `json:"quota,omitempty"`
+}
+
+// TenantNamespaceFeatures names, for each service a tenant can deploy, the
+// namespace of the tenant whose instance this tenant uses: its own when it
+// deploys one, its nearest ancestor's that does otherwise, empty when none
+// does.
+type TenantNamespaceFeatures struct {
+	Etcd       string `json:"etcd,omitempty"`
+	Monitoring string `json:"monitoring,omitempty"`
+	Ingress    string `json:"ingress,omitempty"`
+	Gateway    string `json:"gateway,omitempty"`
+	Seaweedfs  string `json:"seaweedfs,omitempty"`
+}
+
+// TenantNamespaceQuota is the quota of a tenant namespace, in short.
+type TenantNamespaceQuota struct {
+	// Pool is the namespace of the tenant whose budget this namespace draws
+	// from
+	Pool string `json:"pool,omitempty"`
+	// Allocated is what the namespace may use
+	Allocated corev1.ResourceList `json:"allocated,omitempty"`
+	// Used is the current usage of the namespace
+	Used corev1.ResourceList `json:"used,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -0,0 +1,86 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2026 The Cozystack Authors.
+
+// This file contains the cluster-scoped, read-only “TenantQuota” resource.
+// A TenantQuota is the hierarchical quota picture of the tenant namespace it
+// is named after, as the tenant quota controller enforces it.
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// TenantQuota reports the budget, allocation and usage of a tenant namespace.
+// It has no spec: budgets are declared on the Tenant application.
+type TenantQuota struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Status TenantQuotaStatus `json:"status,omitempty"`
+}
+
+// TenantQuotaStatus is the quota picture of a tenant namespace.
+type TenantQuotaStatus struct {
+	// Parent is the namespace of the parent tenant; empty for the root tenant
+	Parent string `json:"parent,omitempty"`
+	// Pool is the namespace of the tenant whose budget this namespace draws
+	// from: itself when it declares a budget, its nearest ancestor that
+	// declares one otherwise; empty when no tenant up to the root declares one
+	Pool string `json:"pool,omitempty"`
+	// Declared is the budget the tenant declares for its whole sub-tree
+	Declared corev1.ResourceList `json:"declared,omitempty"`
+	// CarvedOut is the part of the budget reserved by sub-tenants declaring
+	// their own; set on tenants that declare a budget
+	CarvedOut corev1.ResourceList `json:"carvedOut,omitempty"`
+	// Overcommitted is how far the reservations of sub-tenants exceed the
+	// budget, per resource
+	Overcommitted corev1.ResourceList `json:"overcommitted,omitempty"`
+	// Allocated is what the namespace may use: its declared budget, clamped
+	// to its share of the pool it draws from
+	Allocated corev1.ResourceList `json:"allocated,omitempty"`
+	// Used is the current usage of the namespace
+	Used corev1.ResourceList `json:"used,omitempty"`
+	// Headroom is what is left of Allocated
+	Headroom corev1.ResourceList `json:"headroom,omitempty"`
+	// Descendants rolls up the tenants below this one
+	Descendants TenantQuotaDescendants `json:"descendants,omitempty"`
+	// Borrowing is the lending state of a tenant that opted in to borrowing
+	// idle budget from its siblings
+	// +optional
+	Borrowing *TenantQuotaBorrowing `json:"borrowing,omitempty"`
+}
+
+// TenantQuotaBorrowing is what a tenant borrows from and lends to the sibling
+// tenants sharing its parent's budget.
+type TenantQuotaBorrowing struct {
+	// Limit is the most the tenant may use beyond its budget
+	Limit corev1.ResourceList `json:"limit,omitempty"`
+	// Allowance is how far beyond its budget the tenant may grow now, given
+	// what its siblings leave idle
+	Allowance corev1.ResourceList `json:"allowance,omitempty"`
+	// Borrowed is how far the tenant currently uses more than its budget
+	Borrowed corev1.ResourceList `json:"borrowed,omitempty"`
+	// Lent is the part of the tenant's idle budget its siblings currently use
+	Lent corev1.ResourceList `json:"lent,omitempty"`
+}
+
+// TenantQuotaDescendants rolls up the sub-tree below a tenant.
+type TenantQuotaDescendants struct {
+	// Tenants is the number of tenants in the sub-tree
+	Tenants int32 `json:"tenants,omitempty"`
+	// Used is the usage of the sub-tree, excluding the tenant's own
+	Used corev1.ResourceList `json:"used,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// TenantQuotaList is the list variant for TenantQuota.
+type TenantQuotaList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []TenantQuota `json:"items"`
+}
@@ -13,8 +13,83 @@ type TenantSecret struct {
 	Type       string            `json:"type,omitempty"`
 	Data       map[string][]byte `json:"data,omitempty"`
 	StringData map[string]string `json:"stringData,omitempty"` // write-only hint
+
+	// ExternalRef makes the TenantSecret a copy of a secret kept in the
+	// external secret store of the tenant, rather than holding data of its
+	// own.
+	// +optional
+	ExternalRef *TenantSecretExternalRef `json:"externalRef,omitempty"`
+
+	// +optional
+	Status TenantSecretStatus `json:"status,omitempty"`
 }
 
+// TenantSecretExternalRef refers to a secret of the external secret store.
+type TenantSecretExternalRef struct {
+	// Path is the path of the secret in the KV engine of the store,
+	// relative to its mount.
+	Path string `json:"path"`
+}
+
+// TenantSecretStatus reports the synchronization of a TenantSecret with the
+// external secret store of the tenant.
+type TenantSecretStatus struct {
+	// Sync is set while the TenantSecret is synchronized with the store.
+	// +optional
+	Sync *TenantSecretSyncStatus `json:"sync,omitempty"`
+}
+
+// TenantSecretSyncStatus reports the synchronization with the store.
+type TenantSecretSyncStatus struct {
+	// Direction is Push for credentials mirrored to the store, Pull for
+	// TenantSecrets copied from it.
+	Direction string `json:"direction"`
+	// Path is the path of the secret in the KV engine of the store.
+	Path string `json:"path,omitempty"`
+	// Phase is Pending until the first synchronization, then Synced or
+	// Failed.
+	Phase string `json:"phase"`
+	// Message explains a Failed phase.
+	// +optional
+	Message string `json:"message,omitempty"`
+	// LastSyncTime is when the secret was last synchronized.
+	// +optional
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
+}
+
+// Synchronization directions and phases of TenantSecretSyncStatus.
+const (
+	TenantSecretSyncPush    = "Push"
+	TenantSecretSyncPull    = "Pull"
+	TenantSecretSyncPending = "Pending"
+	TenantSecretSyncSynced  = "Synced"
+	TenantSecretSyncFailed  = "Failed"
+)
+
+// The external secret store of a tenant is the SecretStore of
+// external-secrets named TenantSecretStoreName in its namespace, rendered by
+// the tenant chart. Its annotations tell which credentials are mirrored to it
+// and where; cozystack-controller reports the synchronization of each Secret
+// in the sync annotations of the Secret, which TenantSecretStatus reflects.
+const (
+	TenantSecretStoreName = "tenant-secrets"
+	// TenantSecretStorePathAnnotation is the path of the store, relative
+	// to the mount, credentials are mirrored under.
+	TenantSecretStorePathAnnotation = "secrets.cozystack.io/path"
+	// TenantSecretStoreMirrorAnnotation lists the Application kinds whose
+	// credentials are mirrored, comma-separated, or * for all of them.
+	TenantSecretStoreMirrorAnnotation = "secrets.cozystack.io/mirror"
+
+	// TenantSecretExternalPathAnnotation holds ExternalRef.Path.
+	TenantSecretExternalPathAnnotation = "secrets.cozystack.io/external-path"
+
+	TenantSecretSyncDirectionAnnotation = "secrets.cozystack.io/sync-direction"
+	TenantSecretSyncPathAnnotation      = "secrets.cozystack.io/sync-path"
+	TenantSecretSyncPhaseAnnotation     = "secrets.cozystack.io/sync-phase"
+	TenantSecretSyncMessageAnnotation   = "secrets.cozystack.io/sync-message"
+	TenantSecretSyncedAtAnnotation      = "secrets.cozystack.io/synced-at"
+)
+
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
 type TenantSecretList struct {
@@ -22,3 +97,85 @@ type TenantSecretList struct {
 	metav1.ListMeta `json:"metadata,omitempty"`
 	Items           []TenantSecret `json:"items"`
 }
+
+// Annotations and labels recording the rotations of a TenantSecret. The
+// chart rendering the Secret opts in to rotation by listing the keys it can
+// apply new values of, comma-separated, in TenantSecretRotatableKeysAnnotation.
+// The current Secret carries the version and rotation time; each previous
+// version is kept as an immutable Secret named <name>.v<version>, labelled
+// with the name of the TenantSecret and annotated with when it expires.
+const (
+	TenantSecretRotatableKeysAnnotation = "secrets.cozystack.io/rotatable-keys"
+	TenantSecretVersionAnnotation       = "secrets.cozystack.io/version"
+	TenantSecretRotatedAtAnnotation     = "secrets.cozystack.io/rotated-at"
+	TenantSecretExpiresAtAnnotation     = "secrets.cozystack.io/expires-at"
+	TenantSecretVersionOfLabel          = "secrets.cozystack.io/version-of"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// TenantSecretRotation is the request and response of the
+// tenantsecrets/rotate subresource. POSTing it generates new values for the
+// keys of a TenantSecret that belongs to an Application, keeps the previous
+// values as a version and asks the Application's HelmRelease to reconcile,
+// so that its chart or operator applies them.
+type TenantSecretRotation struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// +optional
+	Spec TenantSecretRotationSpec `json:"spec,omitempty"`
+	// +optional
+	Status TenantSecretRotationStatus `json:"status,omitempty"`
+}
+
+// TenantSecretRotationSpec is the requested rotation.
+type TenantSecretRotationSpec struct {
+	// Keys are the keys to generate new values for, all of them when empty.
+	// +optional
+	Keys []string `json:"keys,omitempty"`
+	// KeepVersions is how many previous versions are kept, 3 by default.
+	// +optional
+	KeepVersions *int32 `json:"keepVersions,omitempty"`
+	// GracePeriod is how long the previous version stays retrievable, 24h
+	// by default.
+	// +optional
+	GracePeriod *metav1.Duration `json:"gracePeriod,omitempty"`
+}
+
+// TenantSecretRotationStatus reports the rotation.
+type TenantSecretRotationStatus struct {
+	// Version is the version of the new values.
+	Version int64 `json:"version,omitempty"`
+	// RotatedAt is when the values were replaced.
+	RotatedAt metav1.Time `json:"rotatedAt,omitempty"`
+	// Keys are the keys given new values.
+	Keys []string `json:"keys,omitempty"`
+	// HelmRelease is the HelmRelease asked to reconcile, empty if the
+	// Secret is not rendered by one or it is suspended.
+	HelmRelease string `json:"helmRelease,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// TenantSecretHistory is served by the tenantsecrets/versions subresource:
+// the previous versions of a TenantSecret that have not expired yet.
+type TenantSecretHistory struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Versions are the previous versions, newest first.
+	Versions []TenantSecretVersion `json:"versions,omitempty"`
+}
+
+// TenantSecretVersion is a previous version of a TenantSecret.
+type TenantSecretVersion struct {
+	// Version is the number of the version.
+	Version int64 `json:"version"`
+	// RotatedAt is when the version was replaced.
+	RotatedAt metav1.Time `json:"rotatedAt,omitempty"`
+	// ExpiresAt is when the version is deleted.
+	ExpiresAt metav1.Time `json:"expiresAt,omitempty"`
+	// Data holds the values of the version.
+	Data map[string][]byte `json:"data,omitempty"`
+}
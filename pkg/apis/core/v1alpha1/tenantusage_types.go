@@ -0,0 +1,68 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2026 The Cozystack Authors.
+
+// This file contains the cluster-scoped, read-only “TenantUsage” resource.
+// A TenantUsage is the month-to-date resource usage of the tenant namespace it
+// is named after and of its sub-tenants, as accounted in hourly UsageRecords.
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// TenantUsage reports what a tenant and its sub-tenants used since the start
+// of the month, per application. The hourly detail is served by its export
+// subresource, as CSV or JSON.
+type TenantUsage struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Status TenantUsageStatus `json:"status,omitempty"`
+}
+
+// TenantUsageStatus is the usage of a tenant over a period.
+type TenantUsageStatus struct {
+	// Start is the beginning of the period: the start of the month, in UTC
+	Start metav1.Time `json:"start"`
+	// End is when the usage was last sampled
+	End metav1.Time `json:"end"`
+	// Applications is the usage of every application of the tenant and its
+	// sub-tenants, sorted by namespace, kind and name
+	Applications []TenantApplicationUsage `json:"applications,omitempty"`
+	// Total is the usage of the tenant and its sub-tenants, in resource-hours
+	Total corev1.ResourceList `json:"total,omitempty"`
+	// Currency of Cost; empty when no price is configured
+	Currency string `json:"currency,omitempty"`
+	// Cost is the price of Total, as a decimal amount; empty when no price is
+	// configured
+	Cost string `json:"cost,omitempty"`
+}
+
+// TenantApplicationUsage is the usage of one application over the period.
+type TenantApplicationUsage struct {
+	// Namespace of the application
+	Namespace string `json:"namespace"`
+	// Kind of the application, empty for workloads that belong to no
+	// application
+	Kind string `json:"kind,omitempty"`
+	// Name of the application
+	Name string `json:"name,omitempty"`
+	// Usage is the resources the application was allocated, in
+	// resource-hours: 2 cores allocated for half an hour account for cpu: 1
+	Usage corev1.ResourceList `json:"usage"`
+	// Cost is the price of Usage, as a decimal amount
+	Cost string `json:"cost,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// TenantUsageList is the list variant for TenantUsage.
+type TenantUsageList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []TenantUsage `json:"items"`
+}
@@ -59,18 +59,50 @@ func (in ApplicationReference) OpenAPIModelName() string {
 	return "com.github.cozystack.cozystack.pkg.apis.sdn.v1alpha1.ApplicationReference"
 }
 
+func (in EgressDenyRule) OpenAPIModelName() string {
+	return "com.github.cozystack.cozystack.pkg.apis.sdn.v1alpha1.EgressDenyRule"
+}
+
 func (in EgressRule) OpenAPIModelName() string {
 	return "com.github.cozystack.cozystack.pkg.apis.sdn.v1alpha1.EgressRule"
 }
 
+func (in ExplainedSecurityGroup) OpenAPIModelName() string {
+	return "com.github.cozystack.cozystack.pkg.apis.sdn.v1alpha1.ExplainedSecurityGroup"
+}
+
 func (in FQDNSelector) OpenAPIModelName() string {
 	return "com.github.cozystack.cozystack.pkg.apis.sdn.v1alpha1.FQDNSelector"
 }
 
+func (in HTTPRule) OpenAPIModelName() string {
+	return "com.github.cozystack.cozystack.pkg.apis.sdn.v1alpha1.HTTPRule"
+}
+
+func (in IngressDenyRule) OpenAPIModelName() string {
+	return "com.github.cozystack.cozystack.pkg.apis.sdn.v1alpha1.IngressDenyRule"
+}
+
 func (in IngressRule) OpenAPIModelName() string {
 	return "com.github.cozystack.cozystack.pkg.apis.sdn.v1alpha1.IngressRule"
 }
 
+func (in KafkaRule) OpenAPIModelName() string {
+	return "com.github.cozystack.cozystack.pkg.apis.sdn.v1alpha1.KafkaRule"
+}
+
+func (in L7Rules) OpenAPIModelName() string {
+	return "com.github.cozystack.cozystack.pkg.apis.sdn.v1alpha1.L7Rules"
+}
+
+func (in MatchedRule) OpenAPIModelName() string {
+	return "com.github.cozystack.cozystack.pkg.apis.sdn.v1alpha1.MatchedRule"
+}
+
+func (in PortDenyRule) OpenAPIModelName() string {
+	return "com.github.cozystack.cozystack.pkg.apis.sdn.v1alpha1.PortDenyRule"
+}
+
 func (in PortProtocol) OpenAPIModelName() string {
 	return "com.github.cozystack.cozystack.pkg.apis.sdn.v1alpha1.PortProtocol"
 }
@@ -79,14 +111,82 @@ func (in PortRule) OpenAPIModelName() string {
 	return "com.github.cozystack.cozystack.pkg.apis.sdn.v1alpha1.PortRule"
 }
 
+func (in RuleFlowCounter) OpenAPIModelName() string {
+	return "com.github.cozystack.cozystack.pkg.apis.sdn.v1alpha1.RuleFlowCounter"
+}
+
 func (in SecurityGroup) OpenAPIModelName() string {
 	return "com.github.cozystack.cozystack.pkg.apis.sdn.v1alpha1.SecurityGroup"
 }
 
+func (in SecurityGroupExplanation) OpenAPIModelName() string {
+	return "com.github.cozystack.cozystack.pkg.apis.sdn.v1alpha1.SecurityGroupExplanation"
+}
+
+func (in SecurityGroupExplanationSpec) OpenAPIModelName() string {
+	return "com.github.cozystack.cozystack.pkg.apis.sdn.v1alpha1.SecurityGroupExplanationSpec"
+}
+
+func (in SecurityGroupExplanationStatus) OpenAPIModelName() string {
+	return "com.github.cozystack.cozystack.pkg.apis.sdn.v1alpha1.SecurityGroupExplanationStatus"
+}
+
+func (in SecurityGroupFlows) OpenAPIModelName() string {
+	return "com.github.cozystack.cozystack.pkg.apis.sdn.v1alpha1.SecurityGroupFlows"
+}
+
 func (in SecurityGroupList) OpenAPIModelName() string {
 	return "com.github.cozystack.cozystack.pkg.apis.sdn.v1alpha1.SecurityGroupList"
 }
 
+func (in SecurityGroupMember) OpenAPIModelName() string {
+	return "com.github.cozystack.cozystack.pkg.apis.sdn.v1alpha1.SecurityGroupMember"
+}
+
+func (in SecurityGroupPeering) OpenAPIModelName() string {
+	return "com.github.cozystack.cozystack.pkg.apis.sdn.v1alpha1.SecurityGroupPeering"
+}
+
+func (in SecurityGroupPeeringList) OpenAPIModelName() string {
+	return "com.github.cozystack.cozystack.pkg.apis.sdn.v1alpha1.SecurityGroupPeeringList"
+}
+
+func (in SecurityGroupPeeringSpec) OpenAPIModelName() string {
+	return "com.github.cozystack.cozystack.pkg.apis.sdn.v1alpha1.SecurityGroupPeeringSpec"
+}
+
+func (in SecurityGroupPeeringStatus) OpenAPIModelName() string {
+	return "com.github.cozystack.cozystack.pkg.apis.sdn.v1alpha1.SecurityGroupPeeringStatus"
+}
+
+func (in SecurityGroupPolicyStatus) OpenAPIModelName() string {
+	return "com.github.cozystack.cozystack.pkg.apis.sdn.v1alpha1.SecurityGroupPolicyStatus"
+}
+
+func (in SecurityGroupSimulation) OpenAPIModelName() string {
+	return "com.github.cozystack.cozystack.pkg.apis.sdn.v1alpha1.SecurityGroupSimulation"
+}
+
+func (in SecurityGroupSimulationSpec) OpenAPIModelName() string {
+	return "com.github.cozystack.cozystack.pkg.apis.sdn.v1alpha1.SecurityGroupSimulationSpec"
+}
+
+func (in SecurityGroupSimulationStatus) OpenAPIModelName() string {
+	return "com.github.cozystack.cozystack.pkg.apis.sdn.v1alpha1.SecurityGroupSimulationStatus"
+}
+
 func (in SecurityGroupSpec) OpenAPIModelName() string {
 	return "com.github.cozystack.cozystack.pkg.apis.sdn.v1alpha1.SecurityGroupSpec"
 }
+
+func (in SecurityGroupStatus) OpenAPIModelName() string {
+	return "com.github.cozystack.cozystack.pkg.apis.sdn.v1alpha1.SecurityGroupStatus"
+}
+
+func (in SimulationEndpoint) OpenAPIModelName() string {
+	return "com.github.cozystack.cozystack.pkg.apis.sdn.v1alpha1.SimulationEndpoint"
+}
+
+func (in SimulationResult) OpenAPIModelName() string {
+	return "com.github.cozystack.cozystack.pkg.apis.sdn.v1alpha1.SimulationResult"
+}
@@ -36,14 +36,19 @@ func init() {
 	localSchemeBuilder.Register(addKnownTypes)
 }
 
-// addKnownTypes registers the SecurityGroup kinds and group-version meta. It is
-// wired into AddToScheme via the SchemeBuilder, so any scheme built through
-// Install (the apiserver, the roundtrip tests) recognizes the concrete types —
-// not just the server-start path.
+// addKnownTypes registers the SecurityGroup, SecurityGroupPeering,
+// SecurityGroupSimulation and SecurityGroupExplanation kinds and group-version
+// meta. It is wired into AddToScheme via the SchemeBuilder, so any scheme built
+// through Install (the apiserver, the roundtrip tests) recognizes the concrete
+// types — not just the server-start path.
 func addKnownTypes(scheme *runtime.Scheme) error {
 	scheme.AddKnownTypes(SchemeGroupVersion,
 		&SecurityGroup{},
 		&SecurityGroupList{},
+		&SecurityGroupPeering{},
+		&SecurityGroupPeeringList{},
+		&SecurityGroupSimulation{},
+		&SecurityGroupExplanation{},
 	)
 	metav1.AddToGroupVersion(scheme, SchemeGroupVersion)
 	return nil
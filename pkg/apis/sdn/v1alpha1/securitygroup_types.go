@@ -52,6 +52,11 @@ type SecurityGroup struct {
 	// Spec describes the applications this SecurityGroup attaches to and the
 	// traffic it allows.
 	Spec SecurityGroupSpec `json:"spec,omitempty"`
+
+	// Status reports what the SecurityGroup resolves to. It is read-only: the
+	// securitygroup-controller and Cilium maintain it on the backing
+	// CiliumNetworkPolicy.
+	Status SecurityGroupStatus `json:"status,omitempty"`
 }
 
 // SecurityGroupSpec describes the managed applications a SecurityGroup attaches
@@ -72,19 +77,29 @@ type SecurityGroupSpec struct {
 	// only ADDS allowed sources. An empty list adds no allow rules and does NOT
 	// isolate the member pods: effective connectivity is the union of every
 	// policy selecting a pod, including the platform's blanket-allow baseline, so
-	// an empty list leaves ingress open rather than denying it. Actual deny /
-	// default-deny enforcement depends on the default-deny baseline, tracked
-	// separately as future work.
+	// an empty list leaves ingress open rather than denying it. Use IngressDeny
+	// to block specific sources; default-deny enforcement depends on the
+	// default-deny baseline, tracked separately as future work.
 	Ingress []IngressRule `json:"ingress,omitempty"`
 
+	// IngressDeny is the list of rules describing blocked inbound traffic.
+	// Deny rules are evaluated before allow rules: a flow matching any deny rule
+	// of any SecurityGroup (or other policy) selecting the pod is dropped, even
+	// when an allow rule matches it too. Order within the list has no effect.
+	IngressDeny []IngressDenyRule `json:"ingressDeny,omitempty"`
+
 	// Egress is the list of rules describing allowed outbound traffic. Each rule
 	// only ADDS allowed destinations. An empty list adds no allow rules and does
 	// NOT isolate the member pods: effective connectivity is the union of every
 	// policy selecting a pod, including the platform's blanket-allow baseline, so
-	// an empty list leaves egress open rather than denying it. Actual deny /
-	// default-deny enforcement depends on the default-deny baseline, tracked
-	// separately as future work.
+	// an empty list leaves egress open rather than denying it. Use EgressDeny
+	// to block specific destinations; default-deny enforcement depends on the
+	// default-deny baseline, tracked separately as future work.
 	Egress []EgressRule `json:"egress,omitempty"`
+
+	// EgressDeny is the list of rules describing blocked outbound traffic,
+	// evaluated before allow rules like IngressDeny.
+	EgressDeny []EgressDenyRule `json:"egressDeny,omitempty"`
 }
 
 // ApplicationReference identifies a managed Cozystack application by its
@@ -92,6 +107,11 @@ type SecurityGroupSpec struct {
 // fromApp/toApp peers, and resolves to the application's lineage labels
 // (apps.cozystack.io/application.{group,kind,name}).
 type ApplicationReference struct {
+	// Namespace of the referenced application, for a fromApp/toApp peer in a
+	// namespace peered through a SecurityGroupPeering. Empty means the
+	// SecurityGroup's own namespace. Attachments cannot set it.
+	Namespace string `json:"namespace,omitempty"`
+
 	// APIGroup of the referenced application. Defaults to apps.cozystack.io when
 	// empty, the group under which Cozystack serves its managed applications.
 	APIGroup string `json:"apiGroup,omitempty"`
@@ -109,9 +129,11 @@ type IngressRule struct {
 	// applications, by their lineage labels.
 	FromApp []ApplicationReference `json:"fromApp,omitempty"`
 
-	// FromSG selects source pods that are members of the named SecurityGroups in
-	// the same namespace, by their membership label. The reference is live: it
-	// follows the other group's membership as attachments change.
+	// FromSG selects source pods that are members of the named SecurityGroups,
+	// by their membership label. A name refers to a SecurityGroup in the same
+	// namespace, a "<namespace>/<name>" to one in a peered namespace. The
+	// reference is live: it follows the other group's membership as attachments
+	// change.
 	FromSG []string `json:"fromSG,omitempty"`
 
 	// FromCIDR is a list of CIDR ranges allowed as traffic sources.
@@ -128,9 +150,11 @@ type EgressRule struct {
 	// applications, by their lineage labels.
 	ToApp []ApplicationReference `json:"toApp,omitempty"`
 
-	// ToSG selects destination pods that are members of the named SecurityGroups
-	// in the same namespace, by their membership label. The reference is live: it
-	// follows the other group's membership as attachments change.
+	// ToSG selects destination pods that are members of the named
+	// SecurityGroups, by their membership label. A name refers to a
+	// SecurityGroup in the same namespace, a "<namespace>/<name>" to one in a
+	// peered namespace. The reference is live: it follows the other group's
+	// membership as attachments change.
 	ToSG []string `json:"toSG,omitempty"`
 
 	// ToCIDR is a list of CIDR ranges allowed as traffic destinations.
@@ -145,10 +169,121 @@ type EgressRule struct {
 	ToPorts []PortRule `json:"toPorts,omitempty"`
 }
 
-// PortRule is a set of ports a traffic rule applies to.
+// IngressDenyRule describes one set of blocked inbound sources and ports. A
+// deny rule must name at least one source, and cannot fully cover an ingress
+// allow rule of the same SecurityGroup, which would then never match.
+type IngressDenyRule struct {
+	// FromApp selects source pods belonging to the referenced managed
+	// applications, by their lineage labels.
+	FromApp []ApplicationReference `json:"fromApp,omitempty"`
+
+	// FromSG selects source pods that are members of the named SecurityGroups,
+	// by their membership label, like IngressRule.FromSG.
+	FromSG []string `json:"fromSG,omitempty"`
+
+	// FromCIDR is a list of CIDR ranges blocked as traffic sources.
+	FromCIDR []string `json:"fromCIDR,omitempty"`
+
+	// ToPorts restricts the rule to the listed destination ports. An empty list
+	// blocks traffic on all ports.
+	ToPorts []PortDenyRule `json:"toPorts,omitempty"`
+}
+
+// EgressDenyRule describes one set of blocked outbound destinations and ports.
+// Cilium cannot deny by domain name, so there is no toFQDNs: block the
+// addresses with ToCIDR instead.
+type EgressDenyRule struct {
+	// ToApp selects destination pods belonging to the referenced managed
+	// applications, by their lineage labels.
+	ToApp []ApplicationReference `json:"toApp,omitempty"`
+
+	// ToSG selects destination pods that are members of the named
+	// SecurityGroups, by their membership label, like EgressRule.ToSG.
+	ToSG []string `json:"toSG,omitempty"`
+
+	// ToCIDR is a list of CIDR ranges blocked as traffic destinations.
+	ToCIDR []string `json:"toCIDR,omitempty"`
+
+	// ToPorts restricts the rule to the listed destination ports. An empty list
+	// blocks traffic on all ports.
+	ToPorts []PortDenyRule `json:"toPorts,omitempty"`
+}
+
+// PortDenyRule is a set of ports a deny rule applies to. Unlike PortRule it
+// can only ever name L4 ports.
+type PortDenyRule struct {
+	// Ports is the list of port/protocol pairs the rule applies to.
+	Ports []PortProtocol `json:"ports,omitempty"`
+}
+
+// PortRule is a set of ports a traffic rule applies to, optionally narrowed
+// to L7 requests.
 type PortRule struct {
 	// Ports is the list of port/protocol pairs the rule applies to.
 	Ports []PortProtocol `json:"ports,omitempty"`
+
+	// Rules restricts the traffic on Ports to the listed L7 requests, which
+	// Cilium enforces through its L7 proxy. Traffic on Ports that matches none
+	// of them is rejected at L7 (an HTTP 403, a refused DNS query) instead of
+	// being dropped. Setting Rules requires explicit ports.
+	Rules *L7Rules `json:"rules,omitempty"`
+}
+
+// L7Rules lists the L7 requests allowed on a set of ports. Exactly one of
+// HTTP, DNS and Kafka must be set.
+type L7Rules struct {
+	// HTTP lists the allowed HTTP requests. A request is allowed when it
+	// matches any of them. Requires TCP ports.
+	HTTP []HTTPRule `json:"http,omitempty"`
+
+	// DNS lists the names DNS queries are allowed for, typically on port 53
+	// of the cluster DNS service. Egress rules only. The names resolved
+	// through such a rule are what toFQDNs peers match against.
+	DNS []FQDNSelector `json:"dns,omitempty"`
+
+	// Kafka lists the allowed Kafka requests, for traffic to a Kafka
+	// application. Requires TCP ports.
+	Kafka []KafkaRule `json:"kafka,omitempty"`
+}
+
+// HTTPRule matches HTTP requests. Empty fields match anything; a request
+// matches when it matches every field set.
+type HTTPRule struct {
+	// Method is an extended POSIX regular expression matched against the
+	// request method, e.g. "GET" or "GET|HEAD".
+	Method string `json:"method,omitempty"`
+
+	// Path is an extended POSIX regular expression matched against the
+	// request path, e.g. "/api/.*".
+	Path string `json:"path,omitempty"`
+
+	// Host is an extended POSIX regular expression matched against the
+	// request host.
+	Host string `json:"host,omitempty"`
+
+	// Headers lists headers the request must carry, each either "Name" for
+	// presence or "Name: value" for an exact value.
+	Headers []string `json:"headers,omitempty"`
+}
+
+const (
+	// KafkaRoleProduce allows the requests of a producer.
+	KafkaRoleProduce = "produce"
+	// KafkaRoleConsume allows the requests of a consumer.
+	KafkaRoleConsume = "consume"
+)
+
+// KafkaRule matches Kafka requests. Empty fields match anything.
+type KafkaRule struct {
+	// Role is the kind of client allowed, one of produce or consume. Empty
+	// allows both.
+	Role string `json:"role,omitempty"`
+
+	// Topic is the topic the requests must be about.
+	Topic string `json:"topic,omitempty"`
+
+	// ClientID is the client identifier the requests must carry.
+	ClientID string `json:"clientID,omitempty"`
 }
 
 // PortProtocol is a single port and protocol pair.
@@ -172,6 +307,100 @@ type FQDNSelector struct {
 	MatchPattern string `json:"matchPattern,omitempty"`
 }
 
+// SecurityGroupStatus reports the pods a SecurityGroup applies to, whether its
+// backing policy is enforced and, when the securitygroup-controller has a flow
+// source, the traffic its rules matched.
+type SecurityGroupStatus struct {
+	// Members lists the attached applications with the number of their pods
+	// carrying the membership label. An attachment with no pods is listed with
+	// zero pods.
+	Members []SecurityGroupMember `json:"members,omitempty"`
+
+	// Policy reports whether Cilium accepted the backing CiliumNetworkPolicy.
+	Policy SecurityGroupPolicyStatus `json:"policy,omitempty"`
+
+	// UnresolvedReferences lists the fromSG/toSG names that match no
+	// SecurityGroup, including the "<namespace>/<name>" references to a
+	// namespace the peering with is not established. Rules referencing them
+	// select no pods.
+	UnresolvedReferences []string `json:"unresolvedReferences,omitempty"`
+
+	// Flows counts the flows matched by each rule, gathered from Hubble flow
+	// data. It is absent when the securitygroup-controller has no flow source.
+	Flows *SecurityGroupFlows `json:"flows,omitempty"`
+
+	// ObservedAt is when the securitygroup-controller last resolved the
+	// members and references.
+	ObservedAt *metav1.Time `json:"observedAt,omitempty"`
+}
+
+// SecurityGroupMember is an attached application and the number of its pods
+// that are members.
+type SecurityGroupMember struct {
+	// Application is the attached application.
+	Application ApplicationReference `json:"application"`
+
+	// Pods is the number of pods of the application carrying the membership
+	// label.
+	Pods int32 `json:"pods"`
+}
+
+const (
+	// SecurityGroupPolicyPending means Cilium has not reported on the backing
+	// policy yet.
+	SecurityGroupPolicyPending = "Pending"
+	// SecurityGroupPolicyEnforced means Cilium accepted the backing policy.
+	SecurityGroupPolicyEnforced = "Enforced"
+	// SecurityGroupPolicyInvalid means Cilium rejected the backing policy; the
+	// message says why.
+	SecurityGroupPolicyInvalid = "Invalid"
+)
+
+// SecurityGroupPolicyStatus is the state of the backing CiliumNetworkPolicy,
+// read from its Valid condition.
+type SecurityGroupPolicyStatus struct {
+	// Phase is one of Pending, Enforced or Invalid.
+	Phase string `json:"phase,omitempty"`
+
+	// Message is the reason Cilium gave for rejecting the policy.
+	Message string `json:"message,omitempty"`
+}
+
+// SecurityGroupFlows counts the flows of the member pods attributed to each
+// rule. A flow is attributed to the first deny rule whose peers and ports match
+// the other end of the flow; failing that to the first allow rule, in order,
+// whose peers and ports match, or the first whose peers match. It is counted as
+// allowed or denied by its verdict. Counters start over when the
+// securitygroup-controller restarts.
+type SecurityGroupFlows struct {
+	// Since is when counting started.
+	Since metav1.Time `json:"since"`
+
+	// Ingress holds the counters of spec.ingress, by rule index.
+	Ingress []RuleFlowCounter `json:"ingress,omitempty"`
+
+	// Egress holds the counters of spec.egress, by rule index.
+	Egress []RuleFlowCounter `json:"egress,omitempty"`
+
+	// IngressDeny holds the counters of spec.ingressDeny, by rule index.
+	IngressDeny []RuleFlowCounter `json:"ingressDeny,omitempty"`
+
+	// EgressDeny holds the counters of spec.egressDeny, by rule index.
+	EgressDeny []RuleFlowCounter `json:"egressDeny,omitempty"`
+}
+
+// RuleFlowCounter counts the flows attributed to one rule.
+type RuleFlowCounter struct {
+	// Rule is the index of the rule in its list.
+	Rule int32 `json:"rule"`
+
+	// Allowed is the number of flows forwarded.
+	Allowed int64 `json:"allowed"`
+
+	// Denied is the number of flows dropped.
+	Denied int64 `json:"denied"`
+}
+
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
 // SecurityGroupList is a list of SecurityGroup objects.
@@ -0,0 +1,80 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2026 The Cozystack Authors.
+
+package v1alpha1
+
+import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+const (
+	// SecurityGroupPeeringKind is the kind of the SecurityGroupPeering resource.
+	SecurityGroupPeeringKind = "SecurityGroupPeering"
+	// SecurityGroupPeeringListKind is the kind of the SecurityGroupPeeringList
+	// resource.
+	SecurityGroupPeeringListKind = "SecurityGroupPeeringList"
+	// SecurityGroupPeeringSingularName is the singular resource name.
+	SecurityGroupPeeringSingularName = "securitygrouppeering"
+	// SecurityGroupPeeringPluralName is the plural resource name.
+	SecurityGroupPeeringPluralName = "securitygrouppeerings"
+
+	// PeerLabelPrefix is the prefix of the consent label the
+	// securitygroup-controller stamps onto the pods a peer namespace may
+	// select. The full key is PeerLabelPrefix + <peer namespace>; the value is
+	// always the empty string. A SecurityGroup rule referencing an application
+	// or SecurityGroup of another namespace projects to a selector requiring
+	// this label, so it selects nothing unless both namespaces have a
+	// SecurityGroupPeering naming the other.
+	PeerLabelPrefix = "peer.sdn.cozystack.io/"
+)
+
+const (
+	// SecurityGroupPeeringOffered means only this namespace consents: the peer
+	// namespace has no SecurityGroupPeering naming it.
+	SecurityGroupPeeringOffered = "Offered"
+	// SecurityGroupPeeringEstablished means both namespaces consent, so their
+	// SecurityGroups may reference each other's applications and groups.
+	SecurityGroupPeeringEstablished = "Established"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// SecurityGroupPeering is one side of a consent between two tenant namespaces
+// to let their SecurityGroups reference each other's applications and
+// SecurityGroups. A peering created in tenant A naming tenant B is an offer;
+// it is established once tenant B creates a SecurityGroupPeering naming tenant
+// A, and withdrawn as soon as either side deletes theirs. It is served by the
+// Cozystack aggregated API as a projection of a marked ConfigMap in the same
+// namespace.
+type SecurityGroupPeering struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec names the peer namespace.
+	Spec SecurityGroupPeeringSpec `json:"spec,omitempty"`
+
+	// Status reports whether the peer namespace consents too. It is read-only.
+	Status SecurityGroupPeeringStatus `json:"status,omitempty"`
+}
+
+// SecurityGroupPeeringSpec names the namespace a SecurityGroupPeering consents
+// to peer with.
+type SecurityGroupPeeringSpec struct {
+	// PeerNamespace is the namespace of the peer tenant. It cannot be changed:
+	// peering with another namespace takes a new SecurityGroupPeering.
+	PeerNamespace string `json:"peerNamespace"`
+}
+
+// SecurityGroupPeeringStatus reports the state of a SecurityGroupPeering.
+type SecurityGroupPeeringStatus struct {
+	// Phase is Offered until the peer namespace has a SecurityGroupPeering
+	// naming this one, then Established.
+	Phase string `json:"phase,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// SecurityGroupPeeringList is a list of SecurityGroupPeering objects.
+type SecurityGroupPeeringList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []SecurityGroupPeering `json:"items"`
+}
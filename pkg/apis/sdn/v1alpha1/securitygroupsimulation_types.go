@@ -0,0 +1,209 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2026 The Cozystack Authors.
+
+package v1alpha1
+
+import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+const (
+	// SecurityGroupSimulationKind is the kind of the SecurityGroupSimulation
+	// resource.
+	SecurityGroupSimulationKind = "SecurityGroupSimulation"
+	// SecurityGroupSimulationSingularName is the singular resource name.
+	SecurityGroupSimulationSingularName = "securitygroupsimulation"
+	// SecurityGroupSimulationPluralName is the plural resource name.
+	SecurityGroupSimulationPluralName = "securitygroupsimulations"
+
+	// SecurityGroupExplanationKind is the kind of the SecurityGroupExplanation
+	// resource.
+	SecurityGroupExplanationKind = "SecurityGroupExplanation"
+	// SecurityGroupExplanationSingularName is the singular resource name.
+	SecurityGroupExplanationSingularName = "securitygroupexplanation"
+	// SecurityGroupExplanationPluralName is the plural resource name.
+	SecurityGroupExplanationPluralName = "securitygroupexplanations"
+)
+
+const (
+	// SimulationAllowed means the SecurityGroups allow the traffic: an allow
+	// rule matches on every side they govern and no deny rule does.
+	SimulationAllowed = "Allowed"
+	// SimulationDenied means a deny rule matches the traffic.
+	SimulationDenied = "Denied"
+	// SimulationUnmatched means no rule of the SecurityGroups applying to an
+	// endpoint matches the traffic.
+	SimulationUnmatched = "Unmatched"
+	// SimulationBaseline means the SecurityGroups do not decide the traffic:
+	// it is left to the tenant baseline policies.
+	SimulationBaseline = "Baseline"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// SecurityGroupSimulation evaluates the SecurityGroups of a namespace for a
+// connection from a source to a destination, without sending any traffic.
+// Like a SubjectAccessReview it is create-only and never stored: POST a spec
+// and the response carries the status.
+type SecurityGroupSimulation struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec describes the connection to evaluate.
+	Spec SecurityGroupSimulationSpec `json:"spec"`
+
+	// Status is the outcome of the evaluation.
+	Status SecurityGroupSimulationStatus `json:"status,omitempty"`
+}
+
+// SecurityGroupSimulationSpec describes a connection.
+type SecurityGroupSimulationSpec struct {
+	// Source is the endpoint opening the connection.
+	Source SimulationEndpoint `json:"source"`
+
+	// Destination is the endpoint receiving the connection.
+	Destination SimulationEndpoint `json:"destination"`
+
+	// Port is the destination port number. Rules naming ports by name never
+	// match, the port a name stands for is only known to the pod.
+	Port int32 `json:"port"`
+
+	// Protocol is TCP, UDP or SCTP. Defaults to TCP.
+	// +optional
+	Protocol string `json:"protocol,omitempty"`
+}
+
+// SimulationEndpoint is one end of a simulated connection. Exactly one field
+// is set.
+type SimulationEndpoint struct {
+	// Application selects the pods of an application. A namespace other
+	// than the simulation's own must have an established
+	// SecurityGroupPeering with it for its SecurityGroups to be evaluated.
+	// +optional
+	Application *ApplicationReference `json:"application,omitempty"`
+
+	// Pod names a pod, as "<name>" in the simulation's namespace or
+	// "<namespace>/<name>" in a peered one. Its current labels are used, so
+	// the memberships the controller has stamped so far are what counts.
+	// +optional
+	Pod string `json:"pod,omitempty"`
+
+	// IP is an address outside the cluster, matched against CIDR rules.
+	// +optional
+	IP string `json:"ip,omitempty"`
+
+	// FQDN is a DNS name outside the cluster, matched against toFQDNs rules.
+	// It can only be a destination.
+	// +optional
+	FQDN string `json:"fqdn,omitempty"`
+}
+
+// SecurityGroupSimulationStatus is the outcome of a simulation.
+type SecurityGroupSimulationStatus struct {
+	// Verdict is Allowed, Denied or Baseline.
+	Verdict string `json:"verdict,omitempty"`
+
+	// Reason explains the verdict.
+	Reason string `json:"reason,omitempty"`
+
+	// Egress is the evaluation of the source's SecurityGroups. It is absent
+	// when the source is outside the cluster or in a namespace not peered
+	// with the simulation's.
+	// +optional
+	Egress *SimulationResult `json:"egress,omitempty"`
+
+	// Ingress is the evaluation of the destination's SecurityGroups, absent
+	// like Egress.
+	// +optional
+	Ingress *SimulationResult `json:"ingress,omitempty"`
+}
+
+// SimulationResult is the evaluation of the SecurityGroups applying to one
+// end of a connection.
+type SimulationResult struct {
+	// Verdict is Allowed, Denied or Unmatched.
+	Verdict string `json:"verdict"`
+
+	// SecurityGroups are the groups the endpoint is a member of.
+	// +optional
+	SecurityGroups []string `json:"securityGroups,omitempty"`
+
+	// Rules are the rules of those groups matching the connection, deny
+	// rules first.
+	// +optional
+	Rules []MatchedRule `json:"rules,omitempty"`
+}
+
+// MatchedRule points at a rule of a SecurityGroup.
+type MatchedRule struct {
+	// SecurityGroup is the name of the group.
+	SecurityGroup string `json:"securityGroup"`
+
+	// Section is ingress, egress, ingressDeny or egressDeny.
+	Section string `json:"section"`
+
+	// Rule is the index of the rule in its section.
+	Rule int32 `json:"rule"`
+
+	// L7 is set when the rule narrows the port to L7 requests, so it only
+	// allows the requests its L7 rules match.
+	// +optional
+	L7 bool `json:"l7,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// SecurityGroupExplanation lists the SecurityGroup rules that apply to an
+// application: those of the groups it is attached to, and the rules of other
+// groups naming it as a peer. It is create-only and never stored, like
+// SecurityGroupSimulation.
+type SecurityGroupExplanation struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec names the application.
+	Spec SecurityGroupExplanationSpec `json:"spec"`
+
+	// Status lists the rules.
+	Status SecurityGroupExplanationStatus `json:"status,omitempty"`
+}
+
+// SecurityGroupExplanationSpec names the application to explain.
+type SecurityGroupExplanationSpec struct {
+	// Application is an application of the explanation's namespace.
+	Application ApplicationReference `json:"application"`
+}
+
+// SecurityGroupExplanationStatus lists the rules applying to an application.
+type SecurityGroupExplanationStatus struct {
+	// SecurityGroups are the groups the application is attached to, with
+	// their rules. These decide what the application's pods may send and
+	// receive.
+	// +optional
+	SecurityGroups []ExplainedSecurityGroup `json:"securityGroups,omitempty"`
+
+	// ReferencedBy are the rules of the namespace's groups naming the
+	// application or one of its groups as a peer.
+	// +optional
+	ReferencedBy []MatchedRule `json:"referencedBy,omitempty"`
+}
+
+// ExplainedSecurityGroup is a SecurityGroup an application is attached to.
+type ExplainedSecurityGroup struct {
+	// Name is the name of the group.
+	Name string `json:"name"`
+
+	// Ingress are the group's ingress rules.
+	// +optional
+	Ingress []IngressRule `json:"ingress,omitempty"`
+
+	// Egress are the group's egress rules.
+	// +optional
+	Egress []EgressRule `json:"egress,omitempty"`
+
+	// IngressDeny are the group's ingress deny rules.
+	// +optional
+	IngressDeny []IngressDenyRule `json:"ingressDeny,omitempty"`
+
+	// EgressDeny are the group's egress deny rules.
+	// +optional
+	EgressDeny []EgressDenyRule `json:"egressDeny,omitempty"`
+}
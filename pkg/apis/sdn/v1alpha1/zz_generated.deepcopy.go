@@ -41,6 +41,44 @@ func (in *ApplicationReference) DeepCopy() *ApplicationReference {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EgressDenyRule) DeepCopyInto(out *EgressDenyRule) {
+	*out = *in
+	if in.ToApp != nil {
+		in, out := &in.ToApp, &out.ToApp
+		*out = make([]ApplicationReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.ToSG != nil {
+		in, out := &in.ToSG, &out.ToSG
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ToCIDR != nil {
+		in, out := &in.ToCIDR, &out.ToCIDR
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ToPorts != nil {
+		in, out := &in.ToPorts, &out.ToPorts
+		*out = make([]PortDenyRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EgressDenyRule.
+func (in *EgressDenyRule) DeepCopy() *EgressDenyRule {
+	if in == nil {
+		return nil
+	}
+	out := new(EgressDenyRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EgressRule) DeepCopyInto(out *EgressRule) {
 	*out = *in
@@ -84,6 +122,50 @@ func (in *EgressRule) DeepCopy() *EgressRule {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExplainedSecurityGroup) DeepCopyInto(out *ExplainedSecurityGroup) {
+	*out = *in
+	if in.Ingress != nil {
+		in, out := &in.Ingress, &out.Ingress
+		*out = make([]IngressRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Egress != nil {
+		in, out := &in.Egress, &out.Egress
+		*out = make([]EgressRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.IngressDeny != nil {
+		in, out := &in.IngressDeny, &out.IngressDeny
+		*out = make([]IngressDenyRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.EgressDeny != nil {
+		in, out := &in.EgressDeny, &out.EgressDeny
+		*out = make([]EgressDenyRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExplainedSecurityGroup.
+func (in *ExplainedSecurityGroup) DeepCopy() *ExplainedSecurityGroup {
+	if in == nil {
+		return nil
+	}
+	out := new(ExplainedSecurityGroup)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FQDNSelector) DeepCopyInto(out *FQDNSelector) {
 	*out = *in
@@ -100,6 +182,65 @@ func (in *FQDNSelector) DeepCopy() *FQDNSelector {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPRule) DeepCopyInto(out *HTTPRule) {
+	*out = *in
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPRule.
+func (in *HTTPRule) DeepCopy() *HTTPRule {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IngressDenyRule) DeepCopyInto(out *IngressDenyRule) {
+	*out = *in
+	if in.FromApp != nil {
+		in, out := &in.FromApp, &out.FromApp
+		*out = make([]ApplicationReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.FromSG != nil {
+		in, out := &in.FromSG, &out.FromSG
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.FromCIDR != nil {
+		in, out := &in.FromCIDR, &out.FromCIDR
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ToPorts != nil {
+		in, out := &in.ToPorts, &out.ToPorts
+		*out = make([]PortDenyRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IngressDenyRule.
+func (in *IngressDenyRule) DeepCopy() *IngressDenyRule {
+	if in == nil {
+		return nil
+	}
+	out := new(IngressDenyRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *IngressRule) DeepCopyInto(out *IngressRule) {
 	*out = *in
@@ -138,6 +279,92 @@ func (in *IngressRule) DeepCopy() *IngressRule {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KafkaRule) DeepCopyInto(out *KafkaRule) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaRule.
+func (in *KafkaRule) DeepCopy() *KafkaRule {
+	if in == nil {
+		return nil
+	}
+	out := new(KafkaRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *L7Rules) DeepCopyInto(out *L7Rules) {
+	*out = *in
+	if in.HTTP != nil {
+		in, out := &in.HTTP, &out.HTTP
+		*out = make([]HTTPRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.DNS != nil {
+		in, out := &in.DNS, &out.DNS
+		*out = make([]FQDNSelector, len(*in))
+		copy(*out, *in)
+	}
+	if in.Kafka != nil {
+		in, out := &in.Kafka, &out.Kafka
+		*out = make([]KafkaRule, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new L7Rules.
+func (in *L7Rules) DeepCopy() *L7Rules {
+	if in == nil {
+		return nil
+	}
+	out := new(L7Rules)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MatchedRule) DeepCopyInto(out *MatchedRule) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MatchedRule.
+func (in *MatchedRule) DeepCopy() *MatchedRule {
+	if in == nil {
+		return nil
+	}
+	out := new(MatchedRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PortDenyRule) DeepCopyInto(out *PortDenyRule) {
+	*out = *in
+	if in.Ports != nil {
+		in, out := &in.Ports, &out.Ports
+		*out = make([]PortProtocol, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PortDenyRule.
+func (in *PortDenyRule) DeepCopy() *PortDenyRule {
+	if in == nil {
+		return nil
+	}
+	out := new(PortDenyRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PortProtocol) DeepCopyInto(out *PortProtocol) {
 	*out = *in
@@ -162,6 +389,11 @@ func (in *PortRule) DeepCopyInto(out *PortRule) {
 		*out = make([]PortProtocol, len(*in))
 		copy(*out, *in)
 	}
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = new(L7Rules)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -175,12 +407,29 @@ func (in *PortRule) DeepCopy() *PortRule {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RuleFlowCounter) DeepCopyInto(out *RuleFlowCounter) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RuleFlowCounter.
+func (in *RuleFlowCounter) DeepCopy() *RuleFlowCounter {
+	if in == nil {
+		return nil
+	}
+	out := new(RuleFlowCounter)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SecurityGroup) DeepCopyInto(out *SecurityGroup) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
@@ -202,6 +451,116 @@ func (in *SecurityGroup) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecurityGroupExplanation) DeepCopyInto(out *SecurityGroupExplanation) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecurityGroupExplanation.
+func (in *SecurityGroupExplanation) DeepCopy() *SecurityGroupExplanation {
+	if in == nil {
+		return nil
+	}
+	out := new(SecurityGroupExplanation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SecurityGroupExplanation) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecurityGroupExplanationSpec) DeepCopyInto(out *SecurityGroupExplanationSpec) {
+	*out = *in
+	out.Application = in.Application
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecurityGroupExplanationSpec.
+func (in *SecurityGroupExplanationSpec) DeepCopy() *SecurityGroupExplanationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SecurityGroupExplanationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecurityGroupExplanationStatus) DeepCopyInto(out *SecurityGroupExplanationStatus) {
+	*out = *in
+	if in.SecurityGroups != nil {
+		in, out := &in.SecurityGroups, &out.SecurityGroups
+		*out = make([]ExplainedSecurityGroup, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ReferencedBy != nil {
+		in, out := &in.ReferencedBy, &out.ReferencedBy
+		*out = make([]MatchedRule, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecurityGroupExplanationStatus.
+func (in *SecurityGroupExplanationStatus) DeepCopy() *SecurityGroupExplanationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SecurityGroupExplanationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecurityGroupFlows) DeepCopyInto(out *SecurityGroupFlows) {
+	*out = *in
+	in.Since.DeepCopyInto(&out.Since)
+	if in.Ingress != nil {
+		in, out := &in.Ingress, &out.Ingress
+		*out = make([]RuleFlowCounter, len(*in))
+		copy(*out, *in)
+	}
+	if in.Egress != nil {
+		in, out := &in.Egress, &out.Egress
+		*out = make([]RuleFlowCounter, len(*in))
+		copy(*out, *in)
+	}
+	if in.IngressDeny != nil {
+		in, out := &in.IngressDeny, &out.IngressDeny
+		*out = make([]RuleFlowCounter, len(*in))
+		copy(*out, *in)
+	}
+	if in.EgressDeny != nil {
+		in, out := &in.EgressDeny, &out.EgressDeny
+		*out = make([]RuleFlowCounter, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecurityGroupFlows.
+func (in *SecurityGroupFlows) DeepCopy() *SecurityGroupFlows {
+	if in == nil {
+		return nil
+	}
+	out := new(SecurityGroupFlows)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SecurityGroupList) DeepCopyInto(out *SecurityGroupList) {
 	*out = *in
@@ -235,6 +594,204 @@ func (in *SecurityGroupList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecurityGroupMember) DeepCopyInto(out *SecurityGroupMember) {
+	*out = *in
+	out.Application = in.Application
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecurityGroupMember.
+func (in *SecurityGroupMember) DeepCopy() *SecurityGroupMember {
+	if in == nil {
+		return nil
+	}
+	out := new(SecurityGroupMember)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecurityGroupPeering) DeepCopyInto(out *SecurityGroupPeering) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	out.Status = in.Status
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecurityGroupPeering.
+func (in *SecurityGroupPeering) DeepCopy() *SecurityGroupPeering {
+	if in == nil {
+		return nil
+	}
+	out := new(SecurityGroupPeering)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SecurityGroupPeering) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecurityGroupPeeringList) DeepCopyInto(out *SecurityGroupPeeringList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]SecurityGroupPeering, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecurityGroupPeeringList.
+func (in *SecurityGroupPeeringList) DeepCopy() *SecurityGroupPeeringList {
+	if in == nil {
+		return nil
+	}
+	out := new(SecurityGroupPeeringList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SecurityGroupPeeringList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecurityGroupPeeringSpec) DeepCopyInto(out *SecurityGroupPeeringSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecurityGroupPeeringSpec.
+func (in *SecurityGroupPeeringSpec) DeepCopy() *SecurityGroupPeeringSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SecurityGroupPeeringSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecurityGroupPeeringStatus) DeepCopyInto(out *SecurityGroupPeeringStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecurityGroupPeeringStatus.
+func (in *SecurityGroupPeeringStatus) DeepCopy() *SecurityGroupPeeringStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SecurityGroupPeeringStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecurityGroupPolicyStatus) DeepCopyInto(out *SecurityGroupPolicyStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecurityGroupPolicyStatus.
+func (in *SecurityGroupPolicyStatus) DeepCopy() *SecurityGroupPolicyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SecurityGroupPolicyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecurityGroupSimulation) DeepCopyInto(out *SecurityGroupSimulation) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecurityGroupSimulation.
+func (in *SecurityGroupSimulation) DeepCopy() *SecurityGroupSimulation {
+	if in == nil {
+		return nil
+	}
+	out := new(SecurityGroupSimulation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SecurityGroupSimulation) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecurityGroupSimulationSpec) DeepCopyInto(out *SecurityGroupSimulationSpec) {
+	*out = *in
+	in.Source.DeepCopyInto(&out.Source)
+	in.Destination.DeepCopyInto(&out.Destination)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecurityGroupSimulationSpec.
+func (in *SecurityGroupSimulationSpec) DeepCopy() *SecurityGroupSimulationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SecurityGroupSimulationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecurityGroupSimulationStatus) DeepCopyInto(out *SecurityGroupSimulationStatus) {
+	*out = *in
+	if in.Egress != nil {
+		in, out := &in.Egress, &out.Egress
+		*out = new(SimulationResult)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Ingress != nil {
+		in, out := &in.Ingress, &out.Ingress
+		*out = new(SimulationResult)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecurityGroupSimulationStatus.
+func (in *SecurityGroupSimulationStatus) DeepCopy() *SecurityGroupSimulationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SecurityGroupSimulationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SecurityGroupSpec) DeepCopyInto(out *SecurityGroupSpec) {
 	*out = *in
@@ -250,6 +807,13 @@ func (in *SecurityGroupSpec) DeepCopyInto(out *SecurityGroupSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.IngressDeny != nil {
+		in, out := &in.IngressDeny, &out.IngressDeny
+		*out = make([]IngressDenyRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.Egress != nil {
 		in, out := &in.Egress, &out.Egress
 		*out = make([]EgressRule, len(*in))
@@ -257,6 +821,13 @@ func (in *SecurityGroupSpec) DeepCopyInto(out *SecurityGroupSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.EgressDeny != nil {
+		in, out := &in.EgressDeny, &out.EgressDeny
+		*out = make([]EgressDenyRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -269,3 +840,86 @@ func (in *SecurityGroupSpec) DeepCopy() *SecurityGroupSpec {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecurityGroupStatus) DeepCopyInto(out *SecurityGroupStatus) {
+	*out = *in
+	if in.Members != nil {
+		in, out := &in.Members, &out.Members
+		*out = make([]SecurityGroupMember, len(*in))
+		copy(*out, *in)
+	}
+	out.Policy = in.Policy
+	if in.UnresolvedReferences != nil {
+		in, out := &in.UnresolvedReferences, &out.UnresolvedReferences
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Flows != nil {
+		in, out := &in.Flows, &out.Flows
+		*out = new(SecurityGroupFlows)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ObservedAt != nil {
+		in, out := &in.ObservedAt, &out.ObservedAt
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecurityGroupStatus.
+func (in *SecurityGroupStatus) DeepCopy() *SecurityGroupStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SecurityGroupStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SimulationEndpoint) DeepCopyInto(out *SimulationEndpoint) {
+	*out = *in
+	if in.Application != nil {
+		in, out := &in.Application, &out.Application
+		*out = new(ApplicationReference)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SimulationEndpoint.
+func (in *SimulationEndpoint) DeepCopy() *SimulationEndpoint {
+	if in == nil {
+		return nil
+	}
+	out := new(SimulationEndpoint)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SimulationResult) DeepCopyInto(out *SimulationResult) {
+	*out = *in
+	if in.SecurityGroups != nil {
+		in, out := &in.SecurityGroups, &out.SecurityGroups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]MatchedRule, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SimulationResult.
+func (in *SimulationResult) DeepCopy() *SimulationResult {
+	if in == nil {
+		return nil
+	}
+	out := new(SimulationResult)
+	in.DeepCopyInto(out)
+	return out
+}
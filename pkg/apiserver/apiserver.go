@@ -23,15 +23,18 @@ import (
 
 	cozyv1alpha1 "github.com/cozystack/cozystack/api/v1alpha1"
 	helmv2 "github.com/fluxcd/helm-controller/api/v2"
+	sourcev1 "github.com/fluxcd/source-controller/api/v1"
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"k8s.io/apiserver/pkg/admission"
 	"k8s.io/apiserver/pkg/registry/rest"
 	genericapiserver "k8s.io/apiserver/pkg/server"
 	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
 	"k8s.io/klog/v2"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
@@ -45,13 +48,17 @@ import (
 	"github.com/cozystack/cozystack/pkg/apis/sdn"
 	sdninstall "github.com/cozystack/cozystack/pkg/apis/sdn/install"
 	"github.com/cozystack/cozystack/pkg/config"
+	"github.com/cozystack/cozystack/pkg/helmrender"
 	cozyregistry "github.com/cozystack/cozystack/pkg/registry"
 	applicationstorage "github.com/cozystack/cozystack/pkg/registry/apps/application"
 	optionstorage "github.com/cozystack/cozystack/pkg/registry/core/option"
 	tenantmodulestorage "github.com/cozystack/cozystack/pkg/registry/core/tenantmodule"
 	tenantnamespacestorage "github.com/cozystack/cozystack/pkg/registry/core/tenantnamespace"
+	tenantquotastorage "github.com/cozystack/cozystack/pkg/registry/core/tenantquota"
 	tenantsecretstorage "github.com/cozystack/cozystack/pkg/registry/core/tenantsecret"
+	tenantusagestorage "github.com/cozystack/cozystack/pkg/registry/core/tenantusage"
 	securitygroupstorage "github.com/cozystack/cozystack/pkg/registry/sdn/securitygroup"
+	securitygrouppeeringstorage "github.com/cozystack/cozystack/pkg/registry/sdn/securitygrouppeering"
 )
 
 var (
@@ -80,6 +87,11 @@ func init() {
 		panic(fmt.Errorf("Failed to add HelmRelease types to scheme: %w", err))
 	}
 
+	// Register Flux source types, read by the Application chart renderer.
+	if err := sourcev1.AddToScheme(mgrScheme); err != nil {
+		panic(fmt.Errorf("failed to add Flux source types to scheme: %w", err))
+	}
+
 	// Register the in-tree CiliumNetworkPolicy mirror backing SecurityGroup.
 	if err := securitygroupstorage.AddToScheme(mgrScheme); err != nil {
 		panic(fmt.Errorf("failed to add CiliumNetworkPolicy mirror to scheme: %w", err))
@@ -114,6 +126,8 @@ func init() {
 type Config struct {
 	GenericConfig  *genericapiserver.RecommendedConfig
 	ResourceConfig *config.ResourceConfig
+	// Pricing prices the resource estimates of the render subresource.
+	Pricing config.Pricing
 }
 
 // CozyServer holds the state for the Kubernetes master/api server.
@@ -124,6 +138,7 @@ type CozyServer struct {
 type completedConfig struct {
 	GenericConfig  genericapiserver.CompletedConfig
 	ResourceConfig *config.ResourceConfig
+	Pricing        config.Pricing
 }
 
 // CompletedConfig embeds a private pointer that cannot be created outside of this package.
@@ -136,6 +151,7 @@ func (cfg *Config) Complete() CompletedConfig {
 	c := completedConfig{
 		cfg.GenericConfig.Complete(),
 		cfg.ResourceConfig,
+		cfg.Pricing,
 	}
 
 	return CompletedConfig{&c}
@@ -177,6 +193,14 @@ func (c completedConfig) New() (*CozyServer, error) {
 		return nil, fmt.Errorf("failed to index service spec.type field: %w", err)
 	}
 
+	if err := mgr.GetFieldIndexer().IndexField(
+		context.Background(),
+		&cozyv1alpha1.UsageRecord{},
+		tenantusagestorage.RecordMonthField,
+		tenantusagestorage.RecordMonth); err != nil {
+		return nil, fmt.Errorf("failed to index usage record months: %w", err)
+	}
+
 	ctx := ctrl.SetupSignalHandler()
 
 	if err = mustGetInformers(ctx, mgr,
@@ -186,6 +210,7 @@ func (c completedConfig) New() (*CozyServer, error) {
 		&corev1.Service{},
 		&rbacv1.RoleBinding{},
 		&cozyv1alpha1.WorkloadMonitor{},
+		&cozyv1alpha1.UsageRecord{},
 	); err != nil {
 		return nil, fmt.Errorf("failed to get informers: %w", err)
 	}
@@ -212,19 +237,39 @@ func (c completedConfig) New() (*CozyServer, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to build dynamic client: %w", err)
 	}
+	// Typed clientset for the Application logs subresource, which streams
+	// pods/log — not something the controller-runtime client can do.
+	kubeClient, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build kubernetes client: %w", err)
+	}
 	// --- static, cluster-scoped resource for core group ---
 	coreV1alpha1Storage := map[string]rest.Storage{}
 	coreV1alpha1Storage["tenantnamespaces"] = cozyregistry.RESTInPeace(
 		tenantnamespacestorage.NewREST(cli, watchCli),
 	)
-	coreV1alpha1Storage["tenantsecrets"] = cozyregistry.RESTInPeace(
-		tenantsecretstorage.NewREST(cli, watchCli),
+	tenantSecrets := tenantsecretstorage.NewREST(cli, watchCli)
+	coreV1alpha1Storage["tenantsecrets"] = cozyregistry.RESTInPeace(tenantSecrets)
+	coreV1alpha1Storage["tenantsecrets/rotate"] = cozyregistry.RESTInPeace(
+		tenantsecretstorage.NewRotateREST(tenantSecrets),
+	)
+	coreV1alpha1Storage["tenantsecrets/versions"] = cozyregistry.RESTInPeace(
+		tenantsecretstorage.NewVersionsREST(tenantSecrets),
 	)
 	coreV1alpha1Storage["tenantmodules"] = cozyregistry.RESTInPeace(
-		tenantmodulestorage.NewREST(cli, watchCli),
+		tenantmodulestorage.NewREST(cli, watchCli, s.GenericAPIServer.Authorizer),
+	)
+	coreV1alpha1Storage["tenantquotas"] = cozyregistry.RESTInPeace(
+		tenantquotastorage.NewREST(cli, watchCli),
+	)
+	tenantUsages := tenantusagestorage.NewREST(cli, watchCli, c.Pricing)
+	coreV1alpha1Storage["tenantusages"] = cozyregistry.RESTInPeace(tenantUsages)
+	coreV1alpha1Storage["tenantusages/export"] = cozyregistry.RESTInPeace(
+		tenantusagestorage.NewExportREST(tenantUsages),
 	)
 	coreV1alpha1Storage["options"] = cozyregistry.RESTInPeace(
-		optionstorage.NewREST(optionstorage.DefaultProviders(dyn)),
+		optionstorage.NewREST(optionstorage.DefaultProviders(dyn)).
+			WithSources(optionstorage.NewSources(cli, dyn, ctx.Done())),
 	)
 
 	coreApiGroupInfo := genericapiserver.NewDefaultAPIGroupInfo(core.GroupName, Scheme, metav1.ParameterCodec, Codecs)
@@ -235,8 +280,16 @@ func (c completedConfig) New() (*CozyServer, error) {
 
 	// --- static, namespaced resource for sdn group ---
 	sdnV1alpha1Storage := map[string]rest.Storage{}
-	sdnV1alpha1Storage["securitygroups"] = cozyregistry.RESTInPeace(
-		securitygroupstorage.NewREST(cli, watchCli),
+	securityGroups := securitygroupstorage.NewREST(cli, watchCli)
+	sdnV1alpha1Storage["securitygroups"] = cozyregistry.RESTInPeace(securityGroups)
+	sdnV1alpha1Storage["securitygroupsimulations"] = cozyregistry.RESTInPeace(
+		securitygroupstorage.NewSimulationREST(securityGroups),
+	)
+	sdnV1alpha1Storage["securitygroupexplanations"] = cozyregistry.RESTInPeace(
+		securitygroupstorage.NewExplanationREST(securityGroups),
+	)
+	sdnV1alpha1Storage["securitygrouppeerings"] = cozyregistry.RESTInPeace(
+		securitygrouppeeringstorage.NewREST(watchCli),
 	)
 
 	sdnApiGroupInfo := genericapiserver.NewDefaultAPIGroupInfo(sdn.GroupName, Scheme, metav1.ParameterCodec, Codecs)
@@ -245,13 +298,34 @@ func (c completedConfig) New() (*CozyServer, error) {
 		return nil, err
 	}
 
+	// Chart renderer for the Application render subresource. It reads chart
+	// sources and valuesFrom objects once per preview, so it goes through
+	// the uncached client rather than growing the informer set.
+	renderer := helmrender.New(watchCli, kubeClient.Discovery())
+	admissionObjects := admission.NewObjectInterfacesFromScheme(Scheme)
+
 	// --- dynamically-configured, per-tenant resources ---
-	appsV1alpha1Storage := map[string]rest.Storage{}
+	// Every kind gets one storage per version it is served under; they share
+	// the HelmRelease backend and differ in spec schema and conversion.
+	appsStorage := map[string]map[string]rest.Storage{}
 	for _, resConfig := range c.ResourceConfig.Resources {
-		storage := applicationstorage.NewREST(cli, watchCli, &resConfig)
-		appsV1alpha1Storage[resConfig.Application.Plural] = cozyregistry.RESTInPeace(storage)
+		for _, v := range resConfig.Application.ServedVersions() {
+			if appsStorage[v.Name] == nil {
+				appsStorage[v.Name] = map[string]rest.Storage{}
+			}
+			storage := applicationstorage.NewVersionedREST(cli, watchCli, &resConfig, v.Name)
+			plural := resConfig.Application.Plural
+			appsStorage[v.Name][plural] = cozyregistry.RESTInPeace(storage)
+			appsStorage[v.Name][plural+"/events"] = cozyregistry.RESTInPeace(applicationstorage.NewEventsREST(storage))
+			appsStorage[v.Name][plural+"/logs"] = cozyregistry.RESTInPeace(applicationstorage.NewLogsREST(storage, kubeClient.CoreV1()))
+			appsStorage[v.Name][plural+"/render"] = cozyregistry.RESTInPeace(applicationstorage.NewRenderREST(storage, renderer, c.Pricing,
+				c.GenericConfig.AdmissionControl, admissionObjects, s.GenericAPIServer.Authorizer))
+			if move := resConfig.Application.Move; move != nil {
+				appsStorage[v.Name][plural+"/move"] = cozyregistry.RESTInPeace(applicationstorage.NewMoveREST(storage, move, s.GenericAPIServer.Authorizer))
+			}
+		}
 	}
-	if err := InstallAppsAPIGroup(s.GenericAPIServer, appsV1alpha1Storage); err != nil {
+	if err := InstallAppsAPIGroup(s.GenericAPIServer, appsStorage); err != nil {
 		return nil, err
 	}
 
@@ -259,10 +333,12 @@ func (c completedConfig) New() (*CozyServer, error) {
 }
 
 // InstallAppsAPIGroup registers the apps.cozystack.io API group on the given
-// server using the provided storage map (plural name → rest.Storage).
-func InstallAppsAPIGroup(server *genericapiserver.GenericAPIServer, storage map[string]rest.Storage) error {
+// server using the provided storage maps (version → plural name → rest.Storage).
+func InstallAppsAPIGroup(server *genericapiserver.GenericAPIServer, storage map[string]map[string]rest.Storage) error {
 	info := genericapiserver.NewDefaultAPIGroupInfo(apps.GroupName, Scheme, metav1.ParameterCodec, Codecs)
-	info.VersionedResourcesStorageMap["v1alpha1"] = storage
+	for version, resources := range storage {
+		info.VersionedResourcesStorageMap[version] = resources
+	}
 	return server.InstallAPIGroup(&info)
 }
 
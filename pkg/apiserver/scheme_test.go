@@ -37,7 +37,7 @@ func TestRoundTripTypes(t *testing.T) {
 // path that the apiserver Scheme and the roundtrip helper use, not only at
 // server start. If this fails, the roundtrip above is exercising nothing.
 func TestSchemeRecognizesSDNTypes(t *testing.T) {
-	for _, kind := range []string{"SecurityGroup", "SecurityGroupList"} {
+	for _, kind := range []string{"SecurityGroup", "SecurityGroupList", "SecurityGroupPeering", "SecurityGroupPeeringList", "SecurityGroupSimulation", "SecurityGroupExplanation"} {
 		gvk := sdnv1alpha1.SchemeGroupVersion.WithKind(kind)
 		if !Scheme.Recognizes(gvk) {
 			t.Errorf("Scheme does not recognize %s — SecurityGroup serialization is untested", gvk)
@@ -26,6 +26,17 @@ const (
 	smp           = "application/strategic-merge-patch+json"
 )
 
+// versionPrefix returns the model-name prefix of the per-kind schemas of an
+// apps.cozystack.io version. v1alpha1 keeps the Go package prefix; other
+// versions swap the trailing version segment, so the v1beta1 PostgreSQL
+// schema is "com.github.cozystack.cozystack.pkg.apis.apps.v1beta1.PostgreSQL".
+func versionPrefix(version string) string {
+	if version == "" {
+		return apiPrefix
+	}
+	return strings.TrimSuffix(apiPrefix, "v1alpha1") + version
+}
+
 // deepCopySchema clones *spec.Schema via JSON-marshal/unmarshal.
 func deepCopySchema(in *spec.Schema) *spec.Schema {
 	if in == nil {
@@ -178,8 +189,9 @@ func patchSpec(target *spec.Schema, raw string) error {
 /*  DRY helpers                                                             */
 /* ────────────────────────────────────────────────────────────────────────── */
 
-// cloneKindSchemas: from base schemas, create new schemas for a specific kind.
-func cloneKindSchemas(kind string, base, baseStatus, baseList *spec.Schema, v3 bool) (obj, status, list *spec.Schema) {
+// cloneKindSchemas: from base schemas, create new schemas for a specific kind
+// served under version.
+func cloneKindSchemas(kind, version string, base, baseStatus, baseList *spec.Schema, v3 bool) (obj, status, list *spec.Schema) {
 	obj = deepCopySchema(base)
 	status = deepCopySchema(baseStatus)
 	list = deepCopySchema(baseList)
@@ -193,7 +205,7 @@ func cloneKindSchemas(kind string, base, baseStatus, baseList *spec.Schema, v3 b
 	setGVK := func(s *spec.Schema, k string) {
 		s.Extensions = map[string]any{
 			"x-kubernetes-group-version-kind": []any{
-				map[string]any{"group": "apps.cozystack.io", "version": "v1alpha1", "kind": k},
+				map[string]any{"group": "apps.cozystack.io", "version": version, "kind": k},
 			},
 		}
 	}
@@ -205,8 +217,8 @@ func cloneKindSchemas(kind string, base, baseStatus, baseList *spec.Schema, v3 b
 	if !v3 {
 		refPrefix = "#/definitions/"
 	}
-	statusRef := refPrefix + apiPrefix + "." + kind + "Status"
-	itemRef := refPrefix + apiPrefix + "." + kind
+	statusRef := refPrefix + versionPrefix(version) + "." + kind + "Status"
+	itemRef := refPrefix + versionPrefix(version) + "." + kind
 
 	if prop, ok := obj.Properties["status"]; ok {
 		prop.Ref = spec.MustCreateRef(statusRef)
@@ -231,51 +243,51 @@ func rewriteDocRefs(doc any) ([]byte, error) {
 	if err := json.Unmarshal(raw, &parsed); err != nil {
 		return nil, err
 	}
-	walkAndRewriteRefs(parsed, "")
+	walkAndRewriteRefs(parsed, "", "")
 	return json.Marshal(parsed)
 }
 
 // walkAndRewriteRefs walks arbitrary JSON (map/array) and
-//   - when encountering x-kubernetes-group-version-kind, extracts kind,
-//     updating the currentKind context;
-//   - rewrites all $ref inside the current context from Application* → kind*.
-func walkAndRewriteRefs(node any, currentKind string) {
+//   - when encountering x-kubernetes-group-version-kind, extracts kind and
+//     version, updating the current context;
+//   - rewrites all $ref inside the current context from Application* → kind*
+//     of that version.
+func walkAndRewriteRefs(node any, currentKind, currentVersion string) {
 	switch n := node.(type) {
 	case map[string]any:
 		if gvk, ok := n["x-kubernetes-group-version-kind"]; ok {
-			switch g := gvk.(type) {
+			var g map[string]any
+			switch t := gvk.(type) {
 			case map[string]any:
-				if k, ok := g["kind"].(string); ok {
-					currentKind = k
-				}
+				g = t
 			case []any:
-				if len(g) > 0 {
-					if mm, ok := g[0].(map[string]any); ok {
-						if k, ok := mm["kind"].(string); ok {
-							currentKind = k
-						}
-					}
+				if len(t) > 0 {
+					g, _ = t[0].(map[string]any)
 				}
 			}
+			if k, ok := g["kind"].(string); ok {
+				currentKind = k
+				currentVersion, _ = g["version"].(string)
+			}
 		}
 		for k, v := range n {
 			if k == "$ref" && currentKind != "" {
 				if s, ok := v.(string); ok {
-					n[k] = rewriteRefForKind(s, currentKind)
+					n[k] = rewriteRefForKind(s, currentKind, currentVersion)
 					continue
 				}
 			}
-			walkAndRewriteRefs(v, currentKind)
+			walkAndRewriteRefs(v, currentKind, currentVersion)
 		}
 	case []any:
 		for _, v := range n {
-			walkAndRewriteRefs(v, currentKind)
+			walkAndRewriteRefs(v, currentKind, currentVersion)
 		}
 	}
 }
 
-// rewriteRefForKind rewrites a reference to a specific kind.
-func rewriteRefForKind(old, kind string) string {
+// rewriteRefForKind rewrites a reference to a specific kind of a version.
+func rewriteRefForKind(old, kind, version string) string {
 	var base string
 	switch {
 	case strings.HasPrefix(old, "#/components/schemas/"):
@@ -285,13 +297,14 @@ func rewriteRefForKind(old, kind string) string {
 	default:
 		return old
 	}
+	prefix := versionPrefix(version)
 	switch {
 	case strings.HasSuffix(old, ".Application"):
-		return base + apiPrefix + "." + kind
+		return base + prefix + "." + kind
 	case strings.HasSuffix(old, ".ApplicationList"):
-		return base + apiPrefix + "." + kind + "List"
+		return base + prefix + "." + kind + "List"
 	case strings.HasSuffix(old, ".ApplicationStatus"):
-		return base + apiPrefix + "." + kind + "Status"
+		return base + prefix + "." + kind + "Status"
 	default:
 		return old
 	}
@@ -301,8 +314,10 @@ func rewriteRefForKind(old, kind string) string {
 // OpenAPI **v3** post-processor
 // -----------------------------------------------------------------------------
 // BuildPostProcessV3 returns an OpenAPI v3 post-processor that clones base
-// Application schemas into per-kind schemas and rewrites $ref pointers.
-func BuildPostProcessV3(kindSchemas map[string]string) func(*spec3.OpenAPI) (*spec3.OpenAPI, error) {
+// Application schemas into per-kind schemas and rewrites $ref pointers. v3
+// documents are per group-version, so only the versions whose paths the
+// document carries get their schemas.
+func BuildPostProcessV3(kindSchemas map[string]map[string]string) func(*spec3.OpenAPI) (*spec3.OpenAPI, error) {
 	return func(doc *spec3.OpenAPI) (*spec3.OpenAPI, error) {
 
 		if doc.Components == nil {
@@ -321,23 +336,28 @@ func BuildPostProcessV3(kindSchemas map[string]string) func(*spec3.OpenAPI) (*sp
 		}
 
 		// Clone base schemas for each kind
-		for kind, raw := range kindSchemas {
-			ref := apiPrefix + "." + kind
-			statusRef := ref + "Status"
-			listRef := ref + "List"
-
-			obj, status, l := cloneKindSchemas(kind, base, stat, list /*v3=*/, true)
-			doc.Components.Schemas[ref] = obj
-			doc.Components.Schemas[statusRef] = status
-			doc.Components.Schemas[listRef] = l
-
-			// patch .spec
-			container := findSpecContainer(obj)
-			if container == nil {
-				container = obj
+		for version, kinds := range kindSchemas {
+			if doc.Paths != nil && !servesVersion(doc.Paths.Paths, version) {
+				continue
 			}
-			if err := patchSpec(container, raw); err != nil {
-				return nil, fmt.Errorf("kind %s: %w", kind, err)
+			for kind, raw := range kinds {
+				ref := versionPrefix(version) + "." + kind
+				statusRef := ref + "Status"
+				listRef := ref + "List"
+
+				obj, status, l := cloneKindSchemas(kind, version, base, stat, list /*v3=*/, true)
+				doc.Components.Schemas[ref] = obj
+				doc.Components.Schemas[statusRef] = status
+				doc.Components.Schemas[listRef] = l
+
+				// patch .spec
+				container := findSpecContainer(obj)
+				if container == nil {
+					container = obj
+				}
+				if err := patchSpec(container, raw); err != nil {
+					return nil, fmt.Errorf("%s kind %s: %w", version, kind, err)
+				}
 			}
 		}
 
@@ -363,6 +383,18 @@ func BuildPostProcessV3(kindSchemas map[string]string) func(*spec3.OpenAPI) (*sp
 	}
 }
 
+// servesVersion reports whether any path belongs to the given
+// apps.cozystack.io version.
+func servesVersion[T any](paths map[string]T, version string) bool {
+	prefix := "/apis/apps.cozystack.io/" + version + "/"
+	for p := range paths {
+		if strings.HasPrefix(p, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
 // hasIntAndStringAnyOf returns true if anyOf is exactly a combination of string and integer.
 func hasIntAndStringAnyOf(anyOf []spec.Schema) bool {
 	seen := map[string]bool{}
@@ -422,18 +454,24 @@ func sanitizeForV2(s *spec.Schema) {
 	}
 }
 
-// KindSchemasFromConfig extracts the kind→OpenAPISchema mapping from a ResourceConfig.
-func KindSchemasFromConfig(rc *config.ResourceConfig) map[string]string {
-	m := make(map[string]string, len(rc.Resources))
+// KindSchemasFromConfig extracts the version→kind→OpenAPISchema mapping from
+// a ResourceConfig, one entry per version each kind is served under.
+func KindSchemasFromConfig(rc *config.ResourceConfig) map[string]map[string]string {
+	m := map[string]map[string]string{}
 	for _, r := range rc.Resources {
-		m[r.Application.Kind] = r.Application.OpenAPISchema
+		for _, v := range r.Application.ServedVersions() {
+			if m[v.Name] == nil {
+				m[v.Name] = map[string]string{}
+			}
+			m[v.Name][r.Application.Kind] = v.OpenAPISchema
+		}
 	}
 	return m
 }
 
 // ConfigureOpenAPI sets up OpenAPI v2 and v3 on a GenericAPIServer Config,
 // including the post-processors that clone Application schemas to per-kind schemas.
-func ConfigureOpenAPI(cfg *genericapiserver.Config, kindSchemas map[string]string, title, version string) {
+func ConfigureOpenAPI(cfg *genericapiserver.Config, kindSchemas map[string]map[string]string, title, version string) {
 	cfg.OpenAPIConfig = genericapiserver.DefaultOpenAPIConfig(
 		sampleopenapi.GetOpenAPIDefinitions, openapi.NewDefinitionNamer(apiserver.Scheme),
 	)
@@ -454,7 +492,7 @@ func ConfigureOpenAPI(cfg *genericapiserver.Config, kindSchemas map[string]strin
 // -----------------------------------------------------------------------------
 // BuildPostProcessV2 returns a Swagger post-processor that clones base
 // Application schemas into per-kind schemas and rewrites $ref pointers.
-func BuildPostProcessV2(kindSchemas map[string]string) func(*spec.Swagger) (*spec.Swagger, error) {
+func BuildPostProcessV2(kindSchemas map[string]map[string]string) func(*spec.Swagger) (*spec.Swagger, error) {
 	return func(sw *spec.Swagger) (*spec.Swagger, error) {
 		defs := sw.Definitions
 		base, ok1 := defs[baseRef]
@@ -464,20 +502,22 @@ func BuildPostProcessV2(kindSchemas map[string]string) func(*spec.Swagger) (*spe
 			return sw, nil // not the apps GV — nothing to patch
 		}
 
-		for kind, raw := range kindSchemas {
-			ref := apiPrefix + "." + kind
-			statusRef := ref + "Status"
-			listRef := ref + "List"
+		for version, kinds := range kindSchemas {
+			for kind, raw := range kinds {
+				ref := versionPrefix(version) + "." + kind
+				statusRef := ref + "Status"
+				listRef := ref + "List"
 
-			obj, status, l := cloneKindSchemas(kind, &base, &stat, &list, false)
+				obj, status, l := cloneKindSchemas(kind, version, &base, &stat, &list, false)
 
-			if err := patchSpec(obj, raw); err != nil {
-				return nil, fmt.Errorf("kind %s: %w", kind, err)
-			}
+				if err := patchSpec(obj, raw); err != nil {
+					return nil, fmt.Errorf("%s kind %s: %w", version, kind, err)
+				}
 
-			defs[ref] = *obj
-			defs[statusRef] = *status
-			defs[listRef] = *l
+				defs[ref] = *obj
+				defs[statusRef] = *status
+				defs[listRef] = *l
+			}
 		}
 
 		delete(defs, baseRef)
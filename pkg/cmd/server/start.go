@@ -24,9 +24,11 @@ import (
 	"fmt"
 	"io"
 	"net"
+	"slices"
 	"time"
 
 	v1alpha1 "github.com/cozystack/cozystack/api/v1alpha1"
+	"github.com/cozystack/cozystack/pkg/apis/apps/conversion"
 	appsv1alpha1 "github.com/cozystack/cozystack/pkg/apis/apps/v1alpha1"
 	corev1alpha1 "github.com/cozystack/cozystack/pkg/apis/core/v1alpha1"
 	sdnv1alpha1 "github.com/cozystack/cozystack/pkg/apis/sdn/v1alpha1"
@@ -34,7 +36,9 @@ import (
 	"github.com/cozystack/cozystack/pkg/config"
 	"github.com/spf13/cobra"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	genericapiserver "k8s.io/apiserver/pkg/server"
 	genericoptions "k8s.io/apiserver/pkg/server/options"
@@ -69,6 +73,15 @@ type CozyServerOptions struct {
 	HelmReleaseInstallTimeout string
 	HelmReleaseUpgradeTimeout string
 	HelmReleaseMaxHistory     int
+
+	// Raw price flag values, parsed into Pricing in Complete().
+	PriceCurrency     string
+	PriceCPU          string
+	PriceMemory       string
+	PriceStorage      string
+	PriceLoadBalancer string
+	PriceGPU          string
+	Pricing           config.Pricing
 }
 
 // NewCozyServerOptions returns a new instance of CozyServerOptions
@@ -147,6 +160,21 @@ func NewCommandStartCozyServer(ctx context.Context, defaults *CozyServerOptions)
 		"Number of release revisions Helm keeps for HelmReleases generated from Application "+
 			"resources (Spec.MaxHistory). 0 means unlimited; 5 matches Helm's default.")
 
+	// Prices the resource estimates of the Application render subresource
+	// and the tenant usage records are costed at.
+	flags.StringVar(&o.PriceCurrency, "price-currency", o.PriceCurrency,
+		"Currency reported with cost estimates, e.g. USD.")
+	flags.StringVar(&o.PriceCPU, "price-cpu", o.PriceCPU,
+		"Monthly price of one requested CPU core, as a decimal number. Empty or 0 leaves CPU out of cost estimates.")
+	flags.StringVar(&o.PriceMemory, "price-memory", o.PriceMemory,
+		"Monthly price of one requested GiB of memory, as a decimal number.")
+	flags.StringVar(&o.PriceStorage, "price-storage", o.PriceStorage,
+		"Monthly price of one GiB of persistent volume claims, as a decimal number.")
+	flags.StringVar(&o.PriceLoadBalancer, "price-loadbalancer", o.PriceLoadBalancer,
+		"Monthly price of one LoadBalancer Service, as a decimal number.")
+	flags.StringVar(&o.PriceGPU, "price-gpu", o.PriceGPU,
+		"Monthly price of one requested GPU, as a decimal number. Usage accounting only: GPUs are left out of render estimates.")
+
 	// Note: KEP-4330 component versioning functionality (k8s.io/apiserver/pkg/util/version)
 	// is not available in Kubernetes v0.34.1. The component versioning code has been removed.
 
@@ -190,12 +218,38 @@ func (o *CozyServerOptions) parseAndValidateHelmReleaseFlags() (helmReleaseFlagV
 	return v, nil
 }
 
+// parsePricingFlags parses the price flags into a Pricing.
+func (o *CozyServerOptions) parsePricingFlags() (config.Pricing, error) {
+	p := config.Pricing{Currency: o.PriceCurrency}
+	for _, f := range []struct {
+		name string
+		raw  string
+		dst  *resource.Quantity
+	}{
+		{"--price-cpu", o.PriceCPU, &p.CPU},
+		{"--price-memory", o.PriceMemory, &p.Memory},
+		{"--price-storage", o.PriceStorage, &p.Storage},
+		{"--price-loadbalancer", o.PriceLoadBalancer, &p.LoadBalancer},
+		{"--price-gpu", o.PriceGPU, &p.GPU},
+	} {
+		q, err := config.ParsePrice(f.name, f.raw)
+		if err != nil {
+			return p, err
+		}
+		*f.dst = q
+	}
+	return p, nil
+}
+
 // Complete fills in the fields that are not set
 func (o *CozyServerOptions) Complete() error {
 	hrFlags, err := o.parseAndValidateHelmReleaseFlags()
 	if err != nil {
 		return err
 	}
+	if o.Pricing, err = o.parsePricingFlags(); err != nil {
+		return err
+	}
 
 	scheme := runtime.NewScheme()
 	if err := v1alpha1.AddToScheme(scheme); err != nil {
@@ -315,6 +369,10 @@ func (o *CozyServerOptions) Complete() error {
 			)
 		}
 		release.HelmInstallDisableWait = disableWait
+		versions, err := versionsFromDefinition(crd.Spec.Application.Versions)
+		if err != nil {
+			return fmt.Errorf("ApplicationDefinition %q has invalid versions: %w", crd.Name, err)
+		}
 		resource := config.Resource{
 			Application: config.ApplicationConfig{
 				Kind:          crd.Spec.Application.Kind,
@@ -322,15 +380,61 @@ func (o *CozyServerOptions) Complete() error {
 				Plural:        crd.Spec.Application.Plural,
 				ShortNames:    []string{}, // TODO: implement shortnames
 				OpenAPISchema: crd.Spec.Application.OpenAPISchema,
+				Versions:      versions,
 			},
 			Release: release,
 		}
+		if move := crd.Spec.Application.Move; move != nil {
+			resource.Application.Move = &config.MoveConfig{
+				Data:            string(move.Data),
+				BackupClassName: move.BackupClassName,
+			}
+		}
 		o.ResourceConfig.Resources = append(o.ResourceConfig.Resources, resource)
 	}
 
 	return nil
 }
 
+// versionsFromDefinition converts the served versions of an
+// ApplicationDefinition into their config form. Conversion rules are compiled
+// here so that a broken rule fails start-up loudly instead of silently
+// serving unconverted specs.
+func versionsFromDefinition(in []v1alpha1.ApplicationDefinitionVersion) ([]config.VersionConfig, error) {
+	if len(in) == 0 {
+		return nil, nil
+	}
+	out := make([]config.VersionConfig, 0, len(in))
+	seen := map[string]bool{}
+	for _, v := range in {
+		if !slices.ContainsFunc(appsv1alpha1.ServedGroupVersions, func(gv schema.GroupVersion) bool { return gv.Version == v.Name }) {
+			return nil, fmt.Errorf("unknown version %q", v.Name)
+		}
+		if seen[v.Name] {
+			return nil, fmt.Errorf("version %q is listed twice", v.Name)
+		}
+		seen[v.Name] = true
+		vc := config.VersionConfig{Name: v.Name, OpenAPISchema: v.OpenAPISchema}
+		for _, r := range v.Conversion {
+			rule := config.ConversionRule{
+				Type:   string(r.Type),
+				Path:   r.Path,
+				To:     r.To,
+				Values: r.Values,
+			}
+			if r.Value != nil {
+				rule.Value = r.Value.Raw
+			}
+			vc.Conversion = append(vc.Conversion, rule)
+		}
+		if _, err := conversion.New(vc.Conversion); err != nil {
+			return nil, fmt.Errorf("version %s: %w", v.Name, err)
+		}
+		out = append(out, vc)
+	}
+	return out, nil
+}
+
 // Validate checks the correctness of the options
 func (o CozyServerOptions) Validate(args []string) error {
 	var allErrors []error
@@ -388,6 +492,7 @@ func (o *CozyServerOptions) Config() (*apiserver.Config, error) {
 	config := &apiserver.Config{
 		GenericConfig:  serverConfig,
 		ResourceConfig: o.ResourceConfig,
+		Pricing:        o.Pricing,
 	}
 	return config, nil
 }
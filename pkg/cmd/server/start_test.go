@@ -20,6 +20,10 @@ import (
 	"strings"
 	"testing"
 	"time"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+
+	"github.com/cozystack/cozystack/api/v1alpha1"
 )
 
 // validHelmReleaseFlags returns a CozyServerOptions populated with the same
@@ -184,3 +188,46 @@ func TestNewCozyServerOptions_DefaultsValidate(t *testing.T) {
 		t.Fatalf("default options must validate, got: %v", err)
 	}
 }
+
+// Pins that ApplicationDefinition versions are checked at startup: unknown
+// or duplicate names and rules that do not compile fail Complete() instead
+// of surfacing as broken requests later.
+func TestVersionsFromDefinition(t *testing.T) {
+	got, err := versionsFromDefinition([]v1alpha1.ApplicationDefinitionVersion{{
+		Name: "v1beta1",
+		Conversion: []v1alpha1.ApplicationDefinitionConversionRule{
+			{Type: v1alpha1.ConversionDefault, Path: "storage.class", Value: &apiextensionsv1.JSON{Raw: []byte(`"replicated"`)}},
+		},
+	}})
+	if err != nil {
+		t.Fatalf("valid versions rejected: %v", err)
+	}
+	if len(got) != 1 || got[0].Name != "v1beta1" || string(got[0].Conversion[0].Value) != `"replicated"` {
+		t.Errorf("versionsFromDefinition = %+v", got)
+	}
+
+	for name, tc := range map[string]struct {
+		in   []v1alpha1.ApplicationDefinitionVersion
+		want string
+	}{
+		"unknown version": {
+			in:   []v1alpha1.ApplicationDefinitionVersion{{Name: "v2"}},
+			want: "unknown version",
+		},
+		"duplicate version": {
+			in:   []v1alpha1.ApplicationDefinitionVersion{{Name: "v1beta1"}, {Name: "v1beta1"}},
+			want: "listed twice",
+		},
+		"invalid rule": {
+			in: []v1alpha1.ApplicationDefinitionVersion{{
+				Name:       "v1alpha1",
+				Conversion: []v1alpha1.ApplicationDefinitionConversionRule{{Type: v1alpha1.ConversionRename, Path: "a", To: "b.c"}},
+			}},
+			want: "single key name",
+		},
+	} {
+		if _, err := versionsFromDefinition(tc.in); err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("%s: error = %v, want it to contain %q", name, err, tc.want)
+		}
+	}
+}
@@ -144,6 +144,70 @@ type ApplicationConfig struct {
 	Plural        string   `yaml:"plural"`
 	ShortNames    []string `yaml:"shortNames"`
 	OpenAPISchema string   `yaml:"openAPISchema"`
+	// Versions are the apps.cozystack.io versions the kind is served under.
+	// Populated from spec.application.versions on the ApplicationDefinition
+	// at start-up; use ServedVersions rather than reading it directly.
+	Versions []VersionConfig `yaml:"versions,omitempty"`
+	// Move enables the move subresource; nil when the kind cannot be moved.
+	Move *MoveConfig `yaml:"move,omitempty"`
+}
+
+// MoveConfig mirrors ApplicationDefinitionMove.
+type MoveConfig struct {
+	Data            string `yaml:"data"`
+	BackupClassName string `yaml:"backupClassName,omitempty"`
+}
+
+// DefaultAppsVersion is the apps.cozystack.io version a kind is served under
+// when its ApplicationDefinition lists no versions.
+const DefaultAppsVersion = "v1alpha1"
+
+// VersionConfig describes one served version of an Application kind.
+type VersionConfig struct {
+	Name string `yaml:"name"`
+	// OpenAPISchema is the spec schema of this version. Empty means the
+	// kind's OpenAPISchema, i.e. the shape of the chart values.
+	OpenAPISchema string `yaml:"openAPISchema,omitempty"`
+	// Conversion translates a spec of this version into chart values.
+	Conversion []ConversionRule `yaml:"conversion,omitempty"`
+}
+
+// ConversionRule mirrors ApplicationDefinitionConversionRule; see
+// pkg/apis/apps/conversion for the semantics of each type.
+type ConversionRule struct {
+	Type string `yaml:"type"`
+	Path string `yaml:"path"`
+	To   string `yaml:"to,omitempty"`
+	// Value is the raw JSON value of a Default rule.
+	Value  []byte            `yaml:"value,omitempty"`
+	Values map[string]string `yaml:"values,omitempty"`
+}
+
+// ServedVersions returns the versions the kind is served under, in the
+// order they were declared, with empty schemas resolved to OpenAPISchema.
+// A kind without explicit versions is served as DefaultAppsVersion only.
+func (a ApplicationConfig) ServedVersions() []VersionConfig {
+	if len(a.Versions) == 0 {
+		return []VersionConfig{{Name: DefaultAppsVersion, OpenAPISchema: a.OpenAPISchema}}
+	}
+	out := make([]VersionConfig, len(a.Versions))
+	for i, v := range a.Versions {
+		if v.OpenAPISchema == "" {
+			v.OpenAPISchema = a.OpenAPISchema
+		}
+		out[i] = v
+	}
+	return out
+}
+
+// ServedVersion returns the named served version and whether it exists.
+func (a ApplicationConfig) ServedVersion(name string) (VersionConfig, bool) {
+	for _, v := range a.ServedVersions() {
+		if v.Name == name {
+			return v, true
+		}
+	}
+	return VersionConfig{}, false
 }
 
 // ReleaseConfig contains the release settings.
@@ -0,0 +1,62 @@
+/*
+Copyright 2026 The Cozystack Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// Pricing holds the monthly list prices cost estimates are computed from.
+// A zero price leaves its resource out of the estimate; a Pricing with no
+// price set disables cost estimates altogether.
+type Pricing struct {
+	// Currency is reported alongside every amount, e.g. "USD".
+	Currency string
+	// CPU is the price of one requested core.
+	CPU resource.Quantity
+	// Memory is the price of one requested GiB.
+	Memory resource.Quantity
+	// Storage is the price of one GiB of persistent volume claims.
+	Storage resource.Quantity
+	// LoadBalancer is the price of one LoadBalancer Service.
+	LoadBalancer resource.Quantity
+	// GPU is the price of one requested GPU, of any vendor.
+	GPU resource.Quantity
+}
+
+// IsZero reports whether no price is set.
+func (p Pricing) IsZero() bool {
+	return p.CPU.IsZero() && p.Memory.IsZero() && p.Storage.IsZero() && p.LoadBalancer.IsZero() && p.GPU.IsZero()
+}
+
+// ParsePrice parses a non-negative decimal price flag. An empty value is a
+// zero price.
+func ParsePrice(flagName, raw string) (resource.Quantity, error) {
+	if raw == "" {
+		return resource.Quantity{}, nil
+	}
+	q, err := resource.ParseQuantity(raw)
+	if err != nil {
+		return resource.Quantity{}, fmt.Errorf("invalid price for %s=%q: %w", flagName, raw, err)
+	}
+	if q.Sign() < 0 {
+		return resource.Quantity{}, fmt.Errorf("%s must be >= 0 (got %q)", flagName, raw)
+	}
+	return q, nil
+}
@@ -0,0 +1,522 @@
+/*
+Copyright 2026 The Cozystack Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package helmrender renders the manifests a Flux HelmRelease would install,
+// without installing it. The chart is the artifact source-controller serves
+// for the release's chartRef, the values are merged the way helm-controller
+// merges them (valuesFrom in order, then spec.values), and the chart is
+// rendered in-process by the Helm engine, as `helm template --skip-tests`
+// would render it.
+package helmrender
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	helmv2 "github.com/fluxcd/helm-controller/api/v2"
+	sourcev1 "github.com/fluxcd/source-controller/api/v1"
+	"helm.sh/helm/v3/pkg/chart/loader"
+	"helm.sh/helm/v3/pkg/chartutil"
+	"helm.sh/helm/v3/pkg/engine"
+	"helm.sh/helm/v3/pkg/release"
+	"helm.sh/helm/v3/pkg/releaseutil"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/client-go/discovery"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// maxArtifactSize bounds the chart artifact download and its extracted
+	// size; charts are small, anything larger is refused rather than
+	// buffered.
+	maxArtifactSize = 64 << 20
+	// defaultValuesKey is the data key helm-controller reads valuesFrom
+	// entries from when valuesKey is unset.
+	defaultValuesKey = "values.yaml"
+	// renderTimeout bounds a single render, download included.
+	renderTimeout = 30 * time.Second
+	// MaskedValue replaces the string values of valuesFrom Secrets the
+	// caller may not read.
+	MaskedValue = "<masked>"
+)
+
+// Result is a rendered HelmRelease.
+type Result struct {
+	// Revision is the revision of the chart artifact that was rendered.
+	Revision string
+	// Objects are the rendered objects, in manifest order.
+	Objects []*unstructured.Unstructured
+	// MaskedSecrets names the valuesFrom Secrets whose values were masked.
+	MaskedSecrets []string
+}
+
+// Options tune a single render.
+type Options struct {
+	// CanReadSecret reports whether the caller the preview is rendered for
+	// may read the valuesFrom Secret name in the HelmRelease namespace. The
+	// renderer reads Secrets with its own identity, so the string values of
+	// a Secret the caller may not read are replaced by MaskedValue before
+	// rendering; otherwise they could surface in rendered ConfigMaps, env
+	// values and the like. Nil masks every Secret.
+	CanReadSecret func(ctx context.Context, name string) (bool, error)
+}
+
+// Renderer renders HelmReleases. Client reads the chart source and the
+// valuesFrom Secrets and ConfigMaps; it should not be cache-backed, as
+// neither kind is otherwise watched. Discovery, when set, supplies the
+// cluster version and API versions charts see as .Capabilities; without it
+// they see Helm's defaults, as under `helm template`.
+type Renderer struct {
+	Client    client.Client
+	Discovery discovery.DiscoveryInterface
+	HTTP      *http.Client
+}
+
+// New returns a Renderer reading through c and discovering capabilities
+// through dc, which may be nil.
+func New(c client.Client, dc discovery.DiscoveryInterface) *Renderer {
+	return &Renderer{Client: c, Discovery: dc, HTTP: &http.Client{Timeout: renderTimeout}}
+}
+
+// Render renders hr as helm-controller would install it.
+func (r *Renderer) Render(ctx context.Context, hr *helmv2.HelmRelease, opts Options) (*Result, error) {
+	ctx, cancel := context.WithTimeout(ctx, renderTimeout)
+	defer cancel()
+
+	if hr.Spec.ChartRef == nil {
+		return nil, fmt.Errorf("HelmRelease %s/%s has no chartRef", hr.Namespace, hr.Name)
+	}
+	src, err := r.source(ctx, hr)
+	if err != nil {
+		return nil, err
+	}
+	artifact := src.GetArtifact()
+	if artifact == nil || artifact.URL == "" {
+		return nil, fmt.Errorf("%s %s/%s has no artifact yet", hr.Spec.ChartRef.Kind, chartRefNamespace(hr), hr.Spec.ChartRef.Name)
+	}
+
+	dir, err := os.MkdirTemp("", "helmrender-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	root, err := r.fetchChart(ctx, artifact.URL, artifact.Digest, dir)
+	if err != nil {
+		return nil, fmt.Errorf("fetch chart %s: %w", artifact.Revision, err)
+	}
+	values, masked, err := r.values(ctx, hr, opts)
+	if err != nil {
+		return nil, err
+	}
+	manifests, err := r.template(hr, root, values)
+	if err != nil {
+		return nil, err
+	}
+	objects, err := ParseManifests(manifests)
+	if err != nil {
+		return nil, err
+	}
+	return &Result{Revision: artifact.Revision, Objects: objects, MaskedSecrets: masked}, nil
+}
+
+// source resolves the chartRef to its source-controller object.
+func (r *Renderer) source(ctx context.Context, hr *helmv2.HelmRelease) (sourcev1.Source, error) {
+	var src interface {
+		sourcev1.Source
+		client.Object
+	}
+	switch hr.Spec.ChartRef.Kind {
+	case sourcev1.ExternalArtifactKind:
+		src = &sourcev1.ExternalArtifact{}
+	case sourcev1.HelmChartKind:
+		src = &sourcev1.HelmChart{}
+	case sourcev1.OCIRepositoryKind:
+		src = &sourcev1.OCIRepository{}
+	default:
+		return nil, fmt.Errorf("unsupported chartRef kind %q", hr.Spec.ChartRef.Kind)
+	}
+	key := client.ObjectKey{Namespace: chartRefNamespace(hr), Name: hr.Spec.ChartRef.Name}
+	if err := r.Client.Get(ctx, key, src); err != nil {
+		return nil, fmt.Errorf("get %s %s: %w", hr.Spec.ChartRef.Kind, key, err)
+	}
+	return src, nil
+}
+
+func chartRefNamespace(hr *helmv2.HelmRelease) string {
+	if hr.Spec.ChartRef.Namespace != "" {
+		return hr.Spec.ChartRef.Namespace
+	}
+	return hr.Namespace
+}
+
+// fetchChart downloads the artifact, checks its digest and extracts it into
+// dir, returning the chart root: dir itself or its single top-level
+// directory, whichever holds Chart.yaml.
+func (r *Renderer) fetchChart(ctx context.Context, url, digest, dir string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := r.HTTP.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("GET %s: %s", url, resp.Status)
+	}
+	raw, err := io.ReadAll(io.LimitReader(resp.Body, maxArtifactSize+1))
+	if err != nil {
+		return "", err
+	}
+	if len(raw) > maxArtifactSize {
+		return "", fmt.Errorf("artifact exceeds %d bytes", maxArtifactSize)
+	}
+	if algo, want, ok := strings.Cut(digest, ":"); ok && algo == "sha256" {
+		sum := sha256.Sum256(raw)
+		if got := hex.EncodeToString(sum[:]); got != want {
+			return "", fmt.Errorf("artifact digest sha256:%s does not match %s", got, digest)
+		}
+	}
+	if err := extractTarGz(bytes.NewReader(raw), dir); err != nil {
+		return "", err
+	}
+	return chartRoot(dir)
+}
+
+// extractTarGz extracts regular files and directories, refusing entries that
+// would land outside dir.
+func extractTarGz(r io.Reader, dir string) error {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return err
+	}
+	defer gz.Close()
+	tr := tar.NewReader(gz)
+	var total int64
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dir, filepath.FromSlash(hdr.Name))
+		if !strings.HasPrefix(target, filepath.Clean(dir)+string(os.PathSeparator)) {
+			return fmt.Errorf("archive entry %q escapes the chart directory", hdr.Name)
+		}
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, 0o755); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			total += hdr.Size
+			if total > maxArtifactSize {
+				return fmt.Errorf("extracted artifact exceeds %d bytes", maxArtifactSize)
+			}
+			if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+				return err
+			}
+			f, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o644)
+			if err != nil {
+				return err
+			}
+			_, err = io.Copy(f, io.LimitReader(tr, hdr.Size))
+			if cerr := f.Close(); err == nil {
+				err = cerr
+			}
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func chartRoot(dir string) (string, error) {
+	if _, err := os.Stat(filepath.Join(dir, "Chart.yaml")); err == nil {
+		return dir, nil
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return "", err
+	}
+	for _, e := range entries {
+		if !e.IsDir() {
+			continue
+		}
+		if _, err := os.Stat(filepath.Join(dir, e.Name(), "Chart.yaml")); err == nil {
+			return filepath.Join(dir, e.Name()), nil
+		}
+	}
+	return "", fmt.Errorf("artifact holds no Chart.yaml")
+}
+
+// values merges the valuesFrom entries and spec.values in the order
+// helm-controller merges them, masking the Secrets the caller may not read.
+func (r *Renderer) values(ctx context.Context, hr *helmv2.HelmRelease, opts Options) (map[string]any, []string, error) {
+	out := map[string]any{}
+	var masked []string
+	for _, ref := range hr.Spec.ValuesFrom {
+		if ref.TargetPath != "" {
+			return nil, nil, fmt.Errorf("valuesFrom %s/%s: targetPath is not supported by the renderer", ref.Kind, ref.Name)
+		}
+		key := ref.ValuesKey
+		if key == "" {
+			key = defaultValuesKey
+		}
+		data, found, err := r.valuesFrom(ctx, hr.Namespace, ref.Kind, ref.Name, key)
+		if err != nil {
+			return nil, nil, err
+		}
+		if !found {
+			if ref.Optional {
+				continue
+			}
+			return nil, nil, fmt.Errorf("valuesFrom %s/%s: key %q not found", ref.Kind, ref.Name, key)
+		}
+		values, err := chartutil.ReadValues(data)
+		if err != nil {
+			return nil, nil, fmt.Errorf("valuesFrom %s/%s: %w", ref.Kind, ref.Name, err)
+		}
+		if ref.Kind == "Secret" {
+			readable := false
+			if opts.CanReadSecret != nil {
+				if readable, err = opts.CanReadSecret(ctx, ref.Name); err != nil {
+					return nil, nil, err
+				}
+			}
+			if !readable {
+				maskStrings(values)
+				masked = append(masked, ref.Name)
+			}
+		}
+		out = mergeMaps(out, values)
+	}
+	if hr.Spec.Values != nil && len(hr.Spec.Values.Raw) > 0 {
+		values, err := chartutil.ReadValues(hr.Spec.Values.Raw)
+		if err != nil {
+			return nil, nil, fmt.Errorf("spec.values: %w", err)
+		}
+		out = mergeMaps(out, values)
+	}
+	return out, masked, nil
+}
+
+func (r *Renderer) valuesFrom(ctx context.Context, namespace, kind, name, key string) ([]byte, bool, error) {
+	key = strings.TrimSpace(key)
+	switch kind {
+	case "Secret":
+		s := &corev1.Secret{}
+		if err := r.Client.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, s); err != nil {
+			if apierrors.IsNotFound(err) {
+				return nil, false, nil
+			}
+			return nil, false, err
+		}
+		data, ok := s.Data[key]
+		return data, ok, nil
+	case "ConfigMap":
+		cm := &corev1.ConfigMap{}
+		if err := r.Client.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, cm); err != nil {
+			if apierrors.IsNotFound(err) {
+				return nil, false, nil
+			}
+			return nil, false, err
+		}
+		data, ok := cm.Data[key]
+		return []byte(data), ok, nil
+	default:
+		return nil, false, fmt.Errorf("valuesFrom %s/%s: unsupported kind", kind, name)
+	}
+}
+
+// mergeMaps merges b into a, recursing into maps present in both, as
+// helm-controller merges values layers.
+func mergeMaps(a, b map[string]any) map[string]any {
+	out := make(map[string]any, len(a))
+	for k, v := range a {
+		out[k] = v
+	}
+	for k, v := range b {
+		if bv, ok := v.(map[string]any); ok {
+			if av, ok := out[k].(map[string]any); ok {
+				out[k] = mergeMaps(av, bv)
+				continue
+			}
+		}
+		out[k] = v
+	}
+	return out
+}
+
+// maskStrings replaces every string in v with MaskedValue. Numbers and
+// booleans are kept so that templates computing with them still render.
+func maskStrings(v any) any {
+	switch t := v.(type) {
+	case map[string]any:
+		for k, e := range t {
+			t[k] = maskStrings(e)
+		}
+	case chartutil.Values:
+		for k, e := range t {
+			t[k] = maskStrings(e)
+		}
+	case []any:
+		for i, e := range t {
+			t[i] = maskStrings(e)
+		}
+	case string:
+		return MaskedValue
+	}
+	return v
+}
+
+// template renders the chart at root with the Helm engine. The output
+// matches `helm template --skip-tests`: the manifests in install order,
+// then the hooks other than tests. NOTES.txt is dropped, and lookup
+// returns nothing, as the engine gets no cluster client.
+func (r *Renderer) template(hr *helmv2.HelmRelease, root string, values map[string]any) ([]byte, error) {
+	chrt, err := loader.LoadDir(root)
+	if err != nil {
+		return nil, fmt.Errorf("load chart: %w", err)
+	}
+	caps := r.capabilities()
+	if chrt.Metadata.KubeVersion != "" && !chartutil.IsCompatibleRange(chrt.Metadata.KubeVersion, caps.KubeVersion.String()) {
+		return nil, fmt.Errorf("chart requires kubeVersion %s, which is incompatible with Kubernetes %s", chrt.Metadata.KubeVersion, caps.KubeVersion.String())
+	}
+	if err := chartutil.ProcessDependenciesWithMerge(chrt, values); err != nil {
+		return nil, fmt.Errorf("process chart dependencies: %w", err)
+	}
+	renderValues, err := chartutil.ToRenderValues(chrt, values, chartutil.ReleaseOptions{
+		Name:      hr.GetReleaseName(),
+		Namespace: hr.GetReleaseNamespace(),
+		Revision:  1,
+		IsInstall: true,
+	}, caps)
+	if err != nil {
+		return nil, err
+	}
+	files, err := engine.Render(chrt, renderValues)
+	if err != nil {
+		return nil, err
+	}
+	for name := range files {
+		if path.Base(name) == "NOTES.txt" {
+			delete(files, name)
+		}
+	}
+	hooks, manifests, err := releaseutil.SortManifests(files, caps.APIVersions, releaseutil.InstallOrder)
+	if err != nil {
+		return nil, err
+	}
+	var out bytes.Buffer
+	for _, m := range manifests {
+		fmt.Fprintf(&out, "---\n# Source: %s\n%s\n", m.Name, m.Content)
+	}
+	for _, h := range hooks {
+		if isTestHook(h) {
+			continue
+		}
+		fmt.Fprintf(&out, "---\n# Source: %s\n%s\n", h.Path, h.Manifest)
+	}
+	return out.Bytes(), nil
+}
+
+func isTestHook(h *release.Hook) bool {
+	for _, e := range h.Events {
+		if e == release.HookTest {
+			return true
+		}
+	}
+	return false
+}
+
+// capabilities reports the cluster as discovered, falling back to Helm's
+// defaults for whatever discovery cannot tell.
+func (r *Renderer) capabilities() *chartutil.Capabilities {
+	caps := chartutil.DefaultCapabilities.Copy()
+	if r.Discovery == nil {
+		return caps
+	}
+	if v, err := r.Discovery.ServerVersion(); err == nil {
+		caps.KubeVersion = chartutil.KubeVersion{Version: v.GitVersion, Major: v.Major, Minor: v.Minor}
+	}
+	groups, resources, err := r.Discovery.ServerGroupsAndResources()
+	if err != nil && !discovery.IsGroupDiscoveryFailedError(err) {
+		return caps
+	}
+	seen := map[string]bool{}
+	var versions chartutil.VersionSet
+	add := func(v string) {
+		if !seen[v] {
+			seen[v] = true
+			versions = append(versions, v)
+		}
+	}
+	for _, g := range groups {
+		for _, gv := range g.Versions {
+			add(gv.GroupVersion)
+		}
+	}
+	for _, list := range resources {
+		for _, res := range list.APIResources {
+			add(path.Join(list.GroupVersion, res.Kind))
+		}
+	}
+	if len(versions) > 0 {
+		caps.APIVersions = versions
+	}
+	return caps
+}
+
+// ParseManifests splits a multi-document YAML stream into objects, skipping
+// empty documents.
+func ParseManifests(manifests []byte) ([]*unstructured.Unstructured, error) {
+	dec := utilyaml.NewYAMLOrJSONDecoder(bytes.NewReader(manifests), 4096)
+	var out []*unstructured.Unstructured
+	for {
+		obj := map[string]any{}
+		if err := dec.Decode(&obj); err != nil {
+			if errors.Is(err, io.EOF) {
+				return out, nil
+			}
+			return nil, fmt.Errorf("parse rendered manifests: %w", err)
+		}
+		if len(obj) == 0 {
+			continue
+		}
+		out = append(out, &unstructured.Unstructured{Object: obj})
+	}
+}
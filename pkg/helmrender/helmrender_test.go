@@ -0,0 +1,225 @@
+/*
+Copyright 2026 The Cozystack Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helmrender
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	helmv2 "github.com/fluxcd/helm-controller/api/v2"
+	"github.com/fluxcd/pkg/apis/meta"
+	sourcev1 "github.com/fluxcd/source-controller/api/v1"
+	"helm.sh/helm/v3/pkg/chartutil"
+	corev1 "k8s.io/api/core/v1"
+	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// testChart renders the release name and namespace, the merged values and
+// the Capabilities it saw into a ConfigMap, next to a hook, a test hook and
+// NOTES.txt.
+var testChart = map[string]string{
+	"postgres/Chart.yaml":  "apiVersion: v2\nname: postgres\nversion: 1.2.3\n",
+	"postgres/values.yaml": "replicas: 1\nimage: postgres:16\n",
+	"postgres/templates/configmap.yaml": `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: {{ .Release.Name }}
+  namespace: {{ .Release.Namespace }}
+data:
+  domain: {{ .Values._cluster.domain | quote }}
+  password: {{ .Values._cluster.password | quote }}
+  replicas: {{ .Values.replicas | quote }}
+  image: {{ .Values.image | quote }}
+  kubeVersion: {{ .Capabilities.KubeVersion.Version | quote }}
+`,
+	"postgres/templates/hook.yaml": `apiVersion: batch/v1
+kind: Job
+metadata:
+  name: {{ .Release.Name }}-migrate
+  annotations:
+    helm.sh/hook: pre-install
+`,
+	"postgres/templates/test.yaml": `apiVersion: v1
+kind: Pod
+metadata:
+  name: {{ .Release.Name }}-test
+  annotations:
+    helm.sh/hook: test
+`,
+	"postgres/templates/NOTES.txt": "Installed {{ .Release.Name }}.\n",
+}
+
+func chartArchive(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for name, body := range files {
+		if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0o644, Size: int64(len(body)), Typeflag: tar.TypeReg}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write([]byte(body)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func newTestRenderer(t *testing.T, archive []byte, digest string) (*Renderer, *helmv2.HelmRelease) {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write(archive)
+	}))
+	t.Cleanup(srv.Close)
+
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = sourcev1.AddToScheme(scheme)
+	artifact := &sourcev1.ExternalArtifact{
+		ObjectMeta: metav1.ObjectMeta{Name: "postgres", Namespace: "cozy-system"},
+		Status: sourcev1.ExternalArtifactStatus{Artifact: &meta.Artifact{
+			URL: srv.URL + "/postgres.tgz", Revision: "1.2.3@sha256:abc", Digest: digest,
+		}},
+	}
+	values := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "cozystack-values", Namespace: "tenant-a"},
+		Data:       map[string][]byte{"values.yaml": []byte("_cluster: {domain: example.org, password: hunter2}\nreplicas: 3\n")},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(artifact, values).WithStatusSubresource(artifact).Build()
+
+	hr := &helmv2.HelmRelease{
+		ObjectMeta: metav1.ObjectMeta{Name: "postgres-db", Namespace: "tenant-a"},
+		Spec: helmv2.HelmReleaseSpec{
+			ChartRef: &helmv2.CrossNamespaceSourceReference{Kind: "ExternalArtifact", Name: "postgres", Namespace: "cozy-system"},
+			ValuesFrom: []helmv2.ValuesReference{
+				{Kind: "Secret", Name: "cozystack-values"},
+				{Kind: "ConfigMap", Name: "absent", Optional: true},
+			},
+			Values: &apiextv1.JSON{Raw: []byte(`{"replicas":2}`)},
+		},
+	}
+	return New(c, nil), hr
+}
+
+func renderTestChart(t *testing.T, opts Options) *Result {
+	t.Helper()
+	archive := chartArchive(t, testChart)
+	sum := sha256.Sum256(archive)
+	r, hr := newTestRenderer(t, archive, "sha256:"+hex.EncodeToString(sum[:]))
+	res, err := r.Render(context.Background(), hr, opts)
+	if err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+	return res
+}
+
+func TestRender(t *testing.T) {
+	res := renderTestChart(t, Options{CanReadSecret: func(context.Context, string) (bool, error) { return true, nil }})
+	if res.Revision != "1.2.3@sha256:abc" {
+		t.Errorf("Revision = %q", res.Revision)
+	}
+	var kinds []string
+	for _, obj := range res.Objects {
+		kinds = append(kinds, obj.GetKind()+"/"+obj.GetName())
+	}
+	if got, want := strings.Join(kinds, ","), "ConfigMap/postgres-db,Job/postgres-db-migrate"; got != want {
+		t.Fatalf("rendered %s, want %s (manifests, then hooks; no tests, no NOTES.txt)", got, want)
+	}
+	cm := res.Objects[0]
+	if cm.GetNamespace() != "tenant-a" {
+		t.Errorf("namespace = %q, want tenant-a", cm.GetNamespace())
+	}
+	data := cm.Object["data"].(map[string]any)
+	for key, want := range map[string]string{
+		"domain":      "example.org",
+		"password":    "hunter2",
+		"replicas":    "2", // spec.values over valuesFrom over the chart defaults
+		"image":       "postgres:16",
+		"kubeVersion": chartutil.DefaultCapabilities.KubeVersion.Version,
+	} {
+		if data[key] != want {
+			t.Errorf("data.%s = %v, want %q", key, data[key], want)
+		}
+	}
+	if len(res.MaskedSecrets) != 0 {
+		t.Errorf("MaskedSecrets = %v, want none", res.MaskedSecrets)
+	}
+}
+
+func TestRender_MasksUnreadableSecrets(t *testing.T) {
+	res := renderTestChart(t, Options{CanReadSecret: func(_ context.Context, name string) (bool, error) {
+		return name != "cozystack-values", nil
+	}})
+	data := res.Objects[0].Object["data"].(map[string]any)
+	if data["password"] != MaskedValue || data["domain"] != MaskedValue {
+		t.Errorf("Secret values reached the output: %v", data)
+	}
+	if data["replicas"] != "2" {
+		t.Errorf("data.replicas = %v, want the spec value 2", data["replicas"])
+	}
+	if len(res.MaskedSecrets) != 1 || res.MaskedSecrets[0] != "cozystack-values" {
+		t.Errorf("MaskedSecrets = %v, want [cozystack-values]", res.MaskedSecrets)
+	}
+}
+
+func TestRender_ChartAtArchiveRoot(t *testing.T) {
+	archive := chartArchive(t, map[string]string{
+		"Chart.yaml":          "apiVersion: v2\nname: postgres\nversion: 1.2.3\n",
+		"templates/sa.yaml":   "apiVersion: v1\nkind: ServiceAccount\nmetadata:\n  name: {{ .Release.Name }}\n",
+		"templates/_help.tpl": `{{- define "x" }}{{ end }}`,
+	})
+	r, hr := newTestRenderer(t, archive, "")
+	res, err := r.Render(context.Background(), hr, Options{})
+	if err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+	if len(res.Objects) != 1 || res.Objects[0].GetName() != "postgres-db" {
+		t.Fatalf("rendered %v, want ServiceAccount postgres-db", res.Objects)
+	}
+}
+
+func TestRender_DigestMismatch(t *testing.T) {
+	archive := chartArchive(t, map[string]string{"Chart.yaml": "name: postgres\n"})
+	r, hr := newTestRenderer(t, archive, "sha256:0000")
+	if _, err := r.Render(context.Background(), hr, Options{}); err == nil || !strings.Contains(err.Error(), "does not match") {
+		t.Fatalf("Render error = %v, want a digest mismatch", err)
+	}
+}
+
+func TestExtractTarGz_RejectsEscapingEntries(t *testing.T) {
+	archive := chartArchive(t, map[string]string{"../evil": "x"})
+	if err := extractTarGz(bytes.NewReader(archive), t.TempDir()); err == nil {
+		t.Fatal("an entry escaping the chart directory was extracted")
+	}
+}
@@ -0,0 +1,86 @@
+/*
+Copyright 2026 The Cozystack Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package application
+
+import (
+	"context"
+
+	helmv2 "github.com/fluxcd/helm-controller/api/v2"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/cozystack/cozystack/pkg/apis/apps/v1alpha1"
+)
+
+// getApplicationHelmRelease resolves the HelmRelease backing the named
+// Application in the request namespace, returning a NotFound for the
+// Application (not the HelmRelease) when it is missing or is not labelled as
+// an Application of this kind.
+func (r *REST) getApplicationHelmRelease(ctx context.Context, name string) (*helmv2.HelmRelease, error) {
+	namespace, err := r.getNamespace(ctx)
+	if err != nil {
+		return nil, err
+	}
+	hr := &helmv2.HelmRelease{}
+	if err := r.c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: r.releaseConfig.Prefix + name}, hr, &client.GetOptions{Raw: &metav1.GetOptions{}}); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, apierrors.NewNotFound(r.gvr.GroupResource(), name)
+		}
+		klog.Errorf("Error retrieving HelmRelease for resource %s: %v", name, err)
+		return nil, err
+	}
+	if !r.hasRequiredApplicationLabelsWithName(hr, name) {
+		return nil, apierrors.NewNotFound(r.gvr.GroupResource(), name)
+	}
+	return hr, nil
+}
+
+// applicationNamespaces returns the namespaces an Application's child objects
+// can live in: the HelmRelease namespace, plus the computed workload namespace
+// for Tenants (see computeTenantNamespace).
+func (r *REST) applicationNamespaces(hrNamespace, appName string) []string {
+	namespaces := []string{hrNamespace}
+	if r.kindName == "Tenant" {
+		if ns := r.computeTenantNamespace(hrNamespace, appName); ns != hrNamespace {
+			namespaces = append(namespaces, ns)
+		}
+	}
+	return namespaces
+}
+
+// lineageLabels returns the labels the lineage webhook stamps on every child
+// object of the named Application.
+func (r *REST) lineageLabels(appName string) client.MatchingLabels {
+	return client.MatchingLabels{
+		appsv1alpha1.ApplicationKindLabel:  r.kindName,
+		appsv1alpha1.ApplicationGroupLabel: r.gvk.Group,
+		appsv1alpha1.ApplicationNameLabel:  appName,
+	}
+}
+
+// hasLineageLabels reports whether objLabels mark an object as a child of the
+// named Application.
+func (r *REST) hasLineageLabels(objLabels map[string]string, appName string) bool {
+	for k, v := range r.lineageLabels(appName) {
+		if objLabels[k] != v {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,276 @@
+/*
+Copyright 2026 The Cozystack Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package application
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/cozystack/cozystack/pkg/apis/apps/presets"
+	appsv1alpha1 "github.com/cozystack/cozystack/pkg/apis/apps/v1alpha1"
+	"github.com/cozystack/cozystack/pkg/config"
+)
+
+// Estimate sources reported in ApplicationResourceEstimate.Source.
+const (
+	estimateFromManifests = "Manifests"
+	estimateFromValues    = "Values"
+)
+
+// inertKinds are rendered kinds that allocate nothing the estimate prices,
+// so they are neither counted nor reported as unaccounted for.
+var inertKinds = map[string]bool{
+	"ConfigMap": true, "Secret": true, "ServiceAccount": true, "Endpoints": true,
+	"Role": true, "RoleBinding": true, "ClusterRole": true, "ClusterRoleBinding": true,
+	"NetworkPolicy": true, "CiliumNetworkPolicy": true, "PodDisruptionBudget": true,
+	"Ingress": true, "HorizontalPodAutoscaler": true, "VerticalPodAutoscaler": true,
+	"ServiceMonitor": true, "PodMonitor": true, "PrometheusRule": true,
+	"Certificate": true, "Issuer": true, "WorkloadMonitor": true,
+}
+
+// resourceTotals accumulates an estimate.
+type resourceTotals struct {
+	requests      corev1.ResourceList
+	limits        corev1.ResourceList
+	storage       resource.Quantity
+	loadBalancers int32
+}
+
+func (t *resourceTotals) addPod(spec *corev1.PodSpec, replicas int64) {
+	for _, c := range spec.Containers {
+		addScaled(&t.requests, c.Resources.Requests, replicas)
+		addScaled(&t.limits, c.Resources.Limits, replicas)
+	}
+}
+
+func (t *resourceTotals) addClaims(claims []corev1.PersistentVolumeClaim, replicas int64) {
+	for _, pvc := range claims {
+		if q, ok := pvc.Spec.Resources.Requests[corev1.ResourceStorage]; ok {
+			for i := int64(0); i < replicas; i++ {
+				t.storage.Add(q)
+			}
+		}
+	}
+}
+
+func (t *resourceTotals) estimate(source string) appsv1alpha1.ApplicationResourceEstimate {
+	e := appsv1alpha1.ApplicationResourceEstimate{
+		Source:        source,
+		Requests:      t.requests,
+		Limits:        t.limits,
+		LoadBalancers: t.loadBalancers,
+	}
+	if !t.storage.IsZero() {
+		storage := t.storage.DeepCopy()
+		e.Storage = &storage
+	}
+	return e
+}
+
+func addScaled(dst *corev1.ResourceList, src corev1.ResourceList, n int64) {
+	for name, q := range src {
+		if name != corev1.ResourceCPU && name != corev1.ResourceMemory {
+			continue
+		}
+		if *dst == nil {
+			*dst = corev1.ResourceList{}
+		}
+		sum := (*dst)[name]
+		for i := int64(0); i < n; i++ {
+			sum.Add(q)
+		}
+		(*dst)[name] = sum
+	}
+}
+
+func replicasOr1(r *int32) int64 {
+	if r == nil {
+		return 1
+	}
+	return int64(*r)
+}
+
+// estimateManifests sums the footprint of rendered objects. It reports
+// whether any pod-running workload was found, and the kinds it could not
+// price; DaemonSets are counted once, as the node count is not known.
+func estimateManifests(objects []*unstructured.Unstructured) (totals resourceTotals, sawWorkload bool, unaccounted []string) {
+	seen := map[string]bool{}
+	convert := func(u *unstructured.Unstructured, into any) bool {
+		return runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, into) == nil
+	}
+	for _, u := range objects {
+		gvk := u.GroupVersionKind()
+		switch {
+		case gvk.Group == "apps" && gvk.Kind == "Deployment":
+			var d appsv1.Deployment
+			if convert(u, &d) {
+				totals.addPod(&d.Spec.Template.Spec, replicasOr1(d.Spec.Replicas))
+				sawWorkload = true
+			}
+		case gvk.Group == "apps" && gvk.Kind == "StatefulSet":
+			var s appsv1.StatefulSet
+			if convert(u, &s) {
+				replicas := replicasOr1(s.Spec.Replicas)
+				totals.addPod(&s.Spec.Template.Spec, replicas)
+				totals.addClaims(s.Spec.VolumeClaimTemplates, replicas)
+				sawWorkload = true
+			}
+		case gvk.Group == "apps" && gvk.Kind == "ReplicaSet":
+			var rs appsv1.ReplicaSet
+			if convert(u, &rs) {
+				totals.addPod(&rs.Spec.Template.Spec, replicasOr1(rs.Spec.Replicas))
+				sawWorkload = true
+			}
+		case gvk.Group == "apps" && gvk.Kind == "DaemonSet":
+			var ds appsv1.DaemonSet
+			if convert(u, &ds) {
+				totals.addPod(&ds.Spec.Template.Spec, 1)
+				sawWorkload = true
+			}
+		case gvk.Group == "batch" && gvk.Kind == "Job":
+			var j batchv1.Job
+			if convert(u, &j) {
+				totals.addPod(&j.Spec.Template.Spec, replicasOr1(j.Spec.Parallelism))
+				sawWorkload = true
+			}
+		case gvk.Group == "" && gvk.Kind == "Pod":
+			var p corev1.Pod
+			if convert(u, &p) {
+				totals.addPod(&p.Spec, 1)
+				sawWorkload = true
+			}
+		case gvk.Group == "" && gvk.Kind == "PersistentVolumeClaim":
+			var pvc corev1.PersistentVolumeClaim
+			if convert(u, &pvc) {
+				totals.addClaims([]corev1.PersistentVolumeClaim{pvc}, 1)
+			}
+		case gvk.Group == "" && gvk.Kind == "Service":
+			var svc corev1.Service
+			if convert(u, &svc) && svc.Spec.Type == corev1.ServiceTypeLoadBalancer {
+				totals.loadBalancers++
+			}
+		case gvk.Group == "batch" && gvk.Kind == "CronJob", inertKinds[gvk.Kind]:
+			// Scheduled or allocation-free: nothing to price.
+		default:
+			name := gvk.Kind
+			if gvk.Group != "" {
+				name = gvk.Kind + "." + gvk.Group
+			}
+			if !seen[name] {
+				seen[name] = true
+				unaccounted = append(unaccounted, name)
+			}
+		}
+	}
+	sort.Strings(unaccounted)
+	return totals, sawWorkload, unaccounted
+}
+
+// estimateValues derives a footprint from the conventions Cozystack charts
+// share: replicas, resources (or resourcesPreset), size and external. It is
+// the estimate for applications whose workloads are custom resources, and
+// the only one available when manifests cannot be rendered.
+func estimateValues(raw []byte) (totals resourceTotals, err error) {
+	var values struct {
+		Replicas        *int32                       `json:"replicas"`
+		Resources       map[string]resource.Quantity `json:"resources"`
+		ResourcesPreset string                       `json:"resourcesPreset"`
+		Size            *resource.Quantity           `json:"size"`
+		External        bool                         `json:"external"`
+	}
+	if len(raw) > 0 {
+		if err := json.Unmarshal(raw, &values); err != nil {
+			return totals, fmt.Errorf("decode values: %w", err)
+		}
+	}
+	replicas := replicasOr1(values.Replicas)
+
+	limits := corev1.ResourceList{}
+	if values.ResourcesPreset != "" {
+		if cpu, memory, ok := presets.Resources(values.ResourcesPreset); ok {
+			limits[corev1.ResourceCPU] = cpu
+			limits[corev1.ResourceMemory] = memory
+		}
+	}
+	// Explicit resources win over the preset, key by key, as in cozy-lib.
+	for _, name := range []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory} {
+		if q, ok := values.Resources[string(name)]; ok {
+			limits[name] = q
+		}
+	}
+	addScaled(&totals.limits, limits, replicas)
+
+	if values.Size != nil {
+		for i := int64(0); i < replicas; i++ {
+			totals.storage.Add(*values.Size)
+		}
+	}
+	if values.External {
+		totals.loadBalancers = 1
+	}
+	return totals, nil
+}
+
+// estimateCost prices an estimate: CPU and memory by requests, or by limits
+// when the estimate has no requests. It returns nil without prices.
+func estimateCost(e appsv1alpha1.ApplicationResourceEstimate, p config.Pricing) *appsv1alpha1.ApplicationCostEstimate {
+	if p.IsZero() {
+		return nil
+	}
+	compute := e.Requests
+	if len(compute) == 0 {
+		compute = e.Limits
+	}
+	const gib = 1 << 30
+	breakdown := map[string]float64{}
+	if q, ok := compute[corev1.ResourceCPU]; ok && !p.CPU.IsZero() {
+		breakdown["cpu"] = q.AsApproximateFloat64() * p.CPU.AsApproximateFloat64()
+	}
+	if q, ok := compute[corev1.ResourceMemory]; ok && !p.Memory.IsZero() {
+		breakdown["memory"] = q.AsApproximateFloat64() / gib * p.Memory.AsApproximateFloat64()
+	}
+	if e.Storage != nil && !p.Storage.IsZero() {
+		breakdown["storage"] = e.Storage.AsApproximateFloat64() / gib * p.Storage.AsApproximateFloat64()
+	}
+	if e.LoadBalancers > 0 && !p.LoadBalancer.IsZero() {
+		breakdown["loadBalancers"] = float64(e.LoadBalancers) * p.LoadBalancer.AsApproximateFloat64()
+	}
+	cost := &appsv1alpha1.ApplicationCostEstimate{
+		Currency:  p.Currency,
+		Breakdown: map[string]string{},
+	}
+	var total float64
+	for k, v := range breakdown {
+		total += v
+		cost.Breakdown[k] = formatAmount(v)
+	}
+	cost.Monthly = formatAmount(total)
+	return cost
+}
+
+func formatAmount(v float64) string {
+	return strconv.FormatFloat(v, 'f', 2, 64)
+}
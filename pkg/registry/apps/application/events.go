@@ -0,0 +1,205 @@
+/*
+Copyright 2026 The Cozystack Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package application
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	helmv2 "github.com/fluxcd/helm-controller/api/v2"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apiserver/pkg/registry/rest"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/cozystack/cozystack/pkg/apis/apps/v1alpha1"
+)
+
+var (
+	_ rest.Getter                   = &EventsREST{}
+	_ rest.GroupVersionKindProvider = &EventsREST{}
+)
+
+// EventsREST serves the <plural>/events subresource: the Events of an
+// Application's HelmRelease and of every object the lineage webhook stamped
+// with the Application's apps.cozystack.io/application.{group,kind,name}
+// labels. Authorization is the regular subresource check done by the
+// aggregated apiserver (get on <plural>/events in the Application's
+// namespace), so whoever may read the Application may read its Events.
+type EventsREST struct {
+	app *REST
+}
+
+// NewEventsREST returns the events subresource storage for the Application
+// kind served by app.
+func NewEventsREST(app *REST) *EventsREST {
+	return &EventsREST{app: app}
+}
+
+// New returns an empty ApplicationEventList.
+func (r *EventsREST) New() runtime.Object {
+	return &appsv1alpha1.ApplicationEventList{}
+}
+
+// Destroy releases resources associated with EventsREST.
+func (r *EventsREST) Destroy() {}
+
+// GroupVersionKind pins the subresource kind so the endpoint installer does
+// not have to resolve it through the scheme.
+func (r *EventsREST) GroupVersionKind(schema.GroupVersion) schema.GroupVersionKind {
+	return r.app.gvk.GroupVersion().WithKind("ApplicationEventList")
+}
+
+// Get returns the aggregated Events of the named Application.
+func (r *EventsREST) Get(ctx context.Context, name string, options *metav1.GetOptions) (runtime.Object, error) {
+	hr, err := r.app.getApplicationHelmRelease(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	appName := name
+	owned := newLineageMatcher(r.app, appName)
+	items := []appsv1alpha1.ApplicationEvent{}
+	for _, ns := range r.app.applicationNamespaces(hr.Namespace, appName) {
+		events := &corev1.EventList{}
+		if err := r.app.w.List(ctx, events, client.InNamespace(ns)); err != nil {
+			klog.Errorf("Failed to list Events in namespace %s for %s %s: %v", ns, r.app.kindName, appName, err)
+			return nil, fmt.Errorf("failed to list events: %w", err)
+		}
+		for i := range events.Items {
+			ev := &events.Items[i]
+			if !r.belongsToApplication(ctx, hr, ev, owned) {
+				continue
+			}
+			items = append(items, convertEvent(ev))
+		}
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].LastTimestamp.Before(&items[j].LastTimestamp)
+	})
+
+	list := &appsv1alpha1.ApplicationEventList{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: r.app.gvk.GroupVersion().String(),
+			Kind:       "ApplicationEventList",
+		},
+		Items: items,
+	}
+	list.SetResourceVersion(hr.GetResourceVersion())
+	return list, nil
+}
+
+// belongsToApplication reports whether ev was recorded against the
+// Application's HelmRelease or against an object labelled as its child.
+func (r *EventsREST) belongsToApplication(ctx context.Context, hr *helmv2.HelmRelease, ev *corev1.Event, owned *lineageMatcher) bool {
+	ref := ev.InvolvedObject
+	if ref.Kind == "HelmRelease" && ref.Name == hr.Name && ref.Namespace == hr.Namespace {
+		return true
+	}
+	return owned.matches(ctx, ref)
+}
+
+// convertEvent flattens a core/v1 Event into its ApplicationEvent form,
+// preferring the events.k8s.io series fields when the legacy ones are unset.
+func convertEvent(ev *corev1.Event) appsv1alpha1.ApplicationEvent {
+	out := appsv1alpha1.ApplicationEvent{
+		InvolvedObject: appsv1alpha1.ApplicationEventObject{
+			APIVersion: ev.InvolvedObject.APIVersion,
+			Kind:       ev.InvolvedObject.Kind,
+			Namespace:  ev.InvolvedObject.Namespace,
+			Name:       ev.InvolvedObject.Name,
+		},
+		Type:           ev.Type,
+		Reason:         ev.Reason,
+		Message:        ev.Message,
+		Source:         ev.Source.Component,
+		Count:          ev.Count,
+		FirstTimestamp: ev.FirstTimestamp,
+		LastTimestamp:  ev.LastTimestamp,
+	}
+	if out.Source == "" {
+		out.Source = ev.ReportingController
+	}
+	if out.FirstTimestamp.IsZero() {
+		out.FirstTimestamp = metav1.NewTime(ev.EventTime.Time)
+	}
+	if ev.Series != nil {
+		if out.Count == 0 {
+			out.Count = ev.Series.Count
+		}
+		if out.LastTimestamp.IsZero() {
+			out.LastTimestamp = metav1.NewTime(ev.Series.LastObservedTime.Time)
+		}
+	}
+	if out.LastTimestamp.IsZero() {
+		out.LastTimestamp = out.FirstTimestamp
+	}
+	if out.Count == 0 {
+		out.Count = 1
+	}
+	return out
+}
+
+// lineageMatcher decides whether an object referenced by an Event carries the
+// lineage labels of one Application. Lookups are memoized per request since a
+// busy object usually has many Events.
+type lineageMatcher struct {
+	app     *REST
+	appName string
+	seen    map[corev1.ObjectReference]bool
+}
+
+func newLineageMatcher(app *REST, appName string) *lineageMatcher {
+	return &lineageMatcher{app: app, appName: appName, seen: map[corev1.ObjectReference]bool{}}
+}
+
+func (m *lineageMatcher) matches(ctx context.Context, ref corev1.ObjectReference) bool {
+	key := corev1.ObjectReference{APIVersion: ref.APIVersion, Kind: ref.Kind, Namespace: ref.Namespace, Name: ref.Name}
+	if v, ok := m.seen[key]; ok {
+		return v
+	}
+	v := m.lookup(ctx, key)
+	m.seen[key] = v
+	return v
+}
+
+func (m *lineageMatcher) lookup(ctx context.Context, ref corev1.ObjectReference) bool {
+	if ref.Kind == "" || ref.Name == "" {
+		return false
+	}
+	gv, err := schema.ParseGroupVersion(ref.APIVersion)
+	if err != nil {
+		return false
+	}
+	obj := &metav1.PartialObjectMetadata{}
+	obj.SetGroupVersionKind(gv.WithKind(ref.Kind))
+	if err := m.app.w.Get(ctx, client.ObjectKey{Namespace: ref.Namespace, Name: ref.Name}, obj); err != nil {
+		// Objects that are gone (or kinds the apiserver cannot map) cannot be
+		// attributed any more; their Events age out with them.
+		if !apierrors.IsNotFound(err) {
+			klog.V(4).Infof("Cannot resolve %s %s/%s for event attribution: %v", ref.Kind, ref.Namespace, ref.Name, err)
+		}
+		return false
+	}
+	return m.app.hasLineageLabels(obj.GetLabels(), m.appName)
+}
@@ -0,0 +1,151 @@
+/*
+Copyright 2026 The Cozystack Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package application
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	helmv2 "github.com/fluxcd/helm-controller/api/v2"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apiserver/pkg/endpoints/request"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	appsv1alpha1 "github.com/cozystack/cozystack/pkg/apis/apps/v1alpha1"
+	"github.com/cozystack/cozystack/pkg/config"
+)
+
+func newChildrenTestREST(t *testing.T, objs ...client.Object) *REST {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = helmv2.AddToScheme(scheme)
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+	return NewREST(c, c, &config.Resource{
+		Application: config.ApplicationConfig{
+			Kind:     "PostgreSQL",
+			Plural:   "postgresqls",
+			Singular: "postgresql",
+		},
+		Release: config.ReleaseConfig{
+			Prefix: "postgresql-",
+		},
+	})
+}
+
+func appHelmRelease(ns, appName string) *helmv2.HelmRelease {
+	return &helmv2.HelmRelease{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "postgresql-" + appName,
+			Namespace: ns,
+			Labels: map[string]string{
+				appsv1alpha1.ApplicationKindLabel:  "PostgreSQL",
+				appsv1alpha1.ApplicationGroupLabel: appsv1alpha1.GroupName,
+				appsv1alpha1.ApplicationNameLabel:  appName,
+			},
+		},
+	}
+}
+
+func appPod(ns, name, appName string, containers ...string) *corev1.Pod {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: ns,
+			Labels: map[string]string{
+				appsv1alpha1.ApplicationKindLabel:  "PostgreSQL",
+				appsv1alpha1.ApplicationGroupLabel: appsv1alpha1.GroupName,
+				appsv1alpha1.ApplicationNameLabel:  appName,
+			},
+		},
+	}
+	for _, c := range containers {
+		pod.Spec.Containers = append(pod.Spec.Containers, corev1.Container{Name: c})
+	}
+	return pod
+}
+
+func eventFor(ns, name string, ref corev1.ObjectReference, reason string, last time.Time) *corev1.Event {
+	return &corev1.Event{
+		ObjectMeta:     metav1.ObjectMeta{Name: name, Namespace: ns},
+		InvolvedObject: ref,
+		Reason:         reason,
+		Type:           corev1.EventTypeNormal,
+		LastTimestamp:  metav1.NewTime(last),
+	}
+}
+
+func TestEventsREST_AggregatesHelmReleaseAndLabelledChildren(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	hrRef := corev1.ObjectReference{APIVersion: "helm.toolkit.fluxcd.io/v2", Kind: "HelmRelease", Namespace: "tenant-foo", Name: "postgresql-db"}
+	podRef := corev1.ObjectReference{APIVersion: "v1", Kind: "Pod", Namespace: "tenant-foo", Name: "db-1"}
+	otherRef := corev1.ObjectReference{APIVersion: "v1", Kind: "Pod", Namespace: "tenant-foo", Name: "other-1"}
+	goneRef := corev1.ObjectReference{APIVersion: "v1", Kind: "Pod", Namespace: "tenant-foo", Name: "gone"}
+
+	r := newChildrenTestREST(t,
+		appHelmRelease("tenant-foo", "db"),
+		appPod("tenant-foo", "db-1", "db", "postgres"),
+		appPod("tenant-foo", "other-1", "other", "postgres"),
+		eventFor("tenant-foo", "e-pod", podRef, "Started", now),
+		eventFor("tenant-foo", "e-hr", hrRef, "InstallSucceeded", now.Add(-time.Minute)),
+		eventFor("tenant-foo", "e-other", otherRef, "Started", now),
+		eventFor("tenant-foo", "e-gone", goneRef, "Killing", now),
+	)
+
+	ctx := request.WithNamespace(context.Background(), "tenant-foo")
+	obj, err := NewEventsREST(r).Get(ctx, "db", &metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	list := obj.(*appsv1alpha1.ApplicationEventList)
+	if len(list.Items) != 2 {
+		t.Fatalf("expected 2 events, got %d: %+v", len(list.Items), list.Items)
+	}
+	if list.Items[0].Reason != "InstallSucceeded" || list.Items[1].Reason != "Started" {
+		t.Errorf("expected events sorted by last timestamp (HelmRelease first), got %q, %q", list.Items[0].Reason, list.Items[1].Reason)
+	}
+	if list.Items[1].InvolvedObject.Name != "db-1" {
+		t.Errorf("expected pod event for db-1, got %+v", list.Items[1].InvolvedObject)
+	}
+	if list.Items[0].Count != 1 {
+		t.Errorf("expected count to default to 1, got %d", list.Items[0].Count)
+	}
+}
+
+func TestEventsREST_NotFound(t *testing.T) {
+	r := newChildrenTestREST(t)
+	ctx := request.WithNamespace(context.Background(), "tenant-foo")
+	_, err := NewEventsREST(r).Get(ctx, "missing", &metav1.GetOptions{})
+	if !apierrors.IsNotFound(err) {
+		t.Fatalf("expected NotFound, got %v", err)
+	}
+}
+
+func TestEventsREST_IgnoresHelmReleaseOfOtherKind(t *testing.T) {
+	hr := appHelmRelease("tenant-foo", "db")
+	hr.Labels[appsv1alpha1.ApplicationKindLabel] = "MySQL"
+	r := newChildrenTestREST(t, hr)
+	ctx := request.WithNamespace(context.Background(), "tenant-foo")
+	if _, err := NewEventsREST(r).Get(ctx, "db", &metav1.GetOptions{}); !apierrors.IsNotFound(err) {
+		t.Fatalf("expected NotFound for a HelmRelease of another kind, got %v", err)
+	}
+}
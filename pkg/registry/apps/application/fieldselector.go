@@ -0,0 +1,213 @@
+/*
+Copyright 2026 The Cozystack Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package application
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	structuralschema "k8s.io/apiextensions-apiserver/pkg/apiserver/schema"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
+
+	appsv1alpha1 "github.com/cozystack/cozystack/pkg/apis/apps/v1alpha1"
+)
+
+// applicationFieldSelector matches converted Applications against a field
+// selector. The backing HelmReleases cannot be filtered by these fields, so
+// List and Watch convert every release and filter the result.
+type applicationFieldSelector struct {
+	selector fields.Selector
+	// specPaths holds the path of every spec.<path> key of the selector.
+	specPaths map[string][]string
+	// mutable reports whether the selector reads fields that change during
+	// an Application's life, so an Application can start or stop matching.
+	mutable bool
+}
+
+// parseApplicationFieldSelector parses a List or Watch field selector. Spec
+// paths must name a scalar field of the served version's schema.
+func (r *REST) parseApplicationFieldSelector(sel fields.Selector) (*applicationFieldSelector, error) {
+	s := &applicationFieldSelector{specPaths: map[string][]string{}}
+	if sel == nil || sel.Empty() {
+		return s, nil
+	}
+	parsed, err := fields.ParseSelector(sel.String())
+	if err != nil {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("invalid field selector: %v", err))
+	}
+	for _, req := range parsed.Requirements() {
+		if _, _, err := appsv1alpha1.ApplicationFieldLabelConversion(req.Field, req.Value); err != nil {
+			return nil, apierrors.NewBadRequest(err.Error())
+		}
+		if path, ok := appsv1alpha1.SpecFieldPath(req.Field); ok {
+			if err := checkScalarSpecPath(r.specSchema, path); err != nil {
+				return nil, apierrors.NewBadRequest(fmt.Sprintf("field selector %q is not supported for %s: %v", req.Field, r.kindName, err))
+			}
+			s.specPaths[req.Field] = path
+		}
+		if !strings.HasPrefix(req.Field, "metadata.") {
+			s.mutable = true
+		}
+	}
+	s.selector = parsed
+	return s, nil
+}
+
+// Matches reports whether app satisfies the selector. Fields app does not
+// set compare as empty strings, as for built-in resources.
+func (s *applicationFieldSelector) Matches(app *appsv1alpha1.Application) bool {
+	if s.selector == nil {
+		return true
+	}
+	set := fields.Set{
+		"metadata.name":                 app.Name,
+		"metadata.namespace":            app.Namespace,
+		appsv1alpha1.StatusVersionField: app.Status.Version,
+	}
+	for _, req := range s.selector.Requirements() {
+		conditionType, ok := appsv1alpha1.ConditionFieldType(req.Field)
+		if !ok {
+			continue
+		}
+		if c := meta.FindStatusCondition(app.Status.Conditions, conditionType); c != nil {
+			set[req.Field] = string(c.Status)
+		}
+	}
+	if len(s.specPaths) > 0 && app.Spec != nil && len(app.Spec.Raw) > 0 {
+		var spec any
+		dec := json.NewDecoder(bytes.NewReader(app.Spec.Raw))
+		dec.UseNumber()
+		if err := dec.Decode(&spec); err == nil {
+			for field, path := range s.specPaths {
+				if v, ok := specScalar(spec, path); ok {
+					set[field] = v
+				}
+			}
+		}
+	}
+	return s.selector.Matches(set)
+}
+
+// checkScalarSpecPath verifies that path leads to a scalar in the spec
+// schema. Paths below schema-less or free-form fields cannot be checked and
+// are accepted.
+func checkScalarSpecPath(s *structuralschema.Structural, path []string) error {
+	for i, p := range path {
+		if s == nil {
+			return nil
+		}
+		if ps, ok := s.Properties[p]; ok {
+			s = &ps
+			continue
+		}
+		if ap := s.AdditionalProperties; ap != nil {
+			if ap.Structural != nil {
+				s = ap.Structural
+				continue
+			}
+			if ap.Bool {
+				return nil
+			}
+		}
+		if s.XPreserveUnknownFields {
+			return nil
+		}
+		return fmt.Errorf("spec.%s is not a field of the spec", strings.Join(path[:i+1], "."))
+	}
+	if s == nil {
+		return nil
+	}
+	switch {
+	case s.Type == "object", s.Type == "array":
+		return fmt.Errorf("spec.%s is an %s, only scalar fields can be selected", strings.Join(path, "."), s.Type)
+	}
+	return nil
+}
+
+// specScalar returns the value at path of a decoded spec, formatted as a
+// field selector value. Objects, arrays and nulls are not selectable.
+func specScalar(v any, path []string) (string, bool) {
+	for _, p := range path {
+		m, ok := v.(map[string]any)
+		if !ok {
+			return "", false
+		}
+		if v, ok = m[p]; !ok {
+			return "", false
+		}
+	}
+	switch v := v.(type) {
+	case string:
+		return v, true
+	case bool:
+		return strconv.FormatBool(v), true
+	case json.Number:
+		return v.String(), true
+	}
+	return "", false
+}
+
+// fieldWatchFilter filters a watch by a field selector. When the selector
+// reads mutable fields it tracks which Applications matched, so that one
+// that starts matching is delivered as ADDED and one that stops matching as
+// DELETED, as the watch cache does for built-in resources.
+type fieldWatchFilter struct {
+	selector *applicationFieldSelector
+	matched  map[types.NamespacedName]bool
+}
+
+func newFieldWatchFilter(selector *applicationFieldSelector) *fieldWatchFilter {
+	return &fieldWatchFilter{selector: selector, matched: map[types.NamespacedName]bool{}}
+}
+
+// Filter returns the event to deliver for an event on app, if any.
+func (f *fieldWatchFilter) Filter(eventType watch.EventType, app *appsv1alpha1.Application) (watch.EventType, bool) {
+	matches := f.selector.Matches(app)
+	if !f.selector.mutable {
+		return eventType, matches
+	}
+	key := types.NamespacedName{Namespace: app.Namespace, Name: app.Name}
+	matched := f.matched[key]
+	switch eventType {
+	case watch.Added:
+		if matches {
+			f.matched[key] = true
+		}
+		return eventType, matches
+	case watch.Modified:
+		switch {
+		case matches && !matched:
+			f.matched[key] = true
+			return watch.Added, true
+		case !matches && matched:
+			delete(f.matched, key)
+			return watch.Deleted, true
+		}
+		return eventType, matches
+	case watch.Deleted:
+		delete(f.matched, key)
+		return eventType, matches || matched
+	}
+	return eventType, matches
+}
@@ -0,0 +1,208 @@
+/*
+Copyright 2026 The Cozystack Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package application
+
+import (
+	"context"
+	"testing"
+
+	helmv2 "github.com/fluxcd/helm-controller/api/v2"
+	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metainternalversion "k8s.io/apimachinery/pkg/apis/meta/internalversion"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	genericapirequest "k8s.io/apiserver/pkg/endpoints/request"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	appsv1alpha1 "github.com/cozystack/cozystack/pkg/apis/apps/v1alpha1"
+	"github.com/cozystack/cozystack/pkg/config"
+)
+
+const fieldSelectorTestSchema = `{
+  "type": "object",
+  "properties": {
+    "version": {"type": "string"},
+    "replicas": {"type": "integer"},
+    "external": {"type": "boolean"},
+    "resources": {"type": "object", "properties": {"cpu": {"x-kubernetes-int-or-string": true}}},
+    "users": {"type": "object", "additionalProperties": {"type": "object", "properties": {"readonly": {"type": "boolean"}}}},
+    "databases": {"type": "array", "items": {"type": "string"}}
+  }
+}`
+
+func newFieldSelectorTestREST(t *testing.T, releases ...*helmv2.HelmRelease) *REST {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	_ = helmv2.AddToScheme(scheme)
+	b := fake.NewClientBuilder().WithScheme(scheme)
+	for _, hr := range releases {
+		b = b.WithObjects(hr)
+	}
+	c := b.Build()
+	return NewREST(c, c, &config.Resource{
+		Application: config.ApplicationConfig{
+			Kind:          "PostgreSQL",
+			Plural:        "postgresqls",
+			Singular:      "postgresql",
+			OpenAPISchema: fieldSelectorTestSchema,
+		},
+		Release: config.ReleaseConfig{
+			Prefix: "postgresql-",
+		},
+	})
+}
+
+func selectorTestRelease(appName, values string, ready metav1.ConditionStatus) *helmv2.HelmRelease {
+	hr := appHelmRelease("tenant-a", appName)
+	hr.Spec.Values = &apiextv1.JSON{Raw: []byte(values)}
+	hr.Status.Conditions = []metav1.Condition{{Type: "Ready", Status: ready, Reason: "Test"}}
+	return hr
+}
+
+func TestParseApplicationFieldSelector(t *testing.T) {
+	r := newFieldSelectorTestREST(t)
+	for _, sel := range []string{
+		"metadata.name=db",
+		"spec.version=v16",
+		"spec.replicas!=3",
+		"spec.resources.cpu=2",
+		"spec.users.alice.readonly=true",
+		"status.conditions[Ready]=True",
+		"status.version=0.3.1",
+	} {
+		if _, err := r.parseApplicationFieldSelector(fields.ParseSelectorOrDie(sel)); err != nil {
+			t.Errorf("%s: unexpected error %v", sel, err)
+		}
+	}
+	for _, sel := range []string{
+		"spec.unknown=x",
+		"spec.resources=x",
+		"spec.databases=x",
+		"spec.version.major=16",
+		"status.phase=Ready",
+		"status.conditions[]=True",
+	} {
+		_, err := r.parseApplicationFieldSelector(fields.ParseSelectorOrDie(sel))
+		if !apierrors.IsBadRequest(err) {
+			t.Errorf("%s: error = %v, want BadRequest", sel, err)
+		}
+	}
+}
+
+func TestApplicationFieldLabelConversion_Registered(t *testing.T) {
+	scheme := runtime.NewScheme()
+	resourceCfg := &config.ResourceConfig{
+		Resources: []config.Resource{{Application: config.ApplicationConfig{Kind: "PostgreSQL"}}},
+	}
+	if err := appsv1alpha1.RegisterDynamicTypes(scheme, resourceCfg); err != nil {
+		t.Fatalf("RegisterDynamicTypes: %v", err)
+	}
+	gvk := appsv1alpha1.SchemeGroupVersion.WithKind("PostgreSQL")
+	for _, label := range []string{"spec.version", "status.conditions[Ready]", "status.version", "metadata.name"} {
+		if _, _, err := scheme.ConvertFieldLabel(gvk, label, "x"); err != nil {
+			t.Errorf("%s: %v", label, err)
+		}
+	}
+	if _, _, err := scheme.ConvertFieldLabel(gvk, "status.phase", "x"); err == nil {
+		t.Error("status.phase was accepted")
+	}
+}
+
+func TestList_FieldSelectorOnSpecAndStatus(t *testing.T) {
+	r := newFieldSelectorTestREST(t,
+		selectorTestRelease("a", `{"version":"v16","replicas":2}`, metav1.ConditionTrue),
+		selectorTestRelease("b", `{"version":"v16","replicas":3}`, metav1.ConditionFalse),
+		selectorTestRelease("c", `{"version":"v15","replicas":2}`, metav1.ConditionTrue),
+		selectorTestRelease("d", `{}`, metav1.ConditionTrue),
+	)
+	ctx := genericapirequest.WithNamespace(context.Background(), "tenant-a")
+
+	for _, tc := range []struct {
+		selector string
+		want     []string
+	}{
+		{"spec.version=v16", []string{"a", "b"}},
+		{"spec.version=v16,status.conditions[Ready]=True", []string{"a"}},
+		{"spec.replicas=2", []string{"a", "c"}},
+		{"spec.version!=v16", []string{"c", "d"}},
+		{"status.conditions[Ready]=False", []string{"b"}},
+	} {
+		obj, err := r.List(ctx, &metainternalversion.ListOptions{FieldSelector: fields.ParseSelectorOrDie(tc.selector)})
+		if err != nil {
+			t.Fatalf("%s: %v", tc.selector, err)
+		}
+		var got []string
+		for _, app := range obj.(*appsv1alpha1.ApplicationList).Items {
+			got = append(got, app.Name)
+		}
+		if len(got) != len(tc.want) {
+			t.Errorf("%s: got %v, want %v", tc.selector, got, tc.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != tc.want[i] {
+				t.Errorf("%s: got %v, want %v", tc.selector, got, tc.want)
+				break
+			}
+		}
+	}
+
+	_, err := r.List(ctx, &metainternalversion.ListOptions{FieldSelector: fields.ParseSelectorOrDie("spec.unknown=x")})
+	if !apierrors.IsBadRequest(err) {
+		t.Errorf("unknown spec field: error = %v, want BadRequest", err)
+	}
+}
+
+func TestFieldWatchFilter_Transitions(t *testing.T) {
+	r := newFieldSelectorTestREST(t)
+	sel, err := r.parseApplicationFieldSelector(fields.ParseSelectorOrDie("status.conditions[Ready]=True"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	f := newFieldWatchFilter(sel)
+	app := func(ready metav1.ConditionStatus) *appsv1alpha1.Application {
+		return &appsv1alpha1.Application{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "tenant-a", Name: "db"},
+			Status: appsv1alpha1.ApplicationStatus{
+				Conditions: []metav1.Condition{{Type: "Ready", Status: ready}},
+			},
+		}
+	}
+
+	steps := []struct {
+		in      watch.EventType
+		ready   metav1.ConditionStatus
+		want    watch.EventType
+		deliver bool
+	}{
+		{watch.Added, metav1.ConditionFalse, watch.Added, false},
+		{watch.Modified, metav1.ConditionFalse, watch.Modified, false},
+		{watch.Modified, metav1.ConditionTrue, watch.Added, true},
+		{watch.Modified, metav1.ConditionTrue, watch.Modified, true},
+		{watch.Modified, metav1.ConditionFalse, watch.Deleted, true},
+		{watch.Deleted, metav1.ConditionFalse, watch.Deleted, false},
+	}
+	for i, s := range steps {
+		got, deliver := f.Filter(s.in, app(s.ready))
+		if deliver != s.deliver || (deliver && got != s.want) {
+			t.Errorf("step %d (%s, Ready=%s): got %s/%v, want %s/%v", i, s.in, s.ready, got, deliver, s.want, s.deliver)
+		}
+	}
+}
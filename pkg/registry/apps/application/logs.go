@@ -0,0 +1,308 @@
+/*
+Copyright 2026 The Cozystack Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package application
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apiserver/pkg/registry/rest"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var (
+	_ rest.Connecter                = &LogsREST{}
+	_ rest.StorageMetadata          = &LogsREST{}
+	_ rest.GroupVersionKindProvider = &LogsREST{}
+)
+
+// LogsREST serves the <plural>/logs subresource: the container logs of every
+// pod carrying the Application's lineage labels, each line prefixed with
+// "[pod/container] ". Like pods/log it is a GET connect endpoint, so access is
+// governed by get on <plural>/logs in the Application's namespace.
+//
+// Supported query parameters:
+//
+//	container     only stream this container (pods without it are skipped)
+//	pod           only stream this pod
+//	sinceSeconds  relative start time, in seconds
+//	sinceTime     absolute start time, RFC3339
+//	tailLines     number of lines from the end of each log
+//	follow        keep streaming until the client disconnects
+//	timestamps    prefix each line with its RFC3339 timestamp
+//	previous      stream the previous terminated container instance
+type LogsREST struct {
+	app  *REST
+	pods corev1client.PodsGetter
+}
+
+// NewLogsREST returns the logs subresource storage for the Application kind
+// served by app. pods is used to open the log streams; pod discovery goes
+// through the storage's own client.
+func NewLogsREST(app *REST, pods corev1client.PodsGetter) *LogsREST {
+	return &LogsREST{app: app, pods: pods}
+}
+
+// New returns an Application of the served kind, the object the connect
+// endpoint is registered against.
+func (r *LogsREST) New() runtime.Object {
+	return r.app.New()
+}
+
+// Destroy releases resources associated with LogsREST.
+func (r *LogsREST) Destroy() {}
+
+// GroupVersionKind reports the kind of the parent Application.
+func (r *LogsREST) GroupVersionKind(gv schema.GroupVersion) schema.GroupVersionKind {
+	return r.app.GroupVersionKind(gv)
+}
+
+// ProducesMIMETypes reports the log stream content type.
+func (r *LogsREST) ProducesMIMETypes(verb string) []string {
+	return []string{"text/plain"}
+}
+
+// ProducesObject reports a plain string body, as pods/log does.
+func (r *LogsREST) ProducesObject(verb string) interface{} {
+	return ""
+}
+
+// ConnectMethods returns the HTTP methods served by the logs endpoint.
+func (r *LogsREST) ConnectMethods() []string {
+	return []string{http.MethodGet}
+}
+
+// NewConnectOptions returns no options object: the query parameters are
+// parsed by the handler itself (see parseLogOptions), which keeps them off
+// the apps.cozystack.io scheme and its parameter codec.
+func (r *LogsREST) NewConnectOptions() (runtime.Object, bool, string) {
+	return nil, false, ""
+}
+
+// Connect resolves the Application and returns a handler streaming its logs.
+func (r *LogsREST) Connect(ctx context.Context, name string, _ runtime.Object, responder rest.Responder) (http.Handler, error) {
+	hr, err := r.app.getApplicationHelmRelease(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	namespaces := r.app.applicationNamespaces(hr.Namespace, name)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		opts, err := parseLogOptions(req.URL.Query())
+		if err != nil {
+			responder.Error(apierrors.NewBadRequest(err.Error()))
+			return
+		}
+		targets, err := r.logTargets(req.Context(), namespaces, name, opts)
+		if err != nil {
+			responder.Error(err)
+			return
+		}
+		if len(targets) == 0 {
+			responder.Error(apierrors.NewNotFound(r.app.gvr.GroupResource(), name+"/logs"))
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		w.Header().Set("X-Content-Type-Options", "nosniff")
+		w.WriteHeader(http.StatusOK)
+		r.stream(req.Context(), newLineWriter(w), targets, opts)
+	}), nil
+}
+
+// applicationLogOptions holds the parsed query of a logs request.
+type applicationLogOptions struct {
+	pod string
+	// podLogOptions is passed to every container stream; Container is filled
+	// in per target.
+	podLogOptions corev1.PodLogOptions
+}
+
+// parseLogOptions validates the logs query parameters.
+func parseLogOptions(q url.Values) (*applicationLogOptions, error) {
+	opts := &applicationLogOptions{pod: q.Get("pod")}
+	opts.podLogOptions.Container = q.Get("container")
+
+	if v := q.Get("sinceSeconds"); v != "" {
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || n < 1 {
+			return nil, fmt.Errorf("sinceSeconds must be a positive integer, got %q", v)
+		}
+		opts.podLogOptions.SinceSeconds = &n
+	}
+	if v := q.Get("sinceTime"); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return nil, fmt.Errorf("sinceTime must be an RFC3339 timestamp, got %q", v)
+		}
+		mt := metav1.NewTime(t)
+		opts.podLogOptions.SinceTime = &mt
+	}
+	if opts.podLogOptions.SinceSeconds != nil && opts.podLogOptions.SinceTime != nil {
+		return nil, fmt.Errorf("at most one of sinceSeconds or sinceTime may be specified")
+	}
+	if v := q.Get("tailLines"); v != "" {
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("tailLines must be a non-negative integer, got %q", v)
+		}
+		opts.podLogOptions.TailLines = &n
+	}
+	for param, dst := range map[string]*bool{
+		"follow":     &opts.podLogOptions.Follow,
+		"timestamps": &opts.podLogOptions.Timestamps,
+		"previous":   &opts.podLogOptions.Previous,
+	} {
+		if v := q.Get(param); v != "" {
+			b, err := strconv.ParseBool(v)
+			if err != nil {
+				return nil, fmt.Errorf("%s must be a boolean, got %q", param, v)
+			}
+			*dst = b
+		}
+	}
+	return opts, nil
+}
+
+// logTarget is a single container log stream.
+type logTarget struct {
+	namespace string
+	pod       string
+	container string
+}
+
+func (t logTarget) prefix() string {
+	return "[" + t.pod + "/" + t.container + "] "
+}
+
+// logTargets lists the Application's pods and expands them into per-container
+// streams, honouring the pod and container filters. Targets are returned in a
+// stable namespace/pod/container order.
+func (r *LogsREST) logTargets(ctx context.Context, namespaces []string, appName string, opts *applicationLogOptions) ([]logTarget, error) {
+	var targets []logTarget
+	for _, ns := range namespaces {
+		pods := &corev1.PodList{}
+		if err := r.app.w.List(ctx, pods, client.InNamespace(ns), r.app.lineageLabels(appName)); err != nil {
+			klog.Errorf("Failed to list pods in namespace %s for %s %s: %v", ns, r.app.kindName, appName, err)
+			return nil, fmt.Errorf("failed to list pods: %w", err)
+		}
+		for i := range pods.Items {
+			pod := &pods.Items[i]
+			if opts.pod != "" && pod.Name != opts.pod {
+				continue
+			}
+			for _, c := range pod.Spec.Containers {
+				if opts.podLogOptions.Container != "" && c.Name != opts.podLogOptions.Container {
+					continue
+				}
+				targets = append(targets, logTarget{namespace: ns, pod: pod.Name, container: c.Name})
+			}
+		}
+	}
+	sort.Slice(targets, func(i, j int) bool {
+		a, b := targets[i], targets[j]
+		if a.namespace != b.namespace {
+			return a.namespace < b.namespace
+		}
+		if a.pod != b.pod {
+			return a.pod < b.pod
+		}
+		return a.container < b.container
+	})
+	return targets, nil
+}
+
+// stream copies every target's log into out. Without follow the streams are
+// drained one after another so each container's output stays contiguous;
+// with follow they run concurrently and lines interleave as they arrive.
+// Per-stream failures are reported inline rather than aborting the response,
+// since the status line has already been sent.
+func (r *LogsREST) stream(ctx context.Context, out *lineWriter, targets []logTarget, opts *applicationLogOptions) {
+	if !opts.podLogOptions.Follow {
+		for _, t := range targets {
+			r.streamOne(ctx, out, t, opts)
+		}
+		return
+	}
+	var wg sync.WaitGroup
+	for _, t := range targets {
+		wg.Add(1)
+		go func(t logTarget) {
+			defer wg.Done()
+			r.streamOne(ctx, out, t, opts)
+		}(t)
+	}
+	wg.Wait()
+}
+
+func (r *LogsREST) streamOne(ctx context.Context, out *lineWriter, t logTarget, opts *applicationLogOptions) {
+	podOpts := opts.podLogOptions
+	podOpts.Container = t.container
+	rc, err := r.pods.Pods(t.namespace).GetLogs(t.pod, &podOpts).Stream(ctx)
+	if err != nil {
+		out.writeLine(t.prefix(), fmt.Sprintf("error: failed to stream logs: %v", err))
+		return
+	}
+	defer rc.Close()
+	if err := out.copyLines(t.prefix(), rc); err != nil && ctx.Err() == nil {
+		out.writeLine(t.prefix(), fmt.Sprintf("error: log stream interrupted: %v", err))
+	}
+}
+
+// lineWriter serializes whole, prefixed lines from concurrent streams onto
+// the response and flushes after each one so followers see output promptly.
+type lineWriter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func newLineWriter(w io.Writer) *lineWriter {
+	return &lineWriter{w: w}
+}
+
+func (lw *lineWriter) writeLine(prefix, line string) {
+	lw.mu.Lock()
+	defer lw.mu.Unlock()
+	_, _ = io.WriteString(lw.w, prefix+line+"\n")
+	if f, ok := lw.w.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+func (lw *lineWriter) copyLines(prefix string, r io.Reader) error {
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 64*1024), 1024*1024)
+	for sc.Scan() {
+		lw.writeLine(prefix, sc.Text())
+	}
+	return sc.Err()
+}
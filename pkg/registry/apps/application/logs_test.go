@@ -0,0 +1,130 @@
+/*
+Copyright 2026 The Cozystack Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package application
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apiserver/pkg/endpoints/request"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+)
+
+func TestParseLogOptions(t *testing.T) {
+	cases := []struct {
+		query   string
+		wantErr bool
+	}{
+		{query: ""},
+		{query: "container=postgres&sinceSeconds=60&tailLines=10&follow=true"},
+		{query: "sinceTime=2026-01-02T03:04:05Z"},
+		{query: "sinceSeconds=0", wantErr: true},
+		{query: "sinceSeconds=abc", wantErr: true},
+		{query: "sinceTime=yesterday", wantErr: true},
+		{query: "sinceSeconds=10&sinceTime=2026-01-02T03:04:05Z", wantErr: true},
+		{query: "tailLines=-1", wantErr: true},
+		{query: "follow=maybe", wantErr: true},
+	}
+	for _, tc := range cases {
+		req := httptest.NewRequest(http.MethodGet, "/logs?"+tc.query, nil)
+		_, err := parseLogOptions(req.URL.Query())
+		if (err != nil) != tc.wantErr {
+			t.Errorf("query %q: err=%v, wantErr=%v", tc.query, err, tc.wantErr)
+		}
+	}
+}
+
+// fakeResponder records the object or error a connect handler reports.
+type fakeResponder struct {
+	obj runtime.Object
+	err error
+}
+
+func (f *fakeResponder) Object(_ int, obj runtime.Object) { f.obj = obj }
+func (f *fakeResponder) Error(err error)                  { f.err = err }
+
+func TestLogsREST_StreamsLabelledPodsWithContainerFilter(t *testing.T) {
+	r := newChildrenTestREST(t,
+		appHelmRelease("tenant-foo", "db"),
+		appPod("tenant-foo", "db-1", "db", "postgres", "exporter"),
+		appPod("tenant-foo", "db-2", "db", "postgres"),
+		appPod("tenant-foo", "other-1", "other", "postgres"),
+	)
+	logs := NewLogsREST(r, kubefake.NewSimpleClientset().CoreV1())
+
+	ctx := request.WithNamespace(context.Background(), "tenant-foo")
+	resp := &fakeResponder{}
+	h, err := logs.Connect(ctx, "db", nil, resp)
+	if err != nil {
+		t.Fatalf("Connect: %v", err)
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/logs?container=postgres", nil))
+	if resp.err != nil {
+		t.Fatalf("unexpected responder error: %v", resp.err)
+	}
+	body, _ := io.ReadAll(rec.Body)
+	got := string(body)
+	for _, want := range []string{"[db-1/postgres] ", "[db-2/postgres] "} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, got)
+		}
+	}
+	for _, unwanted := range []string{"exporter", "other-1"} {
+		if strings.Contains(got, unwanted) {
+			t.Errorf("expected output not to contain %q, got:\n%s", unwanted, got)
+		}
+	}
+}
+
+func TestLogsREST_NoMatchingPods(t *testing.T) {
+	r := newChildrenTestREST(t, appHelmRelease("tenant-foo", "db"))
+	logs := NewLogsREST(r, kubefake.NewSimpleClientset().CoreV1())
+
+	ctx := request.WithNamespace(context.Background(), "tenant-foo")
+	resp := &fakeResponder{}
+	h, err := logs.Connect(ctx, "db", nil, resp)
+	if err != nil {
+		t.Fatalf("Connect: %v", err)
+	}
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/logs", nil))
+	if !apierrors.IsNotFound(resp.err) {
+		t.Fatalf("expected NotFound when the application has no pods, got %v", resp.err)
+	}
+}
+
+func TestLogsREST_RejectsInvalidOptions(t *testing.T) {
+	r := newChildrenTestREST(t, appHelmRelease("tenant-foo", "db"), appPod("tenant-foo", "db-1", "db", "postgres"))
+	logs := NewLogsREST(r, kubefake.NewSimpleClientset().CoreV1())
+
+	ctx := request.WithNamespace(context.Background(), "tenant-foo")
+	resp := &fakeResponder{}
+	h, err := logs.Connect(ctx, "db", nil, resp)
+	if err != nil {
+		t.Fatalf("Connect: %v", err)
+	}
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/logs?tailLines=-5", nil))
+	if !apierrors.IsBadRequest(resp.err) {
+		t.Fatalf("expected BadRequest, got %v", resp.err)
+	}
+}
@@ -0,0 +1,298 @@
+/*
+Copyright 2026 The Cozystack Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package application
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/apiserver/pkg/authorization/authorizer"
+	"k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/apiserver/pkg/registry/rest"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	cozyv1alpha1 "github.com/cozystack/cozystack/api/v1alpha1"
+	appsv1alpha1 "github.com/cozystack/cozystack/pkg/apis/apps/v1alpha1"
+	"github.com/cozystack/cozystack/pkg/apis/apps/validation"
+	"github.com/cozystack/cozystack/pkg/config"
+)
+
+var (
+	_ rest.Connecter                = &MoveREST{}
+	_ rest.StorageMetadata          = &MoveREST{}
+	_ rest.GroupVersionKindProvider = &MoveREST{}
+)
+
+// tenantNamespacePrefix prefixes every tenant namespace, the only namespaces
+// Applications can be moved to.
+const tenantNamespacePrefix = "tenant-"
+
+// MoveREST serves the <plural>/move subresource of Application kinds whose
+// ApplicationDefinition enables moving. POST with an ApplicationMove starts
+// moving the Application to spec.targetNamespace:
+//
+//   - the caller must be allowed to create the kind in the target namespace
+//     and to delete the Application where it is;
+//   - the target must be another existing tenant namespace, without an
+//     Application of the same kind and name;
+//   - a kind moved without data must have no persistent volumes, which the
+//     move would leave behind;
+//   - the Application must fit the target's ResourceQuotas.
+//
+// The move itself is asynchronous: MoveREST records it as a cluster-scoped
+// cozystack.io ApplicationMove, which the application move controller carries
+// out and which stays as the audit record of the move.
+type MoveREST struct {
+	app        *REST
+	move       *config.MoveConfig
+	authorizer authorizer.Authorizer
+}
+
+// NewMoveREST returns the move subresource storage for the Application kind
+// served by app, moved as move describes. Requests are authorized against
+// the target namespace with authz.
+func NewMoveREST(app *REST, move *config.MoveConfig, authz authorizer.Authorizer) *MoveREST {
+	return &MoveREST{app: app, move: move, authorizer: authz}
+}
+
+// New returns an empty ApplicationMove.
+func (r *MoveREST) New() runtime.Object {
+	return &appsv1alpha1.ApplicationMove{}
+}
+
+// Destroy releases resources associated with MoveREST.
+func (r *MoveREST) Destroy() {}
+
+// GroupVersionKind reports ApplicationMove in the parent's version.
+func (r *MoveREST) GroupVersionKind(schema.GroupVersion) schema.GroupVersionKind {
+	return r.app.gvk.GroupVersion().WithKind("ApplicationMove")
+}
+
+// ProducesMIMETypes reports no MIME types beyond the negotiated ones.
+func (r *MoveREST) ProducesMIMETypes(verb string) []string {
+	return nil
+}
+
+// ProducesObject reports the ApplicationMove response body.
+func (r *MoveREST) ProducesObject(verb string) interface{} {
+	return appsv1alpha1.ApplicationMove{}
+}
+
+// ConnectMethods returns the HTTP methods served by the move endpoint.
+func (r *MoveREST) ConnectMethods() []string {
+	return []string{http.MethodPost}
+}
+
+// NewConnectOptions returns no options object.
+func (r *MoveREST) NewConnectOptions() (runtime.Object, bool, string) {
+	return nil, false, ""
+}
+
+// Connect returns a handler starting to move the named Application.
+func (r *MoveREST) Connect(ctx context.Context, name string, _ runtime.Object, responder rest.Responder) (http.Handler, error) {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		out, err := r.start(ctx, name, req.Body)
+		if err != nil {
+			responder.Error(err)
+			return
+		}
+		responder.Object(http.StatusCreated, out)
+	}), nil
+}
+
+// start validates the requested move and records it.
+func (r *MoveREST) start(ctx context.Context, name string, body io.Reader) (*appsv1alpha1.ApplicationMove, error) {
+	gr := r.app.gvr.GroupResource()
+	if r.move == nil || r.app.kindName == validation.TenantKind {
+		// Tenants own the namespaces named after them; they are never
+		// movable, whatever their definition says.
+		return nil, apierrors.NewMethodNotSupported(gr, "move")
+	}
+	namespace, err := r.app.getNamespace(ctx)
+	if err != nil {
+		return nil, err
+	}
+	in := &appsv1alpha1.ApplicationMove{}
+	dec := utilyaml.NewYAMLOrJSONDecoder(io.LimitReader(body, maxRenderRequestSize), 4096)
+	if err := dec.Decode(in); err != nil {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("decode ApplicationMove: %v", err))
+	}
+	target := in.Spec.TargetNamespace
+	fldPath := field.NewPath("spec", "targetNamespace")
+	invalid := func(errs ...*field.Error) error {
+		return apierrors.NewInvalid(r.app.gvk.GroupKind(), name, errs)
+	}
+	switch {
+	case target == "":
+		return nil, invalid(field.Required(fldPath, "the tenant namespace to move to"))
+	case target == namespace:
+		return nil, invalid(field.Invalid(fldPath, target, "the application is already in this namespace"))
+	case !strings.HasPrefix(target, tenantNamespacePrefix):
+		return nil, invalid(field.Invalid(fldPath, target, "not a tenant namespace"))
+	}
+
+	user, ok := request.UserFrom(ctx)
+	if !ok {
+		return nil, apierrors.NewForbidden(gr, name, fmt.Errorf("no user in the request"))
+	}
+	for _, check := range []struct{ verb, namespace string }{
+		{"create", target},
+		{"delete", namespace},
+	} {
+		decision, reason, err := r.authorizer.Authorize(ctx, authorizer.AttributesRecord{
+			User:            user,
+			Verb:            check.verb,
+			Namespace:       check.namespace,
+			APIGroup:        r.app.gvr.Group,
+			APIVersion:      r.app.gvr.Version,
+			Resource:        r.app.gvr.Resource,
+			Name:            name,
+			ResourceRequest: true,
+		})
+		if err != nil || decision != authorizer.DecisionAllow {
+			msg := fmt.Sprintf("moving requires %s on %s in namespace %q", check.verb, gr, check.namespace)
+			if reason != "" {
+				msg += ": " + reason
+			}
+			return nil, apierrors.NewForbidden(gr, name, fmt.Errorf("%s", msg))
+		}
+	}
+
+	hr, err := r.app.getApplicationHelmRelease(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	ns := &corev1.Namespace{}
+	if err := r.app.w.Get(ctx, client.ObjectKey{Name: target}, ns); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, invalid(field.NotFound(fldPath, target))
+		}
+		return nil, err
+	}
+	if ns.DeletionTimestamp != nil {
+		return nil, invalid(field.Invalid(fldPath, target, "the namespace is being deleted"))
+	}
+	if err := r.app.w.Get(ctx, client.ObjectKey{Namespace: target, Name: hr.Name}, hr.DeepCopy()); err == nil {
+		return nil, apierrors.NewAlreadyExists(gr, target+"/"+name)
+	} else if !apierrors.IsNotFound(err) {
+		return nil, err
+	}
+
+	if cozyv1alpha1.ApplicationMoveDataMode(r.move.Data) != cozyv1alpha1.ApplicationMoveDataBackup {
+		pvcs := &corev1.PersistentVolumeClaimList{}
+		if err := r.app.w.List(ctx, pvcs, client.InNamespace(namespace), r.app.lineageLabels(name)); err != nil {
+			return nil, err
+		}
+		if n := len(pvcs.Items); n > 0 {
+			return nil, invalid(field.Forbidden(field.NewPath("spec"),
+				fmt.Sprintf("the application has %d persistent volumes, and %s applications are moved without their data", n, r.app.kindName)))
+		}
+	}
+
+	moves := &cozyv1alpha1.ApplicationMoveList{}
+	if err := r.app.w.List(ctx, moves, r.app.lineageLabels(name)); err != nil {
+		return nil, err
+	}
+	for i := range moves.Items {
+		m := &moves.Items[i]
+		if m.Spec.SourceNamespace == namespace && !m.IsFinished() {
+			return nil, apierrors.NewConflict(gr, name, fmt.Errorf("the application is already being moved by ApplicationMove %s", m.Name))
+		}
+	}
+
+	var values []byte
+	if hr.Spec.Values != nil {
+		values = hr.Spec.Values.Raw
+	}
+	totals, err := estimateValues(values)
+	if err != nil {
+		return nil, apierrors.NewInternalError(err)
+	}
+	if errs := r.app.validateQuotaHeadroom(ctx, target, quotaDemand(totals), fldPath); len(errs) > 0 {
+		return nil, invalid(errs...)
+	}
+	if err := r.app.validateApplicationCount(ctx, target, name); err != nil {
+		return nil, err
+	}
+
+	group := r.app.gvk.Group
+	record := &cozyv1alpha1.ApplicationMove{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: strings.ToLower(r.app.kindName) + "-" + name + "-",
+			Labels:       r.app.lineageLabels(name),
+		},
+		Spec: cozyv1alpha1.ApplicationMoveSpec{
+			Application: corev1.TypedLocalObjectReference{
+				APIGroup: &group,
+				Kind:     r.app.kindName,
+				Name:     name,
+			},
+			SourceNamespace: namespace,
+			TargetNamespace: target,
+			Data:            cozyv1alpha1.ApplicationMoveDataMode(r.move.Data),
+			BackupClassName: r.move.BackupClassName,
+			RequestedBy:     user.GetName(),
+		},
+	}
+	if err := r.app.w.Create(ctx, record); err != nil {
+		return nil, err
+	}
+	klog.Infof("%s %s/%s: move to %s requested by %q, recorded as ApplicationMove %s",
+		r.app.kindName, namespace, name, target, user.GetName(), record.Name)
+
+	return &appsv1alpha1.ApplicationMove{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: r.app.gvk.GroupVersion().String(),
+			Kind:       "ApplicationMove",
+		},
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec:       in.Spec,
+		Status: appsv1alpha1.ApplicationMoveStatus{
+			Record: record.Name,
+			Data:   r.move.Data,
+			Phase:  string(cozyv1alpha1.ApplicationMovePending),
+		},
+	}, nil
+}
+
+// quotaDemand keys an estimate like resourceQuotas, for validateQuotaHeadroom.
+func quotaDemand(t resourceTotals) map[string]resource.Quantity {
+	want := map[string]resource.Quantity{}
+	for name, q := range t.limits {
+		want[string(name)] = q
+	}
+	if !t.storage.IsZero() {
+		want[string(corev1.ResourceStorage)] = t.storage
+	}
+	if t.loadBalancers > 0 {
+		want["services.loadbalancers"] = *resource.NewQuantity(int64(t.loadBalancers), resource.DecimalSI)
+	}
+	return want
+}
@@ -0,0 +1,197 @@
+/*
+Copyright 2026 The Cozystack Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package application
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	helmv2 "github.com/fluxcd/helm-controller/api/v2"
+	corev1 "k8s.io/api/core/v1"
+	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/apiserver/pkg/authorization/authorizer"
+	genericapirequest "k8s.io/apiserver/pkg/endpoints/request"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	cozyv1alpha1 "github.com/cozystack/cozystack/api/v1alpha1"
+	appsv1alpha1 "github.com/cozystack/cozystack/pkg/apis/apps/v1alpha1"
+	"github.com/cozystack/cozystack/pkg/config"
+)
+
+func newMoveTestREST(t *testing.T, objs ...client.Object) *REST {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = helmv2.AddToScheme(scheme)
+	_ = cozyv1alpha1.AddToScheme(scheme)
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+	return NewREST(c, c, &config.Resource{
+		Application: config.ApplicationConfig{
+			Kind:     "PostgreSQL",
+			Plural:   "postgresqls",
+			Singular: "postgresql",
+		},
+		Release: config.ReleaseConfig{
+			Prefix: "postgresql-",
+		},
+	})
+}
+
+// moveTestObjects seeds a one-CPU application db in tenant-a, and tenant-b
+// with a quota leaving two CPUs.
+func moveTestObjects() []client.Object {
+	hr := appHelmRelease("tenant-a", "db")
+	hr.Spec.Values = &apiextv1.JSON{Raw: []byte(`{"replicas":1,"resources":{"cpu":1,"memory":"1Gi"}}`)}
+	return []client.Object{
+		hr,
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "tenant-a"}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "tenant-b"}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "cozy-system"}},
+		&corev1.ResourceQuota{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "tenant-b", Name: "tenant-quota"},
+			Status: corev1.ResourceQuotaStatus{
+				Hard: corev1.ResourceList{"limits.cpu": resource.MustParse("4")},
+				Used: corev1.ResourceList{"limits.cpu": resource.MustParse("2")},
+			},
+		},
+	}
+}
+
+func allowAll(context.Context, authorizer.Attributes) (authorizer.Decision, string, error) {
+	return authorizer.DecisionAllow, "", nil
+}
+
+func serveMove(t *testing.T, r *MoveREST, name, body string) (*appsv1alpha1.ApplicationMove, error) {
+	t.Helper()
+	ctx := genericapirequest.WithNamespace(context.Background(), "tenant-a")
+	ctx = genericapirequest.WithUser(ctx, &user.DefaultInfo{Name: "alice"})
+	resp := &fakeResponder{}
+	h, err := r.Connect(ctx, name, nil, resp)
+	if err != nil {
+		return nil, err
+	}
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/move", strings.NewReader(body)))
+	if resp.err != nil {
+		return nil, resp.err
+	}
+	return resp.obj.(*appsv1alpha1.ApplicationMove), nil
+}
+
+func TestMoveREST_RecordsMove(t *testing.T) {
+	app := newMoveTestREST(t, moveTestObjects()...)
+	move := &config.MoveConfig{Data: "Backup", BackupClassName: "velero"}
+	r := NewMoveREST(app, move, authorizer.AuthorizerFunc(allowAll))
+
+	out, err := serveMove(t, r, "db", `{"spec":{"targetNamespace":"tenant-b"}}`)
+	if err != nil {
+		t.Fatalf("move: %v", err)
+	}
+	if out.Status.Record == "" || out.Status.Phase != "Pending" || out.Status.Data != "Backup" {
+		t.Errorf("status = %+v, want a pending Backup move with its record", out.Status)
+	}
+
+	record := &cozyv1alpha1.ApplicationMove{}
+	if err := app.c.Get(context.Background(), client.ObjectKey{Name: out.Status.Record}, record); err != nil {
+		t.Fatalf("get record: %v", err)
+	}
+	spec := record.Spec
+	if spec.Application.Kind != "PostgreSQL" || spec.Application.Name != "db" ||
+		spec.SourceNamespace != "tenant-a" || spec.TargetNamespace != "tenant-b" ||
+		spec.Data != cozyv1alpha1.ApplicationMoveDataBackup || spec.BackupClassName != "velero" ||
+		spec.RequestedBy != "alice" {
+		t.Errorf("record spec = %+v", spec)
+	}
+	if record.Labels[appsv1alpha1.ApplicationNameLabel] != "db" {
+		t.Errorf("record labels = %v, want the application's lineage", record.Labels)
+	}
+
+	// A second move conflicts with the unfinished first one.
+	if _, err := serveMove(t, r, "db", `{"spec":{"targetNamespace":"tenant-b"}}`); !apierrors.IsConflict(err) {
+		t.Errorf("second move: error = %v, want Conflict", err)
+	}
+}
+
+func TestMoveREST_Rejects(t *testing.T) {
+	deny := authorizer.AuthorizerFunc(func(_ context.Context, a authorizer.Attributes) (authorizer.Decision, string, error) {
+		if a.GetNamespace() == "tenant-b" {
+			return authorizer.DecisionDeny, "no access", nil
+		}
+		return authorizer.DecisionAllow, "", nil
+	})
+	taken := appHelmRelease("tenant-b", "db")
+	bigger := appHelmRelease("tenant-a", "big")
+	bigger.Spec.Values = &apiextv1.JSON{Raw: []byte(`{"replicas":3,"resources":{"cpu":1}}`)}
+	volume := &corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{
+		Namespace: "tenant-a", Name: "data-db-0", Labels: appHelmRelease("tenant-a", "db").Labels,
+	}}
+
+	for _, tc := range []struct {
+		name  string
+		app   string
+		body  string
+		authz authorizer.Authorizer
+		extra []client.Object
+		check func(error) bool
+	}{
+		{"no target", "db", `{"spec":{}}`, nil, nil, apierrors.IsInvalid},
+		{"same namespace", "db", `{"spec":{"targetNamespace":"tenant-a"}}`, nil, nil, apierrors.IsInvalid},
+		{"not a tenant", "db", `{"spec":{"targetNamespace":"cozy-system"}}`, nil, nil, apierrors.IsInvalid},
+		{"missing namespace", "db", `{"spec":{"targetNamespace":"tenant-c"}}`, nil, nil, apierrors.IsInvalid},
+		{"denied in target", "db", `{"spec":{"targetNamespace":"tenant-b"}}`, deny, nil, apierrors.IsForbidden},
+		{"missing application", "nope", `{"spec":{"targetNamespace":"tenant-b"}}`, nil, nil, apierrors.IsNotFound},
+		{"name taken", "db", `{"spec":{"targetNamespace":"tenant-b"}}`, nil, []client.Object{taken}, apierrors.IsAlreadyExists},
+		{"over quota", "big", `{"spec":{"targetNamespace":"tenant-b"}}`, nil, []client.Object{bigger}, apierrors.IsInvalid},
+		{"volumes without data", "db", `{"spec":{"targetNamespace":"tenant-b"}}`, nil, []client.Object{volume}, apierrors.IsInvalid},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			authz := tc.authz
+			if authz == nil {
+				authz = authorizer.AuthorizerFunc(allowAll)
+			}
+			app := newMoveTestREST(t, append(moveTestObjects(), tc.extra...)...)
+			r := NewMoveREST(app, &config.MoveConfig{Data: "None"}, authz)
+			if _, err := serveMove(t, r, tc.app, tc.body); !tc.check(err) {
+				t.Errorf("error = %v", err)
+			}
+			records := &cozyv1alpha1.ApplicationMoveList{}
+			if err := app.c.List(context.Background(), records); err != nil {
+				t.Fatal(err)
+			}
+			if len(records.Items) != 0 {
+				t.Errorf("a rejected move was recorded")
+			}
+		})
+	}
+}
+
+func TestMoveREST_TenantsAreNotMovable(t *testing.T) {
+	app := newMoveTestREST(t, moveTestObjects()...)
+	app.kindName = "Tenant"
+	r := NewMoveREST(app, &config.MoveConfig{Data: "None"}, authorizer.AuthorizerFunc(allowAll))
+	if _, err := serveMove(t, r, "db", `{"spec":{"targetNamespace":"tenant-b"}}`); !apierrors.IsMethodNotSupported(err) {
+		t.Errorf("error = %v, want MethodNotSupported", err)
+	}
+}
@@ -32,6 +32,7 @@ import (
 	"k8s.io/klog/v2"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	"github.com/cozystack/cozystack/internal/controller/tenantquota"
 	appsv1alpha1 "github.com/cozystack/cozystack/pkg/apis/apps/v1alpha1"
 	"github.com/cozystack/cozystack/pkg/apis/apps/validation"
 )
@@ -296,7 +297,11 @@ var rawQuotaKeys = map[string]struct{}{
 //	cpu, memory, ephemeral-storage, custom resources -> "limits.<key>"
 //	storage                                          -> "requests.storage" (no hard limits.storage)
 //	pods, services, services.loadbalancers, ...      -> "<key>" (verbatim)
+//	count/<resource>.<group>                         -> "<key>" (verbatim)
 func renderedLimitKey(raw string) string {
+	if strings.HasPrefix(raw, "count/") {
+		return raw
+	}
 	if raw == "storage" {
 		return "requests.storage"
 	}
@@ -430,3 +435,102 @@ func maxQuotas(dst, src map[string]resource.Quantity) map[string]resource.Quanti
 	}
 	return out
 }
+
+// minQuotas returns the per-resource minimum of dst and src as a new map.
+// Every ResourceQuota in a namespace is enforced, so the tightest hard limit
+// of a resource is the one that binds.
+func minQuotas(dst, src map[string]resource.Quantity) map[string]resource.Quantity {
+	out := map[string]resource.Quantity{}
+	for k, v := range dst {
+		out[k] = v.DeepCopy()
+	}
+	for k, v := range src {
+		if cur, ok := out[k]; !ok || v.Cmp(cur) < 0 {
+			out[k] = v.DeepCopy()
+		}
+	}
+	return out
+}
+
+// validateQuotaHeadroom checks that want, keyed like resourceQuotas (cpu,
+// memory, storage, services.loadbalancers, ...), fits in what the
+// ResourceQuotas of namespace have left. It is the admission check for
+// bringing an existing application into a tenant, where nothing is carved
+// out: the application has to fit the tenant's current usage. Like the
+// declaration-time check, it reads a snapshot and is best-effort.
+func (r *REST) validateQuotaHeadroom(ctx context.Context, namespace string, want map[string]resource.Quantity, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	quotas := &corev1.ResourceQuotaList{}
+	// Uncached and namespace-scoped, as in parentPoolUsage.
+	if err := r.w.List(ctx, quotas, client.InNamespace(namespace)); err != nil {
+		return append(allErrs, field.InternalError(fldPath, err))
+	}
+	var hard, used map[string]resource.Quantity
+	for i := range quotas.Items {
+		rq := &quotas.Items[i]
+		hard = minQuotas(hard, resourceListToQuotas(rq.Status.Hard))
+		used = maxQuotas(used, resourceListToQuotas(rq.Status.Used))
+	}
+	for _, res := range sortedQuotaKeys(want) {
+		key := renderedLimitKey(res)
+		limit, bounded := hard[key]
+		if !bounded {
+			continue
+		}
+		need := want[res]
+		inUse := used[key]
+		remaining := limit.DeepCopy()
+		remaining.Sub(inUse)
+		if need.Cmp(remaining) > 0 {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Key(res),
+				fmt.Sprintf("the application needs %s of %q but namespace %s has %s left (quota %s, %s in use)",
+					need.String(), key, namespace, remaining.String(), limit.String(), inUse.String())))
+		}
+	}
+	return allErrs
+}
+
+// validateApplicationCount enforces the count/<plural>.apps.cozystack.io
+// quota of this kind in namespace before another Application of it is created
+// there. Kubernetes never admits Applications against ResourceQuotas — they
+// are served here, not by kube-apiserver — so this is the admission half of
+// that limit; the tenant quota controller folds the same counts into the
+// shared pools and narrows each member's tenant-quota-allocated share. Like
+// the other quota checks it reads a snapshot and is best-effort.
+func (r *REST) validateApplicationCount(ctx context.Context, namespace, name string) error {
+	key := tenantquota.ApplicationCountResource(r.gvr.Resource)
+	quotas := &corev1.ResourceQuotaList{}
+	// Uncached and namespace-scoped, as in parentPoolUsage.
+	if err := r.w.List(ctx, quotas, client.InNamespace(namespace)); err != nil {
+		return apierrors.NewInternalError(err)
+	}
+	var limit resource.Quantity
+	var limitedBy string
+	for i := range quotas.Items {
+		rq := &quotas.Items[i]
+		hard, ok := rq.Spec.Hard[key]
+		if !ok || (limitedBy != "" && hard.Cmp(limit) >= 0) {
+			continue
+		}
+		limit, limitedBy = hard, rq.Name
+	}
+	if limitedBy == "" {
+		return nil
+	}
+
+	releases := &helmv2.HelmReleaseList{}
+	selector := labels.SelectorFromSet(labels.Set{
+		ApplicationKindLabel:  r.kindName,
+		ApplicationGroupLabel: r.gvk.Group,
+	})
+	if err := r.w.List(ctx, releases, &client.ListOptions{Namespace: namespace, LabelSelector: selector}); err != nil {
+		return apierrors.NewInternalError(err)
+	}
+	used := int64(len(releases.Items))
+	if used+1 > limit.Value() {
+		return apierrors.NewForbidden(r.gvr.GroupResource(), name,
+			fmt.Errorf("exceeded quota: %s, requested: %s=1, used: %s=%d, limited: %s=%s",
+				limitedBy, key, key, used, key, limit.String()))
+	}
+	return nil
+}
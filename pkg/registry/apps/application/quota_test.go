@@ -24,6 +24,7 @@ import (
 	helmv2 "github.com/fluxcd/helm-controller/api/v2"
 	corev1 "k8s.io/api/core/v1"
 	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -138,13 +139,14 @@ func usageQuota(t *testing.T, namespace, name string, used map[string]string) *c
 
 func TestRenderedLimitKey(t *testing.T) {
 	cases := map[string]string{
-		"cpu":                    "limits.cpu",
-		"memory":                 "limits.memory",
-		"ephemeral-storage":      "limits.ephemeral-storage",
-		"devices.com/nvidia":     "limits.devices.com/nvidia",
-		"storage":                "requests.storage",
-		"pods":                   "pods",
-		"services.loadbalancers": "services.loadbalancers",
+		"cpu":                                "limits.cpu",
+		"memory":                             "limits.memory",
+		"ephemeral-storage":                  "limits.ephemeral-storage",
+		"devices.com/nvidia":                 "limits.devices.com/nvidia",
+		"storage":                            "requests.storage",
+		"pods":                               "pods",
+		"services.loadbalancers":             "services.loadbalancers",
+		"count/postgreses.apps.cozystack.io": "count/postgreses.apps.cozystack.io",
 	}
 	for raw, want := range cases {
 		if got := renderedLimitKey(raw); got != want {
@@ -373,3 +375,44 @@ func TestParentTenantHelmReleaseRef(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateApplicationCount(t *testing.T) {
+	key := corev1.ResourceName("count/tenants.apps.cozystack.io")
+	countQuota := func(name, hard string) *corev1.ResourceQuota {
+		return &corev1.ResourceQuota{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "tenant-foo"},
+			Spec:       corev1.ResourceQuotaSpec{Hard: corev1.ResourceList{key: resource.MustParse(hard)}},
+		}
+	}
+	existing := []client.Object{
+		tenantHelmRelease(t, "a", "tenant-foo", nil),
+		tenantHelmRelease(t, "b", "tenant-foo", nil),
+		tenantHelmRelease(t, "c", "tenant-bar", nil),
+	}
+
+	tests := []struct {
+		name    string
+		quotas  []client.Object
+		allowed bool
+	}{
+		{name: "no count quota", allowed: true},
+		{name: "room left", quotas: []client.Object{countQuota("tenant-quota", "3")}, allowed: true},
+		{name: "limit reached", quotas: []client.Object{countQuota("tenant-quota", "2")}},
+		{
+			name:   "tightest quota binds",
+			quotas: []client.Object{countQuota("tenant-quota", "5"), countQuota("tenant-quota-allocated", "2")},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			r := newTenantREST(t, append(append([]client.Object{}, existing...), tc.quotas...)...)
+			err := r.validateApplicationCount(context.Background(), "tenant-foo", "d")
+			if tc.allowed && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !tc.allowed && !apierrors.IsForbidden(err) {
+				t.Fatalf("err = %v, want Forbidden", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,325 @@
+/*
+Copyright 2026 The Cozystack Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package application
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	helmv2 "github.com/fluxcd/helm-controller/api/v2"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/apiserver/pkg/admission"
+	"k8s.io/apiserver/pkg/authorization/authorizer"
+	"k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/apiserver/pkg/registry/rest"
+	"k8s.io/klog/v2"
+
+	appsv1alpha1 "github.com/cozystack/cozystack/pkg/apis/apps/v1alpha1"
+	"github.com/cozystack/cozystack/pkg/config"
+	"github.com/cozystack/cozystack/pkg/helmrender"
+)
+
+var (
+	_ rest.Connecter                = &RenderREST{}
+	_ rest.StorageMetadata          = &RenderREST{}
+	_ rest.GroupVersionKindProvider = &RenderREST{}
+)
+
+// maxRenderRequestSize bounds the Application accepted by POST <plural>/render,
+// matching the apiserver's default request body limit.
+const maxRenderRequestSize = 3 << 20
+
+// redactedValue replaces the values of rendered Secrets.
+const redactedValue = "<redacted>"
+
+// ChartRenderer renders the manifests a HelmRelease would install.
+type ChartRenderer interface {
+	Render(ctx context.Context, hr *helmv2.HelmRelease, opts helmrender.Options) (*helmrender.Result, error)
+}
+
+// RenderREST serves the <plural>/render subresource, a server-side preview
+// of what an Application deploys:
+//
+//	GET  renders the stored Application.
+//	POST renders the Application in the request body. It first goes through
+//	     the regular create (or update, if the Application exists) path with
+//	     dryRun=All, admission included, so the preview fails exactly where
+//	     the real request would, and nothing is persisted.
+//
+// Access follows the connect verbs: get on <plural>/render for GET, create
+// for POST. The response lists the rendered objects, with Secret values
+// redacted, and an ApplicationResourceEstimate priced at the server's
+// Pricing. The chart is rendered with the server's identity, so values
+// the HelmRelease takes from Secrets the caller may not get are masked
+// before rendering. Without a renderer the preview carries the
+// values-based estimate only.
+type RenderREST struct {
+	app        *REST
+	renderer   ChartRenderer
+	pricing    config.Pricing
+	admission  admission.Interface
+	objects    admission.ObjectInterfaces
+	authorizer authorizer.Authorizer
+}
+
+// NewRenderREST returns the render subresource storage for the Application
+// kind served by app. renderer may be nil to disable manifest rendering.
+// POST previews run through admit, as a create or update of the kind would;
+// authz decides which valuesFrom Secrets the caller may see rendered.
+func NewRenderREST(app *REST, renderer ChartRenderer, pricing config.Pricing, admit admission.Interface, objects admission.ObjectInterfaces, authz authorizer.Authorizer) *RenderREST {
+	return &RenderREST{app: app, renderer: renderer, pricing: pricing, admission: admit, objects: objects, authorizer: authz}
+}
+
+// New returns an empty ApplicationRender.
+func (r *RenderREST) New() runtime.Object {
+	return &appsv1alpha1.ApplicationRender{}
+}
+
+// Destroy releases resources associated with RenderREST.
+func (r *RenderREST) Destroy() {}
+
+// GroupVersionKind reports ApplicationRender in the parent's version.
+func (r *RenderREST) GroupVersionKind(schema.GroupVersion) schema.GroupVersionKind {
+	return r.app.gvk.GroupVersion().WithKind("ApplicationRender")
+}
+
+// ProducesMIMETypes reports no MIME types beyond the negotiated ones.
+func (r *RenderREST) ProducesMIMETypes(verb string) []string {
+	return nil
+}
+
+// ProducesObject reports the ApplicationRender response body.
+func (r *RenderREST) ProducesObject(verb string) interface{} {
+	return appsv1alpha1.ApplicationRender{}
+}
+
+// ConnectMethods returns the HTTP methods served by the render endpoint.
+func (r *RenderREST) ConnectMethods() []string {
+	return []string{http.MethodGet, http.MethodPost}
+}
+
+// NewConnectOptions returns no options object.
+func (r *RenderREST) NewConnectOptions() (runtime.Object, bool, string) {
+	return nil, false, ""
+}
+
+// Connect returns a handler rendering the named Application.
+func (r *RenderREST) Connect(ctx context.Context, name string, _ runtime.Object, responder rest.Responder) (http.Handler, error) {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		var (
+			hr  *helmv2.HelmRelease
+			err error
+		)
+		if req.Method == http.MethodPost {
+			hr, err = r.dryRun(ctx, name, req.Body)
+		} else {
+			hr, err = r.app.getApplicationHelmRelease(ctx, name)
+		}
+		if err != nil {
+			responder.Error(err)
+			return
+		}
+		responder.Object(http.StatusOK, r.render(ctx, name, hr))
+	}), nil
+}
+
+// dryRun decodes the proposed Application, runs it through Create or Update
+// with dryRun=All and the server's admission chain, as the API handlers
+// would, and returns the HelmRelease it translates to.
+func (r *RenderREST) dryRun(ctx context.Context, name string, body io.Reader) (*helmv2.HelmRelease, error) {
+	namespace, err := r.app.getNamespace(ctx)
+	if err != nil {
+		return nil, err
+	}
+	app := &appsv1alpha1.Application{}
+	dec := utilyaml.NewYAMLOrJSONDecoder(io.LimitReader(body, maxRenderRequestSize), 4096)
+	if err := dec.Decode(app); err != nil {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("decode %s: %v", r.app.kindName, err))
+	}
+	if app.Name == "" {
+		app.Name = name
+	}
+	if app.Namespace == "" {
+		app.Namespace = namespace
+	}
+	if app.Name != name || app.Namespace != namespace {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("%s %s/%s does not match the request path %s/%s", r.app.kindName, app.Namespace, app.Name, namespace, name))
+	}
+	user, _ := request.UserFrom(ctx)
+	dryRunAll := []string{metav1.DryRunAll}
+	attributes := func(obj, old runtime.Object, op admission.Operation, opts runtime.Object) admission.Attributes {
+		return admission.NewAttributesRecord(obj, old, r.app.gvk, namespace, name, r.app.gvr, "", op, opts, true, user)
+	}
+
+	_, err = r.app.getApplicationHelmRelease(ctx, name)
+	switch {
+	case apierrors.IsNotFound(err):
+		opts := &metav1.CreateOptions{DryRun: dryRunAll}
+		attrs := attributes(app, nil, admission.Create, opts)
+		if err := r.mutate(ctx, attrs); err != nil {
+			return nil, err
+		}
+		_, err = r.app.Create(ctx, app, rest.AdmissionToValidateObjectFunc(r.admission, attrs, r.objects), opts)
+	case err == nil:
+		opts := &metav1.UpdateOptions{DryRun: dryRunAll}
+		attrs := attributes(nil, nil, admission.Update, opts)
+		admit := func(ctx context.Context, obj, old runtime.Object) (runtime.Object, error) {
+			if err := r.mutate(ctx, attributes(obj, old, admission.Update, opts)); err != nil {
+				return nil, err
+			}
+			// Render what admission let through.
+			if admitted, ok := obj.(*appsv1alpha1.Application); ok {
+				app = admitted
+			}
+			return obj, nil
+		}
+		_, _, err = r.app.Update(ctx, name, rest.DefaultUpdatedObjectInfo(app, admit),
+			rest.AdmissionToValidateObjectFunc(r.admission, attrs, r.objects),
+			rest.AdmissionToValidateObjectUpdateFunc(r.admission, attrs, r.objects),
+			false, opts)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	stored, err := r.app.toValues(app)
+	if err != nil {
+		return nil, apierrors.NewBadRequest(err.Error())
+	}
+	return r.app.ConvertApplicationToHelmRelease(stored)
+}
+
+// mutate runs the mutating admission plugins over attrs.
+func (r *RenderREST) mutate(ctx context.Context, attrs admission.Attributes) error {
+	m, ok := r.admission.(admission.MutationInterface)
+	if !ok || !m.Handles(attrs.GetOperation()) {
+		return nil
+	}
+	return m.Admit(ctx, attrs, r.objects)
+}
+
+// canReadSecret reports whether the caller may get the Secret name in
+// namespace. Without an authorizer or a user nothing is readable.
+func (r *RenderREST) canReadSecret(namespace string) func(context.Context, string) (bool, error) {
+	return func(ctx context.Context, name string) (bool, error) {
+		user, ok := request.UserFrom(ctx)
+		if !ok || r.authorizer == nil {
+			return false, nil
+		}
+		decision, _, err := r.authorizer.Authorize(ctx, authorizer.AttributesRecord{
+			User:            user,
+			Verb:            "get",
+			Namespace:       namespace,
+			APIVersion:      "v1",
+			Resource:        "secrets",
+			Name:            name,
+			ResourceRequest: true,
+		})
+		return err == nil && decision == authorizer.DecisionAllow, nil
+	}
+}
+
+// render builds the preview of hr. Rendering and estimate failures are
+// reported as warnings: the preview stays useful with whatever did succeed.
+func (r *RenderREST) render(ctx context.Context, name string, hr *helmv2.HelmRelease) *appsv1alpha1.ApplicationRender {
+	out := &appsv1alpha1.ApplicationRender{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: r.app.gvk.GroupVersion().String(),
+			Kind:       "ApplicationRender",
+		},
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: hr.Namespace},
+	}
+	if ref := hr.Spec.ChartRef; ref != nil {
+		out.Chart = appsv1alpha1.ApplicationRenderChart{Kind: ref.Kind, Namespace: ref.Namespace, Name: ref.Name}
+	}
+
+	var objects []*unstructured.Unstructured
+	if r.renderer == nil {
+		out.Warnings = append(out.Warnings, "manifest rendering is not enabled on this server; the estimate follows the chart values")
+	} else if res, err := r.renderer.Render(ctx, hr, helmrender.Options{CanReadSecret: r.canReadSecret(hr.Namespace)}); err != nil {
+		klog.V(4).Infof("Rendering HelmRelease %s/%s failed: %v", hr.Namespace, hr.Name, err)
+		out.Warnings = append(out.Warnings, fmt.Sprintf("rendering failed: %v", err))
+	} else {
+		out.Chart.Revision = res.Revision
+		for _, name := range res.MaskedSecrets {
+			out.Warnings = append(out.Warnings, fmt.Sprintf("values from Secret %s are shown as %s: you may not get that Secret", name, helmrender.MaskedValue))
+		}
+		objects = res.Objects
+		for _, obj := range objects {
+			redactSecret(obj)
+			raw, err := obj.MarshalJSON()
+			if err != nil {
+				out.Warnings = append(out.Warnings, fmt.Sprintf("encode %s %s: %v", obj.GetKind(), obj.GetName(), err))
+				continue
+			}
+			out.Objects = append(out.Objects, runtime.RawExtension{Raw: raw})
+		}
+	}
+
+	totals, sawWorkload, unaccounted := estimateManifests(objects)
+	source := estimateFromManifests
+	if !sawWorkload {
+		// Charts whose workloads are custom resources (operators'
+		// clusters, virtual machines) render no pods to sum.
+		var values []byte
+		if hr.Spec.Values != nil {
+			values = hr.Spec.Values.Raw
+		}
+		fromValues, err := estimateValues(values)
+		if err != nil {
+			out.Warnings = append(out.Warnings, fmt.Sprintf("estimate: %v", err))
+		} else {
+			// What did render is authoritative over the conventions.
+			if len(objects) > 0 {
+				fromValues.loadBalancers = totals.loadBalancers
+				if !totals.storage.IsZero() {
+					fromValues.storage = totals.storage
+				}
+			}
+			totals, source = fromValues, estimateFromValues
+		}
+	} else if len(unaccounted) > 0 {
+		out.Warnings = append(out.Warnings, "estimate does not cover "+strings.Join(unaccounted, ", "))
+	}
+	out.Estimate = totals.estimate(source)
+	out.Estimate.Cost = estimateCost(out.Estimate, r.pricing)
+	return out
+}
+
+// redactSecret keeps the keys of a rendered Secret and drops its values.
+func redactSecret(obj *unstructured.Unstructured) {
+	if obj.GetAPIVersion() != "v1" || obj.GetKind() != "Secret" {
+		return
+	}
+	for _, field := range []string{"data", "stringData"} {
+		m, ok := obj.Object[field].(map[string]any)
+		if !ok {
+			continue
+		}
+		for k := range m {
+			m[k] = redactedValue
+		}
+	}
+}
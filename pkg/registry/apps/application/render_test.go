@@ -0,0 +1,460 @@
+/*
+Copyright 2026 The Cozystack Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package application
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	helmv2 "github.com/fluxcd/helm-controller/api/v2"
+	"github.com/fluxcd/pkg/apis/meta"
+	sourcev1 "github.com/fluxcd/source-controller/api/v1"
+	corev1 "k8s.io/api/core/v1"
+	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apiserver/pkg/admission"
+	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/apiserver/pkg/authorization/authorizer"
+	genericapirequest "k8s.io/apiserver/pkg/endpoints/request"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	appsv1alpha1 "github.com/cozystack/cozystack/pkg/apis/apps/v1alpha1"
+	"github.com/cozystack/cozystack/pkg/config"
+	"github.com/cozystack/cozystack/pkg/helmrender"
+)
+
+const renderedManifests = `
+apiVersion: apps/v1
+kind: StatefulSet
+metadata: {name: db}
+spec:
+  replicas: 3
+  template:
+    spec:
+      containers:
+      - name: db
+        resources:
+          requests: {cpu: 500m, memory: 1Gi}
+          limits: {cpu: "1", memory: 2Gi}
+  volumeClaimTemplates:
+  - spec:
+      resources:
+        requests: {storage: 10Gi}
+---
+apiVersion: v1
+kind: Service
+metadata: {name: db-external}
+spec: {type: LoadBalancer}
+---
+apiVersion: v1
+kind: Secret
+metadata: {name: db-credentials}
+stringData: {password: hunter2}
+---
+apiVersion: postgresql.cnpg.io/v1
+kind: Cluster
+metadata: {name: db}
+`
+
+// fakeRenderer returns fixed manifests and records what it was asked to
+// render.
+type fakeRenderer struct {
+	manifests string
+	rendered  *helmv2.HelmRelease
+}
+
+func (f *fakeRenderer) Render(_ context.Context, hr *helmv2.HelmRelease, _ helmrender.Options) (*helmrender.Result, error) {
+	f.rendered = hr
+	objects, err := helmrender.ParseManifests([]byte(f.manifests))
+	if err != nil {
+		return nil, err
+	}
+	return &helmrender.Result{Revision: "0.1.0", Objects: objects}, nil
+}
+
+func serveRender(t *testing.T, r *RenderREST, method, name, body string) (*appsv1alpha1.ApplicationRender, error) {
+	t.Helper()
+	ctx := genericapirequest.WithNamespace(context.Background(), "tenant-a")
+	ctx = genericapirequest.WithUser(ctx, &user.DefaultInfo{Name: "alice"})
+	resp := &fakeResponder{}
+	h, err := r.Connect(ctx, name, nil, resp)
+	if err != nil {
+		return nil, err
+	}
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(method, "/render", strings.NewReader(body)))
+	if resp.err != nil {
+		return nil, resp.err
+	}
+	return resp.obj.(*appsv1alpha1.ApplicationRender), nil
+}
+
+func TestEstimateManifests(t *testing.T) {
+	objects, err := helmrender.ParseManifests([]byte(renderedManifests))
+	if err != nil {
+		t.Fatal(err)
+	}
+	totals, sawWorkload, unaccounted := estimateManifests(objects)
+	if !sawWorkload {
+		t.Fatal("the StatefulSet was not counted as a workload")
+	}
+	e := totals.estimate(estimateFromManifests)
+	for name, want := range map[string]resource.Quantity{
+		"requests.cpu":    e.Requests[corev1.ResourceCPU],
+		"requests.memory": e.Requests[corev1.ResourceMemory],
+		"limits.cpu":      e.Limits[corev1.ResourceCPU],
+	} {
+		got := map[string]string{"requests.cpu": "1500m", "requests.memory": "3Gi", "limits.cpu": "3"}[name]
+		if want.Cmp(resource.MustParse(got)) != 0 {
+			t.Errorf("%s = %s, want %s", name, want.String(), got)
+		}
+	}
+	if e.Storage == nil || e.Storage.Cmp(resource.MustParse("30Gi")) != 0 {
+		t.Errorf("storage = %v, want 30Gi", e.Storage)
+	}
+	if e.LoadBalancers != 1 {
+		t.Errorf("loadBalancers = %d, want 1", e.LoadBalancers)
+	}
+	if want := []string{"Cluster.postgresql.cnpg.io"}; !reflect.DeepEqual(unaccounted, want) {
+		t.Errorf("unaccounted = %v, want %v", unaccounted, want)
+	}
+}
+
+func TestEstimateValues(t *testing.T) {
+	totals, err := estimateValues([]byte(`{"replicas":2,"resourcesPreset":"u1.small","resources":{"cpu":2},"size":"5Gi","external":true}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	e := totals.estimate(estimateFromValues)
+	if cpu := e.Limits[corev1.ResourceCPU]; cpu.Cmp(resource.MustParse("4")) != 0 {
+		t.Errorf("limits.cpu = %s, want 4 (explicit cpu over the preset, times replicas)", cpu.String())
+	}
+	if mem := e.Limits[corev1.ResourceMemory]; mem.Cmp(resource.MustParse("8Gi")) != 0 {
+		t.Errorf("limits.memory = %s, want 8Gi (u1.small memory, times replicas)", mem.String())
+	}
+	if e.Storage == nil || e.Storage.Cmp(resource.MustParse("10Gi")) != 0 {
+		t.Errorf("storage = %v, want 10Gi", e.Storage)
+	}
+	if e.LoadBalancers != 1 {
+		t.Errorf("loadBalancers = %d, want 1", e.LoadBalancers)
+	}
+}
+
+func TestEstimateCost(t *testing.T) {
+	e := appsv1alpha1.ApplicationResourceEstimate{
+		Requests:      corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1500m"), corev1.ResourceMemory: resource.MustParse("3Gi")},
+		Limits:        corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("3")},
+		LoadBalancers: 1,
+	}
+	storage := resource.MustParse("30Gi")
+	e.Storage = &storage
+	pricing := config.Pricing{
+		Currency:     "EUR",
+		CPU:          resource.MustParse("10"),
+		Memory:       resource.MustParse("2.5"),
+		Storage:      resource.MustParse("0.1"),
+		LoadBalancer: resource.MustParse("5"),
+	}
+	cost := estimateCost(e, pricing)
+	want := &appsv1alpha1.ApplicationCostEstimate{
+		Currency: "EUR",
+		Monthly:  "30.50",
+		Breakdown: map[string]string{
+			"cpu": "15.00", "memory": "7.50", "storage": "3.00", "loadBalancers": "5.00",
+		},
+	}
+	if !reflect.DeepEqual(cost, want) {
+		t.Errorf("estimateCost = %+v, want %+v", cost, want)
+	}
+	if estimateCost(e, config.Pricing{Currency: "EUR"}) != nil {
+		t.Error("a cost was estimated without prices")
+	}
+}
+
+func TestRenderREST_PostNewApplicationIsDryRun(t *testing.T) {
+	app := newChildrenTestREST(t)
+	renderer := &fakeRenderer{manifests: renderedManifests}
+	r := NewRenderREST(app, renderer, config.Pricing{CPU: resource.MustParse("10")}, nil, nil, nil)
+
+	out, err := serveRender(t, r, http.MethodPost, "db", `{"metadata":{"name":"db"},"spec":{"replicas":3}}`)
+	if err != nil {
+		t.Fatalf("render: %v", err)
+	}
+
+	if err := app.c.Get(context.Background(), client.ObjectKey{Namespace: "tenant-a", Name: "postgresql-db"}, &helmv2.HelmRelease{}); !apierrors.IsNotFound(err) {
+		t.Fatalf("render persisted the HelmRelease (get error %v)", err)
+	}
+	if renderer.rendered == nil || string(renderer.rendered.Spec.Values.Raw) != `{"replicas":3}` {
+		t.Errorf("rendered HelmRelease values = %v, want the posted spec", renderer.rendered)
+	}
+	if out.Chart.Revision != "0.1.0" || len(out.Objects) != 4 {
+		t.Errorf("preview = revision %q, %d objects; want 0.1.0, 4", out.Chart.Revision, len(out.Objects))
+	}
+	var secret unstructured.Unstructured
+	if err := json.Unmarshal(out.Objects[2].Raw, &secret.Object); err != nil {
+		t.Fatal(err)
+	}
+	if pw, _, _ := unstructured.NestedString(secret.Object, "stringData", "password"); pw != redactedValue {
+		t.Errorf("rendered Secret value = %q, want it redacted", pw)
+	}
+	if out.Estimate.Source != estimateFromManifests || out.Estimate.Cost == nil || out.Estimate.Cost.Monthly != "15.00" {
+		t.Errorf("estimate = %+v, want a manifests estimate costing 15.00", out.Estimate)
+	}
+	if len(out.Warnings) != 1 || !strings.Contains(out.Warnings[0], "Cluster.postgresql.cnpg.io") {
+		t.Errorf("warnings = %v, want the unaccounted Cluster", out.Warnings)
+	}
+}
+
+func TestRenderREST_PostExistingApplicationIsDryRun(t *testing.T) {
+	hr := appHelmRelease("tenant-a", "db")
+	hr.Spec.Values = &apiextv1.JSON{Raw: []byte(`{"replicas":1}`)}
+	app := newChildrenTestREST(t, hr)
+	r := NewRenderREST(app, nil, config.Pricing{}, nil, nil, nil)
+
+	out, err := serveRender(t, r, http.MethodPost, "db", `{"spec":{"replicas":2,"resourcesPreset":"t1.small"}}`)
+	if err != nil {
+		t.Fatalf("render: %v", err)
+	}
+	stored := &helmv2.HelmRelease{}
+	if err := app.c.Get(context.Background(), client.ObjectKey{Namespace: "tenant-a", Name: "postgresql-db"}, stored); err != nil {
+		t.Fatal(err)
+	}
+	if got := string(stored.Spec.Values.Raw); got != `{"replicas":1}` {
+		t.Errorf("stored values = %s, the dry-run update was persisted", got)
+	}
+	if out.Estimate.Source != estimateFromValues {
+		t.Errorf("estimate source = %q, want %q without a renderer", out.Estimate.Source, estimateFromValues)
+	}
+	if cpu := out.Estimate.Limits[corev1.ResourceCPU]; cpu.Cmp(resource.MustParse("2")) != 0 {
+		t.Errorf("limits.cpu = %s, want 2 (t1.small times the posted replicas)", cpu.String())
+	}
+	if len(out.Warnings) != 1 || !strings.Contains(out.Warnings[0], "not enabled") {
+		t.Errorf("warnings = %v, want the disabled-renderer notice", out.Warnings)
+	}
+}
+
+func TestRenderREST_PostRejectsMismatchedName(t *testing.T) {
+	r := NewRenderREST(newChildrenTestREST(t), nil, config.Pricing{}, nil, nil, nil)
+	_, err := serveRender(t, r, http.MethodPost, "db", `{"metadata":{"name":"other"},"spec":{}}`)
+	if !apierrors.IsBadRequest(err) {
+		t.Fatalf("error = %v, want BadRequest", err)
+	}
+}
+
+func TestRenderREST_GetMissingApplication(t *testing.T) {
+	r := NewRenderREST(newChildrenTestREST(t), nil, config.Pricing{}, nil, nil, nil)
+	if _, err := serveRender(t, r, http.MethodGet, "db", ""); !apierrors.IsNotFound(err) {
+		t.Fatalf("error = %v, want NotFound", err)
+	}
+}
+
+// fakeAdmission mutates created Applications by setting spec.replicas to
+// 5 and rejects any that ask for more than 10.
+type fakeAdmission struct{}
+
+func (fakeAdmission) Handles(admission.Operation) bool { return true }
+
+func (fakeAdmission) Admit(_ context.Context, a admission.Attributes, _ admission.ObjectInterfaces) error {
+	app := a.GetObject().(*appsv1alpha1.Application)
+	if !a.IsDryRun() {
+		return fmt.Errorf("admission saw a request that is not a dry run")
+	}
+	if app.Spec == nil || len(app.Spec.Raw) == 0 || string(app.Spec.Raw) == "{}" {
+		app.Spec = &apiextv1.JSON{Raw: []byte(`{"replicas":5}`)}
+	}
+	return nil
+}
+
+func (fakeAdmission) Validate(_ context.Context, a admission.Attributes, _ admission.ObjectInterfaces) error {
+	var spec struct{ Replicas int }
+	app := a.GetObject().(*appsv1alpha1.Application)
+	if app.Spec != nil {
+		_ = json.Unmarshal(app.Spec.Raw, &spec)
+	}
+	if spec.Replicas > 10 {
+		return admission.NewForbidden(a, fmt.Errorf("at most 10 replicas"))
+	}
+	return nil
+}
+
+func TestRenderREST_PostRunsAdmission(t *testing.T) {
+	for _, existing := range []bool{false, true} {
+		var objs []client.Object
+		if existing {
+			objs = append(objs, appHelmRelease("tenant-a", "db"))
+		}
+		renderer := &fakeRenderer{manifests: renderedManifests}
+		r := NewRenderREST(newChildrenTestREST(t, objs...), renderer, config.Pricing{}, fakeAdmission{}, nil, nil)
+
+		if _, err := serveRender(t, r, http.MethodPost, "db", `{"spec":{"replicas":20}}`); !apierrors.IsForbidden(err) {
+			t.Errorf("existing=%v: error = %v, want the validating admission to forbid the preview", existing, err)
+		}
+		if _, err := serveRender(t, r, http.MethodPost, "db", `{"spec":{}}`); err != nil {
+			t.Fatalf("existing=%v: render: %v", existing, err)
+		}
+		if got := string(renderer.rendered.Spec.Values.Raw); got != `{"replicas":5}` {
+			t.Errorf("existing=%v: rendered values = %s, want the mutating admission's", existing, got)
+		}
+	}
+}
+
+// renderChart is a chart whose Secret-derived and spec-derived values both
+// reach a ConfigMap.
+var renderChart = map[string]string{
+	"postgresql/Chart.yaml": "apiVersion: v2\nname: postgresql\nversion: 0.1.0\n",
+	"postgresql/templates/statefulset.yaml": `apiVersion: apps/v1
+kind: StatefulSet
+metadata:
+  name: {{ .Release.Name }}
+spec:
+  replicas: {{ .Values.replicas | default 1 }}
+  template:
+    spec:
+      containers:
+      - name: db
+        resources:
+          requests: {cpu: 500m, memory: 1Gi}
+`,
+	"postgresql/templates/configmap.yaml": `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: {{ .Release.Name }}-config
+data:
+  token: {{ .Values._cluster.token | quote }}
+`,
+}
+
+func tarGz(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for name, body := range files {
+		if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0o644, Size: int64(len(body)), Typeflag: tar.TypeReg}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write([]byte(body)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+// TestRenderREST_RendersChart runs a POST preview through the Helm engine:
+// the chart artifact is fetched over HTTP, cozystack-values is merged in
+// and masked for a caller who may not get it.
+func TestRenderREST_RendersChart(t *testing.T) {
+	archive := tarGz(t, renderChart)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write(archive)
+	}))
+	t.Cleanup(srv.Close)
+
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = helmv2.AddToScheme(scheme)
+	_ = sourcev1.AddToScheme(scheme)
+	artifact := &sourcev1.ExternalArtifact{
+		ObjectMeta: metav1.ObjectMeta{Name: "postgresql", Namespace: "cozy-public"},
+		Status: sourcev1.ExternalArtifactStatus{Artifact: &meta.Artifact{
+			URL: srv.URL + "/postgresql.tgz", Revision: "0.1.0@sha256:abc",
+		}},
+	}
+	values := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "cozystack-values", Namespace: "tenant-a"},
+		Data:       map[string][]byte{"values.yaml": []byte("_cluster: {token: s3cr3t}\n")},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(artifact, values).WithStatusSubresource(artifact).Build()
+	app := NewREST(c, c, &config.Resource{
+		Application: config.ApplicationConfig{Kind: "PostgreSQL", Plural: "postgresqls", Singular: "postgresql"},
+		Release: config.ReleaseConfig{
+			Prefix:   "postgresql-",
+			ChartRef: config.ChartRefConfig{Kind: "ExternalArtifact", Name: "postgresql", Namespace: "cozy-public"},
+		},
+	})
+
+	for _, tc := range []struct {
+		name     string
+		canRead  bool
+		token    string
+		warnings int
+	}{
+		{name: "secret reader", canRead: true, token: "s3cr3t"},
+		{name: "no secret access", canRead: false, token: helmrender.MaskedValue, warnings: 1},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			authz := authorizer.AuthorizerFunc(func(_ context.Context, a authorizer.Attributes) (authorizer.Decision, string, error) {
+				if a.GetResource() == "secrets" && a.GetNamespace() == "tenant-a" && a.GetName() == "cozystack-values" && a.GetUser().GetName() == "alice" && tc.canRead {
+					return authorizer.DecisionAllow, "", nil
+				}
+				return authorizer.DecisionNoOpinion, "", nil
+			})
+			r := NewRenderREST(app, helmrender.New(c, nil), config.Pricing{}, nil, nil, authz)
+			out, err := serveRender(t, r, http.MethodPost, "db", `{"spec":{"replicas":3}}`)
+			if err != nil {
+				t.Fatalf("render: %v", err)
+			}
+			if out.Chart.Revision != "0.1.0@sha256:abc" || len(out.Objects) != 2 {
+				t.Fatalf("preview = revision %q, %d objects (warnings %v); want the chart's 2", out.Chart.Revision, len(out.Objects), out.Warnings)
+			}
+			var cm, sts unstructured.Unstructured
+			for _, raw := range out.Objects {
+				var obj unstructured.Unstructured
+				if err := json.Unmarshal(raw.Raw, &obj.Object); err != nil {
+					t.Fatal(err)
+				}
+				switch obj.GetKind() {
+				case "ConfigMap":
+					cm = obj
+				case "StatefulSet":
+					sts = obj
+				}
+			}
+			if token, _, _ := unstructured.NestedString(cm.Object, "data", "token"); token != tc.token {
+				t.Errorf("rendered token = %q, want %q", token, tc.token)
+			}
+			if replicas, _, _ := unstructured.NestedFieldNoCopy(sts.Object, "spec", "replicas"); fmt.Sprint(replicas) != "3" {
+				t.Errorf("rendered replicas = %v, want the posted 3", replicas)
+			}
+			if out.Estimate.Source != estimateFromManifests {
+				t.Errorf("estimate source = %q, want %q", out.Estimate.Source, estimateFromManifests)
+			}
+			if len(out.Warnings) != tc.warnings {
+				t.Errorf("warnings = %v, want %d", out.Warnings, tc.warnings)
+			}
+		})
+	}
+}
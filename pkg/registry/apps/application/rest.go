@@ -31,7 +31,6 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metainternalversion "k8s.io/apimachinery/pkg/apis/meta/internalversion"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/fields"
 	labels "k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -46,6 +45,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	fluxshard "github.com/cozystack/cozystack/internal/fluxshardoperator"
+	"github.com/cozystack/cozystack/pkg/apis/apps/conversion"
 	"github.com/cozystack/cozystack/pkg/apis/apps/presets"
 	appsv1alpha1 "github.com/cozystack/cozystack/pkg/apis/apps/v1alpha1"
 	"github.com/cozystack/cozystack/pkg/apis/apps/validation"
@@ -95,6 +95,9 @@ type REST struct {
 	singularName  string
 	releaseConfig config.ReleaseConfig
 	specSchema    *structuralschema.Structural
+	// converter translates specs of the served version to and from the
+	// chart values; nil when the version is shaped like the values.
+	converter *conversion.Converter
 }
 
 // buildSpecSchema parses an OpenAPI-v3 JSON schema string and returns the
@@ -126,29 +129,48 @@ func buildSpecSchema(raw string) (*structuralschema.Structural, error) {
 	return s, nil
 }
 
-// NewREST creates a new REST storage for Application with specific configuration
+// NewREST creates a new REST storage for Application with specific
+// configuration, serving the first version the kind is served under.
 func NewREST(c client.Client, w client.WithWatch, config *config.Resource) *REST {
-	specSchema, err := buildSpecSchema(config.Application.OpenAPISchema)
+	return NewVersionedREST(c, w, config, config.Application.ServedVersions()[0].Name)
+}
+
+// NewVersionedREST creates the REST storage serving an Application kind under
+// one apps.cozystack.io version. Specs are defaulted with that version's
+// schema and converted to and from the HelmRelease values with its
+// conversion rules.
+func NewVersionedREST(c client.Client, w client.WithWatch, config *config.Resource, version string) *REST {
+	served, ok := config.Application.ServedVersion(version)
+	if !ok {
+		klog.Errorf("%s is not served as %s; serving it without conversion", config.Application.Kind, version)
+		served.OpenAPISchema = config.Application.OpenAPISchema
+	}
+	specSchema, err := buildSpecSchema(served.OpenAPISchema)
 	if err != nil {
 		klog.Errorf("Failed to build spec schema: %v", err)
 	}
+	converter, err := conversion.New(served.Conversion)
+	if err != nil {
+		klog.Errorf("Failed to build %s %s spec conversion: %v", config.Application.Kind, version, err)
+	}
 
 	return &REST{
 		c: c,
 		w: w,
 		gvr: schema.GroupVersionResource{
 			Group:    appsv1alpha1.GroupName,
-			Version:  "v1alpha1",
+			Version:  version,
 			Resource: config.Application.Plural,
 		},
 		gvk: schema.GroupVersion{
 			Group:   appsv1alpha1.GroupName,
-			Version: "v1alpha1",
+			Version: version,
 		}.WithKind(config.Application.Kind),
 		kindName:      config.Application.Kind,
 		singularName:  config.Application.Singular,
 		releaseConfig: config.Release,
 		specSchema:    specSchema,
+		converter:     converter,
 	}
 }
 
@@ -180,6 +202,14 @@ func (r *REST) Create(ctx context.Context, obj runtime.Object, createValidation
 		return nil, apierrors.NewInvalid(r.gvk.GroupKind(), app.Name, nameLenErrs)
 	}
 
+	// Translate the spec from the served version into chart values; the
+	// checks below and the HelmRelease all work on the values shape, while
+	// admission still sees the object as the client sent it.
+	stored, err := r.toValues(app)
+	if err != nil {
+		return nil, apierrors.NewBadRequest(err.Error())
+	}
+
 	// For Tenant applications, also validate that the computed workload
 	// namespace fits within the DNS-1123 label limit. A deeply-nested tenant
 	// can exceed the limit even when its own name passes the per-name Helm
@@ -191,17 +221,17 @@ func (r *REST) Create(ctx context.Context, obj runtime.Object, createValidation
 		}
 		// Enforce hierarchical quota allocation: a child tenant's declared
 		// quota may not exceed its parent's remaining (un-carved) quota.
-		if qErrs := r.validateTenantResourceQuotas(ctx, app); len(qErrs) > 0 {
+		if qErrs := r.validateTenantResourceQuotas(ctx, stored); len(qErrs) > 0 {
 			return nil, apierrors.NewInvalid(r.gvk.GroupKind(), app.Name, qErrs)
 		}
 	}
 
 	// Validate that values don't contain reserved keys (starting with "_")
-	if err := validateNoInternalKeys(app.Spec); err != nil {
+	if err := validateNoInternalKeys(stored.Spec); err != nil {
 		return nil, apierrors.NewBadRequest(err.Error())
 	}
 
-	r.warnLegacyPresets(app)
+	r.warnLegacyPresets(stored)
 
 	// Run the genericapiserver-supplied validating admission chain
 	// (validating webhooks + ValidatingAdmissionPolicies) before
@@ -217,8 +247,15 @@ func (r *REST) Create(ctx context.Context, obj runtime.Object, createValidation
 		}
 	}
 
+	// Enforce the tenant's count/<plural>.apps.cozystack.io quota, if any.
+	// Like Kubernetes' ResourceQuota plugin, it runs last, once the object
+	// has passed validation.
+	if err := r.validateApplicationCount(ctx, app.Namespace, app.Name); err != nil {
+		return nil, err
+	}
+
 	// Convert Application to HelmRelease
-	helmRelease, err := r.ConvertApplicationToHelmRelease(app)
+	helmRelease, err := r.ConvertApplicationToHelmRelease(stored)
 	if err != nil {
 		klog.Errorf("Conversion error: %v", err)
 		return nil, fmt.Errorf("conversion error: %v", err)
@@ -239,8 +276,9 @@ func (r *REST) Create(ctx context.Context, obj runtime.Object, createValidation
 
 	klog.V(6).Infof("Creating HelmRelease %s in namespace %s", helmRelease.Name, app.Namespace)
 
-	// Create HelmRelease in Kubernetes
-	err = r.c.Create(ctx, helmRelease, &client.CreateOptions{Raw: options})
+	// Create HelmRelease in Kubernetes. controller-runtime rebuilds the
+	// request options from its own fields, so DryRun is passed through them.
+	err = r.c.Create(ctx, helmRelease, &client.CreateOptions{Raw: options, DryRun: options.DryRun})
 	if err != nil {
 		klog.Errorf("Failed to create HelmRelease %s: %v", helmRelease.Name, err)
 		return nil, fmt.Errorf("failed to create HelmRelease: %v", err)
@@ -331,13 +369,17 @@ func (r *REST) List(ctx context.Context, options *metainternalversion.ListOption
 		klog.Errorf("Error parsing field selector: %v", err)
 		return nil, err
 	}
+	fieldSelector, err := r.parseApplicationFieldSelector(options.FieldSelector)
+	if err != nil {
+		return nil, err
+	}
 
 	// If field selector specifies namespace different from context, return empty list
 	if fieldFilter.Namespace != "" && namespace != "" && namespace != fieldFilter.Namespace {
 		klog.V(6).Infof("Field selector namespace %s doesn't match context namespace %s, returning empty list", fieldFilter.Namespace, namespace)
 		return &appsv1alpha1.ApplicationList{
 			TypeMeta: metav1.TypeMeta{
-				APIVersion: appsv1alpha1.SchemeGroupVersion.String(),
+				APIVersion: r.gvk.GroupVersion().String(),
 				Kind:       r.kindName + "List",
 			},
 		}, nil
@@ -444,20 +486,9 @@ func (r *REST) List(ctx context.Context, options *metainternalversion.ListOption
 			}
 		}
 
-		// Apply field.selector by name and namespace (if specified)
-		if options.FieldSelector != nil {
-			fs, err := fields.ParseSelector(options.FieldSelector.String())
-			if err != nil {
-				klog.Errorf("Invalid field selector: %v", err)
-				continue
-			}
-			fieldsSet := fields.Set{
-				"metadata.name":      app.Name,
-				"metadata.namespace": app.Namespace,
-			}
-			if !fs.Matches(fieldsSet) {
-				continue
-			}
+		// Apply field.selector, including spec and status fields
+		if !fieldSelector.Matches(&app) {
+			continue
 		}
 
 		items = append(items, app)
@@ -497,7 +528,11 @@ func (r *REST) Update(ctx context.Context, name string, objInfo rest.UpdatedObje
 				klog.Errorf("Failed to get updated object: %v", err)
 				return nil, false, err
 			}
-			createdObj, err := r.Create(ctx, obj, createValidation, &metav1.CreateOptions{})
+			createdObj, err := r.Create(ctx, obj, createValidation, &metav1.CreateOptions{
+				DryRun:          options.DryRun,
+				FieldManager:    options.FieldManager,
+				FieldValidation: options.FieldValidation,
+			})
 			if err != nil {
 				klog.Errorf("Failed to create new Application: %v", err)
 				return nil, false, err
@@ -534,6 +569,12 @@ func (r *REST) Update(ctx context.Context, name string, objInfo rest.UpdatedObje
 	// Update because Kubernetes names are immutable. Validating here would block
 	// updates to pre-existing resources whose names don't conform to the new rules.
 
+	// Translate the spec from the served version into chart values.
+	app, err = r.toValues(app)
+	if err != nil {
+		return nil, false, apierrors.NewBadRequest(err.Error())
+	}
+
 	// Validate that values don't contain reserved keys (starting with "_")
 	if err := validateNoInternalKeys(app.Spec); err != nil {
 		return nil, false, apierrors.NewBadRequest(err.Error())
@@ -603,7 +644,7 @@ func (r *REST) Update(ctx context.Context, name string, objInfo rest.UpdatedObje
 	// real spec conflict here: refresh the resourceVersion from the live object
 	// and retry.
 	err = retry.RetryOnConflict(retry.DefaultBackoff, func() error {
-		updateErr := r.c.Update(ctx, helmRelease, &client.UpdateOptions{Raw: &metav1.UpdateOptions{}})
+		updateErr := r.c.Update(ctx, helmRelease, &client.UpdateOptions{Raw: options, DryRun: options.DryRun})
 		if apierrors.IsConflict(updateErr) {
 			cur := &helmv2.HelmRelease{}
 			if getErr := r.c.Get(ctx, client.ObjectKey{Namespace: helmRelease.Namespace, Name: helmRelease.Name}, cur, &client.GetOptions{Raw: &metav1.GetOptions{}}); getErr != nil {
@@ -723,6 +764,10 @@ func (r *REST) Watch(ctx context.Context, options *metainternalversion.ListOptio
 		klog.Errorf("Error parsing field selector: %v", err)
 		return nil, err
 	}
+	fieldSelector, err := r.parseApplicationFieldSelector(options.FieldSelector)
+	if err != nil {
+		return nil, err
+	}
 
 	// Convert Application name to HelmRelease name for manual filtering
 	var filterByName string
@@ -776,7 +821,7 @@ func (r *REST) Watch(ctx context.Context, options *metainternalversion.ListOptio
 	bookmarker := registry.NewInitialEventsBookmarker(sendInitialEvents, options.ResourceVersion, func() runtime.Object {
 		app := &appsv1alpha1.Application{}
 		app.TypeMeta = metav1.TypeMeta{
-			APIVersion: appsv1alpha1.SchemeGroupVersion.String(),
+			APIVersion: r.gvk.GroupVersion().String(),
 			Kind:       r.kindName,
 		}
 		return app
@@ -845,6 +890,11 @@ func (r *REST) Watch(ctx context.Context, options *metainternalversion.ListOptio
 		// would leave the client with a stale WorkloadsReady forever.
 		var pendingWMEvents []watch.Event
 
+		// Field selectors on spec and status fields are evaluated on the
+		// converted Applications; the filter turns updates that change
+		// whether an Application matches into ADDED and DELETED events.
+		fieldWatch := newFieldWatchFilter(fieldSelector)
+
 		// Get the starting resourceVersion from options
 		// If client provides resourceVersion (e.g., from a previous List), we should skip
 		// objects with resourceVersion <= startingRV (client already has them)
@@ -960,6 +1010,12 @@ func (r *REST) Watch(ctx context.Context, options *metainternalversion.ListOptio
 					}
 				}
 
+				// Apply field.selector
+				eventType, matches := fieldWatch.Filter(event.Type, &app)
+				if !matches {
+					continue
+				}
+
 				// Emit the terminating bookmark before the first live event, then
 				// replay any buffered WorkloadMonitor events.
 				if bookmark, ok := bookmarker.BeforeLiveEvent(event.Type); ok {
@@ -984,7 +1040,7 @@ func (r *REST) Watch(ctx context.Context, options *metainternalversion.ListOptio
 				// When startingRV == 0, always send ADDED events (client wants full state)
 
 				// Send event to custom watcher
-				if !send(watch.Event{Type: event.Type, Object: &app}) {
+				if !send(watch.Event{Type: eventType, Object: &app}) {
 					return
 				}
 
@@ -1066,10 +1122,16 @@ func (r *REST) Watch(ctx context.Context, options *metainternalversion.ListOptio
 						continue
 					}
 				}
+				// Workload readiness feeds the Ready condition, so the
+				// Application may start or stop matching the field selector.
+				eventType, matches := fieldWatch.Filter(watch.Modified, &app)
+				if !matches {
+					continue
+				}
 				// Use the WorkloadMonitor's ResourceVersion for the emitted event
 				// so clients see a monotonically increasing RV and don't skip this update.
 				app.SetResourceVersion(wm.GetResourceVersion())
-				outEvent := watch.Event{Type: watch.Modified, Object: &app}
+				outEvent := watch.Event{Type: eventType, Object: &app}
 				// Buffer WM-triggered events that arrive before the
 				// initial-events-end bookmark. They will be replayed in order
 				// immediately after the bookmark is emitted.
@@ -1239,6 +1301,21 @@ func (r *REST) ConvertApplicationToHelmRelease(app *appsv1alpha1.Application) (*
 	return r.convertApplicationToHelmRelease(app)
 }
 
+// toValues returns app with its spec converted from the served version into
+// chart values, or app itself when the version needs no conversion.
+func (r *REST) toValues(app *appsv1alpha1.Application) (*appsv1alpha1.Application, error) {
+	if r.converter == nil {
+		return app, nil
+	}
+	spec, err := r.converter.ToValues(app.Spec)
+	if err != nil {
+		return nil, fmt.Errorf("cannot convert %s spec: %w", r.gvk.Version, err)
+	}
+	out := app.DeepCopy()
+	out.Spec = spec
+	return out, nil
+}
+
 // filterInternalKeys removes keys starting with "_" from the JSON values
 func filterInternalKeys(values *apiextv1.JSON) *apiextv1.JSON {
 	if values == nil || len(values.Raw) == 0 {
@@ -1340,10 +1417,14 @@ func (r *REST) validateTenantNamespaceLength(currentNamespace, tenantName string
 func (r *REST) convertHelmReleaseToApplication(ctx context.Context, hr *helmv2.HelmRelease, freshMonitor *cozyv1alpha1.WorkloadMonitor) (appsv1alpha1.Application, error) {
 	// Filter out internal keys (starting with "_") from spec
 	filteredSpec := filterInternalKeys(hr.Spec.Values)
+	spec, err := r.converter.FromValues(filteredSpec)
+	if err != nil {
+		return appsv1alpha1.Application{}, fmt.Errorf("failed to convert values of %s to %s: %w", hr.Name, r.gvk.Version, err)
+	}
 
 	app := appsv1alpha1.Application{
 		TypeMeta: metav1.TypeMeta{
-			APIVersion: "apps.cozystack.io/v1alpha1",
+			APIVersion: r.gvk.GroupVersion().String(),
 			Kind:       r.kindName,
 		},
 		ObjectMeta: metav1.ObjectMeta{
@@ -1356,7 +1437,7 @@ func (r *REST) convertHelmReleaseToApplication(ctx context.Context, hr *helmv2.H
 			Labels:            filterPrefixedMap(hr.Labels, LabelPrefix),
 			Annotations:       filterPrefixedMap(hr.Annotations, AnnotationPrefix),
 		},
-		Spec: filteredSpec,
+		Spec: spec,
 		Status: appsv1alpha1.ApplicationStatus{
 			Version: hr.Status.LastAttemptedRevision,
 		},
@@ -0,0 +1,109 @@
+/*
+Copyright 2026 The Cozystack Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	helmv2 "github.com/fluxcd/helm-controller/api/v2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	genericapirequest "k8s.io/apiserver/pkg/endpoints/request"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	appsv1alpha1 "github.com/cozystack/cozystack/pkg/apis/apps/v1alpha1"
+	"github.com/cozystack/cozystack/pkg/config"
+)
+
+// versionedTestConfig serves PostgreSQL as v1alpha1 with a legacy "size"
+// field and as v1beta1 with the chart's "storageSize".
+func versionedTestConfig() *config.Resource {
+	return &config.Resource{
+		Application: config.ApplicationConfig{
+			Kind:          "PostgreSQL",
+			Plural:        "postgresqls",
+			Singular:      "postgresql",
+			OpenAPISchema: `{"type":"object","properties":{"storageSize":{"type":"string"}}}`,
+			Versions: []config.VersionConfig{
+				{
+					Name:          "v1alpha1",
+					OpenAPISchema: `{"type":"object","properties":{"size":{"type":"string"}}}`,
+					Conversion:    []config.ConversionRule{{Type: "Rename", Path: "size", To: "storageSize"}},
+				},
+				{Name: "v1beta1"},
+			},
+		},
+		Release: config.ReleaseConfig{Prefix: "postgresql-"},
+	}
+}
+
+func TestVersionedREST_ConvertsSpec(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = helmv2.AddToScheme(scheme)
+	c := fake.NewClientBuilder().WithScheme(scheme).Build()
+	cfg := versionedTestConfig()
+	alpha := NewVersionedREST(c, c, cfg, "v1alpha1")
+	beta := NewVersionedREST(c, c, cfg, "v1beta1")
+
+	ctx := genericapirequest.WithNamespace(context.Background(), "tenant-a")
+	if _, err := alpha.Create(ctx, makeApp("db", "tenant-a", `{"size":"5Gi"}`), nil, &metav1.CreateOptions{}); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	hr := &helmv2.HelmRelease{}
+	if err := c.Get(ctx, client.ObjectKey{Namespace: "tenant-a", Name: "postgresql-db"}, hr); err != nil {
+		t.Fatalf("get HelmRelease: %v", err)
+	}
+	var values map[string]any
+	if err := json.Unmarshal(hr.Spec.Values.Raw, &values); err != nil {
+		t.Fatalf("unmarshal values: %v", err)
+	}
+	if want := map[string]any{"storageSize": "5Gi"}; !reflect.DeepEqual(values, want) {
+		t.Errorf("HelmRelease values = %v, want %v", values, want)
+	}
+
+	for _, tc := range []struct {
+		rest       *REST
+		apiVersion string
+		spec       map[string]any
+	}{
+		{alpha, "apps.cozystack.io/v1alpha1", map[string]any{"size": "5Gi"}},
+		{beta, "apps.cozystack.io/v1beta1", map[string]any{"storageSize": "5Gi"}},
+	} {
+		obj, err := tc.rest.Get(ctx, "db", &metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("%s Get: %v", tc.apiVersion, err)
+		}
+		app := obj.(*appsv1alpha1.Application)
+		if app.APIVersion != tc.apiVersion {
+			t.Errorf("APIVersion = %q, want %q", app.APIVersion, tc.apiVersion)
+		}
+		var spec map[string]any
+		if err := json.Unmarshal(app.Spec.Raw, &spec); err != nil {
+			t.Fatalf("unmarshal spec: %v", err)
+		}
+		if !reflect.DeepEqual(spec, tc.spec) {
+			t.Errorf("%s spec = %v, want %v", tc.apiVersion, spec, tc.spec)
+		}
+	}
+}
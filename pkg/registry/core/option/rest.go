@@ -38,6 +38,7 @@ var (
 // REST implements the read-only Option resource.
 type REST struct {
 	providers map[string]providerFunc
+	sources   *Sources
 	gvr       schema.GroupVersionResource
 }
 
@@ -53,6 +54,14 @@ func NewREST(providers map[string]providerFunc) *REST {
 	}
 }
 
+// WithSources makes the storage also serve the sources declared as
+// OptionSources. Built-in providers take precedence over sources of the same
+// name.
+func (r *REST) WithSources(sources *Sources) *REST {
+	r.sources = sources
+	return r
+}
+
 // -----------------------------------------------------------------------------
 // Basic meta
 // -----------------------------------------------------------------------------
@@ -82,14 +91,31 @@ func (r *REST) List(ctx context.Context, _ *metainternal.ListOptions) (runtime.O
 		ListMeta: metav1.ListMeta{ResourceVersion: "0"},
 	}
 
-	names := make([]string, 0, len(r.providers))
-	for name := range r.providers {
+	providers := r.providers
+	if r.sources != nil {
+		declared, err := r.sources.Providers(ctx)
+		if err != nil {
+			// Without OptionSources (e.g. the CRD is not installed yet) the
+			// built-in sources are still served.
+			logProviderError("optionsources", err)
+		}
+		providers = make(map[string]providerFunc, len(r.providers)+len(declared))
+		for name, p := range declared {
+			providers[name] = p
+		}
+		for name, p := range r.providers {
+			providers[name] = p
+		}
+	}
+
+	names := make([]string, 0, len(providers))
+	for name := range providers {
 		names = append(names, name)
 	}
 	sort.Strings(names)
 
 	for _, name := range names {
-		items, err := r.providers[name](ctx, ns)
+		items, err := providers[name](ctx, ns)
 		if err != nil {
 			// Tolerate a single failing source (e.g. an optional CRD that is
 			// not installed) so the rest of the dropdowns keep working.
@@ -103,6 +129,12 @@ func (r *REST) List(ctx context.Context, _ *metainternal.ListOptions) (runtime.O
 
 func (r *REST) Get(ctx context.Context, name string, _ *metav1.GetOptions) (runtime.Object, error) {
 	provider, ok := r.providers[name]
+	if !ok && r.sources != nil {
+		var err error
+		if provider, ok, err = r.sources.Provider(ctx, name); err != nil {
+			return nil, apierrors.NewInternalError(err)
+		}
+	}
 	if !ok {
 		return nil, apierrors.NewNotFound(r.gvr.GroupResource(), name)
 	}
@@ -0,0 +1,277 @@
+// SPDX-License-Identifier: Apache-2.0
+// Sources serve the dropdown sources declared as OptionSource objects, so a
+// new dropdown needs no cozystack-api release. The objects they list are read
+// from dynamic informers, started the first time a resource is asked for.
+
+package option
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/jsonpath"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	cozyv1alpha1 "github.com/cozystack/cozystack/api/v1alpha1"
+	corev1alpha1 "github.com/cozystack/cozystack/pkg/apis/core/v1alpha1"
+)
+
+// informerSyncTimeout bounds how long a request waits for the informer of a
+// resource listed for the first time.
+const informerSyncTimeout = 10 * time.Second
+
+// Sources resolves OptionSources into providers.
+type Sources struct {
+	c       client.Reader
+	factory dynamicinformer.DynamicSharedInformerFactory
+	stop    <-chan struct{}
+
+	mu      sync.Mutex
+	started map[schema.GroupVersionResource]bool
+}
+
+// NewSources reads OptionSources with c, normally backed by the manager
+// cache, and the objects they list with informers on dyn that run until stop
+// is closed. Informers are never stopped earlier: a resource no OptionSource
+// lists anymore stays cached.
+func NewSources(c client.Reader, dyn dynamic.Interface, stop <-chan struct{}) *Sources {
+	return &Sources{
+		c:       c,
+		factory: dynamicinformer.NewDynamicSharedInformerFactory(dyn, 0),
+		stop:    stop,
+		started: map[schema.GroupVersionResource]bool{},
+	}
+}
+
+// Providers returns the providers of the declared sources by name. A source
+// that does not compile is left out and logged.
+func (s *Sources) Providers(ctx context.Context) (map[string]providerFunc, error) {
+	list := &cozyv1alpha1.OptionSourceList{}
+	if err := s.c.List(ctx, list); err != nil {
+		return nil, err
+	}
+	out := make(map[string]providerFunc, len(list.Items))
+	for i := range list.Items {
+		src := &list.Items[i]
+		p, err := s.provider(&src.Spec)
+		if err != nil {
+			logProviderError(src.Name, err)
+			continue
+		}
+		out[src.Name] = p
+	}
+	return out, nil
+}
+
+// Provider returns the provider of the source name, or false if there is no
+// such OptionSource.
+func (s *Sources) Provider(ctx context.Context, name string) (providerFunc, bool, error) {
+	src := &cozyv1alpha1.OptionSource{}
+	if err := s.c.Get(ctx, client.ObjectKey{Name: name}, src); err != nil {
+		if client.IgnoreNotFound(err) == nil || meta.IsNoMatchError(err) {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+	p, err := s.provider(&src.Spec)
+	if err != nil {
+		return nil, true, fmt.Errorf("option source %s: %w", name, err)
+	}
+	return p, true, nil
+}
+
+// provider compiles spec into a provider.
+func (s *Sources) provider(spec *cozyv1alpha1.OptionSourceSpec) (providerFunc, error) {
+	gvr := schema.GroupVersionResource{Group: spec.Resource.Group, Version: spec.Resource.Version, Resource: spec.Resource.Resource}
+	if gvr.Version == "" || gvr.Resource == "" {
+		return nil, fmt.Errorf("resource needs a version and a resource")
+	}
+	labelSelector := labels.Everything()
+	if spec.LabelSelector != nil {
+		var err error
+		if labelSelector, err = metav1.LabelSelectorAsSelector(spec.LabelSelector); err != nil {
+			return nil, fmt.Errorf("labelSelector: %w", err)
+		}
+	}
+	fieldSelector, err := parseFieldSelector(spec.FieldSelector)
+	if err != nil {
+		return nil, err
+	}
+	value, err := compileJSONPath("value", spec.Value, "{.metadata.name}")
+	if err != nil {
+		return nil, err
+	}
+	label, err := compileJSONPath("label", spec.Label, "")
+	if err != nil {
+		return nil, err
+	}
+	description, err := compileJSONPath("description", spec.Description, "")
+	if err != nil {
+		return nil, err
+	}
+	defaults := make([]defaultRule, 0, len(spec.Default))
+	for i, rule := range spec.Default {
+		jp, err := compileJSONPath(fmt.Sprintf("default[%d]", i), rule.JSONPath, "")
+		if err != nil {
+			return nil, err
+		}
+		if jp == nil {
+			return nil, fmt.Errorf("default[%d]: jsonPath is required", i)
+		}
+		defaults = append(defaults, defaultRule{path: jp, value: rule.Value})
+	}
+
+	scope := spec.Scope
+	fixedNamespace := spec.Namespace
+	return func(ctx context.Context, namespace string) ([]corev1alpha1.OptionItem, error) {
+		listNamespace := fixedNamespace
+		if scope == cozyv1alpha1.OptionSourceScopeNamespace {
+			if namespace == "" {
+				return nil, nil
+			}
+			listNamespace = namespace
+		}
+		lister, err := s.lister(ctx, gvr)
+		if err != nil {
+			return nil, err
+		}
+		var objs []runtime.Object
+		if listNamespace != "" {
+			objs, err = lister.ByNamespace(listNamespace).List(labelSelector)
+		} else {
+			objs, err = lister.List(labelSelector)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		items := make([]corev1alpha1.OptionItem, 0, len(objs))
+		seen := map[string]struct{}{}
+		for _, o := range objs {
+			obj, ok := o.(*unstructured.Unstructured)
+			if !ok {
+				continue
+			}
+			if !fieldSelector.Matches(fields.Set{"metadata.name": obj.GetName(), "metadata.namespace": obj.GetNamespace()}) {
+				continue
+			}
+			item := corev1alpha1.OptionItem{Value: evalJSONPath(value, obj)}
+			if _, dup := seen[item.Value]; dup || item.Value == "" {
+				continue
+			}
+			seen[item.Value] = struct{}{}
+			item.Label = evalJSONPath(label, obj)
+			item.Description = evalJSONPath(description, obj)
+			for _, rule := range defaults {
+				if rule.matches(obj) {
+					item.Default = true
+					break
+				}
+			}
+			items = append(items, item)
+		}
+		sortItems(items)
+		return items, nil
+	}, nil
+}
+
+// lister returns the lister of gvr. The first request for gvr starts its
+// informer and waits for it to sync; later ones fail at once while it has not,
+// e.g. because the resource does not exist, rather than wait again.
+func (s *Sources) lister(ctx context.Context, gvr schema.GroupVersionResource) (cache.GenericLister, error) {
+	informer := s.factory.ForResource(gvr)
+
+	s.mu.Lock()
+	started := s.started[gvr]
+	s.started[gvr] = true
+	s.mu.Unlock()
+
+	if !started {
+		s.factory.Start(s.stop)
+		ctx, cancel := context.WithTimeout(ctx, informerSyncTimeout)
+		defer cancel()
+		cache.WaitForCacheSync(ctx.Done(), informer.Informer().HasSynced)
+	}
+	if !informer.Informer().HasSynced() {
+		return nil, fmt.Errorf("informer for %s has not synced", gvr)
+	}
+	return informer.Lister(), nil
+}
+
+// defaultRule is a compiled OptionSourceDefaultRule.
+type defaultRule struct {
+	path  *jsonpath.JSONPath
+	value string
+}
+
+func (r defaultRule) matches(obj *unstructured.Unstructured) bool {
+	got := evalJSONPath(r.path, obj)
+	if r.value == "" {
+		return got != ""
+	}
+	return got == r.value
+}
+
+// parseFieldSelector parses a field selector, which may only refer to the
+// fields an informer cache can match: metadata.name and metadata.namespace.
+func parseFieldSelector(raw string) (fields.Selector, error) {
+	if raw == "" {
+		return fields.Everything(), nil
+	}
+	sel, err := fields.ParseSelector(raw)
+	if err != nil {
+		return nil, fmt.Errorf("fieldSelector: %w", err)
+	}
+	for _, req := range sel.Requirements() {
+		if req.Field != "metadata.name" && req.Field != "metadata.namespace" {
+			return nil, fmt.Errorf("fieldSelector: unsupported field %q, only metadata.name and metadata.namespace are", req.Field)
+		}
+	}
+	return sel, nil
+}
+
+// compileJSONPath parses a JSONPath template such as {.metadata.name}; the
+// braces may be left out. An empty expression yields def, nil if empty too.
+func compileJSONPath(name, expr, def string) (*jsonpath.JSONPath, error) {
+	if expr == "" {
+		expr = def
+	}
+	if expr == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(expr, "{") {
+		expr = "{" + expr + "}"
+	}
+	jp := jsonpath.New(name).AllowMissingKeys(true)
+	if err := jp.Parse(expr); err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	return jp, nil
+}
+
+// evalJSONPath evaluates jp against obj; a nil expression or a failing one
+// yields the empty string.
+func evalJSONPath(jp *jsonpath.JSONPath, obj *unstructured.Unstructured) string {
+	if jp == nil {
+		return ""
+	}
+	var buf bytes.Buffer
+	if err := jp.Execute(&buf, obj.Object); err != nil {
+		return ""
+	}
+	return strings.TrimSpace(buf.String())
+}
@@ -0,0 +1,143 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package option
+
+import (
+	"context"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apiserver/pkg/endpoints/request"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	cozyv1alpha1 "github.com/cozystack/cozystack/api/v1alpha1"
+	corev1alpha1 "github.com/cozystack/cozystack/pkg/apis/core/v1alpha1"
+)
+
+var gvkStorageClass = schema.GroupVersionKind{Group: "storage.k8s.io", Version: "v1", Kind: "StorageClass"}
+
+// newSourcesREST serves the built-in providers and the given OptionSources
+// over the objects of dynObjs.
+func newSourcesREST(t *testing.T, sources []client.Object, dynObjs ...runtime.Object) *REST {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	if err := cozyv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(sources...).Build()
+	dyn := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), listKinds(), dynObjs...)
+	stop := make(chan struct{})
+	t.Cleanup(func() { close(stop) })
+	return NewREST(DefaultProviders(dyn)).WithSources(NewSources(c, dyn, stop))
+}
+
+func optionSource(name string, spec cozyv1alpha1.OptionSourceSpec) *cozyv1alpha1.OptionSource {
+	return &cozyv1alpha1.OptionSource{ObjectMeta: metav1.ObjectMeta{Name: name}, Spec: spec}
+}
+
+func getOption(t *testing.T, r *REST, ctx context.Context, name string) []corev1alpha1.OptionItem {
+	t.Helper()
+	obj, err := r.Get(ctx, name, &metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Get %s: %v", name, err)
+	}
+	return obj.(*corev1alpha1.Option).Spec.Items
+}
+
+func TestSourceComputesItemsWithJSONPaths(t *testing.T) {
+	fast := newObj(gvkStorageClass, "", "fast", nil)
+	fast.SetLabels(map[string]string{"tier": "ssd"})
+	fast.SetAnnotations(map[string]string{"description": "NVMe", "storageclass.kubernetes.io/is-default-class": "true"})
+	slow := newObj(gvkStorageClass, "", "slow", nil)
+	slow.SetLabels(map[string]string{"tier": "hdd"})
+	r := newSourcesREST(t, []client.Object{
+		optionSource("disk", cozyv1alpha1.OptionSourceSpec{
+			Resource:    cozyv1alpha1.OptionSourceResource{Group: "storage.k8s.io", Version: "v1", Resource: "storageclasses"},
+			Label:       "{.metadata.labels.tier}",
+			Description: ".metadata.annotations.description",
+			Default: []cozyv1alpha1.OptionSourceDefaultRule{{
+				JSONPath: `{.metadata.annotations.storageclass\.kubernetes\.io/is-default-class}`,
+				Value:    "true",
+			}},
+		}),
+	}, fast, slow)
+
+	items := getOption(t, r, context.Background(), "disk")
+	if got := values(items); len(got) != 2 || got[0] != "fast" || got[1] != "slow" {
+		t.Fatalf("values = %v, want [fast slow]", got)
+	}
+	if items[0].Label != "ssd" || items[0].Description != "NVMe" || !items[0].Default {
+		t.Errorf("fast = %+v, want labelled, described and default", items[0])
+	}
+	if items[1].Label != "hdd" || items[1].Description != "" || items[1].Default {
+		t.Errorf("slow = %+v", items[1])
+	}
+}
+
+func TestSourceNamespaceScopeAndSelectors(t *testing.T) {
+	gvk := schema.GroupVersionKind{Group: "backups.cozystack.io", Version: "v1alpha1", Kind: "Plan"}
+	daily := newObj(gvk, "tenant-foo", "daily", nil)
+	daily.SetLabels(map[string]string{"enabled": "true"})
+	weekly := newObj(gvk, "tenant-foo", "weekly", nil)
+	weekly.SetLabels(map[string]string{"enabled": "true"})
+	hidden := newObj(gvk, "tenant-foo", "hidden", nil)
+	other := newObj(gvk, "tenant-bar", "other", nil)
+	other.SetLabels(map[string]string{"enabled": "true"})
+	r := newSourcesREST(t, []client.Object{
+		optionSource("enabled-plans", cozyv1alpha1.OptionSourceSpec{
+			Resource:      cozyv1alpha1.OptionSourceResource{Group: "backups.cozystack.io", Version: "v1alpha1", Resource: "plans"},
+			Scope:         cozyv1alpha1.OptionSourceScopeNamespace,
+			LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"enabled": "true"}},
+			FieldSelector: "metadata.name!=weekly",
+		}),
+	}, daily, weekly, hidden, other)
+
+	items := getOption(t, r, request.WithNamespace(context.Background(), "tenant-foo"), "enabled-plans")
+	if got := values(items); len(got) != 1 || got[0] != "daily" {
+		t.Errorf("values = %v, want [daily]", got)
+	}
+	if items := getOption(t, r, context.Background(), "enabled-plans"); len(items) != 0 {
+		t.Errorf("values without a namespace = %v, want none", values(items))
+	}
+}
+
+func TestSourcesServedNextToBuiltIns(t *testing.T) {
+	r := newSourcesREST(t, []client.Object{
+		// A source may not shadow a built-in one.
+		optionSource("storageclass", cozyv1alpha1.OptionSourceSpec{
+			Resource: cozyv1alpha1.OptionSourceResource{Version: "v1", Resource: "nodes"},
+		}),
+		optionSource("classes", cozyv1alpha1.OptionSourceSpec{
+			Resource: cozyv1alpha1.OptionSourceResource{Group: "storage.k8s.io", Version: "v1", Resource: "storageclasses"},
+		}),
+		optionSource("broken", cozyv1alpha1.OptionSourceSpec{
+			Resource:      cozyv1alpha1.OptionSourceResource{Version: "v1", Resource: "nodes"},
+			FieldSelector: "spec.unschedulable=true",
+		}),
+	}, newObj(gvkStorageClass, "", "fast", nil))
+
+	obj, err := r.List(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	byName := map[string][]corev1alpha1.OptionItem{}
+	for _, o := range obj.(*corev1alpha1.OptionList).Items {
+		byName[o.Name] = o.Spec.Items
+	}
+	if got := values(byName["classes"]); len(got) != 1 || got[0] != "fast" {
+		t.Errorf("classes = %v, want [fast]", got)
+	}
+	if item, ok := itemByValue(byName["storageclass"], "fast"); !ok || item.Value != "fast" {
+		t.Errorf("storageclass = %v, want the built-in provider", byName["storageclass"])
+	}
+	if _, ok := byName["broken"]; ok {
+		t.Error("List served a source that does not compile")
+	}
+	if _, err := r.Get(context.Background(), "broken", &metav1.GetOptions{}); err == nil {
+		t.Error("Get must surface why a source does not compile")
+	}
+}
@@ -0,0 +1,277 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package tenantmodule
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	helmv2 "github.com/fluxcd/helm-controller/api/v2"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+
+	quotaview "github.com/cozystack/cozystack/internal/controller/tenantquota"
+)
+
+// Keys of the tenant values (packages/apps/tenant/values.yaml) managed through
+// TenantModules, besides the per-module toggles.
+const (
+	moduleValuesKey   = "moduleValues"
+	allowedModulesKey = "allowedModules"
+)
+
+const rootTenantNamespace = "tenant-root"
+
+// tenantModule describes a module the tenant chart can deploy. Its name is
+// both the tenant values key toggling it and the name of its HelmRelease.
+type tenantModule struct {
+	// label is the namespace label naming the tenant that provides the
+	// module to the namespace, its own or inherited from an ancestor.
+	label string
+	// requires lists the modules that must be available to the tenant,
+	// deployed by itself or inherited, for the module to work.
+	requires []string
+	// values lists the keys spec.values may set: the parameters of the
+	// module chart (packages/extra/<name>), and nothing it only sets itself,
+	// such as images or security contexts.
+	values []string
+}
+
+// modules are the modules that can be enabled and disabled through the
+// TenantModule API. The info module is always deployed and is not listed.
+var modules = map[string]tenantModule{
+	"etcd": {
+		label:  "namespace.cozystack.io/etcd",
+		values: []string{"replicas", "resources", "size", "storageClass", "version"},
+	},
+	"monitoring": {
+		label:  "namespace.cozystack.io/monitoring",
+		values: []string{"alerta", "grafana", "host", "logsStorages", "metricsStorages", "oidc", "vmagent"},
+	},
+	"ingress": {
+		label:  "namespace.cozystack.io/ingress",
+		values: []string{"cloudflareProxy", "proxyProtocol", "replicas", "resources", "resourcesPreset", "whitelist"},
+	},
+	"gateway": {
+		label:  "namespace.cozystack.io/gateway",
+		values: []string{"gatewayClassName", "issuer", "l4Listeners", "policies", "tlsPassthroughServices"},
+	},
+	"seaweedfs": {
+		label:  "namespace.cozystack.io/seaweedfs",
+		values: []string{"db", "filer", "host", "master", "replicationFactor", "s3", "topology", "volume"},
+	},
+	"computeplane": {
+		requires: []string{"etcd"},
+		values:   []string{"nodeGroups"},
+	},
+}
+
+// moduleNames returns the names of the modules in a stable order.
+func moduleNames() []string {
+	names := make([]string, 0, len(modules))
+	for name := range modules {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// tenantReleaseRef returns the HelmRelease of the tenant owning namespace: a
+// tenant created as "<name>" in namespace P owns the namespace "P-<name>",
+// or "tenant-<name>" when P is tenant-root, whose own release lives in its
+// own namespace.
+func tenantReleaseRef(namespace string) (types.NamespacedName, bool) {
+	if namespace == rootTenantNamespace {
+		return types.NamespacedName{Namespace: rootTenantNamespace, Name: rootTenantNamespace}, true
+	}
+	parent := quotaview.ParentNamespace(namespace)
+	if parent == "" {
+		return types.NamespacedName{}, false
+	}
+	segments := strings.Split(namespace, "-")
+	return types.NamespacedName{Namespace: parent, Name: "tenant-" + segments[len(segments)-1]}, true
+}
+
+// tenantValues are the values of a tenant HelmRelease, kept as a generic map
+// so that values this package does not know about are written back intact.
+type tenantValues map[string]interface{}
+
+func decodeTenantValues(hr *helmv2.HelmRelease) (tenantValues, error) {
+	values := tenantValues{}
+	if hr.Spec.Values == nil || len(hr.Spec.Values.Raw) == 0 {
+		return values, nil
+	}
+	if err := json.Unmarshal(hr.Spec.Values.Raw, &values); err != nil {
+		return nil, fmt.Errorf("decode values of tenant %s/%s: %w", hr.Namespace, hr.Name, err)
+	}
+	return values, nil
+}
+
+func (v tenantValues) encode() (*apiextensionsv1.JSON, error) {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return &apiextensionsv1.JSON{Raw: raw}, nil
+}
+
+// enabled reports whether the tenant deploys the module.
+func (v tenantValues) enabled(name string) bool {
+	on, _ := v[name].(bool)
+	return on
+}
+
+// moduleValues returns the configuration of the module, nil if none.
+func (v tenantValues) moduleValues(name string) (*apiextensionsv1.JSON, error) {
+	all, _ := v[moduleValuesKey].(map[string]interface{})
+	mv, ok := all[name]
+	if !ok || mv == nil {
+		return nil, nil
+	}
+	raw, err := json.Marshal(mv)
+	if err != nil {
+		return nil, err
+	}
+	return &apiextensionsv1.JSON{Raw: raw}, nil
+}
+
+// setModule enables or disables the module and replaces its configuration.
+func (v tenantValues) setModule(name string, enabled bool, config *apiextensionsv1.JSON) error {
+	v[name] = enabled
+	all, _ := v[moduleValuesKey].(map[string]interface{})
+	if config == nil || len(config.Raw) == 0 {
+		delete(all, name)
+	} else {
+		var mv map[string]interface{}
+		if err := json.Unmarshal(config.Raw, &mv); err != nil {
+			return fmt.Errorf("spec.values must be an object: %w", err)
+		}
+		if all == nil {
+			all = map[string]interface{}{}
+		}
+		all[name] = mv
+	}
+	if len(all) == 0 {
+		delete(v, moduleValuesKey)
+	} else {
+		v[moduleValuesKey] = all
+	}
+	return nil
+}
+
+// allowedModules returns the modules the sub-tenants of the tenant may
+// enable. A tenant that lists none allows none.
+func (v tenantValues) allowedModules() map[string]bool {
+	list, _ := v[allowedModulesKey].([]interface{})
+	allowed := make(map[string]bool, len(list))
+	for _, m := range list {
+		if s, ok := m.(string); ok {
+			allowed[s] = true
+		}
+	}
+	return allowed
+}
+
+// checkPolicy returns a Forbidden error unless every ancestor of the tenant
+// owning namespace allows its sub-tenants to enable the module in its
+// allowedModules.
+func (r *REST) checkPolicy(ctx context.Context, namespace, name string) error {
+	for ancestor := quotaview.ParentNamespace(namespace); ancestor != ""; ancestor = quotaview.ParentNamespace(ancestor) {
+		ref, ok := tenantReleaseRef(ancestor)
+		if !ok {
+			break
+		}
+		hr := &helmv2.HelmRelease{}
+		if err := r.c.Get(ctx, ref, hr); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return err
+		}
+		values, err := decodeTenantValues(hr)
+		if err != nil {
+			return err
+		}
+		if !values.allowedModules()[name] {
+			return apierrors.NewForbidden(r.gvr.GroupResource(), name,
+				fmt.Errorf("tenant %s does not allow its sub-tenants to enable the %s module", ancestor, name))
+		}
+	}
+	return nil
+}
+
+// inherited returns the modules available to the tenant owning namespace
+// from its ancestors, read from the labels of its parent namespace.
+func (r *REST) inherited(ctx context.Context, namespace string) (map[string]bool, error) {
+	out := map[string]bool{}
+	parent := quotaview.ParentNamespace(namespace)
+	if parent == "" {
+		return out, nil
+	}
+	ns := &corev1.Namespace{}
+	if err := r.c.Get(ctx, types.NamespacedName{Name: parent}, ns); err != nil {
+		if apierrors.IsNotFound(err) {
+			return out, nil
+		}
+		return nil, err
+	}
+	for name, m := range modules {
+		if m.label != "" && ns.Labels[m.label] != "" {
+			out[name] = true
+		}
+	}
+	if parent != rootTenantNamespace {
+		return out, nil
+	}
+	// The tenant chart does not label the root namespace, whose modules
+	// are only known from its values.
+	ref, _ := tenantReleaseRef(rootTenantNamespace)
+	hr := &helmv2.HelmRelease{}
+	if err := r.c.Get(ctx, ref, hr); err != nil {
+		if apierrors.IsNotFound(err) {
+			return out, nil
+		}
+		return nil, err
+	}
+	values, err := decodeTenantValues(hr)
+	if err != nil {
+		return nil, err
+	}
+	for name := range modules {
+		if values.enabled(name) {
+			out[name] = true
+		}
+	}
+	return out, nil
+}
+
+// checkDependencies returns a BadRequest error if, with values, the module name
+// or a module requiring it is enabled while one of its dependencies is neither
+// enabled too nor inherited.
+func (r *REST) checkDependencies(ctx context.Context, namespace, name string, values tenantValues) error {
+	inherited, err := r.inherited(ctx, namespace)
+	if err != nil {
+		return err
+	}
+	var missing []string
+	for _, m := range moduleNames() {
+		if !values.enabled(m) || (m != name && !slices.Contains(modules[m].requires, name)) {
+			continue
+		}
+		for _, dep := range modules[m].requires {
+			if !values.enabled(dep) && !inherited[dep] {
+				missing = append(missing, fmt.Sprintf("%s requires %s, which neither the tenant nor its ancestors deploy", m, dep))
+			}
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	return apierrors.NewBadRequest(fmt.Sprintf("module %s: %s", name, strings.Join(missing, "; ")))
+}
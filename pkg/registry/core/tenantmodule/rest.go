@@ -36,6 +36,7 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/duration"
 	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/apiserver/pkg/authorization/authorizer"
 	"k8s.io/apiserver/pkg/endpoints/request"
 	"k8s.io/apiserver/pkg/registry/rest"
 	"k8s.io/klog/v2"
@@ -53,6 +54,9 @@ var (
 	_ rest.Lister               = &REST{}
 	_ rest.Getter               = &REST{}
 	_ rest.Watcher              = &REST{}
+	_ rest.Creater              = &REST{}
+	_ rest.Updater              = &REST{}
+	_ rest.GracefulDeleter      = &REST{}
 	_ rest.TableConvertor       = &REST{}
 	_ rest.Scoper               = &REST{}
 	_ rest.SingularNameProvider = &REST{}
@@ -73,13 +77,16 @@ type REST struct {
 	gvk          schema.GroupVersionKind
 	kindName     string
 	singularName string
+	authorizer   authorizer.Authorizer
 }
 
-// NewREST creates a new REST storage for TenantModule
-func NewREST(c client.Client, w client.WithWatch) *REST {
+// NewREST creates a new REST storage for TenantModule. authz decides whether
+// the user may update the tenants whose modules it changes.
+func NewREST(c client.Client, w client.WithWatch, authz authorizer.Authorizer) *REST {
 	return &REST{
-		c: c,
-		w: w,
+		c:          c,
+		w:          w,
+		authorizer: authz,
 		gvr: schema.GroupVersionResource{
 			Group:    corev1alpha1.GroupName,
 			Version:  "v1alpha1",
@@ -630,6 +637,9 @@ func (r *REST) convertHelmReleaseToTenantModule(hr *helmv2.HelmRelease) (corev1a
 			Labels:            filterInternalLabels(hr.Labels),
 			Annotations:       hr.Annotations,
 		},
+		Spec: corev1alpha1.TenantModuleSpec{
+			Values: hr.Spec.Values,
+		},
 		Status: corev1alpha1.TenantModuleStatus{
 			Version: hr.Status.LastAttemptedRevision,
 		},
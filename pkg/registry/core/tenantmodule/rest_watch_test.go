@@ -53,7 +53,7 @@ func TestWatch_SendInitialEvents_EmitsInitialEventsEndBookmark(t *testing.T) {
 		t.Fatalf("add helmv2 to scheme: %v", err)
 	}
 	fc := fake.NewClientBuilder().WithScheme(scheme).Build()
-	r := NewREST(fc, fc)
+	r := NewREST(fc, fc, nil)
 
 	ctx, cancel := context.WithCancel(request.WithNamespace(context.Background(), testNamespace))
 	defer cancel()
@@ -0,0 +1,327 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package tenantmodule
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	helmv2 "github.com/fluxcd/helm-controller/api/v2"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/apiserver/pkg/authorization/authorizer"
+	"k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/apiserver/pkg/registry/rest"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/cozystack/cozystack/pkg/apis/apps/v1alpha1"
+	corev1alpha1 "github.com/cozystack/cozystack/pkg/apis/core/v1alpha1"
+)
+
+// Create enables a module in the values of the tenant owning the namespace,
+// with spec.values as its configuration. The tenant chart then deploys the
+// module HelmRelease, which Get serves from then on; until it does, the
+// TenantModule has no status.
+//
+// The module must be allowed by every ancestor of the tenant, and the modules
+// it requires must be enabled in the tenant or inherited from an ancestor.
+// Like every write of a TenantModule, it requires update access to the
+// Tenant whose values it changes.
+func (r *REST) Create(ctx context.Context, obj runtime.Object, createValidation rest.ValidateObjectFunc, options *metav1.CreateOptions) (runtime.Object, error) {
+	module, ok := obj.(*corev1alpha1.TenantModule)
+	if !ok {
+		return nil, fmt.Errorf("expected TenantModule object, got %T", obj)
+	}
+	namespace, err := r.getNamespace(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.validateName(module.Name); err != nil {
+		return nil, err
+	}
+	if err := r.validateValues(module); err != nil {
+		return nil, err
+	}
+	if createValidation != nil {
+		if err := createValidation(ctx, obj); err != nil {
+			return nil, err
+		}
+	}
+	if err := r.checkPolicy(ctx, namespace, module.Name); err != nil {
+		return nil, err
+	}
+
+	klog.V(6).Infof("Enabling module %s in tenant namespace %s", module.Name, namespace)
+	err = r.updateTenant(ctx, namespace, module.Name, options.DryRun, func(values tenantValues) error {
+		if values.enabled(module.Name) {
+			return apierrors.NewAlreadyExists(r.gvr.GroupResource(), module.Name)
+		}
+		if err := values.setModule(module.Name, true, module.Spec.Values); err != nil {
+			return apierrors.NewBadRequest(err.Error())
+		}
+		return r.checkDependencies(ctx, namespace, module.Name, values)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return r.pending(namespace, module), nil
+}
+
+// Update replaces the configuration of an enabled module.
+func (r *REST) Update(ctx context.Context, name string, objInfo rest.UpdatedObjectInfo, createValidation rest.ValidateObjectFunc, updateValidation rest.ValidateObjectUpdateFunc, forceAllowCreate bool, options *metav1.UpdateOptions) (runtime.Object, bool, error) {
+	namespace, err := r.getNamespace(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+	if err := r.validateName(name); err != nil {
+		return nil, false, err
+	}
+	old, err := r.current(ctx, namespace, name)
+	if err != nil {
+		return nil, false, err
+	}
+	obj, err := objInfo.UpdatedObject(ctx, old)
+	if err != nil {
+		return nil, false, err
+	}
+	module, ok := obj.(*corev1alpha1.TenantModule)
+	if !ok {
+		return nil, false, fmt.Errorf("expected TenantModule object, got %T", obj)
+	}
+	if module.Name != name {
+		return nil, false, apierrors.NewBadRequest(fmt.Sprintf("name %q does not match the request, %q", module.Name, name))
+	}
+	if err := r.validateValues(module); err != nil {
+		return nil, false, err
+	}
+	if updateValidation != nil {
+		if err := updateValidation(ctx, module, old); err != nil {
+			return nil, false, err
+		}
+	}
+
+	klog.V(6).Infof("Configuring module %s in tenant namespace %s", name, namespace)
+	err = r.updateTenant(ctx, namespace, name, options.DryRun, func(values tenantValues) error {
+		if !values.enabled(name) {
+			return apierrors.NewNotFound(r.gvr.GroupResource(), name)
+		}
+		if err := values.setModule(name, true, module.Spec.Values); err != nil {
+			return apierrors.NewBadRequest(err.Error())
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	old.Spec = module.Spec
+	return old, false, nil
+}
+
+// Delete disables a module in the values of the tenant and drops its
+// configuration. The tenant chart then removes the module HelmRelease. A
+// module other enabled modules require can only be disabled while an
+// ancestor provides it.
+func (r *REST) Delete(ctx context.Context, name string, deleteValidation rest.ValidateObjectFunc, options *metav1.DeleteOptions) (runtime.Object, bool, error) {
+	namespace, err := r.getNamespace(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+	if err := r.validateName(name); err != nil {
+		return nil, false, err
+	}
+	old, err := r.current(ctx, namespace, name)
+	if err != nil {
+		return nil, false, err
+	}
+	if deleteValidation != nil {
+		if err := deleteValidation(ctx, old); err != nil {
+			return nil, false, err
+		}
+	}
+
+	klog.V(6).Infof("Disabling module %s in tenant namespace %s", name, namespace)
+	err = r.updateTenant(ctx, namespace, name, options.DryRun, func(values tenantValues) error {
+		if !values.enabled(name) {
+			return apierrors.NewNotFound(r.gvr.GroupResource(), name)
+		}
+		if err := values.setModule(name, false, nil); err != nil {
+			return err
+		}
+		return r.checkDependencies(ctx, namespace, name, values)
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	return old, false, nil
+}
+
+// validateName rejects modules that cannot be toggled, such as info.
+func (r *REST) validateName(name string) error {
+	if _, ok := modules[name]; ok {
+		return nil
+	}
+	return apierrors.NewInvalid(r.gvk.GroupKind(), name, field.ErrorList{
+		field.NotSupported(field.NewPath("metadata", "name"), name, moduleNames()),
+	})
+}
+
+// validateValues rejects spec.values keys the module chart does not take as
+// parameters.
+func (r *REST) validateValues(module *corev1alpha1.TenantModule) error {
+	if module.Spec.Values == nil || len(module.Spec.Values.Raw) == 0 {
+		return nil
+	}
+	fldPath := field.NewPath("spec", "values")
+	var values map[string]json.RawMessage
+	if err := json.Unmarshal(module.Spec.Values.Raw, &values); err != nil {
+		return apierrors.NewInvalid(r.gvk.GroupKind(), module.Name, field.ErrorList{
+			field.Invalid(fldPath, string(module.Spec.Values.Raw), "must be an object"),
+		})
+	}
+	allowed := modules[module.Name].values
+	var errs field.ErrorList
+	for key := range values {
+		if !slices.Contains(allowed, key) {
+			errs = append(errs, field.NotSupported(fldPath.Key(key), key, allowed))
+		}
+	}
+	if len(errs) > 0 {
+		return apierrors.NewInvalid(r.gvk.GroupKind(), module.Name, errs)
+	}
+	return nil
+}
+
+// authorize returns a Forbidden error unless the user of the request may
+// update the Tenant application ref is the HelmRelease of. The values of a
+// tenant are its parent's to change, and a TenantModule must not be a way
+// around that.
+func (r *REST) authorize(ctx context.Context, ref types.NamespacedName, name string) error {
+	tenant := strings.TrimPrefix(ref.Name, "tenant-")
+	user, ok := request.UserFrom(ctx)
+	if !ok {
+		return apierrors.NewForbidden(r.gvr.GroupResource(), name, fmt.Errorf("no user in the request"))
+	}
+	// Without an authorizer nobody may.
+	decision, reason, err := authorizer.DecisionNoOpinion, "", error(nil)
+	if r.authorizer != nil {
+		decision, reason, err = r.authorizer.Authorize(ctx, authorizer.AttributesRecord{
+			User:            user,
+			Verb:            "update",
+			Namespace:       ref.Namespace,
+			APIGroup:        appsv1alpha1.GroupName,
+			APIVersion:      "v1alpha1",
+			Resource:        "tenants",
+			Name:            tenant,
+			ResourceRequest: true,
+		})
+	}
+	if err != nil || decision != authorizer.DecisionAllow {
+		msg := fmt.Sprintf("changing the modules of a tenant requires update on tenants.%s %q in namespace %q", appsv1alpha1.GroupName, tenant, ref.Namespace)
+		if reason != "" {
+			msg += ": " + reason
+		}
+		return apierrors.NewForbidden(r.gvr.GroupResource(), name, fmt.Errorf("%s", msg))
+	}
+	return nil
+}
+
+// updateTenant applies mutate to the values of the tenant owning namespace
+// and writes them back, unless dryRun is set. The user must be allowed to
+// update the tenant.
+func (r *REST) updateTenant(ctx context.Context, namespace, name string, dryRun []string, mutate func(tenantValues) error) error {
+	ref, ok := tenantReleaseRef(namespace)
+	if !ok {
+		return apierrors.NewBadRequest(fmt.Sprintf("namespace %s is not a tenant namespace", namespace))
+	}
+	if err := r.authorize(ctx, ref, name); err != nil {
+		return err
+	}
+	return retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		hr := &helmv2.HelmRelease{}
+		if err := r.c.Get(ctx, ref, hr); err != nil {
+			if apierrors.IsNotFound(err) {
+				return apierrors.NewBadRequest(fmt.Sprintf("tenant %s of namespace %s not found", ref, namespace))
+			}
+			return err
+		}
+		values, err := decodeTenantValues(hr)
+		if err != nil {
+			return err
+		}
+		if err := mutate(values); err != nil {
+			return err
+		}
+		if hr.Spec.Values, err = values.encode(); err != nil {
+			return err
+		}
+		return r.c.Update(ctx, hr, &client.UpdateOptions{DryRun: dryRun})
+	})
+}
+
+// current returns the module as enabled in the values of the tenant, with
+// the metadata and status of its HelmRelease once the tenant chart deployed
+// it.
+func (r *REST) current(ctx context.Context, namespace, name string) (*corev1alpha1.TenantModule, error) {
+	ref, ok := tenantReleaseRef(namespace)
+	if !ok {
+		return nil, apierrors.NewNotFound(r.gvr.GroupResource(), name)
+	}
+	tenant := &helmv2.HelmRelease{}
+	if err := r.c.Get(ctx, ref, tenant); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, apierrors.NewNotFound(r.gvr.GroupResource(), name)
+		}
+		return nil, err
+	}
+	values, err := decodeTenantValues(tenant)
+	if err != nil {
+		return nil, err
+	}
+	if !values.enabled(name) {
+		return nil, apierrors.NewNotFound(r.gvr.GroupResource(), name)
+	}
+	config, err := values.moduleValues(name)
+	if err != nil {
+		return nil, err
+	}
+
+	module := r.pending(namespace, &corev1alpha1.TenantModule{ObjectMeta: metav1.ObjectMeta{Name: name}})
+	hr := &helmv2.HelmRelease{}
+	err = r.c.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, hr)
+	switch {
+	case err == nil && r.hasTenantModuleLabel(hr):
+		converted, err := r.ConvertHelmReleaseToTenantModule(hr)
+		if err != nil {
+			return nil, err
+		}
+		module = &converted
+	case err != nil && !apierrors.IsNotFound(err):
+		return nil, err
+	}
+	module.Spec.Values = config
+	return module, nil
+}
+
+// pending returns the TenantModule of a module its tenant enables but whose
+// HelmRelease may not exist yet.
+func (r *REST) pending(namespace string, module *corev1alpha1.TenantModule) *corev1alpha1.TenantModule {
+	return &corev1alpha1.TenantModule{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: r.gvk.GroupVersion().String(),
+			Kind:       r.kindName,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      module.Name,
+			Namespace: namespace,
+		},
+		Spec: module.Spec,
+	}
+}
@@ -0,0 +1,315 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package tenantmodule
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+
+	helmv2 "github.com/fluxcd/helm-controller/api/v2"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/apiserver/pkg/authorization/authorizer"
+	"k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/apiserver/pkg/registry/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	corev1alpha1 "github.com/cozystack/cozystack/pkg/apis/core/v1alpha1"
+)
+
+// tenantRelease is the HelmRelease of a tenant with the given values.
+func tenantRelease(namespace, name, values string) *helmv2.HelmRelease {
+	return &helmv2.HelmRelease{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+		Spec:       helmv2.HelmReleaseSpec{Values: &apiextensionsv1.JSON{Raw: []byte(values)}},
+	}
+}
+
+// allowAll lets every user do anything.
+var allowAll = authorizer.AuthorizerFunc(func(context.Context, authorizer.Attributes) (authorizer.Decision, string, error) {
+	return authorizer.DecisionAllow, "", nil
+})
+
+// tenantContext is a request of alice in namespace.
+func tenantContext(namespace string) context.Context {
+	return request.WithUser(request.WithNamespace(context.Background(), namespace), &user.DefaultInfo{Name: "alice"})
+}
+
+// newWriteTestREST serves tenant-foo, a child of tenant-root, and its own
+// child tenant-foo-bar, to users allowed to update every tenant.
+func newWriteTestREST(t *testing.T, objs ...client.Object) *REST {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	if err := helmv2.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	if err := corev1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	objs = append([]client.Object{
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "tenant-root"}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "tenant-foo"}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "tenant-foo-bar"}},
+	}, objs...)
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+	return NewREST(c, c, allowAll)
+}
+
+func tenantValuesOf(t *testing.T, r *REST, namespace, name string) map[string]interface{} {
+	t.Helper()
+	hr := &helmv2.HelmRelease{}
+	if err := r.c.Get(context.Background(), types.NamespacedName{Namespace: namespace, Name: name}, hr); err != nil {
+		t.Fatal(err)
+	}
+	values := map[string]interface{}{}
+	if err := json.Unmarshal(hr.Spec.Values.Raw, &values); err != nil {
+		t.Fatal(err)
+	}
+	return values
+}
+
+func moduleObject(name, values string) *corev1alpha1.TenantModule {
+	module := &corev1alpha1.TenantModule{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	if values != "" {
+		module.Spec.Values = &apiextensionsv1.JSON{Raw: []byte(values)}
+	}
+	return module
+}
+
+func TestCreate_EnablesModuleWithValues(t *testing.T) {
+	r := newWriteTestREST(t,
+		tenantRelease("tenant-root", "tenant-root", `{"allowedModules":["monitoring"]}`),
+		tenantRelease("tenant-root", "tenant-foo", `{"host":"foo.example.org","monitoring":false}`),
+	)
+	ctx := tenantContext("tenant-foo")
+
+	obj, err := r.Create(ctx, moduleObject("monitoring", `{"host":"grafana.foo.example.org"}`), nil, &metav1.CreateOptions{})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if module := obj.(*corev1alpha1.TenantModule); module.Namespace != "tenant-foo" || module.Name != "monitoring" {
+		t.Errorf("created %s/%s", module.Namespace, module.Name)
+	}
+	values := tenantValuesOf(t, r, "tenant-root", "tenant-foo")
+	if values["monitoring"] != true || values["host"] != "foo.example.org" {
+		t.Errorf("values = %v, want monitoring enabled and host kept", values)
+	}
+	config := values["moduleValues"].(map[string]interface{})["monitoring"].(map[string]interface{})
+	if config["host"] != "grafana.foo.example.org" {
+		t.Errorf("moduleValues = %v", values["moduleValues"])
+	}
+
+	if _, err := r.Create(ctx, moduleObject("monitoring", ""), nil, &metav1.CreateOptions{}); !apierrors.IsAlreadyExists(err) {
+		t.Errorf("expected AlreadyExists, got %v", err)
+	}
+	if _, err := r.Create(ctx, moduleObject("info", ""), nil, &metav1.CreateOptions{}); !apierrors.IsInvalid(err) {
+		t.Errorf("expected the info module to be Invalid, got %v", err)
+	}
+}
+
+func TestCreate_RequiresDependencies(t *testing.T) {
+	r := newWriteTestREST(t,
+		tenantRelease("tenant-root", "tenant-root", `{"allowedModules":["computeplane"]}`),
+		tenantRelease("tenant-root", "tenant-foo", `{"allowedModules":["computeplane"]}`),
+		tenantRelease("tenant-foo", "tenant-bar", `{}`),
+	)
+
+	_, err := r.Create(tenantContext("tenant-foo"), moduleObject("computeplane", ""), nil, &metav1.CreateOptions{})
+	if !apierrors.IsBadRequest(err) {
+		t.Fatalf("expected computeplane without etcd to be rejected, got %v", err)
+	}
+
+	// tenant-foo-bar inherits the etcd of tenant-foo.
+	ns := &corev1.Namespace{}
+	if err := r.c.Get(context.Background(), types.NamespacedName{Name: "tenant-foo"}, ns); err != nil {
+		t.Fatal(err)
+	}
+	ns.Labels = map[string]string{"namespace.cozystack.io/etcd": "tenant-foo"}
+	if err := r.c.Update(context.Background(), ns); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.Create(tenantContext("tenant-foo-bar"), moduleObject("computeplane", ""), nil, &metav1.CreateOptions{}); err != nil {
+		t.Fatalf("Create with inherited etcd: %v", err)
+	}
+}
+
+func TestCreate_HonoursAncestorPolicy(t *testing.T) {
+	r := newWriteTestREST(t,
+		tenantRelease("tenant-root", "tenant-root", `{"allowedModules":["monitoring","ingress"]}`),
+		tenantRelease("tenant-root", "tenant-foo", `{"allowedModules":["ingress"]}`),
+		tenantRelease("tenant-foo", "tenant-bar", `{}`),
+	)
+
+	if _, err := r.Create(tenantContext("tenant-foo"), moduleObject("monitoring", ""), nil, &metav1.CreateOptions{}); err != nil {
+		t.Fatalf("Create allowed by tenant-root: %v", err)
+	}
+	bar := tenantContext("tenant-foo-bar")
+	if _, err := r.Create(bar, moduleObject("monitoring", ""), nil, &metav1.CreateOptions{}); !apierrors.IsForbidden(err) {
+		t.Errorf("expected tenant-foo to forbid monitoring, got %v", err)
+	}
+	if _, err := r.Create(bar, moduleObject("etcd", ""), nil, &metav1.CreateOptions{}); !apierrors.IsForbidden(err) {
+		t.Errorf("expected tenant-root to forbid etcd below it, got %v", err)
+	}
+	if _, err := r.Create(bar, moduleObject("ingress", ""), nil, &metav1.CreateOptions{}); err != nil {
+		t.Errorf("Create allowed by every ancestor: %v", err)
+	}
+}
+
+func TestCreate_DeniedWithoutAllowedModules(t *testing.T) {
+	r := newWriteTestREST(t,
+		tenantRelease("tenant-root", "tenant-root", `{"allowedModules":[]}`),
+		tenantRelease("tenant-root", "tenant-foo", `{}`),
+	)
+
+	if _, err := r.Create(tenantContext("tenant-foo"), moduleObject("monitoring", ""), nil, &metav1.CreateOptions{}); !apierrors.IsForbidden(err) {
+		t.Errorf("expected an empty allowedModules to forbid every module, got %v", err)
+	}
+	// The root tenant has no ancestor to restrict it.
+	r = newWriteTestREST(t, tenantRelease("tenant-root", "tenant-root", `{}`))
+	if _, err := r.Create(tenantContext("tenant-root"), moduleObject("monitoring", ""), nil, &metav1.CreateOptions{}); err != nil {
+		t.Errorf("Create in tenant-root: %v", err)
+	}
+}
+
+func TestCreate_RejectsUnknownValues(t *testing.T) {
+	r := newWriteTestREST(t,
+		tenantRelease("tenant-root", "tenant-root", `{"allowedModules":["ingress"]}`),
+		tenantRelease("tenant-root", "tenant-foo", `{"ingress":true}`),
+	)
+	ctx := tenantContext("tenant-foo")
+
+	for _, values := range []string{`{"image":"evil/ingress"}`, `{"replicas":3,"securityContext":{"privileged":true}}`, `[]`} {
+		if _, err := r.Create(ctx, moduleObject("ingress", values), nil, &metav1.CreateOptions{}); !apierrors.IsInvalid(err) {
+			t.Errorf("Create with %s: expected Invalid, got %v", values, err)
+		}
+	}
+	update := rest.DefaultUpdatedObjectInfo(moduleObject("ingress", `{"image":"evil/ingress"}`))
+	if _, _, err := r.Update(ctx, "ingress", update, nil, nil, false, &metav1.UpdateOptions{}); !apierrors.IsInvalid(err) {
+		t.Errorf("Update: expected Invalid, got %v", err)
+	}
+	update = rest.DefaultUpdatedObjectInfo(moduleObject("ingress", `{"replicas":3}`))
+	if _, _, err := r.Update(ctx, "ingress", update, nil, nil, false, &metav1.UpdateOptions{}); err != nil {
+		t.Errorf("Update with a chart parameter: %v", err)
+	}
+}
+
+func TestWrite_RequiresTenantUpdate(t *testing.T) {
+	r := newWriteTestREST(t,
+		tenantRelease("tenant-root", "tenant-root", `{"allowedModules":["monitoring"]}`),
+		tenantRelease("tenant-root", "tenant-foo", `{"ingress":true}`),
+	)
+	var asked []authorizer.Attributes
+	r.authorizer = authorizer.AuthorizerFunc(func(_ context.Context, a authorizer.Attributes) (authorizer.Decision, string, error) {
+		asked = append(asked, a)
+		return authorizer.DecisionNoOpinion, "", nil
+	})
+	ctx := tenantContext("tenant-foo")
+
+	if _, err := r.Create(ctx, moduleObject("monitoring", ""), nil, &metav1.CreateOptions{}); !apierrors.IsForbidden(err) {
+		t.Errorf("Create: expected Forbidden, got %v", err)
+	}
+	update := rest.DefaultUpdatedObjectInfo(moduleObject("ingress", `{"replicas":3}`))
+	if _, _, err := r.Update(ctx, "ingress", update, nil, nil, false, &metav1.UpdateOptions{}); !apierrors.IsForbidden(err) {
+		t.Errorf("Update: expected Forbidden, got %v", err)
+	}
+	if _, _, err := r.Delete(ctx, "ingress", nil, &metav1.DeleteOptions{}); !apierrors.IsForbidden(err) {
+		t.Errorf("Delete: expected Forbidden, got %v", err)
+	}
+	if values := tenantValuesOf(t, r, "tenant-root", "tenant-foo"); values["ingress"] != true || values["monitoring"] != nil {
+		t.Errorf("values = %v, want them unchanged", values)
+	}
+
+	if len(asked) != 3 {
+		t.Fatalf("asked the authorizer %d times, want 3", len(asked))
+	}
+	a := asked[0]
+	if a.GetUser().GetName() != "alice" || a.GetVerb() != "update" || a.GetNamespace() != "tenant-root" ||
+		a.GetAPIGroup() != "apps.cozystack.io" || a.GetResource() != "tenants" || a.GetName() != "foo" {
+		t.Errorf("asked %+v, want alice to update the tenant foo in tenant-root", a)
+	}
+
+	r.authorizer = nil
+	if _, _, err := r.Delete(ctx, "ingress", nil, &metav1.DeleteOptions{}); !apierrors.IsForbidden(err) {
+		t.Errorf("Delete without an authorizer: expected Forbidden, got %v", err)
+	}
+}
+
+// TestModuleValuesMatchCharts asserts that spec.values takes exactly the
+// parameters of each module chart.
+func TestModuleValuesMatchCharts(t *testing.T) {
+	for _, name := range moduleNames() {
+		raw, err := os.ReadFile(filepath.Join("../../../../packages/extra", name, "values.schema.json"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		var schema struct {
+			Properties map[string]json.RawMessage `json:"properties"`
+		}
+		if err := json.Unmarshal(raw, &schema); err != nil {
+			t.Fatal(err)
+		}
+		var params []string
+		for key := range schema.Properties {
+			params = append(params, key)
+		}
+		sort.Strings(params)
+		if got := modules[name].values; !reflect.DeepEqual(got, params) {
+			t.Errorf("module %s takes %v, want the chart parameters %v", name, got, params)
+		}
+	}
+}
+
+func TestDelete_DisablesModuleUnlessRequired(t *testing.T) {
+	r := newWriteTestREST(t,
+		tenantRelease("tenant-root", "tenant-root", `{}`),
+		tenantRelease("tenant-root", "tenant-foo", `{"etcd":true,"computeplane":true,"monitoring":true,"moduleValues":{"monitoring":{"host":"grafana.foo.example.org"}}}`),
+	)
+	ctx := tenantContext("tenant-foo")
+
+	if _, _, err := r.Delete(ctx, "etcd", nil, &metav1.DeleteOptions{}); !apierrors.IsBadRequest(err) {
+		t.Errorf("expected etcd to be kept for computeplane, got %v", err)
+	}
+	obj, _, err := r.Delete(ctx, "monitoring", nil, &metav1.DeleteOptions{})
+	if err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if module := obj.(*corev1alpha1.TenantModule); module.Spec.Values == nil {
+		t.Errorf("deleted module = %+v, want its configuration", module)
+	}
+	values := tenantValuesOf(t, r, "tenant-root", "tenant-foo")
+	if values["monitoring"] != false || values["etcd"] != true {
+		t.Errorf("values = %v, want monitoring disabled and etcd kept", values)
+	}
+	if _, ok := values["moduleValues"]; ok {
+		t.Errorf("moduleValues = %v, want the configuration dropped", values["moduleValues"])
+	}
+	if _, _, err := r.Delete(ctx, "monitoring", nil, &metav1.DeleteOptions{}); !apierrors.IsNotFound(err) {
+		t.Errorf("expected NotFound, got %v", err)
+	}
+}
+
+func TestTenantReleaseRef(t *testing.T) {
+	for ns, want := range map[string]types.NamespacedName{
+		"tenant-root":    {Namespace: "tenant-root", Name: "tenant-root"},
+		"tenant-foo":     {Namespace: "tenant-root", Name: "tenant-foo"},
+		"tenant-foo-bar": {Namespace: "tenant-foo", Name: "tenant-bar"},
+	} {
+		if got, ok := tenantReleaseRef(ns); !ok || got != want {
+			t.Errorf("tenantReleaseRef(%s) = %v, want %v", ns, got, want)
+		}
+	}
+	if _, ok := tenantReleaseRef("kube-system"); ok {
+		t.Error("kube-system is not a tenant namespace")
+	}
+}
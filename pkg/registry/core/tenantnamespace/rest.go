@@ -113,8 +113,20 @@ func (r *REST) List(
 	if err != nil {
 		return nil, err
 	}
+	h, err := r.loadHierarchy(ctx, nsList)
+	if err != nil {
+		return nil, err
+	}
 
-	return r.makeList(nsList, allowed), nil
+	out := r.makeList(nsList, allowed)
+	byName := make(map[string]*corev1.Namespace, len(nsList.Items))
+	for i := range nsList.Items {
+		byName[nsList.Items[i].Name] = &nsList.Items[i]
+	}
+	for i := range out.Items {
+		out.Items[i].Status = status(byName[out.Items[i].Name], h)
+	}
+	return out, nil
 }
 
 func (r *REST) Get(
@@ -146,6 +158,10 @@ func (r *REST) Get(
 		}
 		return nil, err
 	}
+	h, err := r.loadHierarchy(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
 
 	return &corev1alpha1.TenantNamespace{
 		TypeMeta: metav1.TypeMeta{
@@ -153,6 +169,7 @@ func (r *REST) Get(
 			Kind:       "TenantNamespace",
 		},
 		ObjectMeta: ns.ObjectMeta,
+		Status:     status(ns, h),
 	}, nil
 }
 
@@ -173,28 +190,43 @@ func (r *REST) Watch(ctx context.Context, opts *metainternal.ListOptions) (watch
 		groups[group] = struct{}{}
 	}
 
+	// The status of a namespace depends on the other tenant namespaces and
+	// their quotas, so the watch keeps its own view of them: read here, past
+	// the informer cache, then updated from the backing watch events, which
+	// the cache may not have seen yet when they arrive.
 	nsList := &corev1.NamespaceList{}
+	if err := r.w.List(ctx, nsList); err != nil {
+		return nil, err
+	}
+	rqList := &corev1.ResourceQuotaList{}
+	if err := r.w.List(ctx, rqList); err != nil {
+		return nil, err
+	}
+	state := newWatchState(nsList.Items, rqList.Items)
 
 	// For a SendInitialEvents (WatchList) request, ask the backing watch for
 	// bookmarks — the apiserver omits them by default, which would leave the
 	// terminating initial-events-end bookmark with no reliable trigger.
 	sendInitialEvents := opts.SendInitialEvents != nil && *opts.SendInitialEvents
 
-	// Build upstream watch options with field and label selectors
-	rawOpts := &metav1.ListOptions{
+	// The selectors are applied to the events below rather than passed on:
+	// namespaces they exclude still change the status of those they match.
+	nsWatch, err := r.w.Watch(ctx, &corev1.NamespaceList{}, &client.ListOptions{Raw: &metav1.ListOptions{
 		Watch:               true,
 		ResourceVersion:     opts.ResourceVersion,
 		AllowWatchBookmarks: sendInitialEvents,
+	}})
+	if err != nil {
+		return nil, err
 	}
-	if opts.FieldSelector != nil {
-		rawOpts.FieldSelector = opts.FieldSelector.String()
-	}
-	if opts.LabelSelector != nil {
-		rawOpts.LabelSelector = opts.LabelSelector.String()
-	}
-
-	nsWatch, err := r.w.Watch(ctx, nsList, &client.ListOptions{Raw: rawOpts})
+	// ResourceQuotas only feed the quota summary of the status, so the
+	// selectors, which apply to namespaces, are not passed on.
+	rqWatch, err := r.w.Watch(ctx, &corev1.ResourceQuotaList{}, &client.ListOptions{Raw: &metav1.ListOptions{
+		Watch:           true,
+		ResourceVersion: opts.ResourceVersion,
+	}})
 	if err != nil {
+		nsWatch.Stop()
 		return nil, err
 	}
 
@@ -227,6 +259,7 @@ func (r *REST) Watch(ctx context.Context, opts *metainternal.ListOptions) (watch
 		defer close(events)
 		defer pw.Stop()
 		defer nsWatch.Stop()
+		defer rqWatch.Stop()
 
 		// send forwards an event, returning false if the watch or context ended.
 		send := func(ev watch.Event) bool {
@@ -240,7 +273,63 @@ func (r *REST) Watch(ctx context.Context, opts *metainternal.ListOptions) (watch
 			}
 		}
 
-		for ev := range nsWatch.ResultChan() {
+		// known holds what the watcher was last sent of each namespace, so
+		// that changes to the status of a namespace caused by another object
+		// — a sub-tenant created or deleted, a quota updated — are sent too.
+		known := map[string]*corev1alpha1.TenantNamespace{}
+		// refresh sends the namespaces whose status h changes, at the
+		// resourceVersion of the object that changed it.
+		refresh := func(h *hierarchy, except, rv string) bool {
+			for name, prev := range known {
+				if name == except {
+					continue
+				}
+				cur := prev.DeepCopy()
+				cur.Status = status(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: prev.Name, Labels: prev.Labels}}, h)
+				if !statusChanged(prev.Status, cur.Status) {
+					continue
+				}
+				cur.ResourceVersion = rv
+				known[name] = cur
+				if bookmark, ok := bookmarker.BeforeLiveEvent(watch.Modified); ok {
+					if !send(bookmark) {
+						return false
+					}
+				}
+				if !send(watch.Event{Type: watch.Modified, Object: cur}) {
+					return false
+				}
+			}
+			return true
+		}
+
+		nsEvents, rqEvents := nsWatch.ResultChan(), rqWatch.ResultChan()
+		for {
+			var ev watch.Event
+			var ok bool
+			select {
+			case ev, ok = <-nsEvents:
+			case ev, ok = <-rqEvents:
+			case <-pw.StopChan():
+				return
+			case <-ctx.Done():
+				return
+			}
+			if !ok {
+				// Either backing watch ended; the client re-watches.
+				break
+			}
+
+			if rq, isQuota := ev.Object.(*corev1.ResourceQuota); isQuota {
+				if ev.Type == watch.Bookmark || !state.apply(ev) {
+					continue
+				}
+				if !refresh(state.hierarchy(), "", rq.ResourceVersion) {
+					return
+				}
+				continue
+			}
+
 			// Handle bookmark events
 			if ev.Type == watch.Bookmark {
 				if ns, ok := ev.Object.(*corev1.Namespace); ok {
@@ -257,6 +346,8 @@ func (r *REST) Watch(ctx context.Context, opts *metainternal.ListOptions) (watch
 				continue
 			}
 			bookmarker.Observe(ns.ResourceVersion)
+			state.apply(ev)
+			h := state.hierarchy()
 
 			// Apply defensive filtering for field and label selectors
 			if opts.FieldSelector != nil {
@@ -295,6 +386,12 @@ func (r *REST) Watch(ctx context.Context, opts *metainternal.ListOptions) (watch
 					Labels:            ns.Labels,
 					Annotations:       ns.Annotations,
 				},
+				Status: status(ns, h),
+			}
+			if ev.Type == watch.Deleted {
+				delete(known, ns.Name)
+			} else {
+				known[ns.Name] = out
 			}
 
 			// Skip ADDED events based on resourceVersion comparison
@@ -318,6 +415,10 @@ func (r *REST) Watch(ctx context.Context, opts *metainternal.ListOptions) (watch
 			if !send(watch.Event{Type: ev.Type, Object: out}) {
 				return
 			}
+			// A namespace coming or going changes the children of its parent.
+			if !refresh(h, ns.Name, ns.ResourceVersion) {
+				return
+			}
 		}
 
 		// Backing watcher closed: flush the terminating bookmark if still pending.
@@ -337,7 +438,7 @@ func (r *REST) ConvertToTable(_ context.Context, obj runtime.Object, _ runtime.O
 	now := time.Now()
 	row := func(o *corev1alpha1.TenantNamespace) metav1.TableRow {
 		return metav1.TableRow{
-			Cells:  []interface{}{o.Name, duration.HumanDuration(now.Sub(o.CreationTimestamp.Time))},
+			Cells:  []interface{}{o.Name, o.Status.Parent, o.Status.Host, duration.HumanDuration(now.Sub(o.CreationTimestamp.Time))},
 			Object: runtime.RawExtension{Object: o},
 		}
 	}
@@ -346,6 +447,8 @@ func (r *REST) ConvertToTable(_ context.Context, obj runtime.Object, _ runtime.O
 		TypeMeta: metav1.TypeMeta{APIVersion: "meta.k8s.io/v1", Kind: "Table"},
 		ColumnDefinitions: []metav1.TableColumnDefinition{
 			{Name: "NAME", Type: "string"},
+			{Name: "PARENT", Type: "string"},
+			{Name: "HOST", Type: "string"},
 			{Name: "AGE", Type: "string"},
 		},
 	}
@@ -416,6 +519,19 @@ func (r *REST) makeList(src *corev1.NamespaceList, allowed []string) *corev1alph
 	return out
 }
 
+// FilterAccessible returns the tenant namespaces among names that the
+// requesting user can access, in no particular order. Other core.cozystack.io
+// resources derived from tenant namespaces share this access model.
+func (r *REST) FilterAccessible(ctx context.Context, names []string) ([]string, error) {
+	return r.filterAccessible(ctx, names)
+}
+
+// HasAccessForUser reports whether the user with the given name and groups
+// can access namespace.
+func (r *REST) HasAccessForUser(ctx context.Context, namespace, username string, groups map[string]struct{}) (bool, error) {
+	return r.hasAccessToNamespaceForUser(ctx, namespace, username, groups)
+}
+
 // matchesSubject checks if a RoleBinding subject matches the user's identity.
 // It handles Group, User, and ServiceAccount subjects with proper namespace fallback.
 func matchesSubject(subj rbacv1.Subject, bindingNamespace, username string, groups map[string]struct{}) bool {
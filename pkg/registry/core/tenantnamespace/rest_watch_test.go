@@ -635,9 +635,10 @@ func TestWatch_StopTerminatesGoroutine(t *testing.T) {
 		t.Fatalf("Watch returned error: %v", err)
 	}
 
-	w.Stop()
-	// Push an event the goroutine must try (and fail) to deliver.
+	// Push an event the goroutine must try (and fail) to deliver: the fake
+	// hands it over before the watch is stopped.
 	fw.Add(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "tenant-foo"}})
+	w.Stop()
 
 	waitForFakeWatcherStop(t, fw, 2*time.Second)
 	requireResultChanClosed(t, w, 2*time.Second)
@@ -660,8 +661,51 @@ func TestWatch_ContextCancelTerminatesGoroutine(t *testing.T) {
 	}
 	defer w.Stop()
 
-	cancel()
 	fw.Add(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "tenant-foo"}})
+	cancel()
+
+	waitForFakeWatcherStop(t, fw, 2*time.Second)
+	requireResultChanClosed(t, w, 2*time.Second)
+}
+
+// TestWatch_StopWhileIdleTerminatesGoroutine asserts that stopping a watch no
+// event arrives on makes the goroutine exit rather than wait on the backing
+// watchers forever.
+func TestWatch_StopWhileIdleTerminatesGoroutine(t *testing.T) {
+	fc := fake.NewClientBuilder().WithScheme(newTestScheme(t)).Build()
+	fw := watch.NewFake()
+	r := newTestREST(fc, &stubWithWatch{WithWatch: fc, fw: fw})
+
+	u := &user.DefaultInfo{Name: "admin", Groups: []string{"system:masters"}}
+	ctx, cancel := context.WithCancel(request.WithUser(context.Background(), u))
+	defer cancel()
+
+	w, err := r.Watch(ctx, &metainternal.ListOptions{})
+	if err != nil {
+		t.Fatalf("Watch returned error: %v", err)
+	}
+	w.Stop()
+
+	waitForFakeWatcherStop(t, fw, 2*time.Second)
+	requireResultChanClosed(t, w, 2*time.Second)
+}
+
+// TestWatch_ContextCancelWhileIdleTerminatesGoroutine is the same for a
+// cancelled request context.
+func TestWatch_ContextCancelWhileIdleTerminatesGoroutine(t *testing.T) {
+	fc := fake.NewClientBuilder().WithScheme(newTestScheme(t)).Build()
+	fw := watch.NewFake()
+	r := newTestREST(fc, &stubWithWatch{WithWatch: fc, fw: fw})
+
+	u := &user.DefaultInfo{Name: "admin", Groups: []string{"system:masters"}}
+	ctx, cancel := context.WithCancel(request.WithUser(context.Background(), u))
+
+	w, err := r.Watch(ctx, &metainternal.ListOptions{})
+	if err != nil {
+		t.Fatalf("Watch returned error: %v", err)
+	}
+	defer w.Stop()
+	cancel()
 
 	waitForFakeWatcherStop(t, fw, 2*time.Second)
 	requireResultChanClosed(t, w, 2*time.Second)
@@ -685,8 +729,8 @@ func TestWatch_StoppedDuringBookmarkSend_TerminatesGoroutine(t *testing.T) {
 		t.Fatalf("Watch returned error: %v", err)
 	}
 
-	w.Stop()
 	fw.Action(watch.Bookmark, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{ResourceVersion: "7"}})
+	w.Stop()
 
 	waitForFakeWatcherStop(t, fw, 2*time.Second)
 	requireResultChanClosed(t, w, 2*time.Second)
@@ -710,10 +754,10 @@ func TestWatch_StoppedDuringInitialEventsEndBookmarkSend_TerminatesGoroutine(t *
 		t.Fatalf("Watch returned error: %v", err)
 	}
 
-	w.Stop()
 	// A live (non-ADDED) event makes the bookmarker emit the pending
 	// initial-events-end bookmark first; its send must fail and exit.
 	fw.Modify(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "tenant-foo", ResourceVersion: "8"}})
+	w.Stop()
 
 	waitForFakeWatcherStop(t, fw, 2*time.Second)
 	requireResultChanClosed(t, w, 2*time.Second)
@@ -0,0 +1,208 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package tenantnamespace
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
+
+	quotaview "github.com/cozystack/cozystack/internal/controller/tenantquota"
+	corev1alpha1 "github.com/cozystack/cozystack/pkg/apis/core/v1alpha1"
+)
+
+// Labels the tenant chart stamps on tenant namespaces: the host the tenant
+// publishes under, and the namespace of the tenant providing each service.
+const (
+	hostLabel       = "namespace.cozystack.io/host"
+	etcdLabel       = "namespace.cozystack.io/etcd"
+	monitoringLabel = "namespace.cozystack.io/monitoring"
+	ingressLabel    = "namespace.cozystack.io/ingress"
+	gatewayLabel    = "namespace.cozystack.io/gateway"
+	seaweedfsLabel  = "namespace.cozystack.io/seaweedfs"
+)
+
+// hierarchy is what the status of a tenant namespace needs to know about the
+// other ones: its sub-tenants and the quotas.
+type hierarchy struct {
+	children map[string][]string
+	views    map[string]*quotaview.View
+}
+
+// loadHierarchy reads the tenant namespaces, unless nsList already holds
+// them, and the ResourceQuotas of the cluster.
+func (r *REST) loadHierarchy(ctx context.Context, nsList *corev1.NamespaceList) (*hierarchy, error) {
+	if nsList == nil {
+		nsList = &corev1.NamespaceList{}
+		if err := r.c.List(ctx, nsList); err != nil {
+			return nil, err
+		}
+	}
+	rqList := &corev1.ResourceQuotaList{}
+	if err := r.c.List(ctx, rqList); err != nil {
+		return nil, err
+	}
+	return newHierarchy(nsList.Items, rqList.Items), nil
+}
+
+// newHierarchy builds the hierarchy of the tenant namespaces among
+// namespaces, with the quotas of those among quotas.
+func newHierarchy(namespaces []corev1.Namespace, quotas []corev1.ResourceQuota) *hierarchy {
+	var names []string
+	tenants := map[string]struct{}{}
+	for i := range namespaces {
+		if strings.HasPrefix(namespaces[i].Name, prefix) {
+			names = append(names, namespaces[i].Name)
+			tenants[namespaces[i].Name] = struct{}{}
+		}
+	}
+	tenantQuotas := make([]corev1.ResourceQuota, 0, len(quotas))
+	for i := range quotas {
+		if _, ok := tenants[quotas[i].Namespace]; ok {
+			tenantQuotas = append(tenantQuotas, quotas[i])
+		}
+	}
+	sort.Strings(names)
+	h := &hierarchy{children: map[string][]string{}, views: quotaview.ComputeViews(names, tenantQuotas)}
+	for _, name := range names {
+		if parent := h.views[name].Parent; parent != "" {
+			h.children[parent] = append(h.children[parent], name)
+		}
+	}
+	for _, children := range h.children {
+		sort.Strings(children)
+	}
+	return h
+}
+
+// watchState is the tenant Namespaces and ResourceQuotas as a watch has
+// seen them: the state read when it started with the backing watch events
+// applied.
+type watchState struct {
+	namespaces map[string]corev1.Namespace
+	quotas     map[types.NamespacedName]corev1.ResourceQuota
+}
+
+func newWatchState(namespaces []corev1.Namespace, quotas []corev1.ResourceQuota) *watchState {
+	state := &watchState{
+		namespaces: map[string]corev1.Namespace{},
+		quotas:     map[types.NamespacedName]corev1.ResourceQuota{},
+	}
+	for _, ns := range namespaces {
+		if strings.HasPrefix(ns.Name, prefix) {
+			state.namespaces[ns.Name] = ns
+		}
+	}
+	for _, rq := range quotas {
+		if strings.HasPrefix(rq.Namespace, prefix) {
+			state.quotas[types.NamespacedName{Namespace: rq.Namespace, Name: rq.Name}] = rq
+		}
+	}
+	return state
+}
+
+// apply records a Namespace or ResourceQuota event, reporting whether it
+// concerns a tenant namespace and is newer than what was seen of the object.
+func (w *watchState) apply(ev watch.Event) bool {
+	switch obj := ev.Object.(type) {
+	case *corev1.Namespace:
+		if !strings.HasPrefix(obj.Name, prefix) {
+			return false
+		}
+		if prev, ok := w.namespaces[obj.Name]; ok && !newer(obj, &prev) {
+			return false
+		}
+		if ev.Type == watch.Deleted {
+			delete(w.namespaces, obj.Name)
+		} else {
+			w.namespaces[obj.Name] = *obj
+		}
+	case *corev1.ResourceQuota:
+		if !strings.HasPrefix(obj.Namespace, prefix) {
+			return false
+		}
+		key := types.NamespacedName{Namespace: obj.Namespace, Name: obj.Name}
+		if prev, ok := w.quotas[key]; ok && !newer(obj, &prev) {
+			return false
+		}
+		if ev.Type == watch.Deleted {
+			delete(w.quotas, key)
+		} else {
+			w.quotas[key] = *obj
+		}
+	default:
+		return false
+	}
+	return true
+}
+
+func (w *watchState) hierarchy() *hierarchy {
+	nsItems := make([]corev1.Namespace, 0, len(w.namespaces))
+	for _, ns := range w.namespaces {
+		nsItems = append(nsItems, ns)
+	}
+	rqItems := make([]corev1.ResourceQuota, 0, len(w.quotas))
+	for _, rq := range w.quotas {
+		rqItems = append(rqItems, rq)
+	}
+	return newHierarchy(nsItems, rqItems)
+}
+
+// newer reports whether obj is a later version than prev. Events replayed
+// by a watch resuming at an older version than its initial state are not.
+func newer(obj, prev metav1.Object) bool {
+	cur, err := strconv.ParseUint(obj.GetResourceVersion(), 10, 64)
+	if err != nil {
+		return true
+	}
+	old, err := strconv.ParseUint(prev.GetResourceVersion(), 10, 64)
+	return err != nil || cur > old
+}
+
+// status derives the status of a tenant namespace. Without a hierarchy, only
+// what the namespace itself tells is filled in.
+func status(ns *corev1.Namespace, h *hierarchy) corev1alpha1.TenantNamespaceStatus {
+	var path []string
+	for n := ns.Name; n != ""; n = quotaview.ParentNamespace(n) {
+		path = append([]string{n}, path...)
+	}
+	labels := ns.Labels
+	out := corev1alpha1.TenantNamespaceStatus{
+		Parent: quotaview.ParentNamespace(ns.Name),
+		Path:   path,
+		Depth:  int32(len(path) - 1),
+		Host:   labels[hostLabel],
+		Features: corev1alpha1.TenantNamespaceFeatures{
+			Etcd:       labels[etcdLabel],
+			Monitoring: labels[monitoringLabel],
+			Ingress:    labels[ingressLabel],
+			Gateway:    labels[gatewayLabel],
+			Seaweedfs:  labels[seaweedfsLabel],
+		},
+	}
+	if h == nil {
+		return out
+	}
+	out.Children = h.children[ns.Name]
+	if v, ok := h.views[ns.Name]; ok && (v.Pool != "" || len(v.Allocated) > 0) {
+		out.Quota = &corev1alpha1.TenantNamespaceQuota{
+			Pool:      v.Pool,
+			Allocated: v.Allocated,
+			Used:      v.Used,
+		}
+	}
+	return out
+}
+
+// statusChanged reports whether a watcher that was last sent prev must be
+// told about cur.
+func statusChanged(prev, cur corev1alpha1.TenantNamespaceStatus) bool {
+	return !equality.Semantic.DeepEqual(prev, cur)
+}
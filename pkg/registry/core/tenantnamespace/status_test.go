@@ -0,0 +1,189 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package tenantnamespace
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metainternal "k8s.io/apimachinery/pkg/apis/meta/internalversion"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/apiserver/pkg/endpoints/request"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	corev1alpha1 "github.com/cozystack/cozystack/pkg/apis/core/v1alpha1"
+)
+
+func tenantNamespace(name string, labels map[string]string) *corev1.Namespace {
+	return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels}}
+}
+
+func TestGet_Status(t *testing.T) {
+	fc := fake.NewClientBuilder().WithScheme(newTestScheme(t)).WithObjects(
+		tenantNamespace("tenant-root", nil),
+		tenantNamespace("tenant-foo", map[string]string{
+			hostLabel:       "foo.example.org",
+			etcdLabel:       "tenant-foo",
+			ingressLabel:    "tenant-root",
+			monitoringLabel: "",
+		}),
+		tenantNamespace("tenant-foo-bar", nil),
+		tenantNamespace("tenant-foo-baz", nil),
+		tenantNamespace("tenant-foo-bar-qux", nil),
+		&corev1.ResourceQuota{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "tenant-foo", Name: "tenant-quota"},
+			Spec:       corev1.ResourceQuotaSpec{Hard: corev1.ResourceList{corev1.ResourceLimitsCPU: resource.MustParse("4")}},
+			Status: corev1.ResourceQuotaStatus{
+				Hard: corev1.ResourceList{corev1.ResourceLimitsCPU: resource.MustParse("4")},
+				Used: corev1.ResourceList{corev1.ResourceLimitsCPU: resource.MustParse("1")},
+			},
+		},
+	).Build()
+	r := newTestREST(fc, fc)
+	ctx := request.WithUser(context.Background(), &user.DefaultInfo{Name: "admin", Groups: []string{"system:masters"}})
+
+	obj, err := r.Get(ctx, "tenant-foo", &metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	st := obj.(*corev1alpha1.TenantNamespace).Status
+	if st.Parent != "tenant-root" || st.Depth != 1 {
+		t.Errorf("parent = %q, depth = %d", st.Parent, st.Depth)
+	}
+	if want := []string{"tenant-root", "tenant-foo"}; !reflect.DeepEqual(st.Path, want) {
+		t.Errorf("path = %v, want %v", st.Path, want)
+	}
+	if want := []string{"tenant-foo-bar", "tenant-foo-baz"}; !reflect.DeepEqual(st.Children, want) {
+		t.Errorf("children = %v, want the direct sub-tenants %v", st.Children, want)
+	}
+	if st.Host != "foo.example.org" || st.Features.Etcd != "tenant-foo" || st.Features.Ingress != "tenant-root" || st.Features.Monitoring != "" {
+		t.Errorf("host = %q, features = %+v", st.Host, st.Features)
+	}
+	if st.Quota == nil || st.Quota.Pool != "tenant-foo" {
+		t.Fatalf("quota = %+v, want the tenant's own pool", st.Quota)
+	}
+	if used := st.Quota.Used[corev1.ResourceLimitsCPU]; used.String() != "1" {
+		t.Errorf("used cpu = %s, want 1", used.String())
+	}
+
+	obj, err = r.Get(ctx, "tenant-foo-bar-qux", &metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if st := obj.(*corev1alpha1.TenantNamespace).Status; st.Depth != 3 || st.Quota.Pool != "tenant-foo" || len(st.Quota.Allocated) != 0 {
+		t.Errorf("sub-tenant status = %+v, want depth 3 drawing from tenant-foo", st)
+	}
+}
+
+// TestWatch_SubTenantModifiesParent asserts that creating a sub-tenant sends
+// its parent again with the new child, and that a quota change sends the
+// namespace it summarizes.
+func TestWatch_SubTenantModifiesParent(t *testing.T) {
+	fc := fake.NewClientBuilder().WithScheme(newTestScheme(t)).Build()
+	r := newTestREST(fc, fc)
+	ctx, cancel := context.WithCancel(request.WithUser(context.Background(), &user.DefaultInfo{Name: "admin", Groups: []string{"system:masters"}}))
+	defer cancel()
+
+	w, err := r.Watch(ctx, &metainternal.ListOptions{})
+	if err != nil {
+		t.Fatalf("Watch: %v", err)
+	}
+	defer w.Stop()
+
+	if err := fc.Create(ctx, tenantNamespace("tenant-foo", nil)); err != nil {
+		t.Fatal(err)
+	}
+	requireTenantNamespaceEvent(t, collectEvents(t, w, 1, 2*time.Second)[0], watch.Added, "tenant-foo")
+
+	if err := fc.Create(ctx, tenantNamespace("tenant-foo-bar", nil)); err != nil {
+		t.Fatal(err)
+	}
+	evs := collectEvents(t, w, 2, 2*time.Second)
+	if len(evs) != 2 {
+		t.Fatalf("expected 2 events, got %+v", evs)
+	}
+	requireTenantNamespaceEvent(t, evs[0], watch.Added, "tenant-foo-bar")
+	parent := requireTenantNamespaceEvent(t, evs[1], watch.Modified, "tenant-foo")
+	if !reflect.DeepEqual(parent.Status.Children, []string{"tenant-foo-bar"}) {
+		t.Errorf("children = %v, want [tenant-foo-bar]", parent.Status.Children)
+	}
+
+	rq := &corev1.ResourceQuota{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "tenant-foo", Name: "tenant-quota"},
+		Spec:       corev1.ResourceQuotaSpec{Hard: corev1.ResourceList{corev1.ResourceLimitsCPU: resource.MustParse("4")}},
+	}
+	if err := fc.Create(ctx, rq); err != nil {
+		t.Fatal(err)
+	}
+	evs = collectEvents(t, w, 2, 2*time.Second)
+	if len(evs) != 2 {
+		t.Fatalf("expected the namespace and its sub-tenant, got %+v", evs)
+	}
+	for _, ev := range evs {
+		tn := ev.Object.(*corev1alpha1.TenantNamespace)
+		if ev.Type != watch.Modified || tn.Status.Quota == nil || tn.Status.Quota.Pool != "tenant-foo" {
+			t.Errorf("event %s %s: quota = %+v, want drawing from tenant-foo", ev.Type, tn.Name, tn.Status.Quota)
+		}
+		if tn.ResourceVersion != rq.ResourceVersion {
+			t.Errorf("event %s carries resourceVersion %s, want the quota's %s", tn.Name, tn.ResourceVersion, rq.ResourceVersion)
+		}
+	}
+}
+
+// TestWatch_StatusFromEvents asserts that the status sent on a watch comes
+// from the watch events, not from an informer cache that has not seen them.
+func TestWatch_StatusFromEvents(t *testing.T) {
+	live := fake.NewClientBuilder().WithScheme(newTestScheme(t)).Build()
+	stale := fake.NewClientBuilder().WithScheme(newTestScheme(t)).Build()
+	r := newTestREST(stale, live)
+	ctx, cancel := context.WithCancel(request.WithUser(context.Background(), &user.DefaultInfo{Name: "admin", Groups: []string{"system:masters"}}))
+	defer cancel()
+
+	w, err := r.Watch(ctx, &metainternal.ListOptions{})
+	if err != nil {
+		t.Fatalf("Watch: %v", err)
+	}
+	defer w.Stop()
+
+	if err := live.Create(ctx, tenantNamespace("tenant-foo", nil)); err != nil {
+		t.Fatal(err)
+	}
+	requireTenantNamespaceEvent(t, collectEvents(t, w, 1, 2*time.Second)[0], watch.Added, "tenant-foo")
+
+	if err := live.Create(ctx, tenantNamespace("tenant-foo-bar", nil)); err != nil {
+		t.Fatal(err)
+	}
+	evs := collectEvents(t, w, 2, 2*time.Second)
+	if len(evs) != 2 {
+		t.Fatalf("expected 2 events, got %+v", evs)
+	}
+	requireTenantNamespaceEvent(t, evs[0], watch.Added, "tenant-foo-bar")
+	parent := requireTenantNamespaceEvent(t, evs[1], watch.Modified, "tenant-foo")
+	if !reflect.DeepEqual(parent.Status.Children, []string{"tenant-foo-bar"}) {
+		t.Errorf("children = %v, want [tenant-foo-bar]", parent.Status.Children)
+	}
+
+	rq := &corev1.ResourceQuota{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "tenant-foo", Name: "tenant-quota"},
+		Spec:       corev1.ResourceQuotaSpec{Hard: corev1.ResourceList{corev1.ResourceLimitsCPU: resource.MustParse("4")}},
+	}
+	if err := live.Create(ctx, rq); err != nil {
+		t.Fatal(err)
+	}
+	evs = collectEvents(t, w, 2, 2*time.Second)
+	if len(evs) != 2 {
+		t.Fatalf("expected the namespace and its sub-tenant, got %+v", evs)
+	}
+	for _, ev := range evs {
+		tn := ev.Object.(*corev1alpha1.TenantNamespace)
+		if tn.Status.Quota == nil || tn.Status.Quota.Pool != "tenant-foo" {
+			t.Errorf("event %s %s: quota = %+v, want drawing from tenant-foo", ev.Type, tn.Name, tn.Status.Quota)
+		}
+	}
+}
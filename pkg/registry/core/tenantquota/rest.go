@@ -0,0 +1,672 @@
+// SPDX-License-Identifier: Apache-2.0
+// TenantQuota registry: read-only, hierarchical quota view of every tenant
+// namespace, computed from the tenants' ResourceQuotas with the pool model of
+// the tenant quota controller.
+
+package tenantquota
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metainternal "k8s.io/apimachinery/pkg/apis/meta/internalversion"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/duration"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/apiserver/pkg/registry/rest"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	quotaview "github.com/cozystack/cozystack/internal/controller/tenantquota"
+	corev1alpha1 "github.com/cozystack/cozystack/pkg/apis/core/v1alpha1"
+	"github.com/cozystack/cozystack/pkg/registry"
+	"github.com/cozystack/cozystack/pkg/registry/core/tenantnamespace"
+	"github.com/cozystack/cozystack/pkg/registry/sorting"
+)
+
+const (
+	prefix       = "tenant-"
+	singularName = "tenantquota"
+)
+
+// -----------------------------------------------------------------------------
+// REST storage
+// -----------------------------------------------------------------------------
+
+var (
+	_ rest.Lister               = &REST{}
+	_ rest.Getter               = &REST{}
+	_ rest.Watcher              = &REST{}
+	_ rest.TableConvertor       = &REST{}
+	_ rest.Scoper               = &REST{}
+	_ rest.SingularNameProvider = &REST{}
+)
+
+// REST serves TenantQuotas. A TenantQuota is named after its tenant namespace
+// and visible to the users who can access that namespace, as TenantNamespaces
+// are.
+type REST struct {
+	c          client.Client
+	w          client.WithWatch
+	namespaces *tenantnamespace.REST
+	gvr        schema.GroupVersionResource
+}
+
+func NewREST(
+	c client.Client,
+	w client.WithWatch,
+) *REST {
+	return &REST{
+		c:          c,
+		w:          w,
+		namespaces: tenantnamespace.NewREST(c, w),
+		gvr: schema.GroupVersionResource{
+			Group:    corev1alpha1.GroupName,
+			Version:  "v1alpha1",
+			Resource: "tenantquotas",
+		},
+	}
+}
+
+// -----------------------------------------------------------------------------
+// Basic meta
+// -----------------------------------------------------------------------------
+
+func (*REST) NamespaceScoped() bool { return false }
+func (*REST) New() runtime.Object   { return &corev1alpha1.TenantQuota{} }
+func (*REST) NewList() runtime.Object {
+	return &corev1alpha1.TenantQuotaList{}
+}
+func (*REST) Kind() string { return "TenantQuota" }
+func (r *REST) GroupVersionKind(_ schema.GroupVersion) schema.GroupVersionKind {
+	return r.gvr.GroupVersion().WithKind("TenantQuota")
+}
+func (*REST) GetSingularName() string { return singularName }
+
+// -----------------------------------------------------------------------------
+// Lister / Getter
+// -----------------------------------------------------------------------------
+
+func (r *REST) List(
+	ctx context.Context,
+	opts *metainternal.ListOptions,
+) (runtime.Object, error) {
+	snap, err := r.snapshot(ctx)
+	if err != nil {
+		return nil, err
+	}
+	allowed, err := r.namespaces.FilterAccessible(ctx, snap.names)
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(allowed)
+
+	out := &corev1alpha1.TenantQuotaList{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: corev1alpha1.SchemeGroupVersion.String(),
+			Kind:       "TenantQuotaList",
+		},
+		ListMeta: metav1.ListMeta{ResourceVersion: snap.resourceVersion},
+	}
+	for _, name := range allowed {
+		obj := snap.object(name)
+		if !matches(obj, opts) {
+			continue
+		}
+		out.Items = append(out.Items, *obj)
+	}
+	sorting.ByName[corev1alpha1.TenantQuota, *corev1alpha1.TenantQuota](out.Items)
+	return out, nil
+}
+
+func (r *REST) Get(
+	ctx context.Context,
+	name string,
+	_ *metav1.GetOptions,
+) (runtime.Object, error) {
+	if !strings.HasPrefix(name, prefix) {
+		return nil, apierrors.NewNotFound(r.gvr.GroupResource(), name)
+	}
+	allowed, err := r.namespaces.FilterAccessible(ctx, []string{name})
+	if err != nil {
+		return nil, err
+	}
+	if len(allowed) == 0 {
+		// Return Forbidden to follow standard K8s RBAC behavior
+		return nil, apierrors.NewForbidden(r.gvr.GroupResource(), name, fmt.Errorf("access denied"))
+	}
+	snap, err := r.snapshot(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := snap.views[name]; !ok {
+		return nil, apierrors.NewNotFound(r.gvr.GroupResource(), name)
+	}
+	return snap.object(name), nil
+}
+
+// -----------------------------------------------------------------------------
+// Watcher
+// -----------------------------------------------------------------------------
+
+// Watch streams TenantQuota changes. A TenantQuota depends on the
+// ResourceQuotas of its namespace and of every tenant below it, and pool
+// allocations tie siblings together, so every change to a tenant Namespace or
+// ResourceQuota recomputes the whole picture and emits the TenantQuotas that
+// differ from what the watcher last sent. The picture is recomputed from the
+// objects of the backing watch events, applied to the initial snapshot, not
+// from the informer cache, which may not have seen the event yet.
+//
+// Access is evaluated once per namespace for the lifetime of the watch.
+func (r *REST) Watch(ctx context.Context, opts *metainternal.ListOptions) (watch.Interface, error) {
+	u, ok := request.UserFrom(ctx)
+	if !ok {
+		return nil, apierrors.NewUnauthorized("user missing in context")
+	}
+	username := u.GetName()
+	groups := make(map[string]struct{})
+	for _, group := range u.GetGroups() {
+		groups[group] = struct{}{}
+	}
+
+	initial, err := r.snapshot(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Without a resourceVersion, or for a SendInitialEvents (WatchList)
+	// request, the client gets the current state as ADDED events and the
+	// backing watches start where that state was read. Otherwise they resume
+	// at the client's version.
+	sendInitialEvents := opts.SendInitialEvents != nil && *opts.SendInitialEvents
+	sendInitial := sendInitialEvents || opts.ResourceVersion == "" || opts.ResourceVersion == "0"
+	backingRV := opts.ResourceVersion
+	if sendInitial {
+		backingRV = initial.resourceVersion
+	}
+	var startingRV uint64
+	if !sendInitial {
+		if rv, err := strconv.ParseUint(opts.ResourceVersion, 10, 64); err == nil {
+			startingRV = rv
+		}
+	}
+
+	rawOpts := &metav1.ListOptions{Watch: true, ResourceVersion: backingRV}
+	nsWatch, err := r.w.Watch(ctx, &corev1.NamespaceList{}, &client.ListOptions{Raw: rawOpts})
+	if err != nil {
+		return nil, err
+	}
+	rqWatch, err := r.w.Watch(ctx, &corev1.ResourceQuotaList{}, &client.ListOptions{Raw: rawOpts.DeepCopy()})
+	if err != nil {
+		nsWatch.Stop()
+		return nil, err
+	}
+
+	bookmarker := registry.NewInitialEventsBookmarker(sendInitialEvents, initial.resourceVersion, func() runtime.Object {
+		return &corev1alpha1.TenantQuota{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: corev1alpha1.SchemeGroupVersion.String(),
+				Kind:       "TenantQuota",
+			},
+		}
+	})
+
+	access := map[string]bool{}
+	visible := func(obj *corev1alpha1.TenantQuota) bool {
+		allowed, seen := access[obj.Name]
+		if !seen {
+			var err error
+			allowed, err = r.namespaces.HasAccessForUser(ctx, obj.Name, username, groups)
+			if err != nil {
+				klog.ErrorS(err, "Failed to check access for tenant quota in watch", "namespace", obj.Name)
+				return false
+			}
+			access[obj.Name] = allowed
+		}
+		return allowed && matches(obj, opts)
+	}
+
+	events := make(chan watch.Event)
+	pw := watch.NewProxyWatcher(events)
+
+	go func() {
+		// This goroutine is the sole writer to events; closing it on exit
+		// signals end-of-stream to the consumer (ProxyWatcher.Stop does not
+		// close the channel it proxies).
+		defer close(events)
+		defer pw.Stop()
+		defer nsWatch.Stop()
+		defer rqWatch.Stop()
+
+		// send forwards an event, returning false if the watch or context ended.
+		send := func(ev watch.Event) bool {
+			select {
+			case events <- ev:
+				return true
+			case <-pw.StopChan():
+				return false
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		state := newWatchState(initial)
+		known := map[string]*corev1alpha1.TenantQuota{}
+		for _, name := range initial.names {
+			obj := initial.object(name)
+			if !visible(obj) {
+				continue
+			}
+			known[name] = obj
+			switch {
+			case sendInitial:
+				if !send(watch.Event{Type: watch.Added, Object: obj}) {
+					return
+				}
+			case resourceVersion(obj) > startingRV:
+				// Changed since the version the client resumes from.
+				if !send(watch.Event{Type: watch.Modified, Object: obj}) {
+					return
+				}
+			}
+		}
+		// The initial events are the snapshot itself, so they end at its
+		// version.
+		if bookmark, end := bookmarker.OnBackingBookmark(initial.resourceVersion); end {
+			if !send(bookmark) {
+				return
+			}
+		}
+
+		nsEvents, rqEvents := nsWatch.ResultChan(), rqWatch.ResultChan()
+		for {
+			var ev watch.Event
+			var ok bool
+			select {
+			case ev, ok = <-nsEvents:
+			case ev, ok = <-rqEvents:
+			case <-pw.StopChan():
+				return
+			case <-ctx.Done():
+				return
+			}
+			if !ok {
+				// Either backing watch ended; the client re-watches.
+				return
+			}
+			switch ev.Type {
+			case watch.Bookmark:
+				continue
+			case watch.Error:
+				send(ev)
+				return
+			}
+			obj, isObj := ev.Object.(client.Object)
+			if !isObj || !state.apply(ev) {
+				continue
+			}
+
+			snap := state.snapshot()
+			for _, name := range snap.names {
+				cur := snap.object(name)
+				if !visible(cur) {
+					continue
+				}
+				prev, existed := known[name]
+				if existed && !changed(prev, cur) {
+					continue
+				}
+				known[name] = cur
+				eventType := watch.Modified
+				if !existed {
+					eventType = watch.Added
+				}
+				if !send(watch.Event{Type: eventType, Object: cur}) {
+					return
+				}
+			}
+			for name, prev := range known {
+				if _, ok := snap.views[name]; ok && visible(snap.object(name)) {
+					continue
+				}
+				delete(known, name)
+				gone := prev.DeepCopy()
+				gone.ResourceVersion = obj.GetResourceVersion()
+				if !send(watch.Event{Type: watch.Deleted, Object: gone}) {
+					return
+				}
+			}
+		}
+	}()
+
+	return pw, nil
+}
+
+// -----------------------------------------------------------------------------
+// TableConvertor
+// -----------------------------------------------------------------------------
+
+func (r *REST) ConvertToTable(_ context.Context, obj runtime.Object, _ runtime.Object) (*metav1.Table, error) {
+	now := time.Now()
+	row := func(o *corev1alpha1.TenantQuota) metav1.TableRow {
+		return metav1.TableRow{
+			Cells: []interface{}{
+				o.Name,
+				o.Status.Pool,
+				resourceSummary(o.Status.Used, o.Status.Allocated),
+				o.Status.Descendants.Tenants,
+				duration.HumanDuration(now.Sub(o.CreationTimestamp.Time)),
+			},
+			Object: runtime.RawExtension{Object: o},
+		}
+	}
+
+	tbl := &metav1.Table{
+		TypeMeta: metav1.TypeMeta{APIVersion: "meta.k8s.io/v1", Kind: "Table"},
+		ColumnDefinitions: []metav1.TableColumnDefinition{
+			{Name: "NAME", Type: "string"},
+			{Name: "POOL", Type: "string"},
+			{Name: "USED/ALLOCATED", Type: "string"},
+			{Name: "DESCENDANTS", Type: "integer"},
+			{Name: "AGE", Type: "string"},
+		},
+	}
+
+	switch v := obj.(type) {
+	case *corev1alpha1.TenantQuotaList:
+		for i := range v.Items {
+			tbl.Rows = append(tbl.Rows, row(&v.Items[i]))
+		}
+		tbl.ResourceVersion = v.ResourceVersion
+	case *corev1alpha1.TenantQuota:
+		tbl.Rows = append(tbl.Rows, row(v))
+		tbl.ResourceVersion = v.ResourceVersion
+	default:
+		return nil, notAcceptable{r.gvr.GroupResource(), fmt.Sprintf("unexpected %T", obj)}
+	}
+	return tbl, nil
+}
+
+// resourceSummary renders the used/allocated pairs of the resources the
+// namespace is limited on, e.g. "limits.cpu=1/4".
+func resourceSummary(used, allocated corev1.ResourceList) string {
+	names := make([]string, 0, len(allocated))
+	for name := range allocated {
+		names = append(names, string(name))
+	}
+	sort.Strings(names)
+	parts := make([]string, 0, len(names))
+	for _, name := range names {
+		u := used[corev1.ResourceName(name)]
+		a := allocated[corev1.ResourceName(name)]
+		parts = append(parts, fmt.Sprintf("%s=%s/%s", name, u.String(), a.String()))
+	}
+	return strings.Join(parts, ",")
+}
+
+// -----------------------------------------------------------------------------
+// Helpers
+// -----------------------------------------------------------------------------
+
+// snapshot is the quota picture of every tenant namespace at one point in time.
+type snapshot struct {
+	// names lists the tenant namespaces, sorted.
+	names      []string
+	namespaces map[string]*corev1.Namespace
+	quotas     []corev1.ResourceQuota
+	views      map[string]*quotaview.View
+	// ownRV is the highest resourceVersion of a namespace and its quotas.
+	ownRV           map[string]uint64
+	resourceVersion string
+}
+
+func (r *REST) snapshot(ctx context.Context) (*snapshot, error) {
+	nsList := &corev1.NamespaceList{}
+	if err := r.c.List(ctx, nsList); err != nil {
+		return nil, err
+	}
+	rqList := &corev1.ResourceQuotaList{}
+	if err := r.c.List(ctx, rqList); err != nil {
+		return nil, err
+	}
+	return newSnapshot(nsList.Items, rqList.Items), nil
+}
+
+// newSnapshot computes the snapshot of namespaces and quotas, which may
+// include non-tenant ones.
+func newSnapshot(nsItems []corev1.Namespace, rqItems []corev1.ResourceQuota) *snapshot {
+	s := &snapshot{namespaces: map[string]*corev1.Namespace{}, ownRV: map[string]uint64{}}
+	var maxRV uint64
+	observe := func(ns, rv string) {
+		v, err := strconv.ParseUint(rv, 10, 64)
+		if err != nil {
+			return
+		}
+		if v > s.ownRV[ns] {
+			s.ownRV[ns] = v
+		}
+		if v > maxRV {
+			maxRV = v
+		}
+	}
+	for i := range nsItems {
+		ns := &nsItems[i]
+		if !strings.HasPrefix(ns.Name, prefix) {
+			continue
+		}
+		s.names = append(s.names, ns.Name)
+		s.namespaces[ns.Name] = ns
+		observe(ns.Name, ns.ResourceVersion)
+	}
+	sort.Strings(s.names)
+	for i := range rqItems {
+		rq := &rqItems[i]
+		if _, ok := s.namespaces[rq.Namespace]; !ok {
+			continue
+		}
+		s.quotas = append(s.quotas, *rq)
+		observe(rq.Namespace, rq.ResourceVersion)
+	}
+	s.views = quotaview.ComputeViews(s.names, s.quotas)
+	s.resourceVersion = strconv.FormatUint(maxRV, 10)
+	return s
+}
+
+// watchState is the tenant Namespaces and ResourceQuotas as a watch has
+// seen them: its initial snapshot with the backing watch events applied.
+type watchState struct {
+	namespaces map[string]corev1.Namespace
+	quotas     map[types.NamespacedName]corev1.ResourceQuota
+}
+
+func newWatchState(s *snapshot) *watchState {
+	state := &watchState{
+		namespaces: map[string]corev1.Namespace{},
+		quotas:     map[types.NamespacedName]corev1.ResourceQuota{},
+	}
+	for name, ns := range s.namespaces {
+		state.namespaces[name] = *ns
+	}
+	for _, rq := range s.quotas {
+		state.quotas[types.NamespacedName{Namespace: rq.Namespace, Name: rq.Name}] = rq
+	}
+	return state
+}
+
+// apply records a Namespace or ResourceQuota event, reporting whether it
+// concerns a tenant namespace and is newer than what was seen of the object.
+func (w *watchState) apply(ev watch.Event) bool {
+	switch obj := ev.Object.(type) {
+	case *corev1.Namespace:
+		if !strings.HasPrefix(obj.Name, prefix) {
+			return false
+		}
+		if prev, ok := w.namespaces[obj.Name]; ok && !newer(obj, &prev) {
+			return false
+		}
+		if ev.Type == watch.Deleted {
+			delete(w.namespaces, obj.Name)
+		} else {
+			w.namespaces[obj.Name] = *obj
+		}
+	case *corev1.ResourceQuota:
+		if !strings.HasPrefix(obj.Namespace, prefix) {
+			return false
+		}
+		key := types.NamespacedName{Namespace: obj.Namespace, Name: obj.Name}
+		if prev, ok := w.quotas[key]; ok && !newer(obj, &prev) {
+			return false
+		}
+		if ev.Type == watch.Deleted {
+			delete(w.quotas, key)
+		} else {
+			w.quotas[key] = *obj
+		}
+	default:
+		return false
+	}
+	return true
+}
+
+func (w *watchState) snapshot() *snapshot {
+	nsItems := make([]corev1.Namespace, 0, len(w.namespaces))
+	for _, ns := range w.namespaces {
+		nsItems = append(nsItems, ns)
+	}
+	rqItems := make([]corev1.ResourceQuota, 0, len(w.quotas))
+	for _, rq := range w.quotas {
+		rqItems = append(rqItems, rq)
+	}
+	return newSnapshot(nsItems, rqItems)
+}
+
+// newer reports whether obj is a later version than prev. Events replayed
+// by a watch resuming at an older version than the snapshot are not.
+func newer(obj, prev metav1.Object) bool {
+	cur, err := strconv.ParseUint(obj.GetResourceVersion(), 10, 64)
+	if err != nil {
+		return true
+	}
+	old, err := strconv.ParseUint(prev.GetResourceVersion(), 10, 64)
+	return err != nil || cur > old
+}
+
+// object renders the TenantQuota of a namespace of the snapshot. Its
+// resourceVersion is the highest of the objects it is computed from.
+func (s *snapshot) object(name string) *corev1alpha1.TenantQuota {
+	ns := s.namespaces[name]
+	v := s.views[name]
+	rv := s.ownRV[name]
+	for _, d := range v.Descendants {
+		if s.ownRV[d] > rv {
+			rv = s.ownRV[d]
+		}
+	}
+	var borrowing *corev1alpha1.TenantQuotaBorrowing
+	if b := v.Borrowing; b != nil {
+		borrowing = &corev1alpha1.TenantQuotaBorrowing{
+			Limit:     b.Limit,
+			Allowance: nonEmpty(b.Allowance),
+			Borrowed:  nonEmpty(b.Borrowed),
+			Lent:      nonEmpty(b.Lent),
+		}
+	}
+	return &corev1alpha1.TenantQuota{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: corev1alpha1.SchemeGroupVersion.String(),
+			Kind:       "TenantQuota",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              ns.Name,
+			UID:               ns.UID,
+			ResourceVersion:   strconv.FormatUint(rv, 10),
+			CreationTimestamp: ns.CreationTimestamp,
+			Labels:            ns.Labels,
+			Annotations:       ns.Annotations,
+		},
+		Status: corev1alpha1.TenantQuotaStatus{
+			Parent:        v.Parent,
+			Pool:          v.Pool,
+			Declared:      v.Declared,
+			CarvedOut:     nonEmpty(v.CarvedOut),
+			Overcommitted: nonEmpty(v.Overcommitted),
+			Allocated:     nonEmpty(v.Allocated),
+			Used:          v.Used,
+			Headroom:      v.Headroom,
+			Descendants: corev1alpha1.TenantQuotaDescendants{
+				Tenants: int32(len(v.Descendants)),
+				Used:    v.DescendantsUsed,
+			},
+			Borrowing: borrowing,
+		},
+	}
+}
+
+func nonEmpty(rl corev1.ResourceList) corev1.ResourceList {
+	if len(rl) == 0 {
+		return nil
+	}
+	return rl
+}
+
+// matches applies the label selector, on the namespace's labels, and the
+// metadata.name field selector.
+func matches(obj *corev1alpha1.TenantQuota, opts *metainternal.ListOptions) bool {
+	if opts == nil {
+		return true
+	}
+	if opts.LabelSelector != nil && !opts.LabelSelector.Matches(labels.Set(obj.Labels)) {
+		return false
+	}
+	if opts.FieldSelector != nil && !opts.FieldSelector.Matches(fields.Set{"metadata.name": obj.Name}) {
+		return false
+	}
+	return true
+}
+
+// changed reports whether a watcher that last saw prev must be sent cur.
+func changed(prev, cur *corev1alpha1.TenantQuota) bool {
+	return !equality.Semantic.DeepEqual(prev.Status, cur.Status) ||
+		!equality.Semantic.DeepEqual(prev.Labels, cur.Labels) ||
+		!equality.Semantic.DeepEqual(prev.Annotations, cur.Annotations)
+}
+
+func resourceVersion(obj *corev1alpha1.TenantQuota) uint64 {
+	rv, _ := strconv.ParseUint(obj.ResourceVersion, 10, 64)
+	return rv
+}
+
+// -----------------------------------------------------------------------------
+// Boiler-plate
+// -----------------------------------------------------------------------------
+
+func (*REST) Destroy() {}
+
+type notAcceptable struct {
+	resource schema.GroupResource
+	message  string
+}
+
+func (e notAcceptable) Error() string { return e.message }
+func (e notAcceptable) Status() metav1.Status {
+	return metav1.Status{
+		Status:  metav1.StatusFailure,
+		Code:    http.StatusNotAcceptable,
+		Reason:  metav1.StatusReason("NotAcceptable"),
+		Message: e.message,
+	}
+}
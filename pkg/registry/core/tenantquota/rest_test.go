@@ -0,0 +1,235 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package tenantquota
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metainternal "k8s.io/apimachinery/pkg/apis/meta/internalversion"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/apiserver/pkg/endpoints/request"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	corev1alpha1 "github.com/cozystack/cozystack/pkg/apis/core/v1alpha1"
+)
+
+func namespace(name string) *corev1.Namespace {
+	return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}}
+}
+
+func quota(ns, name, hard, used string) *corev1.ResourceQuota {
+	return &corev1.ResourceQuota{
+		ObjectMeta: metav1.ObjectMeta{Namespace: ns, Name: name},
+		Spec:       corev1.ResourceQuotaSpec{Hard: corev1.ResourceList{corev1.ResourceLimitsCPU: resource.MustParse(hard)}},
+		Status: corev1.ResourceQuotaStatus{
+			Hard: corev1.ResourceList{corev1.ResourceLimitsCPU: resource.MustParse(hard)},
+			Used: corev1.ResourceList{corev1.ResourceLimitsCPU: resource.MustParse(used)},
+		},
+	}
+}
+
+func userRoleBinding(namespace, username string) *rbacv1.RoleBinding {
+	return &rbacv1.RoleBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-binding", Namespace: namespace},
+		Subjects: []rbacv1.Subject{
+			{Kind: "User", Name: username, APIGroup: "rbac.authorization.k8s.io"},
+		},
+		RoleRef: rbacv1.RoleRef{APIGroup: "rbac.authorization.k8s.io", Kind: "Role", Name: "test-role"},
+	}
+}
+
+// newTestREST serves a tenant-foo with a budget of 4 CPUs and a child bar
+// drawing from it. The user "alice" can access tenant-foo only.
+func newTestREST(t *testing.T, objs ...client.Object) (*REST, client.WithWatch) {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = rbacv1.AddToScheme(scheme)
+	objs = append([]client.Object{
+		namespace("tenant-root"),
+		namespace("tenant-foo"),
+		namespace("tenant-foo-bar"),
+		namespace("kube-system"),
+		quota("tenant-foo", "tenant-quota", "4", "1"),
+		quota("tenant-foo-bar", "tenant-quota-allocated", "3", "2"),
+		userRoleBinding("tenant-foo", "alice"),
+	}, objs...)
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+	return NewREST(c, c), c
+}
+
+// newStaleCacheTestREST serves the objects of newTestREST with a cached
+// client that never sees the changes made through the returned watch client.
+func newStaleCacheTestREST(t *testing.T) (*REST, client.WithWatch) {
+	t.Helper()
+	_, live := newTestREST(t)
+	stale, _ := newTestREST(t)
+	return NewREST(stale.c, live), live
+}
+
+func userContext(name string, groups ...string) context.Context {
+	return request.WithUser(context.Background(), &user.DefaultInfo{Name: name, Groups: groups})
+}
+
+func cpu(rl corev1.ResourceList) string {
+	q := rl[corev1.ResourceLimitsCPU]
+	return q.String()
+}
+
+func TestList_ShowsAccessibleTenants(t *testing.T) {
+	r, _ := newTestREST(t)
+
+	obj, err := r.List(userContext("alice"), &metainternal.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	list := obj.(*corev1alpha1.TenantQuotaList)
+	if len(list.Items) != 1 || list.Items[0].Name != "tenant-foo" {
+		t.Fatalf("items = %+v, want tenant-foo only", list.Items)
+	}
+
+	obj, err = r.List(userContext("admin", "system:masters"), &metainternal.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, item := range obj.(*corev1alpha1.TenantQuotaList).Items {
+		names = append(names, item.Name)
+	}
+	if len(names) != 3 || names[0] != "tenant-foo" || names[1] != "tenant-foo-bar" || names[2] != "tenant-root" {
+		t.Errorf("admin sees %v, want the three tenants sorted", names)
+	}
+}
+
+func TestGet_ReportsQuotaPicture(t *testing.T) {
+	r, _ := newTestREST(t)
+
+	obj, err := r.Get(userContext("alice"), "tenant-foo", &metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	foo := obj.(*corev1alpha1.TenantQuota)
+	s := foo.Status
+	if s.Parent != "tenant-root" || s.Pool != "tenant-foo" {
+		t.Errorf("parent %q pool %q", s.Parent, s.Pool)
+	}
+	if cpu(s.Declared) != "4" || cpu(s.Allocated) != "4" || cpu(s.Used) != "1" || cpu(s.Headroom) != "3" {
+		t.Errorf("status = %+v", s)
+	}
+	if s.Descendants.Tenants != 1 || cpu(s.Descendants.Used) != "2" {
+		t.Errorf("descendants = %+v", s.Descendants)
+	}
+	if foo.ResourceVersion == "" || foo.ResourceVersion == "0" {
+		t.Errorf("resourceVersion = %q", foo.ResourceVersion)
+	}
+}
+
+func TestGet_DeniesInaccessibleTenants(t *testing.T) {
+	r, _ := newTestREST(t)
+
+	if _, err := r.Get(userContext("alice"), "tenant-foo-bar", &metav1.GetOptions{}); !apierrors.IsForbidden(err) {
+		t.Errorf("Get tenant-foo-bar: err = %v, want Forbidden", err)
+	}
+	if _, err := r.Get(userContext("admin", "system:masters"), "kube-system", &metav1.GetOptions{}); !apierrors.IsNotFound(err) {
+		t.Errorf("Get kube-system: err = %v, want NotFound", err)
+	}
+}
+
+func TestWatch_RecomputesOnUsageChange(t *testing.T) {
+	r, c := newStaleCacheTestREST(t)
+	ctx, cancel := context.WithCancel(userContext("admin", "system:masters"))
+	defer cancel()
+
+	w, err := r.Watch(ctx, &metainternal.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Stop()
+
+	next := func() watch.Event {
+		t.Helper()
+		select {
+		case ev := <-w.ResultChan():
+			return ev
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for an event")
+			return watch.Event{}
+		}
+	}
+	for i := 0; i < 3; i++ {
+		if ev := next(); ev.Type != watch.Added {
+			t.Fatalf("initial event %d = %s", i, ev.Type)
+		}
+	}
+
+	// Usage of bar changes: bar and both ancestors rolling it up change.
+	bar := &corev1.ResourceQuota{}
+	if err := c.Get(ctx, client.ObjectKey{Namespace: "tenant-foo-bar", Name: "tenant-quota-allocated"}, bar); err != nil {
+		t.Fatal(err)
+	}
+	bar.Status.Used[corev1.ResourceLimitsCPU] = resource.MustParse("3")
+	if err := c.Update(ctx, bar); err != nil {
+		t.Fatal(err)
+	}
+
+	got := map[string]*corev1alpha1.TenantQuota{}
+	for i := 0; i < 3; i++ {
+		ev := next()
+		if ev.Type != watch.Modified {
+			t.Fatalf("event %d = %s, want MODIFIED", i, ev.Type)
+		}
+		tq := ev.Object.(*corev1alpha1.TenantQuota)
+		got[tq.Name] = tq
+	}
+	if cpu(got["tenant-foo-bar"].Status.Used) != "3" || cpu(got["tenant-foo-bar"].Status.Headroom) != "0" {
+		t.Errorf("bar = %+v", got["tenant-foo-bar"].Status)
+	}
+	if cpu(got["tenant-foo"].Status.Descendants.Used) != "3" || cpu(got["tenant-root"].Status.Descendants.Used) != "4" {
+		t.Errorf("roll-ups: foo %+v, root %+v", got["tenant-foo"].Status.Descendants, got["tenant-root"].Status.Descendants)
+	}
+}
+
+func TestWatch_NewTenantFromEvent(t *testing.T) {
+	r, c := newStaleCacheTestREST(t)
+	ctx, cancel := context.WithCancel(userContext("admin", "system:masters"))
+	defer cancel()
+
+	w, err := r.Watch(ctx, &metainternal.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Stop()
+	for i := 0; i < 3; i++ {
+		<-w.ResultChan()
+	}
+
+	if err := c.Create(ctx, namespace("tenant-foo-baz")); err != nil {
+		t.Fatal(err)
+	}
+	// The ancestors counting one more descendant change too.
+	for {
+		select {
+		case ev := <-w.ResultChan():
+			tq, _ := ev.Object.(*corev1alpha1.TenantQuota)
+			if tq == nil || tq.Name != "tenant-foo-baz" {
+				continue
+			}
+			if ev.Type != watch.Added || tq.Status.Parent != "tenant-foo" {
+				t.Errorf("event = %s %+v, want tenant-foo-baz ADDED under tenant-foo", ev.Type, tq.Status)
+			}
+			return
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for the new tenant")
+		}
+	}
+}
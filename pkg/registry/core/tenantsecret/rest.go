@@ -27,6 +27,7 @@ import (
 	"k8s.io/apiserver/pkg/registry/rest"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	appsv1alpha1 "github.com/cozystack/cozystack/pkg/apis/apps/v1alpha1"
 	corev1alpha1 "github.com/cozystack/cozystack/pkg/apis/core/v1alpha1"
 	"github.com/cozystack/cozystack/pkg/registry"
 	fieldfilter "github.com/cozystack/cozystack/pkg/registry/fields"
@@ -81,7 +82,45 @@ func decodeStringData(d map[string][]byte) map[string]string {
 	return out
 }
 
+// syncAnnotations are written by cozystack-controller alone: tenants writing
+// a TenantSecret cannot set them, they are carried over from the Secret.
+var syncAnnotations = map[string]bool{
+	corev1alpha1.TenantSecretExternalPathAnnotation:  true,
+	corev1alpha1.TenantSecretSyncDirectionAnnotation: true,
+	corev1alpha1.TenantSecretSyncPathAnnotation:      true,
+	corev1alpha1.TenantSecretSyncPhaseAnnotation:     true,
+	corev1alpha1.TenantSecretSyncMessageAnnotation:   true,
+	corev1alpha1.TenantSecretSyncedAtAnnotation:      true,
+}
+
+// syncStatus reads the synchronization of sec with the external secret store
+// from its sync annotations.
+func syncStatus(sec *corev1.Secret) *corev1alpha1.TenantSecretSyncStatus {
+	a := sec.Annotations
+	direction := a[corev1alpha1.TenantSecretSyncDirectionAnnotation]
+	if direction == "" {
+		return nil
+	}
+	out := &corev1alpha1.TenantSecretSyncStatus{
+		Direction: direction,
+		Path:      a[corev1alpha1.TenantSecretSyncPathAnnotation],
+		Phase:     a[corev1alpha1.TenantSecretSyncPhaseAnnotation],
+		Message:   a[corev1alpha1.TenantSecretSyncMessageAnnotation],
+	}
+	if out.Phase == "" {
+		out.Phase = corev1alpha1.TenantSecretSyncPending
+	}
+	if t, err := time.Parse(time.RFC3339, a[corev1alpha1.TenantSecretSyncedAtAnnotation]); err == nil {
+		out.LastSyncTime = &metav1.Time{Time: t}
+	}
+	return out
+}
+
 func secretToTenant(sec *corev1.Secret) *corev1alpha1.TenantSecret {
+	var externalRef *corev1alpha1.TenantSecretExternalRef
+	if path := sec.Annotations[corev1alpha1.TenantSecretExternalPathAnnotation]; path != "" {
+		externalRef = &corev1alpha1.TenantSecretExternalRef{Path: path}
+	}
 	return &corev1alpha1.TenantSecret{
 		TypeMeta: metav1.TypeMeta{
 			APIVersion: corev1alpha1.SchemeGroupVersion.String(),
@@ -96,9 +135,11 @@ func secretToTenant(sec *corev1.Secret) *corev1alpha1.TenantSecret {
 			Labels:            stripInternal(sec.Labels),
 			Annotations:       sec.Annotations,
 		},
-		Type:       string(sec.Type),
-		Data:       sec.Data,
-		StringData: decodeStringData(sec.Data),
+		Type:        string(sec.Type),
+		Data:        sec.Data,
+		StringData:  decodeStringData(sec.Data),
+		ExternalRef: externalRef,
+		Status:      corev1alpha1.TenantSecretStatus{Sync: syncStatus(sec)},
 	}
 }
 
@@ -122,8 +163,16 @@ func tenantToSecret(ts *corev1alpha1.TenantSecret, cur *corev1.Secret) *corev1.S
 		out.Annotations = map[string]string{}
 	}
 	for k, v := range ts.Annotations {
+		if syncAnnotations[k] {
+			continue
+		}
 		out.Annotations[k] = v
 	}
+	if ts.ExternalRef != nil {
+		out.Annotations[corev1alpha1.TenantSecretExternalPathAnnotation] = ts.ExternalRef.Path
+	} else {
+		delete(out.Annotations, corev1alpha1.TenantSecretExternalPathAnnotation)
+	}
 
 	if len(ts.Data) != 0 {
 		out.Data = ts.Data
@@ -134,6 +183,25 @@ func tenantToSecret(ts *corev1alpha1.TenantSecret, cur *corev1.Secret) *corev1.S
 	return &out
 }
 
+// validateExternalRef rejects an ExternalRef without a path, next to data of
+// its own, or on the credentials of an Application, which its chart renders.
+func validateExternalRef(ts *corev1alpha1.TenantSecret, cur *corev1.Secret) error {
+	ref := ts.ExternalRef
+	if ref == nil {
+		return nil
+	}
+	if ref.Path == "" {
+		return apierrors.NewBadRequest("externalRef.path is required")
+	}
+	if cur == nil && (len(ts.Data) != 0 || len(ts.StringData) != 0) {
+		return apierrors.NewBadRequest("a TenantSecret with an externalRef gets its data from the secret store")
+	}
+	if cur != nil && cur.Labels[appsv1alpha1.ApplicationKindLabel] != "" {
+		return apierrors.NewBadRequest("the TenantSecret of an Application cannot have an externalRef")
+	}
+	return nil
+}
+
 func nsFrom(ctx context.Context) (string, error) {
 	ns, ok := request.NamespaceFrom(ctx)
 	if !ok {
@@ -226,6 +294,9 @@ func (r *REST) Create(
 	if !ok {
 		return nil, fmt.Errorf("expected TenantSecret, got %T", obj)
 	}
+	if err := validateExternalRef(in, nil); err != nil {
+		return nil, err
+	}
 
 	sec := tenantToSecret(in, nil)
 	err := r.c.Create(ctx, sec, &client.CreateOptions{Raw: opts})
@@ -363,6 +434,9 @@ func (r *REST) Update(
 		return nil, false, err
 	}
 	in := newObj.(*corev1alpha1.TenantSecret)
+	if err := validateExternalRef(in, cur); err != nil {
+		return nil, false, err
+	}
 
 	newSec := tenantToSecret(in, cur)
 	newSec.Namespace = ns
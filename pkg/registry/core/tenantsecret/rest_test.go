@@ -9,11 +9,13 @@ import (
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metainternal "k8s.io/apimachinery/pkg/apis/meta/internalversion"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/apiserver/pkg/endpoints/request"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -349,3 +351,66 @@ func equalStrings(a, b []string) bool {
 	}
 	return true
 }
+
+func TestCreate_ExternalRef(t *testing.T) {
+	r := newTestREST(t)
+	ctx := request.WithNamespace(context.Background(), testNamespace)
+
+	withData := &corev1alpha1.TenantSecret{
+		ObjectMeta:  metav1.ObjectMeta{Namespace: testNamespace, Name: "with-data"},
+		ExternalRef: &corev1alpha1.TenantSecretExternalRef{Path: "apps/api"},
+		StringData:  map[string]string{"token": "x"},
+	}
+	if _, err := r.Create(ctx, withData, nil, &metav1.CreateOptions{}); !apierrors.IsBadRequest(err) {
+		t.Errorf("expected data next to an externalRef to be rejected, got %v", err)
+	}
+
+	in := &corev1alpha1.TenantSecret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: testNamespace,
+			Name:      "api-token",
+			// Only cozystack-controller reports the sync status.
+			Annotations: map[string]string{corev1alpha1.TenantSecretSyncPhaseAnnotation: corev1alpha1.TenantSecretSyncSynced},
+		},
+		ExternalRef: &corev1alpha1.TenantSecretExternalRef{Path: "apps/api"},
+	}
+	if _, err := r.Create(ctx, in, nil, &metav1.CreateOptions{}); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	sec := &corev1.Secret{}
+	if err := r.c.Get(ctx, types.NamespacedName{Namespace: testNamespace, Name: "api-token"}, sec); err != nil {
+		t.Fatal(err)
+	}
+	if sec.Annotations[corev1alpha1.TenantSecretExternalPathAnnotation] != "apps/api" {
+		t.Errorf("annotations = %v, want the external path", sec.Annotations)
+	}
+	if _, ok := sec.Annotations[corev1alpha1.TenantSecretSyncPhaseAnnotation]; ok {
+		t.Errorf("annotations = %v, want the sync phase dropped", sec.Annotations)
+	}
+}
+
+func TestGet_ReportsSyncStatus(t *testing.T) {
+	sec := makeTenantSecret("api-token", nil)
+	sec.Annotations = map[string]string{
+		corev1alpha1.TenantSecretExternalPathAnnotation:  "apps/api",
+		corev1alpha1.TenantSecretSyncDirectionAnnotation: corev1alpha1.TenantSecretSyncPull,
+		corev1alpha1.TenantSecretSyncPathAnnotation:      "apps/api",
+		corev1alpha1.TenantSecretSyncPhaseAnnotation:     corev1alpha1.TenantSecretSyncSynced,
+		corev1alpha1.TenantSecretSyncedAtAnnotation:      "2026-10-19T12:00:00Z",
+	}
+	r := newTestREST(t, sec)
+
+	obj, err := r.Get(request.WithNamespace(context.Background(), testNamespace), "api-token", &metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	ts := obj.(*corev1alpha1.TenantSecret)
+	if ts.ExternalRef == nil || ts.ExternalRef.Path != "apps/api" {
+		t.Errorf("externalRef = %+v", ts.ExternalRef)
+	}
+	s := ts.Status.Sync
+	if s == nil || s.Direction != corev1alpha1.TenantSecretSyncPull || s.Phase != corev1alpha1.TenantSecretSyncSynced ||
+		s.LastSyncTime == nil || !s.LastSyncTime.Time.Equal(time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)) {
+		t.Errorf("status.sync = %+v", s)
+	}
+}
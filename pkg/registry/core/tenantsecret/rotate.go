@@ -0,0 +1,509 @@
+// SPDX-License-Identifier: Apache-2.0
+// Rotation of TenantSecrets that belong to Applications: new values are
+// written to the Secret, the previous ones kept as versions for a grace
+// period, and the HelmRelease rendering the Secret is forced to reconcile so
+// that its chart or operator applies them.
+
+package tenantsecret
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	helmv2 "github.com/fluxcd/helm-controller/api/v2"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/apiserver/pkg/registry/rest"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/cozystack/cozystack/pkg/apis/apps/v1alpha1"
+	corev1alpha1 "github.com/cozystack/cozystack/pkg/apis/core/v1alpha1"
+)
+
+const (
+	kindRotation = "TenantSecretRotation"
+	kindHistory  = "TenantSecretHistory"
+
+	defaultKeepVersions = 3
+	maxKeepVersions     = 10
+	defaultGracePeriod  = 24 * time.Hour
+	maxGracePeriod      = 30 * 24 * time.Hour
+
+	// generatedLength is the length of generated values. They are
+	// alphanumeric so that charts can embed them in SQL or URLs unquoted.
+	generatedLength = 32
+	generatedChars  = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+
+	maxRotationRequestSize = 64 << 10
+
+	helmReleaseNameAnnotation      = "meta.helm.sh/release-name"
+	helmReleaseNamespaceAnnotation = "meta.helm.sh/release-namespace"
+	fluxRequestedAtAnnotation      = "reconcile.fluxcd.io/requestedAt"
+	fluxForceAtAnnotation          = "reconcile.fluxcd.io/forceAt"
+)
+
+var (
+	_ rest.Connecter                = &RotateREST{}
+	_ rest.StorageMetadata          = &RotateREST{}
+	_ rest.GroupVersionKindProvider = &RotateREST{}
+	_ rest.Getter                   = &VersionsREST{}
+	_ rest.GroupVersionKindProvider = &VersionsREST{}
+)
+
+// RotateREST serves the tenantsecrets/rotate subresource. POST with a
+// TenantSecretRotation rotates the named TenantSecret:
+//
+//   - only TenantSecrets carrying the lineage labels of an Application can
+//     be rotated, the others have no owner to apply new values, and only the
+//     keys its chart lists in the rotatable-keys annotation: it knows which
+//     of its values it applies back, the others are kept;
+//   - the current values are kept as an immutable version Secret named
+//     <name>.v<version>, owned by the TenantSecret so that they go away with
+//     it;
+//   - versions beyond spec.keepVersions are deleted, the others expire
+//     after their grace period;
+//   - the HelmRelease rendering the Secret is forced to reconcile. Charts
+//     that read their credentials back with lookup, such as Postgres, render
+//     the new values and apply them.
+type RotateREST struct {
+	secrets *REST
+	// now returns the current time; time.Now when nil.
+	now func() time.Time
+}
+
+// NewRotateREST returns the rotate subresource storage of secrets.
+func NewRotateREST(secrets *REST) *RotateREST {
+	return &RotateREST{secrets: secrets}
+}
+
+// New returns an empty TenantSecretRotation.
+func (r *RotateREST) New() runtime.Object {
+	return &corev1alpha1.TenantSecretRotation{}
+}
+
+// Destroy releases resources associated with RotateREST.
+func (r *RotateREST) Destroy() {}
+
+// GroupVersionKind reports TenantSecretRotation.
+func (r *RotateREST) GroupVersionKind(schema.GroupVersion) schema.GroupVersionKind {
+	return r.secrets.gvr.GroupVersion().WithKind(kindRotation)
+}
+
+// ProducesMIMETypes reports no MIME types beyond the negotiated ones.
+func (r *RotateREST) ProducesMIMETypes(verb string) []string {
+	return nil
+}
+
+// ProducesObject reports the TenantSecretRotation response body.
+func (r *RotateREST) ProducesObject(verb string) interface{} {
+	return corev1alpha1.TenantSecretRotation{}
+}
+
+// ConnectMethods returns the HTTP methods served by the rotate endpoint.
+func (r *RotateREST) ConnectMethods() []string {
+	return []string{http.MethodPost}
+}
+
+// NewConnectOptions returns no options object.
+func (r *RotateREST) NewConnectOptions() (runtime.Object, bool, string) {
+	return nil, false, ""
+}
+
+// Connect returns a handler rotating the named TenantSecret.
+func (r *RotateREST) Connect(ctx context.Context, name string, _ runtime.Object, responder rest.Responder) (http.Handler, error) {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		in := &corev1alpha1.TenantSecretRotation{}
+		dec := utilyaml.NewYAMLOrJSONDecoder(io.LimitReader(req.Body, maxRotationRequestSize), 4096)
+		if err := dec.Decode(in); err != nil && err != io.EOF {
+			responder.Error(apierrors.NewBadRequest(fmt.Sprintf("decoding TenantSecretRotation: %v", err)))
+			return
+		}
+		out, err := r.rotate(ctx, name, &in.Spec)
+		if err != nil {
+			responder.Error(err)
+			return
+		}
+		responder.Object(http.StatusOK, out)
+	}), nil
+}
+
+// rotate rotates the TenantSecret name as spec asks.
+func (r *RotateREST) rotate(ctx context.Context, name string, spec *corev1alpha1.TenantSecretRotationSpec) (*corev1alpha1.TenantSecretRotation, error) {
+	gr := r.secrets.gvr.GroupResource()
+	ns, err := nsFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+	keep, grace, err := rotationLimits(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	sec := &corev1.Secret{}
+	if err := r.secrets.c.Get(ctx, types.NamespacedName{Namespace: ns, Name: name}, sec); err != nil {
+		return nil, err
+	}
+	if sec.Labels[tsLabelKey] != tsLabelValue {
+		return nil, apierrors.NewNotFound(gr, name)
+	}
+	if sec.Labels[appsv1alpha1.ApplicationKindLabel] == "" || sec.Labels[appsv1alpha1.ApplicationNameLabel] == "" {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("TenantSecret %s does not belong to an Application, nothing would apply new values", name))
+	}
+	keys, err := rotatedKeys(sec, spec.Keys)
+	if err != nil {
+		return nil, err
+	}
+	versions, err := listVersions(ctx, r.secrets.c, ns, name)
+	if err != nil {
+		return nil, err
+	}
+	version := secretVersion(sec)
+	if len(versions) > 0 {
+		// The annotation may have been dropped, e.g. by a Secret recreated
+		// by its chart: never reuse the number of a kept version.
+		if newest := secretVersion(&versions[0]); newest >= version {
+			version = newest + 1
+		}
+	}
+	if keep > 0 {
+		if msgs := validation.IsDNS1123Subdomain(versionSecretName(name, version)); len(msgs) > 0 {
+			return nil, apierrors.NewBadRequest(fmt.Sprintf("TenantSecret %s is too long a name to keep versions of", name))
+		}
+	}
+
+	data := make(map[string][]byte, len(sec.Data))
+	for k, v := range sec.Data {
+		data[k] = v
+	}
+	for _, k := range keys {
+		value, err := generateValue()
+		if err != nil {
+			return nil, err
+		}
+		data[k] = []byte(value)
+	}
+
+	now := r.clock().UTC().Truncate(time.Second)
+	var previous *corev1.Secret
+	if keep > 0 {
+		previous = versionSecret(sec, version, now, now.Add(grace))
+		if err := r.secrets.c.Create(ctx, previous); err != nil {
+			if apierrors.IsAlreadyExists(err) {
+				return nil, apierrors.NewConflict(gr, name, fmt.Errorf("version %d is being rotated already", version))
+			}
+			return nil, err
+		}
+	}
+
+	rotated := sec.DeepCopy()
+	rotated.Data = data
+	rotated.StringData = nil
+	if rotated.Annotations == nil {
+		rotated.Annotations = map[string]string{}
+	}
+	rotated.Annotations[corev1alpha1.TenantSecretVersionAnnotation] = strconv.FormatInt(version+1, 10)
+	rotated.Annotations[corev1alpha1.TenantSecretRotatedAtAnnotation] = now.Format(time.RFC3339)
+	// The resourceVersion read above guards against concurrent rotations
+	// and writes: the loser gets a Conflict and its version is dropped.
+	if err := r.secrets.c.Update(ctx, rotated); err != nil {
+		if previous != nil {
+			if delErr := r.secrets.c.Delete(ctx, previous); delErr != nil && !apierrors.IsNotFound(delErr) {
+				klog.Errorf("tenantsecret %s/%s: deleting version %d after a failed rotation: %v", ns, name, version, delErr)
+			}
+		}
+		return nil, err
+	}
+	if err := r.pruneVersions(ctx, ns, name, keep, now); err != nil {
+		klog.Errorf("tenantsecret %s/%s: pruning versions: %v", ns, name, err)
+	}
+
+	out := &corev1alpha1.TenantSecretRotation{
+		TypeMeta:   metav1.TypeMeta{APIVersion: corev1alpha1.SchemeGroupVersion.String(), Kind: kindRotation},
+		ObjectMeta: metav1.ObjectMeta{Namespace: ns, Name: name},
+		Spec:       *spec,
+		Status: corev1alpha1.TenantSecretRotationStatus{
+			Version:   version + 1,
+			RotatedAt: metav1.NewTime(now),
+			Keys:      keys,
+		},
+	}
+	release, err := r.reconcileRelease(ctx, rotated, now)
+	if err != nil {
+		// The new values are written: report the rotation, the next
+		// reconciliation of the release applies them anyway.
+		klog.Errorf("tenantsecret %s/%s: requesting reconciliation of the HelmRelease: %v", ns, name, err)
+	}
+	out.Status.HelmRelease = release
+	return out, nil
+}
+
+func (r *RotateREST) clock() time.Time {
+	if r.now != nil {
+		return r.now()
+	}
+	return time.Now()
+}
+
+// pruneVersions deletes the versions of name beyond the keep newest ones and
+// those expired.
+func (r *RotateREST) pruneVersions(ctx context.Context, ns, name string, keep int, now time.Time) error {
+	versions, err := listVersions(ctx, r.secrets.c, ns, name)
+	if err != nil {
+		return err
+	}
+	for i := range versions {
+		v := &versions[i]
+		if i < keep && !versionExpired(v, now) {
+			continue
+		}
+		if err := r.secrets.c.Delete(ctx, v); err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// reconcileRelease forces the HelmRelease that rendered sec to reconcile and
+// returns its name, or "" if there is none or it is suspended. Both
+// annotations are needed: requestedAt alone is a no-op for a release whose
+// chart and values are unchanged.
+func (r *RotateREST) reconcileRelease(ctx context.Context, sec *corev1.Secret, now time.Time) (string, error) {
+	name := sec.Annotations[helmReleaseNameAnnotation]
+	if name == "" {
+		return "", nil
+	}
+	ns := sec.Annotations[helmReleaseNamespaceAnnotation]
+	if ns == "" {
+		ns = sec.Namespace
+	}
+	hr := &helmv2.HelmRelease{}
+	if err := r.secrets.c.Get(ctx, types.NamespacedName{Namespace: ns, Name: name}, hr); err != nil {
+		if apierrors.IsNotFound(err) {
+			return "", nil
+		}
+		return "", err
+	}
+	if hr.Spec.Suspend {
+		return "", nil
+	}
+	stamp := now.Format(time.RFC3339Nano)
+	patch := fmt.Sprintf(`{"metadata":{"annotations":{%q:%q,%q:%q}}}`,
+		fluxForceAtAnnotation, stamp, fluxRequestedAtAnnotation, stamp)
+	if err := r.secrets.c.Patch(ctx, hr, client.RawPatch(types.MergePatchType, []byte(patch))); err != nil {
+		return "", err
+	}
+	return ns + "/" + name, nil
+}
+
+// rotationLimits returns how many versions to keep and for how long, as
+// requested or by default.
+func rotationLimits(spec *corev1alpha1.TenantSecretRotationSpec) (int, time.Duration, error) {
+	keep, grace := defaultKeepVersions, defaultGracePeriod
+	if spec.KeepVersions != nil {
+		keep = int(*spec.KeepVersions)
+		if keep < 0 || keep > maxKeepVersions {
+			return 0, 0, apierrors.NewBadRequest(fmt.Sprintf("keepVersions must be between 0 and %d", maxKeepVersions))
+		}
+	}
+	if spec.GracePeriod != nil {
+		grace = spec.GracePeriod.Duration
+		if grace <= 0 || grace > maxGracePeriod {
+			return 0, 0, apierrors.NewBadRequest(fmt.Sprintf("gracePeriod must be positive and at most %s", maxGracePeriod))
+		}
+	}
+	return keep, grace, nil
+}
+
+// rotatedKeys returns the sorted keys of sec to rotate: requested, which must
+// exist and be rotatable, or all the rotatable ones.
+func rotatedKeys(sec *corev1.Secret, requested []string) ([]string, error) {
+	rotatable := map[string]bool{}
+	for _, k := range strings.Split(sec.Annotations[corev1alpha1.TenantSecretRotatableKeysAnnotation], ",") {
+		if k = strings.TrimSpace(k); k != "" {
+			rotatable[k] = true
+		}
+	}
+	if len(rotatable) == 0 {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("TenantSecret %s is not rotatable: its application lists no keys it applies new values of", sec.Name))
+	}
+	var keys []string
+	if len(requested) == 0 {
+		for k := range sec.Data {
+			if rotatable[k] {
+				keys = append(keys, k)
+			}
+		}
+	} else {
+		seen := map[string]bool{}
+		for _, k := range requested {
+			if _, ok := sec.Data[k]; !ok {
+				return nil, apierrors.NewBadRequest(fmt.Sprintf("TenantSecret %s has no key %q", sec.Name, k))
+			}
+			if !rotatable[k] {
+				return nil, apierrors.NewBadRequest(fmt.Sprintf("key %q of TenantSecret %s is not rotatable", k, sec.Name))
+			}
+			if !seen[k] {
+				seen[k] = true
+				keys = append(keys, k)
+			}
+		}
+	}
+	if len(keys) == 0 {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("TenantSecret %s has no keys to rotate", sec.Name))
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
+
+// secretVersion returns the version of the values of sec, 1 if it was never
+// rotated.
+func secretVersion(sec *corev1.Secret) int64 {
+	v, err := strconv.ParseInt(sec.Annotations[corev1alpha1.TenantSecretVersionAnnotation], 10, 64)
+	if err != nil || v < 1 {
+		return 1
+	}
+	return v
+}
+
+func versionSecretName(name string, version int64) string {
+	return fmt.Sprintf("%s.v%d", name, version)
+}
+
+// versionSecret returns the Secret keeping the current values of sec as
+// version, replaced at rotatedAt and deleted at expiresAt.
+func versionSecret(sec *corev1.Secret, version int64, rotatedAt, expiresAt time.Time) *corev1.Secret {
+	immutable := true
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: sec.Namespace,
+			Name:      versionSecretName(sec.Name, version),
+			Labels:    map[string]string{corev1alpha1.TenantSecretVersionOfLabel: sec.Name},
+			// Owned by the TenantSecret, versions are garbage collected
+			// with it.
+			OwnerReferences: []metav1.OwnerReference{{APIVersion: "v1", Kind: "Secret", Name: sec.Name, UID: sec.UID}},
+			Annotations: map[string]string{
+				corev1alpha1.TenantSecretVersionAnnotation:   strconv.FormatInt(version, 10),
+				corev1alpha1.TenantSecretRotatedAtAnnotation: rotatedAt.Format(time.RFC3339),
+				corev1alpha1.TenantSecretExpiresAtAnnotation: expiresAt.Format(time.RFC3339),
+			},
+		},
+		Type:      sec.Type,
+		Data:      sec.Data,
+		Immutable: &immutable,
+	}
+}
+
+// listVersions returns the version Secrets of name, newest first.
+func listVersions(ctx context.Context, c client.Reader, ns, name string) ([]corev1.Secret, error) {
+	list := &corev1.SecretList{}
+	if err := c.List(ctx, list, client.InNamespace(ns), client.MatchingLabels{corev1alpha1.TenantSecretVersionOfLabel: name}); err != nil {
+		return nil, err
+	}
+	sort.Slice(list.Items, func(i, j int) bool {
+		return secretVersion(&list.Items[i]) > secretVersion(&list.Items[j])
+	})
+	return list.Items, nil
+}
+
+func versionExpired(sec *corev1.Secret, now time.Time) bool {
+	expiresAt, err := time.Parse(time.RFC3339, sec.Annotations[corev1alpha1.TenantSecretExpiresAtAnnotation])
+	return err == nil && !now.Before(expiresAt)
+}
+
+// generateValue returns a random alphanumeric value.
+func generateValue() (string, error) {
+	out := make([]byte, generatedLength)
+	max := big.NewInt(int64(len(generatedChars)))
+	for i := range out {
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", fmt.Errorf("generating a value: %w", err)
+		}
+		out[i] = generatedChars[n.Int64()]
+	}
+	return string(out), nil
+}
+
+// VersionsREST serves the tenantsecrets/versions subresource: the previous
+// versions of a TenantSecret that have not expired.
+type VersionsREST struct {
+	secrets *REST
+	// now returns the current time; time.Now when nil.
+	now func() time.Time
+}
+
+// NewVersionsREST returns the versions subresource storage of secrets.
+func NewVersionsREST(secrets *REST) *VersionsREST {
+	return &VersionsREST{secrets: secrets}
+}
+
+// New returns an empty TenantSecretHistory.
+func (r *VersionsREST) New() runtime.Object {
+	return &corev1alpha1.TenantSecretHistory{}
+}
+
+// Destroy releases resources associated with VersionsREST.
+func (r *VersionsREST) Destroy() {}
+
+// GroupVersionKind reports TenantSecretHistory.
+func (r *VersionsREST) GroupVersionKind(schema.GroupVersion) schema.GroupVersionKind {
+	return r.secrets.gvr.GroupVersion().WithKind(kindHistory)
+}
+
+// Get returns the history of the TenantSecret name.
+func (r *VersionsREST) Get(ctx context.Context, name string, _ *metav1.GetOptions) (runtime.Object, error) {
+	// Reading the TenantSecret checks it exists and is a tenant one.
+	obj, err := r.secrets.Get(ctx, name, &metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	ts := obj.(*corev1alpha1.TenantSecret)
+	versions, err := listVersions(ctx, r.secrets.c, ts.Namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	if r.now != nil {
+		now = r.now()
+	}
+	out := &corev1alpha1.TenantSecretHistory{
+		TypeMeta:   metav1.TypeMeta{APIVersion: corev1alpha1.SchemeGroupVersion.String(), Kind: kindHistory},
+		ObjectMeta: metav1.ObjectMeta{Namespace: ts.Namespace, Name: name, UID: ts.UID, ResourceVersion: ts.ResourceVersion},
+	}
+	for i := range versions {
+		v := &versions[i]
+		if versionExpired(v, now) {
+			continue
+		}
+		out.Versions = append(out.Versions, corev1alpha1.TenantSecretVersion{
+			Version:   secretVersion(v),
+			RotatedAt: annotationTime(v, corev1alpha1.TenantSecretRotatedAtAnnotation),
+			ExpiresAt: annotationTime(v, corev1alpha1.TenantSecretExpiresAtAnnotation),
+			Data:      v.Data,
+		})
+	}
+	return out, nil
+}
+
+func annotationTime(sec *corev1.Secret, key string) metav1.Time {
+	t, err := time.Parse(time.RFC3339, sec.Annotations[key])
+	if err != nil {
+		return metav1.Time{}
+	}
+	return metav1.NewTime(t)
+}
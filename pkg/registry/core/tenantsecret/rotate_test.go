@@ -0,0 +1,213 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package tenantsecret
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	helmv2 "github.com/fluxcd/helm-controller/api/v2"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apiserver/pkg/endpoints/request"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	appsv1alpha1 "github.com/cozystack/cozystack/pkg/apis/apps/v1alpha1"
+	corev1alpha1 "github.com/cozystack/cozystack/pkg/apis/core/v1alpha1"
+)
+
+// newRotateTestREST serves the given objects through the rotate and versions
+// subresources, at the time *now.
+func newRotateTestREST(t *testing.T, now *time.Time, objs ...client.Object) (*RotateREST, *VersionsREST) {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	if err := corev1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	if err := helmv2.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+	secrets := NewREST(c, c)
+	clock := func() time.Time { return *now }
+	return &RotateREST{secrets: secrets, now: clock}, &VersionsREST{secrets: secrets, now: clock}
+}
+
+// credentials is the Secret of the users of a Postgres, rendered by its
+// HelmRelease, which applies new passwords of both users.
+func credentials() *corev1.Secret {
+	sec := makeTenantSecret("postgres-db-credentials", map[string]string{
+		appsv1alpha1.ApplicationKindLabel: "Postgres",
+		appsv1alpha1.ApplicationNameLabel: "db",
+	})
+	sec.UID = "credentials-uid"
+	sec.Annotations = map[string]string{
+		helmReleaseNameAnnotation:                        "postgres-db",
+		helmReleaseNamespaceAnnotation:                   testNamespace,
+		corev1alpha1.TenantSecretRotatableKeysAnnotation: "alice,bob",
+	}
+	sec.Data = map[string][]byte{"alice": []byte("alice-v1"), "bob": []byte("bob-v1")}
+	return sec
+}
+
+func rotateSecret(t *testing.T, r *RotateREST, spec corev1alpha1.TenantSecretRotationSpec) *corev1alpha1.TenantSecretRotation {
+	t.Helper()
+	out, err := r.rotate(request.WithNamespace(context.Background(), testNamespace), "postgres-db-credentials", &spec)
+	if err != nil {
+		t.Fatalf("rotate: %v", err)
+	}
+	return out
+}
+
+func TestRotate_ReplacesValuesAndKeepsVersion(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	hr := &helmv2.HelmRelease{ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: "postgres-db"}}
+	r, versions := newRotateTestREST(t, &now, credentials(), hr)
+
+	out := rotateSecret(t, r, corev1alpha1.TenantSecretRotationSpec{Keys: []string{"alice"}})
+	if out.Status.Version != 2 || len(out.Status.Keys) != 1 || out.Status.Keys[0] != "alice" {
+		t.Errorf("status = %+v, want version 2 of alice", out.Status)
+	}
+	if out.Status.HelmRelease != testNamespace+"/postgres-db" {
+		t.Errorf("helmRelease = %q", out.Status.HelmRelease)
+	}
+
+	sec := &corev1.Secret{}
+	if err := r.secrets.c.Get(context.Background(), types.NamespacedName{Namespace: testNamespace, Name: "postgres-db-credentials"}, sec); err != nil {
+		t.Fatal(err)
+	}
+	if got := string(sec.Data["alice"]); got == "alice-v1" || len(got) != generatedLength {
+		t.Errorf("alice = %q, want a new generated value", got)
+	}
+	if got := string(sec.Data["bob"]); got != "bob-v1" {
+		t.Errorf("bob = %q, want it kept", got)
+	}
+	if sec.Annotations[corev1alpha1.TenantSecretVersionAnnotation] != "2" ||
+		sec.Annotations[corev1alpha1.TenantSecretRotatedAtAnnotation] != "2026-10-19T12:00:00Z" {
+		t.Errorf("annotations = %v", sec.Annotations)
+	}
+
+	if err := r.secrets.c.Get(context.Background(), types.NamespacedName{Namespace: testNamespace, Name: "postgres-db"}, hr); err != nil {
+		t.Fatal(err)
+	}
+	if hr.Annotations[fluxForceAtAnnotation] == "" || hr.Annotations[fluxRequestedAtAnnotation] == "" {
+		t.Errorf("HelmRelease annotations = %v, want a forced reconciliation", hr.Annotations)
+	}
+
+	obj, err := versions.Get(request.WithNamespace(context.Background(), testNamespace), "postgres-db-credentials", &metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("versions: %v", err)
+	}
+	history := obj.(*corev1alpha1.TenantSecretHistory)
+	if len(history.Versions) != 1 {
+		t.Fatalf("versions = %+v, want one", history.Versions)
+	}
+	v := history.Versions[0]
+	if v.Version != 1 || string(v.Data["alice"]) != "alice-v1" || !v.ExpiresAt.Time.Equal(now.Add(defaultGracePeriod)) {
+		t.Errorf("version = %+v, want version 1 expiring after the default grace period", v)
+	}
+}
+
+func TestRotate_PrunesVersions(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	r, versions := newRotateTestREST(t, &now, credentials())
+	keep := int32(2)
+	ctx := request.WithNamespace(context.Background(), testNamespace)
+
+	for i := 0; i < 3; i++ {
+		rotateSecret(t, r, corev1alpha1.TenantSecretRotationSpec{KeepVersions: &keep, GracePeriod: &metav1.Duration{Duration: time.Hour}})
+		now = now.Add(20 * time.Minute)
+	}
+	obj, err := versions.Get(ctx, "postgres-db-credentials", &metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := obj.(*corev1alpha1.TenantSecretHistory).Versions; len(got) != 2 || got[0].Version != 3 || got[1].Version != 2 {
+		t.Errorf("versions = %+v, want 3 and 2", got)
+	}
+
+	// Expired versions are hidden at once, and deleted by the next rotation.
+	now = now.Add(time.Hour)
+	obj, err = versions.Get(ctx, "postgres-db-credentials", &metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := obj.(*corev1alpha1.TenantSecretHistory).Versions; len(got) != 0 {
+		t.Errorf("versions = %+v, want the expired ones hidden", got)
+	}
+	rotateSecret(t, r, corev1alpha1.TenantSecretRotationSpec{KeepVersions: &keep})
+	left := &corev1.SecretList{}
+	if err := r.secrets.c.List(context.Background(), left, client.HasLabels{corev1alpha1.TenantSecretVersionOfLabel}); err != nil {
+		t.Fatal(err)
+	}
+	if len(left.Items) != 1 || left.Items[0].Name != "postgres-db-credentials.v4" {
+		t.Errorf("version Secrets = %v, want only v4", itemSecretNames(left.Items))
+	}
+}
+
+func TestRotate_Rejects(t *testing.T) {
+	now := time.Now()
+	plain := makeTenantSecret("plain", nil)
+	plain.Data = map[string][]byte{"password": []byte("x")}
+	r, _ := newRotateTestREST(t, &now, credentials(), plain)
+	ctx := request.WithNamespace(context.Background(), testNamespace)
+
+	if _, err := r.rotate(ctx, "plain", &corev1alpha1.TenantSecretRotationSpec{}); !apierrors.IsBadRequest(err) {
+		t.Errorf("expected a Secret of no Application to be rejected, got %v", err)
+	}
+	if _, err := r.rotate(ctx, "postgres-db-credentials", &corev1alpha1.TenantSecretRotationSpec{Keys: []string{"carol"}}); !apierrors.IsBadRequest(err) {
+		t.Errorf("expected an unknown key to be rejected, got %v", err)
+	}
+	tooMany := int32(maxKeepVersions + 1)
+	if _, err := r.rotate(ctx, "postgres-db-credentials", &corev1alpha1.TenantSecretRotationSpec{KeepVersions: &tooMany}); !apierrors.IsBadRequest(err) {
+		t.Errorf("expected keepVersions over the maximum to be rejected, got %v", err)
+	}
+	if _, err := r.rotate(ctx, "missing", &corev1alpha1.TenantSecretRotationSpec{}); !apierrors.IsNotFound(err) {
+		t.Errorf("expected NotFound, got %v", err)
+	}
+}
+
+func TestRotate_OnlyRotatableKeys(t *testing.T) {
+	now := time.Now()
+	sec := credentials()
+	// bob has a password set in the spec of the Postgres, which its chart
+	// applies instead of the Secret's.
+	sec.Annotations[corev1alpha1.TenantSecretRotatableKeysAnnotation] = "alice"
+	optedOut := credentials()
+	optedOut.Name = "opted-out"
+	delete(optedOut.Annotations, corev1alpha1.TenantSecretRotatableKeysAnnotation)
+	r, _ := newRotateTestREST(t, &now, sec, optedOut)
+	ctx := request.WithNamespace(context.Background(), testNamespace)
+
+	if _, err := r.rotate(ctx, "opted-out", &corev1alpha1.TenantSecretRotationSpec{}); !apierrors.IsBadRequest(err) {
+		t.Errorf("expected a Secret without rotatable keys to be rejected, got %v", err)
+	}
+	if _, err := r.rotate(ctx, "postgres-db-credentials", &corev1alpha1.TenantSecretRotationSpec{Keys: []string{"alice", "bob"}}); !apierrors.IsBadRequest(err) {
+		t.Errorf("expected a key not listed as rotatable to be rejected, got %v", err)
+	}
+
+	out := rotateSecret(t, r, corev1alpha1.TenantSecretRotationSpec{})
+	if len(out.Status.Keys) != 1 || out.Status.Keys[0] != "alice" {
+		t.Errorf("rotated %v, want only alice", out.Status.Keys)
+	}
+	got := &corev1.Secret{}
+	if err := r.secrets.c.Get(context.Background(), types.NamespacedName{Namespace: testNamespace, Name: "postgres-db-credentials"}, got); err != nil {
+		t.Fatal(err)
+	}
+	if string(got.Data["bob"]) != "bob-v1" {
+		t.Errorf("bob = %q, want it kept", got.Data["bob"])
+	}
+}
+
+func itemSecretNames(items []corev1.Secret) []string {
+	out := make([]string, len(items))
+	for i := range items {
+		out[i] = items[i].Name
+	}
+	return out
+}
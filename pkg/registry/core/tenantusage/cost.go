@@ -0,0 +1,53 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package tenantusage
+
+import (
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/cozystack/cozystack/pkg/config"
+)
+
+const (
+	gib = 1 << 30
+	// hoursPerMonth turns monthly list prices into hourly ones: 365*24/12.
+	hoursPerMonth = 730
+)
+
+// hourlyPrice returns the price of one resource-hour of the Workload resource
+// name, in its own unit (bytes for storage and memory). Resources without a
+// price, including s3-physical-storage-bytes, which would count replicas of
+// the same bytes again, are free.
+func hourlyPrice(p config.Pricing, name string) float64 {
+	var monthly float64
+	switch {
+	case name == string(corev1.ResourceCPU):
+		monthly = p.CPU.AsApproximateFloat64()
+	case name == string(corev1.ResourceMemory):
+		monthly = p.Memory.AsApproximateFloat64() / gib
+	case strings.HasSuffix(name, ".storageclass.storage.k8s.io/requests.storage"),
+		name == "s3-storage-bytes":
+		monthly = p.Storage.AsApproximateFloat64() / gib
+	case strings.HasSuffix(name, ".ipaddresspool.metallb.io/requests.ipaddresses"):
+		monthly = p.LoadBalancer.AsApproximateFloat64()
+	case strings.HasSuffix(name, "/gpu"):
+		monthly = p.GPU.AsApproximateFloat64()
+	}
+	return monthly / hoursPerMonth
+}
+
+// costOf prices usage given in resource-hours.
+func costOf(p config.Pricing, usage corev1.ResourceList) float64 {
+	var cost float64
+	for name, q := range usage {
+		cost += q.AsApproximateFloat64() * hourlyPrice(p, string(name))
+	}
+	return cost
+}
+
+func formatAmount(v float64) string {
+	return strconv.FormatFloat(v, 'f', 2, 64)
+}
@@ -0,0 +1,251 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package tenantusage
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apiserver/pkg/registry/rest"
+	"k8s.io/klog/v2"
+
+	cozyv1alpha1 "github.com/cozystack/cozystack/api/v1alpha1"
+)
+
+var (
+	_ rest.Connecter                = &ExportREST{}
+	_ rest.StorageMetadata          = &ExportREST{}
+	_ rest.GroupVersionKindProvider = &ExportREST{}
+)
+
+// ExportREST serves the tenantusages/export subresource: the hourly usage of
+// a tenant and its sub-tenants, one row per hour, application and resource,
+// for billing systems to import. It is a GET connect endpoint, so access is
+// governed by get on tenantusages/export, then by access to the tenant
+// namespace as for TenantUsages.
+//
+// Supported query parameters:
+//
+//	format  csv or json (the default)
+//	from    start of the period, RFC3339; the start of the month by default
+//	to      end of the period, RFC3339; now by default
+//
+// Hours are included when they start within the period.
+type ExportREST struct {
+	usage *REST
+}
+
+// NewExportREST returns the export subresource storage of usage.
+func NewExportREST(usage *REST) *ExportREST {
+	return &ExportREST{usage: usage}
+}
+
+// New returns a TenantUsage, the object the connect endpoint is registered
+// against.
+func (r *ExportREST) New() runtime.Object {
+	return r.usage.New()
+}
+
+// Destroy releases resources associated with ExportREST.
+func (r *ExportREST) Destroy() {}
+
+// GroupVersionKind reports the kind of the parent TenantUsage.
+func (r *ExportREST) GroupVersionKind(gv schema.GroupVersion) schema.GroupVersionKind {
+	return r.usage.GroupVersionKind(gv)
+}
+
+// ProducesMIMETypes reports the export formats.
+func (r *ExportREST) ProducesMIMETypes(verb string) []string {
+	return []string{"application/json", "text/csv"}
+}
+
+// ProducesObject reports a plain body: the rows are not an API object.
+func (r *ExportREST) ProducesObject(verb string) interface{} {
+	return ""
+}
+
+// ConnectMethods returns the HTTP methods served by the export endpoint.
+func (r *ExportREST) ConnectMethods() []string {
+	return []string{http.MethodGet}
+}
+
+// NewConnectOptions returns no options object: the query parameters are
+// parsed by the handler itself (see parseExportOptions), which keeps them off
+// the core.cozystack.io scheme and its parameter codec.
+func (r *ExportREST) NewConnectOptions() (runtime.Object, bool, string) {
+	return nil, false, ""
+}
+
+// Connect checks access to the tenant and returns a handler writing its rows.
+func (r *ExportREST) Connect(ctx context.Context, name string, _ runtime.Object, responder rest.Responder) (http.Handler, error) {
+	ns, err := r.usage.tenantNamespace(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		opts, err := parseExportOptions(req.URL.Query(), r.usage.now())
+		if err != nil {
+			responder.Error(apierrors.NewBadRequest(err.Error()))
+			return
+		}
+		namespaces, err := r.usage.namespacesOf(req.Context(), ns.Name)
+		if err != nil {
+			responder.Error(err)
+			return
+		}
+		records, err := r.usage.records(req.Context(), namespaces, opts.from, opts.to)
+		if err != nil {
+			responder.Error(err)
+			return
+		}
+		rows := r.rows(ns.Name, records)
+
+		w.Header().Set("X-Content-Type-Options", "nosniff")
+		switch opts.format {
+		case "csv":
+			w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+			w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", name+"-usage.csv"))
+			w.WriteHeader(http.StatusOK)
+			err = writeCSV(w, rows)
+		default:
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			err = json.NewEncoder(w).Encode(rows)
+		}
+		if err != nil {
+			klog.ErrorS(err, "Failed to write tenant usage export", "tenant", name)
+		}
+	}), nil
+}
+
+// exportOptions holds the parsed query of an export request.
+type exportOptions struct {
+	format   string
+	from, to time.Time
+}
+
+// parseExportOptions validates the export query parameters.
+func parseExportOptions(q url.Values, now time.Time) (exportOptions, error) {
+	opts := exportOptions{format: q.Get("format")}
+	opts.from, opts.to = monthToDate(now)
+	switch opts.format {
+	case "":
+		opts.format = "json"
+	case "csv", "json":
+	default:
+		return opts, fmt.Errorf("format must be csv or json, got %q", opts.format)
+	}
+	for _, p := range []struct {
+		name string
+		dst  *time.Time
+	}{
+		{"from", &opts.from},
+		{"to", &opts.to},
+	} {
+		raw := q.Get(p.name)
+		if raw == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			return opts, fmt.Errorf("%s must be an RFC3339 time: %w", p.name, err)
+		}
+		*p.dst = t.UTC()
+	}
+	if !opts.from.Before(opts.to) {
+		return opts, fmt.Errorf("from must be before to")
+	}
+	return opts, nil
+}
+
+// exportRow is the usage of one resource by one application over one hour.
+type exportRow struct {
+	Start     time.Time `json:"start"`
+	End       time.Time `json:"end"`
+	Namespace string    `json:"namespace"`
+	Kind      string    `json:"kind"`
+	Name      string    `json:"name"`
+	Resource  string    `json:"resource"`
+	// Quantity is in resource-hours.
+	Quantity string `json:"quantity"`
+	// Cost has four decimals, so that summing hours does not compound
+	// rounding; it is empty without prices.
+	Cost     string `json:"cost,omitempty"`
+	Currency string `json:"currency,omitempty"`
+}
+
+var csvHeader = []string{"start", "end", "namespace", "kind", "name", "resource", "quantity", "cost", "currency"}
+
+// rows flattens the records covered by tenantNS, keeping their order, with
+// resources sorted by name.
+func (r *ExportREST) rows(tenantNS string, records []cozyv1alpha1.UsageRecord) []exportRow {
+	pricing := r.usage.pricing
+	rows := []exportRow{}
+	for i := range records {
+		record := &records[i]
+		if !covers(tenantNS, record.Namespace) {
+			continue
+		}
+		for _, app := range record.Status.Applications {
+			names := make([]string, 0, len(app.Usage))
+			for name := range app.Usage {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			for _, name := range names {
+				q := app.Usage[name]
+				row := exportRow{
+					Start:     record.Spec.Start.UTC(),
+					End:       record.Spec.End.UTC(),
+					Namespace: record.Namespace,
+					Kind:      app.Kind,
+					Name:      app.Name,
+					Resource:  name,
+					Quantity:  q.String(),
+				}
+				if !pricing.IsZero() {
+					row.Cost = strconv.FormatFloat(q.AsApproximateFloat64()*hourlyPrice(pricing, name), 'f', 4, 64)
+					row.Currency = pricing.Currency
+				}
+				rows = append(rows, row)
+			}
+		}
+	}
+	return rows
+}
+
+func writeCSV(w io.Writer, rows []exportRow) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvHeader); err != nil {
+		return err
+	}
+	for _, row := range rows {
+		if err := cw.Write([]string{
+			row.Start.Format(time.RFC3339),
+			row.End.Format(time.RFC3339),
+			row.Namespace,
+			row.Kind,
+			row.Name,
+			row.Resource,
+			row.Quantity,
+			row.Cost,
+			row.Currency,
+		}); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,475 @@
+// SPDX-License-Identifier: Apache-2.0
+// TenantUsage registry: read-only, month-to-date resource usage of every
+// tenant namespace and its sub-tenants, summed from the hourly UsageRecords the
+// usage accounting controller writes.
+
+package tenantusage
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metainternal "k8s.io/apimachinery/pkg/apis/meta/internalversion"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/duration"
+	"k8s.io/apiserver/pkg/registry/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	cozyv1alpha1 "github.com/cozystack/cozystack/api/v1alpha1"
+	corev1alpha1 "github.com/cozystack/cozystack/pkg/apis/core/v1alpha1"
+	"github.com/cozystack/cozystack/pkg/config"
+	"github.com/cozystack/cozystack/pkg/registry/core/tenantnamespace"
+	"github.com/cozystack/cozystack/pkg/registry/sorting"
+)
+
+const (
+	prefix       = "tenant-"
+	rootTenant   = "tenant-root"
+	singularName = "tenantusage"
+)
+
+// -----------------------------------------------------------------------------
+// REST storage
+// -----------------------------------------------------------------------------
+
+var (
+	_ rest.Lister               = &REST{}
+	_ rest.Getter               = &REST{}
+	_ rest.TableConvertor       = &REST{}
+	_ rest.Scoper               = &REST{}
+	_ rest.SingularNameProvider = &REST{}
+)
+
+// REST serves TenantUsages. A TenantUsage is named after its tenant namespace
+// and visible to the users who can access that namespace, as TenantNamespaces
+// are. UsageRecords are read from the informer cache, by namespace and by
+// month through RecordMonthField.
+type REST struct {
+	c          client.Client
+	w          client.WithWatch
+	namespaces *tenantnamespace.REST
+	pricing    config.Pricing
+	gvr        schema.GroupVersionResource
+	// now returns the current time; overridden in tests.
+	now func() time.Time
+}
+
+func NewREST(
+	c client.Client,
+	w client.WithWatch,
+	pricing config.Pricing,
+) *REST {
+	return &REST{
+		c:          c,
+		w:          w,
+		namespaces: tenantnamespace.NewREST(c, w),
+		pricing:    pricing,
+		gvr: schema.GroupVersionResource{
+			Group:    corev1alpha1.GroupName,
+			Version:  "v1alpha1",
+			Resource: "tenantusages",
+		},
+		now: time.Now,
+	}
+}
+
+// -----------------------------------------------------------------------------
+// Basic meta
+// -----------------------------------------------------------------------------
+
+func (*REST) NamespaceScoped() bool { return false }
+func (*REST) New() runtime.Object   { return &corev1alpha1.TenantUsage{} }
+func (*REST) NewList() runtime.Object {
+	return &corev1alpha1.TenantUsageList{}
+}
+func (*REST) Kind() string { return "TenantUsage" }
+func (r *REST) GroupVersionKind(_ schema.GroupVersion) schema.GroupVersionKind {
+	return r.gvr.GroupVersion().WithKind("TenantUsage")
+}
+func (*REST) GetSingularName() string { return singularName }
+
+// -----------------------------------------------------------------------------
+// Lister / Getter
+// -----------------------------------------------------------------------------
+
+func (r *REST) List(
+	ctx context.Context,
+	opts *metainternal.ListOptions,
+) (runtime.Object, error) {
+	nsList := &corev1.NamespaceList{}
+	if err := r.c.List(ctx, nsList); err != nil {
+		return nil, err
+	}
+	byName := map[string]*corev1.Namespace{}
+	names := make([]string, 0, len(nsList.Items))
+	for i := range nsList.Items {
+		ns := &nsList.Items[i]
+		if strings.HasPrefix(ns.Name, prefix) {
+			byName[ns.Name] = ns
+			names = append(names, ns.Name)
+		}
+	}
+	allowed, err := r.namespaces.FilterAccessible(ctx, names)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &corev1alpha1.TenantUsageList{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: corev1alpha1.SchemeGroupVersion.String(),
+			Kind:       "TenantUsageList",
+		},
+	}
+	if len(allowed) == 0 {
+		return out, nil
+	}
+	start, end := monthToDate(r.now())
+	records, err := r.records(ctx, coveredNamespaces(allowed, names), start, end)
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range allowed {
+		obj := r.object(byName[name], records, start)
+		if !matches(obj, opts) {
+			continue
+		}
+		out.Items = append(out.Items, *obj)
+	}
+	sorting.ByName[corev1alpha1.TenantUsage, *corev1alpha1.TenantUsage](out.Items)
+	return out, nil
+}
+
+func (r *REST) Get(
+	ctx context.Context,
+	name string,
+	_ *metav1.GetOptions,
+) (runtime.Object, error) {
+	ns, err := r.tenantNamespace(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	namespaces, err := r.namespacesOf(ctx, ns.Name)
+	if err != nil {
+		return nil, err
+	}
+	start, end := monthToDate(r.now())
+	records, err := r.records(ctx, namespaces, start, end)
+	if err != nil {
+		return nil, err
+	}
+	return r.object(ns, records, start), nil
+}
+
+// tenantNamespace returns the tenant namespace a TenantUsage is named after,
+// if the requesting user can access it.
+func (r *REST) tenantNamespace(ctx context.Context, name string) (*corev1.Namespace, error) {
+	if !strings.HasPrefix(name, prefix) {
+		return nil, apierrors.NewNotFound(r.gvr.GroupResource(), name)
+	}
+	allowed, err := r.namespaces.FilterAccessible(ctx, []string{name})
+	if err != nil {
+		return nil, err
+	}
+	if len(allowed) == 0 {
+		// Return Forbidden to follow standard K8s RBAC behavior
+		return nil, apierrors.NewForbidden(r.gvr.GroupResource(), name, fmt.Errorf("access denied"))
+	}
+	ns := &corev1.Namespace{}
+	if err := r.c.Get(ctx, types.NamespacedName{Name: name}, ns); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, apierrors.NewNotFound(r.gvr.GroupResource(), name)
+		}
+		return nil, err
+	}
+	return ns, nil
+}
+
+// -----------------------------------------------------------------------------
+// TableConvertor
+// -----------------------------------------------------------------------------
+
+func (r *REST) ConvertToTable(_ context.Context, obj runtime.Object, _ runtime.Object) (*metav1.Table, error) {
+	now := time.Now()
+	row := func(o *corev1alpha1.TenantUsage) metav1.TableRow {
+		cost := o.Status.Cost
+		if cost != "" && o.Status.Currency != "" {
+			cost += " " + o.Status.Currency
+		}
+		return metav1.TableRow{
+			Cells: []interface{}{
+				o.Name,
+				len(o.Status.Applications),
+				resourceSummary(o.Status.Total),
+				cost,
+				duration.HumanDuration(now.Sub(o.CreationTimestamp.Time)),
+			},
+			Object: runtime.RawExtension{Object: o},
+		}
+	}
+
+	tbl := &metav1.Table{
+		TypeMeta: metav1.TypeMeta{APIVersion: "meta.k8s.io/v1", Kind: "Table"},
+		ColumnDefinitions: []metav1.TableColumnDefinition{
+			{Name: "NAME", Type: "string"},
+			{Name: "APPLICATIONS", Type: "integer"},
+			{Name: "USAGE", Type: "string", Description: "CPU and memory used this month, in resource-hours"},
+			{Name: "COST", Type: "string"},
+			{Name: "AGE", Type: "string"},
+		},
+	}
+
+	switch v := obj.(type) {
+	case *corev1alpha1.TenantUsageList:
+		for i := range v.Items {
+			tbl.Rows = append(tbl.Rows, row(&v.Items[i]))
+		}
+		tbl.ResourceVersion = v.ResourceVersion
+	case *corev1alpha1.TenantUsage:
+		tbl.Rows = append(tbl.Rows, row(v))
+		tbl.ResourceVersion = v.ResourceVersion
+	default:
+		return nil, notAcceptable{r.gvr.GroupResource(), fmt.Sprintf("unexpected %T", obj)}
+	}
+	return tbl, nil
+}
+
+// resourceSummary renders the CPU and memory hours of a total, e.g.
+// "cpu=12,memory=48Gi".
+func resourceSummary(total corev1.ResourceList) string {
+	var parts []string
+	for _, name := range []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory} {
+		if q, ok := total[name]; ok {
+			parts = append(parts, fmt.Sprintf("%s=%s", name, q.String()))
+		}
+	}
+	return strings.Join(parts, ",")
+}
+
+// -----------------------------------------------------------------------------
+// Helpers
+// -----------------------------------------------------------------------------
+
+// monthToDate returns the start of the month of now, in UTC, and now.
+func monthToDate(now time.Time) (time.Time, time.Time) {
+	now = now.UTC()
+	return time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC), now
+}
+
+// RecordMonthField indexes UsageRecords by the month their hour starts in,
+// e.g. "2026-10": TenantUsages read the records of a tenant's namespaces
+// through it, month by month, from the informer cache.
+const RecordMonthField = "spec.start.month"
+
+// RecordMonth is the indexer of RecordMonthField.
+func RecordMonth(obj client.Object) []string {
+	record, ok := obj.(*cozyv1alpha1.UsageRecord)
+	if !ok {
+		return nil
+	}
+	return []string{recordMonth(record.Spec.Start.Time)}
+}
+
+func recordMonth(t time.Time) string {
+	return t.UTC().Format("2006-01")
+}
+
+// records returns the UsageRecords of namespaces for the hours starting in
+// [from, to), sorted by start and namespace.
+func (r *REST) records(ctx context.Context, namespaces []string, from, to time.Time) ([]cozyv1alpha1.UsageRecord, error) {
+	var months []string
+	from, to = from.UTC(), to.UTC()
+	for m := time.Date(from.Year(), from.Month(), 1, 0, 0, 0, 0, time.UTC); m.Before(to); m = m.AddDate(0, 1, 0) {
+		months = append(months, recordMonth(m))
+	}
+	var out []cozyv1alpha1.UsageRecord
+	for _, ns := range namespaces {
+		for _, month := range months {
+			list := &cozyv1alpha1.UsageRecordList{}
+			if err := r.c.List(ctx, list, client.InNamespace(ns), client.MatchingFields{RecordMonthField: month}); err != nil {
+				return nil, err
+			}
+			for _, record := range list.Items {
+				if start := record.Spec.Start.Time; !start.Before(from) && start.Before(to) {
+					out = append(out, record)
+				}
+			}
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if !out[i].Spec.Start.Equal(&out[j].Spec.Start) {
+			return out[i].Spec.Start.Before(&out[j].Spec.Start)
+		}
+		return out[i].Namespace < out[j].Namespace
+	})
+	return out, nil
+}
+
+// namespacesOf returns the namespaces of the tenant owning tenantNS and of
+// its sub-tenants.
+func (r *REST) namespacesOf(ctx context.Context, tenantNS string) ([]string, error) {
+	nsList := &corev1.NamespaceList{}
+	if err := r.c.List(ctx, nsList); err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(nsList.Items))
+	for _, ns := range nsList.Items {
+		names = append(names, ns.Name)
+	}
+	return coveredNamespaces([]string{tenantNS}, names), nil
+}
+
+// coveredNamespaces returns the names covered by any of the tenants.
+func coveredNamespaces(tenants, names []string) []string {
+	var out []string
+	for _, name := range names {
+		for _, tenant := range tenants {
+			if covers(tenant, name) {
+				out = append(out, name)
+				break
+			}
+		}
+	}
+	return out
+}
+
+// covers reports whether namespace belongs to the tenant owning tenantNS or
+// to one of its sub-tenants. The hierarchy is encoded in namespace names: a
+// tenant x of namespace P owns P-x, or tenant-x directly under the root.
+func covers(tenantNS, namespace string) bool {
+	if tenantNS == rootTenant {
+		return strings.HasPrefix(namespace, prefix)
+	}
+	return namespace == tenantNS || strings.HasPrefix(namespace, tenantNS+"-")
+}
+
+// object sums the records covered by the tenant of ns into its TenantUsage.
+func (r *REST) object(ns *corev1.Namespace, records []cozyv1alpha1.UsageRecord, start time.Time) *corev1alpha1.TenantUsage {
+	type key struct{ namespace, kind, name string }
+	usage := map[key]corev1.ResourceList{}
+	var end time.Time
+	for i := range records {
+		record := &records[i]
+		if !covers(ns.Name, record.Namespace) {
+			continue
+		}
+		if t := record.Status.LastSampleTime; t != nil && t.After(end) {
+			end = t.Time
+		}
+		for _, app := range record.Status.Applications {
+			k := key{record.Namespace, app.Kind, app.Name}
+			if usage[k] == nil {
+				usage[k] = corev1.ResourceList{}
+			}
+			addUsage(usage[k], app.Usage)
+		}
+	}
+	if end.IsZero() {
+		end = start
+	}
+
+	status := corev1alpha1.TenantUsageStatus{
+		Start: metav1.Time{Time: start},
+		End:   metav1.Time{Time: end},
+		Total: corev1.ResourceList{},
+	}
+	var total float64
+	for k, rl := range usage {
+		app := corev1alpha1.TenantApplicationUsage{Namespace: k.namespace, Kind: k.kind, Name: k.name, Usage: rl}
+		if !r.pricing.IsZero() {
+			cost := costOf(r.pricing, rl)
+			total += cost
+			app.Cost = formatAmount(cost)
+		}
+		status.Applications = append(status.Applications, app)
+		for name, q := range rl {
+			sum := status.Total[name]
+			sum.Add(q)
+			status.Total[name] = sum
+		}
+	}
+	sort.Slice(status.Applications, func(i, j int) bool {
+		a, b := status.Applications[i], status.Applications[j]
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		return a.Name < b.Name
+	})
+	if !r.pricing.IsZero() {
+		status.Currency = r.pricing.Currency
+		status.Cost = formatAmount(total)
+	}
+
+	return &corev1alpha1.TenantUsage{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: corev1alpha1.SchemeGroupVersion.String(),
+			Kind:       "TenantUsage",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              ns.Name,
+			UID:               ns.UID,
+			CreationTimestamp: ns.CreationTimestamp,
+			Labels:            ns.Labels,
+			Annotations:       ns.Annotations,
+		},
+		Status: status,
+	}
+}
+
+func addUsage(dst corev1.ResourceList, src map[string]resource.Quantity) {
+	for name, q := range src {
+		sum := dst[corev1.ResourceName(name)]
+		sum.Add(q)
+		dst[corev1.ResourceName(name)] = sum
+	}
+}
+
+// matches applies the label selector, on the namespace's labels, and the
+// metadata.name field selector.
+func matches(obj *corev1alpha1.TenantUsage, opts *metainternal.ListOptions) bool {
+	if opts == nil {
+		return true
+	}
+	if opts.LabelSelector != nil && !opts.LabelSelector.Matches(labels.Set(obj.Labels)) {
+		return false
+	}
+	if opts.FieldSelector != nil && !opts.FieldSelector.Matches(fields.Set{"metadata.name": obj.Name}) {
+		return false
+	}
+	return true
+}
+
+// -----------------------------------------------------------------------------
+// Boiler-plate
+// -----------------------------------------------------------------------------
+
+func (*REST) Destroy() {}
+
+type notAcceptable struct {
+	resource schema.GroupResource
+	message  string
+}
+
+func (e notAcceptable) Error() string { return e.message }
+func (e notAcceptable) Status() metav1.Status {
+	return metav1.Status{
+		Status:  metav1.StatusFailure,
+		Code:    http.StatusNotAcceptable,
+		Reason:  metav1.StatusReason("NotAcceptable"),
+		Message: e.message,
+	}
+}
@@ -0,0 +1,195 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package tenantusage
+
+import (
+	"context"
+	"encoding/csv"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/apiserver/pkg/endpoints/request"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	cozyv1alpha1 "github.com/cozystack/cozystack/api/v1alpha1"
+	corev1alpha1 "github.com/cozystack/cozystack/pkg/apis/core/v1alpha1"
+	"github.com/cozystack/cozystack/pkg/config"
+)
+
+var testNow = time.Date(2026, 10, 19, 14, 30, 0, 0, time.UTC)
+
+func namespace(name string) *corev1.Namespace {
+	return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}}
+}
+
+func userRoleBinding(namespace, username string) *rbacv1.RoleBinding {
+	return &rbacv1.RoleBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-binding", Namespace: namespace},
+		Subjects: []rbacv1.Subject{
+			{Kind: "User", Name: username, APIGroup: "rbac.authorization.k8s.io"},
+		},
+		RoleRef: rbacv1.RoleRef{APIGroup: "rbac.authorization.k8s.io", Kind: "Role", Name: "test-role"},
+	}
+}
+
+// record is an hour of usage of one application, in cpu-hours.
+func record(ns string, start time.Time, kind, name, cpu string) *cozyv1alpha1.UsageRecord {
+	sampled := metav1.NewTime(start.Add(55 * time.Minute))
+	return &cozyv1alpha1.UsageRecord{
+		ObjectMeta: metav1.ObjectMeta{Namespace: ns, Name: "usage-" + start.Format("2006010215")},
+		Spec: cozyv1alpha1.UsageRecordSpec{
+			Start: metav1.Time{Time: start},
+			End:   metav1.Time{Time: start.Add(time.Hour)},
+		},
+		Status: cozyv1alpha1.UsageRecordStatus{
+			SampledSeconds: 3600,
+			LastSampleTime: &sampled,
+			Applications: []cozyv1alpha1.ApplicationUsage{{
+				Kind:  kind,
+				Name:  name,
+				Usage: map[string]resource.Quantity{"cpu": resource.MustParse(cpu)},
+			}},
+		},
+	}
+}
+
+// newTestREST serves a tenant-foo with a sub-tenant bar and a sibling baz,
+// each with an hour of usage this month; tenant-foo also has one from last
+// month. The user "alice" can access tenant-foo only. A core costs 73 a month,
+// 0.1 an hour.
+func newTestREST(t *testing.T, objs ...client.Object) *REST {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = rbacv1.AddToScheme(scheme)
+	_ = cozyv1alpha1.AddToScheme(scheme)
+	hour := time.Date(2026, 10, 19, 13, 0, 0, 0, time.UTC)
+	objs = append([]client.Object{
+		namespace("tenant-root"),
+		namespace("tenant-foo"),
+		namespace("tenant-foo-bar"),
+		namespace("tenant-baz"),
+		userRoleBinding("tenant-foo", "alice"),
+		record("tenant-foo", hour, "Postgres", "db", "4"),
+		record("tenant-foo", time.Date(2026, 9, 30, 23, 0, 0, 0, time.UTC), "Postgres", "db", "4"),
+		record("tenant-foo-bar", hour, "Redis", "cache", "500m"),
+		record("tenant-baz", hour, "Redis", "cache", "1"),
+	}, objs...)
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(objs...).
+		WithIndex(&cozyv1alpha1.UsageRecord{}, RecordMonthField, RecordMonth).
+		Build()
+	r := NewREST(c, c, config.Pricing{Currency: "USD", CPU: resource.MustParse("73")})
+	r.now = func() time.Time { return testNow }
+	return r
+}
+
+func userContext(name string, groups ...string) context.Context {
+	return request.WithUser(context.Background(), &user.DefaultInfo{Name: name, Groups: groups})
+}
+
+func TestGet_SumsTenantAndSubTenantsMonthToDate(t *testing.T) {
+	r := newTestREST(t)
+	obj, err := r.Get(userContext("alice"), "tenant-foo", &metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	status := obj.(*corev1alpha1.TenantUsage).Status
+	if len(status.Applications) != 2 {
+		t.Fatalf("applications = %+v, want db and the sub-tenant's cache", status.Applications)
+	}
+	if app := status.Applications[1]; app.Namespace != "tenant-foo-bar" || app.Name != "cache" || app.Cost != "0.05" {
+		t.Errorf("sub-tenant application = %+v", app)
+	}
+	total := status.Total[corev1.ResourceCPU]
+	if want := resource.MustParse("4500m"); total.Cmp(want) != 0 {
+		t.Errorf("total cpu = %s, want 4500m: last month must be left out", total.String())
+	}
+	if status.Cost != "0.45" || status.Currency != "USD" {
+		t.Errorf("cost = %s %s, want 0.45 USD", status.Cost, status.Currency)
+	}
+	if want := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC); !status.Start.Time.Equal(want) {
+		t.Errorf("start = %v, want %v", status.Start, want)
+	}
+}
+
+func TestGet_ForbiddenWithoutAccess(t *testing.T) {
+	r := newTestREST(t)
+	_, err := r.Get(userContext("alice"), "tenant-baz", &metav1.GetOptions{})
+	if !apierrors.IsForbidden(err) {
+		t.Fatalf("expected Forbidden, got %v", err)
+	}
+}
+
+func TestList_ShowsAccessibleTenants(t *testing.T) {
+	r := newTestREST(t)
+	obj, err := r.List(userContext("alice"), nil)
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	list := obj.(*corev1alpha1.TenantUsageList)
+	if len(list.Items) != 1 || list.Items[0].Name != "tenant-foo" {
+		t.Fatalf("items = %+v, want tenant-foo only", list.Items)
+	}
+}
+
+func TestExport_CSV(t *testing.T) {
+	r := newTestREST(t)
+	export := NewExportREST(r)
+	handler, err := export.Connect(userContext("alice"), "tenant-foo", nil, nil)
+	if err != nil {
+		t.Fatalf("Connect: %v", err)
+	}
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/?format=csv&from=2026-09-01T00:00:00Z", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", rec.Code, rec.Body.String())
+	}
+	rows, err := csv.NewReader(strings.NewReader(rec.Body.String())).ReadAll()
+	if err != nil {
+		t.Fatalf("parse csv: %v", err)
+	}
+	want := [][]string{
+		csvHeader,
+		{"2026-09-30T23:00:00Z", "2026-10-01T00:00:00Z", "tenant-foo", "Postgres", "db", "cpu", "4", "0.4000", "USD"},
+		{"2026-10-19T13:00:00Z", "2026-10-19T14:00:00Z", "tenant-foo", "Postgres", "db", "cpu", "4", "0.4000", "USD"},
+		{"2026-10-19T13:00:00Z", "2026-10-19T14:00:00Z", "tenant-foo-bar", "Redis", "cache", "cpu", "500m", "0.0500", "USD"},
+	}
+	if len(rows) != len(want) {
+		t.Fatalf("rows = %v, want %v", rows, want)
+	}
+	for i := range want {
+		if strings.Join(rows[i], ",") != strings.Join(want[i], ",") {
+			t.Errorf("row %d = %v, want %v", i, rows[i], want[i])
+		}
+	}
+}
+
+func TestExport_ForbiddenWithoutAccess(t *testing.T) {
+	r := newTestREST(t)
+	_, err := NewExportREST(r).Connect(userContext("alice"), "tenant-baz", nil, nil)
+	if !apierrors.IsForbidden(err) {
+		t.Fatalf("expected Forbidden, got %v", err)
+	}
+}
+
+func TestParseExportOptions(t *testing.T) {
+	for _, query := range []string{"format=xml", "from=yesterday", "from=2026-10-19T00:00:00Z&to=2026-10-18T00:00:00Z"} {
+		q, _ := http.NewRequest(http.MethodGet, "/?"+query, nil)
+		if _, err := parseExportOptions(q.URL.Query(), testNow); err == nil {
+			t.Errorf("%s: expected an error", query)
+		}
+	}
+}
@@ -27,6 +27,31 @@ type CiliumNetworkPolicy struct {
 
 	// Spec is the policy enforced by the CiliumNetworkPolicy.
 	Spec *CiliumNetworkPolicySpec `json:"spec,omitempty"`
+
+	// Status is written by Cilium through the status subresource, so writes of
+	// the storage never change it. It is read for the SecurityGroup's
+	// status.policy.
+	Status *CiliumNetworkPolicyStatus `json:"status,omitempty"`
+}
+
+// CiliumNetworkPolicyStatus is the subset of the cilium.io/v2
+// CiliumNetworkPolicy status the storage reads.
+type CiliumNetworkPolicyStatus struct {
+	// Conditions are the conditions Cilium reports on the policy, among them
+	// Valid, set once the policy is parsed and imported.
+	Conditions []CiliumPolicyCondition `json:"conditions,omitempty"`
+}
+
+// CiliumPolicyCondition mirrors a cilium.io/v2 NetworkPolicyCondition.
+type CiliumPolicyCondition struct {
+	// Type of the condition, e.g. Valid.
+	Type string `json:"type"`
+
+	// Status of the condition, one of True, False or Unknown.
+	Status string `json:"status"`
+
+	// Message is a human-readable explanation of the condition.
+	Message string `json:"message,omitempty"`
 }
 
 // CiliumNetworkPolicySpec is the subset of the cilium.io/v2 CiliumNetworkPolicy
@@ -60,12 +85,20 @@ type CiliumNetworkPolicySpec struct {
 	// CRD anyOf in every case, so egress is emitted only when there are egress
 	// rules.
 	Egress []CiliumEgressRule `json:"egress,omitempty"`
+
+	// IngressDeny is the list of blocked inbound traffic rules. Cilium
+	// evaluates deny rules before allow rules, across every policy selecting
+	// the endpoint.
+	IngressDeny []CiliumIngressDenyRule `json:"ingressDeny,omitempty"`
+
+	// EgressDeny is the list of blocked outbound traffic rules.
+	EgressDeny []CiliumEgressDenyRule `json:"egressDeny,omitempty"`
 }
 
 // CiliumIngressRule mirrors a single cilium.io/v2 ingress rule. fromApp/fromSG
 // peers project into fromEndpoints label selectors; fromCIDR and toPorts carry
 // over 1:1. PortRule/FQDNSelector are reused from the SecurityGroup types
-// because their wire shape already matches Cilium.
+// because their wire shape already matches Cilium, L7 rules included.
 type CiliumIngressRule struct {
 	// FromEndpoints selects allowed source pods by label.
 	FromEndpoints []metav1.LabelSelector `json:"fromEndpoints,omitempty"`
@@ -94,6 +127,31 @@ type CiliumEgressRule struct {
 	ToPorts []sdnv1alpha1.PortRule `json:"toPorts,omitempty"`
 }
 
+// CiliumIngressDenyRule mirrors a single cilium.io/v2 ingressDeny rule. Peers
+// project like those of CiliumIngressRule; toPorts is Cilium's PortDenyRules.
+type CiliumIngressDenyRule struct {
+	// FromEndpoints selects blocked source pods by label.
+	FromEndpoints []metav1.LabelSelector `json:"fromEndpoints,omitempty"`
+
+	// FromCIDR is a list of CIDR ranges blocked as traffic sources.
+	FromCIDR []string `json:"fromCIDR,omitempty"`
+
+	// ToPorts restricts the rule to the listed destination ports.
+	ToPorts []sdnv1alpha1.PortDenyRule `json:"toPorts,omitempty"`
+}
+
+// CiliumEgressDenyRule mirrors a single cilium.io/v2 egressDeny rule.
+type CiliumEgressDenyRule struct {
+	// ToEndpoints selects blocked destination pods by label.
+	ToEndpoints []metav1.LabelSelector `json:"toEndpoints,omitempty"`
+
+	// ToCIDR is a list of CIDR ranges blocked as traffic destinations.
+	ToCIDR []string `json:"toCIDR,omitempty"`
+
+	// ToPorts restricts the rule to the listed destination ports.
+	ToPorts []sdnv1alpha1.PortDenyRule `json:"toPorts,omitempty"`
+}
+
 // CiliumNetworkPolicyList is a list of CiliumNetworkPolicy objects.
 type CiliumNetworkPolicyList struct {
 	metav1.TypeMeta `json:",inline"`
@@ -130,6 +188,11 @@ func (in *CiliumNetworkPolicy) DeepCopyInto(out *CiliumNetworkPolicy) {
 	if in.Spec != nil {
 		out.Spec = in.Spec.DeepCopy()
 	}
+	if in.Status != nil {
+		out.Status = &CiliumNetworkPolicyStatus{
+			Conditions: append([]CiliumPolicyCondition(nil), in.Status.Conditions...),
+		}
+	}
 }
 
 // DeepCopy returns a deep copy of the receiver.
@@ -167,6 +230,18 @@ func (in *CiliumNetworkPolicySpec) DeepCopyInto(out *CiliumNetworkPolicySpec) {
 			in.Egress[i].DeepCopyInto(&out.Egress[i])
 		}
 	}
+	if in.IngressDeny != nil {
+		out.IngressDeny = make([]CiliumIngressDenyRule, len(in.IngressDeny))
+		for i := range in.IngressDeny {
+			in.IngressDeny[i].DeepCopyInto(&out.IngressDeny[i])
+		}
+	}
+	if in.EgressDeny != nil {
+		out.EgressDeny = make([]CiliumEgressDenyRule, len(in.EgressDeny))
+		for i := range in.EgressDeny {
+			in.EgressDeny[i].DeepCopyInto(&out.EgressDeny[i])
+		}
+	}
 }
 
 // DeepCopy returns a deep copy of the receiver.
@@ -225,6 +300,48 @@ func (in *CiliumEgressRule) DeepCopyInto(out *CiliumEgressRule) {
 	}
 }
 
+// DeepCopyInto copies the receiver into out.
+func (in *CiliumIngressDenyRule) DeepCopyInto(out *CiliumIngressDenyRule) {
+	*out = *in
+	if in.FromEndpoints != nil {
+		out.FromEndpoints = make([]metav1.LabelSelector, len(in.FromEndpoints))
+		for i := range in.FromEndpoints {
+			in.FromEndpoints[i].DeepCopyInto(&out.FromEndpoints[i])
+		}
+	}
+	if in.FromCIDR != nil {
+		out.FromCIDR = make([]string, len(in.FromCIDR))
+		copy(out.FromCIDR, in.FromCIDR)
+	}
+	if in.ToPorts != nil {
+		out.ToPorts = make([]sdnv1alpha1.PortDenyRule, len(in.ToPorts))
+		for i := range in.ToPorts {
+			in.ToPorts[i].DeepCopyInto(&out.ToPorts[i])
+		}
+	}
+}
+
+// DeepCopyInto copies the receiver into out.
+func (in *CiliumEgressDenyRule) DeepCopyInto(out *CiliumEgressDenyRule) {
+	*out = *in
+	if in.ToEndpoints != nil {
+		out.ToEndpoints = make([]metav1.LabelSelector, len(in.ToEndpoints))
+		for i := range in.ToEndpoints {
+			in.ToEndpoints[i].DeepCopyInto(&out.ToEndpoints[i])
+		}
+	}
+	if in.ToCIDR != nil {
+		out.ToCIDR = make([]string, len(in.ToCIDR))
+		copy(out.ToCIDR, in.ToCIDR)
+	}
+	if in.ToPorts != nil {
+		out.ToPorts = make([]sdnv1alpha1.PortDenyRule, len(in.ToPorts))
+		for i := range in.ToPorts {
+			in.ToPorts[i].DeepCopyInto(&out.ToPorts[i])
+		}
+	}
+}
+
 // DeepCopyInto copies the receiver into out.
 func (in *CiliumNetworkPolicyList) DeepCopyInto(out *CiliumNetworkPolicyList) {
 	*out = *in
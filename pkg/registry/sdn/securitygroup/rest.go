@@ -16,6 +16,7 @@ import (
 	"strings"
 	"time"
 
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metainternal "k8s.io/apimachinery/pkg/apis/meta/internalversion"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -57,6 +58,29 @@ const (
 	// securitygroup-controller reads it to know which apps' pods to label.
 	attachmentsAnnotation = "sdn.cozystack.io/attachments"
 
+	// statusAnnotation stores the part of the SecurityGroup's status the
+	// securitygroup-controller resolves — members and unresolved references —
+	// as a JSON SecurityGroupStatus. The controller writes it with merge
+	// patches; the storage surfaces it as status, hides it from the view and
+	// carries it over from the current policy on every write, so a tenant can
+	// neither forge nor drop it.
+	statusAnnotation = "sdn.cozystack.io/status"
+
+	// flowsConfigMapName names the ConfigMap of a namespace the
+	// securitygroup-controller records the flow counters of its SecurityGroups
+	// in, one JSON SecurityGroupFlows per group name, and flowsLabelKey marks
+	// it. The counters move with every poll and every change of a
+	// CiliumNetworkPolicy makes the Cilium agents recompute it, so they are
+	// kept off the backing policy; tenants cannot write ConfigMaps. The
+	// controller mirrors all three.
+	flowsConfigMapName = "securitygroup-flows"
+	flowsLabelKey      = "sdn.cozystack.io/securitygroup-flows"
+	flowsLabelValue    = "true"
+
+	// ciliumValidCondition is the condition Cilium sets on a policy once it has
+	// parsed and imported it, or failed to.
+	ciliumValidCondition = "Valid"
+
 	// appGroupLabelKey, appKindLabelKey and appNameLabelKey are the lineage
 	// labels the lineage mutating webhook stamps on every managed-app pod
 	// (see internal/lineagecontrollerwebhook/webhook.go ManagerGroupKey/
@@ -72,6 +96,14 @@ const (
 	// APIGroup is empty — the group under which Cozystack serves managed apps.
 	defaultAppGroup = "apps.cozystack.io"
 
+	// podNamespaceLabel is the label Cilium gives every endpoint with its pod's
+	// namespace. A peer selector without it is scoped to the policy's own
+	// namespace; a peer in a peered namespace projects to a selector carrying
+	// it, together with the peer label (sdnv1alpha1.PeerLabelPrefix + the
+	// policy's namespace) the securitygroup-controller maintains only while
+	// both namespaces consent.
+	podNamespaceLabel = "k8s:io.kubernetes.pod.namespace"
+
 	singularName = sdnv1alpha1.SecurityGroupSingularName
 	kindSG       = sdnv1alpha1.SecurityGroupKind
 	kindSGList   = sdnv1alpha1.SecurityGroupListKind
@@ -106,31 +138,31 @@ func appLabels(ref sdnv1alpha1.ApplicationReference) map[string]string {
 	}
 }
 
-// appFromSelector reads an endpointSelector built from appLabels back into an
-// ApplicationReference. It matches only a selector carrying exactly the three
-// lineage keys, so a fromSG selector (a single membership key) is not mistaken
-// for an app peer.
-func appFromSelector(sel metav1.LabelSelector) (sdnv1alpha1.ApplicationReference, bool) {
-	if len(sel.MatchLabels) != 3 {
+// appFromLabels reads match labels built from appLabels back into an
+// ApplicationReference. It matches only a set of exactly the three lineage
+// keys, so a fromSG selector (a single membership key) is not mistaken for an
+// app peer.
+func appFromLabels(m map[string]string) (sdnv1alpha1.ApplicationReference, bool) {
+	if len(m) != 3 {
 		return sdnv1alpha1.ApplicationReference{}, false
 	}
-	g, gok := sel.MatchLabels[appGroupLabelKey]
-	k, kok := sel.MatchLabels[appKindLabelKey]
-	n, nok := sel.MatchLabels[appNameLabelKey]
+	g, gok := m[appGroupLabelKey]
+	k, kok := m[appKindLabelKey]
+	n, nok := m[appNameLabelKey]
 	if !gok || !kok || !nok {
 		return sdnv1alpha1.ApplicationReference{}, false
 	}
 	return sdnv1alpha1.ApplicationReference{APIGroup: g, Kind: k, Name: n}, true
 }
 
-// sgFromSelector reads a membership-label endpointSelector back into the name of
-// the referenced SecurityGroup. It matches only a selector carrying exactly one
-// membership key.
-func sgFromSelector(sel metav1.LabelSelector) (string, bool) {
-	if len(sel.MatchLabels) != 1 {
+// sgFromLabels reads membership match labels back into the name of the
+// referenced SecurityGroup. It matches only a set of exactly one membership
+// key.
+func sgFromLabels(m map[string]string) (string, bool) {
+	if len(m) != 1 {
 		return "", false
 	}
-	for k := range sel.MatchLabels {
+	for k := range m {
 		if strings.HasPrefix(k, sdnv1alpha1.MembershipLabelPrefix) {
 			return strings.TrimPrefix(k, sdnv1alpha1.MembershipLabelPrefix), true
 		}
@@ -138,25 +170,112 @@ func sgFromSelector(sel metav1.LabelSelector) (string, bool) {
 	return "", false
 }
 
+// peerLabelKey returns the consent label key the pods of a peered namespace
+// carry while the peering with ns is established.
+func peerLabelKey(ns string) string {
+	return sdnv1alpha1.PeerLabelPrefix + ns
+}
+
+// splitSGReference splits a fromSG/toSG entry into the namespace and name of
+// the referenced group. A bare name, or one qualified with the policy's own
+// namespace, refers to a group of the policy's namespace and yields an empty
+// namespace.
+func splitSGReference(ns, ref string) (string, string) {
+	peer, name, ok := strings.Cut(ref, "/")
+	if !ok {
+		return "", ref
+	}
+	if peer == ns {
+		return "", name
+	}
+	return peer, name
+}
+
+// scopeToPeer restricts match labels to the pods of a peered namespace that
+// the policy's namespace ns may select. A peer in ns itself is left as is.
+func scopeToPeer(ns, peer string, m map[string]string) map[string]string {
+	if peer == "" || peer == ns {
+		return m
+	}
+	m[podNamespaceLabel] = peer
+	m[peerLabelKey(ns)] = ""
+	return m
+}
+
+// peerSelectors projects app and SecurityGroup peers of a policy in namespace
+// ns into endpoint selectors: lineage labels for apps, the membership label
+// for SecurityGroups, both scoped to the peer's namespace and its consent
+// label when the peer lives in a peered namespace.
+func peerSelectors(ns string, apps []sdnv1alpha1.ApplicationReference, sgs []string) []metav1.LabelSelector {
+	var eps []metav1.LabelSelector
+	for _, app := range apps {
+		eps = append(eps, metav1.LabelSelector{MatchLabels: scopeToPeer(ns, app.Namespace, appLabels(app))})
+	}
+	for _, ref := range sgs {
+		peer, name := splitSGReference(ns, ref)
+		eps = append(eps, metav1.LabelSelector{MatchLabels: scopeToPeer(ns, peer, map[string]string{membershipLabelKey(name): ""})})
+	}
+	return eps
+}
+
+// selectorPeers is the inverse of peerSelectors. Selectors of neither shape are
+// dropped.
+func selectorPeers(eps []metav1.LabelSelector) ([]sdnv1alpha1.ApplicationReference, []string) {
+	var apps []sdnv1alpha1.ApplicationReference
+	var sgs []string
+	for _, ep := range eps {
+		peer, m := splitPeerLabels(ep.MatchLabels)
+		if app, ok := appFromLabels(m); ok {
+			app.Namespace = peer
+			apps = append(apps, app)
+		} else if name, ok := sgFromLabels(m); ok {
+			if peer != "" {
+				name = peer + "/" + name
+			}
+			sgs = append(sgs, name)
+		}
+	}
+	return apps, sgs
+}
+
+// splitPeerLabels separates the namespace and consent labels scopeToPeer adds
+// from the rest of a selector's match labels. Labels without both are
+// returned unchanged, with an empty namespace.
+func splitPeerLabels(m map[string]string) (string, map[string]string) {
+	peer, ok := m[podNamespaceLabel]
+	if !ok {
+		return "", m
+	}
+	rest := make(map[string]string, len(m))
+	consent := false
+	for k, v := range m {
+		switch {
+		case k == podNamespaceLabel:
+		case strings.HasPrefix(k, sdnv1alpha1.PeerLabelPrefix):
+			consent = true
+		default:
+			rest[k] = v
+		}
+	}
+	if !consent {
+		return "", m
+	}
+	return peer, rest
+}
+
 // projectIngress turns the SecurityGroup ingress rules into Cilium ingress
 // rules: fromApp peers become lineage-label endpointSelectors, fromSG peers
-// become membership-label endpointSelectors, and fromCIDR/toPorts carry over.
-// It always returns a non-nil slice (empty when there are no rules): the backing
+// become membership-label endpointSelectors (both scoped by peerSelectors when
+// they name a peered namespace), and fromCIDR/toPorts carry over. It always
+// returns a non-nil slice (empty when there are no rules): the backing
 // CiliumNetworkPolicy's ingress section must be present on the wire to satisfy
 // the CRD anyOf, and a non-nil empty slice serializes to an empty list rather
 // than null (which the CRD, having no nullable fields, would reject).
-func projectIngress(rules []sdnv1alpha1.IngressRule) []CiliumIngressRule {
+func projectIngress(ns string, rules []sdnv1alpha1.IngressRule) []CiliumIngressRule {
 	out := make([]CiliumIngressRule, len(rules))
 	for i := range rules {
-		var eps []metav1.LabelSelector
-		for _, app := range rules[i].FromApp {
-			eps = append(eps, metav1.LabelSelector{MatchLabels: appLabels(app)})
-		}
-		for _, name := range rules[i].FromSG {
-			eps = append(eps, metav1.LabelSelector{MatchLabels: map[string]string{membershipLabelKey(name): ""}})
-		}
 		out[i] = CiliumIngressRule{
-			FromEndpoints: eps,
+			FromEndpoints: peerSelectors(ns, rules[i].FromApp, rules[i].FromSG),
 			FromCIDR:      append([]string(nil), rules[i].FromCIDR...),
 			ToPorts:       rules[i].ToPorts,
 		}
@@ -165,21 +284,14 @@ func projectIngress(rules []sdnv1alpha1.IngressRule) []CiliumIngressRule {
 }
 
 // projectEgress is the egress counterpart of projectIngress.
-func projectEgress(rules []sdnv1alpha1.EgressRule) []CiliumEgressRule {
+func projectEgress(ns string, rules []sdnv1alpha1.EgressRule) []CiliumEgressRule {
 	if rules == nil {
 		return nil
 	}
 	out := make([]CiliumEgressRule, len(rules))
 	for i := range rules {
-		var eps []metav1.LabelSelector
-		for _, app := range rules[i].ToApp {
-			eps = append(eps, metav1.LabelSelector{MatchLabels: appLabels(app)})
-		}
-		for _, name := range rules[i].ToSG {
-			eps = append(eps, metav1.LabelSelector{MatchLabels: map[string]string{membershipLabelKey(name): ""}})
-		}
 		out[i] = CiliumEgressRule{
-			ToEndpoints: eps,
+			ToEndpoints: peerSelectors(ns, rules[i].ToApp, rules[i].ToSG),
 			ToCIDR:      append([]string(nil), rules[i].ToCIDR...),
 			ToFQDNs:     rules[i].ToFQDNs,
 			ToPorts:     rules[i].ToPorts,
@@ -188,6 +300,40 @@ func projectEgress(rules []sdnv1alpha1.EgressRule) []CiliumEgressRule {
 	return out
 }
 
+// projectIngressDeny turns the SecurityGroup ingressDeny rules into Cilium
+// ingressDeny rules, projecting peers like projectIngress. Unlike ingress the
+// section is omitted when there are no rules.
+func projectIngressDeny(ns string, rules []sdnv1alpha1.IngressDenyRule) []CiliumIngressDenyRule {
+	if len(rules) == 0 {
+		return nil
+	}
+	out := make([]CiliumIngressDenyRule, len(rules))
+	for i := range rules {
+		out[i] = CiliumIngressDenyRule{
+			FromEndpoints: peerSelectors(ns, rules[i].FromApp, rules[i].FromSG),
+			FromCIDR:      append([]string(nil), rules[i].FromCIDR...),
+			ToPorts:       rules[i].ToPorts,
+		}
+	}
+	return out
+}
+
+// projectEgressDeny is the egress counterpart of projectIngressDeny.
+func projectEgressDeny(ns string, rules []sdnv1alpha1.EgressDenyRule) []CiliumEgressDenyRule {
+	if len(rules) == 0 {
+		return nil
+	}
+	out := make([]CiliumEgressDenyRule, len(rules))
+	for i := range rules {
+		out[i] = CiliumEgressDenyRule{
+			ToEndpoints: peerSelectors(ns, rules[i].ToApp, rules[i].ToSG),
+			ToCIDR:      append([]string(nil), rules[i].ToCIDR...),
+			ToPorts:     rules[i].ToPorts,
+		}
+	}
+	return out
+}
+
 // reconstructIngress is the inverse of projectIngress, reading Cilium ingress
 // rules back into the SecurityGroup view. Endpoint selectors that match neither
 // the lineage-label nor the membership-label shape are ignored, so a
@@ -201,15 +347,7 @@ func reconstructIngress(rules []CiliumIngressRule) []sdnv1alpha1.IngressRule {
 	}
 	out := make([]sdnv1alpha1.IngressRule, len(rules))
 	for i := range rules {
-		var apps []sdnv1alpha1.ApplicationReference
-		var sgs []string
-		for _, ep := range rules[i].FromEndpoints {
-			if app, ok := appFromSelector(ep); ok {
-				apps = append(apps, app)
-			} else if name, ok := sgFromSelector(ep); ok {
-				sgs = append(sgs, name)
-			}
-		}
+		apps, sgs := selectorPeers(rules[i].FromEndpoints)
 		out[i] = sdnv1alpha1.IngressRule{
 			FromApp:  apps,
 			FromSG:   sgs,
@@ -229,15 +367,7 @@ func reconstructEgress(rules []CiliumEgressRule) []sdnv1alpha1.EgressRule {
 	}
 	out := make([]sdnv1alpha1.EgressRule, len(rules))
 	for i := range rules {
-		var apps []sdnv1alpha1.ApplicationReference
-		var sgs []string
-		for _, ep := range rules[i].ToEndpoints {
-			if app, ok := appFromSelector(ep); ok {
-				apps = append(apps, app)
-			} else if name, ok := sgFromSelector(ep); ok {
-				sgs = append(sgs, name)
-			}
-		}
+		apps, sgs := selectorPeers(rules[i].ToEndpoints)
 		out[i] = sdnv1alpha1.EgressRule{
 			ToApp:   apps,
 			ToSG:    sgs,
@@ -249,6 +379,42 @@ func reconstructEgress(rules []CiliumEgressRule) []sdnv1alpha1.EgressRule {
 	return out
 }
 
+// reconstructIngressDeny is the inverse of projectIngressDeny.
+func reconstructIngressDeny(rules []CiliumIngressDenyRule) []sdnv1alpha1.IngressDenyRule {
+	if len(rules) == 0 {
+		return nil
+	}
+	out := make([]sdnv1alpha1.IngressDenyRule, len(rules))
+	for i := range rules {
+		apps, sgs := selectorPeers(rules[i].FromEndpoints)
+		out[i] = sdnv1alpha1.IngressDenyRule{
+			FromApp:  apps,
+			FromSG:   sgs,
+			FromCIDR: append([]string(nil), rules[i].FromCIDR...),
+			ToPorts:  rules[i].ToPorts,
+		}
+	}
+	return out
+}
+
+// reconstructEgressDeny is the inverse of projectEgressDeny.
+func reconstructEgressDeny(rules []CiliumEgressDenyRule) []sdnv1alpha1.EgressDenyRule {
+	if len(rules) == 0 {
+		return nil
+	}
+	out := make([]sdnv1alpha1.EgressDenyRule, len(rules))
+	for i := range rules {
+		apps, sgs := selectorPeers(rules[i].ToEndpoints)
+		out[i] = sdnv1alpha1.EgressDenyRule{
+			ToApp:   apps,
+			ToSG:    sgs,
+			ToCIDR:  append([]string(nil), rules[i].ToCIDR...),
+			ToPorts: rules[i].ToPorts,
+		}
+	}
+	return out
+}
+
 // encodeAttachments serializes spec.attachments for the backing-policy
 // annotation. An empty APIGroup is canonicalized to apps.cozystack.io so the
 // stored value is unambiguous for the securitygroup-controller (and surfaced
@@ -286,6 +452,55 @@ func decodeAttachments(s string) []sdnv1alpha1.ApplicationReference {
 	return refs
 }
 
+// decodeStatus builds the SecurityGroup status from the controller-written
+// status annotation and the Valid condition Cilium reports on the policy. A
+// missing or malformed annotation yields an empty status, so a hand-edited
+// backing policy degrades gracefully rather than erroring.
+func decodeStatus(np *CiliumNetworkPolicy) sdnv1alpha1.SecurityGroupStatus {
+	var status sdnv1alpha1.SecurityGroupStatus
+	if s := np.Annotations[statusAnnotation]; s != "" {
+		if err := json.Unmarshal([]byte(s), &status); err != nil {
+			status = sdnv1alpha1.SecurityGroupStatus{}
+		}
+	}
+	status.Policy = sdnv1alpha1.SecurityGroupPolicyStatus{Phase: sdnv1alpha1.SecurityGroupPolicyPending}
+	if np.Status != nil {
+		for _, c := range np.Status.Conditions {
+			if c.Type != ciliumValidCondition {
+				continue
+			}
+			switch c.Status {
+			case string(metav1.ConditionTrue):
+				status.Policy.Phase = sdnv1alpha1.SecurityGroupPolicyEnforced
+			case string(metav1.ConditionFalse):
+				status.Policy = sdnv1alpha1.SecurityGroupPolicyStatus{
+					Phase:   sdnv1alpha1.SecurityGroupPolicyInvalid,
+					Message: c.Message,
+				}
+			}
+		}
+	}
+	return status
+}
+
+// decodeFlows reads the flow counters of a flows ConfigMap by group name. A
+// ConfigMap without the marker label yields nil and a malformed entry is
+// skipped, so a hand-edited ConfigMap degrades gracefully rather than
+// erroring.
+func decodeFlows(cm *corev1.ConfigMap) map[string]*sdnv1alpha1.SecurityGroupFlows {
+	if cm.Labels[flowsLabelKey] != flowsLabelValue {
+		return nil
+	}
+	out := make(map[string]*sdnv1alpha1.SecurityGroupFlows, len(cm.Data))
+	for name, s := range cm.Data {
+		flows := &sdnv1alpha1.SecurityGroupFlows{}
+		if err := json.Unmarshal([]byte(s), flows); err == nil {
+			out[name] = flows
+		}
+	}
+	return out
+}
+
 // stripMarkerLabel returns a copy of m without the SecurityGroup marker label.
 func stripMarkerLabel(m map[string]string) map[string]string {
 	if m == nil {
@@ -302,16 +517,16 @@ func stripMarkerLabel(m map[string]string) map[string]string {
 }
 
 // stripInternalAnnotations returns a copy of m without the storage-owned
-// attachments annotation, which is surfaced as spec.attachments instead. A
-// result with no entries is returned as nil so the SecurityGroup view carries no
-// empty annotations map.
+// attachments and status annotations, which are surfaced as spec.attachments
+// and status instead. A result with no entries is returned as nil so the
+// SecurityGroup view carries no empty annotations map.
 func stripInternalAnnotations(m map[string]string) map[string]string {
 	if m == nil {
 		return nil
 	}
 	out := make(map[string]string, len(m))
 	for k, v := range m {
-		if k == attachmentsAnnotation {
+		if k == attachmentsAnnotation || k == statusAnnotation {
 			continue
 		}
 		out[k] = v
@@ -361,9 +576,12 @@ func policyToSecurityGroup(np *CiliumNetworkPolicy) *sdnv1alpha1.SecurityGroup {
 			// endpointSelector (which is the SecurityGroup's own membership label).
 			Attachments: decodeAttachments(np.Annotations[attachmentsAnnotation]),
 			Ingress:     reconstructIngress(spec.Ingress),
+			IngressDeny: reconstructIngressDeny(spec.IngressDeny),
 			Egress:      reconstructEgress(spec.Egress),
+			EgressDeny:  reconstructEgressDeny(spec.EgressDeny),
 		}
 	}
+	sg.Status = decodeStatus(np)
 	return sg
 }
 
@@ -408,6 +626,12 @@ func securityGroupToPolicy(sg *sdnv1alpha1.SecurityGroup, cur *CiliumNetworkPoli
 	} else {
 		delete(out.Annotations, attachmentsAnnotation)
 	}
+	// The status annotation is the controller's: carry it over from cur
+	// whatever the request says.
+	delete(out.Annotations, statusAnnotation)
+	if cur != nil && cur.Annotations[statusAnnotation] != "" {
+		out.Annotations[statusAnnotation] = cur.Annotations[statusAnnotation]
+	}
 	if len(out.Annotations) == 0 {
 		out.Annotations = nil
 	}
@@ -442,8 +666,10 @@ func securityGroupToPolicy(sg *sdnv1alpha1.SecurityGroup, cur *CiliumNetworkPoli
 	spec := sg.Spec.DeepCopy()
 	out.Spec = &CiliumNetworkPolicySpec{
 		EndpointSelector: buildEndpointSelector(sg.Name),
-		Ingress:          projectIngress(spec.Ingress),
-		Egress:           projectEgress(spec.Egress),
+		Ingress:          projectIngress(sg.Namespace, spec.Ingress),
+		IngressDeny:      projectIngressDeny(sg.Namespace, spec.IngressDeny),
+		Egress:           projectEgress(sg.Namespace, spec.Egress),
+		EgressDeny:       projectEgressDeny(sg.Namespace, spec.EgressDeny),
 	}
 	// Normalize the protocol to upper case: validation accepts it case
 	// insensitively, but the backing CiliumNetworkPolicy CRD enforces a strict
@@ -458,20 +684,32 @@ func normalizePortProtocols(spec *CiliumNetworkPolicySpec) {
 	if spec == nil {
 		return
 	}
-	norm := func(rules []sdnv1alpha1.PortRule) {
-		for i := range rules {
-			for j := range rules[i].Ports {
-				if p := &rules[i].Ports[j]; p.Protocol != "" {
-					p.Protocol = strings.ToUpper(p.Protocol)
-				}
+	norm := func(ports []sdnv1alpha1.PortProtocol) {
+		for j := range ports {
+			if p := &ports[j]; p.Protocol != "" {
+				p.Protocol = strings.ToUpper(p.Protocol)
 			}
 		}
 	}
 	for i := range spec.Ingress {
-		norm(spec.Ingress[i].ToPorts)
+		for _, r := range spec.Ingress[i].ToPorts {
+			norm(r.Ports)
+		}
 	}
 	for i := range spec.Egress {
-		norm(spec.Egress[i].ToPorts)
+		for _, r := range spec.Egress[i].ToPorts {
+			norm(r.Ports)
+		}
+	}
+	for i := range spec.IngressDeny {
+		for _, r := range spec.IngressDeny[i].ToPorts {
+			norm(r.Ports)
+		}
+	}
+	for i := range spec.EgressDeny {
+		for _, r := range spec.EgressDeny[i].ToPorts {
+			norm(r.Ports)
+		}
 	}
 }
 
@@ -650,7 +888,23 @@ func (r *REST) Get(
 	if !isSecurityGroup(np) {
 		return nil, apierrors.NewNotFound(r.gvr.GroupResource(), name)
 	}
-	return policyToSecurityGroup(np), nil
+	flows, err := r.flows(ctx, ns)
+	if err != nil {
+		return nil, err
+	}
+	sg := policyToSecurityGroup(np)
+	sg.Status.Flows = flows[name]
+	return sg, nil
+}
+
+// flows returns the flow counters the securitygroup-controller recorded for
+// the SecurityGroups of ns, by group name; nil before it recorded any.
+func (r *REST) flows(ctx context.Context, ns string) (map[string]*sdnv1alpha1.SecurityGroupFlows, error) {
+	cm := &corev1.ConfigMap{}
+	if err := r.w.Get(ctx, types.NamespacedName{Namespace: ns, Name: flowsConfigMapName}, cm); err != nil {
+		return nil, client.IgnoreNotFound(err)
+	}
+	return decodeFlows(cm), nil
 }
 
 // List returns all SecurityGroups in the request namespace.
@@ -700,6 +954,20 @@ func (r *REST) List(ctx context.Context, opts *metainternal.ListOptions) (runtim
 		listRV, _ = registry.MaxResourceVersion(list)
 	}
 
+	// One flows ConfigMap per namespace, listed once for the whole list.
+	flows := map[string]map[string]*sdnv1alpha1.SecurityGroupFlows{}
+	if len(list.Items) > 0 {
+		cms := &corev1.ConfigMapList{}
+		if err := r.w.List(ctx, cms, client.InNamespace(ns), client.MatchingLabels{flowsLabelKey: flowsLabelValue}); err != nil {
+			return nil, err
+		}
+		for i := range cms.Items {
+			if cms.Items[i].Name == flowsConfigMapName {
+				flows[cms.Items[i].Namespace] = decodeFlows(&cms.Items[i])
+			}
+		}
+	}
+
 	out := &sdnv1alpha1.SecurityGroupList{
 		TypeMeta: metav1.TypeMeta{
 			APIVersion: sdnv1alpha1.SchemeGroupVersion.String(),
@@ -715,7 +983,9 @@ func (r *REST) List(ctx context.Context, opts *metainternal.ListOptions) (runtim
 		if !fieldFilter.MatchesNamespace(list.Items[i].Namespace) {
 			continue
 		}
-		out.Items = append(out.Items, *policyToSecurityGroup(&list.Items[i]))
+		sg := policyToSecurityGroup(&list.Items[i])
+		sg.Status.Flows = flows[sg.Namespace][sg.Name]
+		out.Items = append(out.Items, *sg)
 	}
 	sorting.ByNamespacedName[sdnv1alpha1.SecurityGroup, *sdnv1alpha1.SecurityGroup](out.Items)
 	return out, nil
@@ -1033,6 +1303,12 @@ func (r *REST) Watch(ctx context.Context, opts *metainternal.ListOptions) (watch
 					continue
 				}
 			}
+			if ev.Type != watch.Deleted {
+				// A failed read only leaves the counters out of this event.
+				if flows, err := r.flows(ctx, np.Namespace); err == nil {
+					sg.Status.Flows = flows[np.Name]
+				}
+			}
 
 			if bookmark, ok := bookmarker.BeforeLiveEvent(ev.Type); ok {
 				if !send(bookmark) {
@@ -12,6 +12,7 @@ import (
 	"strings"
 	"testing"
 
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metainternal "k8s.io/apimachinery/pkg/apis/meta/internalversion"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -38,6 +39,9 @@ func newTestREST(t *testing.T, policies ...*CiliumNetworkPolicy) *REST {
 	if err := AddToScheme(scheme); err != nil {
 		t.Fatalf("add cilium mirror to scheme: %v", err)
 	}
+	if err := corev1.AddToScheme(scheme); err != nil {
+		t.Fatalf("add core to scheme: %v", err)
+	}
 
 	objs := make([]client.Object, 0, len(policies))
 	for _, p := range policies {
@@ -1421,3 +1425,418 @@ func TestUpdateRejectsNameMismatch(t *testing.T) {
 		t.Fatalf("expected no policy named sg-evil, got err %v", err)
 	}
 }
+
+func TestStatusProjection(t *testing.T) {
+	np := markedPolicy("sg-db")
+	np.Annotations = map[string]string{
+		statusAnnotation: `{"members":[{"application":{"apiGroup":"apps.cozystack.io","kind":"Postgres","name":"db"},"pods":2}],"unresolvedReferences":["gone"]}`,
+	}
+	np.Status = &CiliumNetworkPolicyStatus{Conditions: []CiliumPolicyCondition{
+		{Type: ciliumValidCondition, Status: "False", Message: "invalid port"},
+	}}
+	r := newTestREST(t, np)
+
+	out, err := r.Get(ctxNS(), "sg-db", &metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Get returned error: %v", err)
+	}
+	sg := out.(*sdnv1alpha1.SecurityGroup)
+	if len(sg.Status.Members) != 1 || sg.Status.Members[0].Pods != 2 ||
+		!reflect.DeepEqual(sg.Status.UnresolvedReferences, []string{"gone"}) {
+		t.Errorf("status = %+v, want the controller-resolved members and references", sg.Status)
+	}
+	want := sdnv1alpha1.SecurityGroupPolicyStatus{Phase: sdnv1alpha1.SecurityGroupPolicyInvalid, Message: "invalid port"}
+	if sg.Status.Policy != want {
+		t.Errorf("policy = %+v, want %+v", sg.Status.Policy, want)
+	}
+	if _, ok := sg.Annotations[statusAnnotation]; ok {
+		t.Errorf("status annotation leaked into the view: %v", sg.Annotations)
+	}
+
+	// A tenant can neither forge nor drop the controller's status.
+	sg.Annotations = map[string]string{statusAnnotation: `{"members":[]}`}
+	if _, _, err := r.Update(ctxNS(), "sg-db", rest.DefaultUpdatedObjectInfo(sg), nil, nil, false, &metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("Update returned error: %v", err)
+	}
+	stored := &CiliumNetworkPolicy{}
+	if err := r.c.Get(context.Background(), types.NamespacedName{Namespace: testNamespace, Name: "sg-db"}, stored); err != nil {
+		t.Fatal(err)
+	}
+	if stored.Annotations[statusAnnotation] != np.Annotations[statusAnnotation] {
+		t.Errorf("stored status = %q, want it carried over", stored.Annotations[statusAnnotation])
+	}
+
+	// Without a Valid condition Cilium has not reported yet.
+	if got := decodeStatus(markedPolicy("x")).Policy.Phase; got != sdnv1alpha1.SecurityGroupPolicyPending {
+		t.Errorf("phase without condition = %q, want Pending", got)
+	}
+}
+
+// The flow counters the controller records in the flows ConfigMap of the
+// namespace surface as status.flows on reads.
+func TestFlowsProjection(t *testing.T) {
+	r := newTestREST(t, markedPolicy("sg-db"), markedPolicy("sg-web"))
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      flowsConfigMapName,
+			Namespace: testNamespace,
+			Labels:    map[string]string{flowsLabelKey: flowsLabelValue},
+		},
+		Data: map[string]string{
+			"sg-db":  `{"since":"2026-10-19T12:00:00Z","ingress":[{"rule":0,"allowed":3,"denied":1}]}`,
+			"sg-web": `not json`,
+		},
+	}
+	if err := r.w.Create(context.Background(), cm); err != nil {
+		t.Fatal(err)
+	}
+	want := []sdnv1alpha1.RuleFlowCounter{{Rule: 0, Allowed: 3, Denied: 1}}
+
+	out, err := r.Get(ctxNS(), "sg-db", &metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Get returned error: %v", err)
+	}
+	if flows := out.(*sdnv1alpha1.SecurityGroup).Status.Flows; flows == nil || !reflect.DeepEqual(flows.Ingress, want) {
+		t.Errorf("flows = %+v, want ingress %+v", flows, want)
+	}
+
+	list, err := r.List(ctxNS(), &metainternal.ListOptions{})
+	if err != nil {
+		t.Fatalf("List returned error: %v", err)
+	}
+	items := list.(*sdnv1alpha1.SecurityGroupList).Items
+	if len(items) != 2 || items[0].Status.Flows == nil || !reflect.DeepEqual(items[0].Status.Flows.Ingress, want) {
+		t.Errorf("listed flows = %+v, want ingress %+v on sg-db", items, want)
+	}
+	// A malformed entry is skipped.
+	if items[1].Status.Flows != nil {
+		t.Errorf("flows of sg-web = %+v, want none", items[1].Status.Flows)
+	}
+
+	// A ConfigMap of that name without the marker label is not the
+	// controller's.
+	cm.Labels = nil
+	if err := r.w.Update(context.Background(), cm); err != nil {
+		t.Fatal(err)
+	}
+	out, err = r.Get(ctxNS(), "sg-db", &metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Get returned error: %v", err)
+	}
+	if flows := out.(*sdnv1alpha1.SecurityGroup).Status.Flows; flows != nil {
+		t.Errorf("flows from an unmarked ConfigMap = %+v", flows)
+	}
+}
+
+func TestDenyRulesRoundTrip(t *testing.T) {
+	// "Allow the whole VPC except one app, and never a known bad range": the
+	// deny rules partially overlap the allow rule, which is what they are for.
+	r := newTestREST(t)
+	web := sdnv1alpha1.ApplicationReference{APIGroup: "apps.cozystack.io", Kind: "Kubernetes", Name: "web"}
+	in := &sdnv1alpha1.SecurityGroup{
+		ObjectMeta: metav1.ObjectMeta{Name: "sg-deny", Namespace: testNamespace},
+		Spec: sdnv1alpha1.SecurityGroupSpec{
+			Attachments: []sdnv1alpha1.ApplicationReference{{APIGroup: "apps.cozystack.io", Kind: "Postgres", Name: "db"}},
+			Ingress:     []sdnv1alpha1.IngressRule{{FromCIDR: []string{"10.0.0.0/8"}}},
+			IngressDeny: []sdnv1alpha1.IngressDenyRule{
+				{FromApp: []sdnv1alpha1.ApplicationReference{web}, FromSG: []string{"untrusted"}},
+				{FromCIDR: []string{"10.66.0.0/16"}, ToPorts: []sdnv1alpha1.PortDenyRule{{Ports: []sdnv1alpha1.PortProtocol{{Port: "5432", Protocol: "tcp"}}}}},
+			},
+			EgressDeny: []sdnv1alpha1.EgressDenyRule{{ToCIDR: []string{"198.51.100.0/24"}}},
+		},
+	}
+	createSG(t, r, in)
+
+	np := &CiliumNetworkPolicy{}
+	if err := r.c.Get(context.Background(), types.NamespacedName{Namespace: testNamespace, Name: "sg-deny"}, np); err != nil {
+		t.Fatalf("backing policy not found: %v", err)
+	}
+	deny := np.Spec.IngressDeny
+	if len(deny) != 2 || len(deny[0].FromEndpoints) != 2 || !reflect.DeepEqual(deny[0].FromEndpoints[0].MatchLabels, appLabels(web)) {
+		t.Fatalf("ingressDeny projection mismatch: %+v", deny)
+	}
+	if p := deny[1].ToPorts[0].Ports[0].Protocol; p != "TCP" {
+		t.Fatalf("deny port protocol = %q, want it normalized to TCP", p)
+	}
+	if len(np.Spec.EgressDeny) != 1 || np.Spec.Egress != nil {
+		t.Fatalf("egress projection mismatch: egress %+v, egressDeny %+v", np.Spec.Egress, np.Spec.EgressDeny)
+	}
+
+	out, err := r.Get(ctxNS(), "sg-deny", &metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Get returned error: %v", err)
+	}
+	got := out.(*sdnv1alpha1.SecurityGroup)
+	in.Spec.IngressDeny[1].ToPorts[0].Ports[0].Protocol = "TCP"
+	if !reflect.DeepEqual(got.Spec, in.Spec) {
+		t.Fatalf("deny round-trip mismatch:\n got: %+v\nwant: %+v", got.Spec, in.Spec)
+	}
+}
+
+func TestCreateRejectsInvalidDenyRules(t *testing.T) {
+	ports := func(port, proto string) []sdnv1alpha1.PortRule {
+		return []sdnv1alpha1.PortRule{{Ports: []sdnv1alpha1.PortProtocol{{Port: port, Protocol: proto}}}}
+	}
+	denyPorts := func(port, proto string) []sdnv1alpha1.PortDenyRule {
+		return []sdnv1alpha1.PortDenyRule{{Ports: []sdnv1alpha1.PortProtocol{{Port: port, Protocol: proto}}}}
+	}
+	cases := map[string]sdnv1alpha1.SecurityGroupSpec{
+		"deny without peers": {
+			IngressDeny: []sdnv1alpha1.IngressDenyRule{{ToPorts: denyPorts("22", "TCP")}},
+		},
+		"bad deny CIDR": {
+			EgressDeny: []sdnv1alpha1.EgressDenyRule{{ToCIDR: []string{"300.0.0.0/8"}}},
+		},
+		"allow inside a denied range": {
+			Ingress:     []sdnv1alpha1.IngressRule{{FromCIDR: []string{"10.1.0.0/16", "10.2.0.1"}, ToPorts: ports("5432", "TCP")}},
+			IngressDeny: []sdnv1alpha1.IngressDenyRule{{FromCIDR: []string{"10.0.0.0/8"}}},
+		},
+		"allow of a denied group on a denied port": {
+			Egress:     []sdnv1alpha1.EgressRule{{ToSG: []string{"cache"}, ToPorts: ports("6379", "TCP")}},
+			EgressDeny: []sdnv1alpha1.EgressDenyRule{{ToSG: []string{"cache", "other"}, ToPorts: denyPorts("6379", "ANY")}},
+		},
+	}
+	for name, spec := range cases {
+		t.Run(name, func(t *testing.T) {
+			r := newTestREST(t)
+			sg := &sdnv1alpha1.SecurityGroup{
+				ObjectMeta: metav1.ObjectMeta{Name: "sg-bad", Namespace: testNamespace},
+				Spec:       spec,
+			}
+			if _, err := r.Create(ctxNS(), sg, nil, &metav1.CreateOptions{}); !apierrors.IsInvalid(err) {
+				t.Fatalf("Create with %s: got err %v, want Invalid", name, err)
+			}
+		})
+	}
+
+	// A deny rule narrower than the allow rule, in peers or ports, leaves part
+	// of it reachable and is accepted.
+	partial := map[string]sdnv1alpha1.SecurityGroupSpec{
+		"narrower range": {
+			Ingress:     []sdnv1alpha1.IngressRule{{FromCIDR: []string{"10.0.0.0/8"}}},
+			IngressDeny: []sdnv1alpha1.IngressDenyRule{{FromCIDR: []string{"10.1.0.0/16"}}},
+		},
+		"other port": {
+			Ingress:     []sdnv1alpha1.IngressRule{{FromSG: []string{"web"}, ToPorts: ports("443", "TCP")}},
+			IngressDeny: []sdnv1alpha1.IngressDenyRule{{FromSG: []string{"web"}, ToPorts: denyPorts("22", "TCP")}},
+		},
+		"fqdn allow": {
+			Egress:     []sdnv1alpha1.EgressRule{{ToFQDNs: []sdnv1alpha1.FQDNSelector{{MatchName: "example.org"}}}},
+			EgressDeny: []sdnv1alpha1.EgressDenyRule{{ToCIDR: []string{"0.0.0.0/0"}}},
+		},
+	}
+	for name, spec := range partial {
+		t.Run(name, func(t *testing.T) {
+			r := newTestREST(t)
+			createSG(t, r, &sdnv1alpha1.SecurityGroup{
+				ObjectMeta: metav1.ObjectMeta{Name: "sg-ok", Namespace: testNamespace},
+				Spec:       spec,
+			})
+		})
+	}
+}
+
+func TestL7RulesRoundTrip(t *testing.T) {
+	// L7 rules ride on PortRule, whose wire shape is Cilium's, so they project
+	// and reconstruct without translation.
+	r := newTestREST(t)
+	in := &sdnv1alpha1.SecurityGroup{
+		ObjectMeta: metav1.ObjectMeta{Name: "sg-l7", Namespace: testNamespace},
+		Spec: sdnv1alpha1.SecurityGroupSpec{
+			Attachments: []sdnv1alpha1.ApplicationReference{{APIGroup: "apps.cozystack.io", Kind: "Kubernetes", Name: "api"}},
+			Ingress: []sdnv1alpha1.IngressRule{{
+				FromApp: []sdnv1alpha1.ApplicationReference{{APIGroup: "apps.cozystack.io", Kind: "Kubernetes", Name: "web"}},
+				ToPorts: []sdnv1alpha1.PortRule{{
+					Ports: []sdnv1alpha1.PortProtocol{{Port: "8080", Protocol: "TCP"}},
+					Rules: &sdnv1alpha1.L7Rules{HTTP: []sdnv1alpha1.HTTPRule{
+						{Method: "GET", Path: "/api/.*", Headers: []string{"X-Tenant: foo"}},
+					}},
+				}},
+			}},
+			Egress: []sdnv1alpha1.EgressRule{
+				{
+					ToCIDR: []string{"10.96.0.10/32"},
+					ToPorts: []sdnv1alpha1.PortRule{{
+						Ports: []sdnv1alpha1.PortProtocol{{Port: "53", Protocol: "ANY"}},
+						Rules: &sdnv1alpha1.L7Rules{DNS: []sdnv1alpha1.FQDNSelector{{MatchPattern: "*.example.com"}}},
+					}},
+				},
+				{
+					ToApp: []sdnv1alpha1.ApplicationReference{{APIGroup: "apps.cozystack.io", Kind: "Kafka", Name: "events"}},
+					ToPorts: []sdnv1alpha1.PortRule{{
+						Ports: []sdnv1alpha1.PortProtocol{{Port: "9092", Protocol: "TCP"}},
+						Rules: &sdnv1alpha1.L7Rules{Kafka: []sdnv1alpha1.KafkaRule{{Role: "produce", Topic: "orders"}}},
+					}},
+				},
+			},
+		},
+	}
+	createSG(t, r, in)
+
+	np := &CiliumNetworkPolicy{}
+	if err := r.c.Get(context.Background(), types.NamespacedName{Namespace: testNamespace, Name: "sg-l7"}, np); err != nil {
+		t.Fatalf("backing policy not found: %v", err)
+	}
+	raw, err := json.Marshal(np.Spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		`"rules":{"http":[{"method":"GET","path":"/api/.*","headers":["X-Tenant: foo"]}]}`,
+		`"rules":{"dns":[{"matchPattern":"*.example.com"}]}`,
+		`"rules":{"kafka":[{"role":"produce","topic":"orders"}]}`,
+	} {
+		if !strings.Contains(string(raw), want) {
+			t.Errorf("backing spec %s lacks %s", raw, want)
+		}
+	}
+
+	out, err := r.Get(ctxNS(), "sg-l7", &metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Get returned error: %v", err)
+	}
+	if got := out.(*sdnv1alpha1.SecurityGroup); !reflect.DeepEqual(got.Spec, in.Spec) {
+		t.Fatalf("L7 round-trip mismatch:\n got: %+v\nwant: %+v", got.Spec, in.Spec)
+	}
+}
+
+func TestCreateRejectsInvalidL7Rules(t *testing.T) {
+	port := func(port, proto string, rules sdnv1alpha1.L7Rules) []sdnv1alpha1.PortRule {
+		return []sdnv1alpha1.PortRule{{Ports: []sdnv1alpha1.PortProtocol{{Port: port, Protocol: proto}}, Rules: &rules}}
+	}
+	http := sdnv1alpha1.L7Rules{HTTP: []sdnv1alpha1.HTTPRule{{Method: "GET"}}}
+	dns := sdnv1alpha1.L7Rules{DNS: []sdnv1alpha1.FQDNSelector{{MatchName: "a.example"}}}
+	cases := map[string]struct {
+		ports  []sdnv1alpha1.PortRule
+		egress bool
+		detail string
+	}{
+		"no rule type":      {ports: port("80", "TCP", sdnv1alpha1.L7Rules{})},
+		"two rule types":    {ports: port("80", "TCP", sdnv1alpha1.L7Rules{HTTP: http.HTTP, DNS: dns.DNS})},
+		"no port":           {ports: []sdnv1alpha1.PortRule{{Rules: &http}}},
+		"wildcard port":     {ports: port("", "TCP", http)},
+		"http over UDP":     {ports: port("80", "UDP", http)},
+		"dns on ingress":    {ports: port("53", "UDP", dns), detail: "dns rules apply to egress only"},
+		"dns over SCTP":     {ports: port("53", "SCTP", dns), egress: true},
+		"bad path regexp":   {ports: port("80", "TCP", sdnv1alpha1.L7Rules{HTTP: []sdnv1alpha1.HTTPRule{{Path: "/api/(.*"}}})},
+		"bad header":        {ports: port("80", "TCP", sdnv1alpha1.L7Rules{HTTP: []sdnv1alpha1.HTTPRule{{Headers: []string{"Bad Header: x"}}}})},
+		"empty dns matcher": {ports: port("53", "UDP", sdnv1alpha1.L7Rules{DNS: []sdnv1alpha1.FQDNSelector{{}}}), egress: true},
+		"bad kafka role":    {ports: port("9092", "TCP", sdnv1alpha1.L7Rules{Kafka: []sdnv1alpha1.KafkaRule{{Role: "admin"}}})},
+		"bad kafka topic":   {ports: port("9092", "TCP", sdnv1alpha1.L7Rules{Kafka: []sdnv1alpha1.KafkaRule{{Topic: "orders/eu"}}})},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			r := newTestREST(t)
+			sg := sgWithIngress("sg-bad", sdnv1alpha1.IngressRule{ToPorts: tc.ports})
+			if tc.egress {
+				sg.Spec.Ingress = nil
+				sg.Spec.Egress = []sdnv1alpha1.EgressRule{{ToPorts: tc.ports}}
+			}
+			_, err := r.Create(ctxNS(), sg, nil, &metav1.CreateOptions{})
+			if !apierrors.IsInvalid(err) {
+				t.Fatalf("Create with %s: got err %v, want Invalid", name, err)
+			}
+			if !strings.Contains(err.Error(), tc.detail) {
+				t.Errorf("Create with %s: got err %v, want it to say %q", name, err, tc.detail)
+			}
+		})
+	}
+
+	// The same DNS rule is accepted on egress.
+	r := newTestREST(t)
+	sg := sgWithIngress("sg-dns", sdnv1alpha1.IngressRule{})
+	sg.Spec.Ingress = nil
+	sg.Spec.Egress = []sdnv1alpha1.EgressRule{{ToPorts: port("53", "UDP", dns)}}
+	if _, err := r.Create(ctxNS(), sg, nil, &metav1.CreateOptions{}); err != nil {
+		t.Fatalf("Create with dns on egress: %v", err)
+	}
+}
+
+func TestPeeredNamespaceProjectionRoundTrip(t *testing.T) {
+	// A peer in another namespace projects to the usual lineage or membership
+	// labels scoped to that namespace and to the consent label the controller
+	// stamps only while both namespaces peer, so an unconsented reference
+	// selects nothing. A reference to the group's own namespace stays local.
+	r := newTestREST(t)
+	in := &sdnv1alpha1.SecurityGroup{
+		ObjectMeta: metav1.ObjectMeta{Name: "sg-peer", Namespace: testNamespace},
+		Spec: sdnv1alpha1.SecurityGroupSpec{
+			Ingress: []sdnv1alpha1.IngressRule{{
+				FromApp: []sdnv1alpha1.ApplicationReference{{Namespace: "tenant-b", APIGroup: "apps.cozystack.io", Kind: "Kubernetes", Name: "web"}},
+				FromSG:  []string{"tenant-b/frontend", testNamespace + "/local"},
+			}},
+		},
+	}
+	createSG(t, r, in)
+
+	np := &CiliumNetworkPolicy{}
+	if err := r.c.Get(context.Background(), types.NamespacedName{Namespace: testNamespace, Name: "sg-peer"}, np); err != nil {
+		t.Fatalf("backing policy not found: %v", err)
+	}
+	eps := np.Spec.Ingress[0].FromEndpoints
+	if len(eps) != 3 {
+		t.Fatalf("ingress projection mismatch: %+v", eps)
+	}
+	consent := sdnv1alpha1.PeerLabelPrefix + testNamespace
+	wantApp := map[string]string{
+		"apps.cozystack.io/application.group": "apps.cozystack.io",
+		"apps.cozystack.io/application.kind":  "Kubernetes",
+		"apps.cozystack.io/application.name":  "web",
+		podNamespaceLabel:                     "tenant-b",
+		consent:                               "",
+	}
+	if !reflect.DeepEqual(eps[0].MatchLabels, wantApp) {
+		t.Fatalf("peer fromApp not scoped to the peer namespace: %+v", eps[0].MatchLabels)
+	}
+	wantSG := map[string]string{"securitygroup.sdn.cozystack.io/frontend": "", podNamespaceLabel: "tenant-b", consent: ""}
+	if !reflect.DeepEqual(eps[1].MatchLabels, wantSG) {
+		t.Fatalf("peer fromSG not scoped to the peer namespace: %+v", eps[1].MatchLabels)
+	}
+	if want := map[string]string{"securitygroup.sdn.cozystack.io/local": ""}; !reflect.DeepEqual(eps[2].MatchLabels, want) {
+		t.Fatalf("own-namespace fromSG not projected locally: %+v", eps[2].MatchLabels)
+	}
+
+	out, err := r.Get(ctxNS(), "sg-peer", &metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Get returned error: %v", err)
+	}
+	got := out.(*sdnv1alpha1.SecurityGroup).Spec.Ingress[0]
+	if !reflect.DeepEqual(got.FromApp, in.Spec.Ingress[0].FromApp) {
+		t.Fatalf("peer fromApp round-trip mismatch: %+v", got.FromApp)
+	}
+	if want := []string{"tenant-b/frontend", "local"}; !reflect.DeepEqual(got.FromSG, want) {
+		t.Fatalf("fromSG round-trip = %v, want %v", got.FromSG, want)
+	}
+}
+
+func TestCreateRejectsInvalidPeerNamespace(t *testing.T) {
+	cases := map[string]sdnv1alpha1.SecurityGroupSpec{
+		"attachment in another namespace": {
+			Attachments: []sdnv1alpha1.ApplicationReference{{Namespace: "tenant-b", Kind: "Postgres", Name: "db"}},
+		},
+		"invalid fromApp namespace": {
+			Ingress: []sdnv1alpha1.IngressRule{{FromApp: []sdnv1alpha1.ApplicationReference{{Namespace: "Tenant_B", Kind: "Kubernetes", Name: "web"}}}},
+		},
+		"invalid toSG namespace": {
+			Egress: []sdnv1alpha1.EgressRule{{ToSG: []string{"Tenant_B/frontend"}}},
+		},
+		"empty toSG namespace": {
+			Egress: []sdnv1alpha1.EgressRule{{ToSG: []string{"/frontend"}}},
+		},
+		"reserved entity in a peer namespace": {
+			Egress: []sdnv1alpha1.EgressRule{{ToSG: []string{"tenant-b/world"}}},
+		},
+	}
+	for name, spec := range cases {
+		t.Run(name, func(t *testing.T) {
+			r := newTestREST(t)
+			sg := &sdnv1alpha1.SecurityGroup{
+				ObjectMeta: metav1.ObjectMeta{Name: "sg-bad", Namespace: testNamespace},
+				Spec:       spec,
+			}
+			if _, err := r.Create(ctxNS(), sg, nil, &metav1.CreateOptions{}); !apierrors.IsInvalid(err) {
+				t.Fatalf("Create with %s: got err %v, want Invalid", name, err)
+			}
+		})
+	}
+}
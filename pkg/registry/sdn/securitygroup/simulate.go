@@ -0,0 +1,619 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2026 The Cozystack Authors.
+
+// SecurityGroup simulation and explanation: the SecurityGroups of a namespace
+// evaluated for a connection, or listed for an application, off the backing
+// CiliumNetworkPolicies. Reading the projection rather than the tenant spec
+// means the answer follows the selectors Cilium enforces.
+
+package securitygroup
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/apiserver/pkg/registry/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	sdnv1alpha1 "github.com/cozystack/cozystack/pkg/apis/sdn/v1alpha1"
+	"github.com/cozystack/cozystack/pkg/sdnmatch"
+)
+
+const (
+	kindSimulation  = sdnv1alpha1.SecurityGroupSimulationKind
+	kindExplanation = sdnv1alpha1.SecurityGroupExplanationKind
+
+	// peeringLabelKey marks the ConfigMaps backing SecurityGroupPeerings, and
+	// peerNamespaceKey is their data key naming the peer namespace. Both mirror
+	// the constants in pkg/registry/sdn/securitygrouppeering.
+	peeringLabelKey   = "sdn.cozystack.io/securitygrouppeering"
+	peeringLabelValue = "true"
+	peerNamespaceKey  = "peerNamespace"
+
+	sectionIngress     = "ingress"
+	sectionEgress      = "egress"
+	sectionIngressDeny = "ingressDeny"
+	sectionEgressDeny  = "egressDeny"
+)
+
+var (
+	_ rest.Creater                  = &SimulationREST{}
+	_ rest.Scoper                   = &SimulationREST{}
+	_ rest.GroupVersionKindProvider = &SimulationREST{}
+	_ rest.SingularNameProvider     = &SimulationREST{}
+	_ rest.Creater                  = &ExplanationREST{}
+	_ rest.Scoper                   = &ExplanationREST{}
+	_ rest.GroupVersionKindProvider = &ExplanationREST{}
+	_ rest.SingularNameProvider     = &ExplanationREST{}
+)
+
+// SimulationREST serves securitygroupsimulations. POST a
+// SecurityGroupSimulation and the response carries its status:
+//
+//   - the source's SecurityGroups are evaluated for egress and the
+//     destination's for ingress, as Cilium enforces a connection at both ends;
+//   - deny rules win over allow rules, across all the groups of an end;
+//   - an end outside the cluster, or in a namespace without an established
+//     peering with the simulation's, is not evaluated;
+//   - nothing is stored.
+type SimulationREST struct {
+	sgs *REST
+}
+
+// NewSimulationREST returns the securitygroupsimulations storage evaluating
+// the SecurityGroups of sgs.
+func NewSimulationREST(sgs *REST) *SimulationREST {
+	return &SimulationREST{sgs: sgs}
+}
+
+// NamespaceScoped reports that SecurityGroupSimulation is namespaced.
+func (*SimulationREST) NamespaceScoped() bool { return true }
+
+// New returns an empty SecurityGroupSimulation.
+func (*SimulationREST) New() runtime.Object { return &sdnv1alpha1.SecurityGroupSimulation{} }
+
+// Destroy releases resources associated with SimulationREST.
+func (*SimulationREST) Destroy() {}
+
+// GroupVersionKind reports SecurityGroupSimulation.
+func (r *SimulationREST) GroupVersionKind(schema.GroupVersion) schema.GroupVersionKind {
+	return r.sgs.gvr.GroupVersion().WithKind(kindSimulation)
+}
+
+// GetSingularName returns the singular resource name.
+func (*SimulationREST) GetSingularName() string {
+	return sdnv1alpha1.SecurityGroupSimulationSingularName
+}
+
+// Create evaluates the simulation and returns it with its status.
+func (r *SimulationREST) Create(ctx context.Context, obj runtime.Object, createValidation rest.ValidateObjectFunc, _ *metav1.CreateOptions) (runtime.Object, error) {
+	in, ok := obj.(*sdnv1alpha1.SecurityGroupSimulation)
+	if !ok {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected SecurityGroupSimulation, got %T", obj))
+	}
+	ns, err := nsFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if createValidation != nil {
+		if err := createValidation(ctx, obj); err != nil {
+			return nil, err
+		}
+	}
+	out := in.DeepCopy()
+	out.Namespace = ns
+	out.Spec.Protocol = strings.ToUpper(out.Spec.Protocol)
+	if out.Spec.Protocol == "" {
+		out.Spec.Protocol = "TCP"
+	}
+	if err := validateSimulation(out); err != nil {
+		return nil, err
+	}
+	status, err := r.sgs.simulate(ctx, ns, &out.Spec)
+	if err != nil {
+		return nil, err
+	}
+	out.Status = *status
+	return out, nil
+}
+
+// ExplanationREST serves securitygroupexplanations. POST a
+// SecurityGroupExplanation naming an application of the namespace and the
+// response lists the rules of the groups it is attached to and the rules
+// naming it as a peer. Nothing is stored.
+type ExplanationREST struct {
+	sgs *REST
+}
+
+// NewExplanationREST returns the securitygroupexplanations storage listing
+// the SecurityGroups of sgs.
+func NewExplanationREST(sgs *REST) *ExplanationREST {
+	return &ExplanationREST{sgs: sgs}
+}
+
+// NamespaceScoped reports that SecurityGroupExplanation is namespaced.
+func (*ExplanationREST) NamespaceScoped() bool { return true }
+
+// New returns an empty SecurityGroupExplanation.
+func (*ExplanationREST) New() runtime.Object { return &sdnv1alpha1.SecurityGroupExplanation{} }
+
+// Destroy releases resources associated with ExplanationREST.
+func (*ExplanationREST) Destroy() {}
+
+// GroupVersionKind reports SecurityGroupExplanation.
+func (r *ExplanationREST) GroupVersionKind(schema.GroupVersion) schema.GroupVersionKind {
+	return r.sgs.gvr.GroupVersion().WithKind(kindExplanation)
+}
+
+// GetSingularName returns the singular resource name.
+func (*ExplanationREST) GetSingularName() string {
+	return sdnv1alpha1.SecurityGroupExplanationSingularName
+}
+
+// Create explains the application and returns the explanation with its
+// status.
+func (r *ExplanationREST) Create(ctx context.Context, obj runtime.Object, createValidation rest.ValidateObjectFunc, _ *metav1.CreateOptions) (runtime.Object, error) {
+	in, ok := obj.(*sdnv1alpha1.SecurityGroupExplanation)
+	if !ok {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected SecurityGroupExplanation, got %T", obj))
+	}
+	ns, err := nsFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if createValidation != nil {
+		if err := createValidation(ctx, obj); err != nil {
+			return nil, err
+		}
+	}
+	out := in.DeepCopy()
+	out.Namespace = ns
+	app := &out.Spec.Application
+	errs := validateAppRef(field.NewPath("spec", "application"), app)
+	if app.Namespace != "" && app.Namespace != ns {
+		errs = append(errs, field.Forbidden(field.NewPath("spec", "application", "namespace"), "must be the explanation's namespace"))
+	}
+	if len(errs) > 0 {
+		return nil, apierrors.NewInvalid(sdnv1alpha1.SchemeGroupVersion.WithKind(kindExplanation).GroupKind(), out.Name, errs)
+	}
+	app.Namespace = ""
+	if app.APIGroup == "" {
+		app.APIGroup = defaultAppGroup
+	}
+	status, err := r.sgs.explain(ctx, ns, *app)
+	if err != nil {
+		return nil, err
+	}
+	out.Status = *status
+	return out, nil
+}
+
+// validateSimulation rejects a simulation whose endpoints or port cannot be
+// evaluated.
+func validateSimulation(s *sdnv1alpha1.SecurityGroupSimulation) error {
+	spec := field.NewPath("spec")
+	errs := validateSimulationEndpoint(spec.Child("source"), &s.Spec.Source)
+	errs = append(errs, validateSimulationEndpoint(spec.Child("destination"), &s.Spec.Destination)...)
+	if s.Spec.Source.FQDN != "" {
+		errs = append(errs, field.Invalid(spec.Child("source", "fqdn"), s.Spec.Source.FQDN, "a DNS name can only be a destination"))
+	}
+	if outside(&s.Spec.Source) && outside(&s.Spec.Destination) {
+		errs = append(errs, field.Invalid(spec, "", "source or destination must be an application or a pod"))
+	}
+	if s.Spec.Port < 1 || s.Spec.Port > 65535 {
+		errs = append(errs, field.Invalid(spec.Child("port"), s.Spec.Port, "must be between 1 and 65535"))
+	}
+	if p := s.Spec.Protocol; p != "TCP" && p != "UDP" && p != "SCTP" {
+		errs = append(errs, field.NotSupported(spec.Child("protocol"), p, []string{"TCP", "UDP", "SCTP"}))
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(sdnv1alpha1.SchemeGroupVersion.WithKind(kindSimulation).GroupKind(), s.Name, errs)
+}
+
+// validateSimulationEndpoint requires exactly one field of an endpoint, and
+// that it names something well-formed.
+func validateSimulationEndpoint(path *field.Path, ep *sdnv1alpha1.SimulationEndpoint) field.ErrorList {
+	var errs field.ErrorList
+	set := 0
+	if ep.Application != nil {
+		set++
+		errs = append(errs, validateAppRef(path.Child("application"), ep.Application)...)
+	}
+	if ep.Pod != "" {
+		set++
+		ns, name, qualified := strings.Cut(ep.Pod, "/")
+		if !qualified {
+			ns, name = "", ep.Pod
+		} else {
+			for _, msg := range validation.IsDNS1123Label(ns) {
+				errs = append(errs, field.Invalid(path.Child("pod"), ep.Pod, "namespace "+msg))
+			}
+		}
+		for _, msg := range validation.IsDNS1123Subdomain(name) {
+			errs = append(errs, field.Invalid(path.Child("pod"), ep.Pod, msg))
+		}
+	}
+	if ep.IP != "" {
+		set++
+		if _, err := netip.ParseAddr(ep.IP); err != nil {
+			errs = append(errs, field.Invalid(path.Child("ip"), ep.IP, "must be an IP address"))
+		}
+	}
+	if ep.FQDN != "" {
+		set++
+		for _, msg := range validation.IsDNS1123Subdomain(strings.TrimSuffix(strings.ToLower(ep.FQDN), ".")) {
+			errs = append(errs, field.Invalid(path.Child("fqdn"), ep.FQDN, msg))
+		}
+	}
+	if set != 1 {
+		errs = append(errs, field.Invalid(path, "", "exactly one of application, pod, ip or fqdn must be set"))
+	}
+	return errs
+}
+
+// outside reports whether an endpoint is outside the cluster.
+func outside(ep *sdnv1alpha1.SimulationEndpoint) bool {
+	return ep.IP != "" || ep.FQDN != ""
+}
+
+// endpoint is a resolved end of a simulated connection.
+type endpoint struct {
+	// namespace is the endpoint's namespace, empty outside the cluster.
+	namespace string
+	// labels are the endpoint's pod labels.
+	labels map[string]string
+	ip     string
+	fqdn   string
+	// evaluate is set when the SecurityGroups of namespace apply to the
+	// simulation: it is the simulation's namespace or peered with it.
+	evaluate bool
+	// app is set when the labels were built from an application reference
+	// rather than read off a pod.
+	app bool
+}
+
+// peerings answers whether two namespaces have an established
+// SecurityGroupPeering, reading each namespace's peerings once.
+type peerings struct {
+	r       *REST
+	offered map[string]map[string]bool
+}
+
+func (p *peerings) of(ctx context.Context, ns string) (map[string]bool, error) {
+	if out, ok := p.offered[ns]; ok {
+		return out, nil
+	}
+	list := &corev1.ConfigMapList{}
+	if err := p.r.w.List(ctx, list, client.InNamespace(ns), client.MatchingLabels{peeringLabelKey: peeringLabelValue}); err != nil {
+		return nil, err
+	}
+	out := map[string]bool{}
+	for i := range list.Items {
+		if peer := list.Items[i].Data[peerNamespaceKey]; peer != "" && list.Items[i].DeletionTimestamp.IsZero() {
+			out[peer] = true
+		}
+	}
+	p.offered[ns] = out
+	return out, nil
+}
+
+// established reports whether a and b each have a SecurityGroupPeering naming
+// the other.
+func (p *peerings) established(ctx context.Context, a, b string) (bool, error) {
+	if a == b {
+		return true, nil
+	}
+	fromA, err := p.of(ctx, a)
+	if err != nil || !fromA[b] {
+		return false, err
+	}
+	fromB, err := p.of(ctx, b)
+	return fromB[a], err
+}
+
+// simulate evaluates spec against the SecurityGroups of ns and of the
+// namespaces peered with it.
+func (r *REST) simulate(ctx context.Context, ns string, spec *sdnv1alpha1.SecurityGroupSimulationSpec) (*sdnv1alpha1.SecurityGroupSimulationStatus, error) {
+	peers := &peerings{r: r, offered: map[string]map[string]bool{}}
+	src, err := r.resolveEndpoint(ctx, ns, &spec.Source, peers)
+	if err != nil {
+		return nil, err
+	}
+	dst, err := r.resolveEndpoint(ctx, ns, &spec.Destination, peers)
+	if err != nil {
+		return nil, err
+	}
+	// An application's pods carry the consent label of a peered namespace
+	// whose groups select them; the controller stamps it on exactly those.
+	for _, pair := range [][2]*endpoint{{src, dst}, {dst, src}} {
+		ep, other := pair[0], pair[1]
+		if !ep.app || other.namespace == "" || other.namespace == ep.namespace {
+			continue
+		}
+		ok, err := peers.established(ctx, ep.namespace, other.namespace)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			ep.labels[peerLabelKey(other.namespace)] = ""
+		}
+	}
+
+	status := &sdnv1alpha1.SecurityGroupSimulationStatus{}
+	if src.evaluate {
+		if status.Egress, err = r.evaluate(ctx, src, dst, true, spec.Protocol, spec.Port); err != nil {
+			return nil, err
+		}
+	}
+	if dst.evaluate {
+		if status.Ingress, err = r.evaluate(ctx, dst, src, false, spec.Protocol, spec.Port); err != nil {
+			return nil, err
+		}
+	}
+
+	var denied, allowed, open []string
+	for _, side := range []struct {
+		name   string
+		ep     *endpoint
+		result *sdnv1alpha1.SimulationResult
+	}{{"source", src, status.Egress}, {"destination", dst, status.Ingress}} {
+		switch {
+		case side.ep.namespace == "":
+		case side.result == nil:
+			open = append(open, fmt.Sprintf("the %s's namespace %s has no established peering with %s", side.name, side.ep.namespace, ns))
+		case side.result.Verdict == sdnv1alpha1.SimulationDenied:
+			for _, m := range side.result.Rules {
+				if strings.HasSuffix(m.Section, "Deny") {
+					denied = append(denied, describeRule(m))
+				}
+			}
+		case side.result.Verdict == sdnv1alpha1.SimulationAllowed:
+			for _, m := range side.result.Rules {
+				allowed = append(allowed, describeRule(m))
+			}
+		default:
+			open = append(open, fmt.Sprintf("no SecurityGroup rule of the %s matches", side.name))
+		}
+	}
+	switch {
+	case len(denied) > 0:
+		status.Verdict = sdnv1alpha1.SimulationDenied
+		status.Reason = "denied by " + strings.Join(denied, ", ")
+	case len(open) == 0:
+		status.Verdict = sdnv1alpha1.SimulationAllowed
+		status.Reason = "allowed by " + strings.Join(allowed, ", ")
+	default:
+		status.Verdict = sdnv1alpha1.SimulationBaseline
+		status.Reason = strings.Join(open, "; ") + ", so the tenant baseline policies decide"
+	}
+	return status, nil
+}
+
+// describeRule names a rule as "<group> <section>[<index>]".
+func describeRule(m sdnv1alpha1.MatchedRule) string {
+	return fmt.Sprintf("%s %s[%d]", m.SecurityGroup, m.Section, m.Rule)
+}
+
+// resolveEndpoint resolves a simulation endpoint in namespace ns. A pod is
+// read only in ns or a peered namespace; an application of another namespace
+// resolves to its lineage labels alone, unevaluated.
+func (r *REST) resolveEndpoint(ctx context.Context, ns string, ep *sdnv1alpha1.SimulationEndpoint, peers *peerings) (*endpoint, error) {
+	switch {
+	case ep.IP != "":
+		return &endpoint{ip: ep.IP}, nil
+	case ep.FQDN != "":
+		return &endpoint{fqdn: strings.TrimSuffix(strings.ToLower(ep.FQDN), ".")}, nil
+	case ep.Pod != "":
+		podNS, name, qualified := strings.Cut(ep.Pod, "/")
+		if !qualified {
+			podNS, name = ns, ep.Pod
+		}
+		ok, err := peers.established(ctx, ns, podNS)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return nil, apierrors.NewForbidden(
+				sdnv1alpha1.SchemeGroupVersion.WithResource(sdnv1alpha1.SecurityGroupSimulationPluralName).GroupResource(),
+				"", fmt.Errorf("namespace %s has no established SecurityGroupPeering with %s", podNS, ns))
+		}
+		pod := &corev1.Pod{}
+		if err := r.w.Get(ctx, types.NamespacedName{Namespace: podNS, Name: name}, pod); err != nil {
+			return nil, err
+		}
+		labels := make(map[string]string, len(pod.Labels))
+		for k, v := range pod.Labels {
+			labels[k] = v
+		}
+		return &endpoint{namespace: podNS, labels: labels, evaluate: true}, nil
+	default:
+		app := *ep.Application
+		appNS := app.Namespace
+		if appNS == "" {
+			appNS = ns
+		}
+		out := &endpoint{namespace: appNS, labels: appLabels(app), app: true}
+		ok, err := peers.established(ctx, ns, appNS)
+		if err != nil || !ok {
+			return out, err
+		}
+		out.evaluate = true
+		// The groups the application is attached to are those whose pods
+		// the controller stamps with the membership label.
+		groups, err := r.groups(ctx, appNS)
+		if err != nil {
+			return nil, err
+		}
+		for i := range groups {
+			if attached(&groups[i], app) {
+				out.labels[membershipLabelKey(groups[i].Name)] = ""
+			}
+		}
+		return out, nil
+	}
+}
+
+// groups returns the backing policies of the SecurityGroups of ns, sorted by
+// name.
+func (r *REST) groups(ctx context.Context, ns string) ([]CiliumNetworkPolicy, error) {
+	list := &CiliumNetworkPolicyList{}
+	if err := r.c.List(ctx, list, client.InNamespace(ns), client.MatchingLabels{sgLabelKey: sgLabelValue}); err != nil {
+		return nil, err
+	}
+	sort.Slice(list.Items, func(i, j int) bool { return list.Items[i].Name < list.Items[j].Name })
+	return list.Items, nil
+}
+
+// attached reports whether app is among the attachments of np.
+func attached(np *CiliumNetworkPolicy, app sdnv1alpha1.ApplicationReference) bool {
+	want := appLabels(app)
+	for _, ref := range decodeAttachments(np.Annotations[attachmentsAnnotation]) {
+		got := appLabels(ref)
+		if got[appGroupLabelKey] == want[appGroupLabelKey] && got[appKindLabelKey] == want[appKindLabelKey] && got[appNameLabelKey] == want[appNameLabelKey] {
+			return true
+		}
+	}
+	return false
+}
+
+// evaluate evaluates the SecurityGroups local is a member of for a connection
+// with remote: its egress rules when egress is set, its ingress rules
+// otherwise. Every matching rule is reported, deny rules first.
+func (r *REST) evaluate(ctx context.Context, local, remote *endpoint, egress bool, protocol string, port int32) (*sdnv1alpha1.SimulationResult, error) {
+	groups, err := r.groups(ctx, local.namespace)
+	if err != nil {
+		return nil, err
+	}
+	res := &sdnv1alpha1.SimulationResult{Verdict: sdnv1alpha1.SimulationUnmatched}
+	var denies, allows []sdnv1alpha1.MatchedRule
+	for i := range groups {
+		np := &groups[i]
+		if _, member := local.labels[membershipLabelKey(np.Name)]; !member || np.Spec == nil {
+			continue
+		}
+		res.SecurityGroups = append(res.SecurityGroups, np.Name)
+		match := func(section string, rule int) sdnv1alpha1.MatchedRule {
+			return sdnv1alpha1.MatchedRule{SecurityGroup: np.Name, Section: section, Rule: int32(rule)}
+		}
+		if egress {
+			for j, rule := range np.Spec.EgressDeny {
+				if peerMatches(np.Namespace, rule.ToEndpoints, rule.ToCIDR, nil, remote) && sdnmatch.DenyPorts(rule.ToPorts, protocol, port) {
+					denies = append(denies, match(sectionEgressDeny, j))
+				}
+			}
+			for j, rule := range np.Spec.Egress {
+				if !peerMatches(np.Namespace, rule.ToEndpoints, rule.ToCIDR, rule.ToFQDNs, remote) {
+					continue
+				}
+				if ok, l7 := sdnmatch.Ports(rule.ToPorts, protocol, port); ok {
+					m := match(sectionEgress, j)
+					m.L7 = l7
+					allows = append(allows, m)
+				}
+			}
+		} else {
+			for j, rule := range np.Spec.IngressDeny {
+				if peerMatches(np.Namespace, rule.FromEndpoints, rule.FromCIDR, nil, remote) && sdnmatch.DenyPorts(rule.ToPorts, protocol, port) {
+					denies = append(denies, match(sectionIngressDeny, j))
+				}
+			}
+			for j, rule := range np.Spec.Ingress {
+				if !peerMatches(np.Namespace, rule.FromEndpoints, rule.FromCIDR, nil, remote) {
+					continue
+				}
+				if ok, l7 := sdnmatch.Ports(rule.ToPorts, protocol, port); ok {
+					m := match(sectionIngress, j)
+					m.L7 = l7
+					allows = append(allows, m)
+				}
+			}
+		}
+	}
+	switch {
+	case len(denies) > 0:
+		res.Verdict = sdnv1alpha1.SimulationDenied
+	case len(allows) > 0:
+		res.Verdict = sdnv1alpha1.SimulationAllowed
+	}
+	res.Rules = append(denies, allows...)
+	return res, nil
+}
+
+// peerMatches reports whether remote is a peer of a rule of a policy in
+// namespace ns. A rule naming no peer at all matches every peer, as in
+// Cilium; CIDRs and FQDNs only match endpoints outside the cluster.
+func peerMatches(ns string, selectors []metav1.LabelSelector, cidrs []string, fqdns []sdnv1alpha1.FQDNSelector, remote *endpoint) bool {
+	if len(selectors) == 0 && len(cidrs) == 0 && len(fqdns) == 0 {
+		return true
+	}
+	if remote.namespace != "" {
+		return sdnmatch.Selectors(selectors, ns, remote.namespace, remote.labels)
+	}
+	if remote.ip != "" {
+		return sdnmatch.CIDRs(remote.ip, cidrs)
+	}
+	return sdnmatch.FQDNs(remote.fqdn, fqdns)
+}
+
+// explain lists the SecurityGroup rules applying to app in namespace ns: the
+// groups it is attached to, as their tenant-facing rules, and the rules of
+// any group of ns selecting its pods as a peer.
+func (r *REST) explain(ctx context.Context, ns string, app sdnv1alpha1.ApplicationReference) (*sdnv1alpha1.SecurityGroupExplanationStatus, error) {
+	groups, err := r.groups(ctx, ns)
+	if err != nil {
+		return nil, err
+	}
+	self := &endpoint{namespace: ns, labels: appLabels(app)}
+	status := &sdnv1alpha1.SecurityGroupExplanationStatus{}
+	for i := range groups {
+		if !attached(&groups[i], app) {
+			continue
+		}
+		self.labels[membershipLabelKey(groups[i].Name)] = ""
+		spec := policyToSecurityGroup(&groups[i]).Spec
+		status.SecurityGroups = append(status.SecurityGroups, sdnv1alpha1.ExplainedSecurityGroup{
+			Name:        groups[i].Name,
+			Ingress:     spec.Ingress,
+			Egress:      spec.Egress,
+			IngressDeny: spec.IngressDeny,
+			EgressDeny:  spec.EgressDeny,
+		})
+	}
+	for i := range groups {
+		np := &groups[i]
+		if np.Spec == nil {
+			continue
+		}
+		ref := func(section string, rule int, selectors []metav1.LabelSelector) {
+			if sdnmatch.Selectors(selectors, np.Namespace, self.namespace, self.labels) {
+				status.ReferencedBy = append(status.ReferencedBy, sdnv1alpha1.MatchedRule{SecurityGroup: np.Name, Section: section, Rule: int32(rule)})
+			}
+		}
+		for j, rule := range np.Spec.Ingress {
+			ref(sectionIngress, j, rule.FromEndpoints)
+		}
+		for j, rule := range np.Spec.Egress {
+			ref(sectionEgress, j, rule.ToEndpoints)
+		}
+		for j, rule := range np.Spec.IngressDeny {
+			ref(sectionIngressDeny, j, rule.FromEndpoints)
+		}
+		for j, rule := range np.Spec.EgressDeny {
+			ref(sectionEgressDeny, j, rule.ToEndpoints)
+		}
+	}
+	return status, nil
+}
@@ -0,0 +1,324 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2026 The Cozystack Authors.
+
+package securitygroup
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apiserver/pkg/endpoints/request"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	sdnv1alpha1 "github.com/cozystack/cozystack/pkg/apis/sdn/v1alpha1"
+)
+
+const peerNamespace = "tenant-b"
+
+// newSimulationREST returns SecurityGroup storage over a fake client that
+// also serves the pods and ConfigMaps the simulator reads.
+func newSimulationREST(t *testing.T, objs ...client.Object) *REST {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatalf("add client-go scheme: %v", err)
+	}
+	if err := AddToScheme(scheme); err != nil {
+		t.Fatalf("add cilium mirror to scheme: %v", err)
+	}
+	fc := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+	return NewREST(fc, fc)
+}
+
+func app(kind, name string) *sdnv1alpha1.ApplicationReference {
+	return &sdnv1alpha1.ApplicationReference{Kind: kind, Name: name}
+}
+
+func tcpPorts(port string) []sdnv1alpha1.PortRule {
+	return []sdnv1alpha1.PortRule{{Ports: []sdnv1alpha1.PortProtocol{{Port: port, Protocol: "TCP"}}}}
+}
+
+func createIn(t *testing.T, r *REST, ns, name string, spec sdnv1alpha1.SecurityGroupSpec) {
+	t.Helper()
+	sg := &sdnv1alpha1.SecurityGroup{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: ns}, Spec: spec}
+	if _, err := r.Create(request.WithNamespace(context.Background(), ns), sg, nil, &metav1.CreateOptions{}); err != nil {
+		t.Fatalf("Create %s/%s: %v", ns, name, err)
+	}
+}
+
+// dbAndWeb creates a db group letting web in on 5432 and a web group letting
+// it out to db on 5432.
+func dbAndWeb(t *testing.T, r *REST) {
+	t.Helper()
+	createIn(t, r, testNamespace, "db", sdnv1alpha1.SecurityGroupSpec{
+		Attachments: []sdnv1alpha1.ApplicationReference{*app("Postgres", "db")},
+		Ingress: []sdnv1alpha1.IngressRule{
+			{FromApp: []sdnv1alpha1.ApplicationReference{*app("Kubernetes", "web")}, ToPorts: tcpPorts("5432")},
+			{FromCIDR: []string{"192.0.2.0/24"}, ToPorts: tcpPorts("5432")},
+		},
+	})
+	createIn(t, r, testNamespace, "web", sdnv1alpha1.SecurityGroupSpec{
+		Attachments: []sdnv1alpha1.ApplicationReference{*app("Kubernetes", "web")},
+		Egress: []sdnv1alpha1.EgressRule{
+			{ToSG: []string{"db"}, ToPorts: tcpPorts("5432")},
+			{ToFQDNs: []sdnv1alpha1.FQDNSelector{{MatchPattern: "*.example.org"}}, ToPorts: []sdnv1alpha1.PortRule{{
+				Ports: []sdnv1alpha1.PortProtocol{{Port: "443", Protocol: "TCP"}},
+				Rules: &sdnv1alpha1.L7Rules{HTTP: []sdnv1alpha1.HTTPRule{{Method: "GET"}}},
+			}}},
+		},
+	})
+}
+
+func simulate(t *testing.T, r *REST, spec sdnv1alpha1.SecurityGroupSimulationSpec) (*sdnv1alpha1.SecurityGroupSimulation, error) {
+	t.Helper()
+	out, err := NewSimulationREST(r).Create(ctxNS(), &sdnv1alpha1.SecurityGroupSimulation{Spec: spec}, nil, &metav1.CreateOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return out.(*sdnv1alpha1.SecurityGroupSimulation), nil
+}
+
+func mustSimulate(t *testing.T, r *REST, spec sdnv1alpha1.SecurityGroupSimulationSpec) *sdnv1alpha1.SecurityGroupSimulationStatus {
+	t.Helper()
+	out, err := simulate(t, r, spec)
+	if err != nil {
+		t.Fatalf("simulate: %v", err)
+	}
+	return &out.Status
+}
+
+func rules(res *sdnv1alpha1.SimulationResult) []sdnv1alpha1.MatchedRule {
+	if res == nil {
+		return nil
+	}
+	return res.Rules
+}
+
+func TestSimulateAllowedAtBothEnds(t *testing.T) {
+	r := newSimulationREST(t)
+	dbAndWeb(t, r)
+
+	st := mustSimulate(t, r, sdnv1alpha1.SecurityGroupSimulationSpec{
+		Source:      sdnv1alpha1.SimulationEndpoint{Application: app("Kubernetes", "web")},
+		Destination: sdnv1alpha1.SimulationEndpoint{Application: app("Postgres", "db")},
+		Port:        5432,
+	})
+	if st.Verdict != sdnv1alpha1.SimulationAllowed {
+		t.Fatalf("verdict = %s (%s), want Allowed", st.Verdict, st.Reason)
+	}
+	wantEgress := []sdnv1alpha1.MatchedRule{{SecurityGroup: "web", Section: "egress", Rule: 0}}
+	wantIngress := []sdnv1alpha1.MatchedRule{{SecurityGroup: "db", Section: "ingress", Rule: 0}}
+	if got := rules(st.Egress); len(got) != 1 || got[0] != wantEgress[0] {
+		t.Errorf("egress rules = %+v, want %+v", got, wantEgress)
+	}
+	if got := rules(st.Ingress); len(got) != 1 || got[0] != wantIngress[0] {
+		t.Errorf("ingress rules = %+v, want %+v", got, wantIngress)
+	}
+	if st.Egress.SecurityGroups[0] != "web" || st.Ingress.SecurityGroups[0] != "db" {
+		t.Errorf("groups = %v / %v", st.Egress.SecurityGroups, st.Ingress.SecurityGroups)
+	}
+
+	// Another port matches no rule at either end: the baseline decides.
+	st = mustSimulate(t, r, sdnv1alpha1.SecurityGroupSimulationSpec{
+		Source:      sdnv1alpha1.SimulationEndpoint{Application: app("Kubernetes", "web")},
+		Destination: sdnv1alpha1.SimulationEndpoint{Application: app("Postgres", "db")},
+		Port:        22,
+	})
+	if st.Verdict != sdnv1alpha1.SimulationBaseline || st.Egress.Verdict != sdnv1alpha1.SimulationUnmatched || st.Ingress.Verdict != sdnv1alpha1.SimulationUnmatched {
+		t.Fatalf("port 22: %+v", st)
+	}
+}
+
+func TestSimulateDenyWins(t *testing.T) {
+	r := newSimulationREST(t)
+	dbAndWeb(t, r)
+	createIn(t, r, testNamespace, "lockdown", sdnv1alpha1.SecurityGroupSpec{
+		Attachments: []sdnv1alpha1.ApplicationReference{*app("Postgres", "db")},
+		IngressDeny: []sdnv1alpha1.IngressDenyRule{{FromSG: []string{"web"}}},
+	})
+
+	st := mustSimulate(t, r, sdnv1alpha1.SecurityGroupSimulationSpec{
+		Source:      sdnv1alpha1.SimulationEndpoint{Application: app("Kubernetes", "web")},
+		Destination: sdnv1alpha1.SimulationEndpoint{Application: app("Postgres", "db")},
+		Port:        5432,
+	})
+	if st.Verdict != sdnv1alpha1.SimulationDenied || st.Reason != "denied by lockdown ingressDeny[0]" {
+		t.Fatalf("verdict = %s (%s), want Denied by lockdown", st.Verdict, st.Reason)
+	}
+	// The allow rule still matches; deny rules are listed first.
+	got := rules(st.Ingress)
+	if len(got) != 2 || got[0].SecurityGroup != "lockdown" || got[1].SecurityGroup != "db" {
+		t.Fatalf("ingress rules = %+v", got)
+	}
+}
+
+func TestSimulateOutsideEndpoints(t *testing.T) {
+	r := newSimulationREST(t)
+	dbAndWeb(t, r)
+
+	// An address outside the cluster has no egress side.
+	st := mustSimulate(t, r, sdnv1alpha1.SecurityGroupSimulationSpec{
+		Source:      sdnv1alpha1.SimulationEndpoint{IP: "192.0.2.10"},
+		Destination: sdnv1alpha1.SimulationEndpoint{Application: app("Postgres", "db")},
+		Port:        5432,
+	})
+	if st.Verdict != sdnv1alpha1.SimulationAllowed || st.Egress != nil || rules(st.Ingress)[0].Rule != 1 {
+		t.Fatalf("CIDR source: %+v", st)
+	}
+
+	// An FQDN destination matches toFQDNs, and the rule narrows to L7.
+	st = mustSimulate(t, r, sdnv1alpha1.SecurityGroupSimulationSpec{
+		Source:      sdnv1alpha1.SimulationEndpoint{Application: app("Kubernetes", "web")},
+		Destination: sdnv1alpha1.SimulationEndpoint{FQDN: "API.example.org."},
+		Port:        443,
+	})
+	if st.Verdict != sdnv1alpha1.SimulationAllowed || st.Ingress != nil {
+		t.Fatalf("FQDN destination: %+v", st)
+	}
+	if got := rules(st.Egress); len(got) != 1 || got[0].Rule != 1 || !got[0].L7 {
+		t.Fatalf("FQDN destination rules = %+v, want egress[1] with L7", got)
+	}
+}
+
+func TestSimulatePodUsesItsLabels(t *testing.T) {
+	// A pod counts as a member only once the controller has labeled it.
+	member := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "db-0", Namespace: testNamespace, Labels: map[string]string{
+		membershipLabelKey("db"): "",
+	}}}
+	fresh := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "db-1", Namespace: testNamespace}}
+	r := newSimulationREST(t, member, fresh)
+	dbAndWeb(t, r)
+
+	spec := sdnv1alpha1.SecurityGroupSimulationSpec{
+		Source:      sdnv1alpha1.SimulationEndpoint{IP: "192.0.2.10"},
+		Destination: sdnv1alpha1.SimulationEndpoint{Pod: "db-0"},
+		Port:        5432,
+	}
+	if st := mustSimulate(t, r, spec); st.Verdict != sdnv1alpha1.SimulationAllowed {
+		t.Fatalf("member pod: %+v", st)
+	}
+	spec.Destination.Pod = testNamespace + "/db-1"
+	if st := mustSimulate(t, r, spec); st.Verdict != sdnv1alpha1.SimulationBaseline || len(st.Ingress.SecurityGroups) != 0 {
+		t.Fatalf("unlabeled pod: %+v", st)
+	}
+	spec.Destination.Pod = "gone"
+	if _, err := simulate(t, r, spec); !apierrors.IsNotFound(err) {
+		t.Fatalf("missing pod: got err %v, want NotFound", err)
+	}
+}
+
+func peeringConfigMap(from, to string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: to, Namespace: from, Labels: map[string]string{peeringLabelKey: peeringLabelValue}},
+		Data:       map[string]string{peerNamespaceKey: to},
+	}
+}
+
+func TestSimulateAcrossPeering(t *testing.T) {
+	peerPod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web-0", Namespace: peerNamespace}}
+	spec := sdnv1alpha1.SecurityGroupSimulationSpec{
+		Source:      sdnv1alpha1.SimulationEndpoint{Application: &sdnv1alpha1.ApplicationReference{Namespace: peerNamespace, Kind: "Kubernetes", Name: "web"}},
+		Destination: sdnv1alpha1.SimulationEndpoint{Application: app("Postgres", "db")},
+		Port:        5432,
+	}
+	setup := func(t *testing.T, objs ...client.Object) *REST {
+		r := newSimulationREST(t, append(objs, peerPod)...)
+		createIn(t, r, testNamespace, "db", sdnv1alpha1.SecurityGroupSpec{
+			Attachments: []sdnv1alpha1.ApplicationReference{*app("Postgres", "db")},
+			Ingress: []sdnv1alpha1.IngressRule{{
+				FromApp: []sdnv1alpha1.ApplicationReference{{Namespace: peerNamespace, Kind: "Kubernetes", Name: "web"}},
+				ToPorts: tcpPorts("5432"),
+			}},
+		})
+		createIn(t, r, peerNamespace, "web", sdnv1alpha1.SecurityGroupSpec{
+			Attachments: []sdnv1alpha1.ApplicationReference{*app("Kubernetes", "web")},
+			Egress: []sdnv1alpha1.EgressRule{{
+				ToApp:   []sdnv1alpha1.ApplicationReference{{Namespace: testNamespace, Kind: "Postgres", Name: "db"}},
+				ToPorts: tcpPorts("5432"),
+			}},
+		})
+		return r
+	}
+
+	// Only an offer: the peer's groups are not read and its pods carry no
+	// consent label, so the rule naming them selects nothing.
+	r := setup(t, peeringConfigMap(testNamespace, peerNamespace))
+	st := mustSimulate(t, r, spec)
+	if st.Verdict != sdnv1alpha1.SimulationBaseline || st.Egress != nil || st.Ingress.Verdict != sdnv1alpha1.SimulationUnmatched {
+		t.Fatalf("unpeered: %+v", st)
+	}
+	spec.Source = sdnv1alpha1.SimulationEndpoint{Pod: peerNamespace + "/web-0"}
+	if _, err := simulate(t, r, spec); !apierrors.IsForbidden(err) {
+		t.Fatalf("pod of an unpeered namespace: got err %v, want Forbidden", err)
+	}
+
+	// Established: both ends are evaluated and allow it.
+	spec.Source = sdnv1alpha1.SimulationEndpoint{Application: &sdnv1alpha1.ApplicationReference{Namespace: peerNamespace, Kind: "Kubernetes", Name: "web"}}
+	r = setup(t, peeringConfigMap(testNamespace, peerNamespace), peeringConfigMap(peerNamespace, testNamespace))
+	if st := mustSimulate(t, r, spec); st.Verdict != sdnv1alpha1.SimulationAllowed {
+		t.Fatalf("peered: %+v", st)
+	}
+}
+
+func TestSimulateValidation(t *testing.T) {
+	web := sdnv1alpha1.SimulationEndpoint{Application: app("Kubernetes", "web")}
+	cases := map[string]sdnv1alpha1.SecurityGroupSimulationSpec{
+		"no source":          {Destination: web, Port: 80},
+		"two fields":         {Source: sdnv1alpha1.SimulationEndpoint{Pod: "a", IP: "192.0.2.1"}, Destination: web, Port: 80},
+		"fqdn source":        {Source: sdnv1alpha1.SimulationEndpoint{FQDN: "example.org"}, Destination: web, Port: 80},
+		"both outside":       {Source: sdnv1alpha1.SimulationEndpoint{IP: "192.0.2.1"}, Destination: sdnv1alpha1.SimulationEndpoint{FQDN: "example.org"}, Port: 80},
+		"bad ip":             {Source: sdnv1alpha1.SimulationEndpoint{IP: "192.0.2.0/24"}, Destination: web, Port: 80},
+		"bad pod namespace":  {Source: sdnv1alpha1.SimulationEndpoint{Pod: "Tenant_B/web-0"}, Destination: web, Port: 80},
+		"no port":            {Source: web, Destination: web},
+		"port out of range":  {Source: web, Destination: web, Port: 70000},
+		"unknown protocol":   {Source: web, Destination: web, Port: 80, Protocol: "ICMP"},
+		"invalid app ref":    {Source: sdnv1alpha1.SimulationEndpoint{Application: app("", "web")}, Destination: web, Port: 80},
+		"reserved app name":  {Source: sdnv1alpha1.SimulationEndpoint{Application: app("Kubernetes", "world")}, Destination: web, Port: 80},
+		"bad fqdn":           {Source: web, Destination: sdnv1alpha1.SimulationEndpoint{FQDN: "not a name"}, Port: 80},
+		"empty destination":  {Source: web, Port: 80},
+		"ANY is not allowed": {Source: web, Destination: web, Port: 80, Protocol: "any"},
+	}
+	for name, spec := range cases {
+		t.Run(name, func(t *testing.T) {
+			if _, err := simulate(t, newSimulationREST(t), spec); !apierrors.IsInvalid(err) {
+				t.Fatalf("got err %v, want Invalid", err)
+			}
+		})
+	}
+}
+
+func TestExplain(t *testing.T) {
+	r := newSimulationREST(t)
+	dbAndWeb(t, r)
+
+	out, err := NewExplanationREST(r).Create(ctxNS(), &sdnv1alpha1.SecurityGroupExplanation{
+		Spec: sdnv1alpha1.SecurityGroupExplanationSpec{Application: *app("Kubernetes", "web")},
+	}, nil, &metav1.CreateOptions{})
+	if err != nil {
+		t.Fatalf("explain: %v", err)
+	}
+	st := out.(*sdnv1alpha1.SecurityGroupExplanation).Status
+	if len(st.SecurityGroups) != 1 || st.SecurityGroups[0].Name != "web" || len(st.SecurityGroups[0].Egress) != 2 {
+		t.Fatalf("securityGroups = %+v", st.SecurityGroups)
+	}
+	if got := st.SecurityGroups[0].Egress[0].ToSG; len(got) != 1 || got[0] != "db" {
+		t.Errorf("explained egress rule = %+v, want the tenant-facing toSG", st.SecurityGroups[0].Egress[0])
+	}
+	want := sdnv1alpha1.MatchedRule{SecurityGroup: "db", Section: "ingress", Rule: 0}
+	if len(st.ReferencedBy) != 1 || st.ReferencedBy[0] != want {
+		t.Errorf("referencedBy = %+v, want [%+v]", st.ReferencedBy, want)
+	}
+
+	_, err = NewExplanationREST(r).Create(ctxNS(), &sdnv1alpha1.SecurityGroupExplanation{
+		Spec: sdnv1alpha1.SecurityGroupExplanationSpec{Application: sdnv1alpha1.ApplicationReference{Namespace: peerNamespace, Kind: "Kubernetes", Name: "web"}},
+	}, nil, &metav1.CreateOptions{})
+	if !apierrors.IsInvalid(err) {
+		t.Fatalf("explaining another namespace's application: got err %v, want Invalid", err)
+	}
+}
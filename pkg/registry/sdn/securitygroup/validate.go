@@ -4,7 +4,11 @@
 package securitygroup
 
 import (
+	"fmt"
 	"net/netip"
+	"reflect"
+	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -13,12 +17,20 @@ import (
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
 	sdnv1alpha1 "github.com/cozystack/cozystack/pkg/apis/sdn/v1alpha1"
+	"github.com/cozystack/cozystack/pkg/sdnmatch"
 )
 
 // validProtocols is the set of L4 protocols a SecurityGroup port rule may name.
 // An empty protocol defaults to ANY and is allowed.
 var validProtocols = map[string]struct{}{"TCP": {}, "UDP": {}, "SCTP": {}, "ANY": {}}
 
+var (
+	// httpHeaderName matches an RFC 9110 field name.
+	httpHeaderName = regexp.MustCompile("^[!#$%&'*+.^_`|~0-9A-Za-z-]+$")
+	// kafkaTopic matches a Kafka topic name.
+	kafkaTopic = regexp.MustCompile(`^[a-zA-Z0-9._-]{1,255}$`)
+)
+
 // reservedEntities are Cilium's built-in entity names. They stay
 // platform-managed and are deliberately not tenant-expressible — a SecurityGroup
 // reaches external destinations through CIDR/FQDN, not entities — so the API
@@ -48,9 +60,15 @@ func validateSecurityGroup(sg *sdnv1alpha1.SecurityGroup) error {
 		errs = append(errs, field.Invalid(field.NewPath("metadata", "name"), sg.Name, msg))
 	}
 
+	// Attachments are always resolved in the SecurityGroup's own namespace: a
+	// group can only ever apply to its own tenant's pods.
 	att := spec.Child("attachments")
 	for i := range sg.Spec.Attachments {
-		errs = append(errs, validateAppRef(att.Index(i), &sg.Spec.Attachments[i])...)
+		ref := &sg.Spec.Attachments[i]
+		if ref.Namespace != "" && ref.Namespace != sg.Namespace {
+			errs = append(errs, field.Forbidden(att.Index(i).Child("namespace"), "an attachment must be in the SecurityGroup's namespace"))
+		}
+		errs = append(errs, validateAppRef(att.Index(i), ref)...)
 	}
 
 	for i := range sg.Spec.Ingress {
@@ -61,7 +79,7 @@ func validateSecurityGroup(sg *sdnv1alpha1.SecurityGroup) error {
 		}
 		errs = append(errs, validateSGNames(p.Child("fromSG"), in.FromSG)...)
 		errs = append(errs, validateCIDRs(p.Child("fromCIDR"), in.FromCIDR)...)
-		errs = append(errs, validatePortRules(p.Child("toPorts"), in.ToPorts)...)
+		errs = append(errs, validatePortRules(p.Child("toPorts"), in.ToPorts, false)...)
 	}
 	for i := range sg.Spec.Egress {
 		eg := &sg.Spec.Egress[i]
@@ -71,9 +89,40 @@ func validateSecurityGroup(sg *sdnv1alpha1.SecurityGroup) error {
 		}
 		errs = append(errs, validateSGNames(p.Child("toSG"), eg.ToSG)...)
 		errs = append(errs, validateCIDRs(p.Child("toCIDR"), eg.ToCIDR)...)
-		errs = append(errs, validatePortRules(p.Child("toPorts"), eg.ToPorts)...)
+		errs = append(errs, validatePortRules(p.Child("toPorts"), eg.ToPorts, true)...)
 		errs = append(errs, validateFQDNs(p.Child("toFQDNs"), eg.ToFQDNs)...)
 	}
+	for i := range sg.Spec.IngressDeny {
+		in := &sg.Spec.IngressDeny[i]
+		p := spec.Child("ingressDeny").Index(i)
+		if len(in.FromApp) == 0 && len(in.FromSG) == 0 && len(in.FromCIDR) == 0 {
+			errs = append(errs, field.Required(p, "a deny rule must name at least one of fromApp, fromSG or fromCIDR"))
+		}
+		for j := range in.FromApp {
+			errs = append(errs, validateAppRef(p.Child("fromApp").Index(j), &in.FromApp[j])...)
+		}
+		errs = append(errs, validateSGNames(p.Child("fromSG"), in.FromSG)...)
+		errs = append(errs, validateCIDRs(p.Child("fromCIDR"), in.FromCIDR)...)
+		errs = append(errs, validatePortDenyRules(p.Child("toPorts"), in.ToPorts)...)
+	}
+	for i := range sg.Spec.EgressDeny {
+		eg := &sg.Spec.EgressDeny[i]
+		p := spec.Child("egressDeny").Index(i)
+		if len(eg.ToApp) == 0 && len(eg.ToSG) == 0 && len(eg.ToCIDR) == 0 {
+			errs = append(errs, field.Required(p, "a deny rule must name at least one of toApp, toSG or toCIDR"))
+		}
+		for j := range eg.ToApp {
+			errs = append(errs, validateAppRef(p.Child("toApp").Index(j), &eg.ToApp[j])...)
+		}
+		errs = append(errs, validateSGNames(p.Child("toSG"), eg.ToSG)...)
+		errs = append(errs, validateCIDRs(p.Child("toCIDR"), eg.ToCIDR)...)
+		errs = append(errs, validatePortDenyRules(p.Child("toPorts"), eg.ToPorts)...)
+	}
+	// Overlaps are only checked on otherwise valid rules, so a malformed CIDR
+	// is reported once, as malformed.
+	if len(errs) == 0 {
+		errs = append(errs, validateShadowedRules(spec, &sg.Spec)...)
+	}
 
 	if len(errs) == 0 {
 		return nil
@@ -87,8 +136,9 @@ func validateSecurityGroup(sg *sdnv1alpha1.SecurityGroup) error {
 // valid label value. Attachments and fromApp/toApp peers project to lineage
 // matchLabels, so a value Kubernetes would reject as a label value (e.g. > 63
 // chars) must be caught here rather than producing an unenforceable policy.
-// Validating apiGroup too keeps the reverse projection lossless. The name must
-// also not collide with a reserved Cilium entity.
+// Validating apiGroup too keeps the reverse projection lossless. A peer
+// namespace must be a namespace name, and the name must not collide with a
+// reserved Cilium entity.
 func validateAppRef(path *field.Path, ref *sdnv1alpha1.ApplicationReference) field.ErrorList {
 	var errs field.ErrorList
 	if ref.Kind == "" {
@@ -97,6 +147,11 @@ func validateAppRef(path *field.Path, ref *sdnv1alpha1.ApplicationReference) fie
 	if ref.Name == "" {
 		errs = append(errs, field.Required(path.Child("name"), "must reference an application name"))
 	}
+	if ref.Namespace != "" {
+		for _, msg := range validation.IsDNS1123Label(ref.Namespace) {
+			errs = append(errs, field.Invalid(path.Child("namespace"), ref.Namespace, msg))
+		}
+	}
 	for _, f := range []struct {
 		name  string
 		value string
@@ -116,13 +171,21 @@ func validateAppRef(path *field.Path, ref *sdnv1alpha1.ApplicationReference) fie
 
 // validateSGNames rejects a fromSG/toSG peer whose name cannot project to a
 // valid membership-label key (MembershipLabelPrefix + name) and one that
-// collides with a reserved Cilium entity. References are resolved within the
-// SecurityGroup's own namespace (the backing CiliumNetworkPolicy is namespaced),
-// so cross-namespace reach is structurally impossible; a reference to a
-// non-existent group simply selects no pods.
+// collides with a reserved Cilium entity. A "<namespace>/<name>" reference
+// must name a valid namespace too. A bare name is resolved within the
+// SecurityGroup's own namespace, a qualified one only through an established
+// SecurityGroupPeering (the projected selector requires the peer label); a
+// reference to a non-existent group simply selects no pods.
 func validateSGNames(path *field.Path, names []string) field.ErrorList {
 	var errs field.ErrorList
-	for i, n := range names {
+	for i, ref := range names {
+		n := ref
+		if ns, name, qualified := strings.Cut(ref, "/"); qualified {
+			for _, msg := range validation.IsDNS1123Label(ns) {
+				errs = append(errs, field.Invalid(path.Index(i), ref, "namespace "+msg))
+			}
+			n = name
+		}
 		if n == "" {
 			errs = append(errs, field.Required(path.Index(i), "must name a SecurityGroup"))
 			continue
@@ -165,31 +228,255 @@ func validateFQDNs(path *field.Path, fqdns []sdnv1alpha1.FQDNSelector) field.Err
 	return errs
 }
 
-func validatePortRules(path *field.Path, rules []sdnv1alpha1.PortRule) field.ErrorList {
+// validatePortRules checks the port rules of an egress rule, or of an ingress
+// rule when egress is false.
+func validatePortRules(path *field.Path, rules []sdnv1alpha1.PortRule, egress bool) field.ErrorList {
 	var errs field.ErrorList
 	for i := range rules {
-		for j := range rules[i].Ports {
-			pp := rules[i].Ports[j]
-			pPath := path.Index(i).Child("ports").Index(j)
-
-			if pp.Port != "" {
-				if n, err := strconv.Atoi(pp.Port); err == nil {
-					if n < 1 || n > 65535 {
-						errs = append(errs, field.Invalid(pPath.Child("port"), pp.Port, "port number must be between 1 and 65535"))
-					}
-				} else {
-					for _, msg := range validation.IsValidPortName(pp.Port) {
-						errs = append(errs, field.Invalid(pPath.Child("port"), pp.Port, msg))
-					}
-				}
+		errs = append(errs, validatePorts(path.Index(i).Child("ports"), rules[i].Ports)...)
+		if rules[i].Rules != nil {
+			errs = append(errs, validateL7Rules(path.Index(i), &rules[i], egress)...)
+		}
+	}
+	return errs
+}
+
+// validateL7Rules checks the L7 rules of a port rule against what Cilium's
+// proxy accepts: exactly one rule type, DNS on egress only, on explicit ports
+// of a protocol the type runs over, with compilable expressions. Cilium
+// otherwise rejects the whole policy asynchronously, leaving the rule
+// unenforced.
+func validateL7Rules(path *field.Path, pr *sdnv1alpha1.PortRule, egress bool) field.ErrorList {
+	var errs field.ErrorList
+	rp := path.Child("rules")
+	l7 := pr.Rules
+
+	var kinds []string
+	if len(l7.HTTP) > 0 {
+		kinds = append(kinds, "http")
+	}
+	if len(l7.DNS) > 0 {
+		kinds = append(kinds, "dns")
+	}
+	if len(l7.Kafka) > 0 {
+		kinds = append(kinds, "kafka")
+	}
+	switch len(kinds) {
+	case 0:
+		return append(errs, field.Required(rp, "must set one of http, dns or kafka"))
+	case 1:
+	default:
+		return append(errs, field.Forbidden(rp, fmt.Sprintf("must set only one of http, dns or kafka, got %s", strings.Join(kinds, ", "))))
+	}
+	// Cilium's DNS proxy only sees the lookups of the selected pods.
+	if kinds[0] == "dns" && !egress {
+		return append(errs, field.Forbidden(rp.Child("dns"), "dns rules apply to egress only"))
+	}
+
+	// HTTP and Kafka run over TCP only; DNS over UDP and TCP.
+	protocols := map[string]bool{"TCP": true, "ANY": true, "": true}
+	if kinds[0] == "dns" {
+		protocols["UDP"] = true
+	}
+	if len(pr.Ports) == 0 {
+		errs = append(errs, field.Required(path.Child("ports"), "L7 rules require explicit ports"))
+	}
+	for j, pp := range pr.Ports {
+		if pp.Port == "" || pp.Port == "0" {
+			errs = append(errs, field.Required(path.Child("ports").Index(j).Child("port"), "L7 rules require an explicit port"))
+		}
+		if !protocols[strings.ToUpper(pp.Protocol)] {
+			errs = append(errs, field.Invalid(path.Child("ports").Index(j).Child("protocol"), pp.Protocol,
+				fmt.Sprintf("%s rules cannot apply to %s", kinds[0], strings.ToUpper(pp.Protocol))))
+		}
+	}
+
+	for i, h := range l7.HTTP {
+		hp := rp.Child("http").Index(i)
+		for _, f := range []struct {
+			name  string
+			value string
+		}{{"method", h.Method}, {"path", h.Path}, {"host", h.Host}} {
+			if _, err := regexp.Compile(f.value); err != nil {
+				errs = append(errs, field.Invalid(hp.Child(f.name), f.value, "must be a valid regular expression: "+err.Error()))
 			}
+		}
+		for j, header := range h.Headers {
+			name, _, _ := strings.Cut(header, ":")
+			if !httpHeaderName.MatchString(strings.TrimSpace(name)) {
+				errs = append(errs, field.Invalid(hp.Child("headers").Index(j), header, `must be "Name" or "Name: value" with a valid header name`))
+			}
+		}
+	}
+	errs = append(errs, validateFQDNs(rp.Child("dns"), l7.DNS)...)
+	for i, k := range l7.Kafka {
+		kp := rp.Child("kafka").Index(i)
+		if k.Role != "" && k.Role != sdnv1alpha1.KafkaRoleProduce && k.Role != sdnv1alpha1.KafkaRoleConsume {
+			errs = append(errs, field.NotSupported(kp.Child("role"), k.Role, []string{sdnv1alpha1.KafkaRoleProduce, sdnv1alpha1.KafkaRoleConsume}))
+		}
+		if k.Topic != "" && !kafkaTopic.MatchString(k.Topic) {
+			errs = append(errs, field.Invalid(kp.Child("topic"), k.Topic, "must be a valid Kafka topic name: up to 255 letters, digits, '.', '_' or '-'"))
+		}
+	}
+	return errs
+}
 
-			if pp.Protocol != "" {
-				if _, ok := validProtocols[strings.ToUpper(pp.Protocol)]; !ok {
-					errs = append(errs, field.NotSupported(pPath.Child("protocol"), pp.Protocol, []string{"TCP", "UDP", "SCTP", "ANY"}))
+func validatePortDenyRules(path *field.Path, rules []sdnv1alpha1.PortDenyRule) field.ErrorList {
+	var errs field.ErrorList
+	for i := range rules {
+		errs = append(errs, validatePorts(path.Index(i).Child("ports"), rules[i].Ports)...)
+	}
+	return errs
+}
+
+func validatePorts(path *field.Path, ports []sdnv1alpha1.PortProtocol) field.ErrorList {
+	var errs field.ErrorList
+	for j, pp := range ports {
+		pPath := path.Index(j)
+
+		if pp.Port != "" {
+			if n, err := strconv.Atoi(pp.Port); err == nil {
+				if n < 1 || n > 65535 {
+					errs = append(errs, field.Invalid(pPath.Child("port"), pp.Port, "port number must be between 1 and 65535"))
 				}
+			} else {
+				for _, msg := range validation.IsValidPortName(pp.Port) {
+					errs = append(errs, field.Invalid(pPath.Child("port"), pp.Port, msg))
+				}
+			}
+		}
+
+		if pp.Protocol != "" {
+			if _, ok := validProtocols[strings.ToUpper(pp.Protocol)]; !ok {
+				errs = append(errs, field.NotSupported(pPath.Child("protocol"), pp.Protocol, []string{"TCP", "UDP", "SCTP", "ANY"}))
+			}
+		}
+	}
+	return errs
+}
+
+// validateShadowedRules rejects an allow rule that a single deny rule of the
+// same SecurityGroup fully covers — every peer and every port — since deny
+// rules are evaluated first and the allow rule could then never match. That
+// is almost always a mistake, like denying 10.0.0.0/8 while allowing
+// 10.1.0.0/16 from it. Partial overlaps are the point of deny rules ("allow
+// the VPC except this app") and are accepted. An allow rule with no peers
+// (all sources or destinations) or with toFQDNs peers, which deny rules cannot
+// express, is never fully covered.
+func validateShadowedRules(spec *field.Path, s *sdnv1alpha1.SecurityGroupSpec) field.ErrorList {
+	var errs field.ErrorList
+	for i, in := range s.Ingress {
+		allow := peerSet{apps: in.FromApp, sgs: in.FromSG, cidrs: in.FromCIDR}
+		for j, deny := range s.IngressDeny {
+			if allow.coveredBy(peerSet{apps: deny.FromApp, sgs: deny.FromSG, cidrs: deny.FromCIDR}) &&
+				portsCovered(in.ToPorts, deny.ToPorts) {
+				errs = append(errs, field.Invalid(spec.Child("ingress").Index(i), "",
+					fmt.Sprintf("is fully covered by spec.ingressDeny[%d] and would never allow any traffic", j)))
+				break
+			}
+		}
+	}
+	for i, eg := range s.Egress {
+		if len(eg.ToFQDNs) > 0 {
+			continue
+		}
+		allow := peerSet{apps: eg.ToApp, sgs: eg.ToSG, cidrs: eg.ToCIDR}
+		for j, deny := range s.EgressDeny {
+			if allow.coveredBy(peerSet{apps: deny.ToApp, sgs: deny.ToSG, cidrs: deny.ToCIDR}) &&
+				portsCovered(eg.ToPorts, deny.ToPorts) {
+				errs = append(errs, field.Invalid(spec.Child("egress").Index(i), "",
+					fmt.Sprintf("is fully covered by spec.egressDeny[%d] and would never allow any traffic", j)))
+				break
 			}
 		}
 	}
 	return errs
 }
+
+// peerSet is the app, SecurityGroup and CIDR peers of one rule.
+type peerSet struct {
+	apps  []sdnv1alpha1.ApplicationReference
+	sgs   []string
+	cidrs []string
+}
+
+// coveredBy reports whether every peer of p is also a peer of deny: the same
+// application or SecurityGroup, or an address range within one of its CIDRs.
+// A peer-less set covers nothing and is covered by nothing.
+func (p peerSet) coveredBy(deny peerSet) bool {
+	if len(p.apps) == 0 && len(p.sgs) == 0 && len(p.cidrs) == 0 {
+		return false
+	}
+	for _, app := range p.apps {
+		found := false
+		for _, d := range deny.apps {
+			if reflect.DeepEqual(appLabels(app), appLabels(d)) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	for _, name := range p.sgs {
+		if !slices.Contains(deny.sgs, name) {
+			return false
+		}
+	}
+	for _, c := range p.cidrs {
+		inner, ok := sdnmatch.ParsePrefix(c)
+		if !ok {
+			return false
+		}
+		found := false
+		for _, d := range deny.cidrs {
+			if outer, ok := sdnmatch.ParsePrefix(d); ok && outer.Bits() <= inner.Bits() && outer.Contains(inner.Addr()) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// portsCovered reports whether the deny port rules cover every port the allow
+// port rules name. No port rules, or a rule with no ports, means all ports.
+func portsCovered(allow []sdnv1alpha1.PortRule, deny []sdnv1alpha1.PortDenyRule) bool {
+	var denied []sdnv1alpha1.PortProtocol
+	for _, r := range deny {
+		if len(r.Ports) == 0 {
+			return true
+		}
+		denied = append(denied, r.Ports...)
+	}
+	if len(deny) == 0 {
+		return true
+	}
+	if len(allow) == 0 {
+		return false
+	}
+	for _, r := range allow {
+		if len(r.Ports) == 0 {
+			return false
+		}
+		for _, a := range r.Ports {
+			if !slices.ContainsFunc(denied, func(d sdnv1alpha1.PortProtocol) bool { return portCovered(a, d) }) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// portCovered reports whether the deny port d covers the allow port a.
+func portCovered(a, d sdnv1alpha1.PortProtocol) bool {
+	if d.Port != "" && d.Port != "0" && d.Port != a.Port {
+		return false
+	}
+	dp, ap := strings.ToUpper(d.Protocol), strings.ToUpper(a.Protocol)
+	return dp == "" || dp == "ANY" || dp == ap
+}
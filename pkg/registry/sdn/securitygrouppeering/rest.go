@@ -0,0 +1,738 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2026 The Cozystack Authors.
+
+// SecurityGroupPeering registry – namespaced projection over ConfigMaps
+// labelled "sdn.cozystack.io/securitygrouppeering=true". The marker label and
+// the peer namespace data key are hidden from the SecurityGroupPeering view.
+
+package securitygrouppeering
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metainternal "k8s.io/apimachinery/pkg/apis/meta/internalversion"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/duration"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/apiserver/pkg/registry/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	sdnv1alpha1 "github.com/cozystack/cozystack/pkg/apis/sdn/v1alpha1"
+	"github.com/cozystack/cozystack/pkg/registry"
+	fieldfilter "github.com/cozystack/cozystack/pkg/registry/fields"
+	"github.com/cozystack/cozystack/pkg/registry/sorting"
+)
+
+// -----------------------------------------------------------------------------
+// Constants & helpers
+// -----------------------------------------------------------------------------
+
+const (
+	// peeringLabelKey marks the ConfigMaps owned by the SecurityGroupPeering
+	// API. Only marked ConfigMaps are visible through this storage. The
+	// securitygroup-controller mirrors it to find the peerings.
+	peeringLabelKey   = "sdn.cozystack.io/securitygrouppeering"
+	peeringLabelValue = "true"
+
+	// peerNamespaceKey is the ConfigMap data key holding spec.peerNamespace.
+	// The securitygroup-controller mirrors it.
+	peerNamespaceKey = "peerNamespace"
+
+	singularName    = sdnv1alpha1.SecurityGroupPeeringSingularName
+	kindPeering     = sdnv1alpha1.SecurityGroupPeeringKind
+	kindPeeringList = sdnv1alpha1.SecurityGroupPeeringListKind
+)
+
+// stripMarkerLabel returns a copy of m without the peering marker label.
+func stripMarkerLabel(m map[string]string) map[string]string {
+	if m == nil {
+		return nil
+	}
+	out := make(map[string]string, len(m))
+	for k, v := range m {
+		if k == peeringLabelKey {
+			continue
+		}
+		out[k] = v
+	}
+	return out
+}
+
+func isPeering(cm *corev1.ConfigMap) bool {
+	return cm.Labels != nil && cm.Labels[peeringLabelKey] == peeringLabelValue
+}
+
+// configMapToPeering projects a marked ConfigMap into a SecurityGroupPeering
+// with the given phase.
+func configMapToPeering(cm *corev1.ConfigMap, phase string) *sdnv1alpha1.SecurityGroupPeering {
+	return &sdnv1alpha1.SecurityGroupPeering{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: sdnv1alpha1.SchemeGroupVersion.String(),
+			Kind:       kindPeering,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              cm.Name,
+			Namespace:         cm.Namespace,
+			UID:               cm.UID,
+			ResourceVersion:   cm.ResourceVersion,
+			CreationTimestamp: cm.CreationTimestamp,
+			DeletionTimestamp: cm.DeletionTimestamp,
+			Labels:            stripMarkerLabel(cm.Labels),
+			Annotations:       cm.Annotations,
+			OwnerReferences:   cm.OwnerReferences,
+			Finalizers:        cm.Finalizers,
+		},
+		Spec:   sdnv1alpha1.SecurityGroupPeeringSpec{PeerNamespace: cm.Data[peerNamespaceKey]},
+		Status: sdnv1alpha1.SecurityGroupPeeringStatus{Phase: phase},
+	}
+}
+
+// peeringToConfigMap builds the backing ConfigMap of p. As for the
+// SecurityGroup projection, labels, annotations, ownerReferences and
+// finalizers follow the request, and the marker label is set last so a tenant
+// cannot drop it.
+func peeringToConfigMap(p *sdnv1alpha1.SecurityGroupPeering, cur *corev1.ConfigMap) *corev1.ConfigMap {
+	var out corev1.ConfigMap
+	if cur != nil {
+		out = *cur.DeepCopy()
+	}
+	out.TypeMeta = metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"}
+	out.Name, out.Namespace = p.Name, p.Namespace
+	out.ResourceVersion = p.ResourceVersion
+
+	out.Labels = make(map[string]string, len(p.Labels)+1)
+	for k, v := range p.Labels {
+		out.Labels[k] = v
+	}
+	out.Labels[peeringLabelKey] = peeringLabelValue
+
+	out.Annotations = nil
+	if len(p.Annotations) != 0 {
+		out.Annotations = make(map[string]string, len(p.Annotations))
+		for k, v := range p.Annotations {
+			out.Annotations[k] = v
+		}
+	}
+	out.OwnerReferences = p.DeepCopy().OwnerReferences
+	out.Finalizers = append([]string(nil), p.Finalizers...)
+
+	out.Data = map[string]string{peerNamespaceKey: p.Spec.PeerNamespace}
+	out.BinaryData = nil
+	return &out
+}
+
+// validatePeering rejects a peering that does not name another valid
+// namespace, and on update (cur set) one that changes it: a consent is to one
+// namespace, and re-pointing it would silently move what the other side's
+// rules reach.
+func validatePeering(p *sdnv1alpha1.SecurityGroupPeering, cur *corev1.ConfigMap) error {
+	var errs field.ErrorList
+	path := field.NewPath("spec", "peerNamespace")
+	switch peer := p.Spec.PeerNamespace; {
+	case peer == "":
+		errs = append(errs, field.Required(path, "must name the peer namespace"))
+	case peer == p.Namespace:
+		errs = append(errs, field.Invalid(path, peer, "must not be the SecurityGroupPeering's own namespace"))
+	default:
+		for _, msg := range validation.IsDNS1123Label(peer) {
+			errs = append(errs, field.Invalid(path, peer, msg))
+		}
+	}
+	if cur != nil && cur.Data[peerNamespaceKey] != p.Spec.PeerNamespace {
+		errs = append(errs, field.Invalid(path, p.Spec.PeerNamespace, "field is immutable"))
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(
+		sdnv1alpha1.SchemeGroupVersion.WithKind(kindPeering).GroupKind(),
+		p.Name, errs)
+}
+
+func nsFrom(ctx context.Context) (string, error) {
+	ns, ok := request.NamespaceFrom(ctx)
+	if !ok {
+		return "", apierrors.NewBadRequest("namespace required")
+	}
+	return ns, nil
+}
+
+// createOptionsFromUpdate carries the caller's write intent from an update
+// request into the create it triggers on the force-create path.
+func createOptionsFromUpdate(opts *metav1.UpdateOptions) *metav1.CreateOptions {
+	if opts == nil {
+		return &metav1.CreateOptions{}
+	}
+	return &metav1.CreateOptions{
+		DryRun:          opts.DryRun,
+		FieldManager:    opts.FieldManager,
+		FieldValidation: opts.FieldValidation,
+	}
+}
+
+// -----------------------------------------------------------------------------
+// REST storage
+// -----------------------------------------------------------------------------
+
+var (
+	_ rest.Creater              = &REST{}
+	_ rest.Getter               = &REST{}
+	_ rest.Lister               = &REST{}
+	_ rest.Updater              = &REST{}
+	_ rest.Patcher              = &REST{}
+	_ rest.GracefulDeleter      = &REST{}
+	_ rest.Watcher              = &REST{}
+	_ rest.TableConvertor       = &REST{}
+	_ rest.Scoper               = &REST{}
+	_ rest.SingularNameProvider = &REST{}
+)
+
+// REST is the storage backend translating SecurityGroupPeering to ConfigMap.
+// It reads through the direct client: peerings are few and cozystack-api does
+// not cache ConfigMaps, which would mean an informer over every ConfigMap of
+// the cluster.
+type REST struct {
+	c   client.WithWatch
+	gvr schema.GroupVersionResource
+}
+
+// NewREST returns a SecurityGroupPeering REST storage backed by the given
+// client.
+func NewREST(c client.WithWatch) *REST {
+	return &REST{
+		c: c,
+		gvr: schema.GroupVersionResource{
+			Group:    sdnv1alpha1.GroupName,
+			Version:  "v1alpha1",
+			Resource: sdnv1alpha1.SecurityGroupPeeringPluralName,
+		},
+	}
+}
+
+// NamespaceScoped reports that SecurityGroupPeering is a namespaced resource.
+func (*REST) NamespaceScoped() bool { return true }
+
+// New returns an empty SecurityGroupPeering.
+func (*REST) New() runtime.Object { return &sdnv1alpha1.SecurityGroupPeering{} }
+
+// NewList returns an empty SecurityGroupPeeringList.
+func (*REST) NewList() runtime.Object { return &sdnv1alpha1.SecurityGroupPeeringList{} }
+
+// Kind returns the resource kind.
+func (*REST) Kind() string { return kindPeering }
+
+// GroupVersionKind returns the GVK served by this storage.
+func (r *REST) GroupVersionKind(_ schema.GroupVersion) schema.GroupVersionKind {
+	return r.gvr.GroupVersion().WithKind(kindPeering)
+}
+
+// GetSingularName returns the singular resource name.
+func (*REST) GetSingularName() string { return singularName }
+
+// buildSelector merges the required marker label with any user-provided
+// requirements from opts.LabelSelector. Returns (selector, true) on success;
+// (nil, false) when the user selector matches nothing.
+func buildSelector(opts *metainternal.ListOptions) (labels.Selector, bool) {
+	ls := labels.NewSelector()
+	req, _ := labels.NewRequirement(peeringLabelKey, selection.Equals, []string{peeringLabelValue})
+	ls = ls.Add(*req)
+	if opts.LabelSelector != nil {
+		reqs, selectable := opts.LabelSelector.Requirements()
+		if !selectable {
+			return nil, false
+		}
+		if len(reqs) > 0 {
+			ls = ls.Add(reqs...)
+		}
+	}
+	return ls, true
+}
+
+// phase reports whether the peer namespace of cm has a live peering naming
+// cm's namespace. A terminating peering no longer consents.
+func (r *REST) phase(ctx context.Context, cm *corev1.ConfigMap) (string, error) {
+	peer := cm.Data[peerNamespaceKey]
+	if peer == "" {
+		return sdnv1alpha1.SecurityGroupPeeringOffered, nil
+	}
+	list := &corev1.ConfigMapList{}
+	if err := r.c.List(ctx, list, client.InNamespace(peer), client.MatchingLabels{peeringLabelKey: peeringLabelValue}); err != nil {
+		return "", err
+	}
+	for i := range list.Items {
+		if list.Items[i].Data[peerNamespaceKey] == cm.Namespace && list.Items[i].DeletionTimestamp.IsZero() {
+			return sdnv1alpha1.SecurityGroupPeeringEstablished, nil
+		}
+	}
+	return sdnv1alpha1.SecurityGroupPeeringOffered, nil
+}
+
+// toPeering projects cm with its current phase.
+func (r *REST) toPeering(ctx context.Context, cm *corev1.ConfigMap) (*sdnv1alpha1.SecurityGroupPeering, error) {
+	phase, err := r.phase(ctx, cm)
+	if err != nil {
+		return nil, err
+	}
+	return configMapToPeering(cm, phase), nil
+}
+
+// checkUnique rejects a second peering of the namespace naming the same peer:
+// deleting one of two would not withdraw the consent, which is what a tenant
+// deleting a peering means to do.
+func (r *REST) checkUnique(ctx context.Context, p *sdnv1alpha1.SecurityGroupPeering) error {
+	list := &corev1.ConfigMapList{}
+	if err := r.c.List(ctx, list, client.InNamespace(p.Namespace), client.MatchingLabels{peeringLabelKey: peeringLabelValue}); err != nil {
+		return err
+	}
+	for i := range list.Items {
+		if list.Items[i].Name != p.Name && list.Items[i].Data[peerNamespaceKey] == p.Spec.PeerNamespace {
+			return apierrors.NewInvalid(
+				sdnv1alpha1.SchemeGroupVersion.WithKind(kindPeering).GroupKind(),
+				p.Name, field.ErrorList{field.Duplicate(field.NewPath("spec", "peerNamespace"),
+					fmt.Sprintf("%s, already named by SecurityGroupPeering %s", p.Spec.PeerNamespace, list.Items[i].Name))})
+		}
+	}
+	return nil
+}
+
+// -----------------------------------------------------------------------------
+// CRUD
+// -----------------------------------------------------------------------------
+
+// Create translates a SecurityGroupPeering into a ConfigMap and creates it.
+func (r *REST) Create(
+	ctx context.Context,
+	obj runtime.Object,
+	createValidation rest.ValidateObjectFunc,
+	opts *metav1.CreateOptions,
+) (runtime.Object, error) {
+	in, ok := obj.(*sdnv1alpha1.SecurityGroupPeering)
+	if !ok {
+		return nil, fmt.Errorf("expected SecurityGroupPeering, got %T", obj)
+	}
+	ns, err := nsFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if in.Namespace != "" && in.Namespace != ns {
+		return nil, apierrors.NewBadRequest("metadata.namespace must match request namespace")
+	}
+	in = in.DeepCopy()
+	in.Namespace = ns
+
+	if err := validatePeering(in, nil); err != nil {
+		return nil, err
+	}
+	if err := r.checkUnique(ctx, in); err != nil {
+		return nil, err
+	}
+	if createValidation != nil {
+		if err := createValidation(ctx, in); err != nil {
+			return nil, err
+		}
+	}
+
+	cm := peeringToConfigMap(in, nil)
+	if err := r.c.Create(ctx, cm, &client.CreateOptions{Raw: opts}); err != nil {
+		return nil, err
+	}
+	return r.toPeering(ctx, cm)
+}
+
+// Get returns the SecurityGroupPeering with the given name.
+func (r *REST) Get(
+	ctx context.Context,
+	name string,
+	opts *metav1.GetOptions,
+) (runtime.Object, error) {
+	ns, err := nsFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+	cm := &corev1.ConfigMap{}
+	if err := r.c.Get(ctx, types.NamespacedName{Namespace: ns, Name: name}, cm, &client.GetOptions{Raw: opts}); err != nil {
+		return nil, err
+	}
+	if !isPeering(cm) {
+		return nil, apierrors.NewNotFound(r.gvr.GroupResource(), name)
+	}
+	return r.toPeering(ctx, cm)
+}
+
+// List returns the SecurityGroupPeerings in the request namespace, or in all
+// namespaces for a cluster-wide list.
+func (r *REST) List(ctx context.Context, opts *metainternal.ListOptions) (runtime.Object, error) {
+	ns := request.NamespaceValue(ctx)
+
+	out := &sdnv1alpha1.SecurityGroupPeeringList{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: sdnv1alpha1.SchemeGroupVersion.String(),
+			Kind:       kindPeeringList,
+		},
+	}
+	ls, selectable := buildSelector(opts)
+	if !selectable {
+		return out, nil
+	}
+	fieldFilter, err := fieldfilter.ParseFieldSelector(opts.FieldSelector)
+	if err != nil {
+		return nil, err
+	}
+	if fieldFilter.Namespace != "" && ns != "" && ns != fieldFilter.Namespace {
+		return out, nil
+	}
+
+	list := &corev1.ConfigMapList{}
+	if err := r.c.List(ctx, list, &client.ListOptions{Namespace: ns, LabelSelector: ls}); err != nil {
+		return nil, err
+	}
+	out.ResourceVersion = list.ResourceVersion
+	if out.ResourceVersion == "" {
+		out.ResourceVersion, _ = registry.MaxResourceVersion(list)
+	}
+	for i := range list.Items {
+		if !fieldFilter.MatchesName(list.Items[i].Name) || !fieldFilter.MatchesNamespace(list.Items[i].Namespace) {
+			continue
+		}
+		p, err := r.toPeering(ctx, &list.Items[i])
+		if err != nil {
+			return nil, err
+		}
+		out.Items = append(out.Items, *p)
+	}
+	sorting.ByNamespacedName[sdnv1alpha1.SecurityGroupPeering, *sdnv1alpha1.SecurityGroupPeering](out.Items)
+	return out, nil
+}
+
+// Update creates or updates the ConfigMap backing the SecurityGroupPeering.
+func (r *REST) Update(
+	ctx context.Context,
+	name string,
+	objInfo rest.UpdatedObjectInfo,
+	createValidation rest.ValidateObjectFunc,
+	updateValidation rest.ValidateObjectUpdateFunc,
+	forceCreate bool,
+	opts *metav1.UpdateOptions,
+) (runtime.Object, bool, error) {
+	ns, err := nsFrom(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+
+	var cur *corev1.ConfigMap
+	var oldObj runtime.Object
+	previous := &corev1.ConfigMap{}
+	if err := r.c.Get(ctx, types.NamespacedName{Namespace: ns, Name: name}, previous); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return nil, false, err
+		}
+	} else {
+		if !isPeering(previous) {
+			return nil, false, apierrors.NewNotFound(r.gvr.GroupResource(), name)
+		}
+		cur = previous
+		if oldObj, err = r.toPeering(ctx, cur); err != nil {
+			return nil, false, err
+		}
+	}
+
+	newObj, err := objInfo.UpdatedObject(ctx, oldObj)
+	if err != nil {
+		return nil, false, err
+	}
+	in, ok := newObj.(*sdnv1alpha1.SecurityGroupPeering)
+	if !ok {
+		return nil, false, fmt.Errorf("expected SecurityGroupPeering, got %T", newObj)
+	}
+	if in.Name != "" && in.Name != name {
+		return nil, false, apierrors.NewBadRequest("metadata.name must match request name")
+	}
+	in = in.DeepCopy()
+	in.Name = name
+	in.Namespace = ns
+
+	if err := validatePeering(in, cur); err != nil {
+		return nil, false, err
+	}
+
+	cm := peeringToConfigMap(in, cur)
+	if cur == nil {
+		if !forceCreate {
+			return nil, false, apierrors.NewNotFound(r.gvr.GroupResource(), name)
+		}
+		if err := r.checkUnique(ctx, in); err != nil {
+			return nil, false, err
+		}
+		if createValidation != nil {
+			if err := createValidation(ctx, in); err != nil {
+				return nil, false, err
+			}
+		}
+		if err := r.c.Create(ctx, cm, &client.CreateOptions{Raw: createOptionsFromUpdate(opts)}); err != nil {
+			return nil, false, err
+		}
+		p, err := r.toPeering(ctx, cm)
+		return p, true, err
+	}
+
+	if updateValidation != nil {
+		if err := updateValidation(ctx, in, oldObj); err != nil {
+			return nil, false, err
+		}
+	}
+	if cm.ResourceVersion == "" {
+		cm.ResourceVersion = cur.ResourceVersion
+	}
+	if err := r.c.Update(ctx, cm, &client.UpdateOptions{Raw: opts}); err != nil {
+		return nil, false, err
+	}
+	p, err := r.toPeering(ctx, cm)
+	return p, false, err
+}
+
+// Delete removes the ConfigMap backing the SecurityGroupPeering, withdrawing
+// the consent.
+func (r *REST) Delete(
+	ctx context.Context,
+	name string,
+	deleteValidation rest.ValidateObjectFunc,
+	opts *metav1.DeleteOptions,
+) (runtime.Object, bool, error) {
+	ns, err := nsFrom(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+	current := &corev1.ConfigMap{}
+	if err := r.c.Get(ctx, types.NamespacedName{Namespace: ns, Name: name}, current); err != nil {
+		return nil, false, err
+	}
+	if !isPeering(current) {
+		return nil, false, apierrors.NewNotFound(r.gvr.GroupResource(), name)
+	}
+	p, err := r.toPeering(ctx, current)
+	if err != nil {
+		return nil, false, err
+	}
+	if deleteValidation != nil {
+		if err := deleteValidation(ctx, p); err != nil {
+			return nil, false, err
+		}
+	}
+	if err := r.c.Delete(ctx, &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: ns, Name: name}}, &client.DeleteOptions{Raw: opts}); err != nil {
+		return nil, false, err
+	}
+	return p, len(current.Finalizers) == 0, nil
+}
+
+// -----------------------------------------------------------------------------
+// Watcher
+// -----------------------------------------------------------------------------
+
+// Watch streams SecurityGroupPeering events translated from ConfigMap events.
+// The phase is computed when an event is sent: a peering becoming established
+// because the peer namespace created its side produces no event here.
+func (r *REST) Watch(ctx context.Context, opts *metainternal.ListOptions) (watch.Interface, error) {
+	ns := request.NamespaceValue(ctx)
+
+	ls, selectable := buildSelector(opts)
+	fieldFilter, err := fieldfilter.ParseFieldSelector(opts.FieldSelector)
+	if err != nil {
+		return nil, err
+	}
+	if !selectable || (fieldFilter.Namespace != "" && ns != "" && ns != fieldFilter.Namespace) {
+		ch := make(chan watch.Event)
+		close(ch)
+		return watch.NewProxyWatcher(ch), nil
+	}
+
+	sendInitialEvents := opts.SendInitialEvents != nil && *opts.SendInitialEvents
+	base, err := r.c.Watch(ctx, &corev1.ConfigMapList{}, &client.ListOptions{
+		Namespace:     ns,
+		LabelSelector: ls,
+		Raw: &metav1.ListOptions{
+			Watch:                true,
+			ResourceVersion:      opts.ResourceVersion,
+			SendInitialEvents:    opts.SendInitialEvents,
+			ResourceVersionMatch: opts.ResourceVersionMatch,
+			AllowWatchBookmarks:  opts.AllowWatchBookmarks || sendInitialEvents,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var startingRV uint64
+	if opts.ResourceVersion != "" {
+		if rv, err := strconv.ParseUint(opts.ResourceVersion, 10, 64); err == nil {
+			startingRV = rv
+		}
+	}
+
+	bookmarker := registry.NewInitialEventsBookmarker(sendInitialEvents, opts.ResourceVersion, func() runtime.Object {
+		return &sdnv1alpha1.SecurityGroupPeering{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: sdnv1alpha1.SchemeGroupVersion.String(),
+				Kind:       kindPeering,
+			},
+		}
+	})
+
+	ch := make(chan watch.Event)
+	proxy := watch.NewProxyWatcher(ch)
+
+	go func() {
+		defer proxy.Stop()
+		defer base.Stop()
+
+		send := func(ev watch.Event) bool {
+			select {
+			case ch <- ev:
+				return true
+			case <-proxy.StopChan():
+				return false
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		for ev := range base.ResultChan() {
+			if ev.Type == watch.Bookmark {
+				if cm, ok := ev.Object.(*corev1.ConfigMap); ok {
+					bookmark, _ := bookmarker.OnBackingBookmark(cm.ResourceVersion)
+					if !send(bookmark) {
+						return
+					}
+				}
+				continue
+			}
+			if ev.Type == watch.Error {
+				if !send(ev) {
+					return
+				}
+				continue
+			}
+
+			cm, ok := ev.Object.(*corev1.ConfigMap)
+			if !ok || cm == nil {
+				continue
+			}
+			bookmarker.Observe(cm.ResourceVersion)
+
+			if ev.Type != watch.Deleted && !ls.Matches(labels.Set(cm.Labels)) {
+				continue
+			}
+			if !fieldFilter.MatchesName(cm.Name) || !fieldFilter.MatchesNamespace(cm.Namespace) {
+				continue
+			}
+			if !sendInitialEvents && ev.Type == watch.Added && startingRV > 0 {
+				objRV, parseErr := strconv.ParseUint(cm.ResourceVersion, 10, 64)
+				if parseErr == nil && objRV <= startingRV {
+					continue
+				}
+			}
+
+			// A deleted peering consents to nothing, whatever its peer says.
+			phase := sdnv1alpha1.SecurityGroupPeeringOffered
+			if ev.Type != watch.Deleted {
+				cur, err := r.phase(ctx, cm)
+				if err != nil {
+					if !send(watch.Event{Type: watch.Error, Object: &apierrors.NewInternalError(err).ErrStatus}) {
+						return
+					}
+					continue
+				}
+				phase = cur
+			}
+
+			if bookmark, ok := bookmarker.BeforeLiveEvent(ev.Type); ok {
+				if !send(bookmark) {
+					return
+				}
+			}
+			if !send(watch.Event{Type: ev.Type, Object: configMapToPeering(cm, phase)}) {
+				return
+			}
+		}
+
+		if bookmark, ok := bookmarker.OnClose(); ok {
+			send(bookmark)
+		}
+	}()
+
+	return proxy, nil
+}
+
+// -----------------------------------------------------------------------------
+// TableConvertor
+// -----------------------------------------------------------------------------
+
+// ConvertToTable renders SecurityGroupPeerings for kubectl's table output.
+func (r *REST) ConvertToTable(_ context.Context, obj runtime.Object, _ runtime.Object) (*metav1.Table, error) {
+	now := time.Now()
+	row := func(o *sdnv1alpha1.SecurityGroupPeering) metav1.TableRow {
+		return metav1.TableRow{
+			Cells:  []interface{}{o.Name, o.Spec.PeerNamespace, o.Status.Phase, duration.HumanDuration(now.Sub(o.CreationTimestamp.Time))},
+			Object: runtime.RawExtension{Object: o},
+		}
+	}
+
+	tbl := &metav1.Table{
+		TypeMeta: metav1.TypeMeta{APIVersion: "meta.k8s.io/v1", Kind: "Table"},
+		ColumnDefinitions: []metav1.TableColumnDefinition{
+			{Name: "NAME", Type: "string"},
+			{Name: "PEER", Type: "string"},
+			{Name: "PHASE", Type: "string"},
+			{Name: "AGE", Type: "string"},
+		},
+	}
+
+	switch v := obj.(type) {
+	case *sdnv1alpha1.SecurityGroupPeeringList:
+		for i := range v.Items {
+			tbl.Rows = append(tbl.Rows, row(&v.Items[i]))
+		}
+		tbl.ResourceVersion = v.ResourceVersion
+	case *sdnv1alpha1.SecurityGroupPeering:
+		tbl.Rows = append(tbl.Rows, row(v))
+		tbl.ResourceVersion = v.ResourceVersion
+	default:
+		return nil, notAcceptable{r.gvr.GroupResource(), fmt.Sprintf("unexpected %T", obj)}
+	}
+	return tbl, nil
+}
+
+// -----------------------------------------------------------------------------
+// Boiler-plate
+// -----------------------------------------------------------------------------
+
+// Destroy releases resources held by the storage. There are none.
+func (*REST) Destroy() {}
+
+type notAcceptable struct {
+	resource schema.GroupResource
+	message  string
+}
+
+func (e notAcceptable) Error() string { return e.message }
+func (e notAcceptable) Status() metav1.Status {
+	return metav1.Status{
+		Status:  metav1.StatusFailure,
+		Code:    http.StatusNotAcceptable,
+		Reason:  metav1.StatusReason("NotAcceptable"),
+		Message: e.message,
+	}
+}
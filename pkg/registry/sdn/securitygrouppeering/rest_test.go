@@ -0,0 +1,140 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2026 The Cozystack Authors.
+
+package securitygrouppeering
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/apiserver/pkg/registry/rest"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	sdnv1alpha1 "github.com/cozystack/cozystack/pkg/apis/sdn/v1alpha1"
+)
+
+const (
+	tenantA = "tenant-a"
+	tenantB = "tenant-b"
+)
+
+func newTestREST(t *testing.T) *REST {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatalf("add client-go scheme: %v", err)
+	}
+	return NewREST(fake.NewClientBuilder().WithScheme(scheme).Build())
+}
+
+func ctxIn(ns string) context.Context {
+	return request.WithNamespace(context.Background(), ns)
+}
+
+func peering(ns, name, peer string) *sdnv1alpha1.SecurityGroupPeering {
+	return &sdnv1alpha1.SecurityGroupPeering{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: ns},
+		Spec:       sdnv1alpha1.SecurityGroupPeeringSpec{PeerNamespace: peer},
+	}
+}
+
+func create(t *testing.T, r *REST, p *sdnv1alpha1.SecurityGroupPeering) *sdnv1alpha1.SecurityGroupPeering {
+	t.Helper()
+	out, err := r.Create(ctxIn(p.Namespace), p, nil, &metav1.CreateOptions{})
+	if err != nil {
+		t.Fatalf("Create returned error: %v", err)
+	}
+	return out.(*sdnv1alpha1.SecurityGroupPeering)
+}
+
+func phaseOf(t *testing.T, r *REST, ns, name string) string {
+	t.Helper()
+	out, err := r.Get(ctxIn(ns), name, &metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Get %s/%s: %v", ns, name, err)
+	}
+	return out.(*sdnv1alpha1.SecurityGroupPeering).Status.Phase
+}
+
+func TestPeeringEstablishedOnlyWhileBothSidesConsent(t *testing.T) {
+	r := newTestREST(t)
+
+	if got := create(t, r, peering(tenantA, "to-b", tenantB)); got.Status.Phase != sdnv1alpha1.SecurityGroupPeeringOffered {
+		t.Fatalf("phase of a lone offer = %q, want Offered", got.Status.Phase)
+	}
+
+	if got := create(t, r, peering(tenantB, "to-a", tenantA)); got.Status.Phase != sdnv1alpha1.SecurityGroupPeeringEstablished {
+		t.Fatalf("phase of the accepting side = %q, want Established", got.Status.Phase)
+	}
+	if got := phaseOf(t, r, tenantA, "to-b"); got != sdnv1alpha1.SecurityGroupPeeringEstablished {
+		t.Fatalf("phase of the offering side = %q, want Established", got)
+	}
+
+	if _, _, err := r.Delete(ctxIn(tenantB), "to-a", nil, &metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("Delete returned error: %v", err)
+	}
+	if got := phaseOf(t, r, tenantA, "to-b"); got != sdnv1alpha1.SecurityGroupPeeringOffered {
+		t.Fatalf("phase after the peer withdrew = %q, want Offered", got)
+	}
+}
+
+func TestPeeringBackingConfigMapIsMarkedAndHidden(t *testing.T) {
+	r := newTestREST(t)
+	p := peering(tenantA, "to-b", tenantB)
+	p.Labels = map[string]string{"team": "db"}
+	got := create(t, r, p)
+	if _, ok := got.Labels[peeringLabelKey]; ok {
+		t.Fatalf("marker label leaked into the view: %v", got.Labels)
+	}
+
+	cm := &corev1.ConfigMap{}
+	if err := r.c.Get(context.Background(), types.NamespacedName{Namespace: tenantA, Name: "to-b"}, cm); err != nil {
+		t.Fatalf("backing ConfigMap not found: %v", err)
+	}
+	if cm.Labels[peeringLabelKey] != peeringLabelValue || cm.Labels["team"] != "db" || cm.Data[peerNamespaceKey] != tenantB {
+		t.Fatalf("backing ConfigMap = %+v / %v", cm.Labels, cm.Data)
+	}
+
+	// An unmarked ConfigMap of the same namespace is invisible.
+	plain := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "plain", Namespace: tenantA}}
+	if err := r.c.Create(context.Background(), plain); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.Get(ctxIn(tenantA), "plain", &metav1.GetOptions{}); !apierrors.IsNotFound(err) {
+		t.Fatalf("Get of an unmarked ConfigMap: got err %v, want NotFound", err)
+	}
+}
+
+func TestPeeringValidation(t *testing.T) {
+	cases := map[string]*sdnv1alpha1.SecurityGroupPeering{
+		"empty peer":   peering(tenantA, "p", ""),
+		"own":          peering(tenantA, "p", tenantA),
+		"invalid peer": peering(tenantA, "p", "Tenant_B"),
+	}
+	for name, p := range cases {
+		t.Run(name, func(t *testing.T) {
+			r := newTestREST(t)
+			if _, err := r.Create(ctxIn(tenantA), p, nil, &metav1.CreateOptions{}); !apierrors.IsInvalid(err) {
+				t.Fatalf("Create with %s: got err %v, want Invalid", name, err)
+			}
+		})
+	}
+
+	r := newTestREST(t)
+	create(t, r, peering(tenantA, "to-b", tenantB))
+	if _, err := r.Create(ctxIn(tenantA), peering(tenantA, "again", tenantB), nil, &metav1.CreateOptions{}); !apierrors.IsInvalid(err) {
+		t.Fatalf("second peering with the same namespace: got err %v, want Invalid", err)
+	}
+
+	moved := peering(tenantA, "to-b", "tenant-c")
+	if _, _, err := r.Update(ctxIn(tenantA), "to-b", rest.DefaultUpdatedObjectInfo(moved), nil, nil, false, &metav1.UpdateOptions{}); !apierrors.IsInvalid(err) {
+		t.Fatalf("changing peerNamespace: got err %v, want Invalid", err)
+	}
+}
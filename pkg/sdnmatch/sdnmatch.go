@@ -0,0 +1,180 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2026 The Cozystack Authors.
+
+// Package sdnmatch matches endpoints, addresses, domain names and ports
+// against the peers and ports of the rules of a SecurityGroup-backing
+// CiliumNetworkPolicy, the way Cilium does. The SecurityGroup simulation in
+// cozystack-api and the flow attribution of the securitygroup-controller both
+// use it, so the two cannot disagree on what a rule selects.
+package sdnmatch
+
+import (
+	"net/netip"
+	"strconv"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	sdnv1alpha1 "github.com/cozystack/cozystack/pkg/apis/sdn/v1alpha1"
+)
+
+// PodNamespaceLabel is the label Cilium gives every endpoint with its pod's
+// namespace; a selector naming it selects pods of that namespace.
+const PodNamespaceLabel = "k8s:io.kubernetes.pod.namespace"
+
+// Selectors reports whether an endpoint in namespace ns with the given labels
+// matches one of the endpoint selectors of a policy in namespace policyNS.
+// Like Cilium, a selector is scoped to the policy's namespace unless it names
+// one with PodNamespaceLabel, as a peer in a peered namespace does. Empty
+// selectors and selectors with match expressions, which the SecurityGroup
+// projection never writes, match nothing.
+func Selectors(selectors []metav1.LabelSelector, policyNS, ns string, labels map[string]string) bool {
+	for _, sel := range selectors {
+		if len(sel.MatchLabels) == 0 || len(sel.MatchExpressions) != 0 {
+			continue
+		}
+		want := policyNS
+		if v, scoped := sel.MatchLabels[PodNamespaceLabel]; scoped {
+			want = v
+		}
+		if ns != want {
+			continue
+		}
+		ok := true
+		for k, v := range sel.MatchLabels {
+			if k == PodNamespaceLabel {
+				continue
+			}
+			if got, found := labels[k]; !found || got != v {
+				ok = false
+				break
+			}
+		}
+		if ok {
+			return true
+		}
+	}
+	return false
+}
+
+// CIDRs reports whether ip lies in one of cidrs. Like fromCIDR and toCIDR
+// themselves, a CIDR may be a bare address, matching that address only.
+func CIDRs(ip string, cidrs []string) bool {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return false
+	}
+	addr = addr.Unmap()
+	for _, c := range cidrs {
+		if p, ok := ParsePrefix(c); ok && p.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// ParsePrefix parses a CIDR or, like Cilium, a bare IP as a single-host
+// prefix. The prefix is returned masked.
+func ParsePrefix(s string) (netip.Prefix, bool) {
+	if p, err := netip.ParsePrefix(s); err == nil {
+		return p.Masked(), true
+	}
+	if a, err := netip.ParseAddr(s); err == nil {
+		return netip.PrefixFrom(a, a.BitLen()), true
+	}
+	return netip.Prefix{}, false
+}
+
+// FQDNs reports whether name matches one of the FQDN selectors. Names and
+// selectors compare case-insensitively and without a trailing dot.
+func FQDNs(name string, fqdns []sdnv1alpha1.FQDNSelector) bool {
+	name = normalizeName(name)
+	for _, f := range fqdns {
+		if f.MatchName != "" && normalizeName(f.MatchName) == name {
+			return true
+		}
+		if f.MatchPattern != "" && Pattern(normalizeName(f.MatchPattern), name) {
+			return true
+		}
+	}
+	return false
+}
+
+func normalizeName(s string) string {
+	return strings.TrimSuffix(strings.ToLower(s), ".")
+}
+
+// Pattern matches a name against a Cilium matchPattern, where "*" stands for
+// any run of characters valid in a DNS label.
+func Pattern(pattern, name string) bool {
+	if pattern == "*" {
+		return true
+	}
+	p, n := strings.Split(pattern, "."), strings.Split(name, ".")
+	if len(p) != len(n) {
+		return false
+	}
+	for i := range p {
+		prefix, suffix, wild := strings.Cut(p[i], "*")
+		if !wild {
+			if p[i] != n[i] {
+				return false
+			}
+			continue
+		}
+		if len(n[i]) < len(prefix)+len(suffix) || !strings.HasPrefix(n[i], prefix) || !strings.HasSuffix(n[i], suffix) {
+			return false
+		}
+	}
+	return true
+}
+
+// Ports reports whether a connection on protocol/port falls under the port
+// rules of an allow rule, and whether the port rule it falls under narrows it
+// to L7 requests. No port rules match every connection; named ports never
+// match.
+func Ports(rules []sdnv1alpha1.PortRule, protocol string, port int32) (bool, bool) {
+	if len(rules) == 0 {
+		return true, false
+	}
+	for _, r := range rules {
+		if PortList(r.Ports, protocol, port) {
+			return true, r.Rules != nil
+		}
+	}
+	return false, false
+}
+
+// DenyPorts is Ports for the port rules of a deny rule.
+func DenyPorts(rules []sdnv1alpha1.PortDenyRule, protocol string, port int32) bool {
+	if len(rules) == 0 {
+		return true
+	}
+	for _, r := range rules {
+		if PortList(r.Ports, protocol, port) {
+			return true
+		}
+	}
+	return false
+}
+
+// PortList reports whether protocol/port is one of ports; no ports means all
+// of them.
+func PortList(ports []sdnv1alpha1.PortProtocol, protocol string, port int32) bool {
+	if len(ports) == 0 {
+		return true
+	}
+	for _, p := range ports {
+		proto := strings.ToUpper(p.Protocol)
+		if proto != "" && proto != "ANY" && proto != protocol {
+			continue
+		}
+		if p.Port == "" || p.Port == "0" {
+			return true
+		}
+		if n, err := strconv.ParseInt(p.Port, 10, 32); err == nil && int32(n) == port {
+			return true
+		}
+	}
+	return false
+}
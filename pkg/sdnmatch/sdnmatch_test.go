@@ -0,0 +1,126 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2026 The Cozystack Authors.
+
+package sdnmatch
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	sdnv1alpha1 "github.com/cozystack/cozystack/pkg/apis/sdn/v1alpha1"
+)
+
+func TestSelectors(t *testing.T) {
+	web := metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}}
+	peer := metav1.LabelSelector{MatchLabels: map[string]string{"app": "web", PodNamespaceLabel: "tenant-b"}}
+	expr := metav1.LabelSelector{
+		MatchLabels:      map[string]string{"app": "web"},
+		MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "tier", Operator: metav1.LabelSelectorOpExists}},
+	}
+	cases := []struct {
+		name      string
+		selectors []metav1.LabelSelector
+		ns        string
+		labels    map[string]string
+		want      bool
+	}{
+		{name: "same namespace", selectors: []metav1.LabelSelector{web}, ns: "tenant-a", labels: map[string]string{"app": "web", "x": "y"}, want: true},
+		{name: "other label value", selectors: []metav1.LabelSelector{web}, ns: "tenant-a", labels: map[string]string{"app": "db"}},
+		{name: "scoped to the policy namespace", selectors: []metav1.LabelSelector{web}, ns: "tenant-b", labels: map[string]string{"app": "web"}},
+		{name: "peer namespace", selectors: []metav1.LabelSelector{peer}, ns: "tenant-b", labels: map[string]string{"app": "web"}, want: true},
+		{name: "peer selector in the policy namespace", selectors: []metav1.LabelSelector{peer}, ns: "tenant-a", labels: map[string]string{"app": "web"}},
+		{name: "empty selector", selectors: []metav1.LabelSelector{{}}, ns: "tenant-a", labels: map[string]string{"app": "web"}},
+		{name: "match expressions", selectors: []metav1.LabelSelector{expr}, ns: "tenant-a", labels: map[string]string{"app": "web", "tier": "x"}},
+		{name: "second selector", selectors: []metav1.LabelSelector{peer, web}, ns: "tenant-a", labels: map[string]string{"app": "web"}, want: true},
+	}
+	for _, tc := range cases {
+		if got := Selectors(tc.selectors, "tenant-a", tc.ns, tc.labels); got != tc.want {
+			t.Errorf("%s: Selectors = %v, want %v", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestCIDRs(t *testing.T) {
+	cases := []struct {
+		ip    string
+		cidrs []string
+		want  bool
+	}{
+		{ip: "10.1.2.3", cidrs: []string{"10.0.0.0/8"}, want: true},
+		{ip: "10.1.2.3", cidrs: []string{"192.168.0.0/16"}},
+		{ip: "10.1.2.3", cidrs: []string{"10.1.2.3"}, want: true},
+		{ip: "10.1.2.4", cidrs: []string{"10.1.2.3"}},
+		{ip: "2001:db8::1", cidrs: []string{"2001:db8::1"}, want: true},
+		{ip: "2001:db8::2", cidrs: []string{"2001:db8::/32"}, want: true},
+		{ip: "::ffff:10.1.2.3", cidrs: []string{"10.1.2.3"}, want: true},
+		{ip: "10.1.2.3", cidrs: []string{"not-a-cidr"}},
+		{ip: "", cidrs: []string{"10.0.0.0/8"}},
+	}
+	for _, tc := range cases {
+		if got := CIDRs(tc.ip, tc.cidrs); got != tc.want {
+			t.Errorf("CIDRs(%q, %v) = %v, want %v", tc.ip, tc.cidrs, got, tc.want)
+		}
+	}
+}
+
+func TestFQDNs(t *testing.T) {
+	cases := []struct {
+		name     string
+		selector sdnv1alpha1.FQDNSelector
+		want     bool
+	}{
+		{name: "api.example.org", selector: sdnv1alpha1.FQDNSelector{MatchName: "api.example.org"}, want: true},
+		{name: "API.Example.org.", selector: sdnv1alpha1.FQDNSelector{MatchName: "api.example.org."}, want: true},
+		{name: "www.example.org", selector: sdnv1alpha1.FQDNSelector{MatchName: "api.example.org"}},
+		{name: "api.example.org", selector: sdnv1alpha1.FQDNSelector{MatchPattern: "*.example.org"}, want: true},
+		{name: "a.b.example.org", selector: sdnv1alpha1.FQDNSelector{MatchPattern: "*.example.org"}},
+		{name: "example.org", selector: sdnv1alpha1.FQDNSelector{MatchPattern: "*.example.org"}},
+		{name: "api-eu.example.org", selector: sdnv1alpha1.FQDNSelector{MatchPattern: "api-*.example.org"}, want: true},
+		{name: "web-eu.example.org", selector: sdnv1alpha1.FQDNSelector{MatchPattern: "api-*.example.org"}},
+		{name: "anything.at.all", selector: sdnv1alpha1.FQDNSelector{MatchPattern: "*"}, want: true},
+	}
+	for _, tc := range cases {
+		if got := FQDNs(tc.name, []sdnv1alpha1.FQDNSelector{tc.selector}); got != tc.want {
+			t.Errorf("FQDNs(%q, %+v) = %v, want %v", tc.name, tc.selector, got, tc.want)
+		}
+	}
+}
+
+func TestPorts(t *testing.T) {
+	tcp := func(port string) sdnv1alpha1.PortProtocol {
+		return sdnv1alpha1.PortProtocol{Port: port, Protocol: "TCP"}
+	}
+	l7 := &sdnv1alpha1.L7Rules{HTTP: []sdnv1alpha1.HTTPRule{{Method: "GET"}}}
+	cases := []struct {
+		name     string
+		rules    []sdnv1alpha1.PortRule
+		protocol string
+		port     int32
+		want     bool
+		wantL7   bool
+	}{
+		{name: "no port rules", protocol: "UDP", port: 53, want: true},
+		{name: "no ports", rules: []sdnv1alpha1.PortRule{{}}, protocol: "TCP", port: 22, want: true},
+		{name: "listed port", rules: []sdnv1alpha1.PortRule{{Ports: []sdnv1alpha1.PortProtocol{tcp("5432")}}}, protocol: "TCP", port: 5432, want: true},
+		{name: "other port", rules: []sdnv1alpha1.PortRule{{Ports: []sdnv1alpha1.PortProtocol{tcp("5432")}}}, protocol: "TCP", port: 22},
+		{name: "other protocol", rules: []sdnv1alpha1.PortRule{{Ports: []sdnv1alpha1.PortProtocol{tcp("53")}}}, protocol: "UDP", port: 53},
+		{name: "any protocol", rules: []sdnv1alpha1.PortRule{{Ports: []sdnv1alpha1.PortProtocol{{Port: "53", Protocol: "any"}}}}, protocol: "UDP", port: 53, want: true},
+		{name: "all ports of a protocol", rules: []sdnv1alpha1.PortRule{{Ports: []sdnv1alpha1.PortProtocol{tcp("0")}}}, protocol: "TCP", port: 22, want: true},
+		{name: "named port", rules: []sdnv1alpha1.PortRule{{Ports: []sdnv1alpha1.PortProtocol{tcp("http")}}}, protocol: "TCP", port: 80},
+		{name: "L7 rule", rules: []sdnv1alpha1.PortRule{{Ports: []sdnv1alpha1.PortProtocol{tcp("80")}, Rules: l7}}, protocol: "TCP", port: 80, want: true, wantL7: true},
+	}
+	for _, tc := range cases {
+		got, gotL7 := Ports(tc.rules, tc.protocol, tc.port)
+		if got != tc.want || gotL7 != tc.wantL7 {
+			t.Errorf("%s: Ports = %v, %v, want %v, %v", tc.name, got, gotL7, tc.want, tc.wantL7)
+		}
+		deny := make([]sdnv1alpha1.PortDenyRule, len(tc.rules))
+		for i := range tc.rules {
+			deny[i] = sdnv1alpha1.PortDenyRule{Ports: tc.rules[i].Ports}
+		}
+		if got := DenyPorts(deny, tc.protocol, tc.port); got != tc.want {
+			t.Errorf("%s: DenyPorts = %v, want %v", tc.name, got, tc.want)
+		}
+	}
+}
@@ -31,6 +31,7 @@ import (
 	"github.com/cozystack/cozystack/pkg/apiserver"
 	cozyserver "github.com/cozystack/cozystack/pkg/cmd/server"
 	"github.com/cozystack/cozystack/pkg/config"
+	applicationstorage "github.com/cozystack/cozystack/pkg/registry/apps/application"
 	sampleopenapi "github.com/cozystack/cozystack/pkg/generated/openapi"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	metainternalversion "k8s.io/apimachinery/pkg/apis/meta/internalversion"
@@ -144,7 +145,18 @@ func run() error {
 			singularName: res.Application.Singular,
 		}
 	}
-	if err := apiserver.InstallAppsAPIGroup(server, appsStorage); err != nil {
+	// The events, logs, render and move subresources are served by the real storage types;
+	// constructing them touches no client, and only their shape matters here.
+	for _, res := range resourceConfig.Resources {
+		app := applicationstorage.NewREST(nil, nil, &res)
+		appsStorage[res.Application.Plural+"/events"] = applicationstorage.NewEventsREST(app)
+		appsStorage[res.Application.Plural+"/logs"] = applicationstorage.NewLogsREST(app, nil)
+		appsStorage[res.Application.Plural+"/render"] = applicationstorage.NewRenderREST(app, nil, config.Pricing{}, nil, nil, nil)
+		if res.Application.Move != nil {
+			appsStorage[res.Application.Plural+"/move"] = applicationstorage.NewMoveREST(app, res.Application.Move, nil)
+		}
+	}
+	if err := apiserver.InstallAppsAPIGroup(server, map[string]map[string]rest.Storage{"v1alpha1": appsStorage}); err != nil {
 		return fmt.Errorf("install apps API group: %w", err)
 	}
 